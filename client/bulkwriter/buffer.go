@@ -0,0 +1,420 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+// buffer holds the validated rows column by column until they are persisted to a file.
+type buffer struct {
+	fields       []*entity.Field // the fields need to be provided in import files
+	dynamicField string          // name of the dynamic field, empty if dynamic field is disabled
+
+	columns  [][]any
+	dynamics []map[string]any
+	rowCount int
+	size     int64
+}
+
+func newBuffer(fields []*entity.Field, dynamicField string) *buffer {
+	return &buffer{
+		fields:       fields,
+		dynamicField: dynamicField,
+		columns:      make([][]any, len(fields)),
+	}
+}
+
+// appendRow validates the row and appends it to the buffer,
+// the keys not in schema are stored in the dynamic field if it is enabled.
+func (b *buffer) appendRow(row map[string]any) error {
+	values := make([]any, len(b.fields))
+	var size int64
+	for i, field := range b.fields {
+		value, err := convertValue(field, row[field.Name])
+		if err != nil {
+			return err
+		}
+		values[i] = value
+		size += estimateSize(value)
+	}
+
+	var dynamic map[string]any
+	if b.dynamicField != "" {
+		dynamic = make(map[string]any)
+		if meta, ok := row[b.dynamicField]; ok && meta != nil {
+			if err := mergeDynamicValues(dynamic, meta); err != nil {
+				return err
+			}
+		}
+		for key, value := range row {
+			if key == b.dynamicField || b.isSchemaField(key) {
+				continue
+			}
+			dynamic[key] = value
+		}
+		bs, err := json.Marshal(dynamic)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal dynamic field")
+		}
+		size += int64(len(bs))
+	} else {
+		for key := range row {
+			if !b.isSchemaField(key) {
+				return errors.Newf("field %s is not in schema and dynamic field is disabled", key)
+			}
+		}
+	}
+
+	for i, value := range values {
+		b.columns[i] = append(b.columns[i], value)
+	}
+	if b.dynamicField != "" {
+		b.dynamics = append(b.dynamics, dynamic)
+	}
+	b.rowCount++
+	b.size += size
+	return nil
+}
+
+func (b *buffer) isSchemaField(name string) bool {
+	for _, field := range b.fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeDynamicValues merges the JSON object provided for the dynamic field into the dynamic values.
+func mergeDynamicValues(dynamic map[string]any, meta any) error {
+	switch v := meta.(type) {
+	case map[string]any:
+		for key, value := range v {
+			dynamic[key] = value
+		}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, &dynamic)
+	case json.RawMessage:
+		return json.Unmarshal(v, &dynamic)
+	case string:
+		return json.Unmarshal([]byte(v), &dynamic)
+	default:
+		return errors.Newf("dynamic field expects a JSON object, got %T", meta)
+	}
+}
+
+// persist writes the buffered rows to the file in the file type.
+func (b *buffer) persist(path string, fileType BulkFileType) error {
+	switch fileType {
+	case BulkFileTypeParquet:
+		return b.persistParquet(path)
+	case BulkFileTypeJSON:
+		return b.persistJSON(path)
+	default:
+		return errors.Newf("unsupported file type %d", fileType)
+	}
+}
+
+// arrowDataType returns the arrow data type of the field accepted by milvus parquet import.
+func arrowDataType(field *entity.Field) (arrow.DataType, error) {
+	switch field.DataType {
+	case entity.FieldTypeBool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case entity.FieldTypeInt8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case entity.FieldTypeInt16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case entity.FieldTypeInt32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case entity.FieldTypeInt64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case entity.FieldTypeFloat:
+		return arrow.PrimitiveTypes.Float32, nil
+	case entity.FieldTypeDouble:
+		return arrow.PrimitiveTypes.Float64, nil
+	case entity.FieldTypeString, entity.FieldTypeVarChar, entity.FieldTypeJSON, entity.FieldTypeGeometry,
+		entity.FieldTypeTimestamptz, entity.FieldTypeSparseVector:
+		return arrow.BinaryTypes.String, nil
+	case entity.FieldTypeArray:
+		if isStructArrayField(field) {
+			if field.StructSchema == nil {
+				return nil, errors.Newf("struct array field %s has no struct schema", field.Name)
+			}
+			subFields := make([]arrow.Field, 0, len(field.StructSchema.Fields))
+			for _, sub := range field.StructSchema.Fields {
+				subType, err := arrowDataType(sub)
+				if err != nil {
+					return nil, err
+				}
+				subFields = append(subFields, arrow.Field{Name: sub.Name, Type: subType})
+			}
+			return arrow.ListOf(arrow.StructOf(subFields...)), nil
+		}
+		elemType, err := arrowDataType(elementField(field))
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elemType), nil
+	case entity.FieldTypeFloatVector:
+		return arrow.ListOf(arrow.PrimitiveTypes.Float32), nil
+	case entity.FieldTypeBinaryVector, entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		return arrow.ListOf(arrow.PrimitiveTypes.Uint8), nil
+	case entity.FieldTypeInt8Vector:
+		return arrow.ListOf(arrow.PrimitiveTypes.Int8), nil
+	default:
+		return nil, errors.Newf("unsupported data type %s of field %s", field.DataType.String(), field.Name)
+	}
+}
+
+func (b *buffer) arrowSchema() (*arrow.Schema, error) {
+	arrFields := make([]arrow.Field, 0, len(b.fields)+1)
+	for _, field := range b.fields {
+		dataType, err := arrowDataType(field)
+		if err != nil {
+			return nil, err
+		}
+		arrFields = append(arrFields, arrow.Field{
+			Name:     field.Name,
+			Type:     dataType,
+			Nullable: field.Nullable || field.DefaultValue != nil,
+		})
+	}
+	if b.dynamicField != "" {
+		arrFields = append(arrFields, arrow.Field{
+			Name: b.dynamicField,
+			Type: arrow.BinaryTypes.String,
+		})
+	}
+	return arrow.NewSchema(arrFields, nil), nil
+}
+
+func (b *buffer) persistParquet(path string) error {
+	schema, err := b.arrowSchema()
+	if err != nil {
+		return err
+	}
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	for i, values := range b.columns {
+		for _, value := range values {
+			if err := appendArrowValue(builder.Field(i), value); err != nil {
+				return errors.Wrapf(err, "field %s", b.fields[i].Name)
+			}
+		}
+	}
+	if b.dynamicField != "" {
+		dynamicBuilder := builder.Field(len(b.columns)).(*array.StringBuilder)
+		for _, dynamic := range b.dynamics {
+			bs, err := json.Marshal(dynamic)
+			if err != nil {
+				return err
+			}
+			dynamicBuilder.Append(string(bs))
+		}
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Zstd),
+		parquet.WithMaxRowGroupLength(int64(b.rowCount)),
+	)
+	writer, err := pqarrow.NewFileWriter(schema, f, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(record); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// appendArrowValue appends the canonical value to the arrow builder.
+func appendArrowValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		b.Append(value.(bool))
+	case *array.Int8Builder:
+		b.Append(value.(int8))
+	case *array.Int16Builder:
+		b.Append(value.(int16))
+	case *array.Int32Builder:
+		b.Append(value.(int32))
+	case *array.Int64Builder:
+		b.Append(value.(int64))
+	case *array.Float32Builder:
+		b.Append(value.(float32))
+	case *array.Float64Builder:
+		b.Append(value.(float64))
+	case *array.StringBuilder:
+		switch v := value.(type) {
+		case string:
+			b.Append(v)
+		case json.RawMessage:
+			b.Append(string(v))
+		case sparseVector:
+			bs, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Append(string(bs))
+		default:
+			return errors.Newf("unexpected value type %T for string column", value)
+		}
+	case *array.ListBuilder:
+		b.Append(true)
+		switch v := value.(type) {
+		case []float32:
+			b.ValueBuilder().(*array.Float32Builder).AppendValues(v, nil)
+		case []byte:
+			b.ValueBuilder().(*array.Uint8Builder).AppendValues(v, nil)
+		case []int8:
+			b.ValueBuilder().(*array.Int8Builder).AppendValues(v, nil)
+		case []any:
+			for _, e := range v {
+				if err := appendArrowValue(b.ValueBuilder(), e); err != nil {
+					return err
+				}
+			}
+		case []map[string]any:
+			for _, e := range v {
+				if err := appendArrowValue(b.ValueBuilder(), e); err != nil {
+					return err
+				}
+			}
+		default:
+			return errors.Newf("unexpected value type %T for list column", value)
+		}
+	case *array.StructBuilder:
+		v, ok := value.(map[string]any)
+		if !ok {
+			return errors.Newf("unexpected value type %T for struct column", value)
+		}
+		b.Append(true)
+		structType := b.Type().(*arrow.StructType)
+		for i, f := range structType.Fields() {
+			if err := appendArrowValue(b.FieldBuilder(i), v[f.Name]); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Newf("unsupported arrow type %s", builder.Type().String())
+	}
+	return nil
+}
+
+func (b *buffer) persistJSON(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	if _, err := w.WriteString("[\n"); err != nil {
+		return err
+	}
+	for i := 0; i < b.rowCount; i++ {
+		row := make(map[string]any, len(b.fields))
+		if b.dynamicField != "" {
+			for key, value := range b.dynamics[i] {
+				row[key] = value
+			}
+		}
+		for j, field := range b.fields {
+			row[field.Name] = jsonValue(field, b.columns[j][i])
+		}
+		bs, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := w.WriteString(",\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(bs); err != nil {
+			return err
+		}
+	}
+	if _, err := w.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// jsonValue converts the canonical value to the value accepted by milvus JSON import.
+func jsonValue(field *entity.Field, value any) any {
+	if value == nil {
+		return nil
+	}
+	switch field.DataType {
+	case entity.FieldTypeJSON:
+		// JSON field is provided as string to keep non-object JSON values
+		return string(value.(json.RawMessage))
+	case entity.FieldTypeBinaryVector:
+		bytes := value.([]byte)
+		ints := make([]int, 0, len(bytes))
+		for _, b := range bytes {
+			ints = append(ints, int(b))
+		}
+		return ints
+	case entity.FieldTypeFloat16Vector:
+		return entity.Float16Vector(value.([]byte)).ToFloat32Vector()
+	case entity.FieldTypeBFloat16Vector:
+		return entity.BFloat16Vector(value.([]byte)).ToFloat32Vector()
+	case entity.FieldTypeArray:
+		if isStructArrayField(field) {
+			elements := value.([]map[string]any)
+			result := make([]map[string]any, 0, len(elements))
+			for _, element := range elements {
+				converted := make(map[string]any, len(element))
+				for _, sub := range field.StructSchema.Fields {
+					converted[sub.Name] = jsonValue(sub, element[sub.Name])
+				}
+				result = append(result, converted)
+			}
+			return result
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,307 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+// BulkFileType is the format of the files written by BulkWriter.
+type BulkFileType int
+
+const (
+	BulkFileTypeParquet BulkFileType = iota + 1
+	BulkFileTypeJSON
+)
+
+const (
+	// DefaultChunkSize is the default approximate size of the data in a file.
+	DefaultChunkSize = 128 * 1024 * 1024
+
+	dynamicFieldName = "$meta"
+)
+
+func (t BulkFileType) extension() string {
+	switch t {
+	case BulkFileTypeParquet:
+		return ".parquet"
+	case BulkFileTypeJSON:
+		return ".json"
+	default:
+		return ""
+	}
+}
+
+// BulkWriterOption is the option to create BulkWriter.
+type BulkWriterOption struct {
+	Schema *entity.Schema
+	// LocalPath is the directory to write the files, the files are removed after uploaded to remote storage
+	LocalPath string
+	// ChunkSize is the approximate size of the data in a file, the buffer is committed to a file when exceeded
+	ChunkSize int64
+	FileType  BulkFileType
+
+	// remote storage to upload the files, optional
+	Storage    ObjectStorage
+	RemotePath string
+}
+
+// NewBulkWriterOption returns the option of BulkWriter writing parquet files to the local path.
+func NewBulkWriterOption(schema *entity.Schema, localPath string) *BulkWriterOption {
+	return &BulkWriterOption{
+		Schema:    schema,
+		LocalPath: localPath,
+		ChunkSize: DefaultChunkSize,
+		FileType:  BulkFileTypeParquet,
+	}
+}
+
+func (opt *BulkWriterOption) WithChunkSize(chunkSize int64) *BulkWriterOption {
+	opt.ChunkSize = chunkSize
+	return opt
+}
+
+func (opt *BulkWriterOption) WithFileType(fileType BulkFileType) *BulkWriterOption {
+	opt.FileType = fileType
+	return opt
+}
+
+// WithRemoteStorage makes the BulkWriter upload the files under the remote path of the object storage.
+func (opt *BulkWriterOption) WithRemoteStorage(storage ObjectStorage, remotePath string) *BulkWriterOption {
+	opt.Storage = storage
+	opt.RemotePath = remotePath
+	return opt
+}
+
+// BulkWriter validates the rows against the collection schema, and writes them to the files
+// which can be imported by BulkImport directly. Each file contains the rows of a chunk.
+type BulkWriter struct {
+	opt       *BulkWriterOption
+	uuid      string
+	localDir  string
+	remoteDir string
+
+	mut        sync.Mutex
+	buffer     *buffer
+	fileCount  int
+	batchFiles [][]string
+	excluded   map[string]string // field name -> the reason why it shall not be provided
+	closed     bool
+}
+
+// NewBulkWriter returns a BulkWriter, the files are written to a new sub-directory of the local path
+// and the remote path named by a random uuid.
+func NewBulkWriter(opt *BulkWriterOption) (*BulkWriter, error) {
+	if opt.Schema == nil {
+		return nil, errors.New("collection schema is not provided")
+	}
+	if opt.ChunkSize <= 0 {
+		return nil, errors.Newf("invalid chunk size %d", opt.ChunkSize)
+	}
+	if opt.FileType.extension() == "" {
+		return nil, errors.Newf("unsupported file type %d", opt.FileType)
+	}
+
+	outputFields := make(map[string]struct{})
+	for _, function := range opt.Schema.Functions {
+		for _, name := range function.OutputFieldNames {
+			outputFields[name] = struct{}{}
+		}
+	}
+	fields := make([]*entity.Field, 0, len(opt.Schema.Fields))
+	excluded := make(map[string]string)
+	dynamicField := ""
+	if opt.Schema.EnableDynamicField {
+		dynamicField = dynamicFieldName
+	}
+	for _, field := range opt.Schema.Fields {
+		switch {
+		case field.IsDynamic:
+			dynamicField = field.Name
+		case field.PrimaryKey && field.AutoID:
+			excluded[field.Name] = "the primary key is auto-generated"
+		case isFunctionOutput(outputFields, field.Name):
+			excluded[field.Name] = "the field is the output of function"
+		default:
+			if _, err := arrowDataType(field); err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	id := uuid.NewString()
+	localDir := filepath.Join(opt.LocalPath, id)
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		return nil, err
+	}
+	w := &BulkWriter{
+		opt:       opt,
+		uuid:      id,
+		localDir:  localDir,
+		remoteDir: path.Join(opt.RemotePath, id),
+		buffer:    newBuffer(fields, dynamicField),
+		excluded:  excluded,
+	}
+	return w, nil
+}
+
+func isFunctionOutput(outputFields map[string]struct{}, name string) bool {
+	_, ok := outputFields[name]
+	return ok
+}
+
+// UUID returns the name of the sub-directory the files are written to.
+func (w *BulkWriter) UUID() string {
+	return w.uuid
+}
+
+// AppendRow validates the row and appends it to the buffer, the buffer is committed to a file
+// when its size exceeds the chunk size. The keys not in schema are stored in the dynamic field.
+func (w *BulkWriter) AppendRow(ctx context.Context, row map[string]any) error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if w.closed {
+		return errors.New("bulk writer is closed")
+	}
+	for name := range row {
+		if reason, ok := w.excluded[name]; ok {
+			return errors.Newf("field %s shall not be provided, %s", name, reason)
+		}
+	}
+	if err := w.buffer.appendRow(row); err != nil {
+		return err
+	}
+	if w.buffer.size >= w.opt.ChunkSize {
+		return w.commit(ctx)
+	}
+	return nil
+}
+
+// AppendColumns appends the rows of the columns, all the columns shall have the same length.
+func (w *BulkWriter) AppendColumns(ctx context.Context, columns ...column.Column) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	rowNum := columns[0].Len()
+	for _, col := range columns {
+		if col.Len() != rowNum {
+			return errors.Newf("column %s has %d rows, but column %s has %d rows", col.Name(), col.Len(), columns[0].Name(), rowNum)
+		}
+	}
+	for i := 0; i < rowNum; i++ {
+		row := make(map[string]any, len(columns))
+		for _, col := range columns {
+			isNull, err := col.IsNull(i)
+			if err != nil {
+				return err
+			}
+			if isNull {
+				row[col.Name()] = nil
+				continue
+			}
+			value, err := col.Get(i)
+			if err != nil {
+				return err
+			}
+			row[col.Name()] = value
+		}
+		if err := w.AppendRow(ctx, row); err != nil {
+			return errors.Wrapf(err, "row %d", i)
+		}
+	}
+	return nil
+}
+
+// Commit writes the buffered rows to a new file, and uploads it if remote storage is set.
+func (w *BulkWriter) Commit(ctx context.Context) error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	return w.commit(ctx)
+}
+
+func (w *BulkWriter) commit(ctx context.Context) error {
+	if w.buffer.rowCount == 0 {
+		return nil
+	}
+	// the file count is only increased after the file is committed, so a failed commit leaves no gap in the file names
+	fileName := fmt.Sprintf("%d%s", w.fileCount+1, w.opt.FileType.extension())
+	localPath := filepath.Join(w.localDir, fileName)
+	if err := w.buffer.persist(localPath, w.opt.FileType); err != nil {
+		os.Remove(localPath)
+		return errors.Wrap(err, "failed to write file")
+	}
+
+	filePath := localPath
+	if w.opt.Storage != nil {
+		filePath = path.Join(w.remoteDir, fileName)
+		err := w.opt.Storage.Upload(ctx, localPath, filePath)
+		os.Remove(localPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to upload file %s", localPath)
+		}
+	}
+	w.fileCount++
+	w.batchFiles = append(w.batchFiles, []string{filePath})
+	w.buffer = newBuffer(w.buffer.fields, w.buffer.dynamicField)
+	return nil
+}
+
+// BatchFiles returns the committed files, each group of files is an import unit of BulkImport.
+// The paths are the object keys if remote storage is set, otherwise the local paths.
+func (w *BulkWriter) BatchFiles() [][]string {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	files := make([][]string, 0, len(w.batchFiles))
+	for _, group := range w.batchFiles {
+		files = append(files, append([]string{}, group...))
+	}
+	return files
+}
+
+// NewImportOption returns the BulkImportOption to import the committed files into the collection.
+func (w *BulkWriter) NewImportOption(uri string, collectionName string) *BulkImportOption {
+	return NewBulkImportOption(uri, collectionName, w.BatchFiles())
+}
+
+// Close commits the buffered rows, and removes the local directory if remote storage is set.
+func (w *BulkWriter) Close(ctx context.Context) error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if w.closed {
+		return nil
+	}
+	if err := w.commit(ctx); err != nil {
+		return err
+	}
+	w.closed = true
+	if w.opt.Storage != nil {
+		return os.RemoveAll(w.localDir)
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+type BulkWriterSuite struct {
+	suite.Suite
+
+	schema *entity.Schema
+}
+
+func (s *BulkWriterSuite) SetupTest() {
+	s.schema = entity.NewSchema().WithName("bulk_writer").WithDynamicFieldEnabled(true).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("name").WithDataType(entity.FieldTypeVarChar).WithMaxLength(8)).
+		WithField(entity.NewField().WithName("age").WithDataType(entity.FieldTypeInt8).WithNullable(true)).
+		WithField(entity.NewField().WithName("meta").WithDataType(entity.FieldTypeJSON)).
+		WithField(entity.NewField().WithName("tags").WithDataType(entity.FieldTypeArray).WithElementType(entity.FieldTypeVarChar).WithMaxLength(8).WithMaxCapacity(4)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(4)).
+		WithField(entity.NewField().WithName("binary").WithDataType(entity.FieldTypeBinaryVector).WithDim(16))
+}
+
+func (s *BulkWriterSuite) row(i int) map[string]any {
+	return map[string]any{
+		"id":     int64(i),
+		"name":   fmt.Sprintf("n_%d", i),
+		"age":    int32(i % 100),
+		"meta":   map[string]any{"idx": i},
+		"tags":   []string{"a", "b"},
+		"vector": []float32{float32(i), 1, 2, 3},
+		"binary": []byte{byte(i), 0xff},
+		"extra":  i,
+	}
+}
+
+func (s *BulkWriterSuite) TestParquet() {
+	ctx := context.Background()
+	w, err := NewBulkWriter(NewBulkWriterOption(s.schema, s.T().TempDir()))
+	s.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		row := s.row(i)
+		if i%2 == 0 {
+			row["age"] = nil
+		}
+		s.Require().NoError(w.AppendRow(ctx, row))
+	}
+	s.Require().NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Require().Len(files, 1)
+	s.Require().Len(files[0], 1)
+
+	reader, err := file.OpenParquetFile(files[0][0], false)
+	s.Require().NoError(err)
+	defer reader.Close()
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{BatchSize: 1024}, memory.DefaultAllocator)
+	s.Require().NoError(err)
+	table, err := fileReader.ReadTable(ctx)
+	s.Require().NoError(err)
+	defer table.Release()
+
+	s.EqualValues(10, table.NumRows())
+	names := make([]string, 0, table.NumCols())
+	for _, field := range table.Schema().Fields() {
+		names = append(names, field.Name)
+	}
+	s.ElementsMatch([]string{"id", "name", "age", "meta", "tags", "vector", "binary", "$meta"}, names)
+
+	ages := table.Column(2).Data().Chunk(0).(*array.Int8)
+	s.True(ages.IsNull(0))
+	s.EqualValues(1, ages.Value(1))
+	metas := table.Column(3).Data().Chunk(0).(*array.String)
+	s.JSONEq(`{"idx": 3}`, metas.Value(3))
+	dynamics := table.Column(7).Data().Chunk(0).(*array.String)
+	s.JSONEq(`{"extra": 5}`, dynamics.Value(5))
+}
+
+func (s *BulkWriterSuite) TestJSON() {
+	ctx := context.Background()
+	w, err := NewBulkWriter(NewBulkWriterOption(s.schema, s.T().TempDir()).WithFileType(BulkFileTypeJSON))
+	s.Require().NoError(err)
+
+	s.Require().NoError(w.AppendRow(ctx, s.row(1)))
+	s.Require().NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Require().Len(files, 1)
+	bs, err := os.ReadFile(files[0][0])
+	s.Require().NoError(err)
+
+	var rows []map[string]any
+	s.Require().NoError(json.Unmarshal(bs, &rows))
+	s.Require().Len(rows, 1)
+	s.EqualValues(1, rows[0]["id"])
+	s.Equal("n_1", rows[0]["name"])
+	s.JSONEq(`{"idx": 1}`, rows[0]["meta"].(string))
+	s.Equal([]any{1.0, 255.0}, rows[0]["binary"])
+	s.EqualValues(1, rows[0]["extra"])
+}
+
+func (s *BulkWriterSuite) TestChunk() {
+	ctx := context.Background()
+	w, err := NewBulkWriter(NewBulkWriterOption(s.schema, s.T().TempDir()).WithChunkSize(256))
+	s.Require().NoError(err)
+
+	for i := 0; i < 20; i++ {
+		s.Require().NoError(w.AppendRow(ctx, s.row(i)))
+	}
+	s.Require().NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Greater(len(files), 1)
+	for _, group := range files {
+		_, err := os.Stat(group[0])
+		s.NoError(err)
+	}
+
+	opt := w.NewImportOption("http://localhost:19530", "bulk_writer")
+	s.Equal(files, opt.Files)
+}
+
+func (s *BulkWriterSuite) TestAppendColumns() {
+	ctx := context.Background()
+	schema := entity.NewSchema().WithName("bulk_writer").
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true).WithIsAutoID(true)).
+		WithField(entity.NewField().WithName("name").WithDataType(entity.FieldTypeVarChar).WithMaxLength(8)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(2))
+	w, err := NewBulkWriter(NewBulkWriterOption(schema, s.T().TempDir()))
+	s.Require().NoError(err)
+
+	err = w.AppendColumns(ctx,
+		column.NewColumnVarChar("name", []string{"a", "b", "c"}),
+		column.NewColumnFloatVector("vector", 2, [][]float32{{1, 2}, {3, 4}, {5, 6}}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(w.Commit(ctx))
+	s.Len(w.BatchFiles(), 1)
+
+	err = w.AppendColumns(ctx,
+		column.NewColumnVarChar("name", []string{"a", "b"}),
+		column.NewColumnFloatVector("vector", 2, [][]float32{{1, 2}}),
+	)
+	s.Error(err)
+
+	// auto id shall not be provided
+	err = w.AppendColumns(ctx,
+		column.NewColumnInt64("id", []int64{1}),
+		column.NewColumnVarChar("name", []string{"a"}),
+		column.NewColumnFloatVector("vector", 2, [][]float32{{1, 2}}),
+	)
+	s.Error(err)
+}
+
+func (s *BulkWriterSuite) TestValidation() {
+	ctx := context.Background()
+	w, err := NewBulkWriter(NewBulkWriterOption(s.schema, s.T().TempDir()))
+	s.Require().NoError(err)
+
+	cases := map[string]func(row map[string]any){
+		"missing_field":     func(row map[string]any) { delete(row, "name") },
+		"exceed_max_length": func(row map[string]any) { row["name"] = "too_long_name" },
+		"out_of_range":      func(row map[string]any) { row["age"] = 200 },
+		"wrong_type":        func(row map[string]any) { row["id"] = "1" },
+		"exceed_capacity":   func(row map[string]any) { row["tags"] = []string{"a", "b", "c", "d", "e"} },
+		"dim_mismatch":      func(row map[string]any) { row["vector"] = []float32{1, 2} },
+		"binary_dim":        func(row map[string]any) { row["binary"] = []byte{1} },
+		"null_not_nullable": func(row map[string]any) { row["vector"] = nil },
+	}
+	for name, modify := range cases {
+		s.Run(name, func() {
+			row := s.row(1)
+			modify(row)
+			s.Error(w.AppendRow(ctx, row))
+		})
+	}
+	s.Require().NoError(w.Close(ctx))
+	s.Len(w.BatchFiles(), 0)
+	s.Error(w.AppendRow(ctx, s.row(1)))
+
+	s.Run("no_dynamic", func() {
+		schema := entity.NewSchema().WithName("bulk_writer").
+			WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true))
+		w, err := NewBulkWriter(NewBulkWriterOption(schema, s.T().TempDir()))
+		s.Require().NoError(err)
+		s.Error(w.AppendRow(ctx, map[string]any{"id": 1, "extra": 1}))
+	})
+
+	s.Run("invalid_option", func() {
+		_, err := NewBulkWriter(NewBulkWriterOption(nil, s.T().TempDir()))
+		s.Error(err)
+		_, err = NewBulkWriter(NewBulkWriterOption(s.schema, s.T().TempDir()).WithChunkSize(0))
+		s.Error(err)
+		_, err = NewBulkWriter(NewBulkWriterOption(s.schema, s.T().TempDir()).WithFileType(0))
+		s.Error(err)
+	})
+}
+
+type mockStorage struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (m *mockStorage) Upload(ctx context.Context, localPath string, objectKey string) error {
+	if m.err != nil {
+		return m.err
+	}
+	bs, err := os.ReadFile(localPath)
+	if err != nil {
+		return err
+	}
+	m.objects[objectKey] = bs
+	return nil
+}
+
+func (s *BulkWriterSuite) TestRemote() {
+	ctx := context.Background()
+	storage := &mockStorage{objects: make(map[string][]byte)}
+	localPath := s.T().TempDir()
+	w, err := NewBulkWriter(NewBulkWriterOption(s.schema, localPath).WithRemoteStorage(storage, "bulk"))
+	s.Require().NoError(err)
+
+	s.Require().NoError(w.AppendRow(ctx, s.row(1)))
+	s.Require().NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Require().Len(files, 1)
+	s.Equal(fmt.Sprintf("bulk/%s/1.parquet", w.UUID()), files[0][0])
+	s.Contains(storage.objects, files[0][0])
+
+	entries, err := os.ReadDir(localPath)
+	s.NoError(err)
+	s.Len(entries, 0)
+
+	s.Run("upload_fail", func() {
+		storage := &mockStorage{objects: make(map[string][]byte), err: fmt.Errorf("mock error")}
+		localPath := s.T().TempDir()
+		w, err := NewBulkWriter(NewBulkWriterOption(s.schema, localPath).WithRemoteStorage(storage, "bulk"))
+		s.Require().NoError(err)
+		s.Require().NoError(w.AppendRow(ctx, s.row(1)))
+		s.Error(w.Commit(ctx))
+		s.Len(w.BatchFiles(), 0)
+
+		// the local file of the failed upload is removed
+		entries, err := os.ReadDir(filepath.Join(localPath, w.UUID()))
+		s.NoError(err)
+		s.Len(entries, 0)
+
+		// the retry reuses the file name of the failed commit
+		storage.err = nil
+		s.Require().NoError(w.Commit(ctx))
+		files := w.BatchFiles()
+		s.Require().Len(files, 1)
+		s.Equal(fmt.Sprintf("bulk/%s/1.parquet", w.UUID()), files[0][0])
+	})
+}
+
+func TestBulkWriter(t *testing.T) {
+	suite.Run(t, new(BulkWriterSuite))
+}
@@ -0,0 +1,79 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// ObjectStorage is the remote storage the BulkWriter uploads the files to,
+// it shall be the bucket milvus reads the import files from.
+type ObjectStorage interface {
+	// Upload uploads the local file to the object key of the bucket.
+	Upload(ctx context.Context, localPath string, objectKey string) error
+}
+
+// MinioStorageOption is the option of the S3 compatible object storage, such as MinIO, AWS S3 and GCS.
+type MinioStorageOption struct {
+	Endpoint   string
+	AccessKey  string
+	SecretKey  string
+	BucketName string
+	UseSSL     bool
+	Region     string
+	// UseIAM uses the credentials from IAM role instead of the access key
+	UseIAM bool
+}
+
+type minioStorage struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewMinioStorage returns the ObjectStorage of the S3 compatible object storage,
+// it returns error if the bucket doesn't exist.
+func NewMinioStorage(ctx context.Context, opt *MinioStorageOption) (ObjectStorage, error) {
+	creds := credentials.NewStaticV4(opt.AccessKey, opt.SecretKey, "")
+	if opt.UseIAM {
+		creds = credentials.NewIAM("")
+	}
+	client, err := minio.New(opt.Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: opt.UseSSL,
+		Region: opt.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	exist, err := client.BucketExists(ctx, opt.BucketName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.Newf("bucket %s doesn't exist", opt.BucketName)
+	}
+	return &minioStorage{client: client, bucket: opt.BucketName}, nil
+}
+
+func (s *minioStorage) Upload(ctx context.Context, localPath string, objectKey string) error {
+	_, err := s.client.FPutObject(ctx, s.bucket, objectKey, localPath, minio.PutObjectOptions{})
+	return err
+}
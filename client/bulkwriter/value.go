@@ -0,0 +1,463 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+// sparseVector is the import format of sparse vector, {"indices": [...], "values": [...]}.
+type sparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
+func isStructArrayField(field *entity.Field) bool {
+	return field.DataType == entity.FieldTypeArray && field.ElementType == entity.FieldTypeStruct
+}
+
+func getTypeParam(field *entity.Field, key string) (int64, error) {
+	str, ok := field.TypeParams[key]
+	if !ok {
+		return 0, errors.Newf("field %s has no type param %s", field.Name, key)
+	}
+	value, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, errors.Newf("field %s has bad format type param %s: %s", field.Name, key, str)
+	}
+	return value, nil
+}
+
+// convertValue validates the value against the field and converts it to the canonical value stored in the buffer:
+// bool, int8, int16, int32, int64, float32, float64 and string for scalars, json.RawMessage for JSON,
+// []any for arrays, []float32, []byte and []int8 for dense vectors, sparseVector for sparse vector
+// and []map[string]any for struct arrays.
+func convertValue(field *entity.Field, value any) (any, error) {
+	if value == nil {
+		if field.Nullable || field.DefaultValue != nil {
+			return nil, nil
+		}
+		return nil, errors.Newf("field %s is not nullable, but got null value", field.Name)
+	}
+	v, err := convertNonNullValue(field, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "field %s", field.Name)
+	}
+	return v, nil
+}
+
+func convertNonNullValue(field *entity.Field, value any) (any, error) {
+	switch field.DataType {
+	case entity.FieldTypeBool:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		return v, nil
+	case entity.FieldTypeInt8:
+		v, err := toInt64(field, value, math.MinInt8, math.MaxInt8)
+		return int8(v), err
+	case entity.FieldTypeInt16:
+		v, err := toInt64(field, value, math.MinInt16, math.MaxInt16)
+		return int16(v), err
+	case entity.FieldTypeInt32:
+		v, err := toInt64(field, value, math.MinInt32, math.MaxInt32)
+		return int32(v), err
+	case entity.FieldTypeInt64:
+		return toInt64(field, value, math.MinInt64, math.MaxInt64)
+	case entity.FieldTypeFloat:
+		v, err := toFloat64(field, value)
+		if err == nil && (math.IsNaN(v) || math.IsInf(float64(float32(v)), 0)) {
+			err = errors.Newf("invalid float value %v", v)
+		}
+		return float32(v), err
+	case entity.FieldTypeDouble:
+		v, err := toFloat64(field, value)
+		if err == nil && (math.IsNaN(v) || math.IsInf(v, 0)) {
+			err = errors.Newf("invalid double value %v", v)
+		}
+		return v, err
+	case entity.FieldTypeString, entity.FieldTypeVarChar:
+		v, ok := value.(string)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		if maxLength, err := getTypeParam(field, entity.TypeParamMaxLength); err == nil && int64(len(v)) > maxLength {
+			return nil, errors.Newf("value length %d exceeds max_length %d", len(v), maxLength)
+		}
+		return v, nil
+	case entity.FieldTypeJSON:
+		return convertJSON(value)
+	case entity.FieldTypeGeometry:
+		v, ok := value.(string)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		return v, nil
+	case entity.FieldTypeTimestamptz:
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(time.RFC3339Nano), nil
+		case string:
+			return v, nil
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+	case entity.FieldTypeArray:
+		if isStructArrayField(field) {
+			return convertStructArray(field, value)
+		}
+		return convertArray(field, value)
+	case entity.FieldTypeFloatVector:
+		v, ok := toFloat32Slice(value)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		if err := checkDim(field, len(v), 1); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case entity.FieldTypeBinaryVector:
+		v, ok := toByteSlice(value)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		if err := checkDim(field, len(v)*8, 1); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		// accepts both the raw bytes and the float32 values
+		if fv, ok := toFloat32Slice(value); ok {
+			if field.DataType == entity.FieldTypeFloat16Vector {
+				value = []byte(entity.FloatVector(fv).ToFloat16Vector())
+			} else {
+				value = []byte(entity.FloatVector(fv).ToBFloat16Vector())
+			}
+		}
+		v, ok := toByteSlice(value)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		if err := checkDim(field, len(v), 2); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case entity.FieldTypeInt8Vector:
+		var v []int8
+		switch vv := value.(type) {
+		case []int8:
+			v = vv
+		case entity.Int8Vector:
+			v = vv
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+		if err := checkDim(field, len(v), 1); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case entity.FieldTypeSparseVector:
+		return convertSparseVector(field, value)
+	default:
+		return nil, errors.Newf("unsupported data type %s", field.DataType.String())
+	}
+}
+
+func wrapTypeError(field *entity.Field, value any) error {
+	return errors.Newf("unexpected value type %T for data type %s", value, field.DataType.String())
+}
+
+func toInt64(field *entity.Field, value any, minValue, maxValue int64) (int64, error) {
+	var v int64
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, errors.Newf("value %d out of range [%d, %d]", rv.Uint(), minValue, maxValue)
+		}
+		v = int64(rv.Uint())
+	default:
+		return 0, wrapTypeError(field, value)
+	}
+	if v < minValue || v > maxValue {
+		return 0, errors.Newf("value %d out of range [%d, %d]", v, minValue, maxValue)
+	}
+	return v, nil
+}
+
+func toFloat64(field *entity.Field, value any) (float64, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	default:
+		return 0, wrapTypeError(field, value)
+	}
+}
+
+func toFloat32Slice(value any) ([]float32, bool) {
+	switch v := value.(type) {
+	case []float32:
+		return v, true
+	case entity.FloatVector:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+func toByteSlice(value any) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case entity.BinaryVector:
+		return v, true
+	case entity.Float16Vector:
+		return v, true
+	case entity.BFloat16Vector:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// checkDim checks the vector dimension, size is the number of elements, bytes or bits of the vector,
+// and unit is the size of a dimension.
+func checkDim(field *entity.Field, size int, unit int) error {
+	dim, err := field.GetDim()
+	if err != nil {
+		return err
+	}
+	if int64(size) != dim*int64(unit) {
+		return errors.Newf("vector dimension mismatch, expect %d, got %d", dim, size/unit)
+	}
+	return nil
+}
+
+func convertJSON(value any) (any, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		raw = v
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(bs), nil
+	}
+	if !json.Valid(raw) {
+		return nil, errors.New("invalid JSON value")
+	}
+	return json.RawMessage(raw), nil
+}
+
+func convertSparseVector(field *entity.Field, value any) (any, error) {
+	var indices []uint32
+	var values []float32
+	switch v := value.(type) {
+	case entity.SparseEmbedding:
+		for i := 0; i < v.Len(); i++ {
+			idx, val, _ := v.Get(i)
+			indices = append(indices, idx)
+			values = append(values, val)
+		}
+	case map[uint32]float32:
+		indices = make([]uint32, 0, len(v))
+		for idx := range v {
+			indices = append(indices, idx)
+		}
+		sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+		for _, idx := range indices {
+			values = append(values, v[idx])
+		}
+	default:
+		return nil, wrapTypeError(field, value)
+	}
+	if indices == nil {
+		indices, values = []uint32{}, []float32{}
+	}
+	return sparseVector{Indices: indices, Values: values}, nil
+}
+
+// elementField returns the field describing the elements of the array field.
+func elementField(field *entity.Field) *entity.Field {
+	return &entity.Field{
+		Name:       field.Name,
+		DataType:   field.ElementType,
+		TypeParams: field.TypeParams,
+	}
+}
+
+func checkCapacity(field *entity.Field, length int) error {
+	maxCapacity, err := getTypeParam(field, entity.TypeParamMaxCapacity)
+	if err != nil {
+		return err
+	}
+	if int64(length) > maxCapacity {
+		return errors.Newf("array length %d exceeds max_capacity %d", length, maxCapacity)
+	}
+	return nil
+}
+
+func convertArray(field *entity.Field, value any) (any, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, wrapTypeError(field, value)
+	}
+	if err := checkCapacity(field, rv.Len()); err != nil {
+		return nil, err
+	}
+	elemField := elementField(field)
+	result := make([]any, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		elem, err := convertNonNullValue(elemField, rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, elem)
+	}
+	return result, nil
+}
+
+// convertStructArray accepts the struct array as a list of structs []map[string]any,
+// or as a struct of lists map[string]any which is returned by the struct array column.
+func convertStructArray(field *entity.Field, value any) (any, error) {
+	if field.StructSchema == nil {
+		return nil, errors.New("struct array field has no struct schema")
+	}
+	var elements []map[string]any
+	switch v := value.(type) {
+	case []map[string]any:
+		elements = v
+	case []any:
+		elements = make([]map[string]any, 0, len(v))
+		for _, e := range v {
+			m, ok := e.(map[string]any)
+			if !ok {
+				return nil, wrapTypeError(field, value)
+			}
+			elements = append(elements, m)
+		}
+	case map[string]any:
+		length := -1
+		for _, sub := range field.StructSchema.Fields {
+			rv := reflect.ValueOf(v[sub.Name])
+			if rv.Kind() != reflect.Slice {
+				return nil, errors.Newf("sub-field %s expects a list, got %T", sub.Name, v[sub.Name])
+			}
+			if length >= 0 && rv.Len() != length {
+				return nil, errors.Newf("sub-fields of struct array have different lengths")
+			}
+			length = rv.Len()
+		}
+		elements = make([]map[string]any, length)
+		for i := range elements {
+			elements[i] = make(map[string]any, len(field.StructSchema.Fields))
+			for _, sub := range field.StructSchema.Fields {
+				elements[i][sub.Name] = reflect.ValueOf(v[sub.Name]).Index(i).Interface()
+			}
+		}
+	default:
+		return nil, wrapTypeError(field, value)
+	}
+	if err := checkCapacity(field, len(elements)); err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]any, 0, len(elements))
+	for _, element := range elements {
+		converted := make(map[string]any, len(field.StructSchema.Fields))
+		for _, sub := range field.StructSchema.Fields {
+			subValue, ok := element[sub.Name]
+			if !ok || subValue == nil {
+				return nil, errors.Newf("sub-field %s of struct array is not provided", sub.Name)
+			}
+			v, err := convertNonNullValue(sub, subValue)
+			if err != nil {
+				return nil, errors.Wrapf(err, "sub-field %s", sub.Name)
+			}
+			converted[sub.Name] = v
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// estimateSize returns the approximate memory size of the canonical value.
+func estimateSize(value any) int64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool, int8:
+		return 1
+	case int16:
+		return 2
+	case int32, float32:
+		return 4
+	case int64, float64:
+		return 8
+	case string:
+		return int64(len(v))
+	case json.RawMessage:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case []int8:
+		return int64(len(v))
+	case []float32:
+		return int64(len(v)) * 4
+	case sparseVector:
+		return int64(len(v.Indices)) * 8
+	case []any:
+		var size int64
+		for _, e := range v {
+			size += estimateSize(e)
+		}
+		return size
+	case []map[string]any:
+		var size int64
+		for _, e := range v {
+			for _, sub := range e {
+				size += estimateSize(sub)
+			}
+		}
+		return size
+	default:
+		return 8
+	}
+}
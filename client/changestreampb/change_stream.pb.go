@@ -0,0 +1,487 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.27.0
+// source: change_stream.proto
+
+package changestreampb
+
+import (
+	commonpb "github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	schemapb "github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChangeType int32
+
+const (
+	ChangeType_UnknownChange ChangeType = 0
+	ChangeType_Insert        ChangeType = 1
+	ChangeType_Upsert        ChangeType = 2
+	ChangeType_Delete        ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "UnknownChange",
+		1: "Insert",
+		2: "Upsert",
+		3: "Delete",
+	}
+	ChangeType_value = map[string]int32{
+		"UnknownChange": 0,
+		"Insert":        1,
+		"Upsert":        2,
+		"Delete":        3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_change_stream_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_change_stream_proto_enumTypes[0]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_change_stream_proto_rawDescGZIP(), []int{0}
+}
+
+// StartPosition is where a subscription without checkpoint starts from.
+type StartPosition int32
+
+const (
+	StartPosition_Latest   StartPosition = 0
+	StartPosition_Earliest StartPosition = 1
+)
+
+// Enum value maps for StartPosition.
+var (
+	StartPosition_name = map[int32]string{
+		0: "Latest",
+		1: "Earliest",
+	}
+	StartPosition_value = map[string]int32{
+		"Latest":   0,
+		"Earliest": 1,
+	}
+)
+
+func (x StartPosition) Enum() *StartPosition {
+	p := new(StartPosition)
+	*p = x
+	return p
+}
+
+func (x StartPosition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StartPosition) Descriptor() protoreflect.EnumDescriptor {
+	return file_change_stream_proto_enumTypes[1].Descriptor()
+}
+
+func (StartPosition) Type() protoreflect.EnumType {
+	return &file_change_stream_proto_enumTypes[1]
+}
+
+func (x StartPosition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StartPosition.Descriptor instead.
+func (StartPosition) EnumDescriptor() ([]byte, []int) {
+	return file_change_stream_proto_rawDescGZIP(), []int{1}
+}
+
+type SubscribeChangesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DbName         string                 `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName string                 `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// only the changes of the given partitions are delivered if not empty.
+	PartitionNames []string `protobuf:"bytes,3,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
+	// boolean expression applied to the inserted and upserted rows,
+	// deletes only carry primary keys so they are never filtered.
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// fields returned with the inserted and upserted rows, all fields are returned if empty.
+	OutputFields []string `protobuf:"bytes,5,rep,name=output_fields,json=outputFields,proto3" json:"output_fields,omitempty"`
+	// checkpoint of a previous response, the subscription resumes right after it.
+	Checkpoint string `protobuf:"bytes,6,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	// start_position is used for the vchannels not recorded in the checkpoint.
+	StartPosition StartPosition `protobuf:"varint,7,opt,name=start_position,json=startPosition,proto3,enum=milvus.proto.changestream.StartPosition" json:"start_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeChangesRequest) Reset() {
+	*x = SubscribeChangesRequest{}
+	mi := &file_change_stream_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeChangesRequest) ProtoMessage() {}
+
+func (x *SubscribeChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_change_stream_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeChangesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeChangesRequest) Descriptor() ([]byte, []int) {
+	return file_change_stream_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeChangesRequest) GetDbName() string {
+	if x != nil {
+		return x.DbName
+	}
+	return ""
+}
+
+func (x *SubscribeChangesRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SubscribeChangesRequest) GetPartitionNames() []string {
+	if x != nil {
+		return x.PartitionNames
+	}
+	return nil
+}
+
+func (x *SubscribeChangesRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *SubscribeChangesRequest) GetOutputFields() []string {
+	if x != nil {
+		return x.OutputFields
+	}
+	return nil
+}
+
+func (x *SubscribeChangesRequest) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+func (x *SubscribeChangesRequest) GetStartPosition() StartPosition {
+	if x != nil {
+		return x.StartPosition
+	}
+	return StartPosition_Latest
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          ChangeType             `protobuf:"varint,1,opt,name=type,proto3,enum=milvus.proto.changestream.ChangeType" json:"type,omitempty"`
+	Vchannel      string                 `protobuf:"bytes,2,opt,name=vchannel,proto3" json:"vchannel,omitempty"`
+	PartitionName string                 `protobuf:"bytes,3,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	Timestamp     uint64                 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	NumRows       int64                  `protobuf:"varint,5,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
+	// fields_data is empty for delete events.
+	FieldsData    []*schemapb.FieldData `protobuf:"bytes,6,rep,name=fields_data,json=fieldsData,proto3" json:"fields_data,omitempty"`
+	PrimaryKeys   *schemapb.IDs         `protobuf:"bytes,7,opt,name=primary_keys,json=primaryKeys,proto3" json:"primary_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_change_stream_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_change_stream_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_change_stream_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChangeEvent) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_UnknownChange
+}
+
+func (x *ChangeEvent) GetVchannel() string {
+	if x != nil {
+		return x.Vchannel
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetPartitionName() string {
+	if x != nil {
+		return x.PartitionName
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetNumRows() int64 {
+	if x != nil {
+		return x.NumRows
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetFieldsData() []*schemapb.FieldData {
+	if x != nil {
+		return x.FieldsData
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetPrimaryKeys() *schemapb.IDs {
+	if x != nil {
+		return x.PrimaryKeys
+	}
+	return nil
+}
+
+type SubscribeChangesResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status *commonpb.Status       `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Events []*ChangeEvent         `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	// checkpoint after all the events of this response, the response may carry no events
+	// and only be used to advance the checkpoint.
+	Checkpoint    string `protobuf:"bytes,3,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeChangesResponse) Reset() {
+	*x = SubscribeChangesResponse{}
+	mi := &file_change_stream_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeChangesResponse) ProtoMessage() {}
+
+func (x *SubscribeChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_change_stream_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeChangesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeChangesResponse) Descriptor() ([]byte, []int) {
+	return file_change_stream_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeChangesResponse) GetStatus() *commonpb.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *SubscribeChangesResponse) GetEvents() []*ChangeEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *SubscribeChangesResponse) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+var File_change_stream_proto protoreflect.FileDescriptor
+
+const file_change_stream_proto_rawDesc = "" +
+	"\n" +
+	"\x13change_stream.proto\x12\x19milvus.proto.changestream\x1a\fcommon.proto\x1a\fschema.proto\"\xb2\x02\n" +
+	"\x17SubscribeChangesRequest\x12\x17\n" +
+	"\adb_name\x18\x01 \x01(\tR\x06dbName\x12'\n" +
+	"\x0fcollection_name\x18\x02 \x01(\tR\x0ecollectionName\x12'\n" +
+	"\x0fpartition_names\x18\x03 \x03(\tR\x0epartitionNames\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x12#\n" +
+	"\routput_fields\x18\x05 \x03(\tR\foutputFields\x12\x1e\n" +
+	"\n" +
+	"checkpoint\x18\x06 \x01(\tR\n" +
+	"checkpoint\x12O\n" +
+	"\x0estart_position\x18\a \x01(\x0e2(.milvus.proto.changestream.StartPositionR\rstartPosition\"\xc2\x02\n" +
+	"\vChangeEvent\x129\n" +
+	"\x04type\x18\x01 \x01(\x0e2%.milvus.proto.changestream.ChangeTypeR\x04type\x12\x1a\n" +
+	"\bvchannel\x18\x02 \x01(\tR\bvchannel\x12%\n" +
+	"\x0epartition_name\x18\x03 \x01(\tR\rpartitionName\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x04R\ttimestamp\x12\x19\n" +
+	"\bnum_rows\x18\x05 \x01(\x03R\anumRows\x12?\n" +
+	"\vfields_data\x18\x06 \x03(\v2\x1e.milvus.proto.schema.FieldDataR\n" +
+	"fieldsData\x12;\n" +
+	"\fprimary_keys\x18\a \x01(\v2\x18.milvus.proto.schema.IDsR\vprimaryKeys\"\xaf\x01\n" +
+	"\x18SubscribeChangesResponse\x123\n" +
+	"\x06status\x18\x01 \x01(\v2\x1b.milvus.proto.common.StatusR\x06status\x12>\n" +
+	"\x06events\x18\x02 \x03(\v2&.milvus.proto.changestream.ChangeEventR\x06events\x12\x1e\n" +
+	"\n" +
+	"checkpoint\x18\x03 \x01(\tR\n" +
+	"checkpoint*C\n" +
+	"\n" +
+	"ChangeType\x12\x11\n" +
+	"\rUnknownChange\x10\x00\x12\n" +
+	"\n" +
+	"\x06Insert\x10\x01\x12\n" +
+	"\n" +
+	"\x06Upsert\x10\x02\x12\n" +
+	"\n" +
+	"\x06Delete\x10\x03*)\n" +
+	"\rStartPosition\x12\n" +
+	"\n" +
+	"\x06Latest\x10\x00\x12\f\n" +
+	"\bEarliest\x10\x012\x96\x01\n" +
+	"\x13ChangeStreamService\x12\x7f\n" +
+	"\x10SubscribeChanges\x122.milvus.proto.changestream.SubscribeChangesRequest\x1a3.milvus.proto.changestream.SubscribeChangesResponse\"\x000\x01B6Z4github.com/milvus-io/milvus/client/v3/changestreampbb\x06proto3"
+
+var (
+	file_change_stream_proto_rawDescOnce sync.Once
+	file_change_stream_proto_rawDescData []byte
+)
+
+func file_change_stream_proto_rawDescGZIP() []byte {
+	file_change_stream_proto_rawDescOnce.Do(func() {
+		file_change_stream_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_change_stream_proto_rawDesc), len(file_change_stream_proto_rawDesc)))
+	})
+	return file_change_stream_proto_rawDescData
+}
+
+var file_change_stream_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_change_stream_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_change_stream_proto_goTypes = []any{
+	(ChangeType)(0),                  // 0: milvus.proto.changestream.ChangeType
+	(StartPosition)(0),               // 1: milvus.proto.changestream.StartPosition
+	(*SubscribeChangesRequest)(nil),  // 2: milvus.proto.changestream.SubscribeChangesRequest
+	(*ChangeEvent)(nil),              // 3: milvus.proto.changestream.ChangeEvent
+	(*SubscribeChangesResponse)(nil), // 4: milvus.proto.changestream.SubscribeChangesResponse
+	(*schemapb.FieldData)(nil),       // 5: milvus.proto.schema.FieldData
+	(*schemapb.IDs)(nil),             // 6: milvus.proto.schema.IDs
+	(*commonpb.Status)(nil),          // 7: milvus.proto.common.Status
+}
+var file_change_stream_proto_depIdxs = []int32{
+	1, // 0: milvus.proto.changestream.SubscribeChangesRequest.start_position:type_name -> milvus.proto.changestream.StartPosition
+	0, // 1: milvus.proto.changestream.ChangeEvent.type:type_name -> milvus.proto.changestream.ChangeType
+	5, // 2: milvus.proto.changestream.ChangeEvent.fields_data:type_name -> milvus.proto.schema.FieldData
+	6, // 3: milvus.proto.changestream.ChangeEvent.primary_keys:type_name -> milvus.proto.schema.IDs
+	7, // 4: milvus.proto.changestream.SubscribeChangesResponse.status:type_name -> milvus.proto.common.Status
+	3, // 5: milvus.proto.changestream.SubscribeChangesResponse.events:type_name -> milvus.proto.changestream.ChangeEvent
+	2, // 6: milvus.proto.changestream.ChangeStreamService.SubscribeChanges:input_type -> milvus.proto.changestream.SubscribeChangesRequest
+	4, // 7: milvus.proto.changestream.ChangeStreamService.SubscribeChanges:output_type -> milvus.proto.changestream.SubscribeChangesResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_change_stream_proto_init() }
+func file_change_stream_proto_init() {
+	if File_change_stream_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_change_stream_proto_rawDesc), len(file_change_stream_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_change_stream_proto_goTypes,
+		DependencyIndexes: file_change_stream_proto_depIdxs,
+		EnumInfos:         file_change_stream_proto_enumTypes,
+		MessageInfos:      file_change_stream_proto_msgTypes,
+	}.Build()
+	File_change_stream_proto = out.File
+	file_change_stream_proto_goTypes = nil
+	file_change_stream_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.27.0
+// source: change_stream.proto
+
+package changestreampb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ChangeStreamService_SubscribeChanges_FullMethodName = "/milvus.proto.changestream.ChangeStreamService/SubscribeChanges"
+)
+
+// ChangeStreamServiceClient is the client API for ChangeStreamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChangeStreamServiceClient interface {
+	// SubscribeChanges streams the insert, upsert and delete events of one collection.
+	// The users restricted by row policies are not permitted to subscribe.
+	SubscribeChanges(ctx context.Context, in *SubscribeChangesRequest, opts ...grpc.CallOption) (ChangeStreamService_SubscribeChangesClient, error)
+}
+
+type changeStreamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChangeStreamServiceClient(cc grpc.ClientConnInterface) ChangeStreamServiceClient {
+	return &changeStreamServiceClient{cc}
+}
+
+func (c *changeStreamServiceClient) SubscribeChanges(ctx context.Context, in *SubscribeChangesRequest, opts ...grpc.CallOption) (ChangeStreamService_SubscribeChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ChangeStreamService_ServiceDesc.Streams[0], ChangeStreamService_SubscribeChanges_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &changeStreamServiceSubscribeChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChangeStreamService_SubscribeChangesClient interface {
+	Recv() (*SubscribeChangesResponse, error)
+	grpc.ClientStream
+}
+
+type changeStreamServiceSubscribeChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *changeStreamServiceSubscribeChangesClient) Recv() (*SubscribeChangesResponse, error) {
+	m := new(SubscribeChangesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChangeStreamServiceServer is the server API for ChangeStreamService service.
+// All implementations should embed UnimplementedChangeStreamServiceServer
+// for forward compatibility
+type ChangeStreamServiceServer interface {
+	// SubscribeChanges streams the insert, upsert and delete events of one collection.
+	// The users restricted by row policies are not permitted to subscribe.
+	SubscribeChanges(*SubscribeChangesRequest, ChangeStreamService_SubscribeChangesServer) error
+}
+
+// UnimplementedChangeStreamServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedChangeStreamServiceServer struct {
+}
+
+func (UnimplementedChangeStreamServiceServer) SubscribeChanges(*SubscribeChangesRequest, ChangeStreamService_SubscribeChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeChanges not implemented")
+}
+
+// UnsafeChangeStreamServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChangeStreamServiceServer will
+// result in compilation errors.
+type UnsafeChangeStreamServiceServer interface {
+	mustEmbedUnimplementedChangeStreamServiceServer()
+}
+
+func RegisterChangeStreamServiceServer(s grpc.ServiceRegistrar, srv ChangeStreamServiceServer) {
+	s.RegisterService(&ChangeStreamService_ServiceDesc, srv)
+}
+
+func _ChangeStreamService_SubscribeChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChangeStreamServiceServer).SubscribeChanges(m, &changeStreamServiceSubscribeChangesServer{stream})
+}
+
+type ChangeStreamService_SubscribeChangesServer interface {
+	Send(*SubscribeChangesResponse) error
+	grpc.ServerStream
+}
+
+type changeStreamServiceSubscribeChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *changeStreamServiceSubscribeChangesServer) Send(m *SubscribeChangesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ChangeStreamService_ServiceDesc is the grpc.ServiceDesc for ChangeStreamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChangeStreamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "milvus.proto.changestream.ChangeStreamService",
+	HandlerType: (*ChangeStreamServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeChanges",
+			Handler:       _ChangeStreamService_SubscribeChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "change_stream.proto",
+}
@@ -0,0 +1,155 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+// ChangeType is the type of entity change.
+type ChangeType = changestreampb.ChangeType
+
+const (
+	ChangeTypeInsert = changestreampb.ChangeType_Insert
+	ChangeTypeUpsert = changestreampb.ChangeType_Upsert
+	ChangeTypeDelete = changestreampb.ChangeType_Delete
+)
+
+// ChangeEvent is a batch of rows changed by one write of the subscribed collection.
+type ChangeEvent struct {
+	Type          ChangeType
+	VChannel      string
+	PartitionName string
+	Timestamp     uint64
+	NumRows       int
+	// IDs is the primary keys of the changed rows.
+	IDs column.Column
+	// Fields is the output fields of the inserted or upserted rows, it's empty for deletes.
+	Fields DataSet
+}
+
+// GetColumn returns column with provided field name.
+func (e *ChangeEvent) GetColumn(fieldName string) column.Column {
+	for _, column := range e.Fields {
+		if column.Name() == fieldName {
+			return column
+		}
+	}
+	return nil
+}
+
+// ChangeBatch is the events received in one response together with the checkpoint after them,
+// the batch may carry no events and only advance the checkpoint.
+type ChangeBatch struct {
+	Events []ChangeEvent
+	// Checkpoint resumes the subscription right after this batch, see subscribeChangesOption.WithCheckpoint.
+	Checkpoint string
+}
+
+// ChangeStream is the stream of changes returned by SubscribeChanges.
+type ChangeStream struct {
+	stream changestreampb.ChangeStreamService_SubscribeChangesClient
+	schema *entity.Schema
+	cancel context.CancelFunc
+}
+
+// Recv blocks until the next batch of changes is received, io.EOF is returned if the stream is closed by server.
+func (s *ChangeStream) Recv() (*ChangeBatch, error) {
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if err := merr.Error(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+
+	batch := &ChangeBatch{
+		Events:     make([]ChangeEvent, 0, len(resp.GetEvents())),
+		Checkpoint: resp.GetCheckpoint(),
+	}
+	for _, evt := range resp.GetEvents() {
+		ids, err := column.IDColumns(s.schema, evt.GetPrimaryKeys(), 0, -1)
+		if err != nil {
+			return nil, err
+		}
+		fields := make(DataSet, 0, len(evt.GetFieldsData()))
+		for _, fieldData := range evt.GetFieldsData() {
+			col, err := column.FieldDataColumn(fieldData, 0, -1)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, col)
+		}
+		batch.Events = append(batch.Events, ChangeEvent{
+			Type:          evt.GetType(),
+			VChannel:      evt.GetVchannel(),
+			PartitionName: evt.GetPartitionName(),
+			Timestamp:     evt.GetTimestamp(),
+			NumRows:       int(evt.GetNumRows()),
+			IDs:           ids,
+			Fields:        fields,
+		})
+	}
+	return batch, nil
+}
+
+// Close cancels the subscription.
+func (s *ChangeStream) Close() {
+	s.cancel()
+}
+
+// SubscribeChanges subscribes the decoded insert, upsert and delete changes of a collection.
+// The events of one vchannel are delivered in order, and the subscription could be resumed
+// from the checkpoint of any received batch with at least once semantics.
+func (c *Client) SubscribeChanges(ctx context.Context, option SubscribeChangesOption, callOptions ...grpc.CallOption) (*ChangeStream, error) {
+	req := option.Request()
+	collection, err := c.getCollection(ctx, req.GetCollectionName())
+	if err != nil {
+		return nil, err
+	}
+
+	ep := c.pickEndpoint()
+	if ep == nil {
+		return nil, merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+	}
+	service := ep.getChangeStreamService()
+	if service == nil {
+		return nil, merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	// the unary interceptors are not applied to stream rpc, attach the metadata here.
+	ctx = c.metadata(ctx)
+	ctx = c.state(ctx, ep.getConn())
+	stream, err := service.SubscribeChanges(ctx, req, callOptions...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &ChangeStream{
+		stream: stream,
+		schema: collection.Schema,
+		cancel: cancel,
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+)
+
+// SubscribeChangesOption is the interface builds SubscribeChangesRequest.
+type SubscribeChangesOption interface {
+	Request() *changestreampb.SubscribeChangesRequest
+}
+
+type subscribeChangesOption struct {
+	dbName         string
+	collectionName string
+	partitionNames []string
+	filter         string
+	outputFields   []string
+	checkpoint     string
+	startPosition  changestreampb.StartPosition
+}
+
+func (opt *subscribeChangesOption) Request() *changestreampb.SubscribeChangesRequest {
+	return &changestreampb.SubscribeChangesRequest{
+		DbName:         opt.dbName,
+		CollectionName: opt.collectionName,
+		PartitionNames: opt.partitionNames,
+		Filter:         opt.filter,
+		OutputFields:   opt.outputFields,
+		Checkpoint:     opt.checkpoint,
+		StartPosition:  opt.startPosition,
+	}
+}
+
+func (opt *subscribeChangesOption) WithDbName(dbName string) *subscribeChangesOption {
+	opt.dbName = dbName
+	return opt
+}
+
+// WithPartitions only subscribes the changes of the provided partitions.
+func (opt *subscribeChangesOption) WithPartitions(partitionNames ...string) *subscribeChangesOption {
+	opt.partitionNames = partitionNames
+	return opt
+}
+
+// WithFilter only delivers the inserted and upserted rows matching the filter expression,
+// deletes are always delivered since they only carry the primary keys.
+func (opt *subscribeChangesOption) WithFilter(expr string) *subscribeChangesOption {
+	opt.filter = expr
+	return opt
+}
+
+func (opt *subscribeChangesOption) WithOutputFields(fieldNames ...string) *subscribeChangesOption {
+	opt.outputFields = fieldNames
+	return opt
+}
+
+// WithCheckpoint resumes the subscription right after the checkpoint of a previously received ChangeBatch.
+func (opt *subscribeChangesOption) WithCheckpoint(checkpoint string) *subscribeChangesOption {
+	opt.checkpoint = checkpoint
+	return opt
+}
+
+// WithStartFromEarliest subscribes from the earliest changes retained in the WAL instead of the latest,
+// it only works for the vchannels not recorded in the checkpoint.
+func (opt *subscribeChangesOption) WithStartFromEarliest() *subscribeChangesOption {
+	opt.startPosition = changestreampb.StartPosition_Earliest
+	return opt
+}
+
+// NewSubscribeChangesOption creates the option to subscribe the changes of the collection.
+func NewSubscribeChangesOption(collectionName string) *subscribeChangesOption {
+	return &subscribeChangesOption{
+		collectionName: collectionName,
+	}
+}
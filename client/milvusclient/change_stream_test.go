@@ -0,0 +1,184 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+type fakeChangeStreamServer struct {
+	req       *changestreampb.SubscribeChangesRequest
+	md        metadata.MD
+	responses []*changestreampb.SubscribeChangesResponse
+}
+
+func (f *fakeChangeStreamServer) SubscribeChanges(req *changestreampb.SubscribeChangesRequest, stream changestreampb.ChangeStreamService_SubscribeChangesServer) error {
+	f.req = req
+	f.md, _ = metadata.FromIncomingContext(stream.Context())
+	for _, resp := range f.responses {
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ChangeStreamSuite struct {
+	MockSuiteBase
+
+	changeStream *fakeChangeStreamServer
+}
+
+func (s *ChangeStreamSuite) SetupSuite() {
+	s.lis = bufconn.Listen(bufSize)
+	s.svr = grpc.NewServer()
+	s.mock = &MilvusServiceServer{}
+	s.changeStream = &fakeChangeStreamServer{}
+
+	milvuspb.RegisterMilvusServiceServer(s.svr, s.mock)
+	changestreampb.RegisterChangeStreamServiceServer(s.svr, s.changeStream)
+
+	go func() {
+		if err := s.svr.Serve(s.lis); err != nil {
+			s.Fail("failed to start mock server", err.Error())
+		}
+	}()
+	s.setupConnect()
+}
+
+func (s *ChangeStreamSuite) TestSubscribeChanges() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	collectionName := "test_change_stream"
+	s.setupCache(collectionName, entity.NewSchema().WithName(collectionName).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("age").WithDataType(entity.FieldTypeInt64)))
+
+	s.changeStream.responses = []*changestreampb.SubscribeChangesResponse{
+		{
+			Status: &commonpb.Status{},
+			Events: []*changestreampb.ChangeEvent{
+				{
+					Type:          changestreampb.ChangeType_Upsert,
+					Vchannel:      "v0",
+					PartitionName: "_default",
+					Timestamp:     100,
+					NumRows:       2,
+					PrimaryKeys:   &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}}},
+					FieldsData: []*schemapb.FieldData{
+						{
+							FieldName: "age", Type: schemapb.DataType_Int64,
+							Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{20, 30}}}}},
+						},
+					},
+				},
+				{
+					Type:        changestreampb.ChangeType_Delete,
+					Vchannel:    "v0",
+					Timestamp:   101,
+					NumRows:     1,
+					PrimaryKeys: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{3}}}},
+				},
+			},
+			Checkpoint: "checkpoint-1",
+		},
+		{
+			Status:     &commonpb.Status{},
+			Checkpoint: "checkpoint-2",
+		},
+	}
+
+	stream, err := s.client.SubscribeChanges(ctx, NewSubscribeChangesOption(collectionName).
+		WithPartitions("_default").
+		WithFilter("age > 10").
+		WithOutputFields("age").
+		WithCheckpoint("checkpoint-0").
+		WithStartFromEarliest())
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	batch, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal("checkpoint-1", batch.Checkpoint)
+	s.Require().Len(batch.Events, 2)
+
+	upsert := batch.Events[0]
+	s.Equal(ChangeTypeUpsert, upsert.Type)
+	s.Equal(2, upsert.NumRows)
+	s.EqualValues(100, upsert.Timestamp)
+	s.Equal(2, upsert.IDs.Len())
+	age := upsert.GetColumn("age")
+	s.Require().NotNil(age)
+	v, err := age.GetAsInt64(1)
+	s.NoError(err)
+	s.EqualValues(30, v)
+	s.Nil(upsert.GetColumn("not_exist"))
+
+	del := batch.Events[1]
+	s.Equal(ChangeTypeDelete, del.Type)
+	s.Empty(del.Fields)
+	pk, err := del.IDs.GetAsInt64(0)
+	s.NoError(err)
+	s.EqualValues(3, pk)
+
+	batch, err = stream.Recv()
+	s.Require().NoError(err)
+	s.Empty(batch.Events)
+	s.Equal("checkpoint-2", batch.Checkpoint)
+
+	_, err = stream.Recv()
+	s.ErrorIs(err, io.EOF)
+
+	req := s.changeStream.req
+	s.Equal(collectionName, req.GetCollectionName())
+	s.Equal([]string{"_default"}, req.GetPartitionNames())
+	s.Equal("age > 10", req.GetFilter())
+	s.Equal([]string{"age"}, req.GetOutputFields())
+	s.Equal("checkpoint-0", req.GetCheckpoint())
+	s.Equal(changestreampb.StartPosition_Earliest, req.GetStartPosition())
+	// the identifier registered by connect is attached to the stream
+	s.Equal([]string{"1"}, s.changeStream.md.Get(identifierHeader))
+
+	s.Run("failure_status", func() {
+		s.changeStream.responses = []*changestreampb.SubscribeChangesResponse{
+			{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "mocked"}},
+		}
+		stream, err := s.client.SubscribeChanges(ctx, NewSubscribeChangesOption(collectionName))
+		s.Require().NoError(err)
+		defer stream.Close()
+		_, err = stream.Recv()
+		s.Error(err)
+	})
+}
+
+func TestChangeStream(t *testing.T) {
+	suite.Run(t, new(ChangeStreamSuite))
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -43,15 +44,14 @@ import (
 )
 
 type Client struct {
-	conn             *grpc.ClientConn
-	service          milvuspb.MilvusServiceClient
-	telemetryService milvuspb.ClientTelemetryServiceClient
-	config           *ClientConfig
+	// endpoints of the proxies, requests are balanced across the healthy ones
+	endpoints    []*endpoint
+	nextEndpoint atomic.Uint64
+	config       *ClientConfig
 
 	// mutable status
-	stateMut   sync.RWMutex
-	currentDB  string
-	identifier string // Identifier for this connection
+	stateMut  sync.RWMutex
+	currentDB string
 
 	metadataHeaders map[string]string
 
@@ -59,9 +59,19 @@ type Client struct {
 
 	// Telemetry manager for metrics collection and heartbeat
 	telemetry *ClientTelemetryManager
+
+	// dial options to reconnect the endpoints
+	dialOpts   []grpc.DialOption
+	connectMut sync.Mutex // serializes the connect requests updating the config
+
+	healthCheckCancel context.CancelFunc
+	healthCheckWg     sync.WaitGroup
 }
 
 func New(ctx context.Context, config *ClientConfig) (*Client, error) {
+	if err := config.resolveSRV(ctx); err != nil {
+		return nil, err
+	}
 	if err := config.parse(); err != nil {
 		return nil, err
 	}
@@ -71,18 +81,19 @@ func New(ctx context.Context, config *ClientConfig) (*Client, error) {
 		currentDB: config.DBName,
 	}
 
-	// Parse remote address.
-	addr := c.config.getParsedAddress()
+	// Parse remote addresses.
+	addrs := c.config.getParsedAddresses()
 
 	// parse authentication parameters
 	c.parseAuthentication()
 	// Parse grpc options
 	options := c.dialOptions()
 
-	// Connect the grpc server.
-	if err := c.connect(ctx, addr, options...); err != nil {
+	// Connect the grpc servers.
+	if err := c.connect(ctx, addrs, options...); err != nil {
 		return nil, err
 	}
+	c.startHealthCheck()
 
 	c.collCache = NewCollectionCache(func(ctx context.Context, collName string) (*entity.Collection, error) {
 		return c.DescribeCollection(ctx, NewDescribeCollectionOption(collName))
@@ -112,6 +123,11 @@ func (c *Client) dialOptions() []grpc.DialOption {
 	options = append(options, DefaultGrpcOpts...)
 	options = append(options, c.config.DialOptions...)
 
+	// failover interceptor shall be the outermost one to retry on other endpoints after the retries exhausted
+	options = append(options, grpc.WithChainUnaryInterceptor(
+		c.FailoverUnaryInterceptor(),
+	))
+
 	options = append(options,
 		grpc.WithChainUnaryInterceptor(grpc_retry.UnaryClientInterceptor(
 			grpc_retry.WithMax(6),
@@ -150,16 +166,16 @@ func (c *Client) Close(ctx context.Context) error {
 	if c.telemetry != nil {
 		c.telemetry.Stop()
 	}
+	c.stopHealthCheck()
 
-	if c.conn == nil {
-		return nil
+	var err error
+	for _, ep := range c.endpoints {
+		err = errors.CombineErrors(err, ep.close())
 	}
-	err := c.conn.Close()
 	if err != nil {
 		return err
 	}
-	c.conn = nil
-	c.service = nil
+	c.endpoints = nil
 	return nil
 }
 
@@ -175,36 +191,91 @@ func (c *Client) getCurrentDB() string {
 	return c.currentDB
 }
 
-func (c *Client) setIdentifier(identifier string) {
-	c.stateMut.Lock()
-	defer c.stateMut.Unlock()
-	c.identifier = identifier
-}
-
-func (c *Client) connect(ctx context.Context, addr string, options ...grpc.DialOption) error {
-	if addr == "" {
+func (c *Client) connect(ctx context.Context, addrs []string, options ...grpc.DialOption) error {
+	if len(addrs) == 0 {
 		return errors.New("address is empty")
 	}
-	conn, err := grpc.DialContext(ctx, addr, options...)
-	if err != nil {
+	for _, addr := range addrs {
+		if addr == "" {
+			return errors.New("address is empty")
+		}
+		c.endpoints = append(c.endpoints, &endpoint{address: addr})
+	}
+	c.dialOpts = options
+
+	// dial the endpoints concurrently, the client is available if any of them is connected
+	errs := make([]error, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range c.endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			errs[i] = c.connectEndpoint(ctx, ep, options...)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	// endpoints failed to connect are reconnected by health check
+	if !c.hasHealthyEndpoint() {
+		for _, ep := range c.endpoints {
+			ep.close()
+		}
+		c.endpoints = nil
+		var err error
+		for _, epErr := range errs {
+			err = errors.CombineErrors(err, epErr)
+		}
 		return err
 	}
 
-	c.conn = conn
-	c.service = milvuspb.NewMilvusServiceClient(c.conn)
-	c.telemetryService = milvuspb.NewClientTelemetryServiceClient(c.conn)
+	return nil
+}
+
+func (c *Client) connectEndpoint(ctx context.Context, ep *endpoint, options ...grpc.DialOption) error {
+	conn, err := grpc.DialContext(ctx, ep.address, options...)
+	if err != nil {
+		return err
+	}
+	ep.setConn(conn)
 
 	if !c.config.DisableConn {
-		err = c.connectInternal(ctx)
+		err = c.connectInternalEndpoint(ctx, ep)
 		if err != nil {
 			return err
 		}
 	}
+	ep.healthy.Store(true)
 
 	return nil
 }
 
+// connectInternal connects all the healthy endpoints, so that they share the same database and client info.
 func (c *Client) connectInternal(ctx context.Context) error {
+	var lastErr error
+	connected := false
+	for _, ep := range c.endpoints {
+		if !ep.healthy.Load() {
+			continue
+		}
+		if err := c.connectInternalEndpoint(ctx, ep); err != nil {
+			lastErr = err
+			continue
+		}
+		connected = true
+	}
+	if !connected && lastErr == nil {
+		return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+	}
+	if c.collCache != nil {
+		c.collCache.Reset()
+	}
+	return lastErr
+}
+
+func (c *Client) connectInternalEndpoint(ctx context.Context, ep *endpoint) error {
+	c.connectMut.Lock()
+	defer c.connectMut.Unlock()
+
 	hostName, err := os.Hostname()
 	if err != nil {
 		return err
@@ -220,7 +291,12 @@ func (c *Client) connectInternal(ctx context.Context) error {
 		},
 	}
 
-	resp, err := c.service.Connect(ctx, req)
+	service, _ := ep.getService()
+	if service == nil {
+		return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+	}
+	// connect request shall be sent to the endpoint itself
+	resp, err := service.Connect(context.WithValue(ctx, pinEndpoint, true), req)
 	if err != nil {
 		status, ok := status.FromError(err)
 		if ok {
@@ -242,25 +318,32 @@ func (c *Client) connectInternal(ctx context.Context) error {
 	}
 
 	c.config.setServerInfo(resp.GetServerInfo().GetBuildTags())
-	c.setIdentifier(strconv.FormatInt(resp.GetIdentifier(), 10))
-	if c.collCache != nil {
-		c.collCache.Reset()
-	}
+	c.setIdentifier(ep, strconv.FormatInt(resp.GetIdentifier(), 10))
 
 	return nil
 }
 
 func (c *Client) callService(fn func(milvusService milvuspb.MilvusServiceClient) error) error {
-	service := c.service
+	ep := c.pickEndpoint()
+	if ep == nil {
+		return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+	}
+	service, _ := ep.getService()
 	if service == nil {
 		return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
 	}
 
-	return fn(c.service)
+	return fn(service)
 }
 
+// GetService returns the service of one of the healthy endpoints.
 func (c *Client) GetService() milvuspb.MilvusServiceClient {
-	return c.service
+	ep := c.pickEndpoint()
+	if ep == nil {
+		return nil
+	}
+	service, _ := ep.getService()
+	return service
 }
 
 // GetTelemetry returns the telemetry manager for this client
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"math"
+	"net"
 	"net/url"
 	"regexp"
 	"strings"
@@ -49,7 +50,16 @@ var DefaultGrpcOpts = []grpc.DialOption{
 
 // ClientConfig for milvus client.
 type ClientConfig struct {
-	Address  string // Remote address, "localhost:19530".
+	Address string // Remote address, "localhost:19530".
+	// Addresses of multiple proxies, requests are balanced across the healthy ones.
+	// Address, if set, is used together with Addresses.
+	Addresses []string
+	// SRVRecord is the DNS SRV record resolving the proxy addresses, "_milvus._tcp.milvus.example.com".
+	// The record is resolved once when the client is created.
+	SRVRecord string
+	// HealthCheckInterval is the interval to check the health of proxies when multiple addresses are provided.
+	HealthCheckInterval time.Duration
+
 	Username string // Username for auth.
 	Password string // Password for auth.
 	DBName   string // DBName for this client.
@@ -68,9 +78,10 @@ type ClientConfig struct {
 	// TelemetryConfig for client telemetry settings
 	TelemetryConfig *TelemetryConfig
 
-	ServerVersion string // ServerVersion
-	parsedAddress *url.URL
-	flags         uint64 // internal flags
+	ServerVersion   string // ServerVersion
+	parsedAddresses []*url.URL
+	srvAddresses    []string // addresses resolved from SRVRecord
+	flags           uint64   // internal flags
 }
 
 type RetryRateLimitOption struct {
@@ -78,39 +89,77 @@ type RetryRateLimitOption struct {
 	MaxBackoff time.Duration
 }
 
-func (cfg *ClientConfig) parse() error {
-	// Prepend default fake tcp:// scheme for remote address.
-	address := cfg.Address
-	if !regexValidScheme.MatchString(address) {
-		address = fmt.Sprintf("tcp://%s", address)
-	}
+const defaultHealthCheckInterval = 10 * time.Second
 
-	remoteURL, err := url.Parse(address)
+// resolveSRV looks up the proxy addresses of the SRV record if provided.
+func (cfg *ClientConfig) resolveSRV(ctx context.Context) error {
+	if cfg.SRVRecord == "" {
+		return nil
+	}
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", cfg.SRVRecord)
 	if err != nil {
-		return errors.Wrap(err, "milvus address parse fail")
+		return errors.Wrapf(err, "failed to resolve SRV record %s", cfg.SRVRecord)
 	}
-	// Remote Host should never be empty.
-	if remoteURL.Host == "" {
-		return errors.New("empty remote host of milvus address")
+	cfg.srvAddresses = make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		cfg.srvAddresses = append(cfg.srvAddresses, net.JoinHostPort(host, fmt.Sprint(record.Port)))
+	}
+	return nil
+}
+
+func (cfg *ClientConfig) parse() error {
+	var addresses []string
+	if cfg.Address != "" || (len(cfg.Addresses) == 0 && len(cfg.srvAddresses) == 0) {
+		addresses = append(addresses, cfg.Address)
+	}
+	addresses = append(addresses, cfg.Addresses...)
+	addresses = append(addresses, cfg.srvAddresses...)
+
+	remoteURLs := make([]*url.URL, 0, len(addresses))
+	for _, address := range addresses {
+		// Prepend default fake tcp:// scheme for remote address.
+		if !regexValidScheme.MatchString(address) {
+			address = fmt.Sprintf("tcp://%s", address)
+		}
+
+		remoteURL, err := url.Parse(address)
+		if err != nil {
+			return errors.Wrap(err, "milvus address parse fail")
+		}
+		// Remote Host should never be empty.
+		if remoteURL.Host == "" {
+			return errors.New("empty remote host of milvus address")
+		}
+		// Always enable tls auth for https remote url.
+		if remoteURL.Scheme == "https" {
+			cfg.EnableTLSAuth = true
+		}
+		remoteURLs = append(remoteURLs, remoteURL)
 	}
 	// Use DBName in remote url path.
 	if cfg.DBName == "" {
-		cfg.DBName = strings.TrimLeft(remoteURL.Path, "/")
+		cfg.DBName = strings.TrimLeft(remoteURLs[0].Path, "/")
 	}
-	// Always enable tls auth for https remote url.
-	if remoteURL.Scheme == "https" {
-		cfg.EnableTLSAuth = true
+	for _, remoteURL := range remoteURLs {
+		if remoteURL.Port() == "" && cfg.EnableTLSAuth {
+			remoteURL.Host += ":443"
+		}
 	}
-	if remoteURL.Port() == "" && cfg.EnableTLSAuth {
-		remoteURL.Host += ":443"
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = defaultHealthCheckInterval
 	}
-	cfg.parsedAddress = remoteURL
+	cfg.parsedAddresses = remoteURLs
 	return nil
 }
 
-// Get parsed remote milvus address, should be called after parse was called.
-func (c *ClientConfig) getParsedAddress() string {
-	return c.parsedAddress.Host
+// Get all parsed remote milvus addresses, should be called after parse was called.
+func (c *ClientConfig) getParsedAddresses() []string {
+	addresses := make([]string, 0, len(c.parsedAddresses))
+	for _, remoteURL := range c.parsedAddresses {
+		addresses = append(addresses, remoteURL.Host)
+	}
+	return addresses
 }
 
 // useDatabase change the inner db name.
@@ -0,0 +1,298 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+const healthCheckTimeout = 3 * time.Second
+
+// idempotentMethodPrefixes are the prefixes of the read only methods,
+// which are safe to be retried on another endpoint.
+var idempotentMethodPrefixes = []string{
+	"Describe",
+	"Get",
+	"Has",
+	"List",
+	"Show",
+	"Search",
+	"HybridSearch",
+	"Query",
+	"CheckHealth",
+}
+
+// endpoint is the connection to one of the proxies.
+type endpoint struct {
+	address string
+
+	mut              sync.RWMutex
+	conn             *grpc.ClientConn
+	service          milvuspb.MilvusServiceClient
+	telemetryService milvuspb.ClientTelemetryServiceClient
+	changeStream     changestreampb.ChangeStreamServiceClient
+
+	identifier string // Identifier for this connection, guarded by Client.stateMut
+	healthy    atomic.Bool
+}
+
+func (ep *endpoint) setConn(conn *grpc.ClientConn) {
+	ep.mut.Lock()
+	defer ep.mut.Unlock()
+	ep.conn = conn
+	ep.service = milvuspb.NewMilvusServiceClient(conn)
+	ep.telemetryService = milvuspb.NewClientTelemetryServiceClient(conn)
+	ep.changeStream = changestreampb.NewChangeStreamServiceClient(conn)
+}
+
+func (ep *endpoint) getConn() *grpc.ClientConn {
+	ep.mut.RLock()
+	defer ep.mut.RUnlock()
+	return ep.conn
+}
+
+func (ep *endpoint) getService() (milvuspb.MilvusServiceClient, milvuspb.ClientTelemetryServiceClient) {
+	ep.mut.RLock()
+	defer ep.mut.RUnlock()
+	return ep.service, ep.telemetryService
+}
+
+func (ep *endpoint) getChangeStreamService() changestreampb.ChangeStreamServiceClient {
+	ep.mut.RLock()
+	defer ep.mut.RUnlock()
+	return ep.changeStream
+}
+
+func (ep *endpoint) close() error {
+	ep.mut.Lock()
+	defer ep.mut.Unlock()
+	ep.healthy.Store(false)
+	if ep.conn == nil {
+		return nil
+	}
+	err := ep.conn.Close()
+	ep.conn = nil
+	ep.service = nil
+	ep.telemetryService = nil
+	ep.changeStream = nil
+	return err
+}
+
+func (c *Client) setIdentifier(ep *endpoint, identifier string) {
+	c.stateMut.Lock()
+	defer c.stateMut.Unlock()
+	ep.identifier = identifier
+}
+
+func (c *Client) hasHealthyEndpoint() bool {
+	for _, ep := range c.endpoints {
+		if ep.healthy.Load() {
+			return true
+		}
+	}
+	return false
+}
+
+// pickEndpoint picks the healthy endpoints in round robin,
+// it returns a connected one if none of them is healthy to let the request fail with the actual error.
+func (c *Client) pickEndpoint() *endpoint {
+	n := uint64(len(c.endpoints))
+	if n == 0 {
+		return nil
+	}
+	start := c.nextEndpoint.Add(1) - 1
+	for i := uint64(0); i < n; i++ {
+		ep := c.endpoints[(start+i)%n]
+		if ep.healthy.Load() {
+			return ep
+		}
+	}
+	for i := uint64(0); i < n; i++ {
+		ep := c.endpoints[(start+i)%n]
+		if ep.getConn() != nil {
+			return ep
+		}
+	}
+	return nil
+}
+
+// endpointOf returns the endpoint of the grpc connection.
+func (c *Client) endpointOf(cc *grpc.ClientConn) *endpoint {
+	for _, ep := range c.endpoints {
+		if ep.getConn() == cc {
+			return ep
+		}
+	}
+	return nil
+}
+
+// failoverCandidates returns the endpoints to retry the request on, healthy ones first.
+func (c *Client) failoverCandidates(current *endpoint) []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, ep := range c.endpoints {
+		if ep == current || ep.getConn() == nil {
+			continue
+		}
+		if ep.healthy.Load() {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func isIdempotentMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range idempotentMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEndpointPinned(ctx context.Context) bool {
+	pinned, _ := ctx.Value(pinEndpoint).(bool)
+	return pinned
+}
+
+// FailoverUnaryInterceptor returns the interceptor retrying the read only requests on other endpoints
+// when the endpoint is unavailable. Only the last endpoint tried keeps the retries of the connection,
+// so that the request fails over without waiting for the retry backoff.
+func (c *Client) FailoverUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if len(c.endpoints) < 2 || isEndpointPinned(ctx) || !isIdempotentMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		current := c.endpointOf(cc)
+		candidates := c.failoverCandidates(current)
+		if len(candidates) == 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc_retry.Disable())...)
+		ctx = context.WithValue(ctx, pinEndpoint, true)
+		for i, ep := range candidates {
+			if status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+				return err
+			}
+			if current != nil {
+				current.healthy.Store(false)
+			}
+			conn := ep.getConn()
+			if conn == nil {
+				continue
+			}
+			callOpts := opts
+			if i < len(candidates)-1 {
+				callOpts = append(callOpts, grpc_retry.Disable())
+			}
+			current = ep
+			err = conn.Invoke(ctx, method, req, reply, callOpts...)
+		}
+		return err
+	}
+}
+
+// startHealthCheck checks the health of the endpoints periodically if there are multiple of them,
+// unhealthy endpoints are excluded from balancing until they recover.
+func (c *Client) startHealthCheck() {
+	if len(c.endpoints) < 2 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.healthCheckCancel = cancel
+	c.healthCheckWg.Add(1)
+	go func() {
+		defer c.healthCheckWg.Done()
+		ticker := time.NewTicker(c.config.HealthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.checkEndpoints(ctx)
+			}
+		}
+	}()
+}
+
+func (c *Client) stopHealthCheck() {
+	if c.healthCheckCancel != nil {
+		c.healthCheckCancel()
+		c.healthCheckWg.Wait()
+		c.healthCheckCancel = nil
+	}
+}
+
+func (c *Client) checkEndpoints(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, ep := range c.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			c.checkEndpoint(ctx, ep)
+		}(ep)
+	}
+	wg.Wait()
+}
+
+func (c *Client) checkEndpoint(ctx context.Context, ep *endpoint) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	// reconnect the endpoint failed to dial
+	if ep.getConn() == nil {
+		if err := c.connectEndpoint(ctx, ep, c.dialOpts...); err != nil {
+			ep.close()
+		}
+		return
+	}
+
+	service, _ := ep.getService()
+	resp, err := service.GetVersion(context.WithValue(ctx, pinEndpoint, true), &milvuspb.GetVersionRequest{}, grpc_retry.Disable())
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		ep.healthy.Store(false)
+		return
+	}
+	if ep.healthy.Load() {
+		return
+	}
+	// the proxy may be restarted, connect again to register the client info
+	if !c.config.DisableConn {
+		if err := c.connectInternalEndpoint(ctx, ep); err != nil {
+			return
+		}
+	}
+	ep.healthy.Store(true)
+}
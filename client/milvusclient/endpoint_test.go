@@ -0,0 +1,215 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+)
+
+type mockProxy struct {
+	lis  *bufconn.Listener
+	svr  *grpc.Server
+	mock *MilvusServiceServer
+}
+
+type EndpointSuite struct {
+	suite.Suite
+
+	proxies map[string]*mockProxy
+}
+
+func (s *EndpointSuite) SetupTest() {
+	s.proxies = make(map[string]*mockProxy)
+	for i, addr := range []string{"proxy-a", "proxy-b"} {
+		p := &mockProxy{
+			lis:  bufconn.Listen(bufSize),
+			svr:  grpc.NewServer(),
+			mock: &MilvusServiceServer{},
+		}
+		milvuspb.RegisterMilvusServiceServer(p.svr, p.mock)
+		go p.svr.Serve(p.lis)
+
+		addr, identifier := addr, int64(i+1)
+		p.mock.EXPECT().Connect(mock.Anything, mock.Anything).Return(&milvuspb.ConnectResponse{
+			Status:     &commonpb.Status{},
+			Identifier: identifier,
+		}, nil).Maybe()
+		p.mock.EXPECT().GetVersion(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.GetVersionRequest) (*milvuspb.GetVersionResponse, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			if ids := md.Get(identifierHeader); len(ids) == 0 || ids[0] != fmt.Sprint(identifier) {
+				return nil, fmt.Errorf("unexpected identifier %v", ids)
+			}
+			return &milvuspb.GetVersionResponse{Status: &commonpb.Status{}, Version: addr}, nil
+		}).Maybe()
+		s.proxies[addr] = p
+	}
+}
+
+func (s *EndpointSuite) TearDownTest() {
+	for _, p := range s.proxies {
+		p.svr.Stop()
+		p.lis.Close()
+	}
+}
+
+func (s *EndpointSuite) dialer(ctx context.Context, addr string) (net.Conn, error) {
+	p, ok := s.proxies[addr]
+	if !ok {
+		return nil, fmt.Errorf("unknown address %s", addr)
+	}
+	return p.lis.DialContext(ctx)
+}
+
+func (s *EndpointSuite) newClient(ctx context.Context, addrs ...string) (*Client, error) {
+	return New(ctx, &ClientConfig{
+		Addresses:           addrs,
+		HealthCheckInterval: 50 * time.Millisecond,
+		TelemetryConfig:     &TelemetryConfig{Enabled: false},
+		DialOptions: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(s.dialer),
+		},
+	})
+}
+
+func (s *EndpointSuite) TestBalance() {
+	ctx := context.Background()
+	c, err := s.newClient(ctx, "proxy-a", "proxy-b")
+	s.Require().NoError(err)
+	defer c.Close(ctx)
+
+	versions := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		version, err := c.GetServerVersion(ctx, NewGetServerVersionOption())
+		s.Require().NoError(err)
+		versions[version]++
+	}
+	s.Equal(map[string]int{"proxy-a": 5, "proxy-b": 5}, versions)
+}
+
+func (s *EndpointSuite) TestFailover() {
+	ctx := context.Background()
+	c, err := s.newClient(ctx, "proxy-a", "proxy-b")
+	s.Require().NoError(err)
+	defer c.Close(ctx)
+
+	s.proxies["proxy-a"].svr.Stop()
+	s.proxies["proxy-a"].lis.Close()
+
+	// read requests are retried on the other proxy
+	for i := 0; i < 4; i++ {
+		version, err := c.GetServerVersion(ctx, NewGetServerVersionOption())
+		s.Require().NoError(err)
+		s.Equal("proxy-b", version)
+	}
+
+	s.Eventually(func() bool {
+		return !c.endpoints[0].healthy.Load() && c.endpoints[1].healthy.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	// requests are sent to healthy proxy only
+	s.proxies["proxy-b"].mock.EXPECT().CreateDatabase(mock.Anything, mock.Anything).Return(&commonpb.Status{}, nil).Once()
+	s.NoError(c.CreateDatabase(ctx, NewCreateDatabaseOption("db")))
+}
+
+func (s *EndpointSuite) TestUseDatabase() {
+	ctx := context.Background()
+	c, err := s.newClient(ctx, "proxy-a", "proxy-b")
+	s.Require().NoError(err)
+	defer c.Close(ctx)
+
+	for _, p := range s.proxies {
+		p.mock.EXPECT().ListDatabases(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.ListDatabasesRequest) (*milvuspb.ListDatabasesResponse, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			return &milvuspb.ListDatabasesResponse{Status: &commonpb.Status{}, DbNames: md.Get(databaseHeader)}, nil
+		}).Times(1)
+	}
+
+	s.Require().NoError(c.UseDatabase(ctx, NewUseDatabaseOption("db")))
+	for i := 0; i < 2; i++ {
+		dbNames, err := c.ListDatabase(ctx, NewListDatabaseOption())
+		s.Require().NoError(err)
+		s.Equal([]string{"db"}, dbNames)
+	}
+}
+
+func (s *EndpointSuite) TestPartialConnect() {
+	s.Run("one_unavailable", func() {
+		dialCtx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		c, err := s.newClient(dialCtx, "proxy-a", "proxy-unknown")
+		s.Require().NoError(err)
+		defer c.Close(context.Background())
+
+		for i := 0; i < 4; i++ {
+			version, err := c.GetServerVersion(context.Background(), NewGetServerVersionOption())
+			s.Require().NoError(err)
+			s.Equal("proxy-a", version)
+		}
+	})
+
+	s.Run("all_unavailable", func() {
+		dialCtx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		_, err := s.newClient(dialCtx, "proxy-unknown", "proxy-missing")
+		s.Error(err)
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	suite.Run(t, new(EndpointSuite))
+}
+
+func TestParseAddresses(t *testing.T) {
+	suite.Run(t, new(ParseAddressesSuite))
+}
+
+type ParseAddressesSuite struct {
+	suite.Suite
+}
+
+func (s *ParseAddressesSuite) TestParse() {
+	cfg := &ClientConfig{
+		Address:   "localhost:19530/db",
+		Addresses: []string{"proxy-1:19530", "https://proxy-2"},
+	}
+	s.Require().NoError(cfg.parse())
+	s.Equal([]string{"localhost:19530", "proxy-1:19530", "proxy-2:443"}, cfg.getParsedAddresses())
+	s.Equal("db", cfg.DBName)
+	s.True(cfg.EnableTLSAuth)
+	s.Equal(defaultHealthCheckInterval, cfg.HealthCheckInterval)
+
+	cfg = &ClientConfig{Addresses: []string{"proxy-1:19530", ""}}
+	s.Error(cfg.parse())
+
+	cfg = &ClientConfig{}
+	s.Error(cfg.parse())
+}
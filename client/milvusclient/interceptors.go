@@ -45,7 +45,7 @@ const (
 func (c *Client) MetadataUnaryInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		ctx = c.metadata(ctx)
-		ctx = c.state(ctx)
+		ctx = c.state(ctx, cc)
 		ctx = c.extraInfo(ctx)
 
 		return invoker(ctx, method, req, reply, cc, opts...)
@@ -59,15 +59,18 @@ func (c *Client) metadata(ctx context.Context) context.Context {
 	return ctx
 }
 
-func (c *Client) state(ctx context.Context) context.Context {
+func (c *Client) state(ctx context.Context, cc *grpc.ClientConn) context.Context {
+	// identifier is registered by the proxy the request is sent to
+	ep := c.endpointOf(cc)
+
 	c.stateMut.RLock()
 	defer c.stateMut.RUnlock()
 
 	if c.currentDB != "" {
 		ctx = metadata.AppendToOutgoingContext(ctx, databaseHeader, c.currentDB)
 	}
-	if c.identifier != "" {
-		ctx = metadata.AppendToOutgoingContext(ctx, identifierHeader, c.identifier)
+	if ep != nil && ep.identifier != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, identifierHeader, ep.identifier)
 	}
 
 	return ctx
@@ -84,6 +87,8 @@ type ctxKey int
 
 const (
 	RetryOnRateLimit ctxKey = iota
+	// pinEndpoint makes the request sent to the endpoint without failover
+	pinEndpoint
 )
 
 // RetryOnRateLimitInterceptor returns a new retrying unary client interceptor.
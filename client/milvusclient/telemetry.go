@@ -602,7 +602,15 @@ func (m *ClientTelemetryManager) sendHeartbeat() {
 		return
 	}
 
-	if m.client == nil || m.client.service == nil {
+	if m.client == nil {
+		return
+	}
+	ep := m.client.pickEndpoint()
+	if ep == nil {
+		return
+	}
+	_, telemetryService := ep.getService()
+	if telemetryService == nil {
 		return
 	}
 
@@ -666,7 +674,7 @@ func (m *ClientTelemetryManager) sendHeartbeat() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := m.client.telemetryService.ClientHeartbeat(ctx, req)
+	resp, err := telemetryService.ClientHeartbeat(ctx, req)
 	if err != nil {
 		// Log error but continue - telemetry is best-effort
 		return
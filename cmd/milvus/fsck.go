@@ -0,0 +1,317 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	datacoordserver "github.com/milvus-io/milvus/internal/datacoord"
+	"github.com/milvus-io/milvus/internal/json"
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
+	"github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
+	"github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/internal/storage"
+	fsckutil "github.com/milvus-io/milvus/internal/util/fsck"
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/logutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const (
+	FsckCmd = "fsck"
+)
+
+type fsck struct {
+	params       *paramtable.ComponentParam
+	metaKV       kv.MetaKv
+	metaRootPath string
+	chunkManager storage.ChunkManager
+
+	etcdIP       string
+	etcdRootPath string
+	collectionID int64
+	mode         string
+	reportPath   string
+	orphanMinAge time.Duration
+	concurrency  int
+}
+
+func (c *fsck) execute(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, fsckLine)
+	}
+
+	logutil.SetupLogger(&mlog.Config{
+		Level: "info",
+		File: mlog.FileLogConfig{
+			Filename: fmt.Sprintf("fsck-%s.log", time.Now().Format("20060102150405.99")),
+		},
+	})
+
+	c.formatFlags(args, flags)
+	mode, err := fsckutil.ParseMode(c.mode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		fmt.Fprintln(os.Stderr, fsckLine)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	paramtable.Init()
+	c.params = paramtable.Get()
+	c.metaKV, c.metaRootPath, err = c.connectMetaStore()
+	if err != nil {
+		mlog.Fatal(ctx, "failed to connect to the meta store", mlog.Err(err))
+	}
+	c.chunkManager, err = storage.NewChunkManagerFactoryWithParam(c.params).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		mlog.Fatal(ctx, "failed to connect to the object storage", mlog.Err(err))
+	}
+
+	meta, err := c.loadMeta(ctx)
+	if err != nil {
+		mlog.Fatal(ctx, "failed to load the meta", mlog.Err(err))
+	}
+	checker := fsckutil.NewChecker(&chunkManagerStorage{ChunkManager: c.chunkManager}, meta, fsckutil.Option{
+		Mode:         mode,
+		CollectionID: c.collectionID,
+		OrphanMinAge: c.orphanMinAge,
+		Concurrency:  c.concurrency,
+	})
+	report, err := checker.Run(ctx)
+	if err != nil {
+		mlog.Fatal(ctx, "failed to check the storage", mlog.Err(err))
+	}
+
+	c.printReport(report)
+	if err := c.writeReport(report); err != nil {
+		mlog.Fatal(ctx, "failed to write the report", mlog.String("path", c.reportPath), mlog.Err(err))
+	}
+	if report.HasIssues() {
+		os.Exit(1)
+	}
+}
+
+func (c *fsck) formatFlags(args []string, flags *flag.FlagSet) {
+	flags.StringVar(&c.etcdIP, "etcdIp", "", "Etcd endpoint to connect")
+	flags.StringVar(&c.etcdRootPath, "etcdRootPath", "", "Etcd root path")
+	flags.Int64Var(&c.collectionID, "collectionID", 0, "Only check the collection")
+	flags.StringVar(&c.mode, "mode", string(fsckutil.ModeReport), "report, quarantine or repair")
+	flags.StringVar(&c.reportPath, "report", fmt.Sprintf("fsck-report-%s.json", time.Now().Format("20060102150405")), "Path to write the json report")
+	flags.DurationVar(&c.orphanMinAge, "orphanMinAge", 24*time.Hour, "Ignore the unreferenced objects modified within the duration")
+	flags.IntVar(&c.concurrency, "concurrency", 16, "Max in-flight requests to the object storage")
+
+	if err := flags.Parse(args[2:]); err != nil {
+		mlog.Fatal(context.TODO(), "failed to parse flags", mlog.Err(err))
+	}
+	mlog.Info(context.TODO(), "args", mlog.Strings("args", args))
+}
+
+// connectMetaStore connects to the meta store in the config, tikv is not supported yet.
+// The bolt file is locked exclusively by the running milvus, so it can only be checked after milvus is stopped.
+func (c *fsck) connectMetaStore() (kv.MetaKv, string, error) {
+	switch metaType := c.params.MetaStoreCfg.MetaStoreType.GetValue(); metaType {
+	case util.MetaStoreTypeEtcd:
+		metaKV, rootPath := connectEtcd(c.params, c.etcdIP, c.etcdRootPath)
+		return metaKV, rootPath, nil
+	case util.MetaStoreTypeBolt:
+		// never create an empty meta file by mistake
+		boltPath := c.params.MetaStoreCfg.BoltPath.GetValue()
+		if _, err := os.Stat(boltPath); err != nil {
+			return nil, "", merr.WrapErrIoFailed(boltPath, err)
+		}
+		db, err := boltkv.OpenDBWithConfig(&c.params.MetaStoreCfg)
+		if err != nil {
+			return nil, "", err
+		}
+		rootPath := getConfigValue(c.etcdRootPath, c.params.EtcdCfg.MetaRootPath.GetValue(), "ectd_root_path")
+		return boltkv.NewBoltKV(db, rootPath), rootPath, nil
+	default:
+		return nil, "", merr.WrapErrParameterInvalidMsg("fsck doesn't support the meta store %s", metaType)
+	}
+}
+
+// loadMeta loads the collections from rootcoord and the segments and segment indexes from datacoord.
+func (c *fsck) loadMeta(ctx context.Context) (*fsckutil.Meta, error) {
+	rootCatalog := rootcoord.NewCatalog(c.metaKV)
+	dbs, err := rootCatalog.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	// collections created before database was introduced are stored without db id.
+	dbIDs := []int64{util.NonDBID}
+	for _, db := range dbs {
+		dbIDs = append(dbIDs, db.ID)
+	}
+	collectionIDs := typeutil.NewUniqueSet()
+	for _, dbID := range dbIDs {
+		collections, err := rootCatalog.ListCollections(ctx, dbID, typeutil.MaxTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		for _, collection := range collections {
+			collectionIDs.Insert(collection.CollectionID)
+		}
+	}
+
+	// segments of dropped collections are not reachable from rootcoord, so list them by the datacoord keys.
+	toCheck := typeutil.NewUniqueSet(c.collectionID)
+	if c.collectionID == 0 {
+		toCheck, err = c.listSegmentCollectionIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		toCheck.Insert(collectionIDs.Collect()...)
+	}
+
+	meta := &fsckutil.Meta{CollectionIDs: collectionIDs}
+	dataCatalog := datacoord.NewCatalog(c.metaKV, c.chunkManager.RootPath(), c.metaRootPath)
+	for _, collectionID := range toCheck.Collect() {
+		segments, err := dataCatalog.ListSegments(ctx, collectionID)
+		if err != nil {
+			return nil, err
+		}
+		segIndexes, err := dataCatalog.ListSegmentIndexes(ctx, collectionID)
+		if err != nil {
+			return nil, err
+		}
+		meta.Segments = append(meta.Segments, segments...)
+		meta.SegmentIndexes = append(meta.SegmentIndexes, segIndexes...)
+	}
+	if err := c.loadSnapshots(ctx, dataCatalog, meta); err != nil {
+		return nil, err
+	}
+	mlog.Info(ctx, "meta loaded",
+		mlog.Int("collections", collectionIDs.Len()),
+		mlog.Int("segments", len(meta.Segments)),
+		mlog.Int("segmentIndexes", len(meta.SegmentIndexes)),
+		mlog.Int("snapshotSegments", meta.SnapshotSegmentIDs.Len()),
+		mlog.Int("snapshotBuilds", meta.SnapshotBuildIDs.Len()),
+		mlog.Int("snapshotBlockedCollections", meta.SnapshotBlockedCollectionIDs.Len()))
+	return meta, nil
+}
+
+// loadSnapshots loads the segments and index builds referenced by the snapshots like the datacoord snapshot meta,
+// the snapshots of all the collections are loaded since a snapshot may refer to the segments of another collection.
+// If the references of a snapshot can not be read, its collection is blocked as the garbage collector does.
+func (c *fsck) loadSnapshots(ctx context.Context, catalog *datacoord.Catalog, meta *fsckutil.Meta) error {
+	snapshots, err := catalog.ListSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	meta.SnapshotSegmentIDs = typeutil.NewUniqueSet()
+	meta.SnapshotBuildIDs = typeutil.NewUniqueSet()
+	meta.SnapshotBlockedCollectionIDs = typeutil.NewUniqueSet()
+	reader := datacoordserver.NewSnapshotReader(c.chunkManager)
+	for _, snapshot := range snapshots {
+		// the pending and deleting snapshots are not protected, they are cleaned by the garbage collector.
+		if snapshot.GetState() == datapb.SnapshotState_SnapshotStatePending ||
+			snapshot.GetState() == datapb.SnapshotState_SnapshotStateDeleting {
+			continue
+		}
+		data, err := reader.ReadSnapshot(ctx, snapshot.GetS3Location(), false)
+		if err != nil {
+			mlog.Warn(ctx, "failed to read the snapshot, block its collection",
+				mlog.Int64("collectionID", snapshot.GetCollectionId()),
+				mlog.String("name", snapshot.GetName()),
+				mlog.Err(err))
+			meta.SnapshotBlockedCollectionIDs.Insert(snapshot.GetCollectionId())
+			continue
+		}
+		meta.SnapshotSegmentIDs.Insert(data.SegmentIDs...)
+		meta.SnapshotBuildIDs.Insert(data.BuildIDs...)
+	}
+	return nil
+}
+
+// listSegmentCollectionIDs parses the collection ids of datacoord-meta/s/{collectionID}/{partitionID}/{segmentID}.
+func (c *fsck) listSegmentCollectionIDs(ctx context.Context) (typeutil.UniqueSet, error) {
+	prefix := datacoord.SegmentPrefix + "/"
+	collectionIDs := typeutil.NewUniqueSet()
+	err := c.metaKV.WalkWithPrefix(ctx, prefix, c.params.MetaStoreCfg.PaginationSize.GetAsInt(), func(key []byte, value []byte) error {
+		_, suffix, _ := strings.Cut(string(key), prefix)
+		collectionID, err := strconv.ParseInt(strings.Split(suffix, "/")[0], 10, 64)
+		if err != nil {
+			mlog.Warn(ctx, "skip unknown segment key", mlog.String("key", string(key)))
+			return nil
+		}
+		collectionIDs.Insert(collectionID)
+		return nil
+	})
+	return collectionIDs, err
+}
+
+func (c *fsck) printReport(report *fsckutil.Report) {
+	line()
+	fmt.Printf("Root path: %s, mode: %s\n", report.RootPath, report.Mode)
+	fmt.Printf("Checked segments: %d, skipped segments: %d, checked segment indexes: %d\n",
+		report.Summary.CheckedSegments, report.Summary.SkippedSegments, report.Summary.CheckedSegmentIndexes)
+	fmt.Printf("Checked files: %d, scanned objects: %d\n", report.Summary.CheckedFiles, report.Summary.ScannedObjects)
+	line()
+	for _, missing := range report.MissingFiles {
+		fmt.Printf("Missing %s of segment %d: %s\n", missing.Kind, missing.SegmentID, missing.Path)
+	}
+	for _, mismatch := range report.SizeMismatches {
+		fmt.Printf("Size mismatch of %s: expected %d, actual %d\n", mismatch.Path, mismatch.ExpectedSize, mismatch.ActualSize)
+	}
+	for _, orphan := range report.OrphanObjects {
+		fmt.Printf("Orphan %s (%s): %s\n", orphan.Kind, orphan.Reason, orphan.Path)
+	}
+	for _, segment := range report.UnreachableSegments {
+		fmt.Printf("Unreachable segment %d of collection %d\n", segment.SegmentID, segment.CollectionID)
+	}
+	line2()
+	fmt.Printf("Missing files: %d, size mismatches: %d, orphan objects: %d, unreachable segments: %d\n",
+		report.Summary.MissingFiles, report.Summary.SizeMismatches, report.Summary.OrphanObjects, report.Summary.UnreachableSegments)
+	if report.Mode != fsckutil.ModeReport {
+		fmt.Printf("Quarantined objects: %d, restored files: %d\n", report.Summary.QuarantinedObjects, report.Summary.RestoredFiles)
+	}
+}
+
+func (c *fsck) writeReport(report *fsckutil.Report) error {
+	bytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.reportPath, bytes, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Report is written to %s\n", c.reportPath)
+	return nil
+}
+
+// chunkManagerStorage adapts the chunk manager to fsckutil.ObjectStorage.
+type chunkManagerStorage struct {
+	storage.ChunkManager
+}
+
+func (s *chunkManagerStorage) WalkWithPrefix(ctx context.Context, prefix string, walkFunc func(filePath string, modifyTime time.Time) bool) error {
+	return s.ChunkManager.WalkWithPrefix(ctx, prefix, true, func(info *storage.ChunkObjectInfo) bool {
+		return walkFunc(info.FilePath, info.ModifyTime)
+	})
+}
@@ -13,7 +13,7 @@ const (
 
 var (
 	usageLine = fmt.Sprintf("Usage:\n"+
-		"%s\n%s\n%s\n%s\n", runLine, stopLine, mckLine, serverTypeLine)
+		"%s\n%s\n%s\n%s\n%s\n", runLine, stopLine, mckLine, fsckLine, serverTypeLine)
 
 	serverTypeLine = `
 [server type]
@@ -64,5 +64,30 @@ milvus mck run [flags]
 milvus mck cleanTrash [flags]
 	Clean the back inconsistent data
 	Tips: The flags is the same as its of the 'milvus mck [flags]'
+`
+	fsckLine = `
+milvus fsck [flags]
+	Check the datacoord meta against the object storage offline, report the missing files,
+	the size mismatches, the orphan objects and the segments unreachable from any collection.
+	Tips: The flags are optional, exit with 1 if any inconsistency is found.
+	The objects referenced by snapshots are never orphans.
+	The meta store could be etcd or bolt, milvus must be stopped to check the bolt file.
+[flags]
+	-etcdIp ''
+		Ip to connect the etcd server.
+	-etcdRootPath ''
+		The root path of operating the etcd data.
+	-collectionID 0
+		Only check the collection, 0 means all collections.
+	-mode 'report'
+		report: only report the inconsistencies.
+		quarantine: move the orphan objects under 'fsck_quarantine' of the storage root path.
+		repair: move the missing files back from 'fsck_quarantine' if they are there.
+	-report 'fsck-report-{time}.json'
+		Path to write the json report.
+	-orphanMinAge '24h'
+		Ignore the unreferenced objects modified within the duration, they may be being written.
+	-concurrency 16
+		Max in-flight requests to the object storage.
 `
 )
@@ -210,32 +210,38 @@ func (c *mck) formatFlags(args []string, flags *flag.FlagSet) {
 
 func (c *mck) connectEctd() {
 	c.params.Init(paramtable.NewBaseTable())
+	c.metaKV, _ = connectEtcd(c.params, c.etcdIP, c.ectdRootPath)
+}
+
+// connectEtcd connects to the etcd of @etcdIP, or the one in the config if it's empty,
+// and returns the meta kv and its root path.
+func connectEtcd(params *paramtable.ComponentParam, etcdIP string, etcdRootPath string) (kv.MetaKv, string) {
 	var etcdCli *clientv3.Client
 	var err error
 
-	if c.etcdIP != "" {
-		etcdCli, err = etcd.GetRemoteEtcdClient([]string{c.etcdIP}, c.params.EtcdCfg.ClientOptions()...)
+	if etcdIP != "" {
+		etcdCli, err = etcd.GetRemoteEtcdClient([]string{etcdIP}, params.EtcdCfg.ClientOptions()...)
 	} else {
 		etcdCli, err = etcd.CreateEtcdClient(
-			c.params.EtcdCfg.UseEmbedEtcd.GetAsBool(),
-			c.params.EtcdCfg.EtcdEnableAuth.GetAsBool(),
-			c.params.EtcdCfg.EtcdAuthUserName.GetValue(),
-			c.params.EtcdCfg.EtcdAuthPassword.GetValue(),
-			c.params.EtcdCfg.EtcdUseSSL.GetAsBool(),
-			c.params.EtcdCfg.Endpoints.GetAsStrings(),
-			c.params.EtcdCfg.EtcdTLSCert.GetValue(),
-			c.params.EtcdCfg.EtcdTLSKey.GetValue(),
-			c.params.EtcdCfg.EtcdTLSCACert.GetValue(),
-			c.params.EtcdCfg.EtcdTLSMinVersion.GetValue(),
-			c.params.EtcdCfg.ClientOptions()...)
+			params.EtcdCfg.UseEmbedEtcd.GetAsBool(),
+			params.EtcdCfg.EtcdEnableAuth.GetAsBool(),
+			params.EtcdCfg.EtcdAuthUserName.GetValue(),
+			params.EtcdCfg.EtcdAuthPassword.GetValue(),
+			params.EtcdCfg.EtcdUseSSL.GetAsBool(),
+			params.EtcdCfg.Endpoints.GetAsStrings(),
+			params.EtcdCfg.EtcdTLSCert.GetValue(),
+			params.EtcdCfg.EtcdTLSKey.GetValue(),
+			params.EtcdCfg.EtcdTLSCACert.GetValue(),
+			params.EtcdCfg.EtcdTLSMinVersion.GetValue(),
+			params.EtcdCfg.ClientOptions()...)
 	}
 	if err != nil {
 		mlog.Fatal(context.TODO(), "failed to connect to etcd", mlog.Err(err))
 	}
 
-	rootPath := getConfigValue(c.ectdRootPath, c.params.EtcdCfg.MetaRootPath.GetValue(), "ectd_root_path")
-	c.metaKV = etcdkv.NewEtcdKV(etcdCli, rootPath)
+	rootPath := getConfigValue(etcdRootPath, params.EtcdCfg.MetaRootPath.GetValue(), "ectd_root_path")
 	mlog.Info(context.TODO(), "Etcd root path", mlog.String("root_path", rootPath))
+	return etcdkv.NewEtcdKV(etcdCli, rootPath), rootPath
 }
 
 func (c *mck) connectMinio() {
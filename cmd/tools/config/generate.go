@@ -233,12 +233,13 @@ func WriteYaml(w io.Writer) {
 		{
 			name: "mq",
 			header: `
-# Milvus supports four message queues (MQ): rocksmq (based on RocksDB), Pulsar, Kafka, and Woodpecker.
+# Milvus supports five message queues (MQ): rocksmq (based on RocksDB), Pulsar, Kafka, Woodpecker and localfs (pure-go, based on local files).
 # You can change the MQ by setting the mq.type field.
 # If the mq.type field is not set, the following priority is used when multiple MQs are configured in this file:
 # 1. standalone (local) mode: rocksmq (default) > Pulsar > Kafka > Woodpecker
 # 2. cluster mode: Pulsar (default) > Kafka (rocksmq is unsupported in cluster mode) > Woodpecker
-# Note: These MQ priorities are compatible with existing instances. For new instances, it is recommended to explicitly use Woodpecker to achieve better performance, operational simplicity, and cost efficiency.`,
+# Note: These MQ priorities are compatible with existing instances. For new instances, it is recommended to explicitly use Woodpecker to achieve better performance, operational simplicity, and cost efficiency.
+# localfs is never selected by default, set mq.type to localfs to use it in standalone mode.`,
 		},
 		{
 			name: "woodpecker",
@@ -258,6 +259,10 @@ func WriteYaml(w io.Writer) {
 		{
 			name: "rocksmq",
 		},
+		{
+			name:   "localfs",
+			header: "\n# Related configuration of localfs, a pure-go wal which stores the messages of each pchannel as append-only segment files on local disk, only available in standalone mode.",
+		},
 		{
 			name:   "mixCoord",
 			header: "\n# Related configuration of mixCoord",
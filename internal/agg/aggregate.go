@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
@@ -17,23 +18,38 @@ const (
 	kAvg   = "avg"
 	kMin   = "min"
 	kMax   = "max"
+
+	kCountDistinct       = "count_distinct"
+	kApproxCountDistinct = "approx_count_distinct"
+
+	kVariance   = "variance"
+	kStddev     = "stddev"
+	kPercentile = "percentile"
+	kMedian     = "median"
 )
 
 var (
 	// Define the regular expression pattern once to avoid repeated concatenation.
-	aggregationTypes   = kSum + `|` + kCount + `|` + kAvg + `|` + kMin + `|` + kMax
-	aggregationPattern = regexp.MustCompile(`(?i)^(` + aggregationTypes + `)\s*\(\s*([\w\*]*)\s*\)$`)
+	aggregationTypes = kApproxCountDistinct + `|` + kSum + `|` + kCount + `|` + kAvg + `|` + kMin + `|` + kMax +
+		`|` + kVariance + `|` + kStddev + `|` + kPercentile + `|` + kMedian
+	aggregationPattern = regexp.MustCompile(`(?i)^(` + aggregationTypes + `)\s*\(\s*(distinct\s+)?([\w\*]*)\s*(?:,\s*([^,\s\)]+)\s*)?\)$`)
 )
 
-// MatchAggregationExpression return isAgg, operator name, operator parameter
-func MatchAggregationExpression(expression string) (bool, string, string) {
+// MatchAggregationExpression return isAgg, operator name, operator parameter and the optional argument,
+// e.g. percentile(a, 0.95) returns the argument 0.95.
+// The DISTINCT modifier is folded into the operator name, e.g. count(distinct a) returns count_distinct.
+func MatchAggregationExpression(expression string) (bool, string, string, string) {
 	// FindStringSubmatch returns the full match and submatches.
 	matches := aggregationPattern.FindStringSubmatch(expression)
 	if len(matches) > 0 {
 		// Return true, the operator, and the captured parameter.
-		return true, strings.ToLower(matches[1]), strings.TrimSpace(matches[2])
+		op := strings.ToLower(matches[1])
+		if matches[2] != "" {
+			op += "_distinct"
+		}
+		return true, op, strings.TrimSpace(matches[3]), matches[4]
 	}
-	return false, "", ""
+	return false, "", "", ""
 }
 
 type AggregateBase interface {
@@ -49,7 +65,8 @@ type AggregateBase interface {
 
 func isSupportedAggregateName(aggregateName string) bool {
 	switch aggregateName {
-	case kCount, kSum, kAvg, kMin, kMax:
+	case kCount, kSum, kAvg, kMin, kMax, kCountDistinct, kApproxCountDistinct,
+		kVariance, kStddev, kPercentile, kMedian:
 		return true
 	default:
 		return false
@@ -57,6 +74,12 @@ func isSupportedAggregateName(aggregateName string) bool {
 }
 
 func NewAggregate(aggregateName string, aggFieldID int64, originalName string, fieldType schemapb.DataType) ([]AggregateBase, error) {
+	return NewAggregateWithArg(aggregateName, aggFieldID, originalName, fieldType, "")
+}
+
+// NewAggregateWithArg creates the aggregate with the optional argument returned by MatchAggregationExpression,
+// only percentile takes an argument, which is the rank in [0, 1].
+func NewAggregateWithArg(aggregateName string, aggFieldID int64, originalName string, fieldType schemapb.DataType, arg string) ([]AggregateBase, error) {
 	if !isSupportedAggregateName(aggregateName) {
 		return nil, merr.WrapErrParameterInvalidMsg("invalid Aggregation operator %s", aggregateName)
 	}
@@ -65,6 +88,17 @@ func NewAggregate(aggregateName string, aggFieldID int64, originalName string, f
 		return nil, err
 	}
 
+	if aggregateName == kPercentile {
+		percentile, err := strconv.ParseFloat(arg, 64)
+		if err != nil || percentile < 0 || percentile > 1 {
+			return nil, merr.WrapErrParameterInvalidMsg("percentile expects a rank in [0, 1], got '%s'", arg)
+		}
+		return []AggregateBase{&PercentileAggregate{fieldID: aggFieldID, originalName: originalName, percentile: percentile}}, nil
+	}
+	if arg != "" {
+		return nil, merr.WrapErrParameterInvalidMsg("aggregation %s does not take argument %s", aggregateName, arg)
+	}
+
 	switch aggregateName {
 	case kCount:
 		return []AggregateBase{&CountAggregate{fieldID: aggFieldID, originalName: originalName, isAvg: false}}, nil
@@ -77,6 +111,16 @@ func NewAggregate(aggregateName string, aggFieldID int64, originalName string, f
 		return []AggregateBase{&MinAggregate{fieldID: aggFieldID, originalName: originalName}}, nil
 	case kMax:
 		return []AggregateBase{&MaxAggregate{fieldID: aggFieldID, originalName: originalName}}, nil
+	case kCountDistinct:
+		return []AggregateBase{&CountDistinctAggregate{fieldID: aggFieldID, originalName: originalName, approx: false}}, nil
+	case kApproxCountDistinct:
+		return []AggregateBase{&CountDistinctAggregate{fieldID: aggFieldID, originalName: originalName, approx: true}}, nil
+	case kVariance:
+		return []AggregateBase{&VarianceAggregate{fieldID: aggFieldID, originalName: originalName, stddev: false}}, nil
+	case kStddev:
+		return []AggregateBase{&VarianceAggregate{fieldID: aggFieldID, originalName: originalName, stddev: true}}, nil
+	case kMedian:
+		return []AggregateBase{&PercentileAggregate{fieldID: aggFieldID, originalName: originalName, percentile: 0.5}}, nil
 	default:
 		// should never happen due to isSupportedAggregateName check
 		return nil, merr.WrapErrParameterInvalidMsg("invalid Aggregation operator %s", aggregateName)
@@ -93,6 +137,16 @@ func FromPB(pb *planpb.Aggregate) (AggregateBase, error) {
 		return &MinAggregate{fieldID: pb.GetFieldId()}, nil
 	case planpb.AggregateOp_max:
 		return &MaxAggregate{fieldID: pb.GetFieldId()}, nil
+	case planpb.AggregateOp_count_distinct:
+		return &CountDistinctAggregate{fieldID: pb.GetFieldId(), approx: false}, nil
+	case planpb.AggregateOp_approx_count_distinct:
+		return &CountDistinctAggregate{fieldID: pb.GetFieldId(), approx: true}, nil
+	case planpb.AggregateOp_variance:
+		return &VarianceAggregate{fieldID: pb.GetFieldId(), stddev: false}, nil
+	case planpb.AggregateOp_stddev:
+		return &VarianceAggregate{fieldID: pb.GetFieldId(), stddev: true}, nil
+	case planpb.AggregateOp_percentile:
+		return &PercentileAggregate{fieldID: pb.GetFieldId(), percentile: pb.GetPercentile()}, nil
 	default:
 		return nil, merr.WrapErrParameterInvalidMsg("invalid Aggregation operator %d", pb.Op)
 	}
@@ -157,6 +157,17 @@ func genEmptyFieldDataByType(dataType schemapb.DataType) (*schemapb.FieldData, e
 		}, nil
 	case schemapb.DataType_Timestamptz:
 		return genEmptyLongFieldData(dataType, []int64{0}), nil
+	case aggStateType:
+		// a null state is finalized as the empty state
+		return &schemapb.FieldData{
+			Type: dataType,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: [][]byte{nil}}},
+				},
+			},
+			ValidData: []bool{false},
+		}, nil
 	default:
 		// For other types, try to use the original field's GenEmptyFieldData
 		return nil, merr.WrapErrParameterInvalidMsg("unsupported data type for aggregate result: %s", dataType.String())
@@ -176,6 +187,12 @@ func getAggregateResultType(op planpb.AggregateOp, inputType schemapb.DataType)
 	case planpb.AggregateOp_min, planpb.AggregateOp_max:
 		// min/max keep the original field type
 		return inputType, nil
+	case planpb.AggregateOp_count_distinct, planpb.AggregateOp_approx_count_distinct:
+		// distinct counts are reduced as serialized distinct states
+		return aggStateType, nil
+	case planpb.AggregateOp_variance, planpb.AggregateOp_stddev, planpb.AggregateOp_percentile:
+		// statistical aggregations are reduced as serialized moments states or t-digests
+		return aggStateType, nil
 	case planpb.AggregateOp_sum:
 		// sum returns Int64 for integer types, Double for float types
 		switch inputType {
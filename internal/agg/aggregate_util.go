@@ -28,6 +28,8 @@ func NewFieldAccessor(fieldType schemapb.DataType) (FieldAccessor, error) {
 		return newFloat32FieldAccessor(), nil
 	case schemapb.DataType_Double:
 		return newFloat64FieldAccessor(), nil
+	case aggStateType:
+		return newAggStateFieldAccessor(), nil
 	default:
 		return nil, merr.WrapErrParameterInvalidMsg("unsupported data type for hasher")
 	}
@@ -367,6 +369,49 @@ func newStringFieldAccessor() FieldAccessor {
 	return &StringFieldAccessor{hasher: fnv.New64a()}
 }
 
+// AggStateFieldAccessor reads the serialized aggregation states, it's only used as accumulator.
+type AggStateFieldAccessor struct {
+	vals      [][]byte
+	validData []bool
+	hasher    hash.Hash64
+}
+
+func (stateField *AggStateFieldAccessor) Hash(idx int) uint64 {
+	if idx < 0 || idx >= len(stateField.vals) {
+		panic(fmt.Sprintf("AggStateFieldAccessor.Hash: index %d out of range [0,%d)", idx, len(stateField.vals)))
+	}
+	if stateField.IsNullAt(idx) {
+		return nullHashValue
+	}
+	stateField.hasher.Reset()
+	stateField.hasher.Write(stateField.vals[idx])
+	return stateField.hasher.Sum64()
+}
+
+func (stateField *AggStateFieldAccessor) SetVals(fieldData *schemapb.FieldData) {
+	stateField.vals = fieldData.GetScalars().GetJsonData().GetData()
+	stateField.validData = fieldData.GetValidData()
+}
+
+func (stateField *AggStateFieldAccessor) RowCount() int {
+	return len(stateField.vals)
+}
+
+func (stateField *AggStateFieldAccessor) ValAt(idx int) interface{} {
+	return stateField.vals[idx]
+}
+
+func (stateField *AggStateFieldAccessor) IsNullAt(idx int) bool {
+	if len(stateField.validData) > 0 && !stateField.validData[idx] {
+		return true
+	}
+	return len(stateField.vals[idx]) == 0
+}
+
+func newAggStateFieldAccessor() FieldAccessor {
+	return &AggStateFieldAccessor{hasher: fnv.New64a()}
+}
+
 func AssembleBucket(bucket *Bucket, fieldDatas []*schemapb.FieldData) error {
 	colCount := len(fieldDatas)
 	for r := 0; r < bucket.RowCount(); r++ {
@@ -410,6 +455,8 @@ func AssembleSingleValue(fv *FieldValue, fieldData *schemapb.FieldData) error {
 			fieldData.GetScalars().GetDoubleData().Data = append(fieldData.GetScalars().GetDoubleData().GetData(), 0)
 		case schemapb.DataType_VarChar, schemapb.DataType_String:
 			fieldData.GetScalars().GetStringData().Data = append(fieldData.GetScalars().GetStringData().GetData(), "")
+		case aggStateType:
+			fieldData.GetScalars().GetJsonData().Data = append(fieldData.GetScalars().GetJsonData().GetData(), nil)
 		default:
 			return merr.WrapErrParameterInvalidMsg("unsupported DataType:%d", fieldData.GetType())
 		}
@@ -461,6 +508,17 @@ func AssembleSingleValue(fv *FieldValue, fieldData *schemapb.FieldData) error {
 			return merr.WrapErrServiceInternalMsg("type assertion failed: expected string, got %T", val)
 		}
 		fieldData.GetScalars().GetStringData().Data = append(fieldData.GetScalars().GetStringData().GetData(), stringVal)
+	case aggStateType:
+		var stateVal []byte
+		switch state := val.(type) {
+		case []byte:
+			stateVal = state
+		case aggState:
+			stateVal = state.marshal()
+		default:
+			return merr.WrapErrServiceInternalMsg("type assertion failed: expected aggregation state, got %T", val)
+		}
+		fieldData.GetScalars().GetJsonData().Data = append(fieldData.GetScalars().GetJsonData().GetData(), stateVal)
 	default:
 		return merr.WrapErrParameterInvalidMsg("unsupported DataType:%d", fieldData.GetType())
 	}
@@ -469,7 +527,8 @@ func AssembleSingleValue(fv *FieldValue, fieldData *schemapb.FieldData) error {
 
 type AggregationFieldMap struct {
 	userOriginalOutputFields     []string
-	userOriginalOutputFieldIdxes [][]int // Each user output field can map to multiple field indices (e.g., avg maps to sum and count)
+	userOriginalOutputFieldIdxes [][]int         // Each user output field can map to multiple field indices (e.g., avg maps to sum and count)
+	userOriginalOutputStates     []AggregateBase // The state aggregation of the user output field whose states must be finalized, nil if it's not one
 }
 
 func (aggMap *AggregationFieldMap) Count() int {
@@ -497,6 +556,20 @@ func (aggMap *AggregationFieldMap) NameAt(idx int) string {
 	return aggMap.userOriginalOutputFields[idx]
 }
 
+// IsStateAt returns whether the given user output field is a state aggregation, e.g. count(distinct)
+// and percentile, whose reduced states must be finalized by FinalizeStateAt.
+func (aggMap *AggregationFieldMap) IsStateAt(idx int) bool {
+	return aggMap.userOriginalOutputStates[idx] != nil
+}
+
+// FinalizeStateAt turns the reduced states of the given user output field into the user visible values.
+func (aggMap *AggregationFieldMap) FinalizeStateAt(idx int, stateFieldData *schemapb.FieldData) (*schemapb.FieldData, error) {
+	if !aggMap.IsStateAt(idx) {
+		return nil, merr.WrapErrServiceInternalMsg("output field %s is not a state aggregation", aggMap.NameAt(idx))
+	}
+	return FinalizeAggState(aggMap.userOriginalOutputStates[idx], stateFieldData)
+}
+
 func NewAggregationFieldMap(originalUserOutputFields []string, groupByFields []string, aggs []AggregateBase) (*AggregationFieldMap, error) {
 	numGroupingKeys := len(groupByFields)
 
@@ -507,6 +580,7 @@ func NewAggregationFieldMap(originalUserOutputFields []string, groupByFields []s
 
 	// Build a map from originalName to all indices (for avg, this will include both sum and count indices)
 	aggFieldMap := make(map[string][]int, len(aggs))
+	stateFieldMap := make(map[string]AggregateBase)
 	for i, agg := range aggs {
 		originalName := agg.OriginalName()
 		idx := i + numGroupingKeys
@@ -518,6 +592,8 @@ func NewAggregationFieldMap(originalUserOutputFields []string, groupByFields []s
 			isAvg = a.isAvg
 		case *CountAggregate:
 			isAvg = a.isAvg
+		case stateAggregate:
+			stateFieldMap[originalName] = agg
 		}
 
 		if isAvg {
@@ -531,6 +607,7 @@ func NewAggregationFieldMap(originalUserOutputFields []string, groupByFields []s
 	}
 
 	userOriginalOutputFieldIdxes := make([][]int, len(originalUserOutputFields))
+	userOriginalOutputStates := make([]AggregateBase, len(originalUserOutputFields))
 	for i, outputField := range originalUserOutputFields {
 		if idx, exist := groupByFieldMap[outputField]; exist {
 			// Group by field maps to a single index
@@ -538,6 +615,7 @@ func NewAggregationFieldMap(originalUserOutputFields []string, groupByFields []s
 		} else if indices, exist := aggFieldMap[outputField]; exist {
 			// Aggregate field may map to multiple indices (for avg: sum and count)
 			userOriginalOutputFieldIdxes[i] = indices
+			userOriginalOutputStates[i] = stateFieldMap[outputField]
 		} else {
 			// Field is neither a group_by field nor an aggregation — reject early.
 			// This covers two cases:
@@ -559,7 +637,7 @@ func NewAggregationFieldMap(originalUserOutputFields []string, groupByFields []s
 		}
 	}
 
-	return &AggregationFieldMap{originalUserOutputFields, userOriginalOutputFieldIdxes}, nil
+	return &AggregationFieldMap{originalUserOutputFields, userOriginalOutputFieldIdxes, userOriginalOutputStates}, nil
 }
 
 // ComputeAvgFromSumAndCount computes average from sum and count field data.
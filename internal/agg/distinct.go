@@ -0,0 +1,230 @@
+package agg
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/spaolacci/murmur3"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// distinctState is the accumulator of distinct count aggregations.
+type distinctState interface {
+	aggState
+	// count returns the (estimated) count of distinct values.
+	count() int64
+}
+
+// distinctKey returns the canonical key of a raw field value, the same value
+// always gets the same key on every node so the keys and the hashes of them can be merged.
+func distinctKey(v any) (string, error) {
+	var buf [8]byte
+	switch value := v.(type) {
+	case bool:
+		if value {
+			return "\x01", nil
+		}
+		return "\x00", nil
+	case int32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(value))
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(value))
+	case float32:
+		binary.LittleEndian.PutUint64(buf[:], canonicalFloatBits(float64(value)))
+	case float64:
+		binary.LittleEndian.PutUint64(buf[:], canonicalFloatBits(value))
+	case string:
+		return value, nil
+	default:
+		return "", merr.WrapErrParameterInvalidMsg("unsupported type for distinct count aggregation: %T", v)
+	}
+	return string(buf[:]), nil
+}
+
+// canonicalFloatBits folds -0 into 0 and all NaNs into one NaN.
+func canonicalFloatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	if math.IsNaN(f) {
+		return math.Float64bits(math.NaN())
+	}
+	return math.Float64bits(f)
+}
+
+// exactDistinctState keeps every distinct value, it's bounded by common.countDistinct.maxValues.
+type exactDistinctState struct {
+	keys      map[string]struct{}
+	maxValues int64
+}
+
+func newExactDistinctState() *exactDistinctState {
+	return &exactDistinctState{
+		keys:      make(map[string]struct{}),
+		maxValues: paramtable.Get().CommonCfg.CountDistinctMaxValues.GetAsInt64(),
+	}
+}
+
+func (s *exactDistinctState) add(v any) error {
+	key, err := distinctKey(v)
+	if err != nil {
+		return err
+	}
+	return s.addKey(key)
+}
+
+func (s *exactDistinctState) addKey(key string) error {
+	s.keys[key] = struct{}{}
+	if int64(len(s.keys)) > s.maxValues {
+		return merr.WrapErrParameterInvalidMsg("count(distinct) tracked too many distinct values (%d). "+
+			"Use approx_count_distinct or increase common.countDistinct.maxValues (current: %d)",
+			len(s.keys), s.maxValues)
+	}
+	return nil
+}
+
+func (s *exactDistinctState) merge(other aggState) error {
+	o, ok := other.(*exactDistinctState)
+	if !ok {
+		return merr.WrapErrServiceInternalMsg("cannot merge %T into exact distinct state", other)
+	}
+	for key := range o.keys {
+		if err := s.addKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *exactDistinctState) count() int64 {
+	return int64(len(s.keys))
+}
+
+// marshal encodes the state as |kind|count uvarint|(len uvarint|key)...|.
+func (s *exactDistinctState) marshal() []byte {
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64)
+	buf = append(buf, distinctStateExact)
+	buf = binary.AppendUvarint(buf, uint64(len(s.keys)))
+	for key := range s.keys {
+		buf = binary.AppendUvarint(buf, uint64(len(key)))
+		buf = append(buf, key...)
+	}
+	return buf
+}
+
+func unmarshalExactDistinctState(data []byte) (*exactDistinctState, error) {
+	s := newExactDistinctState()
+	data = data[1:]
+	n, read := binary.Uvarint(data)
+	if read <= 0 {
+		return nil, merr.WrapErrServiceInternalMsg("invalid exact distinct state")
+	}
+	data = data[read:]
+	for i := uint64(0); i < n; i++ {
+		l, read := binary.Uvarint(data)
+		if read <= 0 || uint64(len(data)-read) < l {
+			return nil, merr.WrapErrServiceInternalMsg("invalid exact distinct state, truncated at value %d", i)
+		}
+		data = data[read:]
+		if err := s.addKey(string(data[:l])); err != nil {
+			return nil, err
+		}
+		data = data[l:]
+	}
+	return s, nil
+}
+
+func (s *hllSketch) add(v any) error {
+	key, err := distinctKey(v)
+	if err != nil {
+		return err
+	}
+	s.addHash(murmur3.Sum64([]byte(key)))
+	return nil
+}
+
+func (s *hllSketch) merge(other aggState) error {
+	o, ok := other.(*hllSketch)
+	if !ok {
+		return merr.WrapErrServiceInternalMsg("cannot merge %T into hll sketch", other)
+	}
+	s.mergeSketch(o)
+	return nil
+}
+
+func (s *hllSketch) count() int64 {
+	return s.estimate()
+}
+
+func newDistinctState(approx bool) distinctState {
+	if approx {
+		return newHLLSketch()
+	}
+	return newExactDistinctState()
+}
+
+type CountDistinctAggregate struct {
+	fieldID      int64
+	originalName string
+	approx       bool
+}
+
+func (cd *CountDistinctAggregate) Name() string {
+	if cd.approx {
+		return kApproxCountDistinct
+	}
+	return kCountDistinct
+}
+
+// Update merges the partial distinct state in new into target.
+func (cd *CountDistinctAggregate) Update(target *FieldValue, new *FieldValue) error {
+	return mergeAggState(target, new)
+}
+
+func (cd *CountDistinctAggregate) NewState() []*FieldValue {
+	return newSingleSlotState()
+}
+
+// UpdateState adds a raw field value into the distinct state.
+func (cd *CountDistinctAggregate) UpdateState(slots []*FieldValue, new *FieldValue) error {
+	return addToAggState(cd, slots, new)
+}
+
+func (cd *CountDistinctAggregate) Terminate(slots []*FieldValue) (any, error) {
+	return terminateAggState(cd, slots)
+}
+
+func (cd *CountDistinctAggregate) newAggState() aggState {
+	return newDistinctState(cd.approx)
+}
+
+func (cd *CountDistinctAggregate) finalize(state aggState) (any, error) {
+	ds, ok := state.(distinctState)
+	if !ok {
+		return nil, merr.WrapErrServiceInternalMsg("unexpected distinct state %T", state)
+	}
+	return ds.count(), nil
+}
+
+func (cd *CountDistinctAggregate) resultType() schemapb.DataType {
+	return schemapb.DataType_Int64
+}
+
+func (cd *CountDistinctAggregate) ToPB() *planpb.Aggregate {
+	if cd.approx {
+		return &planpb.Aggregate{Op: planpb.AggregateOp_approx_count_distinct, FieldId: cd.FieldID()}
+	}
+	return &planpb.Aggregate{Op: planpb.AggregateOp_count_distinct, FieldId: cd.FieldID()}
+}
+
+func (cd *CountDistinctAggregate) FieldID() int64 {
+	return cd.fieldID
+}
+
+func (cd *CountDistinctAggregate) OriginalName() string {
+	return cd.originalName
+}
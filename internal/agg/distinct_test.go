@@ -0,0 +1,256 @@
+package agg
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestMatchAggregationExpressionDistinct(t *testing.T) {
+	tests := []struct {
+		expr  string
+		op    string
+		param string
+	}{
+		{expr: "count(distinct a)", op: kCountDistinct, param: "a"},
+		{expr: "COUNT( DISTINCT  a )", op: kCountDistinct, param: "a"},
+		{expr: "approx_count_distinct(a)", op: kApproxCountDistinct, param: "a"},
+		{expr: "count(a)", op: kCount, param: "a"},
+		{expr: "sum(distinct a)", op: "sum_distinct", param: "a"},
+	}
+	for _, test := range tests {
+		isAgg, op, param, arg := MatchAggregationExpression(test.expr)
+		assert.True(t, isAgg, test.expr)
+		assert.Empty(t, arg, test.expr)
+		assert.Equal(t, test.op, op, test.expr)
+		assert.Equal(t, test.param, param, test.expr)
+	}
+
+	_, err := NewAggregate("sum_distinct", 100, "sum(distinct a)", schemapb.DataType_Int64)
+	assert.Error(t, err)
+}
+
+func TestNewAggregateCountDistinct(t *testing.T) {
+	aggregates, err := NewAggregate(kCountDistinct, 100, "count(distinct a)", schemapb.DataType_VarChar)
+	require.NoError(t, err)
+	require.Len(t, aggregates, 1)
+	assert.Equal(t, kCountDistinct, aggregates[0].Name())
+	assert.Equal(t, planpb.AggregateOp_count_distinct, aggregates[0].ToPB().GetOp())
+
+	aggregates, err = NewAggregate(kApproxCountDistinct, 100, "approx_count_distinct(a)", schemapb.DataType_Int64)
+	require.NoError(t, err)
+	assert.Equal(t, planpb.AggregateOp_approx_count_distinct, aggregates[0].ToPB().GetOp())
+
+	fromPB, err := FromPB(aggregates[0].ToPB())
+	require.NoError(t, err)
+	assert.Equal(t, kApproxCountDistinct, fromPB.Name())
+
+	_, err = NewAggregate(kCountDistinct, 100, "count(distinct v)", schemapb.DataType_FloatVector)
+	assert.Error(t, err)
+}
+
+func TestCountDistinctAggregateUpdateState(t *testing.T) {
+	for _, approx := range []bool{false, true} {
+		t.Run(fmt.Sprintf("approx=%v", approx), func(t *testing.T) {
+			cd := &CountDistinctAggregate{approx: approx}
+			slots := cd.NewState()
+			result, err := cd.Terminate(slots)
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), result)
+
+			for _, v := range []any{"a", "b", "a", "c", "b"} {
+				require.NoError(t, cd.UpdateState(slots, NewFieldValue(v)))
+			}
+			require.NoError(t, cd.UpdateState(slots, NewNullFieldValue()))
+			result, err = cd.Terminate(slots)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), result)
+		})
+	}
+}
+
+func TestDistinctKeyCanonicalFloat(t *testing.T) {
+	cd := &CountDistinctAggregate{}
+	slots := cd.NewState()
+	for _, v := range []any{0.0, math.Copysign(0, -1), math.NaN(), math.NaN(), 1.5} {
+		require.NoError(t, cd.UpdateState(slots, NewFieldValue(v)))
+	}
+	result, err := cd.Terminate(slots)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result)
+
+	require.Error(t, cd.UpdateState(slots, NewFieldValue([]int{1})))
+}
+
+func TestCountDistinctAggregateMergeStates(t *testing.T) {
+	for _, approx := range []bool{false, true} {
+		t.Run(fmt.Sprintf("approx=%v", approx), func(t *testing.T) {
+			cd := &CountDistinctAggregate{approx: approx}
+			// build two partial states with overlapped values and merge them in serialized form.
+			partial := func(from, to int64) *FieldValue {
+				slots := cd.NewState()
+				for i := from; i < to; i++ {
+					require.NoError(t, cd.UpdateState(slots, NewFieldValue(i)))
+				}
+				state, err := asAggState(slots[0])
+				require.NoError(t, err)
+				return NewFieldValue(state.marshal())
+			}
+			target := NewNullFieldValue()
+			require.NoError(t, cd.Update(target, partial(0, 600)))
+			require.NoError(t, cd.Update(target, partial(400, 1000)))
+			require.NoError(t, cd.Update(target, NewNullFieldValue()))
+
+			result, err := cd.Terminate([]*FieldValue{target})
+			require.NoError(t, err)
+			if approx {
+				assert.InDelta(t, 1000, result, 50)
+			} else {
+				assert.Equal(t, int64(1000), result)
+			}
+		})
+	}
+}
+
+func TestExactDistinctStateMaxValues(t *testing.T) {
+	paramtable.Get().Save(paramtable.Get().CommonCfg.CountDistinctMaxValues.Key, "3")
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.CountDistinctMaxValues.Key)
+
+	cd := &CountDistinctAggregate{}
+	slots := cd.NewState()
+	for i := int64(0); i < 3; i++ {
+		require.NoError(t, cd.UpdateState(slots, NewFieldValue(i)))
+	}
+	err := cd.UpdateState(slots, NewFieldValue(int64(3)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "approx_count_distinct")
+}
+
+func TestHLLSketchEstimate(t *testing.T) {
+	for _, n := range []int{10, 1000, 100000} {
+		s := newHLLSketch()
+		for i := 0; i < n; i++ {
+			require.NoError(t, s.add(int64(i)))
+		}
+		// 4 standard errors
+		assert.InDelta(t, n, s.count(), float64(n)*0.065+1, "n=%d", n)
+
+		decoded, err := unmarshalAggState(s.marshal())
+		require.NoError(t, err)
+		assert.Equal(t, s.count(), decoded.(distinctState).count())
+	}
+
+	_, err := unmarshalAggState([]byte{distinctStateHLL, hllPrecision, 1})
+	assert.Error(t, err)
+	_, err = unmarshalAggState([]byte{9})
+	assert.Error(t, err)
+	_, err = unmarshalAggState(nil)
+	assert.Error(t, err)
+	assert.Error(t, newHLLSketch().merge(newExactDistinctState()))
+}
+
+func makeDistinctStateFieldData(states ...distinctState) *schemapb.FieldData {
+	data := make([][]byte, 0, len(states))
+	for _, state := range states {
+		data = append(data, state.marshal())
+	}
+	return &schemapb.FieldData{
+		Type: aggStateType,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: data}},
+			},
+		},
+	}
+}
+
+func TestReduceCountDistinct(t *testing.T) {
+	schema := makeTestSchema()
+	for _, op := range []planpb.AggregateOp{planpb.AggregateOp_count_distinct, planpb.AggregateOp_approx_count_distinct} {
+		t.Run(op.String(), func(t *testing.T) {
+			approx := op == planpb.AggregateOp_approx_count_distinct
+			makeState := func(values ...int64) distinctState {
+				state := newDistinctState(approx)
+				for _, v := range values {
+					require.NoError(t, state.add(v))
+				}
+				return state
+			}
+			makeResult := func(keys []string, states ...distinctState) *AggregationResult {
+				return NewAggregationResult([]*schemapb.FieldData{
+					{
+						Type: schemapb.DataType_VarChar,
+						Field: &schemapb.FieldData_Scalars{
+							Scalars: &schemapb.ScalarField{
+								Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: keys}},
+							},
+						},
+					},
+					makeDistinctStateFieldData(states...),
+				}, int64(len(keys)))
+			}
+
+			aggregate, err := FromPB(&planpb.Aggregate{Op: op, FieldId: 2})
+			require.NoError(t, err)
+			reducer := NewGroupAggReducer([]int64{1}, []*planpb.Aggregate{{Op: op, FieldId: 2}}, -1, schema)
+			out, err := reducer.Reduce(context.Background(), []*AggregationResult{
+				makeResult([]string{"a", "b"}, makeState(1, 2, 3), makeState(1)),
+				makeResult([]string{"a"}, makeState(3, 4)),
+				makeResult([]string{"b"}, makeState(1, 5)),
+			})
+			require.NoError(t, err)
+
+			counts, err := FinalizeAggState(aggregate, out.GetFieldDatas()[1])
+			require.NoError(t, err)
+			got := make(map[string]int64)
+			keys := out.GetFieldDatas()[0].GetScalars().GetStringData().GetData()
+			for i, key := range keys {
+				got[key] = counts.GetScalars().GetLongData().GetData()[i]
+			}
+			assert.Equal(t, map[string]int64{"a": 4, "b": 2}, got)
+
+			// global aggregation
+			reducer = NewGroupAggReducer(nil, []*planpb.Aggregate{{Op: op, FieldId: 2}}, -1, schema)
+			out, err = reducer.Reduce(context.Background(), []*AggregationResult{
+				NewAggregationResult([]*schemapb.FieldData{makeDistinctStateFieldData(makeState(1, 2))}, 2),
+				NewAggregationResult([]*schemapb.FieldData{makeDistinctStateFieldData(makeState(2, 3))}, 2),
+			})
+			require.NoError(t, err)
+			counts, err = FinalizeAggState(aggregate, out.GetFieldDatas()[0])
+			require.NoError(t, err)
+			assert.Equal(t, []int64{3}, counts.GetScalars().GetLongData().GetData())
+
+			// empty results are finalized as 0
+			out, err = reducer.Reduce(context.Background(), nil)
+			require.NoError(t, err)
+			counts, err = FinalizeAggState(aggregate, out.GetFieldDatas()[0])
+			require.NoError(t, err)
+			assert.Equal(t, []int64{0}, counts.GetScalars().GetLongData().GetData())
+		})
+	}
+}
+
+func TestAggregationFieldMapDistinct(t *testing.T) {
+	aggs, err := NewAggregate(kCountDistinct, 2, "count(distinct value)", schemapb.DataType_Int64)
+	require.NoError(t, err)
+	countAggs, err := NewAggregate(kCount, 2, "count(value)", schemapb.DataType_Int64)
+	require.NoError(t, err)
+	aggMap, err := NewAggregationFieldMap([]string{"category", "count(distinct value)", "count(value)"}, []string{"category"}, append(aggs, countAggs...))
+	require.NoError(t, err)
+	assert.False(t, aggMap.IsStateAt(0))
+	assert.True(t, aggMap.IsStateAt(1))
+	assert.False(t, aggMap.IsStateAt(2))
+
+	_, err = aggMap.FinalizeStateAt(1, &schemapb.FieldData{Type: schemapb.DataType_Int64})
+	assert.Error(t, err)
+	_, err = aggMap.FinalizeStateAt(2, makeDistinctStateFieldData(newExactDistinctState()))
+	assert.Error(t, err)
+}
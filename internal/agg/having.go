@@ -0,0 +1,633 @@
+package agg
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const (
+	// havingGroupFieldIDBase and havingAggFieldIDBase are the field ids of the
+	// columns in the schema that the HAVING predicate is parsed against.
+	havingGroupFieldIDBase = 100
+	havingAggFieldIDBase   = 10000
+	havingAggFieldPrefix   = "__having_agg_"
+)
+
+// havingAggregationPattern matches the aggregation expressions inside a HAVING predicate.
+var havingAggregationPattern = regexp.MustCompile(`(?i)\b(` + aggregationTypes + `)\s*\(\s*(distinct\s+)?([\w\*]*)\s*(?:,\s*([^,\s\)]+)\s*)?\)`)
+
+type havingColumnKind int
+
+const (
+	havingColumnPlain havingColumnKind = iota
+	havingColumnAvg
+	havingColumnState
+)
+
+// havingColumn binds a column of the HAVING predicate to the columns of the
+// reduced aggregation layout [group_cols..., agg_cols...].
+type havingColumn struct {
+	kind      havingColumnKind
+	indexes   []int         // avg binds to the sum and count columns
+	aggregate AggregateBase // the state aggregation to finalize the states of a state column
+}
+
+// HavingFilter filters the groups of a reduced aggregation result by the HAVING predicate.
+type HavingFilter struct {
+	predicate havingPredicate
+	columns   map[int64]havingColumn
+}
+
+// havingRow is the values of the bound columns at one row.
+type havingRow map[int64]any
+
+// havingPredicate evaluates a compiled HAVING expression on one row.
+type havingPredicate func(row havingRow) bool
+
+// ParseHaving parses the HAVING predicate, which is a filter expression over the group by
+// fields and the aggregation expressions, e.g. `count(*) > 10 and avg(price) < 5`.
+// The aggregations referenced by the predicate but not requested by output fields are
+// returned, the caller must append them to the aggregates so that they are computed too.
+func ParseHaving(having string, schema *schemapb.CollectionSchema, groupByFields []string, aggregates []AggregateBase) (*HavingFilter, []AggregateBase, error) {
+	if len(groupByFields) == 0 && len(aggregates) == 0 {
+		return nil, nil, merr.WrapErrParameterInvalidMsg("having is only supported by aggregation queries")
+	}
+	fields := make(map[string]*schemapb.FieldSchema, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		fields[field.GetName()] = field
+	}
+
+	numGroupingKeys := len(groupByFields)
+	havingSchema := &schemapb.CollectionSchema{}
+	columns := make(map[int64]havingColumn)
+	for i, name := range groupByFields {
+		field, ok := fields[name]
+		if !ok {
+			return nil, nil, merr.WrapErrParameterInvalidMsg("group by field %s not exist", name)
+		}
+		fieldID := int64(havingGroupFieldIDBase + i)
+		havingSchema.Fields = append(havingSchema.Fields, &schemapb.FieldSchema{
+			FieldID:  fieldID,
+			Name:     name,
+			DataType: field.GetDataType(),
+			Nullable: true,
+		})
+		columns[fieldID] = havingColumn{kind: havingColumnPlain, indexes: []int{i}}
+	}
+
+	var extra []AggregateBase
+	var parseErr error
+	// aggregation expressions with the same operator and field share the same column.
+	aggColumnNames := make(map[string]string)
+	bindAggregation := func(op string, param string, arg string, originalName string) string {
+		key := op + "(" + param + "," + arg + ")"
+		if name, ok := aggColumnNames[key]; ok {
+			return name
+		}
+		var fieldID int64
+		dataType := schemapb.DataType_None
+		if param == "*" {
+			if op != kCount {
+				parseErr = merr.WrapErrParameterInvalidMsg("%s(*) is not supported, only count(*) is allowed", op)
+				return originalName
+			}
+		} else {
+			field, ok := fields[param]
+			if !ok {
+				parseErr = merr.WrapErrParameterInvalidMsg("target field %s for aggregation:%s does not exist", param, op)
+				return originalName
+			}
+			fieldID = field.GetFieldID()
+			dataType = field.GetDataType()
+		}
+
+		aggs, err := NewAggregateWithArg(op, fieldID, originalName, dataType, arg)
+		if err != nil {
+			parseErr = err
+			return originalName
+		}
+		idx := findAggregate(aggregates, aggs[0])
+		if idx == NONE {
+			if idx = findAggregate(extra, aggs[0]); idx != NONE {
+				idx += len(aggregates)
+			}
+		}
+		if idx == NONE {
+			idx = len(aggregates) + len(extra)
+			extra = append(extra, aggs...)
+		}
+
+		resultType := schemapb.DataType_Int64
+		column := havingColumn{kind: havingColumnPlain, indexes: []int{numGroupingKeys + idx}}
+		if sa, ok := aggs[0].(stateAggregate); ok {
+			resultType = sa.resultType()
+			column.kind = havingColumnState
+			column.aggregate = sa
+		} else {
+			switch op {
+			case kAvg:
+				resultType = schemapb.DataType_Double
+				column = havingColumn{kind: havingColumnAvg, indexes: []int{numGroupingKeys + idx, numGroupingKeys + idx + 1}}
+			case kCount:
+			default:
+				resultType, err = getAggregateResultType(aggs[0].ToPB().GetOp(), dataType)
+				if err != nil {
+					parseErr = err
+					return originalName
+				}
+			}
+		}
+
+		fieldID = int64(havingAggFieldIDBase + len(aggColumnNames))
+		name := fmt.Sprintf("%s%d", havingAggFieldPrefix, len(aggColumnNames))
+		havingSchema.Fields = append(havingSchema.Fields, &schemapb.FieldSchema{
+			FieldID:  fieldID,
+			Name:     name,
+			DataType: resultType,
+			Nullable: true,
+		})
+		columns[fieldID] = column
+		aggColumnNames[key] = name
+		return name
+	}
+
+	rewritten := rewriteOutsideQuotes(having, func(segment string) string {
+		return havingAggregationPattern.ReplaceAllStringFunc(segment, func(expr string) string {
+			_, op, param, arg := MatchAggregationExpression(expr)
+			return bindAggregation(op, param, arg, expr)
+		})
+	})
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+
+	helper, err := typeutil.CreateSchemaHelper(havingSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	expr, err := planparserv2.ParseExpr(helper, rewritten, nil)
+	if err != nil {
+		return nil, nil, merr.WrapErrParameterInvalidMsg("failed to parse having expression %s: %s", having, err.Error())
+	}
+	predicate, err := compileHavingExpr(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &HavingFilter{predicate: predicate, columns: columns}, extra, nil
+}
+
+// findAggregate returns the index of the aggregate that computes the same as target,
+// for avg the index of the sum part is returned.
+func findAggregate(aggregates []AggregateBase, target AggregateBase) int {
+	for i, aggregate := range aggregates {
+		switch a := aggregate.(type) {
+		case *SumAggregate:
+			if t, ok := target.(*SumAggregate); ok && a.fieldID == t.fieldID && a.isAvg == t.isAvg {
+				return i
+			}
+		case *CountAggregate:
+			if t, ok := target.(*CountAggregate); ok && a.fieldID == t.fieldID && a.isAvg == t.isAvg {
+				return i
+			}
+		default:
+			if proto.Equal(aggregate.ToPB(), target.ToPB()) {
+				return i
+			}
+		}
+	}
+	return NONE
+}
+
+// rewriteOutsideQuotes applies the rewrite function to the parts of the expression that are not quoted string literals.
+func rewriteOutsideQuotes(expr string, rewrite func(string) string) string {
+	var builder strings.Builder
+	start := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+			builder.WriteString(expr[start : i+1])
+			start = i + 1
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+			builder.WriteString(rewrite(expr[start:i]))
+			start = i
+		}
+	}
+	if quote != 0 {
+		builder.WriteString(expr[start:])
+	} else {
+		builder.WriteString(rewrite(expr[start:]))
+	}
+	return builder.String()
+}
+
+// Filter returns the rows of the reduced aggregation result that satisfy the HAVING predicate,
+// at most limit rows are kept if limit is not -1.
+func (f *HavingFilter) Filter(fieldDatas []*schemapb.FieldData, limit int64) ([]*schemapb.FieldData, error) {
+	if len(fieldDatas) == 0 {
+		return fieldDatas, nil
+	}
+	values := make(map[int64][]any, len(f.columns))
+	rowCount := -1
+	for fieldID, column := range f.columns {
+		vals, err := column.values(fieldDatas)
+		if err != nil {
+			return nil, err
+		}
+		if rowCount != -1 && rowCount != len(vals) {
+			return nil, merr.WrapErrServiceInternalMsg("columns of having predicate have different row count, %d vs %d", rowCount, len(vals))
+		}
+		rowCount = len(vals)
+		values[fieldID] = vals
+	}
+	if rowCount == -1 {
+		accessor, err := NewFieldAccessor(fieldDatas[0].GetType())
+		if err != nil {
+			return nil, err
+		}
+		accessor.SetVals(fieldDatas[0])
+		rowCount = accessor.RowCount()
+	}
+
+	kept := make([]int64, 0, rowCount)
+	row := make(havingRow, len(values))
+	for i := 0; i < rowCount; i++ {
+		if limit != -1 && int64(len(kept)) >= limit {
+			break
+		}
+		for fieldID, vals := range values {
+			row[fieldID] = vals[i]
+		}
+		if f.predicate(row) {
+			kept = append(kept, int64(i))
+		}
+	}
+	if len(kept) == rowCount {
+		return fieldDatas, nil
+	}
+
+	ret := typeutil.PrepareResultFieldData(fieldDatas, int64(len(kept)))
+	for i, fieldData := range fieldDatas {
+		typeutil.AppendFieldDataByColumn(ret[i], fieldData, kept)
+	}
+	return ret, nil
+}
+
+// values returns the values of the column at each row, nil means null.
+func (column havingColumn) values(fieldDatas []*schemapb.FieldData) ([]any, error) {
+	for _, idx := range column.indexes {
+		if idx >= len(fieldDatas) {
+			return nil, merr.WrapErrServiceInternalMsg("having column %d out of range %d", idx, len(fieldDatas))
+		}
+	}
+	switch column.kind {
+	case havingColumnAvg:
+		avg, err := ComputeAvgFromSumAndCount(fieldDatas[column.indexes[0]], fieldDatas[column.indexes[1]])
+		if err != nil {
+			return nil, err
+		}
+		return accessorValues(avg)
+	case havingColumnState:
+		finalized, err := FinalizeAggState(column.aggregate, fieldDatas[column.indexes[0]])
+		if err != nil {
+			return nil, err
+		}
+		return accessorValues(finalized)
+	default:
+		return accessorValues(fieldDatas[column.indexes[0]])
+	}
+}
+
+func accessorValues(fieldData *schemapb.FieldData) ([]any, error) {
+	accessor, err := NewFieldAccessor(fieldData.GetType())
+	if err != nil {
+		return nil, err
+	}
+	accessor.SetVals(fieldData)
+	vals := make([]any, accessor.RowCount())
+	for i := range vals {
+		if !accessor.IsNullAt(i) {
+			vals[i] = normalizeHavingValue(accessor.ValAt(i))
+		}
+	}
+	return vals, nil
+}
+
+// normalizeHavingValue widens the values so that they can be compared with the literals of the predicate.
+func normalizeHavingValue(v any) any {
+	switch value := v.(type) {
+	case int32:
+		return int64(value)
+	case float32:
+		return float64(value)
+	default:
+		return v
+	}
+}
+
+func genericValue(v *planpb.GenericValue) (any, error) {
+	switch val := v.GetVal().(type) {
+	case *planpb.GenericValue_BoolVal:
+		return val.BoolVal, nil
+	case *planpb.GenericValue_Int64Val:
+		return val.Int64Val, nil
+	case *planpb.GenericValue_FloatVal:
+		return val.FloatVal, nil
+	case *planpb.GenericValue_StringVal:
+		return val.StringVal, nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unsupported value %v in having expression", v)
+	}
+}
+
+// compareHavingValues compares two non-null values, ok is false if they are not comparable.
+func compareHavingValues(a, b any) (int, bool) {
+	switch left := a.(type) {
+	case int64:
+		switch right := b.(type) {
+		case int64:
+			return compareOrdered(left, right), true
+		case float64:
+			return compareOrdered(float64(left), right), true
+		}
+	case float64:
+		switch right := b.(type) {
+		case int64:
+			return compareOrdered(left, float64(right)), true
+		case float64:
+			return compareOrdered(left, right), true
+		}
+	case string:
+		if right, ok := b.(string); ok {
+			return strings.Compare(left, right), true
+		}
+	case bool:
+		if right, ok := b.(bool); ok {
+			if left == right {
+				return 0, true
+			}
+			if !left {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+func compareOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func matchCompareOp(op planpb.OpType, a, b any) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	switch op {
+	case planpb.OpType_PrefixMatch, planpb.OpType_PostfixMatch, planpb.OpType_InnerMatch:
+		left, ok1 := a.(string)
+		right, ok2 := b.(string)
+		if !ok1 || !ok2 {
+			return false
+		}
+		switch op {
+		case planpb.OpType_PrefixMatch:
+			return strings.HasPrefix(left, right)
+		case planpb.OpType_PostfixMatch:
+			return strings.HasSuffix(left, right)
+		default:
+			return strings.Contains(left, right)
+		}
+	}
+	c, ok := compareHavingValues(a, b)
+	if !ok {
+		return false
+	}
+	switch op {
+	case planpb.OpType_GreaterThan:
+		return c > 0
+	case planpb.OpType_GreaterEqual:
+		return c >= 0
+	case planpb.OpType_LessThan:
+		return c < 0
+	case planpb.OpType_LessEqual:
+		return c <= 0
+	case planpb.OpType_Equal:
+		return c == 0
+	case planpb.OpType_NotEqual:
+		return c != 0
+	default:
+		return false
+	}
+}
+
+func isSupportedHavingOp(op planpb.OpType) bool {
+	switch op {
+	case planpb.OpType_GreaterThan, planpb.OpType_GreaterEqual, planpb.OpType_LessThan, planpb.OpType_LessEqual,
+		planpb.OpType_Equal, planpb.OpType_NotEqual,
+		planpb.OpType_PrefixMatch, planpb.OpType_PostfixMatch, planpb.OpType_InnerMatch:
+		return true
+	default:
+		return false
+	}
+}
+
+func arithHavingValue(op planpb.ArithOpType, a, b any) (any, bool) {
+	if left, ok := a.(int64); ok {
+		if right, ok := b.(int64); ok {
+			switch op {
+			case planpb.ArithOpType_Add:
+				return left + right, true
+			case planpb.ArithOpType_Sub:
+				return left - right, true
+			case planpb.ArithOpType_Mul:
+				return left * right, true
+			case planpb.ArithOpType_Div:
+				if right == 0 {
+					return nil, false
+				}
+				return left / right, true
+			case planpb.ArithOpType_Mod:
+				if right == 0 {
+					return nil, false
+				}
+				return left % right, true
+			}
+			return nil, false
+		}
+	}
+	left, ok1 := toHavingFloat(a)
+	right, ok2 := toHavingFloat(b)
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+	switch op {
+	case planpb.ArithOpType_Add:
+		return left + right, true
+	case planpb.ArithOpType_Sub:
+		return left - right, true
+	case planpb.ArithOpType_Mul:
+		return left * right, true
+	case planpb.ArithOpType_Div:
+		if right == 0 {
+			return nil, false
+		}
+		return left / right, true
+	case planpb.ArithOpType_Mod:
+		if right == 0 {
+			return nil, false
+		}
+		return math.Mod(left, right), true
+	}
+	return nil, false
+}
+
+func toHavingFloat(v any) (float64, bool) {
+	switch value := v.(type) {
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+// compileHavingExpr compiles the parsed HAVING expression into a predicate.
+// Comparisons involving null are false.
+func compileHavingExpr(expr *planpb.Expr) (havingPredicate, error) {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_AlwaysTrueExpr:
+		return func(havingRow) bool { return true }, nil
+	case *planpb.Expr_BinaryExpr:
+		left, err := compileHavingExpr(e.BinaryExpr.GetLeft())
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileHavingExpr(e.BinaryExpr.GetRight())
+		if err != nil {
+			return nil, err
+		}
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return func(row havingRow) bool { return left(row) && right(row) }, nil
+		case planpb.BinaryExpr_LogicalOr:
+			return func(row havingRow) bool { return left(row) || right(row) }, nil
+		}
+	case *planpb.Expr_UnaryExpr:
+		child, err := compileHavingExpr(e.UnaryExpr.GetChild())
+		if err != nil {
+			return nil, err
+		}
+		if e.UnaryExpr.GetOp() == planpb.UnaryExpr_Not {
+			return func(row havingRow) bool { return !child(row) }, nil
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		fieldID := e.UnaryRangeExpr.GetColumnInfo().GetFieldId()
+		op := e.UnaryRangeExpr.GetOp()
+		value, err := genericValue(e.UnaryRangeExpr.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		if isSupportedHavingOp(op) {
+			return func(row havingRow) bool { return matchCompareOp(op, row[fieldID], value) }, nil
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		fieldID := e.BinaryRangeExpr.GetColumnInfo().GetFieldId()
+		lower, err := genericValue(e.BinaryRangeExpr.GetLowerValue())
+		if err != nil {
+			return nil, err
+		}
+		upper, err := genericValue(e.BinaryRangeExpr.GetUpperValue())
+		if err != nil {
+			return nil, err
+		}
+		lowerOp, upperOp := planpb.OpType_GreaterThan, planpb.OpType_LessThan
+		if e.BinaryRangeExpr.GetLowerInclusive() {
+			lowerOp = planpb.OpType_GreaterEqual
+		}
+		if e.BinaryRangeExpr.GetUpperInclusive() {
+			upperOp = planpb.OpType_LessEqual
+		}
+		return func(row havingRow) bool {
+			return matchCompareOp(lowerOp, row[fieldID], lower) && matchCompareOp(upperOp, row[fieldID], upper)
+		}, nil
+	case *planpb.Expr_TermExpr:
+		fieldID := e.TermExpr.GetColumnInfo().GetFieldId()
+		values := make([]any, 0, len(e.TermExpr.GetValues()))
+		for _, v := range e.TermExpr.GetValues() {
+			value, err := genericValue(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return func(row havingRow) bool {
+			for _, value := range values {
+				if matchCompareOp(planpb.OpType_Equal, row[fieldID], value) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case *planpb.Expr_CompareExpr:
+		left := e.CompareExpr.GetLeftColumnInfo().GetFieldId()
+		right := e.CompareExpr.GetRightColumnInfo().GetFieldId()
+		op := e.CompareExpr.GetOp()
+		if isSupportedHavingOp(op) {
+			return func(row havingRow) bool { return matchCompareOp(op, row[left], row[right]) }, nil
+		}
+	case *planpb.Expr_BinaryArithOpEvalRangeExpr:
+		fieldID := e.BinaryArithOpEvalRangeExpr.GetColumnInfo().GetFieldId()
+		arithOp := e.BinaryArithOpEvalRangeExpr.GetArithOp()
+		op := e.BinaryArithOpEvalRangeExpr.GetOp()
+		operand, err := genericValue(e.BinaryArithOpEvalRangeExpr.GetRightOperand())
+		if err != nil {
+			return nil, err
+		}
+		value, err := genericValue(e.BinaryArithOpEvalRangeExpr.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		if isSupportedHavingOp(op) {
+			return func(row havingRow) bool {
+				if row[fieldID] == nil {
+					return false
+				}
+				result, ok := arithHavingValue(arithOp, row[fieldID], operand)
+				return ok && matchCompareOp(op, result, value)
+			}, nil
+		}
+	case *planpb.Expr_NullExpr:
+		fieldID := e.NullExpr.GetColumnInfo().GetFieldId()
+		switch e.NullExpr.GetOp() {
+		case planpb.NullExpr_IsNull:
+			return func(row havingRow) bool { return row[fieldID] == nil }, nil
+		case planpb.NullExpr_IsNotNull:
+			return func(row havingRow) bool { return row[fieldID] != nil }, nil
+		}
+	}
+	return nil, merr.WrapErrParameterInvalidMsg("unsupported expression in having: %s", expr.String())
+}
@@ -0,0 +1,192 @@
+package agg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+)
+
+func makeHavingTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "id", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "category", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Int64},
+			{FieldID: 103, Name: "score", DataType: schemapb.DataType_Float},
+		},
+	}
+}
+
+func makeStringFieldData(vals ...string) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type: schemapb.DataType_VarChar,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: vals}},
+			},
+		},
+	}
+}
+
+func makeDoubleFieldData(vals ...float64) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type: schemapb.DataType_Double,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: vals}},
+			},
+		},
+	}
+}
+
+func TestParseHaving(t *testing.T) {
+	schema := makeHavingTestSchema()
+	countStar, err := NewAggregate(kCount, 0, "count(*)", schemapb.DataType_None)
+	require.NoError(t, err)
+
+	t.Run("reuse output aggregates", func(t *testing.T) {
+		filter, extra, err := ParseHaving("COUNT( * ) > 1", schema, []string{"category"}, countStar)
+		require.NoError(t, err)
+		assert.NotNil(t, filter)
+		assert.Empty(t, extra)
+	})
+
+	t.Run("hidden aggregates", func(t *testing.T) {
+		_, extra, err := ParseHaving("count(*) > 1 and avg(price) < 5 and avg(price) > 1 and sum(price) > 2", schema, []string{"category"}, countStar)
+		require.NoError(t, err)
+		require.Len(t, extra, 3)
+		assert.Equal(t, kSum, extra[0].Name())
+		assert.Equal(t, kCount, extra[1].Name())
+		assert.Equal(t, kSum, extra[2].Name())
+	})
+
+	t.Run("quoted aggregation", func(t *testing.T) {
+		_, extra, err := ParseHaving(`category != "sum(price)"`, schema, []string{"category"}, countStar)
+		require.NoError(t, err)
+		assert.Empty(t, extra)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := ParseHaving("count(*) > 1", schema, nil, nil)
+		assert.Error(t, err)
+		_, _, err = ParseHaving("sum(*) > 1", schema, []string{"category"}, countStar)
+		assert.Error(t, err)
+		_, _, err = ParseHaving("sum(unknown) > 1", schema, []string{"category"}, countStar)
+		assert.Error(t, err)
+		_, _, err = ParseHaving("price > 1", schema, []string{"category"}, countStar)
+		assert.Error(t, err)
+		_, _, err = ParseHaving("count(*) >", schema, []string{"category"}, countStar)
+		assert.Error(t, err)
+	})
+}
+
+func TestHavingFilter(t *testing.T) {
+	schema := makeHavingTestSchema()
+	aggregates, err := NewAggregate(kCount, 0, "count(*)", schemapb.DataType_None)
+	require.NoError(t, err)
+	maxScore, err := NewAggregate(kMax, 103, "max(score)", schemapb.DataType_Float)
+	require.NoError(t, err)
+	aggregates = append(aggregates, maxScore...)
+
+	filter, extra, err := ParseHaving(`(count(*) >= 2 and avg(price) < 5) or category like "z%"`, schema, []string{"category"}, aggregates)
+	require.NoError(t, err)
+	require.Len(t, extra, 2)
+
+	// layout: category, count(*), max(score), sum(price), count(price)
+	fieldDatas := []*schemapb.FieldData{
+		makeStringFieldData("a", "b", "c", "zz"),
+		genEmptyLongFieldData(schemapb.DataType_Int64, []int64{3, 1, 2, 1}),
+		makeDoubleFieldData(1, 2, 3, 4),
+		genEmptyLongFieldData(schemapb.DataType_Int64, []int64{9, 1, 20, 100}),
+		genEmptyLongFieldData(schemapb.DataType_Int64, []int64{3, 1, 2, 1}),
+	}
+	fieldDatas[2].Type = schemapb.DataType_Float
+	fieldDatas[2].GetScalars().Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4}}}
+
+	filtered, err := filter.Filter(fieldDatas, -1)
+	require.NoError(t, err)
+	require.Len(t, filtered, 5)
+	assert.Equal(t, []string{"a", "zz"}, filtered[0].GetScalars().GetStringData().GetData())
+	assert.Equal(t, []int64{3, 1}, filtered[1].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []float32{1, 4}, filtered[2].GetScalars().GetFloatData().GetData())
+
+	filtered, err = filter.Filter(fieldDatas, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, filtered[0].GetScalars().GetStringData().GetData())
+
+	filter, _, err = ParseHaving("max(score) in [2, 3] and not (count(*) + 1 == 3)", schema, []string{"category"}, aggregates)
+	require.NoError(t, err)
+	filtered, err = filter.Filter(fieldDatas, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, filtered[0].GetScalars().GetStringData().GetData())
+}
+
+func TestHavingFilterNullAndDistinct(t *testing.T) {
+	schema := makeHavingTestSchema()
+	aggregates, err := NewAggregate(kCountDistinct, 102, "count(distinct price)", schemapb.DataType_Int64)
+	require.NoError(t, err)
+	filter, extra, err := ParseHaving("count(distinct price) > 1 and category is not null", schema, []string{"category"}, aggregates)
+	require.NoError(t, err)
+	require.Empty(t, extra)
+
+	state := newExactDistinctState()
+	require.NoError(t, state.add(int64(1)))
+	require.NoError(t, state.add(int64(2)))
+	category := makeStringFieldData("a", "", "c")
+	category.ValidData = []bool{true, false, true}
+	fieldDatas := []*schemapb.FieldData{
+		category,
+		makeDistinctStateFieldData(state, state, newExactDistinctState()),
+	}
+	filtered, err := filter.Filter(fieldDatas, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, filtered[0].GetScalars().GetStringData().GetData())
+	assert.Len(t, filtered[1].GetScalars().GetJsonData().GetData(), 1)
+}
+
+func TestHavingFilterStatistical(t *testing.T) {
+	schema := makeHavingTestSchema()
+	filter, extra, err := ParseHaving("percentile(price, 0.9) > 5 and stddev(price) < 10", schema, []string{"category"}, nil)
+	require.NoError(t, err)
+	require.Len(t, extra, 2)
+	assert.Equal(t, 0.9, extra[0].(*PercentileAggregate).percentile)
+
+	state := func(aggregate AggregateBase, values ...int64) aggState {
+		slots := aggregate.NewState()
+		for _, v := range values {
+			require.NoError(t, aggregate.UpdateState(slots, NewFieldValue(v)))
+		}
+		s, err := asAggState(slots[0])
+		require.NoError(t, err)
+		return s
+	}
+	stateFieldData := func(aggregate AggregateBase, groups ...[]int64) *schemapb.FieldData {
+		fd := &schemapb.FieldData{
+			Type: aggStateType,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{}},
+				},
+			},
+		}
+		for _, values := range groups {
+			require.NoError(t, AssembleSingleValue(NewFieldValue(state(aggregate, values...)), fd))
+		}
+		return fd
+	}
+	groups := [][]int64{{1, 2, 3}, {6, 7, 8}, {1, 100}}
+	fieldDatas := []*schemapb.FieldData{
+		makeStringFieldData("a", "b", "c"),
+		stateFieldData(extra[0], groups...),
+		stateFieldData(extra[1], groups...),
+	}
+	filtered, err := filter.Filter(fieldDatas, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, filtered[0].GetScalars().GetStringData().GetData())
+
+	_, _, err = ParseHaving("percentile(price) > 5", schema, []string{"category"}, nil)
+	assert.Error(t, err)
+}
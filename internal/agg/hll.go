@@ -0,0 +1,81 @@
+package agg
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const (
+	// hllPrecision is the number of index bits of the sketch, 2^12 registers
+	// give a standard error of about 1.6% with a 4KB sketch per group.
+	hllPrecision = 12
+	hllRegisters = 1 << hllPrecision
+)
+
+// hllSketch is a dense HyperLogLog sketch. Sketches built anywhere in the
+// cluster share the same precision and hash, so they can be merged by taking
+// the register-wise maximum.
+type hllSketch struct {
+	registers []uint8
+}
+
+func newHLLSketch() *hllSketch {
+	return &hllSketch{registers: make([]uint8, hllRegisters)}
+}
+
+// addHash inserts a 64-bit hash of a value into the sketch.
+func (s *hllSketch) addHash(hash uint64) {
+	idx := hash >> (64 - hllPrecision)
+	// the remaining bits are shifted to the top, the sentinel bit bounds the rank.
+	w := hash<<hllPrecision | 1<<(hllPrecision-1)
+	rank := uint8(bits.LeadingZeros64(w)) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// mergeSketch folds other into s.
+func (s *hllSketch) mergeSketch(other *hllSketch) {
+	for i, r := range other.registers {
+		if r > s.registers[i] {
+			s.registers[i] = r
+		}
+	}
+}
+
+// estimate returns the estimated cardinality of the sketch.
+func (s *hllSketch) estimate() int64 {
+	m := float64(hllRegisters)
+	sum := 0.0
+	zeros := 0
+	for _, r := range s.registers {
+		sum += 1.0 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	est := alpha * m * m / sum
+	// use linear counting for the small range where raw hll is biased.
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return int64(est + 0.5)
+}
+
+func (s *hllSketch) marshal() []byte {
+	buf := make([]byte, 0, 2+len(s.registers))
+	buf = append(buf, distinctStateHLL, hllPrecision)
+	return append(buf, s.registers...)
+}
+
+func unmarshalHLLSketch(data []byte) (*hllSketch, error) {
+	if len(data) != 2+hllRegisters || data[1] != hllPrecision {
+		return nil, merr.WrapErrServiceInternalMsg("invalid hll sketch, size %d", len(data))
+	}
+	s := newHLLSketch()
+	copy(s.registers, data[2:])
+	return s, nil
+}
@@ -0,0 +1,90 @@
+package agg
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// momentsState is the (count, mean, M2) accumulator of variance and stddev,
+// where M2 is the sum of squared differences from the mean. It's updated by
+// Welford's algorithm and merged by the parallel algorithm of Chan et al.,
+// which are both numerically stable.
+type momentsState struct {
+	n    int64
+	mean float64
+	m2   float64
+}
+
+func (s *momentsState) add(v any) error {
+	x, err := fieldValueToFloat64(v)
+	if err != nil {
+		return err
+	}
+	s.n++
+	delta := x - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (x - s.mean)
+	return nil
+}
+
+// addWeighted adds a value occurring weight times, which is a state of weight equal values.
+func (s *momentsState) addWeighted(v any, weight int64) error {
+	x, err := fieldValueToFloat64(v)
+	if err != nil {
+		return err
+	}
+	return s.merge(&momentsState{n: weight, mean: x})
+}
+
+func (s *momentsState) merge(other aggState) error {
+	o, ok := other.(*momentsState)
+	if !ok {
+		return merr.WrapErrServiceInternalMsg("cannot merge %T into moments state", other)
+	}
+	if o.n == 0 {
+		return nil
+	}
+	if s.n == 0 {
+		*s = *o
+		return nil
+	}
+	n := s.n + o.n
+	delta := o.mean - s.mean
+	s.mean += delta * float64(o.n) / float64(n)
+	s.m2 += o.m2 + delta*delta*float64(s.n)*float64(o.n)/float64(n)
+	s.n = n
+	return nil
+}
+
+// sampleVariance returns the sample variance, it's undefined for less than 2 values.
+func (s *momentsState) sampleVariance() (float64, bool) {
+	if s.n < 2 {
+		return 0, false
+	}
+	return s.m2 / float64(s.n-1), true
+}
+
+// marshal encodes the state as |kind|n uvarint|mean|m2|, floats are little endian IEEE 754.
+func (s *momentsState) marshal() []byte {
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64+16)
+	buf = append(buf, momentsStateKind)
+	buf = binary.AppendUvarint(buf, uint64(s.n))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(s.mean))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(s.m2))
+}
+
+func unmarshalMomentsState(data []byte) (*momentsState, error) {
+	data = data[1:]
+	n, read := binary.Uvarint(data)
+	if read <= 0 || len(data)-read != 16 {
+		return nil, merr.WrapErrServiceInternalMsg("invalid moments state")
+	}
+	data = data[read:]
+	return &momentsState{
+		n:    int64(n),
+		mean: math.Float64frombits(binary.LittleEndian.Uint64(data)),
+		m2:   math.Float64frombits(binary.LittleEndian.Uint64(data[8:])),
+	}, nil
+}
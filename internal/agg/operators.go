@@ -1,6 +1,9 @@
 package agg
 
 import (
+	"math"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 )
@@ -358,3 +361,133 @@ func (max *MaxAggregate) FieldID() int64 {
 func (max *MaxAggregate) OriginalName() string {
 	return max.originalName
 }
+
+// VarianceAggregate computes the sample variance, or the sample standard deviation
+// if stddev is set, of the values. Its partial results are serialized moments states.
+type VarianceAggregate struct {
+	fieldID      int64
+	originalName string
+	stddev       bool
+}
+
+func (variance *VarianceAggregate) Name() string {
+	if variance.stddev {
+		return kStddev
+	}
+	return kVariance
+}
+
+func (variance *VarianceAggregate) Update(target *FieldValue, new *FieldValue) error {
+	return mergeAggState(target, new)
+}
+
+func (variance *VarianceAggregate) NewState() []*FieldValue {
+	return newSingleSlotState()
+}
+
+func (variance *VarianceAggregate) UpdateState(slots []*FieldValue, new *FieldValue) error {
+	return addToAggState(variance, slots, new)
+}
+
+func (variance *VarianceAggregate) Terminate(slots []*FieldValue) (any, error) {
+	return terminateAggState(variance, slots)
+}
+
+func (variance *VarianceAggregate) newAggState() aggState {
+	return &momentsState{}
+}
+
+// finalize returns null for less than 2 values, same as var_samp and stddev_samp of SQL.
+func (variance *VarianceAggregate) finalize(state aggState) (any, error) {
+	moments, ok := state.(*momentsState)
+	if !ok {
+		return nil, merr.WrapErrServiceInternalMsg("unexpected variance state %T", state)
+	}
+	v, ok := moments.sampleVariance()
+	if !ok {
+		return nil, nil
+	}
+	if variance.stddev {
+		return math.Sqrt(v), nil
+	}
+	return v, nil
+}
+
+func (variance *VarianceAggregate) resultType() schemapb.DataType {
+	return schemapb.DataType_Double
+}
+
+func (variance *VarianceAggregate) ToPB() *planpb.Aggregate {
+	if variance.stddev {
+		return &planpb.Aggregate{Op: planpb.AggregateOp_stddev, FieldId: variance.FieldID()}
+	}
+	return &planpb.Aggregate{Op: planpb.AggregateOp_variance, FieldId: variance.FieldID()}
+}
+
+func (variance *VarianceAggregate) FieldID() int64 {
+	return variance.fieldID
+}
+
+func (variance *VarianceAggregate) OriginalName() string {
+	return variance.originalName
+}
+
+// PercentileAggregate estimates the value at the given rank in [0, 1] of the values,
+// median is the percentile at 0.5. Its partial results are serialized t-digests.
+type PercentileAggregate struct {
+	fieldID      int64
+	originalName string
+	percentile   float64
+}
+
+func (percentile *PercentileAggregate) Name() string {
+	return kPercentile
+}
+
+func (percentile *PercentileAggregate) Update(target *FieldValue, new *FieldValue) error {
+	return mergeAggState(target, new)
+}
+
+func (percentile *PercentileAggregate) NewState() []*FieldValue {
+	return newSingleSlotState()
+}
+
+func (percentile *PercentileAggregate) UpdateState(slots []*FieldValue, new *FieldValue) error {
+	return addToAggState(percentile, slots, new)
+}
+
+func (percentile *PercentileAggregate) Terminate(slots []*FieldValue) (any, error) {
+	return terminateAggState(percentile, slots)
+}
+
+func (percentile *PercentileAggregate) newAggState() aggState {
+	return newTDigest()
+}
+
+func (percentile *PercentileAggregate) finalize(state aggState) (any, error) {
+	digest, ok := state.(*tdigest)
+	if !ok {
+		return nil, merr.WrapErrServiceInternalMsg("unexpected percentile state %T", state)
+	}
+	v, ok := digest.quantile(percentile.percentile)
+	if !ok {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func (percentile *PercentileAggregate) resultType() schemapb.DataType {
+	return schemapb.DataType_Double
+}
+
+func (percentile *PercentileAggregate) ToPB() *planpb.Aggregate {
+	return &planpb.Aggregate{Op: planpb.AggregateOp_percentile, FieldId: percentile.FieldID(), Percentile: percentile.percentile}
+}
+
+func (percentile *PercentileAggregate) FieldID() int64 {
+	return percentile.fieldID
+}
+
+func (percentile *PercentileAggregate) OriginalName() string {
+	return percentile.originalName
+}
@@ -348,3 +348,122 @@ func TestAvgTerminateRejectsNonNumericState(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "avg expects numeric accumulator")
 }
+
+func TestVarianceAggregate(t *testing.T) {
+	values := []int64{2, 4, 4, 4, 5, 5, 7, 9}
+	for _, stddev := range []bool{false, true} {
+		variance := &VarianceAggregate{stddev: stddev}
+		// two partial states merged in serialized form
+		left, right := variance.NewState(), variance.NewState()
+		for i, v := range values {
+			slots := left
+			if i%2 == 1 {
+				slots = right
+			}
+			require.NoError(t, variance.UpdateState(slots, NewFieldValue(v)))
+		}
+		require.NoError(t, variance.UpdateState(left, NewNullFieldValue()))
+		target := NewNullFieldValue()
+		for _, slots := range [][]*FieldValue{left, right} {
+			state, err := asAggState(slots[0])
+			require.NoError(t, err)
+			require.NoError(t, variance.Update(target, NewFieldValue(state.marshal())))
+		}
+
+		result, err := variance.Terminate([]*FieldValue{target})
+		require.NoError(t, err)
+		// sample variance of the values is 32/7
+		if stddev {
+			require.InDelta(t, math.Sqrt(32.0/7), result, 1e-9)
+		} else {
+			require.InDelta(t, 32.0/7, result, 1e-9)
+		}
+	}
+
+	variance := &VarianceAggregate{}
+	slots := variance.NewState()
+	result, err := variance.Terminate(slots)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.NoError(t, variance.UpdateState(slots, NewFieldValue(float32(1.5))))
+	result, err = variance.Terminate(slots)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.Error(t, variance.UpdateState(slots, NewFieldValue("a")))
+}
+
+func TestPercentileAggregate(t *testing.T) {
+	median := &PercentileAggregate{percentile: 0.5}
+	slots := median.NewState()
+	result, err := median.Terminate(slots)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	for _, v := range []int32{5, 1, 4, 2, 3} {
+		require.NoError(t, median.UpdateState(slots, NewFieldValue(v)))
+	}
+	result, err = median.Terminate(slots)
+	require.NoError(t, err)
+	require.Equal(t, float64(3), result)
+
+	require.NoError(t, median.UpdateState(slots, NewFieldValue(int32(6))))
+	result, err = median.Terminate(slots)
+	require.NoError(t, err)
+	require.Equal(t, 3.5, result)
+
+	for _, p := range []float64{0, 1} {
+		percentile := &PercentileAggregate{percentile: p}
+		result, err = percentile.Terminate(slots)
+		require.NoError(t, err)
+		require.Equal(t, 1+5*p, result)
+	}
+}
+
+func TestNewStatisticalAggregates(t *testing.T) {
+	aggregates, err := NewAggregateWithArg(kPercentile, 100, "percentile(a, 0.95)", schemapb.DataType_Double, "0.95")
+	require.NoError(t, err)
+	require.Equal(t, &planpb.Aggregate{Op: planpb.AggregateOp_percentile, FieldId: 100, Percentile: 0.95}, aggregates[0].ToPB())
+	fromPB, err := FromPB(aggregates[0].ToPB())
+	require.NoError(t, err)
+	require.Equal(t, 0.95, fromPB.(*PercentileAggregate).percentile)
+
+	aggregates, err = NewAggregate(kMedian, 100, "median(a)", schemapb.DataType_Int64)
+	require.NoError(t, err)
+	require.Equal(t, kPercentile, aggregates[0].Name())
+	require.Equal(t, 0.5, aggregates[0].(*PercentileAggregate).percentile)
+
+	for _, op := range []string{kVariance, kStddev} {
+		aggregates, err = NewAggregate(op, 100, op+"(a)", schemapb.DataType_Float)
+		require.NoError(t, err)
+		require.Equal(t, op, aggregates[0].Name())
+		fromPB, err = FromPB(aggregates[0].ToPB())
+		require.NoError(t, err)
+		require.Equal(t, op, fromPB.Name())
+	}
+
+	for _, arg := range []string{"", "1.5", "-0.1", "p"} {
+		_, err = NewAggregateWithArg(kPercentile, 100, "percentile(a)", schemapb.DataType_Double, arg)
+		require.Error(t, err, arg)
+	}
+	_, err = NewAggregateWithArg(kSum, 100, "sum(a, 1)", schemapb.DataType_Double, "1")
+	require.Error(t, err)
+	_, err = NewAggregate(kStddev, 100, "stddev(a)", schemapb.DataType_VarChar)
+	require.Error(t, err)
+}
+
+func TestMatchAggregationExpressionArg(t *testing.T) {
+	isAgg, op, param, arg := MatchAggregationExpression("PERCENTILE( price , 0.95 )")
+	require.True(t, isAgg)
+	require.Equal(t, kPercentile, op)
+	require.Equal(t, "price", param)
+	require.Equal(t, "0.95", arg)
+
+	isAgg, op, param, arg = MatchAggregationExpression("median(price)")
+	require.True(t, isAgg)
+	require.Equal(t, kMedian, op)
+	require.Equal(t, "price", param)
+	require.Empty(t, arg)
+
+	isAgg, _, _, _ = MatchAggregationExpression("percentile(price, 0.5, 0.9)")
+	require.False(t, isAgg)
+}
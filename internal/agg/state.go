@@ -0,0 +1,211 @@
+package agg
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// aggStateType is the data type of the partial results of the state aggregations.
+// The partial result is not a user visible value but a serialized mergeable state,
+// which is carried in the json data of the field data as opaque bytes and is merged
+// by the reducers. The proxy turns it into the user visible value by FinalizeAggState.
+const aggStateType = schemapb.DataType_JSON
+
+// The first byte of a serialized state marks its kind.
+const (
+	distinctStateExact byte = 1
+	distinctStateHLL   byte = 2
+	momentsStateKind   byte = 3
+	tdigestStateKind   byte = 4
+)
+
+// aggState is the mergeable accumulator of a state aggregation.
+type aggState interface {
+	// add adds a raw field value into the state.
+	add(v any) error
+	// merge merges another partial state of the same kind into the state.
+	merge(other aggState) error
+	marshal() []byte
+}
+
+// stateAggregate is an aggregation whose partial results are serialized aggStates.
+type stateAggregate interface {
+	AggregateBase
+	newAggState() aggState
+	// finalize returns the user visible value of the fully merged state, nil means null.
+	finalize(state aggState) (any, error)
+	// resultType returns the data type of the finalized value.
+	resultType() schemapb.DataType
+}
+
+func unmarshalAggState(data []byte) (aggState, error) {
+	if len(data) == 0 {
+		return nil, merr.WrapErrServiceInternalMsg("empty aggregation state")
+	}
+	switch data[0] {
+	case distinctStateExact:
+		return unmarshalExactDistinctState(data)
+	case distinctStateHLL:
+		return unmarshalHLLSketch(data)
+	case momentsStateKind:
+		return unmarshalMomentsState(data)
+	case tdigestStateKind:
+		return unmarshalTDigest(data)
+	default:
+		return nil, merr.WrapErrServiceInternalMsg("unknown aggregation state kind %d", data[0])
+	}
+}
+
+// asAggState returns the state held by the field value,
+// the serialized state received from other nodes is decoded in place.
+func asAggState(fv *FieldValue) (aggState, error) {
+	switch val := fv.val.(type) {
+	case aggState:
+		return val, nil
+	case []byte:
+		state, err := unmarshalAggState(val)
+		if err != nil {
+			return nil, err
+		}
+		fv.val = state
+		return state, nil
+	default:
+		return nil, merr.WrapErrServiceInternalMsg("unexpected aggregation state type %T", fv.val)
+	}
+}
+
+// mergeAggState merges the partial state in new into target, it's the Update of the state aggregations.
+func mergeAggState(target *FieldValue, new *FieldValue) error {
+	if target == nil || new == nil {
+		return merr.WrapErrServiceInternalMsg("target or new field value is nil")
+	}
+	if new.IsNull() {
+		return nil
+	}
+	if target.IsNull() || target.val == nil {
+		target.val = new.val
+		target.isNull = false
+		return nil
+	}
+	targetState, err := asAggState(target)
+	if err != nil {
+		return err
+	}
+	newState, err := asAggState(new)
+	if err != nil {
+		return err
+	}
+	return targetState.merge(newState)
+}
+
+// addToAggState adds a raw field value into the state slot, it's the UpdateState of the state aggregations.
+func addToAggState(sa stateAggregate, slots []*FieldValue, new *FieldValue) error {
+	if len(slots) != 1 {
+		return merr.WrapErrParameterInvalidMsg("aggregate expects 1 accumulator slot, got %d", len(slots))
+	}
+	if new == nil || new.IsNull() {
+		return nil
+	}
+	if slots[0].IsNull() {
+		slots[0].val = sa.newAggState()
+		slots[0].isNull = false
+	}
+	state, err := asAggState(slots[0])
+	if err != nil {
+		return err
+	}
+	return state.add(new.val)
+}
+
+// addWeightedToAggState adds a raw field value occurring weight times into the state slot.
+func addWeightedToAggState(sa stateAggregate, slot *FieldValue, new *FieldValue, weight int64) error {
+	if new == nil || new.IsNull() || weight <= 0 {
+		return nil
+	}
+	if slot.IsNull() {
+		slot.val = sa.newAggState()
+		slot.isNull = false
+	}
+	state, err := asAggState(slot)
+	if err != nil {
+		return err
+	}
+	switch s := state.(type) {
+	case distinctState:
+		// the distinct states don't depend on how many times a value occurs
+		return s.add(new.val)
+	case *momentsState:
+		return s.addWeighted(new.val, weight)
+	case *tdigest:
+		return s.addWeighted(new.val, weight)
+	default:
+		return merr.WrapErrServiceInternalMsg("aggregation state %T does not support weighted values", state)
+	}
+}
+
+// terminateAggState finalizes the state slot, it's the Terminate of the state aggregations.
+func terminateAggState(sa stateAggregate, slots []*FieldValue) (any, error) {
+	if len(slots) != 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("aggregate expects 1 accumulator slot, got %d", len(slots))
+	}
+	state := sa.newAggState()
+	if slots[0] != nil && !slots[0].IsNull() {
+		var err error
+		state, err = asAggState(slots[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sa.finalize(state)
+}
+
+// FinalizeAggState turns the states of a state aggregation reduced from all the sources
+// into the user visible values, null states are finalized as the empty state.
+func FinalizeAggState(aggregate AggregateBase, stateFieldData *schemapb.FieldData) (*schemapb.FieldData, error) {
+	sa, ok := aggregate.(stateAggregate)
+	if !ok {
+		return nil, merr.WrapErrServiceInternalMsg("aggregation %s has no state to finalize", aggregate.Name())
+	}
+	if stateFieldData == nil {
+		return nil, merr.WrapErrServiceInternalMsg("stateFieldData cannot be nil")
+	}
+	if stateFieldData.GetType() != aggStateType {
+		return nil, merr.WrapErrParameterInvalidMsg("aggregation state field must be %s type, got %s",
+			aggStateType.String(), stateFieldData.GetType().String())
+	}
+	states := stateFieldData.GetScalars().GetJsonData().GetData()
+	validData := stateFieldData.GetValidData()
+	ret := &schemapb.FieldData{
+		Type: sa.resultType(),
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{},
+		},
+	}
+	switch ret.GetType() {
+	case schemapb.DataType_Int64:
+		ret.GetScalars().Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: make([]int64, 0, len(states))}}
+	case schemapb.DataType_Double:
+		ret.GetScalars().Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: make([]float64, 0, len(states))}}
+	default:
+		return nil, merr.WrapErrServiceInternalMsg("unsupported result type %s of aggregation state", ret.GetType().String())
+	}
+	for i, data := range states {
+		var state aggState
+		if (len(validData) > 0 && !validData[i]) || len(data) == 0 {
+			state = sa.newAggState()
+		} else {
+			var err error
+			if state, err = unmarshalAggState(data); err != nil {
+				return nil, err
+			}
+		}
+		val, err := sa.finalize(state)
+		if err != nil {
+			return nil, err
+		}
+		if err := AssembleSingleValue(&FieldValue{val: val, isNull: val == nil}, ret); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
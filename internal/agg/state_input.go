@@ -0,0 +1,220 @@
+package agg
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// StateInputPlan describes how the query nodes compute the partial states of the state aggregations.
+//
+// segcore has no kernels of the state aggregations, so the plan executed by segcore groups by the
+// grouping keys and the input fields of the state aggregations, and computes the other aggregations
+// and count(*) of every group. The columns of the segcore results are:
+//
+//	| grouping keys | state input fields not in the keys | other aggregations | count(*) |
+//
+// The query nodes fold every row into the states of its group by FoldSegcoreResult, a row is an input
+// value occurring count(*) times. The folded result has the layout of the original aggregations, which
+// is merged by GroupAggReducer as usual.
+type StateInputPlan struct {
+	groupByFieldIDs []int64
+	aggregates      []*planpb.Aggregate
+	// inputFieldIDs are the input fields of the state aggregations which are not grouping keys.
+	inputFieldIDs []int64
+	// plainAggregates are the aggregations computed by segcore, excluding the trailing count(*).
+	plainAggregates []*planpb.Aggregate
+}
+
+// HasStateAggregates returns whether any of the aggregations is a state aggregation.
+func HasStateAggregates(aggregates []*planpb.Aggregate) bool {
+	for _, aggregate := range aggregates {
+		if isStateAggregateOp(aggregate.GetOp()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isStateAggregateOp(op planpb.AggregateOp) bool {
+	switch op {
+	case planpb.AggregateOp_count_distinct, planpb.AggregateOp_approx_count_distinct,
+		planpb.AggregateOp_variance, planpb.AggregateOp_stddev, planpb.AggregateOp_percentile:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewStateInputPlan(groupByFieldIDs []int64, aggregates []*planpb.Aggregate) *StateInputPlan {
+	plan := &StateInputPlan{groupByFieldIDs: groupByFieldIDs, aggregates: aggregates}
+	grouped := typeutil.NewSet(groupByFieldIDs...)
+	for _, aggregate := range aggregates {
+		if !isStateAggregateOp(aggregate.GetOp()) {
+			plan.plainAggregates = append(plan.plainAggregates, aggregate)
+			continue
+		}
+		if !grouped.Contain(aggregate.GetFieldId()) {
+			grouped.Insert(aggregate.GetFieldId())
+			plan.inputFieldIDs = append(plan.inputFieldIDs, aggregate.GetFieldId())
+		}
+	}
+	return plan
+}
+
+// SegcoreGroupByFieldIDs returns the grouping keys of the plan executed by segcore.
+func (plan *StateInputPlan) SegcoreGroupByFieldIDs() []int64 {
+	ret := make([]int64, 0, len(plan.groupByFieldIDs)+len(plan.inputFieldIDs))
+	ret = append(ret, plan.groupByFieldIDs...)
+	return append(ret, plan.inputFieldIDs...)
+}
+
+// SegcoreAggregates returns the aggregations of the plan executed by segcore.
+func (plan *StateInputPlan) SegcoreAggregates() []*planpb.Aggregate {
+	ret := make([]*planpb.Aggregate, 0, len(plan.plainAggregates)+1)
+	ret = append(ret, plan.plainAggregates...)
+	return append(ret, &planpb.Aggregate{Op: planpb.AggregateOp_count})
+}
+
+// inputColumn returns the column of the segcore results holding the input values of the field.
+func (plan *StateInputPlan) inputColumn(fieldID int64) int {
+	for idx, id := range plan.groupByFieldIDs {
+		if id == fieldID {
+			return idx
+		}
+	}
+	for idx, id := range plan.inputFieldIDs {
+		if id == fieldID {
+			return len(plan.groupByFieldIDs) + idx
+		}
+	}
+	return NONE
+}
+
+// FoldSegcoreResult folds the result of a segment computed by the segcore plan into the partial
+// results of the original aggregations, nil is returned if the segment has no group.
+func (plan *StateInputPlan) FoldSegcoreResult(fieldDatas []*schemapb.FieldData) ([]*schemapb.FieldData, error) {
+	numKeys := len(plan.groupByFieldIDs)
+	numInputs := numKeys + len(plan.inputFieldIDs)
+	countColumn := numInputs + len(plan.plainAggregates)
+	if len(fieldDatas) != countColumn+1 {
+		return nil, merr.WrapErrServiceInternalMsg("segcore result of state aggregations has %d columns, expected %d",
+			len(fieldDatas), countColumn+1)
+	}
+	accessors := make([]FieldAccessor, len(fieldDatas))
+	rowCount := -1
+	for idx, fieldData := range fieldDatas {
+		if fieldData == nil {
+			return nil, merr.WrapErrServiceInternalMsg("segcore result of state aggregations has nil column %d", idx)
+		}
+		accessor, err := NewFieldAccessor(fieldData.GetType())
+		if err != nil {
+			return nil, err
+		}
+		accessor.SetVals(fieldData)
+		if rowCount == -1 {
+			rowCount = accessor.RowCount()
+		} else if rowCount != accessor.RowCount() {
+			return nil, merr.WrapErrServiceInternalMsg("columns of segcore result have different row count, %d vs %d",
+				rowCount, accessor.RowCount())
+		}
+		accessors[idx] = accessor
+	}
+	if rowCount <= 0 {
+		return nil, nil
+	}
+
+	aggs := make([]AggregateBase, len(plan.aggregates))
+	// columns[i] is the column of the segcore results aggregated by the i-th aggregation
+	columns := make([]int, len(plan.aggregates))
+	plainIdx := 0
+	for idx, aggPb := range plan.aggregates {
+		aggregate, err := FromPB(aggPb)
+		if err != nil {
+			return nil, err
+		}
+		aggs[idx] = aggregate
+		if _, ok := aggregate.(stateAggregate); ok {
+			columns[idx] = plan.inputColumn(aggPb.GetFieldId())
+		} else {
+			columns[idx] = numInputs + plainIdx
+			plainIdx++
+		}
+	}
+
+	buckets := make(map[uint64]*Bucket)
+	groups := make([]*Row, 0)
+	for row := 0; row < rowCount; row++ {
+		var hashVal uint64
+		fieldValues := make([]*FieldValue, numKeys+len(aggs))
+		for col := 0; col < numKeys; col++ {
+			if col > 0 {
+				hashVal = typeutil2.HashMix(hashVal, accessors[col].Hash(row))
+			} else {
+				hashVal = accessors[col].Hash(row)
+			}
+			fieldValues[col] = fieldValueAt(accessors[col], row)
+		}
+		for idx := range aggs {
+			fieldValues[numKeys+idx] = NewNullFieldValue()
+		}
+		group := NewRow(fieldValues)
+		bucket := buckets[hashVal]
+		if bucket == nil {
+			bucket = NewBucket()
+			buckets[hashVal] = bucket
+		}
+		if groupIdx := bucket.Find(group, numKeys); groupIdx == NONE {
+			bucket.AddRow(group)
+			groups = append(groups, group)
+		} else {
+			group = bucket.RowAt(groupIdx)
+		}
+
+		weight, ok := accessors[countColumn].ValAt(row).(int64)
+		if !ok {
+			return nil, merr.WrapErrServiceInternalMsg("unexpected count(*) type %T of segcore result", accessors[countColumn].ValAt(row))
+		}
+		for idx, aggregate := range aggs {
+			target := group.FieldValueAt(numKeys + idx)
+			input := fieldValueAt(accessors[columns[idx]], row)
+			if sa, ok := aggregate.(stateAggregate); ok {
+				if err := addWeightedToAggState(sa, target, input, weight); err != nil {
+					return nil, err
+				}
+			} else if err := aggregate.Update(target, input); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	templates := make([]*schemapb.FieldData, 0, numKeys+len(aggs))
+	templates = append(templates, fieldDatas[:numKeys]...)
+	for idx, aggregate := range aggs {
+		if _, ok := aggregate.(stateAggregate); ok {
+			template, err := genEmptyFieldDataByType(aggStateType)
+			if err != nil {
+				return nil, err
+			}
+			templates = append(templates, template)
+		} else {
+			templates = append(templates, fieldDatas[columns[idx]])
+		}
+	}
+	ret := typeutil.PrepareResultFieldData(templates, int64(len(groups)))
+	for _, group := range groups {
+		if err := AssembleSingleRow(len(ret), group, ret); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func fieldValueAt(accessor FieldAccessor, row int) *FieldValue {
+	if accessor.IsNullAt(row) {
+		return NewNullFieldValue()
+	}
+	return NewFieldValue(accessor.ValAt(row))
+}
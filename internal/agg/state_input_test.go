@@ -0,0 +1,160 @@
+package agg
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+)
+
+func makeInt64FieldData(vals []int64, validData []bool) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type: schemapb.DataType_Int64,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: vals}},
+			},
+		},
+		ValidData: validData,
+	}
+}
+
+func TestStateInputPlan(t *testing.T) {
+	aggregates := []*planpb.Aggregate{
+		{Op: planpb.AggregateOp_count_distinct, FieldId: 2},
+		{Op: planpb.AggregateOp_sum, FieldId: 2},
+		{Op: planpb.AggregateOp_approx_count_distinct, FieldId: 1},
+		{Op: planpb.AggregateOp_count_distinct, FieldId: 3},
+	}
+	assert.True(t, HasStateAggregates(aggregates))
+	assert.False(t, HasStateAggregates(aggregates[1:2]))
+
+	plan := NewStateInputPlan([]int64{1}, aggregates)
+	// field 1 is a grouping key and field 3 is deduplicated
+	assert.Equal(t, []int64{1, 2, 3}, plan.SegcoreGroupByFieldIDs())
+	assert.Equal(t, []*planpb.Aggregate{
+		{Op: planpb.AggregateOp_sum, FieldId: 2},
+		{Op: planpb.AggregateOp_count},
+	}, plan.SegcoreAggregates())
+}
+
+func TestFoldSegcoreResult(t *testing.T) {
+	schema := makeTestSchema()
+	aggregates := []*planpb.Aggregate{
+		{Op: planpb.AggregateOp_count_distinct, FieldId: 2},
+		{Op: planpb.AggregateOp_sum, FieldId: 2},
+		{Op: planpb.AggregateOp_count},
+	}
+	plan := NewStateInputPlan([]int64{1}, aggregates)
+
+	// | category | value | sum(value) | count(*) | count(*) |
+	segment1, err := plan.FoldSegcoreResult([]*schemapb.FieldData{
+		makeStringFieldData("a", "a", "b", "a"),
+		makeInt64FieldData([]int64{1, 2, 1, 0}, []bool{true, true, true, false}),
+		makeInt64FieldData([]int64{2, 2, 3, 0}, []bool{true, true, true, false}),
+		makeInt64FieldData([]int64{2, 1, 3, 4}, nil),
+		makeInt64FieldData([]int64{2, 1, 3, 4}, nil),
+	})
+	require.NoError(t, err)
+	require.Len(t, segment1, 4)
+	assert.Equal(t, []string{"a", "b"}, segment1[0].GetScalars().GetStringData().GetData())
+	assert.Equal(t, aggStateType, segment1[1].GetType())
+	assert.Equal(t, []int64{4, 3}, segment1[2].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []int64{7, 3}, segment1[3].GetScalars().GetLongData().GetData())
+
+	segment2, err := plan.FoldSegcoreResult([]*schemapb.FieldData{
+		makeStringFieldData("a", "c"),
+		makeInt64FieldData([]int64{3, 5}, nil),
+		makeInt64FieldData([]int64{3, 5}, nil),
+		makeInt64FieldData([]int64{1, 1}, nil),
+		makeInt64FieldData([]int64{1, 1}, nil),
+	})
+	require.NoError(t, err)
+
+	reducer := NewGroupAggReducer([]int64{1}, aggregates, -1, schema)
+	out, err := reducer.Reduce(context.Background(), []*AggregationResult{
+		NewAggregationResult(segment1, 0),
+		NewAggregationResult(segment2, 0),
+	})
+	require.NoError(t, err)
+	aggregate, err := FromPB(aggregates[0])
+	require.NoError(t, err)
+	counts, err := FinalizeAggState(aggregate, out.GetFieldDatas()[1])
+	require.NoError(t, err)
+	got := make(map[string][]int64)
+	for i, key := range out.GetFieldDatas()[0].GetScalars().GetStringData().GetData() {
+		got[key] = []int64{
+			counts.GetScalars().GetLongData().GetData()[i],
+			out.GetFieldDatas()[2].GetScalars().GetLongData().GetData()[i],
+			out.GetFieldDatas()[3].GetScalars().GetLongData().GetData()[i],
+		}
+	}
+	assert.Equal(t, map[string][]int64{"a": {3, 7, 8}, "b": {1, 3, 3}, "c": {1, 5, 1}}, got)
+
+	t.Run("global", func(t *testing.T) {
+		plan := NewStateInputPlan(nil, aggregates[:1])
+		folded, err := plan.FoldSegcoreResult([]*schemapb.FieldData{
+			makeInt64FieldData([]int64{1, 2, 3}, nil),
+			makeInt64FieldData([]int64{5, 1, 1}, nil),
+		})
+		require.NoError(t, err)
+		require.Len(t, folded, 1)
+		counts, err := FinalizeAggState(aggregate, folded[0])
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, counts.GetScalars().GetLongData().GetData())
+
+		// segments without any row have no group
+		folded, err = plan.FoldSegcoreResult([]*schemapb.FieldData{
+			makeInt64FieldData(nil, nil),
+			makeInt64FieldData(nil, nil),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, folded)
+	})
+
+	t.Run("wrong layout", func(t *testing.T) {
+		_, err := plan.FoldSegcoreResult([]*schemapb.FieldData{makeStringFieldData("a")})
+		assert.Error(t, err)
+		_, err = plan.FoldSegcoreResult([]*schemapb.FieldData{
+			makeStringFieldData("a"),
+			makeInt64FieldData([]int64{1, 2}, nil),
+			makeInt64FieldData([]int64{1}, nil),
+			makeInt64FieldData([]int64{1}, nil),
+			makeInt64FieldData([]int64{1}, nil),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestFoldSegcoreResultStatistical(t *testing.T) {
+	aggregates := []*planpb.Aggregate{
+		{Op: planpb.AggregateOp_variance, FieldId: 2},
+		{Op: planpb.AggregateOp_stddev, FieldId: 2},
+		{Op: planpb.AggregateOp_percentile, FieldId: 2, Percentile: 0.5},
+	}
+	plan := NewStateInputPlan(nil, aggregates)
+	assert.Equal(t, []int64{2}, plan.SegcoreGroupByFieldIDs())
+
+	// values 1, 1, 1, 2, 6 grouped by value
+	folded, err := plan.FoldSegcoreResult([]*schemapb.FieldData{
+		makeInt64FieldData([]int64{1, 2, 6}, nil),
+		makeInt64FieldData([]int64{3, 1, 1}, nil),
+	})
+	require.NoError(t, err)
+	require.Len(t, folded, 3)
+
+	expected := []float64{4.7, math.Sqrt(4.7), 1}
+	for idx, aggPb := range aggregates {
+		aggregate, err := FromPB(aggPb)
+		require.NoError(t, err)
+		finalized, err := FinalizeAggState(aggregate, folded[idx])
+		require.NoError(t, err)
+		require.Len(t, finalized.GetScalars().GetDoubleData().GetData(), 1)
+		assert.InDelta(t, expected[idx], finalized.GetScalars().GetDoubleData().GetData()[0], 1e-9)
+	}
+}
@@ -0,0 +1,213 @@
+package agg
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const (
+	// tdigestCompression bounds the number of centroids of a t-digest to about
+	// the compression, the rank error is about 1/compression in the middle and
+	// much smaller near the tails.
+	tdigestCompression = 100
+	// tdigestBufferSize is the number of values buffered before they're merged into the centroids.
+	tdigestBufferSize = 5 * tdigestCompression
+)
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// tdigest is a merging t-digest, a sketch of the distribution of the values
+// to estimate quantiles. Digests built anywhere in the cluster share the same
+// compression, so they can be merged by merging their centroids.
+type tdigest struct {
+	centroids []centroid // sorted by mean
+	buffer    []centroid // not merged yet
+	count     float64
+	min       float64
+	max       float64
+}
+
+func newTDigest() *tdigest {
+	return &tdigest{min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// add adds a raw field value into the digest, NaN is ignored as it has no rank.
+func (t *tdigest) add(v any) error {
+	x, err := fieldValueToFloat64(v)
+	if err != nil {
+		return err
+	}
+	if math.IsNaN(x) {
+		return nil
+	}
+	t.addCentroid(centroid{mean: x, weight: 1})
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+	return nil
+}
+
+// addWeighted adds a value occurring weight times into the digest. The occurrences are added
+// as unit centroids like add does, a single heavy centroid would be treated as spread around
+// the value by quantile, while the compression merges the equal values anyway.
+func (t *tdigest) addWeighted(v any, weight int64) error {
+	x, err := fieldValueToFloat64(v)
+	if err != nil {
+		return err
+	}
+	if math.IsNaN(x) {
+		return nil
+	}
+	for i := int64(0); i < weight; i++ {
+		t.addCentroid(centroid{mean: x, weight: 1})
+	}
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+	return nil
+}
+
+func (t *tdigest) addCentroid(c centroid) {
+	t.buffer = append(t.buffer, c)
+	t.count += c.weight
+	if len(t.buffer) >= tdigestBufferSize {
+		t.compress()
+	}
+}
+
+func (t *tdigest) merge(other aggState) error {
+	o, ok := other.(*tdigest)
+	if !ok {
+		return merr.WrapErrServiceInternalMsg("cannot merge %T into t-digest", other)
+	}
+	if o.count == 0 {
+		return nil
+	}
+	for _, c := range o.centroids {
+		t.addCentroid(c)
+	}
+	for _, c := range o.buffer {
+		t.addCentroid(c)
+	}
+	t.min = math.Min(t.min, o.min)
+	t.max = math.Max(t.max, o.max)
+	return nil
+}
+
+// tdigestScale is the k1 scale function of the t-digest, a centroid can only span
+// a unit of scale, so the centroids are small near the tails and large in the middle.
+func tdigestScale(q float64) float64 {
+	q = math.Max(0, math.Min(1, q))
+	return tdigestCompression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// compress merges the buffered values into the centroids.
+func (t *tdigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+	all := make([]centroid, 0, len(t.centroids)+len(t.buffer))
+	all = append(all, t.centroids...)
+	all = append(all, t.buffer...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(t.centroids)+1)
+	cur := all[0]
+	weightSoFar := 0.0
+	lowerScale := tdigestScale(0)
+	for _, c := range all[1:] {
+		if tdigestScale((weightSoFar+cur.weight+c.weight)/t.count)-lowerScale <= 1 {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		weightSoFar += cur.weight
+		merged = append(merged, cur)
+		lowerScale = tdigestScale(weightSoFar / t.count)
+		cur = c
+	}
+	t.centroids = append(merged, cur)
+	t.buffer = t.buffer[:0]
+}
+
+// quantile returns the estimated value at rank q in [0, 1], it's undefined for an empty digest.
+// The weight of a centroid is treated as spread around its mean, and the quantile is
+// interpolated between the centers of the adjacent centroids and the min and max values.
+func (t *tdigest) quantile(q float64) (float64, bool) {
+	t.compress()
+	if len(t.centroids) == 0 {
+		return 0, false
+	}
+	if q <= 0 {
+		return t.min, true
+	}
+	if q >= 1 {
+		return t.max, true
+	}
+	target := q * t.count
+	first := t.centroids[0]
+	if target < first.weight/2 {
+		return t.min + (first.mean-t.min)*target/(first.weight/2), true
+	}
+	weightSoFar := 0.0
+	for i := 0; i+1 < len(t.centroids); i++ {
+		cur, next := t.centroids[i], t.centroids[i+1]
+		center := weightSoFar + cur.weight/2
+		nextCenter := weightSoFar + cur.weight + next.weight/2
+		if target <= nextCenter {
+			return cur.mean + (next.mean-cur.mean)*(target-center)/(nextCenter-center), true
+		}
+		weightSoFar += cur.weight
+	}
+	last := t.centroids[len(t.centroids)-1]
+	center := weightSoFar + last.weight/2
+	if t.count <= center {
+		return last.mean, true
+	}
+	return last.mean + (t.max-last.mean)*(target-center)/(t.count-center), true
+}
+
+// marshal encodes the digest as |kind|min|max|count uvarint|(mean|weight)...|,
+// floats are little endian IEEE 754.
+func (t *tdigest) marshal() []byte {
+	t.compress()
+	buf := make([]byte, 0, 1+16+binary.MaxVarintLen64+16*len(t.centroids))
+	buf = append(buf, tdigestStateKind)
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(t.min))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(t.max))
+	buf = binary.AppendUvarint(buf, uint64(len(t.centroids)))
+	for _, c := range t.centroids {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(c.mean))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(c.weight))
+	}
+	return buf
+}
+
+func unmarshalTDigest(data []byte) (*tdigest, error) {
+	data = data[1:]
+	if len(data) < 16 {
+		return nil, merr.WrapErrServiceInternalMsg("invalid t-digest, size %d", len(data))
+	}
+	t := newTDigest()
+	t.min = math.Float64frombits(binary.LittleEndian.Uint64(data))
+	t.max = math.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+	data = data[16:]
+	n, read := binary.Uvarint(data)
+	if read <= 0 || uint64(len(data)-read) != 16*n {
+		return nil, merr.WrapErrServiceInternalMsg("invalid t-digest centroids")
+	}
+	data = data[read:]
+	t.centroids = make([]centroid, n)
+	for i := range t.centroids {
+		t.centroids[i].mean = math.Float64frombits(binary.LittleEndian.Uint64(data[16*i:]))
+		t.centroids[i].weight = math.Float64frombits(binary.LittleEndian.Uint64(data[16*i+8:]))
+		t.count += t.centroids[i].weight
+	}
+	return t, nil
+}
@@ -0,0 +1,78 @@
+package agg
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTDigestQuantile(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	values := make([]float64, 100000)
+	// two digests built from the halves are merged in serialized form
+	left, right := newTDigest(), newTDigest()
+	for i := range values {
+		values[i] = r.ExpFloat64() * 100
+		digest := left
+		if i%2 == 1 {
+			digest = right
+		}
+		require.NoError(t, digest.add(values[i]))
+	}
+	decoded, err := unmarshalAggState(right.marshal())
+	require.NoError(t, err)
+	require.NoError(t, left.merge(decoded))
+	assert.LessOrEqual(t, len(left.centroids), 2*tdigestCompression)
+
+	sort.Float64s(values)
+	for _, q := range []float64{0.01, 0.1, 0.5, 0.9, 0.99, 0.999} {
+		got, ok := left.quantile(q)
+		require.True(t, ok)
+		// the rank error is bounded rather than the value error
+		rank := float64(sort.SearchFloat64s(values, got)) / float64(len(values))
+		assert.InDelta(t, q, rank, 0.01, "q=%v", q)
+	}
+	got, _ := left.quantile(0)
+	assert.Equal(t, values[0], got)
+	got, _ = left.quantile(1)
+	assert.Equal(t, values[len(values)-1], got)
+}
+
+func TestTDigestEdgeCases(t *testing.T) {
+	digest := newTDigest()
+	_, ok := digest.quantile(0.5)
+	assert.False(t, ok)
+	require.NoError(t, digest.merge(newTDigest()))
+
+	require.NoError(t, digest.add(7.0))
+	got, ok := digest.quantile(0.3)
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, got)
+
+	require.Error(t, digest.add("a"))
+	require.Error(t, digest.merge(&momentsState{}))
+
+	_, err := unmarshalAggState([]byte{tdigestStateKind, 1})
+	assert.Error(t, err)
+	_, err = unmarshalAggState(append(newTDigest().marshal(), 1))
+	assert.Error(t, err)
+}
+
+func TestMomentsStateMarshal(t *testing.T) {
+	state := &momentsState{}
+	for _, v := range []float64{1, 2, 3} {
+		require.NoError(t, state.add(v))
+	}
+	decoded, err := unmarshalAggState(state.marshal())
+	require.NoError(t, err)
+	assert.Equal(t, state, decoded)
+	require.NoError(t, decoded.merge(&momentsState{}))
+	assert.Equal(t, state, decoded)
+	require.Error(t, decoded.merge(newTDigest()))
+
+	_, err = unmarshalAggState([]byte{momentsStateKind, 1, 2})
+	assert.Error(t, err)
+}
@@ -38,6 +38,34 @@ func isSupportedAggFieldType(aggregateName string, dt schemapb.DataType) bool {
 		default:
 			return false
 		}
+	case kVariance, kStddev, kPercentile, kMedian:
+		switch dt {
+		case schemapb.DataType_Int8,
+			schemapb.DataType_Int16,
+			schemapb.DataType_Int32,
+			schemapb.DataType_Int64,
+			schemapb.DataType_Float,
+			schemapb.DataType_Double:
+			return true
+		default:
+			return false
+		}
+	case kCountDistinct, kApproxCountDistinct:
+		switch dt {
+		case schemapb.DataType_Bool,
+			schemapb.DataType_Int8,
+			schemapb.DataType_Int16,
+			schemapb.DataType_Int32,
+			schemapb.DataType_Int64,
+			schemapb.DataType_Float,
+			schemapb.DataType_Double,
+			schemapb.DataType_VarChar,
+			schemapb.DataType_String,
+			schemapb.DataType_Timestamptz:
+			return true
+		default:
+			return false
+		}
 	default:
 		// operator validity is handled by NewAggregate; keep this conservative.
 		return false
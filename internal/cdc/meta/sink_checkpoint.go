@@ -0,0 +1,66 @@
+package meta
+
+import (
+	"context"
+	"path"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// SinkCheckpointPrefix is the prefix of the checkpoints of the replications
+// that write into a sink rather than a milvus cluster.
+// A milvus cluster keeps the checkpoint of replication by itself (GetReplicateInfo),
+// but a sink can not, so CDC keeps it in the metastore of the source cluster.
+const SinkCheckpointPrefix = "cdc-meta/sink-checkpoint/"
+
+// BuildSinkCheckpointKey returns the key of the sink checkpoint of the replicate pchannel.
+// The name of replicate pchannel key is unique by target cluster and source channel.
+func BuildSinkCheckpointKey(rootPath string, replicatePChannelKey string) string {
+	return path.Join(rootPath, SinkCheckpointPrefix, path.Base(replicatePChannelKey))
+}
+
+// GetSinkCheckpoint gets the sink checkpoint from metastore, nil is returned if the checkpoint is not found.
+func GetSinkCheckpoint(ctx context.Context, etcdCli *clientv3.Client, key string) (*commonpb.ReplicateCheckpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := etcdCli.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	checkpoint := &commonpb.ReplicateCheckpoint{}
+	if err := proto.Unmarshal(resp.Kvs[0].Value, checkpoint); err != nil {
+		return nil, merr.Wrapf(err, "unmarshal sink checkpoint %s failed", key)
+	}
+	return checkpoint, nil
+}
+
+// SaveSinkCheckpoint saves the sink checkpoint into metastore.
+func SaveSinkCheckpoint(ctx context.Context, etcdCli *clientv3.Client, key string, checkpoint *commonpb.ReplicateCheckpoint) error {
+	value, err := proto.Marshal(checkpoint)
+	if err != nil {
+		return merr.Wrapf(err, "marshal sink checkpoint %s failed", key)
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err = etcdCli.Put(ctx, key, string(value))
+	return err
+}
+
+// RemoveSinkCheckpoint removes the sink checkpoint from metastore,
+// should be called when the replication into the sink is removed.
+func RemoveSinkCheckpoint(ctx context.Context, etcdCli *clientv3.Client, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err := etcdCli.Delete(ctx, key)
+	return err
+}
@@ -0,0 +1,66 @@
+package meta
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+)
+
+func startEmbedEtcdServer(t *testing.T) *embed.Etcd {
+	config := embed.NewConfig()
+	config.Dir = t.TempDir()
+	config.LogLevel = "warn"
+	config.LogOutputs = []string{"default"}
+	u, err := url.Parse("http://localhost:0")
+	require.NoError(t, err)
+	config.ListenClientUrls = []url.URL{*u}
+	config.ListenPeerUrls = []url.URL{*u}
+	e, err := embed.StartEtcd(config)
+	require.NoError(t, err)
+	<-e.Server.ReadyNotify()
+	t.Cleanup(e.Close)
+	return e
+}
+
+func TestSinkCheckpoint(t *testing.T) {
+	etcdCli := v3client.New(startEmbedEtcdServer(t).Server)
+	ctx := context.Background()
+
+	key := BuildSinkCheckpointKey("by-dev/meta", "by-dev/meta/streamingcoord-meta/replicating-pchannel/kafka-by-dev-dml_0")
+	assert.Equal(t, "by-dev/meta/cdc-meta/sink-checkpoint/kafka-by-dev-dml_0", key)
+
+	checkpoint, err := GetSinkCheckpoint(ctx, etcdCli, key)
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	err = SaveSinkCheckpoint(ctx, etcdCli, key, &commonpb.ReplicateCheckpoint{
+		ClusterId: "by-dev",
+		Pchannel:  "by-dev-dml_0",
+		MessageId: &commonpb.MessageID{Id: "1", WALName: commonpb.WALName_Kafka},
+		TimeTick:  100,
+	})
+	assert.NoError(t, err)
+	checkpoint, err = GetSinkCheckpoint(ctx, etcdCli, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "by-dev-dml_0", checkpoint.GetPchannel())
+	assert.Equal(t, "1", checkpoint.GetMessageId().GetId())
+	assert.Equal(t, uint64(100), checkpoint.GetTimeTick())
+
+	_, err = etcdCli.Put(ctx, key, "corrupted")
+	assert.NoError(t, err)
+	_, err = GetSinkCheckpoint(ctx, etcdCli, key)
+	assert.Error(t, err)
+
+	err = RemoveSinkCheckpoint(ctx, etcdCli, key)
+	assert.NoError(t, err)
+	checkpoint, err = GetSinkCheckpoint(ctx, etcdCli, key)
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
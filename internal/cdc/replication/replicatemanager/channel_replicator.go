@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus/internal/cdc/cluster"
 	"github.com/milvus-io/milvus/internal/cdc/meta"
 	"github.com/milvus-io/milvus/internal/cdc/replication/replicatestream"
+	"github.com/milvus-io/milvus/internal/cdc/replication/sink"
 	"github.com/milvus-io/milvus/internal/cdc/util"
 	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/internal/streamingnode/server/wal/utility"
@@ -51,15 +52,22 @@ type Replicator interface {
 
 var _ Replicator = (*channelReplicator)(nil)
 
+// CreateSinkStreamClientFunc creates the stream client that replicates the message into the sink of the channel.
+type CreateSinkStreamClientFunc func(ctx context.Context, channel *meta.ReplicateChannel) (replicatestream.ReplicateStreamClient, error)
+
 // channelReplicator is the implementation of ChannelReplicator.
+// The target of the channel is either a milvus cluster or a sink (kafka, files) selected by the scheme of target uri.
 type channelReplicator struct {
-	channel       *meta.ReplicateChannel
-	createRscFunc replicatestream.CreateReplicateStreamClientFunc
-	createMcFunc  cluster.CreateMilvusClientFunc
-	targetClient  cluster.MilvusClient
-	streamClient  replicatestream.ReplicateStreamClient
-	msgScanner    streaming.Scanner
-	msgChan       adaptor.ChanMessageHandler
+	channel          *meta.ReplicateChannel
+	createRscFunc    replicatestream.CreateReplicateStreamClientFunc
+	createMcFunc     cluster.CreateMilvusClientFunc
+	createSinkScFunc CreateSinkStreamClientFunc
+	// sinkTarget is true if the target is a sink, the target client is not used.
+	sinkTarget   bool
+	targetClient cluster.MilvusClient
+	streamClient replicatestream.ReplicateStreamClient
+	msgScanner   streaming.Scanner
+	msgChan      adaptor.ChanMessageHandler
 
 	asyncNotifier *syncutil.AsyncTaskNotifier[struct{}]
 }
@@ -68,10 +76,12 @@ type channelReplicator struct {
 func NewChannelReplicator(channel *meta.ReplicateChannel) Replicator {
 	createRscFunc := replicatestream.NewReplicateStreamClient
 	return &channelReplicator{
-		channel:       channel,
-		createRscFunc: createRscFunc,
-		createMcFunc:  cluster.NewMilvusClient,
-		asyncNotifier: syncutil.NewAsyncTaskNotifier[struct{}](),
+		channel:          channel,
+		createRscFunc:    createRscFunc,
+		createMcFunc:     cluster.NewMilvusClient,
+		createSinkScFunc: sink.NewSinkStreamClient,
+		sinkTarget:       sink.IsSinkURI(channel.Value.GetTargetCluster().GetConnectionParam().GetUri()),
+		asyncNotifier:    syncutil.NewAsyncTaskNotifier[struct{}](),
 	}
 }
 
@@ -128,7 +138,7 @@ func (r *channelReplicator) initLastReplicatedTimeTickMetric() {
 func (r *channelReplicator) init() error {
 	logger := mlog.With(mlog.String("key", r.channel.Key), mlog.Int64("modRevision", r.channel.ModRevision))
 	// init target client
-	if r.targetClient == nil {
+	if !r.sinkTarget && r.targetClient == nil {
 		dialCtx, dialCancel := context.WithTimeout(r.asyncNotifier.Context(), 30*time.Second)
 		defer dialCancel()
 		milvusClient, err := r.createMcFunc(dialCtx, r.channel.Value.GetTargetCluster())
@@ -163,8 +173,16 @@ func (r *channelReplicator) init() error {
 	}
 	// init replicate stream client
 	if r.streamClient == nil {
-		r.streamClient = r.createRscFunc(r.asyncNotifier.Context(), r.targetClient, r.channel)
-		logger.Info(context.TODO(), "stream client initialized")
+		if r.sinkTarget {
+			streamClient, err := r.createSinkScFunc(r.asyncNotifier.Context(), r.channel)
+			if err != nil {
+				return err
+			}
+			r.streamClient = streamClient
+		} else {
+			r.streamClient = r.createRscFunc(r.asyncNotifier.Context(), r.targetClient, r.channel)
+		}
+		logger.Info(context.TODO(), "stream client initialized", mlog.Bool("sink", r.sinkTarget))
 	}
 	return nil
 }
@@ -206,6 +224,11 @@ func (r *channelReplicator) startConsumeLoop() {
 func (r *channelReplicator) getReplicateCheckpoint() (*utility.ReplicateCheckpoint, error) {
 	logger := mlog.With(mlog.String("key", r.channel.Key), mlog.Int64("modRevision", r.channel.ModRevision))
 
+	// A sink can not keep the checkpoint by itself, the checkpoint is kept in the metastore by CDC.
+	if r.sinkTarget {
+		return r.getSinkCheckpoint()
+	}
+
 	// For pchannel-increasing tasks, the secondary WAL for new pchannels hasn't received the
 	// AlterReplicateConfig yet, so GetReplicateInfo would fail. Use InitializedCheckpoint directly.
 	if r.channel.Value.GetSkipGetReplicateCheckpoint() {
@@ -248,6 +271,33 @@ func (r *channelReplicator) getReplicateCheckpoint() (*utility.ReplicateCheckpoi
 	return cp, nil
 }
 
+func (r *channelReplicator) getSinkCheckpoint() (*utility.ReplicateCheckpoint, error) {
+	logger := mlog.With(mlog.String("key", r.channel.Key), mlog.Int64("modRevision", r.channel.ModRevision))
+
+	ctx, cancel := context.WithTimeout(r.asyncNotifier.Context(), 30*time.Second)
+	defer cancel()
+
+	checkpoint, err := sink.GetCheckpoint(ctx, r.channel)
+	if err != nil {
+		return nil, merr.Wrap(err, "failed to get sink checkpoint")
+	}
+	if checkpoint == nil {
+		initializedCheckpoint := utility.NewReplicateCheckpointFromProto(r.channel.Value.InitializedCheckpoint)
+		logger.Info(context.TODO(), "sink checkpoint not found, will start from the beginning",
+			mlog.Stringer("messageID", initializedCheckpoint.MessageID),
+			mlog.Uint64("timeTick", initializedCheckpoint.TimeTick),
+		)
+		return initializedCheckpoint, nil
+	}
+
+	cp := utility.NewReplicateCheckpointFromProto(checkpoint)
+	logger.Info(context.TODO(), "replicate messages into sink from position",
+		mlog.Stringer("messageID", cp.MessageID),
+		mlog.Uint64("timeTick", cp.TimeTick),
+	)
+	return cp, nil
+}
+
 func (r *channelReplicator) StopReplication() {
 	r.asyncNotifier.Cancel()
 	r.asyncNotifier.BlockUntilFinish()
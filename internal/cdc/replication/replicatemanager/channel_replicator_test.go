@@ -18,6 +18,7 @@ package replicatemanager
 
 import (
 	"context"
+	"net/url"
 	"testing"
 	"time"
 
@@ -25,12 +26,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/cdc/cluster"
 	"github.com/milvus-io/milvus/internal/cdc/meta"
 	"github.com/milvus-io/milvus/internal/cdc/replication/replicatestream"
+	"github.com/milvus-io/milvus/internal/cdc/resource"
 	"github.com/milvus-io/milvus/internal/distributed/streaming"
 	"github.com/milvus-io/milvus/internal/mocks/distributed/mock_streaming"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
@@ -101,6 +106,61 @@ func TestChannelReplicator_StartReplicateChannel(t *testing.T) {
 	replicator.StopReplication()
 }
 
+func TestChannelReplicator_StartReplicateChannelIntoSink(t *testing.T) {
+	config := embed.NewConfig()
+	config.Dir = t.TempDir()
+	config.LogLevel = "warn"
+	config.LogOutputs = []string{"default"}
+	u, err := url.Parse("http://localhost:0")
+	require.NoError(t, err)
+	config.ListenClientUrls = []url.URL{*u}
+	config.ListenPeerUrls = []url.URL{*u}
+	etcdServer, err := embed.StartEtcd(config)
+	require.NoError(t, err)
+	defer etcdServer.Close()
+	<-etcdServer.Server.ReadyNotify()
+	resource.InitForTest(t, resource.OptETCD(v3client.New(etcdServer.Server)))
+
+	scanner := mock_streaming.NewMockScanner(t)
+	scanner.EXPECT().Close().Return()
+	wal := mock_streaming.NewMockWALAccesser(t)
+	wal.EXPECT().Read(mock.Anything, mock.Anything).Return(scanner)
+	streaming.SetWALForTest(wal)
+
+	rs := replicatestream.NewMockReplicateStreamClient(t)
+	rs.EXPECT().Close().Return()
+
+	replicator := NewChannelReplicator(&meta.ReplicateChannel{
+		Key: "sink-test-source-channel",
+		Value: &streamingpb.ReplicatePChannelMeta{
+			SourceChannelName: "test-source-channel",
+			TargetChannelName: "test-target-channel",
+			TargetCluster: &commonpb.MilvusCluster{
+				ClusterId:       "test-sink",
+				ConnectionParam: &commonpb.ConnectionParam{Uri: "kafka://localhost:9092/cdc"},
+			},
+			InitializedCheckpoint: &commonpb.ReplicateCheckpoint{
+				Pchannel:  "test-source-channel",
+				MessageId: newMockPulsarMessageID(),
+			},
+		},
+	})
+	channelReplicator := replicator.(*channelReplicator)
+	assert.True(t, channelReplicator.sinkTarget)
+	// the milvus client is never created for a sink.
+	channelReplicator.createMcFunc = func(ctx context.Context, cluster *commonpb.MilvusCluster) (cluster.MilvusClient, error) {
+		t.Fatal("milvus client should not be created for sink")
+		return nil, nil
+	}
+	channelReplicator.createSinkScFunc = func(ctx context.Context, channel *meta.ReplicateChannel) (replicatestream.ReplicateStreamClient, error) {
+		return rs, nil
+	}
+
+	replicator.StartReplication()
+	time.Sleep(200 * time.Millisecond)
+	replicator.StopReplication()
+}
+
 // TestChannelReplicatorConsumeLoopDeletesLagSeriesOnRemoval verifies the
 // in-band removal path: when the consume loop replicates an
 // AlterReplicateConfig message that removes this replication, the lag series
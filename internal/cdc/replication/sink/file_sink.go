@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/minio/minio-go/v7"
+
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	paramRollBytes   = "rollBytes"
+	defaultRollBytes = 64 * 1024 * 1024
+
+	fileSuffix      = ".ndjson"
+	fileContentType = "application/x-ndjson"
+)
+
+// fileStorage is the storage of the files written by the file sink.
+type fileStorage interface {
+	// Put writes the file, the file is either fully written or not written.
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+var _ Sink = (*fileSink)(nil)
+
+// fileSink writes the records as rolling ndjson files.
+// The records are buffered in memory and rolled into a new file when the buffer exceeds the roll bytes or the sink is flushed.
+// A file is named by the first and last time tick of its records and a sequence number under the directory
+// of target channel, so the files of a channel can be consumed in order by their names.
+// The sequence number increases by every rolled file and starts from the creation time of the sink,
+// so the files rolled within the same time tick, even by a recreated sink, never overwrite each other.
+type fileSink struct {
+	storage   fileStorage
+	dir       string
+	rollBytes int64
+
+	seq           uint64
+	buf           bytes.Buffer
+	firstTimeTick uint64
+	lastTimeTick  uint64
+}
+
+func newFileSink(opts *Options, storage fileStorage) (*fileSink, error) {
+	rollBytes, err := getInt64Param(opts.URL.Query(), paramRollBytes, defaultRollBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{
+		storage:   storage,
+		dir:       opts.TargetChannel,
+		rollBytes: rollBytes,
+		seq:       uint64(time.Now().UnixNano()),
+	}, nil
+}
+
+func (s *fileSink) Write(ctx context.Context, records []*Record) error {
+	for _, r := range records {
+		line, err := FormatJSON.Encode(r)
+		if err != nil {
+			return err
+		}
+		if s.buf.Len() == 0 {
+			s.firstTimeTick = r.TimeTick
+		}
+		s.lastTimeTick = r.TimeTick
+		s.buf.Write(line)
+		s.buf.WriteByte('\n')
+		if int64(s.buf.Len()) >= s.rollBytes {
+			if err := s.roll(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fileSink) Flush(ctx context.Context) error {
+	if s.buf.Len() == 0 {
+		return nil
+	}
+	return s.roll(ctx)
+}
+
+// roll writes the buffered records into a new file.
+func (s *fileSink) roll(ctx context.Context) error {
+	name := path.Join(s.dir, fmt.Sprintf("%020d-%020d-%020d%s", s.firstTimeTick, s.lastTimeTick, s.seq, fileSuffix))
+	if err := s.storage.Put(ctx, name, s.buf.Bytes()); err != nil {
+		return err
+	}
+	s.seq++
+	s.buf.Reset()
+	return nil
+}
+
+func (s *fileSink) Close() {
+	s.buf.Reset()
+}
+
+// localFileStorage writes the files into a local directory.
+type localFileStorage struct {
+	root string
+}
+
+func newLocalFileStorage(root string) (*localFileStorage, error) {
+	if root == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("path is not set in file sink uri")
+	}
+	return &localFileStorage{root: root}, nil
+}
+
+// Put writes the data into a temporary file and renames it to the name after synced,
+// so a partial file is never observed.
+func (s *localFileStorage) Put(ctx context.Context, name string, data []byte) error {
+	filePath := filepath.Join(s.root, filepath.FromSlash(name))
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", dir)
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write file %s", f.Name())
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to sync file %s", f.Name())
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close file %s", f.Name())
+	}
+	if err := os.Rename(f.Name(), filePath); err != nil {
+		return errors.Wrapf(err, "failed to rename file to %s", filePath)
+	}
+	return nil
+}
+
+// objectFileStorage writes the files into a bucket of object storage.
+type objectFileStorage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// newObjectFileStorage creates the object storage with the address and credentials of the minio config,
+// the bucket may be different from the bucket of milvus.
+func newObjectFileStorage(ctx context.Context, params *paramtable.ComponentParam, bucket string, prefix string) (*objectFileStorage, error) {
+	if bucket == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("bucket is not set in object storage sink uri")
+	}
+	c := objectstorage.NewDefaultConfig()
+	for _, opt := range []objectstorage.Option{
+		objectstorage.Address(params.MinioCfg.Address.GetValue()),
+		objectstorage.AccessKeyID(params.MinioCfg.AccessKeyID.GetValue()),
+		objectstorage.SecretAccessKeyID(params.MinioCfg.SecretAccessKey.GetValue()),
+		objectstorage.UseSSL(params.MinioCfg.UseSSL.GetAsBool()),
+		objectstorage.SslCACert(params.MinioCfg.SslCACert.GetValue()),
+		objectstorage.SslTLSMinVersion(params.MinioCfg.SslTLSMinVersion.GetValue()),
+		objectstorage.BucketName(bucket),
+		objectstorage.UseIAM(params.MinioCfg.UseIAM.GetAsBool()),
+		objectstorage.CloudProvider(params.MinioCfg.CloudProvider.GetValue()),
+		objectstorage.IAMEndpoint(params.MinioCfg.IAMEndpoint.GetValue()),
+		objectstorage.UseVirtualHost(params.MinioCfg.UseVirtualHost.GetAsBool()),
+		objectstorage.Region(params.MinioCfg.Region.GetValue()),
+		objectstorage.RequestTimeout(params.MinioCfg.RequestTimeoutMs.GetAsInt64()),
+	} {
+		opt(c)
+	}
+	client, err := objectstorage.NewMinioClient(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return &objectFileStorage{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+// Put uploads the data as an object, the object is visible only after it's fully uploaded.
+func (s *objectFileStorage) Put(ctx context.Context, name string, data []byte) error {
+	key := path.Join(s.prefix, name)
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: fileContentType,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to put object %s", key)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+type failedFileStorage struct{}
+
+func (failedFileStorage) Put(ctx context.Context, name string, data []byte) error {
+	return errors.New("put failed")
+}
+
+func readNDJSON(t *testing.T, filePath string) []*Record {
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	var records []*Record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		r := &Record{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), r))
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	opts, err := ParseOptions(newTestReplicateChannel("file://" + root + "?rollBytes=200"))
+	require.NoError(t, err)
+	s, err := NewSink(ctx, opts)
+	require.NoError(t, err)
+	defer s.Close()
+
+	newRecord := func(timeTick uint64) *Record {
+		return &Record{Op: OpDelete, SourceChannel: "by-dev-dml_0", TimeTick: timeTick, Data: json.RawMessage(`[1]`)}
+	}
+	// the buffer is less than roll bytes, nothing is written.
+	require.NoError(t, s.Write(ctx, []*Record{newRecord(1)}))
+	dir := filepath.Join(root, "sink-dml_0")
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+
+	// the buffer exceeds the roll bytes, a file is rolled.
+	require.NoError(t, s.Write(ctx, []*Record{newRecord(2), newRecord(3)}))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	seq := s.(*fileSink).seq - 1
+	assert.Equal(t, fmt.Sprintf("00000000000000000001-00000000000000000002-%020d.ndjson", seq), entries[0].Name())
+	records := readNDJSON(t, filepath.Join(dir, entries[0].Name()))
+	require.Len(t, records, 2)
+	assert.Equal(t, uint64(1), records[0].TimeTick)
+	assert.Equal(t, uint64(2), records[1].TimeTick)
+
+	// flush writes the rest records.
+	require.NoError(t, s.Flush(ctx))
+	require.NoError(t, s.Flush(ctx))
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, fmt.Sprintf("00000000000000000003-00000000000000000003-%020d.ndjson", seq+1), entries[1].Name())
+	records = readNDJSON(t, filepath.Join(dir, entries[1].Name()))
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(3), records[0].TimeTick)
+
+	// the files rolled within the same time tick are not overwritten.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, s.Write(ctx, []*Record{newRecord(4)}))
+		require.NoError(t, s.Flush(ctx))
+	}
+	// the files of a recreated sink are not overwritten either.
+	s2, err := NewSink(ctx, opts)
+	require.NoError(t, err)
+	defer s2.Close()
+	require.NoError(t, s2.Write(ctx, []*Record{newRecord(4)}))
+	require.NoError(t, s2.Flush(ctx))
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	for _, entry := range entries[2:] {
+		assert.True(t, strings.HasPrefix(entry.Name(), "00000000000000000004-00000000000000000004-"))
+		records = readNDJSON(t, filepath.Join(dir, entry.Name()))
+		require.Len(t, records, 1)
+		assert.Equal(t, uint64(4), records[0].TimeTick)
+	}
+
+	// the buffered records are kept if the storage fails.
+	fs := &fileSink{storage: failedFileStorage{}, dir: "sink-dml_0", rollBytes: defaultRollBytes}
+	require.NoError(t, fs.Write(ctx, []*Record{newRecord(5)}))
+	assert.Error(t, fs.Flush(ctx))
+	assert.NotZero(t, fs.buf.Len())
+}
+
+func TestLocalFileStorage(t *testing.T) {
+	_, err := newLocalFileStorage("")
+	assert.Error(t, err)
+
+	root := t.TempDir()
+	storage, err := newLocalFileStorage(root)
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(context.Background(), "a/b.ndjson", []byte("data")))
+	data, err := os.ReadFile(filepath.Join(root, "a", "b.ndjson"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+	// no temporary file is left.
+	entries, err := os.ReadDir(filepath.Join(root, "a"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// the parent is a file.
+	assert.Error(t, storage.Put(context.Background(), "a/b.ndjson/c.ndjson", []byte("data")))
+}
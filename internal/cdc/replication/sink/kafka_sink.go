@@ -0,0 +1,192 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const (
+	// kafkaConfigPrefix is the prefix of the query parameters passed to the kafka producer config.
+	kafkaConfigPrefix = "kafka."
+
+	kafkaHeaderFormat        = "format"
+	kafkaHeaderSourceChannel = "source_channel"
+	kafkaHeaderTargetChannel = "target_channel"
+
+	kafkaFlushTimeoutMs = 1000
+)
+
+// kafkaProducer is the subset of kafka.Producer used by the kafka sink.
+type kafkaProducer interface {
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+	Flush(timeoutMs int) int
+	Close()
+}
+
+var _ Sink = (*kafkaSink)(nil)
+
+// kafkaSink publishes every record as a kafka message into the topic.
+// The records of a collection are keyed by the collection id, so they keep the order in a kafka partition.
+type kafkaSink struct {
+	producer      kafkaProducer
+	topic         string
+	format        Format
+	targetChannel string
+
+	deliveryCh chan kafka.Event
+	// pending is the count of the produced messages that are not delivered.
+	pending int
+	// err is the first delivery error since last flush.
+	err error
+}
+
+func newKafkaSink(opts *Options) (*kafkaSink, error) {
+	topic := strings.Trim(opts.URL.Path, "/")
+	if topic == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("topic is not set in kafka sink uri")
+	}
+	if opts.URL.Host == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("broker is not set in kafka sink uri")
+	}
+	config := kafka.ConfigMap{
+		"bootstrap.servers":  opts.URL.Host,
+		"enable.idempotence": true,
+	}
+	if user := opts.URL.User; user != nil {
+		password, _ := user.Password()
+		config.SetKey("sasl.username", user.Username())
+		config.SetKey("sasl.password", password)
+	}
+	for key, values := range opts.URL.Query() {
+		if strings.HasPrefix(key, kafkaConfigPrefix) && len(values) > 0 {
+			config.SetKey(strings.TrimPrefix(key, kafkaConfigPrefix), values[0])
+		}
+	}
+	producer, err := kafka.NewProducer(&config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create kafka producer")
+	}
+	return newKafkaSinkWithProducer(producer, topic, opts), nil
+}
+
+func newKafkaSinkWithProducer(producer kafkaProducer, topic string, opts *Options) *kafkaSink {
+	return &kafkaSink{
+		producer:      producer,
+		topic:         topic,
+		format:        opts.Format,
+		targetChannel: opts.TargetChannel,
+		deliveryCh:    make(chan kafka.Event, 1024),
+	}
+}
+
+func (s *kafkaSink) Write(ctx context.Context, records []*Record) error {
+	for _, r := range records {
+		value, err := s.format.Encode(r)
+		if err != nil {
+			return err
+		}
+		msg := &kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &s.topic, Partition: kafka.PartitionAny},
+			Key:            []byte(strconv.FormatInt(r.CollectionID, 10)),
+			Value:          value,
+			Headers: []kafka.Header{
+				{Key: kafkaHeaderFormat, Value: []byte(s.format)},
+				{Key: kafkaHeaderSourceChannel, Value: []byte(r.SourceChannel)},
+				{Key: kafkaHeaderTargetChannel, Value: []byte(s.targetChannel)},
+			},
+		}
+		for {
+			err := s.producer.Produce(msg, s.deliveryCh)
+			if err == nil {
+				s.pending++
+				break
+			}
+			var kafkaErr kafka.Error
+			if !errors.As(err, &kafkaErr) || kafkaErr.Code() != kafka.ErrQueueFull {
+				return errors.Wrap(err, "failed to produce kafka message")
+			}
+			// the local queue of producer is full, wait for the delivery and retry.
+			if err := s.waitDelivery(ctx); err != nil {
+				return err
+			}
+		}
+		// drain the delivered events to avoid blocking the producer.
+		s.drainDelivery()
+	}
+	return nil
+}
+
+func (s *kafkaSink) Flush(ctx context.Context) error {
+	for s.pending > 0 {
+		s.producer.Flush(kafkaFlushTimeoutMs)
+		if err := s.waitDelivery(ctx); err != nil {
+			return err
+		}
+		s.drainDelivery()
+	}
+	err := s.err
+	s.err = nil
+	return err
+}
+
+// waitDelivery waits for one delivery event.
+func (s *kafkaSink) waitDelivery(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-s.deliveryCh:
+		s.onDelivery(e)
+		return nil
+	case <-time.After(kafkaFlushTimeoutMs * time.Millisecond):
+		return nil
+	}
+}
+
+func (s *kafkaSink) drainDelivery() {
+	for {
+		select {
+		case e := <-s.deliveryCh:
+			s.onDelivery(e)
+		default:
+			return
+		}
+	}
+}
+
+func (s *kafkaSink) onDelivery(e kafka.Event) {
+	msg, ok := e.(*kafka.Message)
+	if !ok {
+		return
+	}
+	s.pending--
+	if msg.TopicPartition.Error != nil && s.err == nil {
+		s.err = errors.Wrap(msg.TopicPartition.Error, "failed to deliver kafka message")
+	}
+}
+
+func (s *kafkaSink) Close() {
+	s.producer.Close()
+}
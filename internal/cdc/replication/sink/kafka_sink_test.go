@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+// fakeKafkaProducer delivers the produced messages when it's flushed.
+type fakeKafkaProducer struct {
+	produced   []*kafka.Message
+	undeliverd []*kafka.Message
+	deliveryCh chan kafka.Event
+	// queueFull is the count of the produce calls that fail by queue full.
+	queueFull int
+	// deliveryErr is the error of the delivered messages.
+	deliveryErr error
+	closed      bool
+}
+
+func (p *fakeKafkaProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	if p.queueFull > 0 {
+		p.queueFull--
+		p.deliveryCh = deliveryChan
+		p.deliver()
+		return kafka.NewError(kafka.ErrQueueFull, "queue full", false)
+	}
+	p.produced = append(p.produced, msg)
+	p.undeliverd = append(p.undeliverd, msg)
+	p.deliveryCh = deliveryChan
+	return nil
+}
+
+func (p *fakeKafkaProducer) Flush(timeoutMs int) int {
+	p.deliver()
+	return 0
+}
+
+func (p *fakeKafkaProducer) deliver() {
+	for _, msg := range p.undeliverd {
+		msg.TopicPartition.Error = p.deliveryErr
+		p.deliveryCh <- msg
+	}
+	p.undeliverd = nil
+}
+
+func (p *fakeKafkaProducer) Close() {
+	p.closed = true
+}
+
+func TestKafkaSink(t *testing.T) {
+	ctx := context.Background()
+	opts, err := ParseOptions(newTestReplicateChannel("kafka://localhost:9092/cdc"))
+	require.NoError(t, err)
+	producer := &fakeKafkaProducer{}
+	s := newKafkaSinkWithProducer(producer, "cdc", opts)
+
+	records := []*Record{
+		{Op: OpInsert, SourceChannel: "by-dev-dml_0", CollectionID: 1, TimeTick: 1, Data: json.RawMessage(`[{"pk":1}]`)},
+		{Op: OpDelete, SourceChannel: "by-dev-dml_0", CollectionID: 2, TimeTick: 2, Data: json.RawMessage(`[1]`)},
+	}
+	require.NoError(t, s.Write(ctx, records))
+	assert.Equal(t, 2, s.pending)
+	require.NoError(t, s.Flush(ctx))
+	assert.Equal(t, 0, s.pending)
+	require.Len(t, producer.produced, 2)
+	msg := producer.produced[0]
+	assert.Equal(t, "cdc", *msg.TopicPartition.Topic)
+	assert.Equal(t, "1", string(msg.Key))
+	got := &Record{}
+	require.NoError(t, json.Unmarshal(msg.Value, got))
+	assert.Equal(t, records[0], got)
+	assert.Equal(t, []kafka.Header{
+		{Key: kafkaHeaderFormat, Value: []byte(FormatJSON)},
+		{Key: kafkaHeaderSourceChannel, Value: []byte("by-dev-dml_0")},
+		{Key: kafkaHeaderTargetChannel, Value: []byte("sink-dml_0")},
+	}, msg.Headers)
+
+	// retry if the queue of producer is full.
+	producer.queueFull = 1
+	require.NoError(t, s.Write(ctx, records[:1]))
+	require.NoError(t, s.Flush(ctx))
+	assert.Len(t, producer.produced, 3)
+
+	// the delivery error is returned by flush.
+	producer.deliveryErr = kafka.NewError(kafka.ErrMsgTimedOut, "timeout", false)
+	require.NoError(t, s.Write(ctx, records))
+	assert.Error(t, s.Flush(ctx))
+	producer.deliveryErr = nil
+	require.NoError(t, s.Flush(ctx))
+
+	s.Close()
+	assert.True(t, producer.closed)
+}
+
+type failedKafkaProducer struct {
+	fakeKafkaProducer
+}
+
+func (p *failedKafkaProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	return errors.New("produce failed")
+}
+
+func TestKafkaSinkProduceFailed(t *testing.T) {
+	opts, err := ParseOptions(newTestReplicateChannel("kafka://localhost:9092/cdc?format=avro"))
+	require.NoError(t, err)
+	s := newKafkaSinkWithProducer(&failedKafkaProducer{}, "cdc", opts)
+	err = s.Write(context.Background(), []*Record{{Op: OpInsert}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,376 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"github.com/hamba/avro/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const (
+	OpInsert = "insert"
+	OpUpsert = "upsert"
+	OpDelete = "delete"
+	OpDDL    = "ddl"
+)
+
+// ddlMessageTypes is the message types that are written into the sink as ddl records.
+// The rbac, import and internal messages are never written into the sink.
+var ddlMessageTypes = typeutil.NewSet(
+	message.MessageTypeCreateDatabase,
+	message.MessageTypeAlterDatabase,
+	message.MessageTypeDropDatabase,
+	message.MessageTypeCreateCollection,
+	message.MessageTypeAlterCollection,
+	message.MessageTypeDropCollection,
+	message.MessageTypeTruncateCollection,
+	message.MessageTypeSchemaChange,
+	message.MessageTypeCreatePartition,
+	message.MessageTypeDropPartition,
+	message.MessageTypeAlterAlias,
+	message.MessageTypeDropAlias,
+	message.MessageTypeCreateIndex,
+	message.MessageTypeAlterIndex,
+	message.MessageTypeDropIndex,
+)
+
+// Record is the decoded change written into the sink.
+type Record struct {
+	// Op is one of insert, upsert, delete and ddl.
+	Op string `json:"op"`
+	// DDLType is the message type of the ddl record, e.g. CreateCollection.
+	DDLType       string `json:"ddl_type,omitempty"`
+	SourceCluster string `json:"source_cluster"`
+	SourceChannel string `json:"source_channel"`
+	VChannel      string `json:"vchannel,omitempty"`
+	MessageID     string `json:"message_id"`
+	TimeTick      uint64 `json:"time_tick"`
+	// BroadcastID is set if the message is broadcasted to multiple channels,
+	// the consumer can deduplicate the ddl records of different channels by it.
+	BroadcastID    uint64 `json:"broadcast_id,omitempty"`
+	DbName         string `json:"db_name,omitempty"`
+	CollectionName string `json:"collection_name,omitempty"`
+	CollectionID   int64  `json:"collection_id,omitempty"`
+	PartitionName  string `json:"partition_name,omitempty"`
+	NumRows        int64  `json:"num_rows,omitempty"`
+	// Data is the rows of insert and upsert, the primary keys of delete,
+	// and the header and body of ddl.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// recordAvroSchema is the avro schema of the record,
+// the data is kept as json text because the rows of different collections have different schemas.
+var recordAvroSchema = avro.MustParse(`{
+	"type": "record",
+	"name": "Record",
+	"namespace": "io.milvus.cdc",
+	"fields": [
+		{"name": "op", "type": "string"},
+		{"name": "ddl_type", "type": "string", "default": ""},
+		{"name": "source_cluster", "type": "string"},
+		{"name": "source_channel", "type": "string"},
+		{"name": "vchannel", "type": "string", "default": ""},
+		{"name": "message_id", "type": "string"},
+		{"name": "time_tick", "type": "long"},
+		{"name": "broadcast_id", "type": "long", "default": 0},
+		{"name": "db_name", "type": "string", "default": ""},
+		{"name": "collection_name", "type": "string", "default": ""},
+		{"name": "collection_id", "type": "long", "default": 0},
+		{"name": "partition_name", "type": "string", "default": ""},
+		{"name": "num_rows", "type": "long", "default": 0},
+		{"name": "data", "type": "string", "default": ""}
+	]
+}`)
+
+// Format is the encoding format of the records.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatAvro Format = "avro"
+)
+
+// Encode encodes the record with the format.
+func (f Format) Encode(r *Record) ([]byte, error) {
+	switch f {
+	case FormatJSON:
+		return json.Marshal(r)
+	case FormatAvro:
+		return avro.Marshal(recordAvroSchema, map[string]any{
+			"op":              r.Op,
+			"ddl_type":        r.DDLType,
+			"source_cluster":  r.SourceCluster,
+			"source_channel":  r.SourceChannel,
+			"vchannel":        r.VChannel,
+			"message_id":      r.MessageID,
+			"time_tick":       int64(r.TimeTick),
+			"broadcast_id":    int64(r.BroadcastID),
+			"db_name":         r.DbName,
+			"collection_name": r.CollectionName,
+			"collection_id":   r.CollectionID,
+			"partition_name":  r.PartitionName,
+			"num_rows":        r.NumRows,
+			"data":            string(r.Data),
+		})
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unknown sink format %s", f)
+	}
+}
+
+// recordDecoder decodes the messages of a source channel into records.
+type recordDecoder struct {
+	sourceCluster string
+	sourceChannel string
+}
+
+// Decode returns the records of the message, the message in a transaction is decoded one by one.
+// Nil is returned if the message should not be written into the sink.
+func (d *recordDecoder) Decode(msg message.ImmutableMessage) ([]*Record, error) {
+	if txnMsg, ok := msg.(message.ImmutableTxnMessage); ok {
+		var records []*Record
+		err := txnMsg.RangeOver(func(msg message.ImmutableMessage) error {
+			rs, err := d.Decode(msg)
+			records = append(records, rs...)
+			return err
+		})
+		return records, err
+	}
+
+	switch msg.MessageType() {
+	case message.MessageTypeInsert:
+		insertMsg, err := message.AsImmutableInsertMessageV1(msg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := insertMsg.Body()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeInsert(msg, body)
+	case message.MessageTypeDelete:
+		// the deletes of upsert are covered by the upsert records.
+		if msg.Properties().Exist(message.PropertyUpsert) {
+			return nil, nil
+		}
+		deleteMsg, err := message.AsImmutableDeleteMessageV1(msg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := deleteMsg.Body()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeDelete(msg, body)
+	default:
+		if !ddlMessageTypes.Contain(msg.MessageType()) {
+			return nil, nil
+		}
+		return d.decodeDDL(msg)
+	}
+}
+
+func (d *recordDecoder) newRecord(msg message.ImmutableMessage, op string) *Record {
+	r := &Record{
+		Op:            op,
+		SourceCluster: d.sourceCluster,
+		SourceChannel: d.sourceChannel,
+		VChannel:      msg.VChannel(),
+		MessageID:     msg.MessageID().String(),
+		TimeTick:      msg.TimeTick(),
+	}
+	if bh := msg.BroadcastHeader(); bh != nil {
+		r.BroadcastID = bh.BroadcastID
+	}
+	return r
+}
+
+func (d *recordDecoder) decodeInsert(msg message.ImmutableMessage, body *msgpb.InsertRequest) ([]*Record, error) {
+	numRows := int(body.GetNumRows())
+	if numRows == 0 {
+		return nil, nil
+	}
+	fieldNames := make([]string, 0, len(body.GetFieldsData()))
+	iterators := make([]func(int) any, 0, len(body.GetFieldsData()))
+	for _, field := range body.GetFieldsData() {
+		fieldNames = append(fieldNames, field.GetFieldName())
+		iterators = append(iterators, newFieldValueIterator(field))
+	}
+	rows := make([]map[string]any, 0, numRows)
+	for i := 0; i < numRows; i++ {
+		row := make(map[string]any, len(fieldNames))
+		for j, name := range fieldNames {
+			row[name] = iterators[j](i)
+		}
+		rows = append(rows, row)
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return nil, merr.Wrap(err, "failed to encode insert rows")
+	}
+
+	op := OpInsert
+	if msg.Properties().Exist(message.PropertyUpsert) {
+		op = OpUpsert
+	}
+	r := d.newRecord(msg, op)
+	r.DbName = body.GetDbName()
+	r.CollectionName = body.GetCollectionName()
+	r.CollectionID = body.GetCollectionID()
+	r.PartitionName = body.GetPartitionName()
+	r.NumRows = int64(numRows)
+	r.Data = data
+	return []*Record{r}, nil
+}
+
+func (d *recordDecoder) decodeDelete(msg message.ImmutableMessage, body *msgpb.DeleteRequest) ([]*Record, error) {
+	if body.GetNumRows() == 0 {
+		return nil, nil
+	}
+	var pks any
+	switch body.GetPrimaryKeys().GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		pks = body.GetPrimaryKeys().GetIntId().GetData()
+	case *schemapb.IDs_StrId:
+		pks = body.GetPrimaryKeys().GetStrId().GetData()
+	}
+	data, err := json.Marshal(pks)
+	if err != nil {
+		return nil, merr.Wrap(err, "failed to encode delete primary keys")
+	}
+
+	r := d.newRecord(msg, OpDelete)
+	r.DbName = body.GetDbName()
+	r.CollectionName = body.GetCollectionName()
+	r.CollectionID = body.GetCollectionID()
+	r.PartitionName = body.GetPartitionName()
+	r.NumRows = body.GetNumRows()
+	r.Data = data
+	return []*Record{r}, nil
+}
+
+func (d *recordDecoder) decodeDDL(msg message.ImmutableMessage) ([]*Record, error) {
+	header, body, err := message.UnmarshalSpecializedMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	headerJSON, err := protojson.Marshal(header)
+	if err != nil {
+		return nil, merr.Wrap(err, "failed to encode ddl header")
+	}
+	bodyJSON, err := protojson.Marshal(body)
+	if err != nil {
+		return nil, merr.Wrap(err, "failed to encode ddl body")
+	}
+	data, err := json.Marshal(map[string]json.RawMessage{
+		"header": headerJSON,
+		"body":   bodyJSON,
+	})
+	if err != nil {
+		return nil, merr.Wrap(err, "failed to encode ddl")
+	}
+
+	r := d.newRecord(msg, OpDDL)
+	r.DDLType = msg.MessageType().String()
+	r.Data = data
+	return []*Record{r}, nil
+}
+
+// newFieldValueIterator returns the iterator of the row values of the field,
+// the values can be encoded into json.
+func newFieldValueIterator(field *schemapb.FieldData) func(int) any {
+	switch field.GetType() {
+	case schemapb.DataType_JSON:
+		data := field.GetScalars().GetJsonData().GetData()
+		return newNullableIterator(field, len(data), func(idx int) any {
+			if len(data[idx]) == 0 {
+				return nil
+			}
+			return json.RawMessage(data[idx])
+		})
+	case schemapb.DataType_Array:
+		data := field.GetScalars().GetArrayData().GetData()
+		return newNullableIterator(field, len(data), func(idx int) any {
+			return arrayValues(data[idx])
+		})
+	case schemapb.DataType_Geometry:
+		data := field.GetScalars().GetGeometryData().GetData()
+		return newNullableIterator(field, len(data), func(idx int) any {
+			return data[idx]
+		})
+	default:
+		return typeutil.GetDataIterator(field)
+	}
+}
+
+// newNullableIterator wraps the getter of the field with its valid data,
+// the data may be either full-size or compact with the valid data.
+func newNullableIterator(field *schemapb.FieldData, dataLen int, get func(int) any) func(int) any {
+	validData := field.GetValidData()
+	if len(validData) == 0 {
+		return get
+	}
+	if dataLen == len(validData) {
+		return func(idx int) any {
+			if !validData[idx] {
+				return nil
+			}
+			return get(idx)
+		}
+	}
+	idxs := make([]int, len(validData))
+	cnt := 0
+	for i, valid := range validData {
+		if valid {
+			idxs[i] = cnt
+			cnt++
+		} else {
+			idxs[i] = -1
+		}
+	}
+	return func(idx int) any {
+		if idxs[idx] == -1 {
+			return nil
+		}
+		return get(idxs[idx])
+	}
+}
+
+func arrayValues(field *schemapb.ScalarField) any {
+	switch field.GetData().(type) {
+	case *schemapb.ScalarField_BoolData:
+		return field.GetBoolData().GetData()
+	case *schemapb.ScalarField_IntData:
+		return field.GetIntData().GetData()
+	case *schemapb.ScalarField_LongData:
+		return field.GetLongData().GetData()
+	case *schemapb.ScalarField_FloatData:
+		return field.GetFloatData().GetData()
+	case *schemapb.ScalarField_DoubleData:
+		return field.GetDoubleData().GetData()
+	case *schemapb.ScalarField_StringData:
+		return field.GetStringData().GetData()
+	default:
+		return nil
+	}
+}
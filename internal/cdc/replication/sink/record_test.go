@@ -0,0 +1,248 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/walimplstest"
+)
+
+func newTestInsertMessage(id int64, timeTick uint64, upsert bool) message.ImmutableMessage {
+	builder := message.NewInsertMessageBuilderV1().
+		WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.InsertRequest{
+			DbName:         "default",
+			CollectionName: "coll",
+			CollectionID:   1,
+			PartitionName:  "_default",
+			NumRows:        2,
+			FieldsData: []*schemapb.FieldData{
+				{
+					Type:      schemapb.DataType_Int64,
+					FieldName: "pk",
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}},
+					}},
+				},
+				{
+					Type:      schemapb.DataType_FloatVector,
+					FieldName: "vec",
+					Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+						Dim:  2,
+						Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{0.1, 0.2, 0.3, 0.4}}},
+					}},
+				},
+				{
+					Type:      schemapb.DataType_JSON,
+					FieldName: "meta",
+					ValidData: []bool{true, false},
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: [][]byte{[]byte(`{"a":1}`)}}},
+					}},
+				},
+				{
+					Type:      schemapb.DataType_Array,
+					FieldName: "tags",
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_ArrayData{ArrayData: &schemapb.ArrayArray{Data: []*schemapb.ScalarField{
+							{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"x"}}}},
+							{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"y", "z"}}}},
+						}}},
+					}},
+				},
+			},
+		}).
+		WithVChannel("by-dev-dml_0_1v0")
+	if upsert {
+		builder = builder.WithProperty(message.PropertyUpsert, "")
+	}
+	return builder.MustBuildMutable().
+		WithTimeTick(timeTick).
+		WithLastConfirmedUseMessageID().
+		IntoImmutableMessage(walimplstest.NewTestMessageID(id))
+}
+
+func newTestDeleteMessage(id int64, timeTick uint64, upsert bool) message.ImmutableMessage {
+	builder := message.NewDeleteMessageBuilderV1().
+		WithHeader(&message.DeleteMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.DeleteRequest{
+			CollectionName: "coll",
+			CollectionID:   1,
+			NumRows:        2,
+			PrimaryKeys: &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{
+				Data: []string{"a", "b"},
+			}}},
+		}).
+		WithVChannel("by-dev-dml_0_1v0")
+	if upsert {
+		builder = builder.WithProperty(message.PropertyUpsert, "")
+	}
+	return builder.MustBuildMutable().
+		WithTimeTick(timeTick).
+		WithLastConfirmedUseMessageID().
+		IntoImmutableMessage(walimplstest.NewTestMessageID(id))
+}
+
+func TestRecordDecoder(t *testing.T) {
+	d := &recordDecoder{sourceCluster: "by-dev", sourceChannel: "by-dev-dml_0"}
+
+	t.Run("insert", func(t *testing.T) {
+		records, err := d.Decode(newTestInsertMessage(1, 10, false))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		r := records[0]
+		assert.Equal(t, OpInsert, r.Op)
+		assert.Equal(t, "by-dev", r.SourceCluster)
+		assert.Equal(t, "by-dev-dml_0", r.SourceChannel)
+		assert.Equal(t, "by-dev-dml_0_1v0", r.VChannel)
+		assert.Equal(t, uint64(10), r.TimeTick)
+		assert.Equal(t, "coll", r.CollectionName)
+		assert.Equal(t, int64(1), r.CollectionID)
+		assert.Equal(t, int64(2), r.NumRows)
+		assert.JSONEq(t, `[
+			{"pk": 1, "vec": [0.1, 0.2], "meta": {"a": 1}, "tags": ["x"]},
+			{"pk": 2, "vec": [0.3, 0.4], "meta": null, "tags": ["y", "z"]}
+		]`, string(r.Data))
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		records, err := d.Decode(newTestInsertMessage(1, 10, true))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, OpUpsert, records[0].Op)
+
+		// the deletes of upsert are skipped.
+		records, err = d.Decode(newTestDeleteMessage(2, 10, true))
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		records, err := d.Decode(newTestDeleteMessage(2, 11, false))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, OpDelete, records[0].Op)
+		assert.Equal(t, int64(2), records[0].NumRows)
+		assert.JSONEq(t, `["a", "b"]`, string(records[0].Data))
+	})
+
+	t.Run("ddl", func(t *testing.T) {
+		msg := message.NewDropCollectionMessageBuilderV1().
+			WithHeader(&message.DropCollectionMessageHeader{CollectionId: 1}).
+			WithBody(&msgpb.DropCollectionRequest{CollectionName: "coll"}).
+			WithBroadcast([]string{"by-dev-dml_0_1v0"}).
+			MustBuildBroadcast().
+			WithBroadcastID(100).
+			SplitIntoMutableMessage()[0].
+			WithTimeTick(12).
+			WithLastConfirmedUseMessageID().
+			IntoImmutableMessage(walimplstest.NewTestMessageID(3))
+		records, err := d.Decode(msg)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, OpDDL, records[0].Op)
+		assert.Equal(t, "DropCollection", records[0].DDLType)
+		assert.Equal(t, uint64(100), records[0].BroadcastID)
+		assert.JSONEq(t, `{"header": {"collectionId": "1"}, "body": {"collectionName": "coll"}}`, string(records[0].Data))
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		msg := message.NewFlushMessageBuilderV2().
+			WithHeader(&message.FlushMessageHeader{CollectionId: 1}).
+			WithBody(&message.FlushMessageBody{}).
+			WithVChannel("by-dev-dml_0_1v0").
+			MustBuildMutable().
+			WithTimeTick(13).
+			WithLastConfirmedUseMessageID().
+			IntoImmutableMessage(walimplstest.NewTestMessageID(4))
+		records, err := d.Decode(msg)
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
+
+func TestUpsertPropertyIntoSink(t *testing.T) {
+	ctx := context.Background()
+	d := &recordDecoder{sourceCluster: "by-dev", sourceChannel: "by-dev-dml_0"}
+	storage, err := newLocalFileStorage(t.TempDir())
+	require.NoError(t, err)
+	s := &fileSink{storage: storage, dir: "sink-dml_0", rollBytes: defaultRollBytes}
+
+	// the property set by proxy survives the encoding of wal.
+	for _, msg := range []message.ImmutableMessage{
+		newTestDeleteMessage(1, 10, true),
+		newTestInsertMessage(2, 10, true),
+	} {
+		pb := msg.IntoImmutableMessageProto()
+		id, err := message.UnmarshalMessageID(pb.GetId())
+		require.NoError(t, err)
+		records, err := d.Decode(message.NewImmutableMesasge(id, pb.GetPayload(), pb.GetProperties()))
+		require.NoError(t, err)
+		require.NoError(t, s.Write(ctx, records))
+	}
+	require.NoError(t, s.Flush(ctx))
+
+	entries, err := os.ReadDir(filepath.Join(storage.root, "sink-dml_0"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	records := readNDJSON(t, filepath.Join(storage.root, "sink-dml_0", entries[0].Name()))
+	require.Len(t, records, 1)
+	assert.Equal(t, OpUpsert, records[0].Op)
+	assert.Equal(t, int64(2), records[0].NumRows)
+}
+
+func TestFormatEncode(t *testing.T) {
+	r := &Record{
+		Op:            OpInsert,
+		SourceCluster: "by-dev",
+		SourceChannel: "by-dev-dml_0",
+		MessageID:     "1",
+		TimeTick:      10,
+		CollectionID:  1,
+		NumRows:       1,
+		Data:          json.RawMessage(`[{"pk":1}]`),
+	}
+
+	data, err := FormatJSON.Encode(r)
+	require.NoError(t, err)
+	got := &Record{}
+	require.NoError(t, json.Unmarshal(data, got))
+	assert.Equal(t, r, got)
+
+	data, err = FormatAvro.Encode(r)
+	require.NoError(t, err)
+	m := map[string]any{}
+	require.NoError(t, avro.Unmarshal(recordAvroSchema, data, &m))
+	assert.Equal(t, OpInsert, m["op"])
+	assert.Equal(t, int64(10), m["time_tick"])
+	assert.Equal(t, `[{"pk":1}]`, m["data"])
+
+	_, err = Format("csv").Encode(r)
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/cdc/meta"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	SchemeKafka = "kafka"
+	SchemeFile  = "file"
+	SchemeS3    = "s3"
+	SchemeMinio = "minio"
+
+	// the common query parameters of the sink uri.
+	paramFormat        = "format"
+	paramFlushInterval = "flushInterval"
+
+	defaultFlushInterval = 10 * time.Second
+)
+
+// Sink is the destination that the replicated messages are written into other than a milvus cluster.
+// The sink is not safe for concurrent use.
+type Sink interface {
+	// Write writes the records into the sink.
+	// The records may be buffered, they are durable only after the Flush returns.
+	Write(ctx context.Context, records []*Record) error
+
+	// Flush makes all the written records durable.
+	Flush(ctx context.Context) error
+
+	// Close closes the sink, the records not flushed are dropped.
+	Close()
+}
+
+// IsSinkURI checks if the uri of target cluster is a sink rather than a milvus cluster.
+func IsSinkURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case SchemeKafka, SchemeFile, SchemeS3, SchemeMinio:
+		return true
+	default:
+		return false
+	}
+}
+
+// Options is the options of the sink parsed from the uri of target cluster.
+//
+//	kafka://[user:password@]broker1:9092[,broker2:9092]/topic?format=json|avro&kafka.<librdkafka config>=<value>
+//	file:///path/to/dir
+//	s3://bucket/path/to/dir or minio://bucket/path/to/dir, with the credentials of the minio config.
+//
+// All sinks accept flushInterval (a duration, 10s by default) to control how often the records are flushed and checkpointed,
+// the file sinks accept rollBytes to control the max size of a file.
+type Options struct {
+	URL           *url.URL
+	Format        Format
+	FlushInterval time.Duration
+	// TargetChannel is the target channel of the replication, used to distinguish the output of different source channels.
+	TargetChannel string
+}
+
+// ParseOptions parses the sink options of the replicate channel.
+func ParseOptions(channel *meta.ReplicateChannel) (*Options, error) {
+	uri := channel.Value.GetTargetCluster().GetConnectionParam().GetUri()
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid sink uri %s: %s", uri, err.Error())
+	}
+	if !IsSinkURI(uri) {
+		return nil, merr.WrapErrParameterInvalidMsg("unknown sink scheme %s", u.Scheme)
+	}
+	opts := &Options{
+		URL:           u,
+		Format:        FormatJSON,
+		FlushInterval: defaultFlushInterval,
+		TargetChannel: channel.Value.GetTargetChannelName(),
+	}
+	query := u.Query()
+	if format := query.Get(paramFormat); format != "" {
+		opts.Format = Format(format)
+		if opts.Format != FormatJSON && opts.Format != FormatAvro {
+			return nil, merr.WrapErrParameterInvalidMsg("unknown sink format %s", format)
+		}
+		if opts.Format == FormatAvro && u.Scheme != SchemeKafka {
+			return nil, merr.WrapErrParameterInvalidMsg("avro format is only supported by kafka sink")
+		}
+	}
+	if interval := query.Get(paramFlushInterval); interval != "" {
+		opts.FlushInterval, err = time.ParseDuration(interval)
+		if err != nil || opts.FlushInterval <= 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid sink flush interval %s", interval)
+		}
+	}
+	return opts, nil
+}
+
+// NewSink creates the sink by the scheme of the uri.
+func NewSink(ctx context.Context, opts *Options) (Sink, error) {
+	switch opts.URL.Scheme {
+	case SchemeKafka:
+		return newKafkaSink(opts)
+	case SchemeFile:
+		storage, err := newLocalFileStorage(opts.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		return newFileSink(opts, storage)
+	case SchemeS3, SchemeMinio:
+		storage, err := newObjectFileStorage(ctx, paramtable.Get(), opts.URL.Host, opts.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		return newFileSink(opts, storage)
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unknown sink scheme %s", opts.URL.Scheme)
+	}
+}
+
+// getInt64Param gets the int64 query parameter of the uri, the default value is returned if it's not set.
+func getInt64Param(query url.Values, key string, defaultValue int64) (int64, error) {
+	value := query.Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, merr.WrapErrParameterInvalidMsg("invalid sink parameter %s=%s", key, value)
+	}
+	return v, nil
+}
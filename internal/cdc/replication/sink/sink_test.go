@@ -0,0 +1,111 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/cdc/meta"
+	"github.com/milvus-io/milvus/pkg/v3/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	os.Exit(m.Run())
+}
+
+func newTestReplicateChannel(uri string) *meta.ReplicateChannel {
+	return &meta.ReplicateChannel{
+		Key:         "by-dev/meta/streamingcoord-meta/replicating-pchannel/sink-by-dev-dml_0",
+		ModRevision: 1,
+		Value: &streamingpb.ReplicatePChannelMeta{
+			SourceChannelName: "by-dev-dml_0",
+			TargetChannelName: "sink-dml_0",
+			TargetCluster: &commonpb.MilvusCluster{
+				ClusterId:       "sink",
+				ConnectionParam: &commonpb.ConnectionParam{Uri: uri},
+			},
+		},
+	}
+}
+
+func TestIsSinkURI(t *testing.T) {
+	assert.True(t, IsSinkURI("kafka://localhost:9092/topic"))
+	assert.True(t, IsSinkURI("file:///tmp/cdc"))
+	assert.True(t, IsSinkURI("s3://bucket/cdc"))
+	assert.True(t, IsSinkURI("minio://bucket/cdc"))
+	assert.False(t, IsSinkURI("http://localhost:19530"))
+	assert.False(t, IsSinkURI("localhost:19530"))
+	assert.False(t, IsSinkURI("://invalid"))
+}
+
+func TestParseOptions(t *testing.T) {
+	opts, err := ParseOptions(newTestReplicateChannel("kafka://broker1:9092,broker2:9092/topic?format=avro&flushInterval=1s"))
+	require.NoError(t, err)
+	assert.Equal(t, "broker1:9092,broker2:9092", opts.URL.Host)
+	assert.Equal(t, FormatAvro, opts.Format)
+	assert.Equal(t, time.Second, opts.FlushInterval)
+	assert.Equal(t, "sink-dml_0", opts.TargetChannel)
+
+	opts, err = ParseOptions(newTestReplicateChannel("file:///tmp/cdc"))
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, opts.Format)
+	assert.Equal(t, defaultFlushInterval, opts.FlushInterval)
+
+	for _, uri := range []string{
+		"http://localhost:19530",
+		"kafka://localhost:9092/topic?format=csv",
+		"file:///tmp/cdc?format=avro",
+		"kafka://localhost:9092/topic?flushInterval=-1s",
+		"kafka://localhost:9092/topic?flushInterval=abc",
+	} {
+		_, err = ParseOptions(newTestReplicateChannel(uri))
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	ctx := context.Background()
+	opts, err := ParseOptions(newTestReplicateChannel("file://" + t.TempDir()))
+	require.NoError(t, err)
+	s, err := NewSink(ctx, opts)
+	require.NoError(t, err)
+	s.Close()
+
+	opts, err = ParseOptions(newTestReplicateChannel("file:///tmp/cdc?rollBytes=0"))
+	require.NoError(t, err)
+	_, err = NewSink(ctx, opts)
+	assert.Error(t, err)
+
+	opts, err = ParseOptions(newTestReplicateChannel("kafka://localhost:9092"))
+	require.NoError(t, err)
+	_, err = NewSink(ctx, opts)
+	assert.Error(t, err)
+
+	opts, err = ParseOptions(newTestReplicateChannel("s3:///cdc"))
+	require.NoError(t, err)
+	_, err = NewSink(ctx, opts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/cdc/meta"
+	"github.com/milvus-io/milvus/internal/cdc/replication/replicatestream"
+	"github.com/milvus-io/milvus/internal/cdc/resource"
+	"github.com/milvus-io/milvus/internal/cdc/util"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+var _ replicatestream.ReplicateStreamClient = (*sinkStreamClient)(nil)
+
+// CreateSinkFunc creates the sink with the options.
+type CreateSinkFunc func(ctx context.Context, opts *Options) (Sink, error)
+
+// sinkStreamClient replicates the messages into a sink.
+// The messages are kept in the pending queue until they're flushed into the sink and checkpointed,
+// if the sink fails, the sink is recreated and the pending messages are written again,
+// so the records are delivered at least once.
+type sinkStreamClient struct {
+	clusterID       string
+	channel         *meta.ReplicateChannel
+	opts            *Options
+	createSink      CreateSinkFunc
+	decoder         *recordDecoder
+	checkpointKey   string
+	pendingMessages replicatestream.MsgQueue
+	metrics         replicatestream.ReplicateMetrics
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	finishedCh chan struct{}
+}
+
+// NewSinkStreamClient creates a new ReplicateStreamClient that replicates the messages into the sink of the target cluster.
+func NewSinkStreamClient(ctx context.Context, channel *meta.ReplicateChannel) (replicatestream.ReplicateStreamClient, error) {
+	return newSinkStreamClient(ctx, channel, NewSink)
+}
+
+func newSinkStreamClient(ctx context.Context, channel *meta.ReplicateChannel, createSink CreateSinkFunc) (*sinkStreamClient, error) {
+	opts, err := ParseOptions(channel)
+	if err != nil {
+		return nil, err
+	}
+	ctx1, cancel := context.WithCancel(ctx)
+	clusterID := paramtable.Get().CommonCfg.ClusterPrefix.GetValue()
+	c := &sinkStreamClient{
+		clusterID:  clusterID,
+		channel:    channel,
+		opts:       opts,
+		createSink: createSink,
+		decoder: &recordDecoder{
+			sourceCluster: clusterID,
+			sourceChannel: channel.Value.GetSourceChannelName(),
+		},
+		checkpointKey: getCheckpointKey(channel),
+		pendingMessages: replicatestream.NewMsgQueue(replicatestream.MsgQueueOptions{
+			Capacity: paramtable.Get().StreamingCfg.ReplicationPendingMessagesQueueLength.GetAsInt(),
+			MaxSize:  paramtable.Get().StreamingCfg.ReplicationPendingMessagesQueueMaxSize.GetAsInt(),
+		}),
+		metrics:    replicatestream.NewReplicateMetrics(channel.Value),
+		ctx:        ctx1,
+		cancel:     cancel,
+		finishedCh: make(chan struct{}),
+	}
+
+	c.metrics.OnInitiate()
+	go c.startInternal()
+	return c, nil
+}
+
+// GetCheckpoint gets the checkpoint of the replication into the sink,
+// nil is returned if the replication is never checkpointed or the checkpoint is outdated.
+func GetCheckpoint(ctx context.Context, channel *meta.ReplicateChannel) (*commonpb.ReplicateCheckpoint, error) {
+	checkpoint, err := meta.GetSinkCheckpoint(ctx, resource.Resource().ETCD(), getCheckpointKey(channel))
+	if err != nil {
+		return nil, err
+	}
+	// The checkpoint may be left by a removed replication with the same target channel,
+	// which is outdated if it's before the initialized checkpoint.
+	if checkpoint == nil || checkpoint.GetMessageId() == nil ||
+		checkpoint.GetTimeTick() < channel.Value.GetInitializedCheckpoint().GetTimeTick() {
+		return nil, nil
+	}
+	return checkpoint, nil
+}
+
+func getCheckpointKey(channel *meta.ReplicateChannel) string {
+	return meta.BuildSinkCheckpointKey(paramtable.Get().EtcdCfg.MetaRootPath.GetValue(), channel.Key)
+}
+
+func (c *sinkStreamClient) startInternal() {
+	defer func() {
+		mlog.Info(c.ctx, "sink stream client closed",
+			mlog.String("key", c.channel.Key),
+			mlog.Int64("revision", c.channel.ModRevision))
+		c.metrics.OnClose()
+		close(c.finishedCh)
+	}()
+
+	backoff := backoff.NewExponentialBackOff()
+	backoff.InitialInterval = 100 * time.Millisecond
+	backoff.MaxInterval = 10 * time.Second
+	backoff.MaxElapsedTime = 0
+
+	for {
+		restart := c.startReplicating(backoff)
+		if !restart {
+			return
+		}
+		time.Sleep(backoff.NextBackOff())
+	}
+}
+
+func (c *sinkStreamClient) startReplicating(backoff backoff.BackOff) (needRestart bool) {
+	logger := mlog.With(mlog.String("key", c.channel.Key), mlog.Int64("revision", c.channel.ModRevision))
+	if c.ctx.Err() != nil {
+		logger.Info(c.ctx, "close sink stream client due to ctx done")
+		return false
+	}
+
+	s, err := c.createSink(c.ctx, c.opts)
+	if err != nil {
+		logger.Warn(c.ctx, "create sink failed, retry...", mlog.Err(err))
+		return true
+	}
+	defer s.Close()
+
+	logger.Info(c.ctx, "sink stream client started", mlog.String("scheme", c.opts.URL.Scheme))
+	c.metrics.OnConnect()
+	backoff.Reset()
+	// rewrite all the messages that are not checkpointed.
+	c.pendingMessages.SeekToHead()
+
+	err = c.writeLoop(s)
+	if c.ctx.Err() != nil {
+		logger.Info(c.ctx, "close sink stream client due to ctx done")
+		return false
+	} else if errors.Is(err, replicatestream.ErrReplicationRemoved) {
+		logger.Info(c.ctx, "close sink stream client due to replication removed")
+		return false
+	}
+	logger.Warn(c.ctx, "restart sink stream client due to unexpected error", mlog.Err(err))
+	c.metrics.OnDisconnect()
+	return true
+}
+
+// writeLoop writes the pending messages into the sink, and flushes the sink every flush interval.
+func (c *sinkStreamClient) writeLoop(s Sink) error {
+	var lastWritten message.ImmutableMessage
+	nextFlush := time.Now().Add(c.opts.FlushInterval)
+	for {
+		if lastWritten != nil && !time.Now().Before(nextFlush) {
+			if err := c.flush(s, lastWritten); err != nil {
+				return err
+			}
+			lastWritten = nil
+		}
+		if !time.Now().Before(nextFlush) {
+			nextFlush = time.Now().Add(c.opts.FlushInterval)
+		}
+
+		readCtx, cancel := context.WithDeadline(c.ctx, nextFlush)
+		msg, err := c.pendingMessages.ReadNext(readCtx)
+		cancel()
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return c.ctx.Err()
+			}
+			// time to flush.
+			continue
+		}
+		records, err := c.decoder.Decode(msg)
+		if err != nil {
+			// the message can not be decoded will never be decoded, so it's unrecoverable.
+			panic(fmt.Sprintf("decode message failed due to unrecoverable error: %v", err))
+		}
+		if err := s.Write(c.ctx, records); err != nil {
+			return err
+		}
+		c.metrics.OnSent(msg)
+		lastWritten = msg
+		if msg.MessageType() == message.MessageTypeAlterReplicateConfig {
+			// flush the alter replicate config message at once to stop the replication as soon as possible if it's removed.
+			nextFlush = time.Now()
+		}
+	}
+}
+
+// flush flushes the sink, checkpoints the last written message and cleanups the pending messages.
+func (c *sinkStreamClient) flush(s Sink, lastWritten message.ImmutableMessage) error {
+	if err := s.Flush(c.ctx); err != nil {
+		return err
+	}
+	checkpoint := &commonpb.ReplicateCheckpoint{
+		ClusterId: c.clusterID,
+		Pchannel:  c.channel.Value.GetSourceChannelName(),
+		MessageId: lastWritten.LastConfirmedMessageID().IntoProto(),
+		TimeTick:  lastWritten.TimeTick(),
+	}
+	if err := meta.SaveSinkCheckpoint(c.ctx, resource.Resource().ETCD(), c.checkpointKey, checkpoint); err != nil {
+		return errors.Wrap(err, "failed to save sink checkpoint")
+	}
+	c.metrics.UpdateLastReplicatedTimeTick(lastWritten.TimeTick())
+	messages := c.pendingMessages.CleanupConfirmedMessages(lastWritten.TimeTick())
+	for _, msg := range messages {
+		c.metrics.OnConfirmed(msg)
+		if msg.MessageType() == message.MessageTypeAlterReplicateConfig {
+			if c.handleAlterReplicateConfigMessage(msg) {
+				return replicatestream.ErrReplicationRemoved
+			}
+		}
+	}
+	return nil
+}
+
+func (c *sinkStreamClient) handleAlterReplicateConfigMessage(msg message.ImmutableMessage) (replicationRemoved bool) {
+	logger := mlog.With(mlog.String("key", c.channel.Key), mlog.Int64("revision", c.channel.ModRevision))
+	if !util.IsReplicationRemovedByAlterReplicateConfigMessage(msg, c.channel.Value) {
+		return false
+	}
+	// The sink is removed from the topology, remove the replicate pchannel and the checkpoint of sink and stop replicate.
+	etcdCli := resource.Resource().ETCD()
+	ok, err := meta.RemoveReplicatePChannelWithRevision(c.ctx, etcdCli, c.channel.Key, c.channel.ModRevision)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		panic(fmt.Sprintf("failed to remove replicate pchannel: %v", err))
+	}
+	if err := meta.RemoveSinkCheckpoint(c.ctx, etcdCli, c.checkpointKey); err != nil {
+		// the outdated checkpoint is ignored by the initialized checkpoint of next replication.
+		logger.Warn(c.ctx, "failed to remove sink checkpoint", mlog.Err(err))
+	}
+	logger.Info(c.ctx, "replication into sink removed", mlog.Bool("replicatePChannelRemoved", ok))
+	return true
+}
+
+// Replicate enqueues the message to be written into the sink.
+func (c *sinkStreamClient) Replicate(msg message.ImmutableMessage) error {
+	select {
+	case <-c.ctx.Done():
+		return nil
+	default:
+		if msg.MessageType().IsSelfControlled() || msg.IsUnreplicable() {
+			// If no messages are being replicated, update the last replicated time tick.
+			if c.pendingMessages.Len() == 0 {
+				c.metrics.UpdateLastReplicatedTimeTick(msg.TimeTick())
+			}
+			return replicatestream.ErrReplicateIgnored
+		}
+		c.metrics.StartReplicate(msg)
+		c.pendingMessages.Enqueue(c.ctx, msg)
+		return nil
+	}
+}
+
+func (c *sinkStreamClient) BlockUntilFinish() {
+	<-c.finishedCh
+}
+
+func (c *sinkStreamClient) Close() {
+	c.cancel()
+	<-c.finishedCh
+}
@@ -0,0 +1,208 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/cdc/meta"
+	"github.com/milvus-io/milvus/internal/cdc/replication/replicatestream"
+	"github.com/milvus-io/milvus/internal/cdc/resource"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/walimplstest"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newTestEtcdClient(t *testing.T) *clientv3.Client {
+	config := embed.NewConfig()
+	config.Dir = t.TempDir()
+	config.LogLevel = "warn"
+	config.LogOutputs = []string{"default"}
+	u, err := url.Parse("http://localhost:0")
+	require.NoError(t, err)
+	config.ListenClientUrls = []url.URL{*u}
+	config.ListenPeerUrls = []url.URL{*u}
+	e, err := embed.StartEtcd(config)
+	require.NoError(t, err)
+	<-e.Server.ReadyNotify()
+	t.Cleanup(e.Close)
+	return v3client.New(e.Server)
+}
+
+// recordingSink records the written and flushed records of all the created sinks.
+type recordingSink struct {
+	mu       sync.Mutex
+	created  int
+	buffered []*Record
+	flushed  []*Record
+	// writeErrs is the count of the write calls that fail.
+	writeErrs int
+}
+
+func (s *recordingSink) create(ctx context.Context, opts *Options) (Sink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.created++
+	s.buffered = nil
+	return s, nil
+}
+
+func (s *recordingSink) Write(ctx context.Context, records []*Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writeErrs > 0 {
+		s.writeErrs--
+		return errors.New("write failed")
+	}
+	s.buffered = append(s.buffered, records...)
+	return nil
+}
+
+func (s *recordingSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushed = append(s.flushed, s.buffered...)
+	s.buffered = nil
+	return nil
+}
+
+func (s *recordingSink) Close() {}
+
+func (s *recordingSink) flushedTimeTicks() []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	timeTicks := make([]uint64, 0, len(s.flushed))
+	for _, r := range s.flushed {
+		timeTicks = append(timeTicks, r.TimeTick)
+	}
+	return timeTicks
+}
+
+func TestSinkStreamClient(t *testing.T) {
+	etcdCli := newTestEtcdClient(t)
+	resource.InitForTest(t, resource.OptETCD(etcdCli))
+	ctx := context.Background()
+
+	channel := newTestReplicateChannel("file:///tmp/cdc?flushInterval=50ms")
+	channel.Value.InitializedCheckpoint = &commonpb.ReplicateCheckpoint{TimeTick: 5}
+	s := &recordingSink{writeErrs: 1}
+	c, err := newSinkStreamClient(ctx, channel, s.create)
+	require.NoError(t, err)
+
+	checkpoint, err := GetCheckpoint(ctx, channel)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	timeTick := message.NewTimeTickMessageBuilderV1().
+		WithHeader(&message.TimeTickMessageHeader{}).
+		WithBody(&message.TimeTickMsg{}).
+		WithAllVChannel().
+		MustBuildMutable().
+		WithTimeTick(9).
+		WithLastConfirmedUseMessageID().
+		IntoImmutableMessage(walimplstest.NewTestMessageID(9))
+	assert.ErrorIs(t, c.Replicate(timeTick), replicatestream.ErrReplicateIgnored)
+
+	require.NoError(t, c.Replicate(newTestInsertMessage(10, 10, false)))
+	require.NoError(t, c.Replicate(newTestDeleteMessage(11, 11, false)))
+
+	// the first write fails, the sink is recreated and the messages are written again.
+	assert.Eventually(t, func() bool {
+		checkpoint, err := GetCheckpoint(ctx, channel)
+		return err == nil && checkpoint.GetTimeTick() == 11
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []uint64{10, 11}, s.flushedTimeTicks())
+	assert.Equal(t, 2, s.created)
+	checkpoint, err = GetCheckpoint(ctx, channel)
+	require.NoError(t, err)
+	assert.Equal(t, "by-dev-dml_0", checkpoint.GetPchannel())
+	assert.Equal(t, walimplstest.NewTestMessageID(11).IntoProto().GetId(), checkpoint.GetMessageId().GetId())
+
+	c.Close()
+	// the messages are not replicated after closed.
+	assert.NoError(t, c.Replicate(newTestInsertMessage(12, 12, false)))
+
+	// the checkpoint before the initialized checkpoint is outdated.
+	channel.Value.InitializedCheckpoint = &commonpb.ReplicateCheckpoint{TimeTick: 20}
+	checkpoint, err = GetCheckpoint(ctx, channel)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	_, err = NewSinkStreamClient(ctx, newTestReplicateChannel("http://localhost:19530"))
+	assert.Error(t, err)
+}
+
+func TestSinkStreamClientReplicationRemoved(t *testing.T) {
+	paramtable.Get().Save(paramtable.Get().CommonCfg.ClusterPrefix.Key, "by-dev")
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.ClusterPrefix.Key)
+	etcdCli := newTestEtcdClient(t)
+	resource.InitForTest(t, resource.OptETCD(etcdCli))
+	ctx := context.Background()
+
+	channel := newTestReplicateChannel("kafka://localhost:9092/cdc?flushInterval=1h")
+	resp, err := etcdCli.Put(ctx, channel.Key, "meta")
+	require.NoError(t, err)
+	channel.ModRevision = resp.Header.Revision
+	s := &recordingSink{}
+	c, err := newSinkStreamClient(ctx, channel, s.create)
+	require.NoError(t, err)
+
+	// An AlterReplicateConfig whose topology no longer contains the sink.
+	msg := message.NewAlterReplicateConfigMessageBuilderV2().
+		WithHeader(&message.AlterReplicateConfigMessageHeader{
+			ReplicateConfiguration: &commonpb.ReplicateConfiguration{
+				Clusters: []*commonpb.MilvusCluster{
+					{
+						ClusterId:       "by-dev",
+						ConnectionParam: &commonpb.ConnectionParam{Uri: "localhost:19530"},
+						Pchannels:       []string{"by-dev-dml_0"},
+					},
+				},
+			},
+		}).
+		WithBody(&message.AlterReplicateConfigMessageBody{}).
+		WithAllVChannel().
+		MustBuildMutable().
+		WithLastConfirmedUseMessageID().
+		WithTimeTick(10).
+		IntoImmutableMessage(walimplstest.NewTestMessageID(10))
+	require.NoError(t, c.Replicate(newTestInsertMessage(9, 9, false)))
+	require.NoError(t, c.Replicate(msg))
+
+	// the alter replicate config message is flushed at once without waiting for the flush interval.
+	c.BlockUntilFinish()
+	assert.Equal(t, []uint64{9}, s.flushedTimeTicks())
+	got, err := etcdCli.Get(ctx, channel.Key)
+	require.NoError(t, err)
+	assert.Empty(t, got.Kvs)
+	checkpoint, err := meta.GetSinkCheckpoint(ctx, etcdCli, getCheckpointKey(channel))
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+	c.Close()
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/datacoord"
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/querycoordv2"
@@ -59,6 +60,7 @@ type mixCoordImpl struct {
 	wg      sync.WaitGroup
 	etcdCli *clientv3.Client
 	tikvCli *txnkv.Client
+	boltDB  *boltkv.DB
 	address string
 
 	proxyCreator       proxyutil.ProxyCreator
@@ -131,7 +133,9 @@ func (s *mixCoordImpl) Init() error {
 		return initErr
 	}
 	s.factory.Init(Params)
-	s.initKVCreator()
+	if initErr = s.initKVCreator(); initErr != nil {
+		return initErr
+	}
 	s.initStreamingCoord()
 	s.UpdateStateCode(commonpb.StateCode_StandBy)
 	mlog.Info(s.ctx, "MixCoord enter standby mode successfully")
@@ -214,20 +218,31 @@ func (s *mixCoordImpl) initInternal() error {
 	return nil
 }
 
-func (s *mixCoordImpl) initKVCreator() {
+func (s *mixCoordImpl) initKVCreator() error {
 	if s.metaKVCreator == nil {
-		if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeTiKV {
+		switch Params.MetaStoreCfg.MetaStoreType.GetValue() {
+		case util.MetaStoreTypeTiKV:
 			s.metaKVCreator = func() kv.MetaKv {
 				return tikv.NewTiKV(s.tikvCli, Params.TiKVCfg.MetaRootPath.GetValue(),
 					tikv.WithRequestTimeout(paramtable.Get().TiKVCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 			}
-		} else {
+		case util.MetaStoreTypeBolt:
+			db, err := boltkv.OpenDBWithConfig(&Params.MetaStoreCfg)
+			if err != nil {
+				return err
+			}
+			s.boltDB = db
+			s.metaKVCreator = func() kv.MetaKv {
+				return boltkv.NewBoltKV(s.boltDB, Params.EtcdCfg.MetaRootPath.GetValue())
+			}
+		default:
 			s.metaKVCreator = func() kv.MetaKv {
 				return etcdkv.NewEtcdKV(s.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(),
 					etcdkv.WithRequestTimeout(paramtable.Get().EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 			}
 		}
 	}
+	return nil
 }
 
 func (s *mixCoordImpl) Start() error {
@@ -360,6 +375,9 @@ func (s *mixCoordImpl) Stop() error {
 
 	s.fileResourceObserver.Stop()
 	s.cancel()
+	if s.boltDB != nil {
+		s.boltDB.Close()
+	}
 	return nil
 }
 
@@ -44,6 +44,8 @@ func RegisterMgrRoute(s *mixCoordImpl) {
 			{management.BatchNodeStatusPath, s.HandleBatchNodeStatus},
 			{management.BatchNodeDistributionPath, s.GetBatchNodeDistribution},
 			{management.BatchTransferPath, s.TransferBatchSegment},
+			{management.BatchBalanceDryRunPath, s.DryRunBatchBalance},
+			{management.BatchCheckersDryRunPath, s.DryRunBatchCheckers},
 			// streaming
 			{management.StreamingBalanceStatusPath, s.HandleStreamingBalanceStatus},
 			{management.StreamingNodesPath, s.HandleStreamingNodes},
@@ -1069,6 +1071,80 @@ func (s *mixCoordImpl) TransferBatchSegment(w http.ResponseWriter, req *http.Req
 	w.Write([]byte(`{"msg": "OK"}`))
 }
 
+// DryRunBatchBalance handles GET requests to explain the balance plans of query nodes without executing them.
+// The optional collection_ids is a comma separated list of collections to balance, all collections if absent.
+func (s *mixCoordImpl) DryRunBatchBalance(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+	logger := mlog.With(mlog.String("Scope", "Rolling"))
+	collectionIDs, err := parseIDList(req.URL.Query().Get("collection_ids"), func(id string) (int64, error) {
+		return strconv.ParseInt(id, 10, 64)
+	})
+	if err != nil {
+		logger.Info(req.Context(), "DryRunBatchBalance failed to parse collection_ids", mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("invalid collection_ids: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	report, err := s.queryCoordServer.DryRunBalance(req.Context(), collectionIDs...)
+	if err != nil {
+		logger.Info(req.Context(), "DryRunBatchBalance failed", mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("failed to dry run balance, %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	logger.Info(req.Context(), "DryRunBatchBalance success",
+		mlog.Int("segmentPlans", len(report.SegmentPlans)),
+		mlog.Int("channelPlans", len(report.ChannelPlans)))
+	writeJSONResponse(w, http.StatusOK, report)
+}
+
+// DryRunBatchCheckers handles GET requests to list the tasks the query coord checkers would generate without executing them.
+// The optional checker_ids is a comma separated list of checkers to run, all checkers if absent.
+func (s *mixCoordImpl) DryRunBatchCheckers(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeJSONError(w, "Method not allowed, use GET", http.StatusMethodNotAllowed)
+		return
+	}
+	logger := mlog.With(mlog.String("Scope", "Rolling"))
+	checkerIDs, err := parseIDList(req.URL.Query().Get("checker_ids"), func(id string) (int32, error) {
+		checkerID, err := strconv.ParseInt(id, 10, 32)
+		return int32(checkerID), err
+	})
+	if err != nil {
+		logger.Info(req.Context(), "DryRunBatchCheckers failed to parse checker_ids", mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("invalid checker_ids: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	results, err := s.queryCoordServer.DryRunCheckers(req.Context(), checkerIDs...)
+	if err != nil {
+		logger.Info(req.Context(), "DryRunBatchCheckers failed", mlog.Err(err))
+		writeJSONError(w, fmt.Sprintf("failed to dry run checkers, %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	logger.Info(req.Context(), "DryRunBatchCheckers success", mlog.Int("checkers", len(results)))
+	writeJSONResponse(w, http.StatusOK, results)
+}
+
+// parseIDList parses the comma separated ids, empty ids are skipped.
+func parseIDList[T int32 | int64](ids string, parse func(string) (T, error)) ([]T, error) {
+	ret := make([]T, 0)
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		value, err := parse(id)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, value)
+	}
+	return ret, nil
+}
+
 // TransferStreamingChannel handles the transfer and defreeze operation.
 func (s *mixCoordImpl) TransferStreamingChannel(w http.ResponseWriter, req *http.Request) {
 	logger := mlog.With(mlog.String("Scope", "Rolling"))
@@ -38,6 +38,7 @@
 #include "exec/expression/LogicalUnaryExpr.h"
 #include "exec/expression/MatchExpr.h"
 #include "exec/expression/NullExpr.h"
+#include "exec/expression/StringFunctionFilterExpr.h"
 #include "exec/expression/TermExpr.h"
 #include "exec/expression/TimestamptzArithCompareExpr.h"
 #include "exec/expression/UnaryExpr.h"
@@ -441,6 +442,17 @@ CompileExpression(const expr::TypedExprPtr& expr,
             context->get_active_count(),
             context->query_config()->get_expr_batch_size(),
             context->get_consistency_level());
+    } else if (auto casted_expr = std::dynamic_pointer_cast<
+                   const milvus::expr::StringFunctionFilterExpr>(expr)) {
+        result = std::make_shared<PhyStringFunctionFilterExpr>(
+            compiled_inputs,
+            casted_expr,
+            "PhyStringFunctionFilterExpr",
+            op_ctx,
+            context->get_segment(),
+            context->get_active_count(),
+            context->query_config()->get_expr_batch_size(),
+            context->get_consistency_level());
     } else if (auto match_expr =
                    std::dynamic_pointer_cast<const milvus::expr::MatchExpr>(
                        expr)) {
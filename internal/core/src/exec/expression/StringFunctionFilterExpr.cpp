@@ -0,0 +1,342 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "StringFunctionFilterExpr.h"
+
+#include <algorithm>
+#include <cctype>
+#include <cstdint>
+#include <string>
+#include <string_view>
+
+#include "common/EasyAssert.h"
+#include "common/Json.h"
+#include "common/Tracer.h"
+#include "common/Types.h"
+#include "opentelemetry/trace/span.h"
+#include "storage/MmapManager.h"
+
+namespace milvus {
+namespace exec {
+
+namespace {
+
+constexpr std::string_view kWhitespaces = " \t\n\v\f\r";
+
+std::string_view
+Trim(std::string_view value) {
+    auto begin = value.find_first_not_of(kWhitespaces);
+    if (begin == std::string_view::npos) {
+        return {};
+    }
+    auto end = value.find_last_not_of(kWhitespaces);
+    return value.substr(begin, end - begin + 1);
+}
+
+// Substr returns the characters of the value from start, which counts from 1,
+// the rest of the value is returned if the length is absent.
+std::string_view
+Substr(std::string_view value, const std::vector<int64_t>& arguments) {
+    AssertInfo(!arguments.empty() && arguments.size() <= 2,
+               "substr expects start and optional length, but got {} "
+               "arguments",
+               arguments.size());
+    size_t pos = 0;
+    for (int64_t i = 1; i < arguments[0] && pos < value.size(); ++i) {
+        pos += Utf8ValidatedCharByteLen(value.data() + pos, value.size() - pos);
+    }
+    if (arguments.size() == 1) {
+        return value.substr(pos);
+    }
+    size_t end = pos;
+    for (int64_t i = 0; i < arguments[1] && end < value.size(); ++i) {
+        end += Utf8ValidatedCharByteLen(value.data() + end, value.size() - end);
+    }
+    return value.substr(pos, end - pos);
+}
+
+}  // namespace
+
+StringFunctionEvaluator::StringFunctionEvaluator(
+    const milvus::expr::StringFunctionFilterExpr& expr)
+    : expr_(expr) {
+    AssertInfo(!expr_.functions_.empty(),
+               "string function filter expr without function");
+    for (size_t i = 0; i < expr_.functions_.size(); ++i) {
+        const auto& name = expr_.functions_[i].name_;
+        if (name != "lower" && name != "upper" && name != "trim" &&
+            name != "substr" && name != "length") {
+            ThrowInfo(ExprInvalid, "unsupported string function {}", name);
+        }
+        if (name == "length" && i != expr_.functions_.size() - 1) {
+            ThrowInfo(ExprInvalid,
+                      "length can not be the input of another string "
+                      "function");
+        }
+    }
+    is_length_ = expr_.functions_.back().name_ == "length";
+
+    for (const auto& val : expr_.vals_) {
+        if (is_length_) {
+            AssertInfo(val.val_case() == proto::plan::GenericValue::kInt64Val,
+                       "length can only be compared with integer, but got {}",
+                       val.ShortDebugString());
+            int_vals_.push_back(val.int64_val());
+        } else {
+            AssertInfo(val.val_case() == proto::plan::GenericValue::kStringVal,
+                       "string function can only be compared with string, "
+                       "but got {}",
+                       val.ShortDebugString());
+            string_vals_.push_back(val.string_val());
+        }
+    }
+
+    switch (expr_.op_) {
+        case proto::plan::OpType::Equal:
+        case proto::plan::OpType::NotEqual:
+        case proto::plan::OpType::GreaterThan:
+        case proto::plan::OpType::GreaterEqual:
+        case proto::plan::OpType::LessThan:
+        case proto::plan::OpType::LessEqual:
+            AssertInfo(expr_.vals_.size() == 1,
+                       "comparison of string function expects one value, "
+                       "but got {}",
+                       expr_.vals_.size());
+            break;
+        case proto::plan::OpType::In:
+            break;
+        case proto::plan::OpType::PrefixMatch:
+        case proto::plan::OpType::PostfixMatch:
+        case proto::plan::OpType::InnerMatch:
+        case proto::plan::OpType::Match:
+            AssertInfo(!is_length_ && string_vals_.size() == 1,
+                       "like of string function expects one string pattern");
+            if (expr_.op_ == proto::plan::OpType::Match) {
+                like_matcher_.emplace(string_vals_[0]);
+            }
+            break;
+        default:
+            ThrowInfo(OpTypeInvalid,
+                      "unsupported op {} of string function filter expr",
+                      proto::plan::OpType_Name(expr_.op_));
+    }
+}
+
+std::string
+StringFunctionEvaluator::Apply(std::string_view value) const {
+    std::string ret(value);
+    for (const auto& function : expr_.functions_) {
+        if (function.name_ == "lower") {
+            std::transform(ret.begin(), ret.end(), ret.begin(), [](char c) {
+                return static_cast<char>(
+                    std::tolower(static_cast<unsigned char>(c)));
+            });
+        } else if (function.name_ == "upper") {
+            std::transform(ret.begin(), ret.end(), ret.begin(), [](char c) {
+                return static_cast<char>(
+                    std::toupper(static_cast<unsigned char>(c)));
+            });
+        } else if (function.name_ == "trim") {
+            ret = std::string(Trim(ret));
+        } else if (function.name_ == "substr") {
+            ret = std::string(Substr(ret, function.arguments_));
+        }
+    }
+    return ret;
+}
+
+bool
+StringFunctionEvaluator::operator()(std::string_view value) const {
+    auto applied = Apply(value);
+    if (is_length_) {
+        return CompareLength(static_cast<int64_t>(
+            Utf8CharCount(applied.data(), applied.size())));
+    }
+    return CompareString(applied);
+}
+
+bool
+StringFunctionEvaluator::CompareString(const std::string& value) const {
+    switch (expr_.op_) {
+        case proto::plan::OpType::Equal:
+            return value == string_vals_[0];
+        case proto::plan::OpType::NotEqual:
+            return value != string_vals_[0];
+        case proto::plan::OpType::GreaterThan:
+            return value > string_vals_[0];
+        case proto::plan::OpType::GreaterEqual:
+            return value >= string_vals_[0];
+        case proto::plan::OpType::LessThan:
+            return value < string_vals_[0];
+        case proto::plan::OpType::LessEqual:
+            return value <= string_vals_[0];
+        case proto::plan::OpType::In:
+            return std::find(string_vals_.begin(), string_vals_.end(),
+                             value) != string_vals_.end();
+        case proto::plan::OpType::PrefixMatch:
+            return std::string_view(value).starts_with(string_vals_[0]);
+        case proto::plan::OpType::PostfixMatch:
+            return std::string_view(value).ends_with(string_vals_[0]);
+        case proto::plan::OpType::InnerMatch:
+            return value.find(string_vals_[0]) != std::string::npos;
+        case proto::plan::OpType::Match:
+            return (*like_matcher_)(value);
+        default:
+            return false;
+    }
+}
+
+bool
+StringFunctionEvaluator::CompareLength(int64_t length) const {
+    switch (expr_.op_) {
+        case proto::plan::OpType::Equal:
+            return length == int_vals_[0];
+        case proto::plan::OpType::NotEqual:
+            return length != int_vals_[0];
+        case proto::plan::OpType::GreaterThan:
+            return length > int_vals_[0];
+        case proto::plan::OpType::GreaterEqual:
+            return length >= int_vals_[0];
+        case proto::plan::OpType::LessThan:
+            return length < int_vals_[0];
+        case proto::plan::OpType::LessEqual:
+            return length <= int_vals_[0];
+        case proto::plan::OpType::In:
+            return std::find(int_vals_.begin(), int_vals_.end(), length) !=
+                   int_vals_.end();
+        default:
+            return false;
+    }
+}
+
+void
+PhyStringFunctionFilterExpr::Eval(EvalCtx& context, VectorPtr& result) {
+    WaitPrefetch();
+    tracer::AutoSpan span(
+        "PhyStringFunctionFilterExpr::Eval", tracer::GetRootSpan(), true);
+    span.GetSpan()->SetAttribute("data_type",
+                                 static_cast<int>(expr_->column_.data_type_));
+
+    auto input = context.get_offset_input();
+    SetHasOffsetInput((input != nullptr));
+    switch (expr_->column_.data_type_) {
+        case DataType::VARCHAR:
+        case DataType::STRING: {
+            if (segment_->type() == SegmentType::Growing &&
+                !storage::MmapManager::GetInstance()
+                     .GetMmapConfig()
+                     .growing_enable_mmap) {
+                result = ExecVisitorImpl<std::string>(context);
+            } else {
+                result = ExecVisitorImpl<std::string_view>(context);
+            }
+            break;
+        }
+        case DataType::JSON: {
+            result = ExecVisitorImpl<Json>(context);
+            break;
+        }
+        default:
+            ThrowInfo(DataTypeInvalid,
+                      "unsupported data type of string function: {}",
+                      expr_->column_.data_type_);
+    }
+}
+
+template <typename T>
+VectorPtr
+PhyStringFunctionFilterExpr::ExecVisitorImpl(EvalCtx& context) {
+    auto* input = context.get_offset_input();
+    const auto& bitmap_input = context.get_bitmap_input();
+    auto real_batch_size =
+        has_offset_input_ ? input->size() : GetNextBatchSize();
+    if (real_batch_size == 0) {
+        return nullptr;
+    }
+    auto res_vec =
+        std::make_shared<ColumnVector>(TargetBitmap(real_batch_size, false),
+                                       TargetBitmap(real_batch_size, true));
+    TargetBitmapView res(res_vec->GetRawData(), real_batch_size);
+    TargetBitmapView valid_res(res_vec->GetValidRawData(), real_batch_size);
+
+    auto pointer = milvus::Json::pointer(expr_->column_.nested_path_);
+    int processed_cursor = 0;
+    const auto& evaluator = evaluator_;
+    auto execute_sub_batch =
+        [&bitmap_input, &processed_cursor, &
+         evaluator ]<FilterType filter_type = FilterType::sequential>(
+            const T* data,
+            const bool* valid_data,
+            const int32_t* offsets,
+            const int size,
+            TargetBitmapView res,
+            TargetBitmapView valid_res,
+            const std::string& pointer) {
+        // If data is nullptr, this chunk was skipped by SkipIndex.
+        // We only need to update processed_cursor for bitmap_input indexing.
+        if (data == nullptr) {
+            processed_cursor += size;
+            return;
+        }
+        bool has_bitmap_input = !bitmap_input.empty();
+        for (int i = 0; i < size; ++i) {
+            auto offset = i;
+            if constexpr (filter_type == FilterType::random) {
+                offset = (offsets) ? offsets[i] : i;
+            }
+            if (valid_data != nullptr && !valid_data[offset]) {
+                res[i] = valid_res[i] = false;
+                continue;
+            }
+            if (has_bitmap_input && !bitmap_input[processed_cursor + i]) {
+                continue;
+            }
+            if constexpr (std::is_same_v<T, Json>) {
+                auto x = data[offset].template at<std::string_view>(pointer);
+                if (x.error()) {
+                    res[i] = valid_res[i] = false;
+                    continue;
+                }
+                res[i] = evaluator(x.value());
+            } else {
+                res[i] = evaluator(std::string_view(data[offset]));
+            }
+        }
+        processed_cursor += size;
+    };
+
+    int64_t processed_size;
+    if (has_offset_input_) {
+        processed_size = ProcessDataByOffsets<T>(execute_sub_batch,
+                                                 std::nullptr_t{},
+                                                 input,
+                                                 res,
+                                                 valid_res,
+                                                 pointer);
+    } else {
+        processed_size = ProcessDataChunks<T>(
+            execute_sub_batch, std::nullptr_t{}, res, valid_res, pointer);
+    }
+    AssertInfo(processed_size == real_batch_size,
+               "internal error: expr processed rows {} not equal "
+               "expect batch size {}",
+               processed_size,
+               real_batch_size);
+    return res_vec;
+}
+
+}  //namespace exec
+}  // namespace milvus
@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#pragma once
+
+#include <fmt/core.h>
+#include <stdint.h>
+#include <memory>
+#include <optional>
+#include <string>
+#include <string_view>
+#include <utility>
+#include <vector>
+
+#include "common/OpContext.h"
+#include "common/RegexQuery.h"
+#include "common/Types.h"
+#include "common/Vector.h"
+#include "exec/expression/EvalCtx.h"
+#include "exec/expression/Expr.h"
+#include "expr/ITypeExpr.h"
+#include "pb/plan.pb.h"
+#include "segcore/SegmentInterface.h"
+
+namespace milvus {
+namespace exec {
+
+// StringFunctionEvaluator applies the string functions on a value and
+// compares the result with the values of the expression.
+class StringFunctionEvaluator {
+ public:
+    explicit StringFunctionEvaluator(
+        const milvus::expr::StringFunctionFilterExpr& expr);
+
+    bool
+    operator()(std::string_view value) const;
+
+ private:
+    // apply the string functions except a trailing length
+    std::string
+    Apply(std::string_view value) const;
+
+    bool
+    CompareString(const std::string& value) const;
+
+    bool
+    CompareLength(int64_t length) const;
+
+ private:
+    const milvus::expr::StringFunctionFilterExpr& expr_;
+    // length is the last function if present, which turns the value into
+    // the number of characters
+    bool is_length_;
+    std::vector<std::string> string_vals_;
+    std::vector<int64_t> int_vals_;
+    std::optional<LikePatternMatcher> like_matcher_;
+};
+
+class PhyStringFunctionFilterExpr : public SegmentExpr {
+ public:
+    PhyStringFunctionFilterExpr(
+        const std::vector<std::shared_ptr<Expr>>& input,
+        const std::shared_ptr<const milvus::expr::StringFunctionFilterExpr>&
+            expr,
+        const std::string& name,
+        milvus::OpContext* op_ctx,
+        const segcore::SegmentInternalInterface* segment,
+        int64_t active_count,
+        int64_t batch_size,
+        int32_t consistency_level)
+        : SegmentExpr(std::move(input),
+                      name,
+                      op_ctx,
+                      segment,
+                      expr->column_.field_id_,
+                      expr->column_.nested_path_,
+                      DataType::NONE,
+                      active_count,
+                      batch_size,
+                      consistency_level),
+          expr_(expr),
+          evaluator_(*expr) {
+    }
+
+    void
+    Eval(EvalCtx& context, VectorPtr& result) override;
+
+    // the functions are evaluated on the raw data, no index can serve them
+    void
+    DetermineExecPath() override {
+        exec_path_ = ExprExecPath::RawData;
+    }
+
+    std::string
+    ToString() const override {
+        return fmt::format("{}", expr_->ToString());
+    }
+
+    bool
+    IsSource() const override {
+        return true;
+    }
+
+    std::optional<milvus::expr::ColumnInfo>
+    GetColumnInfo() const override {
+        return expr_->column_;
+    }
+
+ private:
+    template <typename T>
+    VectorPtr
+    ExecVisitorImpl(EvalCtx& context);
+
+ private:
+    std::shared_ptr<const milvus::expr::StringFunctionFilterExpr> expr_;
+    StringFunctionEvaluator evaluator_;
+};
+
+}  //namespace exec
+}  // namespace milvus
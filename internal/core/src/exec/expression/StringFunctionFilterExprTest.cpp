@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include <gtest/gtest.h>
+#include <string>
+#include <vector>
+
+#include "exec/expression/StringFunctionFilterExpr.h"
+#include "expr/ITypeExpr.h"
+#include "pb/plan.pb.h"
+
+using namespace milvus;
+using namespace milvus::exec;
+
+namespace {
+
+proto::plan::GenericValue
+StringValue(const std::string& value) {
+    proto::plan::GenericValue ret;
+    ret.set_string_val(value);
+    return ret;
+}
+
+proto::plan::GenericValue
+Int64Value(int64_t value) {
+    proto::plan::GenericValue ret;
+    ret.set_int64_val(value);
+    return ret;
+}
+
+expr::StringFunctionFilterExpr
+MakeExpr(const std::vector<expr::StringFunction>& functions,
+         proto::plan::OpType op,
+         const std::vector<proto::plan::GenericValue>& vals) {
+    return expr::StringFunctionFilterExpr(
+        expr::ColumnInfo(FieldId(100), DataType::VARCHAR),
+        functions,
+        op,
+        vals);
+}
+
+}  // namespace
+
+TEST(StringFunctionFilterExprTest, Functions) {
+    auto lower = MakeExpr({{"lower", {}}},
+                          proto::plan::OpType::Equal,
+                          {StringValue("milvus")});
+    StringFunctionEvaluator eval_lower(lower);
+    EXPECT_TRUE(eval_lower("MilVus"));
+    EXPECT_FALSE(eval_lower("MilVus "));
+
+    auto trim_upper = MakeExpr({{"trim", {}}, {"upper", {}}},
+                               proto::plan::OpType::In,
+                               {StringValue("A"), StringValue("B")});
+    StringFunctionEvaluator eval_trim_upper(trim_upper);
+    EXPECT_TRUE(eval_trim_upper("  b\t"));
+    EXPECT_FALSE(eval_trim_upper(" c "));
+
+    // substr counts utf-8 characters from 1
+    auto substr = MakeExpr({{"substr", {2, 2}}},
+                           proto::plan::OpType::Equal,
+                           {StringValue("éf")});
+    StringFunctionEvaluator eval_substr(substr);
+    EXPECT_TRUE(eval_substr("aéfg"));
+    EXPECT_FALSE(eval_substr("aé"));
+
+    auto substr_rest = MakeExpr({{"substr", {3}}},
+                                proto::plan::OpType::PrefixMatch,
+                                {StringValue("fg")});
+    StringFunctionEvaluator eval_substr_rest(substr_rest);
+    EXPECT_TRUE(eval_substr_rest("aéfgh"));
+    EXPECT_FALSE(eval_substr_rest("ab"));
+
+    auto length = MakeExpr({{"trim", {}}, {"length", {}}},
+                           proto::plan::OpType::GreaterEqual,
+                           {Int64Value(3)});
+    StringFunctionEvaluator eval_length(length);
+    EXPECT_TRUE(eval_length(" 中文字 "));
+    EXPECT_FALSE(eval_length(" ab "));
+}
+
+TEST(StringFunctionFilterExprTest, Like) {
+    auto like = MakeExpr({{"lower", {}}},
+                         proto::plan::OpType::Match,
+                         {StringValue("a_c%")});
+    StringFunctionEvaluator eval_like(like);
+    EXPECT_TRUE(eval_like("ABCdef"));
+    EXPECT_FALSE(eval_like("ABD"));
+
+    auto inner = MakeExpr({{"upper", {}}},
+                          proto::plan::OpType::InnerMatch,
+                          {StringValue("BC")});
+    StringFunctionEvaluator eval_inner(inner);
+    EXPECT_TRUE(eval_inner("abcd"));
+    EXPECT_FALSE(eval_inner("acbd"));
+}
+
+TEST(StringFunctionFilterExprTest, Invalid) {
+    auto unknown = MakeExpr(
+        {{"reverse", {}}}, proto::plan::OpType::Equal, {StringValue("a")});
+    EXPECT_ANY_THROW(StringFunctionEvaluator{unknown});
+
+    auto nested_length = MakeExpr({{"length", {}}, {"lower", {}}},
+                                  proto::plan::OpType::Equal,
+                                  {StringValue("a")});
+    EXPECT_ANY_THROW(StringFunctionEvaluator{nested_length});
+
+    auto length_like = MakeExpr({{"length", {}}},
+                                proto::plan::OpType::PrefixMatch,
+                                {StringValue("a")});
+    EXPECT_ANY_THROW(StringFunctionEvaluator{length_like});
+}
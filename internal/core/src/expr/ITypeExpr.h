@@ -875,6 +875,55 @@ class GISFunctionFilterExpr : public ITypeFilterExpr {
     const double distance_;
 };
 
+// StringFunction is a string function applied on the value of its input,
+// arguments are the start and optional length of substr.
+struct StringFunction {
+    std::string name_;
+    std::vector<int64_t> arguments_;
+};
+
+class StringFunctionFilterExpr : public ITypeFilterExpr {
+ public:
+    // functions are applied in order on the value of the column, the first
+    // one is the innermost function of the expression.
+    StringFunctionFilterExpr(
+        const ColumnInfo& column,
+        const std::vector<StringFunction>& functions,
+        proto::plan::OpType op,
+        const std::vector<proto::plan::GenericValue>& vals)
+        : column_(column), functions_(functions), op_(op), vals_(vals) {
+    }
+
+    std::string
+    ToString() const override {
+        std::string functions;
+        for (const auto& function : functions_) {
+            functions += function.name_ + "(";
+            for (auto argument : function.arguments_) {
+                functions += std::to_string(argument) + ",";
+            }
+            functions += "), ";
+        }
+        std::string values;
+        for (const auto& val : vals_) {
+            values += val.ShortDebugString() + ", ";
+        }
+        return fmt::format(
+            "StringFunctionFilterExpr:[Column: {}, Functions: [{}], "
+            "Operator: {}, Values: [{}]]",
+            column_.ToString(),
+            functions,
+            proto::plan::OpType_Name(op_),
+            values);
+    }
+
+ public:
+    const ColumnInfo column_;
+    const std::vector<StringFunction> functions_;
+    const proto::plan::OpType op_;
+    const std::vector<proto::plan::GenericValue> vals_;
+};
+
 class JsonContainsExpr : public ITypeFilterExpr {
  public:
     JsonContainsExpr(ColumnInfo column,
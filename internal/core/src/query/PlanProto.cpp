@@ -1303,6 +1303,46 @@ ProtoParser::ParseGISFunctionFilterExprs(
     return expr;
 }
 
+expr::TypedExprPtr
+ProtoParser::ParseStringFunctionFilterExprs(
+    const proto::plan::StringFunctionFilterExpr& expr_pb) {
+    // unwrap the nested string functions down to the column, the innermost
+    // function is applied first
+    std::vector<expr::StringFunction> functions;
+    const proto::plan::StringFunctionExpr* function = &expr_pb.input();
+    while (true) {
+        functions.push_back(expr::StringFunction{
+            function->function_name(),
+            std::vector<int64_t>(function->arguments().begin(),
+                                 function->arguments().end())});
+        auto& input = function->input();
+        if (input.has_string_function_expr()) {
+            function = &input.string_function_expr();
+            continue;
+        }
+        AssertInfo(input.has_column_expr(),
+                   "the input of string function {} must be a column",
+                   function->function_name());
+        break;
+    }
+    std::reverse(functions.begin(), functions.end());
+
+    auto& column_info = function->input().column_expr().info();
+    auto field_id = FieldId(column_info.field_id());
+    auto& field = schema->operator[](field_id);
+    auto data_type = field.get_data_type();
+    Assert(data_type == static_cast<DataType>(column_info.data_type()));
+    AssertInfo(IsStringDataType(data_type) || data_type == DataType::JSON,
+               "string function can only be applied on VarChar or JSON field, "
+               "but got {}",
+               data_type);
+
+    std::vector<proto::plan::GenericValue> values(expr_pb.values().begin(),
+                                                  expr_pb.values().end());
+    return std::make_shared<expr::StringFunctionFilterExpr>(
+        expr::ColumnInfo(column_info), functions, expr_pb.op(), values);
+}
+
 expr::TypedExprPtr
 ProtoParser::CreateAlwaysTrueExprs() {
     return std::make_shared<expr::AlwaysTrueExpr>();
@@ -1377,6 +1417,11 @@ ProtoParser::ParseExprs(const proto::plan::Expr& expr_pb,
                 ParseGISFunctionFilterExprs(expr_pb.gisfunction_filter_expr());
             break;
         }
+        case ppe::kStringFunctionFilterExpr: {
+            result = ParseStringFunctionFilterExprs(
+                expr_pb.string_function_filter_expr());
+            break;
+        }
         case ppe::kTimestamptzArithCompareExpr: {
             result = ParseTimestamptzArithCompareExprs(
                 expr_pb.timestamptz_arith_compare_expr());
@@ -101,6 +101,10 @@ class ProtoParser {
     ParseGISFunctionFilterExprs(
         const proto::plan::GISFunctionFilterExpr& expr_pb);
 
+    expr::TypedExprPtr
+    ParseStringFunctionFilterExprs(
+        const proto::plan::StringFunctionFilterExpr& expr_pb);
+
     expr::TypedExprPtr
     ParseTermExprs(const proto::plan::TermExpr& expr_pb);
 
@@ -81,6 +81,7 @@ TEST(CPackedTest, PackedWriterAndReader) {
                                     1,
                                     part_upload_size,
                                     cgs,
+                                    nullptr,
                                     &c_packed_writer,
                                     nullptr);
     EXPECT_EQ(c_status.error_code, 0);
@@ -45,6 +45,33 @@
 #include "storage/StorageV2FSCache.h"
 #include "storage/plugin/PluginInterface.h"
 
+namespace {
+
+// the codec is recorded in the column chunks of the parquet files, so the
+// readers decompress the files written with any compress type.
+void
+SetWriterCompression(parquet::WriterProperties::Builder& builder,
+                     const char* compression) {
+    if (compression == nullptr) {
+        return;
+    }
+    auto type = std::string(compression);
+    if (type.empty() || type == "zstd") {
+        return;
+    }
+    if (type == "lz4") {
+        builder.compression(arrow::Compression::LZ4);
+    } else if (type == "snappy") {
+        builder.compression(arrow::Compression::SNAPPY);
+    } else {
+        ThrowInfo(milvus::ErrorCode::InvalidParameter,
+                  "unsupported compress type of packed writer: {}",
+                  type);
+    }
+}
+
+}  // namespace
+
 CStatus
 NewPackedWriterWithStorageConfig(struct ArrowSchema* schema,
                                  const int64_t buffer_size,
@@ -52,6 +79,7 @@ NewPackedWriterWithStorageConfig(struct ArrowSchema* schema,
                                  int64_t num_paths,
                                  int64_t part_upload_size,
                                  CColumnSplits column_splits,
+                                 const char* compression,
                                  CStorageConfig c_storage_config,
                                  CPackedWriter* c_packed_writer,
                                  CPluginContext* c_plugin_context) {
@@ -99,6 +127,7 @@ NewPackedWriterWithStorageConfig(struct ArrowSchema* schema,
             *static_cast<std::vector<std::vector<int>>*>(column_splits);
 
         parquet::WriterProperties::Builder builder;
+        SetWriterCompression(builder, compression);
         auto plugin_ptr =
             milvus::storage::PluginLoader::GetInstance().getCipherPlugin();
         if (plugin_ptr != nullptr && c_plugin_context != nullptr) {
@@ -149,6 +178,7 @@ NewPackedWriter(struct ArrowSchema* schema,
                 int64_t num_paths,
                 int64_t part_upload_size,
                 CColumnSplits column_splits,
+                const char* compression,
                 CPackedWriter* c_packed_writer,
                 CPluginContext* c_plugin_context) {
     SCOPE_CGO_CALL_METRIC();
@@ -172,6 +202,7 @@ NewPackedWriter(struct ArrowSchema* schema,
             *static_cast<std::vector<std::vector<int>>*>(column_splits);
 
         parquet::WriterProperties::Builder builder;
+        SetWriterCompression(builder, compression);
         auto plugin_ptr =
             milvus::storage::PluginLoader::GetInstance().getCipherPlugin();
         if (plugin_ptr != nullptr && c_plugin_context != nullptr) {
@@ -31,6 +31,9 @@ struct ArrowArray;
 
 typedef void* CPackedWriter;
 
+// compression is the compress type of the parquet files, one of zstd, lz4 and
+// snappy, the default compression of the writer is kept if it is null or zstd.
+
 CStatus
 NewPackedWriterWithStorageConfig(struct ArrowSchema* schema,
                                  const int64_t buffer_size,
@@ -38,6 +41,7 @@ NewPackedWriterWithStorageConfig(struct ArrowSchema* schema,
                                  int64_t num_paths,
                                  int64_t part_upload_size,
                                  CColumnSplits column_splits,
+                                 const char* compression,
                                  CStorageConfig c_storage_config,
                                  CPackedWriter* c_packed_writer,
                                  CPluginContext* c_plugin_context);
@@ -49,6 +53,7 @@ NewPackedWriter(struct ArrowSchema* schema,
                 int64_t num_paths,
                 int64_t part_upload_size,
                 CColumnSplits column_splits,
+                const char* compression,
                 CPackedWriter* c_packed_writer,
                 CPluginContext* c_plugin_context);
 
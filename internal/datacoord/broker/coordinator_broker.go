@@ -55,6 +55,10 @@ type Broker interface {
 	// Used for rollback when snapshot restore fails.
 	DropCollection(ctx context.Context, dbName, collectionName string) error
 
+	// DropPartition drops a partition via RootCoord.
+	// Used for rollback when snapshot partition restore fails.
+	DropPartition(ctx context.Context, dbName, collectionName, partitionName string) error
+
 	// DescribeDatabase retrieves database information via RootCoord.
 	// Used for CMEK validation during snapshot restore.
 	DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error)
@@ -302,6 +306,35 @@ func (b *coordinatorBroker) DropCollection(ctx context.Context, dbName, collecti
 	return nil
 }
 
+// DropPartition drops a partition via RootCoord.
+// Used for rollback when snapshot partition restore fails.
+func (b *coordinatorBroker) DropPartition(ctx context.Context, dbName, collectionName, partitionName string) error {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+	log := mlog.With(
+		mlog.FieldDbName(dbName),
+		mlog.FieldCollectionName(collectionName),
+		mlog.FieldPartitionName(partitionName),
+	)
+
+	resp, err := b.mixCoord.DropPartition(ctx, &milvuspb.DropPartitionRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_DropPartition),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		DbName:         dbName,
+		CollectionName: collectionName,
+		PartitionName:  partitionName,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Warn(ctx, "DropPartition failed", mlog.Err(err))
+		return err
+	}
+
+	log.Info(ctx, "DropPartition succeeded")
+	return nil
+}
+
 // DescribeDatabase retrieves database information via RootCoord.
 // Used for CMEK validation during snapshot restore.
 func (b *coordinatorBroker) DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
@@ -693,6 +693,40 @@ func TestDropCollection_StatusError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDropPartition_Success(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	mockMixCoord := mocks.NewMixCoord(t)
+	mockMixCoord.EXPECT().DropPartition(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *milvuspb.DropPartitionRequest) (*commonpb.Status, error) {
+			assert.Equal(t, "test_db", req.GetDbName())
+			assert.Equal(t, "test_collection", req.GetCollectionName())
+			assert.Equal(t, "test_partition", req.GetPartitionName())
+			assert.NotNil(t, req.GetBase())
+			return merr.Success(), nil
+		})
+
+	broker := NewCoordinatorBroker(mockMixCoord)
+	err := broker.DropPartition(ctx, "test_db", "test_collection", "test_partition")
+
+	assert.NoError(t, err)
+}
+
+func TestDropPartition_StatusError(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	mockMixCoord := mocks.NewMixCoord(t)
+	mockMixCoord.EXPECT().DropPartition(mock.Anything, mock.Anything).Return(
+		merr.Status(merr.WrapErrPartitionNotFound("test_partition")), nil)
+
+	broker := NewCoordinatorBroker(mockMixCoord)
+	err := broker.DropPartition(ctx, "test_db", "test_collection", "test_partition")
+
+	assert.Error(t, err)
+}
+
 func TestCreateCollection_Success(t *testing.T) {
 	paramtable.Init()
 	ctx := context.Background()
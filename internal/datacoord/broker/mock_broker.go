@@ -344,6 +344,55 @@ func (_c *MockBroker_DropCollection_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DropPartition provides a mock function with given fields: ctx, dbName, collectionName, partitionName
+func (_m *MockBroker) DropPartition(ctx context.Context, dbName string, collectionName string, partitionName string) error {
+	ret := _m.Called(ctx, dbName, collectionName, partitionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropPartition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, dbName, collectionName, partitionName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBroker_DropPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropPartition'
+type MockBroker_DropPartition_Call struct {
+	*mock.Call
+}
+
+// DropPartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbName string
+//   - collectionName string
+//   - partitionName string
+func (_e *MockBroker_Expecter) DropPartition(ctx interface{}, dbName interface{}, collectionName interface{}, partitionName interface{}) *MockBroker_DropPartition_Call {
+	return &MockBroker_DropPartition_Call{Call: _e.mock.On("DropPartition", ctx, dbName, collectionName, partitionName)}
+}
+
+func (_c *MockBroker_DropPartition_Call) Run(run func(ctx context.Context, dbName string, collectionName string, partitionName string)) *MockBroker_DropPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockBroker_DropPartition_Call) Return(_a0 error) *MockBroker_DropPartition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBroker_DropPartition_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockBroker_DropPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HasCollection provides a mock function with given fields: ctx, collectionID
 func (_m *MockBroker) HasCollection(ctx context.Context, collectionID int64) (bool, error) {
 	ret := _m.Called(ctx, collectionID)
@@ -103,7 +103,7 @@ func (s *DDLCallbacks) restoreSnapshotV2AckCallback(ctx context.Context, result
 
 	// Restore data (create copy segment job)
 	// Use the pre-allocated jobID from the WAL message for idempotency
-	jobID, err := s.snapshotManager.RestoreData(ctx, header.SourceCollectionId, header.SnapshotName, header.CollectionId, header.JobId, header.PinId)
+	jobID, err := s.snapshotManager.RestoreData(ctx, header.SourceCollectionId, header.SnapshotName, header.CollectionId, header.JobId, header.PinId, header.PartitionName)
 	if err != nil {
 		log.Error(ctx, "failed to restore data", mlog.Err(err))
 		return err
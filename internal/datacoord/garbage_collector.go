@@ -2110,7 +2110,9 @@ func (gc *garbageCollector) recycleSnapshots(ctx context.Context, signal <-chan
 	//      forever since Pin/Unpin only touch their own entries.
 	//   2. For collections whose owning collection was DROPPED, cascade-delete
 	//      the orphan snapshots. Handles the case where the drop-collection
-	//      cascade callback failed to fully clean up.
+	//      cascade callback failed to fully clean up. Recycle bin snapshots are
+	//      kept until common.recycleBin.retentionSeconds expires, for both
+	//      dropped and existing collections.
 	activeCollectionIDs := snapshotMeta.GetActiveCollectionIDs()
 
 	if len(activeCollectionIDs) > 0 {
@@ -2140,6 +2142,16 @@ func (gc *garbageCollector) recycleSnapshots(ctx context.Context, signal <-chan
 			}
 			if has {
 				// Collection still exists, not an orphan — expired pins already reaped above.
+				// Only the recycle bin snapshots of its dropped partitions may have expired.
+				dropped, err := snapshotMeta.DropExpiredRecycleBinSnapshots(ctx, collectionID)
+				for _, n := range dropped {
+					setSnapshotActivePinsGauge(collectionID, n, 0)
+				}
+				if err != nil {
+					log.Warn(ctx, "failed to drop expired recycle bin snapshots for collection",
+						mlog.Int64("collectionID", collectionID),
+						mlog.Err(err))
+				}
 				continue
 			}
 
@@ -40,6 +40,7 @@ import (
 	"github.com/milvus-io/milvus/internal/datacoord/session"
 	"github.com/milvus-io/milvus/internal/datacoord/task"
 	datanodeclient "github.com/milvus-io/milvus/internal/distributed/datanode/client"
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
@@ -103,6 +104,7 @@ type Server struct {
 
 	etcdCli        *clientv3.Client
 	tikvCli        *txnkv.Client
+	boltDB         *boltkv.DB
 	address        string
 	watchClient    kv.WatchKV
 	kv             kv.MetaKv
@@ -616,6 +618,14 @@ func (s *Server) initKV() error {
 		s.metaRootPath = Params.EtcdCfg.MetaRootPath.GetValue()
 		s.kv = etcdkv.NewEtcdKV(s.etcdCli, s.metaRootPath,
 			etcdkv.WithRequestTimeout(paramtable.Get().EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+	case util.MetaStoreTypeBolt:
+		db, err := boltkv.OpenDBWithConfig(&Params.MetaStoreCfg)
+		if err != nil {
+			return err
+		}
+		s.boltDB = db
+		s.metaRootPath = Params.EtcdCfg.MetaRootPath.GetValue()
+		s.kv = boltkv.NewBoltKV(s.boltDB, s.metaRootPath)
 	default:
 		return retry.Unrecoverable(merr.WrapErrServiceInternalMsg("unsupported meta store: %s", metaType))
 	}
@@ -1101,6 +1111,10 @@ func (s *Server) Stop() error {
 
 	s.stopServerLoop()
 	mlog.Info(s.ctx, "datacoord serverloop stopped")
+
+	if s.boltDB != nil {
+		s.boltDB.Close()
+	}
 	mlog.Warn(s.ctx, "datacoord stop successful")
 	return nil
 }
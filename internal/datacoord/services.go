@@ -2349,34 +2349,51 @@ func (s *Server) DropSnapshot(ctx context.Context, req *datapb.DropSnapshotReque
 		return merr.Status(err), nil
 	}
 
-	// Resolve collection identity so the broadcast can acquire proper DB/collection locks.
-	// Dropping a snapshot must serialize against concurrent DropCollection / AlterCollection
-	// on the owning collection, otherwise DropSnapshot can race with a cascade drop path.
-	//
-	// Read from the datacoord-local meta cache (populated at startup and kept in sync
-	// via BroadcastAlteredCollection). This avoids a cross-component RPC to MixCoord
-	// on every DropSnapshot, and on cache miss handler.GetCollection transparently
-	// falls back to rootcoord with bounded retries.
-	coll, err := s.handler.GetCollection(ctx, req.GetCollectionId())
-	if err != nil {
-		mlog.Warn(context.TODO(), "DropSnapshot failed to resolve collection", mlog.Err(err))
-		return merr.Status(err), nil
-	}
-	if coll == nil {
-		mlog.Warn(context.TODO(), "DropSnapshot: collection not found")
-		return merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionId())), nil
+	// The recycle bin keeps the snapshots of dropped collections until they are purged,
+	// there is no owning collection to lock for them once the collection is gone.
+	lockCollection := true
+	if _, _, _, ok := common.ParseRecycleBinSnapshotName(req.GetName()); ok {
+		has, err := s.broker.HasCollection(ctx, req.GetCollectionId())
+		if err != nil {
+			mlog.Warn(context.TODO(), "DropSnapshot: failed to check collection existence", mlog.Err(err))
+			return merr.Status(err), nil
+		}
+		lockCollection = has
 	}
-	dbName := coll.DatabaseName
-	collectionName := coll.Schema.GetName()
 
-	// Start broadcast with DB + collection + per-collection snapshot locks.
 	// The snapshot resource key is namespaced by collectionID so DropSnapshot on
 	// (collA, "backup") does not falsely block DropSnapshot on (collB, "backup").
-	broadcaster, err := broadcast.StartBroadcastWithResourceKeys(ctx,
-		message.NewSharedDBNameResourceKey(dbName),
-		message.NewExclusiveCollectionNameResourceKey(dbName, collectionName),
+	resourceKeys := []message.ResourceKey{
 		message.NewExclusiveSnapshotNameResourceKey(req.GetCollectionId(), req.GetName()),
-	)
+	}
+	if lockCollection {
+		// Resolve collection identity so the broadcast can acquire proper DB/collection locks.
+		// Dropping a snapshot must serialize against concurrent DropCollection / AlterCollection
+		// on the owning collection, otherwise DropSnapshot can race with a cascade drop path.
+		//
+		// Read from the datacoord-local meta cache (populated at startup and kept in sync
+		// via BroadcastAlteredCollection). This avoids a cross-component RPC to MixCoord
+		// on every DropSnapshot, and on cache miss handler.GetCollection transparently
+		// falls back to rootcoord with bounded retries.
+		coll, err := s.handler.GetCollection(ctx, req.GetCollectionId())
+		if err != nil {
+			mlog.Warn(context.TODO(), "DropSnapshot failed to resolve collection", mlog.Err(err))
+			return merr.Status(err), nil
+		}
+		if coll == nil {
+			mlog.Warn(context.TODO(), "DropSnapshot: collection not found")
+			return merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionId())), nil
+		}
+		dbName := coll.DatabaseName
+		collectionName := coll.Schema.GetName()
+		resourceKeys = append(resourceKeys,
+			message.NewSharedDBNameResourceKey(dbName),
+			message.NewExclusiveCollectionNameResourceKey(dbName, collectionName),
+		)
+	}
+
+	// Start broadcast with DB + collection + per-collection snapshot locks.
+	broadcaster, err := broadcast.StartBroadcastWithResourceKeys(ctx, resourceKeys...)
 	if err != nil {
 		mlog.Error(context.TODO(), "DropSnapshot failed to start broadcast", mlog.Err(err))
 		return merr.Status(err), nil
@@ -2489,6 +2506,30 @@ func (s *Server) RestoreSnapshot(ctx context.Context, req *datapb.RestoreSnapsho
 			Status: merr.Status(err),
 		}, nil
 	}
+	if req.GetTargetPartitionName() != "" {
+		// the partition is restored in place into the source collection.
+		jobID, err := s.snapshotManager.RestorePartition(
+			ctx,
+			req.GetSourceCollectionId(),
+			req.GetName(),
+			req.GetTargetPartitionName(),
+			s.startRestoreSnapshotLock,
+			s.startBroadcastForRestoreSnapshot,
+			s.rollbackRestorePartition,
+			s.validateRestoreSnapshotResources,
+		)
+		if err != nil {
+			mlog.Error(context.TODO(), "restore partition failed", mlog.Err(err))
+			return &datapb.RestoreSnapshotResponse{
+				Status: merr.Status(err),
+			}, nil
+		}
+		mlog.Info(context.TODO(), "restore partition completed", mlog.Int64("jobID", jobID))
+		return &datapb.RestoreSnapshotResponse{
+			Status: merr.Success(),
+			JobId:  jobID,
+		}, nil
+	}
 	if req.GetTargetCollectionName() == "" {
 		err := merr.WrapErrParameterMissingMsg("target collection name is required")
 		mlog.Warn(context.TODO(), "invalid request", mlog.Err(err))
@@ -2549,6 +2590,23 @@ func (s *Server) rollbackRestoreSnapshot(ctx context.Context, dbName, collection
 	return nil
 }
 
+// rollbackRestorePartition drops the recreated partition when restore partition fails.
+func (s *Server) rollbackRestorePartition(ctx context.Context, dbName, collectionName, partitionName string) error {
+	mlog.Info(context.TODO(), "rolling back restore partition, dropping partition")
+
+	if err := s.broker.DropPartition(ctx, dbName, collectionName, partitionName); err != nil {
+		if errors.Is(err, merr.ErrPartitionNotFound) {
+			mlog.Debug(context.TODO(), "partition not found, skipping rollback")
+			return nil
+		}
+		mlog.Error(context.TODO(), "failed to drop partition during rollback", mlog.Err(err))
+		return err
+	}
+
+	mlog.Info(context.TODO(), "rollback completed, partition dropped")
+	return nil
+}
+
 func (s *Server) GetRestoreSnapshotState(ctx context.Context, req *datapb.GetRestoreSnapshotStateRequest) (*datapb.GetRestoreSnapshotStateResponse, error) {
 	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
 		return &datapb.GetRestoreSnapshotStateResponse{
@@ -77,6 +77,10 @@ type StartRestoreLockFunc func(ctx context.Context, sourceCollectionID int64, sn
 // Used by RestoreSnapshot to delegate collection cleanup to the caller (Server).
 type RollbackFunc func(ctx context.Context, dbName, collectionName string) error
 
+// RollbackPartitionFunc performs rollback on partition restore failure.
+// Used by RestorePartition to delegate partition cleanup to the caller (Server).
+type RollbackPartitionFunc func(ctx context.Context, dbName, collectionName, partitionName string) error
+
 // ValidateResourcesFunc validates that all required resources exist.
 // Used by RestoreSnapshot to validate snapshot, collection, partitions, and indexes.
 type ValidateResourcesFunc func(ctx context.Context, collectionID int64, snapshotData *SnapshotData) error
@@ -204,6 +208,34 @@ type SnapshotManager interface {
 		validateResources ValidateResourcesFunc,
 	) (int64, error)
 
+	// RestorePartition restores a dropped partition from the snapshot in place into the source collection.
+	// It recreates the partition, then broadcasts the restore message carrying the partition name,
+	// only the segments of the partition are copied when the message is acked.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeout
+	//   - sourceCollectionID: ID of the collection the snapshot is taken from, the partition is restored into it
+	//   - snapshotName: Name of the snapshot to restore (unique within source collection)
+	//   - partitionName: Name of the partition to restore, it should not exist in the collection
+	//   - startRestoreLock: Function to acquire the Phase 0 restore lock set
+	//   - startBroadcaster: Function to start a broadcaster for DDL operations
+	//   - rollback: Function to rollback on failure (drops partition)
+	//   - validateResources: Function to validate that all resources exist
+	//
+	// Returns:
+	//   - jobID: ID of the restore job (can be used for progress tracking)
+	//   - error: If any step fails
+	RestorePartition(
+		ctx context.Context,
+		sourceCollectionID int64,
+		snapshotName string,
+		partitionName string,
+		startRestoreLock StartRestoreLockFunc,
+		startBroadcaster StartBroadcasterFunc,
+		rollback RollbackPartitionFunc,
+		validateResources ValidateResourcesFunc,
+	) (int64, error)
+
 	// RestoreCollection creates a new collection and its user partitions based on snapshot data.
 	// It marshals the schema, sets preserve field IDs property, calls RootCoord to create collection,
 	// then creates user-defined partitions (filtering out default and partition-key partitions).
@@ -250,11 +282,12 @@ type SnapshotManager interface {
 	//   - snapshotName: Name of the snapshot to restore (unique within source collection)
 	//   - collectionID: ID of the target collection (already created)
 	//   - jobID: Pre-allocated job ID for idempotency (from WAL message)
+	//   - partitionName: If set, only the segments of the partition are restored
 	//
 	// Returns:
 	//   - jobID: The restore job ID (same as input if job created, or existing job ID)
 	//   - error: If mapping fails or job creation fails
-	RestoreData(ctx context.Context, sourceCollectionID int64, snapshotName string, collectionID int64, jobID int64, pinID int64) (int64, error)
+	RestoreData(ctx context.Context, sourceCollectionID int64, snapshotName string, collectionID int64, jobID int64, pinID int64, partitionName string) (int64, error)
 
 	// Restore state query
 
@@ -616,44 +649,11 @@ func (sm *snapshotManager) RestoreSnapshot(
 	rollback RollbackFunc,
 	validateResources ValidateResourcesFunc,
 ) (jobID int64, err error) {
-	// ========================================================================
 	// Phase 0: Acquire serialization lock + claim restore reference
-	//
-	// This MUST happen before reading any snapshot data or creating any target
-	// resources. Without this, a concurrent DropSnapshot could delete the
-	// source snapshot between Phase 1 and Phase 4, leaving an orphan target
-	// collection and an ack callback that retries forever against a missing
-	// snapshot.
-	// ========================================================================
-	phase0Lock, err := startRestoreLock(ctx, sourceCollectionID, snapshotName, targetDbName, targetCollectionName)
+	pinID, err := sm.pinSnapshotForRestore(ctx, sourceCollectionID, snapshotName, targetDbName, targetCollectionName, startRestoreLock)
 	if err != nil {
-		return 0, merr.Wrap(err, "failed to acquire restore lock")
-	}
-
-	// Pin the source snapshot while holding the phase-0 lock. The pin is the
-	// persistent guard that any subsequent DropSnapshot (RPC / drop-collection
-	// cascade / GC) observes and rejects against — pin checks already live in
-	// snapshotMeta.DropSnapshot, so no separate ref-count mechanism is needed.
-	//
-	// TTL acts as an orphan-pin safety net: if the job fails to persist, datacoord
-	// crashes between Pin and broadcast, or UnpinSnapshot fails at terminal state,
-	// the pin self-expires so DropSnapshot is not blocked indefinitely. The default
-	// is 24h (dataCoord.snapshot.restorePinTTLSeconds), well above the worst-case
-	// restore wall time since restore is a segment-level S3 object copy (no data
-	// rewrite) — even multi-TB restores complete in minutes.
-	//
-	// PinSnapshot also does its own GetSnapshot under pinMu, which closes the
-	// TOCTOU against any DropSnapshot that committed between the proxy-level
-	// check and now — replacing the previous re-validation step.
-	pinTTLSeconds := Params.DataCoordCfg.SnapshotRestorePinTTLSeconds.GetAsInt64()
-	pinID, activePins, err := sm.snapshotMeta.PinSnapshot(ctx, sourceCollectionID, snapshotName, pinTTLSeconds)
-	if err != nil {
-		phase0Lock.Close()
-		return 0, merr.Wrap(err, "failed to pin source snapshot under restore lock")
+		return 0, err
 	}
-	setSnapshotActivePinsGauge(sourceCollectionID, snapshotName, activePins)
-	phase0Lock.Close()
-	mlog.Info(context.TODO(), "source snapshot pinned under phase 0 lock", mlog.Int64("pinID", pinID))
 
 	// If any subsequent phase fails, release the pin we just claimed. On the
 	// success path, ownership of the pin is transferred to the copy segment
@@ -662,16 +662,7 @@ func (sm *snapshotManager) RestoreSnapshot(
 	pinOwned := true
 	defer func() {
 		if pinOwned {
-			collID, snapName, remaining, unpinErr := sm.snapshotMeta.UnpinSnapshot(ctx, pinID)
-			if unpinErr != nil {
-				mlog.Warn(context.TODO(), "failed to release pin on failure path",
-					mlog.Int64("pinID", pinID), mlog.Err(unpinErr))
-				return
-			}
-			if snapName != "" {
-				setSnapshotActivePinsGauge(collID, snapName, remaining)
-			}
-			mlog.Info(context.TODO(), "released pin on failure path", mlog.Int64("pinID", pinID))
+			sm.releaseRestorePin(ctx, pinID)
 		}
 	}()
 
@@ -786,6 +777,208 @@ func (sm *snapshotManager) RestoreSnapshot(
 	return jobID, nil
 }
 
+// pinSnapshotForRestore acquires the phase 0 restore lock and pins the source snapshot under it.
+// The pin should be released by releaseRestorePin if the restore fails before the pin is
+// transferred to the copy segment job.
+func (sm *snapshotManager) pinSnapshotForRestore(
+	ctx context.Context,
+	sourceCollectionID int64,
+	snapshotName, targetDbName, targetCollectionName string,
+	startRestoreLock StartRestoreLockFunc,
+) (int64, error) {
+	// This MUST happen before reading any snapshot data or creating any target
+	// resources. Without this, a concurrent DropSnapshot could delete the
+	// source snapshot between Phase 1 and Phase 4, leaving an orphan target
+	// collection and an ack callback that retries forever against a missing
+	// snapshot.
+	phase0Lock, err := startRestoreLock(ctx, sourceCollectionID, snapshotName, targetDbName, targetCollectionName)
+	if err != nil {
+		return 0, merr.Wrap(err, "failed to acquire restore lock")
+	}
+
+	// Pin the source snapshot while holding the phase-0 lock. The pin is the
+	// persistent guard that any subsequent DropSnapshot (RPC / drop-collection
+	// cascade / GC) observes and rejects against — pin checks already live in
+	// snapshotMeta.DropSnapshot, so no separate ref-count mechanism is needed.
+	//
+	// TTL acts as an orphan-pin safety net: if the job fails to persist, datacoord
+	// crashes between Pin and broadcast, or UnpinSnapshot fails at terminal state,
+	// the pin self-expires so DropSnapshot is not blocked indefinitely. The default
+	// is 24h (dataCoord.snapshot.restorePinTTLSeconds), well above the worst-case
+	// restore wall time since restore is a segment-level S3 object copy (no data
+	// rewrite) — even multi-TB restores complete in minutes.
+	//
+	// PinSnapshot also does its own GetSnapshot under pinMu, which closes the
+	// TOCTOU against any DropSnapshot that committed between the proxy-level
+	// check and now — replacing the previous re-validation step.
+	pinTTLSeconds := Params.DataCoordCfg.SnapshotRestorePinTTLSeconds.GetAsInt64()
+	pinID, activePins, err := sm.snapshotMeta.PinSnapshot(ctx, sourceCollectionID, snapshotName, pinTTLSeconds)
+	if err != nil {
+		phase0Lock.Close()
+		return 0, merr.Wrap(err, "failed to pin source snapshot under restore lock")
+	}
+	setSnapshotActivePinsGauge(sourceCollectionID, snapshotName, activePins)
+	phase0Lock.Close()
+	mlog.Info(context.TODO(), "source snapshot pinned under phase 0 lock", mlog.Int64("pinID", pinID))
+	return pinID, nil
+}
+
+// releaseRestorePin releases the pin claimed by pinSnapshotForRestore on the restore failure path.
+func (sm *snapshotManager) releaseRestorePin(ctx context.Context, pinID int64) {
+	collID, snapName, remaining, unpinErr := sm.snapshotMeta.UnpinSnapshot(ctx, pinID)
+	if unpinErr != nil {
+		mlog.Warn(context.TODO(), "failed to release pin on failure path",
+			mlog.Int64("pinID", pinID), mlog.Err(unpinErr))
+		return
+	}
+	if snapName != "" {
+		setSnapshotActivePinsGauge(collID, snapName, remaining)
+	}
+	mlog.Info(context.TODO(), "released pin on failure path", mlog.Int64("pinID", pinID))
+}
+
+// RestorePartition restores a dropped partition from the snapshot in place into the source collection.
+// The partition is recreated with its original name, then only the segments of the partition are
+// copied into it. The collection-level L0 segments are scoped to the restored partition so that
+// the deletes in them never reach the other partitions. The indexes of the collection are kept as is.
+func (sm *snapshotManager) RestorePartition(
+	ctx context.Context,
+	sourceCollectionID int64,
+	snapshotName string,
+	partitionName string,
+	startRestoreLock StartRestoreLockFunc,
+	startBroadcaster StartBroadcasterFunc,
+	rollback RollbackPartitionFunc,
+	validateResources ValidateResourcesFunc,
+) (jobID int64, err error) {
+	coll, err := sm.broker.DescribeCollectionInternal(ctx, sourceCollectionID)
+	if err != nil {
+		return 0, merr.Wrapf(err, "collection %d of the partition does not exist", sourceCollectionID)
+	}
+	dbName := coll.GetDbName()
+	collectionName := coll.GetCollectionName()
+
+	// Phase 0: Acquire serialization lock + claim restore reference
+	pinID, err := sm.pinSnapshotForRestore(ctx, sourceCollectionID, snapshotName, dbName, collectionName, startRestoreLock)
+	if err != nil {
+		return 0, err
+	}
+	pinOwned := true
+	defer func() {
+		if pinOwned {
+			sm.releaseRestorePin(ctx, pinID)
+		}
+	}()
+
+	// Phase 1: Read snapshot data of the partition
+	snapshotData, err := sm.ReadSnapshotData(ctx, sourceCollectionID, snapshotName)
+	if err != nil {
+		return 0, merr.Wrap(err, "failed to read snapshot data")
+	}
+	snapshotData, err = snapshotDataOfPartition(snapshotData, partitionName)
+	if err != nil {
+		return 0, err
+	}
+
+	// Phase 2: Recreate the partition, it fails if the partition exists
+	if err := sm.broker.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		PartitionName:  partitionName,
+	}); err != nil {
+		return 0, merr.Wrapf(err, "failed to create partition %s", partitionName)
+	}
+	rollbackPartition := func() {
+		if rollbackErr := rollback(ctx, dbName, collectionName, partitionName); rollbackErr != nil {
+			mlog.Error(context.TODO(), "rollback failed", mlog.Err(rollbackErr))
+		}
+	}
+
+	// Phase 3: Pre-allocate job ID and broadcast restore message
+	jobID, err = sm.allocator.AllocID(ctx)
+	if err != nil {
+		rollbackPartition()
+		return 0, merr.Wrap(err, "failed to allocate job ID")
+	}
+
+	restoreBroadcaster, err := startBroadcaster(ctx, sourceCollectionID, snapshotName)
+	if err != nil {
+		rollbackPartition()
+		return 0, merr.Wrap(err, "failed to start broadcaster for restore message")
+	}
+	defer func() {
+		if restoreBroadcaster != nil {
+			restoreBroadcaster.Close()
+		}
+	}()
+
+	if valErr := validateResources(ctx, sourceCollectionID, snapshotData); valErr != nil {
+		// Release broadcast lock before rollback: rollback calls DropPartition
+		// which requires its own WAL broadcast lock on the same collection.
+		restoreBroadcaster.Close()
+		restoreBroadcaster = nil
+		rollbackPartition()
+		return 0, merr.Wrap(valErr, "resource validation failed")
+	}
+
+	msg := message.NewRestoreSnapshotMessageBuilderV2().
+		WithHeader(&message.RestoreSnapshotMessageHeader{
+			SnapshotName:       snapshotName,
+			CollectionId:       sourceCollectionID,
+			JobId:              jobID,
+			SourceCollectionId: sourceCollectionID,
+			PinId:              pinID,
+			PartitionName:      partitionName,
+		}).
+		WithBody(&message.RestoreSnapshotMessageBody{}).
+		WithBroadcast([]string{streaming.WAL().ControlChannel()}).
+		WithUnreplicable().
+		MustBuildBroadcast()
+
+	if _, bcErr := restoreBroadcaster.Broadcast(ctx, msg); bcErr != nil {
+		restoreBroadcaster.Close()
+		restoreBroadcaster = nil
+		rollbackPartition()
+		return 0, merr.Wrap(bcErr, "failed to broadcast restore message")
+	}
+
+	pinOwned = false
+	mlog.Info(context.TODO(), "restore partition completed",
+		mlog.FieldCollectionID(sourceCollectionID),
+		mlog.FieldPartitionName(partitionName),
+		mlog.Int64("jobID", jobID))
+	return jobID, nil
+}
+
+// snapshotDataOfPartition returns the snapshot data restricted to the partition, the indexes are
+// left out since the partition is restored into a collection having them. The collection-level L0
+// segments are scoped to the partition, so their deletes are only applied to the restored rows.
+func snapshotDataOfPartition(snapshotData *SnapshotData, partitionName string) (*SnapshotData, error) {
+	partitionID, ok := snapshotData.Collection.GetPartitions()[partitionName]
+	if !ok {
+		return nil, merr.WrapErrPartitionNotFound(partitionName, "partition does not exist in snapshot")
+	}
+
+	collection := proto.Clone(snapshotData.Collection).(*datapb.CollectionDescription)
+	collection.Partitions = map[string]int64{partitionName: partitionID}
+	segments := make([]*datapb.SegmentDescription, 0, len(snapshotData.Segments))
+	for _, segment := range snapshotData.Segments {
+		switch {
+		case segment.GetPartitionId() == partitionID:
+			segments = append(segments, segment)
+		case segment.GetSegmentLevel() == datapb.SegmentLevel_L0 && segment.GetPartitionId() == common.AllPartitionsID:
+			segment = proto.Clone(segment).(*datapb.SegmentDescription)
+			segment.PartitionId = partitionID
+			segments = append(segments, segment)
+		}
+	}
+	return &SnapshotData{
+		SnapshotInfo: snapshotData.SnapshotInfo,
+		Collection:   collection,
+		Segments:     segments,
+	}, nil
+}
+
 // RestoreCollection creates a new collection and its user partitions based on snapshot data.
 func (sm *snapshotManager) RestoreCollection(
 	ctx context.Context,
@@ -945,6 +1138,7 @@ func (sm *snapshotManager) RestoreData(
 	collectionID int64,
 	jobID int64,
 	pinID int64,
+	partitionName string,
 ) (int64, error) {
 	mlog.Info(context.TODO(), "restore data started")
 
@@ -961,6 +1155,13 @@ func (sm *snapshotManager) RestoreData(
 		mlog.Error(context.TODO(), "failed to read snapshot data", mlog.Err(err))
 		return 0, merr.Wrap(err, "failed to read snapshot data")
 	}
+	if partitionName != "" {
+		snapshotData, err = snapshotDataOfPartition(snapshotData, partitionName)
+		if err != nil {
+			mlog.Error(context.TODO(), "failed to read snapshot data of partition", mlog.Err(err))
+			return 0, err
+		}
+	}
 
 	// ========== Phase 2: Build partition mapping ==========
 	partitionMapping, err := sm.buildPartitionMapping(ctx, snapshotData, collectionID)
@@ -38,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/internal/mocks/distributed/mock_streaming"
 	"github.com/milvus-io/milvus/internal/streamingcoord/server/broadcaster"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
@@ -2011,6 +2012,141 @@ func (m *mockBroadcastAPI) Close() {
 // Ensure mockBroadcastAPI satisfies the broadcaster.BroadcastAPI interface.
 var _ broadcaster.BroadcastAPI = (*mockBroadcastAPI)(nil)
 
+// --- Test RestorePartition ---
+
+func TestSnapshotDataOfPartition(t *testing.T) {
+	snapshotData := &SnapshotData{
+		SnapshotInfo: &datapb.SnapshotInfo{Name: "snap1"},
+		Collection: &datapb.CollectionDescription{
+			Partitions: map[string]int64{"_default": 1, "p1": 2},
+		},
+		Segments: []*datapb.SegmentDescription{
+			{SegmentId: 10, PartitionId: 1, SegmentLevel: datapb.SegmentLevel_L1},
+			{SegmentId: 11, PartitionId: 2, SegmentLevel: datapb.SegmentLevel_L1},
+			{SegmentId: 12, PartitionId: 1, SegmentLevel: datapb.SegmentLevel_L0},
+			{SegmentId: 13, PartitionId: -1, SegmentLevel: datapb.SegmentLevel_L0},
+		},
+		Indexes: []*indexpb.IndexInfo{{IndexName: "idx"}},
+	}
+
+	partData, err := snapshotDataOfPartition(snapshotData, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"p1": 2}, partData.Collection.GetPartitions())
+	assert.Empty(t, partData.Indexes)
+	require.Len(t, partData.Segments, 2)
+	assert.Equal(t, int64(11), partData.Segments[0].GetSegmentId())
+	// the collection-level L0 segment is scoped to the restored partition.
+	assert.Equal(t, int64(13), partData.Segments[1].GetSegmentId())
+	assert.Equal(t, int64(2), partData.Segments[1].GetPartitionId())
+	// the snapshot data is not modified.
+	assert.Equal(t, int64(-1), snapshotData.Segments[3].GetPartitionId())
+	assert.Len(t, snapshotData.Collection.GetPartitions(), 2)
+
+	_, err = snapshotDataOfPartition(snapshotData, "p2")
+	assert.ErrorIs(t, err, merr.ErrPartitionNotFound)
+}
+
+func TestRestorePartition(t *testing.T) {
+	ctx := context.Background()
+
+	mPin := mockey.Mock((*snapshotMeta).PinSnapshot).Return(int64(42), 1, nil).Build()
+	defer mPin.UnPatch()
+	unpinCalls := 0
+	mUnpin := mockey.Mock((*snapshotMeta).UnpinSnapshot).To(
+		func(_ *snapshotMeta, _ context.Context, _ int64) (int64, string, int, error) {
+			unpinCalls++
+			return 0, "", 0, nil
+		}).Build()
+	defer mUnpin.UnPatch()
+	mRead := mockey.Mock((*snapshotMeta).ReadSnapshotData).Return(&SnapshotData{
+		SnapshotInfo: &datapb.SnapshotInfo{Name: "snap1"},
+		Collection: &datapb.CollectionDescription{
+			Partitions: map[string]int64{"_default": 1, "p1": 2},
+		},
+		Segments: []*datapb.SegmentDescription{
+			{SegmentId: 10, PartitionId: 1},
+			{SegmentId: 11, PartitionId: 2},
+		},
+	}, nil).Build()
+	defer mRead.UnPatch()
+
+	mockWAL := mock_streaming.NewMockWALAccesser(t)
+	mockWAL.EXPECT().ControlChannel().Return("control_channel").Maybe()
+	streaming.SetWALForTest(mockWAL)
+
+	newManager := func(t *testing.T) *snapshotManager {
+		mockBroker := broker.NewMockBroker(t)
+		mockBroker.EXPECT().DescribeCollectionInternal(mock.Anything, int64(100)).Return(&milvuspb.DescribeCollectionResponse{
+			Status:         merr.Success(),
+			DbName:         "db1",
+			CollectionName: "coll1",
+		}, nil)
+		mockBroker.EXPECT().CreatePartition(mock.Anything, mock.MatchedBy(func(req *milvuspb.CreatePartitionRequest) bool {
+			return req.GetDbName() == "db1" && req.GetCollectionName() == "coll1" && req.GetPartitionName() == "p1"
+		})).Return(nil)
+		mockAlloc := allocator.NewMockAllocator(t)
+		mockAlloc.EXPECT().AllocID(mock.Anything).Return(int64(999), nil)
+		return &snapshotManager{
+			broker:          mockBroker,
+			allocator:       mockAlloc,
+			snapshotMeta:    &snapshotMeta{},
+			copySegmentMeta: &copySegmentMeta{},
+		}
+	}
+	startRestoreLock := func(ctx context.Context, sourceCollectionID int64, snapshotName, targetDbName, targetCollectionName string) (broadcaster.BroadcastAPI, error) {
+		assert.Equal(t, "db1", targetDbName)
+		assert.Equal(t, "coll1", targetCollectionName)
+		return &mockBroadcastAPI{}, nil
+	}
+
+	t.Run("success", func(t *testing.T) {
+		unpinCalls = 0
+		broadcastCalled := false
+		startBroadcaster := func(ctx context.Context, collectionID int64, snapshotName string) (broadcaster.BroadcastAPI, error) {
+			return &mockBroadcastAPI{broadcastFn: func() { broadcastCalled = true }}, nil
+		}
+		rollback := func(ctx context.Context, dbName, collectionName, partitionName string) error {
+			t.Fatal("rollback should not be called on success")
+			return nil
+		}
+		validateResources := func(ctx context.Context, collectionID int64, snapshotData *SnapshotData) error {
+			assert.Equal(t, int64(100), collectionID)
+			assert.Equal(t, map[string]int64{"p1": 2}, snapshotData.Collection.GetPartitions())
+			assert.Len(t, snapshotData.Segments, 1)
+			return nil
+		}
+
+		jobID, err := newManager(t).RestorePartition(ctx, int64(100), "snap1", "p1",
+			startRestoreLock, startBroadcaster, rollback, validateResources)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(999), jobID)
+		assert.True(t, broadcastCalled)
+		assert.Equal(t, 0, unpinCalls)
+	})
+
+	t.Run("validation fails", func(t *testing.T) {
+		unpinCalls = 0
+		startBroadcaster := func(ctx context.Context, collectionID int64, snapshotName string) (broadcaster.BroadcastAPI, error) {
+			return &mockBroadcastAPI{}, nil
+		}
+		rollbackCalled := false
+		rollback := func(ctx context.Context, dbName, collectionName, partitionName string) error {
+			rollbackCalled = true
+			assert.Equal(t, "p1", partitionName)
+			return nil
+		}
+		validateResources := func(ctx context.Context, collectionID int64, snapshotData *SnapshotData) error {
+			return errors.New("partition missing")
+		}
+
+		_, err := newManager(t).RestorePartition(ctx, int64(100), "snap1", "p1",
+			startRestoreLock, startBroadcaster, rollback, validateResources)
+		assert.Error(t, err)
+		assert.True(t, rollbackCalled)
+		assert.Equal(t, 1, unpinCalls)
+	})
+}
+
 // --- Test NewSnapshotManager ---
 
 func TestNewSnapshotManager(t *testing.T) {
@@ -2180,7 +2316,7 @@ func TestSnapshotManager_RestoreData_Success(t *testing.T) {
 		copySegmentMeta: &copySegmentMeta{},
 	}
 
-	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0))
+	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0), "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12345), jobID)
@@ -2216,7 +2352,7 @@ func TestSnapshotManager_RestoreData_Idempotent(t *testing.T) {
 	}
 
 	// Should return immediately without creating a new job
-	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0))
+	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0), "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12345), jobID)
@@ -2271,7 +2407,7 @@ func TestSnapshotManager_RestoreData_PartitionMappingError(t *testing.T) {
 		copySegmentMeta: &copySegmentMeta{},
 	}
 
-	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0))
+	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0), "")
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), jobID)
@@ -2338,7 +2474,7 @@ func TestSnapshotManager_RestoreData_ChannelMappingError(t *testing.T) {
 		copySegmentMeta: &copySegmentMeta{},
 	}
 
-	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0))
+	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0), "")
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), jobID)
@@ -2420,7 +2556,7 @@ func TestSnapshotManager_RestoreData_CreateJobError(t *testing.T) {
 		copySegmentMeta: &copySegmentMeta{},
 	}
 
-	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0))
+	jobID, err := sm.RestoreData(ctx, int64(100), snapshotData.SnapshotInfo.GetName(), 200, 12345, int64(0), "")
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), jobID)
@@ -2458,7 +2594,7 @@ func TestSnapshotManager_RestoreData_ReadSnapshotDataError(t *testing.T) {
 		copySegmentMeta: &copySegmentMeta{},
 	}
 
-	jobID, err := sm.RestoreData(ctx, int64(100), "test_snapshot", 200, 12345, int64(0))
+	jobID, err := sm.RestoreData(ctx, int64(100), "test_snapshot", 200, 12345, int64(0), "")
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), jobID)
@@ -14,6 +14,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/metastore"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
@@ -735,16 +736,40 @@ func (sm *snapshotMeta) DropSnapshot(ctx context.Context, collectionID int64, sn
 
 // DropSnapshotsByCollection deletes all snapshots belonging to a collection.
 // Used during drop collection cascade cleanup. Each snapshot goes through the
-// same delete flow as individual DropSnapshot.
+// same delete flow as individual DropSnapshot. Recycle bin snapshots are kept
+// until their retention expires, see isRecycleBinSnapshotRetained.
 //
 // Returns the names of snapshots that were successfully dropped so callers
 // (manager layer) can clear per-snapshot metric series. Snapshots that were
-// skipped (pinned, not-found) or failed are NOT included.
+// skipped (retained, pinned, not-found) or failed are NOT included.
 //
 // Note: a concurrent SaveSnapshot between Step 1 (snapshot ID copy) and Step 3 (drop loop)
 // could create a snapshot that is missed by this cascade. The GC orphan detection serves
 // as a fallback to clean up such snapshots.
 func (sm *snapshotMeta) DropSnapshotsByCollection(ctx context.Context, collectionID int64) ([]string, error) {
+	now := time.Now()
+	return sm.dropSnapshotsOfCollection(ctx, collectionID, func(name string) bool {
+		if isRecycleBinSnapshotRetained(name, now) {
+			mlog.Info(context.TODO(), "skip snapshot retained in recycle bin during collection cleanup",
+				mlog.String("snapshotName", name))
+			return false
+		}
+		return true
+	})
+}
+
+// DropExpiredRecycleBinSnapshots deletes the recycle bin snapshots of a collection
+// whose retention has expired. Other snapshots are left untouched.
+func (sm *snapshotMeta) DropExpiredRecycleBinSnapshots(ctx context.Context, collectionID int64) ([]string, error) {
+	now := time.Now()
+	return sm.dropSnapshotsOfCollection(ctx, collectionID, func(name string) bool {
+		_, _, _, ok := common.ParseRecycleBinSnapshotName(name)
+		return ok && !isRecycleBinSnapshotRetained(name, now)
+	})
+}
+
+// dropSnapshotsOfCollection deletes the snapshots of a collection accepted by the filter.
+func (sm *snapshotMeta) dropSnapshotsOfCollection(ctx context.Context, collectionID int64, filter func(name string) bool) ([]string, error) {
 	// Step 1: Get all snapshot IDs for this collection.
 	// Copy IDs under lock since UniqueSet (raw map) is not thread-safe for concurrent read/write.
 	sm.collectionIndexMu.RLock()
@@ -763,7 +788,9 @@ func (sm *snapshotMeta) DropSnapshotsByCollection(ctx context.Context, collectio
 	var snapshotNames []string
 	for _, id := range ids {
 		if info, exists := sm.snapshotID2Info.Get(id); exists {
-			snapshotNames = append(snapshotNames, info.GetName())
+			if filter(info.GetName()) {
+				snapshotNames = append(snapshotNames, info.GetName())
+			}
 		}
 	}
 
@@ -1772,3 +1799,14 @@ func (sm *snapshotMeta) generatePinID(snapshot *datapb.SnapshotInfo) (int64, err
 	}
 	return 0, merr.WrapErrServiceInternalMsg("failed to generate unique pin ID after 3 attempts")
 }
+
+// isRecycleBinSnapshotRetained returns true if the snapshot keeps a dropped collection or
+// partition in the recycle bin and common.recycleBin.retentionSeconds has not elapsed since the drop.
+func isRecycleBinSnapshotRetained(name string, now time.Time) bool {
+	_, _, dropTime, ok := common.ParseRecycleBinSnapshotName(name)
+	if !ok {
+		return false
+	}
+	retention := paramtable.Get().CommonCfg.RecycleBinRetentionSeconds.GetAsDuration(time.Second)
+	return now.Before(dropTime.Add(retention))
+}
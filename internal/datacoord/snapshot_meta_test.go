@@ -31,6 +31,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	kv_datacoord "github.com/milvus-io/milvus/internal/metastore/kv/datacoord"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
@@ -2915,6 +2916,60 @@ func TestSnapshotMeta_DropSnapshotsByCollection_DeletesAllForCollection(t *testi
 	assert.Contains(t, names200, "snap_c")
 }
 
+func TestSnapshotMeta_DropSnapshotsByCollection_RetainsRecycleBinSnapshots(t *testing.T) {
+	// Should keep the recycle bin snapshots until the retention expires.
+	// Arrange
+	ctx := context.Background()
+	sm := createTestSnapshotMetaLoaded(t)
+	paramtable.Get().Save(paramtable.Get().CommonCfg.RecycleBinRetentionSeconds.Key, "3600")
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.RecycleBinRetentionSeconds.Key)
+
+	snap1 := createTestSnapshotDataForMeta()
+	snap1.SnapshotInfo.Id = 1
+	snap1.SnapshotInfo.Name = "snap_a"
+	snap1.SnapshotInfo.CollectionId = 100
+
+	snap2 := createTestSnapshotDataForMeta()
+	snap2.SnapshotInfo.Id = 2
+	snap2.SnapshotInfo.Name = common.RecycleBinSnapshotName(100, "", time.Now())
+	snap2.SnapshotInfo.CollectionId = 100
+
+	snap3 := createTestSnapshotDataForMeta()
+	snap3.SnapshotInfo.Id = 3
+	snap3.SnapshotInfo.Name = common.RecycleBinSnapshotName(100, "p1", time.Now().Add(-2*time.Hour))
+	snap3.SnapshotInfo.CollectionId = 100
+
+	cleanup := saveTestSnapshots(t, sm, snap1, snap2, snap3)
+	cleanup()
+
+	mock0 := mockey.Mock((*kv_datacoord.Catalog).SaveSnapshot).Return(nil).Build()
+	defer mock0.UnPatch()
+	mock1 := mockey.Mock((*kv_datacoord.Catalog).DropSnapshot).Return(nil).Build()
+	defer mock1.UnPatch()
+	mock2 := mockey.Mock((*SnapshotWriter).Drop).Return(nil).Build()
+	defer mock2.UnPatch()
+
+	// Act - only the expired recycle bin snapshot is dropped
+	dropped, err := sm.DropExpiredRecycleBinSnapshots(ctx, int64(100))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{snap3.SnapshotInfo.Name}, dropped)
+
+	// Act - the recycle bin snapshot within retention survives the collection cascade
+	dropped, err = sm.DropSnapshotsByCollection(ctx, int64(100))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"snap_a"}, dropped)
+
+	names, err := sm.ListSnapshots(ctx, int64(100), int64(0))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{snap2.SnapshotInfo.Name}, names)
+
+	// Act - disabling the recycle bin expires all of its snapshots
+	paramtable.Get().Save(paramtable.Get().CommonCfg.RecycleBinRetentionSeconds.Key, "0")
+	dropped, err = sm.DropSnapshotsByCollection(ctx, int64(100))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{snap2.SnapshotInfo.Name}, dropped)
+}
+
 func TestSnapshotMeta_DropSnapshotsByCollection_NoopForNonexistentCollection(t *testing.T) {
 	// Should be no-op for collection with no snapshots.
 	// Arrange
@@ -96,6 +96,9 @@ const (
 
 	CommitAction = "commit"
 	AbortAction  = "abort"
+
+	// OpenAPIPath serves the OpenAPI spec of the v2 API
+	OpenAPIPath = "/openapi.json"
 )
 
 const (
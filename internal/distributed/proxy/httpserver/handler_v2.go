@@ -190,168 +190,165 @@ var routeToMethod = map[string]string{ //nolint:gosec // not credentials, just a
 	"/v2/vectordb/common/run_analyzer": "RunAnalyzer",
 }
 
+// routeV2 is a POST route of the RESTful v2 API, the route table is the source of
+// both the registered routes and the OpenAPI spec served by the proxy.
+type routeV2 struct {
+	path    string
+	newReq  newReqFunc
+	handler handlerFuncV2
+	// sizeObserved routes are wrapped by restfulSizeMiddleware, see observeOutbound.
+	sizeObserved    bool
+	observeOutbound bool
+}
+
+func (h *HandlersV2) routesV2() []routeV2 {
+	return []routeV2{
+		{path: CollectionCategory + ListAction, newReq: func() any { return &DatabaseReq{} }, handler: h.listCollections},
+		{path: CollectionCategory + HasAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.hasCollection},
+		// todo review the return data
+		{path: CollectionCategory + DescribeAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.getCollectionDetails},
+		{path: CollectionCategory + StatsAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.getCollectionStats},
+		{path: CollectionCategory + LoadStateAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.getCollectionLoadState},
+		{path: CollectionCategory + CreateAction, newReq: func() any { return &CollectionReq{AutoID: DisableAutoID} }, handler: h.createCollection},
+		{path: CollectionCategory + DropAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.dropCollection},
+		{path: CollectionCategory + TruncateAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.truncateCollection},
+		{path: CollectionCategory + RenameAction, newReq: func() any { return &RenameCollectionReq{} }, handler: h.renameCollection},
+		{path: CollectionCategory + LoadAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.loadCollection},
+		{path: CollectionCategory + RefreshLoadAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.refreshLoadCollection},
+		{path: CollectionCategory + ReleaseAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.releaseCollection},
+		{path: CollectionCategory + AlterPropertiesAction, newReq: func() any { return &CollectionReqWithProperties{} }, handler: h.alterCollectionProperties},
+		{path: CollectionCategory + AddFunctionAction, newReq: func() any { return &CollectionAddFunction{} }, handler: h.addCollectionFunction},
+		{path: CollectionCategory + AlterFunctionAction, newReq: func() any { return &CollectionAlterFunction{} }, handler: h.alterCollectionFunction},
+		{path: CollectionCategory + DropFunctionAction, newReq: func() any { return &CollectionDropFunction{} }, handler: h.dropCollectionFunction},
+		{path: CollectionCategory + AddFunctionFieldAction, newReq: func() any { return &CollectionAddFunctionField{} }, handler: h.addCollectionFunctionField},
+		{path: CollectionCategory + DropFunctionFieldAction, newReq: func() any { return &CollectionDropFunctionField{} }, handler: h.dropCollectionFunctionField},
+		{path: CollectionCategory + DropPropertiesAction, newReq: func() any { return &DropCollectionPropertiesReq{} }, handler: h.dropCollectionProperties},
+		{path: CollectionCategory + CompactAction, newReq: func() any { return &CompactReq{} }, handler: h.compact},
+		{path: CollectionCategory + CompactionStateAction, newReq: func() any { return &GetCompactionStateReq{} }, handler: h.getcompactionState},
+		{path: CollectionCategory + FlushAction, newReq: func() any { return &FlushReq{} }, handler: h.flush},
+
+		{path: CollectionFieldCategory + AlterPropertiesAction, newReq: func() any { return &CollectionFieldReqWithParams{} }, handler: h.alterCollectionFieldProperties},
+
+		// /collections/fields/add
+		{path: CollectionFieldCategory + AddAction, newReq: func() any { return &CollectionFieldReqWithSchema{} }, handler: h.addCollectionField},
+		{path: CollectionStructFieldCategory + AddAction, newReq: func() any { return &CollectionFieldReqWithSchema{} }, handler: h.addCollectionStructField},
+
+		{path: DataBaseCategory + CreateAction, newReq: func() any { return &DatabaseReqWithProperties{} }, handler: h.createDatabase},
+		{path: DataBaseCategory + DropAction, newReq: func() any { return &DatabaseReqRequiredName{} }, handler: h.dropDatabase},
+		{path: DataBaseCategory + DropPropertiesAction, newReq: func() any { return &DropDatabasePropertiesReq{} }, handler: h.dropDatabaseProperties},
+		{path: DataBaseCategory + ListAction, newReq: func() any { return &EmptyReq{} }, handler: h.listDatabases},
+		{path: DataBaseCategory + DescribeAction, newReq: func() any { return &DatabaseReqRequiredName{} }, handler: h.describeDatabase},
+		{path: DataBaseCategory + AlterAction, newReq: func() any { return &DatabaseReqWithProperties{} }, handler: h.alterDatabase},
+		{path: DataBaseCategory + AlterPropertiesAction, newReq: func() any { return &DatabaseReqWithProperties{} }, handler: h.alterDatabase},
+		// Query
+		{path: EntityCategory + QueryAction, newReq: func() any { return &QueryReqV2{Limit: 100, OutputFields: []string{DefaultOutputFields}} }, handler: h.query, sizeObserved: true, observeOutbound: true},
+		// Get
+		{path: EntityCategory + GetAction, newReq: func() any { return &CollectionIDReq{OutputFields: []string{DefaultOutputFields}} }, handler: h.get, sizeObserved: true, observeOutbound: true},
+		// Delete
+		{path: EntityCategory + DeleteAction, newReq: func() any { return &CollectionFilterReq{} }, handler: h.delete, sizeObserved: true},
+		// Insert
+		{path: EntityCategory + InsertAction, newReq: func() any { return &CollectionDataReq{} }, handler: h.insert, sizeObserved: true},
+		// Upsert
+		{path: EntityCategory + UpsertAction, newReq: func() any { return &CollectionDataReq{} }, handler: h.upsert, sizeObserved: true},
+		// Search
+		{path: EntityCategory + SearchAction, newReq: func() any { return &SearchReqV2{Limit: 100} }, handler: h.search, sizeObserved: true, observeOutbound: true},
+		// advanced_search, backward compatible uri
+		{path: EntityCategory + AdvancedSearchAction, newReq: func() any { return &HybridSearchReq{Limit: 100} }, handler: h.advancedSearch, sizeObserved: true, observeOutbound: true},
+		// HybridSearch
+		{path: EntityCategory + HybridSearchAction, newReq: func() any { return &HybridSearchReq{Limit: 100} }, handler: h.advancedSearch, sizeObserved: true, observeOutbound: true},
+
+		{path: PartitionCategory + ListAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.listPartitions},
+		{path: PartitionCategory + HasAction, newReq: func() any { return &PartitionReq{} }, handler: h.hasPartitions},
+		{path: PartitionCategory + StatsAction, newReq: func() any { return &PartitionReq{} }, handler: h.statsPartition},
+
+		{path: PartitionCategory + CreateAction, newReq: func() any { return &PartitionReq{} }, handler: h.createPartition},
+		{path: PartitionCategory + DropAction, newReq: func() any { return &PartitionReq{} }, handler: h.dropPartition},
+		{path: PartitionCategory + LoadAction, newReq: func() any { return &PartitionsReq{} }, handler: h.loadPartitions},
+		{path: PartitionCategory + ReleaseAction, newReq: func() any { return &PartitionsReq{} }, handler: h.releasePartitions},
+
+		{path: UserCategory + ListAction, newReq: func() any { return &DatabaseReq{} }, handler: h.listUsers},
+		{path: UserCategory + DescribeAction, newReq: func() any { return &UserReq{} }, handler: h.describeUser},
+
+		{path: UserCategory + CreateAction, newReq: func() any { return &PasswordReq{} }, handler: h.createUser},
+		{path: UserCategory + UpdatePasswordAction, newReq: func() any { return &NewPasswordReq{} }, handler: h.updateUser},
+		{path: UserCategory + DropAction, newReq: func() any { return &UserReq{} }, handler: h.dropUser},
+		{path: UserCategory + GrantRoleAction, newReq: func() any { return &UserRoleReq{} }, handler: h.addRoleToUser},
+		{path: UserCategory + RevokeRoleAction, newReq: func() any { return &UserRoleReq{} }, handler: h.removeRoleFromUser},
+
+		{path: RoleCategory + ListAction, newReq: func() any { return &DatabaseReq{} }, handler: h.listRoles},
+		{path: RoleCategory + DescribeAction, newReq: func() any { return &RoleReq{} }, handler: h.describeRole},
+
+		{path: RoleCategory + CreateAction, newReq: func() any { return &RoleReq{} }, handler: h.createRole},
+		{path: RoleCategory + AlterAction, newReq: func() any { return &RoleReq{} }, handler: h.alterRole},
+		{path: RoleCategory + DropAction, newReq: func() any { return &RoleReq{} }, handler: h.dropRole},
+		{path: RoleCategory + GrantPrivilegeAction, newReq: func() any { return &GrantReq{} }, handler: h.addPrivilegeToRole},
+		{path: RoleCategory + RevokePrivilegeAction, newReq: func() any { return &GrantReq{} }, handler: h.removePrivilegeFromRole},
+		{path: RoleCategory + GrantPrivilegeActionV2, newReq: func() any { return &GrantV2Req{} }, handler: h.grantV2},
+		{path: RoleCategory + RevokePrivilegeActionV2, newReq: func() any { return &GrantV2Req{} }, handler: h.revokeV2},
+
+		// privilege group
+		{path: PrivilegeGroupCategory + CreateAction, newReq: func() any { return &PrivilegeGroupReq{} }, handler: h.createPrivilegeGroup},
+		{path: PrivilegeGroupCategory + DropAction, newReq: func() any { return &PrivilegeGroupReq{} }, handler: h.dropPrivilegeGroup},
+		{path: PrivilegeGroupCategory + ListAction, newReq: func() any { return &DatabaseReq{} }, handler: h.listPrivilegeGroups},
+		{path: PrivilegeGroupCategory + AddPrivilegesToGroupAction, newReq: func() any { return &PrivilegeGroupReq{} }, handler: h.addPrivilegesToGroup},
+		{path: PrivilegeGroupCategory + RemovePrivilegesFromGroupAction, newReq: func() any { return &PrivilegeGroupReq{} }, handler: h.removePrivilegesFromGroup},
+
+		{path: IndexCategory + ListAction, newReq: func() any { return &CollectionNameReq{} }, handler: h.listIndexes},
+		{path: IndexCategory + DescribeAction, newReq: func() any { return &IndexReq{} }, handler: h.describeIndex},
+
+		{path: IndexCategory + CreateAction, newReq: func() any { return &IndexParamReq{} }, handler: h.createIndex},
+		// todo cannot drop index before release it ?
+		{path: IndexCategory + DropAction, newReq: func() any { return &IndexReq{} }, handler: h.dropIndex},
+		{path: IndexCategory + AlterPropertiesAction, newReq: func() any { return &IndexReqWithProperties{} }, handler: h.alterIndexProperties},
+		{path: IndexCategory + DropPropertiesAction, newReq: func() any { return &DropIndexPropertiesReq{} }, handler: h.dropIndexProperties},
+
+		{path: AliasCategory + ListAction, newReq: func() any { return &OptionalCollectionNameReq{} }, handler: h.listAlias},
+		{path: AliasCategory + DescribeAction, newReq: func() any { return &AliasReq{} }, handler: h.describeAlias},
+
+		{path: AliasCategory + CreateAction, newReq: func() any { return &AliasCollectionReq{} }, handler: h.createAlias},
+		{path: AliasCategory + DropAction, newReq: func() any { return &AliasReq{} }, handler: h.dropAlias},
+		{path: AliasCategory + AlterAction, newReq: func() any { return &AliasCollectionReq{} }, handler: h.alterAlias},
+
+		{path: ImportJobCategory + ListAction, newReq: func() any { return &OptionalCollectionNameReq{} }, handler: h.listImportJob},
+		{path: ImportJobCategory + CreateAction, newReq: func() any { return &ImportReq{} }, handler: h.createImportJob},
+		{path: ImportJobCategory + GetProgressAction, newReq: func() any { return &JobIDReq{} }, handler: h.getImportJobProcess},
+		{path: ImportJobCategory + DescribeAction, newReq: func() any { return &JobIDReq{} }, handler: h.getImportJobProcess},
+		{path: ImportJobCategory + CommitAction, newReq: func() any { return &JobIDReq{} }, handler: h.commitImportJob},
+		{path: ImportJobCategory + AbortAction, newReq: func() any { return &JobIDReq{} }, handler: h.abortImportJob},
+		{path: SnapshotJobCategory + RestoreExternalAction, newReq: func() any { return &RestoreExternalSnapshotReq{} }, handler: h.restoreExternalSnapshot},
+		{path: SnapshotJobCategory + ExportAction, newReq: func() any { return &ExportSnapshotReq{} }, handler: h.exportSnapshot},
+		{path: SnapshotJobCategory + DescribeAction, newReq: func() any { return &JobIDReq{} }, handler: h.getRestoreSnapshotState},
+		{path: SnapshotJobCategory + ListAction, newReq: func() any { return &OptionalCollectionNameReq{} }, handler: h.listRestoreSnapshotJobs},
+		{path: ExternalCollectionJobCategory + RefreshAction, newReq: func() any { return &RefreshExternalCollectionReq{} }, handler: h.refreshExternalCollection},
+		{path: ExternalCollectionJobCategory + DescribeAction, newReq: func() any { return &RefreshExternalCollectionProgressReq{} }, handler: h.getRefreshExternalCollectionProgress},
+		{path: ExternalCollectionJobCategory + ListAction, newReq: func() any { return &OptionalCollectionNameReq{} }, handler: h.listRefreshExternalCollectionJobs},
+
+		// resource group
+		{path: ResourceGroupCategory + CreateAction, newReq: func() any { return &ResourceGroupReq{} }, handler: h.createResourceGroup},
+		{path: ResourceGroupCategory + DropAction, newReq: func() any { return &ResourceGroupReq{} }, handler: h.dropResourceGroup},
+		{path: ResourceGroupCategory + AlterAction, newReq: func() any { return &UpdateResourceGroupReq{} }, handler: h.updateResourceGroup},
+		{path: ResourceGroupCategory + DescribeAction, newReq: func() any { return &ResourceGroupReq{} }, handler: h.describeResourceGroup},
+		{path: ResourceGroupCategory + ListAction, newReq: func() any { return &EmptyReq{} }, handler: h.listResourceGroups},
+		{path: ResourceGroupCategory + TransferReplicaAction, newReq: func() any { return &TransferReplicaReq{} }, handler: h.transferReplica},
+
+		// segment group
+		{path: SegmentCategory + DescribeAction, newReq: func() any { return &GetSegmentsInfoReq{} }, handler: h.getSegmentsInfo},
+		{path: QuotaCenterCategory + DescribeAction, newReq: func() any { return &GetQuotaMetricsReq{} }, handler: h.getQuotaMetrics},
+
+		// common
+		{path: CommonCategory + RunAnalyzerAction, newReq: func() any { return &RunAnalyzerReq{} }, handler: h.runAnalyzer},
+	}
+}
+
 func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
-	router.POST(CollectionCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listCollections))))
-	router.POST(CollectionCategory+HasAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.hasCollection))))
-	// todo review the return data
-	router.POST(CollectionCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionDetails))))
-	router.POST(CollectionCategory+StatsAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionStats))))
-	router.POST(CollectionCategory+LoadStateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionLoadState))))
-	router.POST(CollectionCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionReq{AutoID: DisableAutoID} }, wrapperTraceLog(h.createCollection))))
-	router.POST(CollectionCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.dropCollection))))
-	router.POST(CollectionCategory+TruncateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.truncateCollection))))
-	router.POST(CollectionCategory+RenameAction, timeoutMiddleware(wrapperPost(func() any { return &RenameCollectionReq{} }, wrapperTraceLog(h.renameCollection))))
-	router.POST(CollectionCategory+LoadAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.loadCollection))))
-	router.POST(CollectionCategory+RefreshLoadAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.refreshLoadCollection))))
-	router.POST(CollectionCategory+ReleaseAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.releaseCollection))))
-	router.POST(CollectionCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionReqWithProperties{} }, wrapperTraceLog(h.alterCollectionProperties))))
-	router.POST(CollectionCategory+AddFunctionAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionAddFunction{} }, wrapperTraceLog(h.addCollectionFunction))))
-	router.POST(CollectionCategory+AlterFunctionAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionAlterFunction{} }, wrapperTraceLog(h.alterCollectionFunction))))
-	router.POST(CollectionCategory+DropFunctionAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionDropFunction{} }, wrapperTraceLog(h.dropCollectionFunction))))
-	router.POST(CollectionCategory+AddFunctionFieldAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionAddFunctionField{} }, wrapperTraceLog(h.addCollectionFunctionField))))
-	router.POST(CollectionCategory+DropFunctionFieldAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionDropFunctionField{} }, wrapperTraceLog(h.dropCollectionFunctionField))))
-	router.POST(CollectionCategory+DropPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DropCollectionPropertiesReq{} }, wrapperTraceLog(h.dropCollectionProperties))))
-	router.POST(CollectionCategory+CompactAction, timeoutMiddleware(wrapperPost(func() any { return &CompactReq{} }, wrapperTraceLog(h.compact))))
-	router.POST(CollectionCategory+CompactionStateAction, timeoutMiddleware(wrapperPost(func() any { return &GetCompactionStateReq{} }, wrapperTraceLog(h.getcompactionState))))
-	router.POST(CollectionCategory+FlushAction, timeoutMiddleware(wrapperPost(func() any { return &FlushReq{} }, wrapperTraceLog(h.flush))))
-
-	router.POST(CollectionFieldCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionFieldReqWithParams{} }, wrapperTraceLog(h.alterCollectionFieldProperties))))
-
-	// /collections/fields/add
-	router.POST(CollectionFieldCategory+AddAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionFieldReqWithSchema{} }, wrapperTraceLog(h.addCollectionField))))
-	router.POST(CollectionStructFieldCategory+AddAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionFieldReqWithSchema{} }, wrapperTraceLog(h.addCollectionStructField))))
-
-	router.POST(DataBaseCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.createDatabase))))
-	router.POST(DataBaseCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.dropDatabase))))
-	router.POST(DataBaseCategory+DropPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DropDatabasePropertiesReq{} }, wrapperTraceLog(h.dropDatabaseProperties))))
-	router.POST(DataBaseCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &EmptyReq{} }, wrapperTraceLog(h.listDatabases))))
-	router.POST(DataBaseCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.describeDatabase))))
-	router.POST(DataBaseCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
-	router.POST(DataBaseCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
-	// Query
-	router.POST(EntityCategory+QueryAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &QueryReqV2{
-			Limit:        100,
-			OutputFields: []string{DefaultOutputFields},
-		}
-	}, wrapperTraceLog(h.query))), true))
-	// Get
-	router.POST(EntityCategory+GetAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &CollectionIDReq{
-			OutputFields: []string{DefaultOutputFields},
-		}
-	}, wrapperTraceLog(h.get))), true))
-	// Delete
-	router.POST(EntityCategory+DeleteAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &CollectionFilterReq{}
-	}, wrapperTraceLog(h.delete))), false))
-	// Insert
-	router.POST(EntityCategory+InsertAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &CollectionDataReq{}
-	}, wrapperTraceLog(h.insert))), false))
-	// Upsert
-	router.POST(EntityCategory+UpsertAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &CollectionDataReq{}
-	}, wrapperTraceLog(h.upsert))), false))
-	// Search
-	router.POST(EntityCategory+SearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &SearchReqV2{
-			Limit: 100,
-		}
-	}, wrapperTraceLog(h.search))), true))
-	// advanced_search, backward compatible uri
-	router.POST(EntityCategory+AdvancedSearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &HybridSearchReq{
-			Limit: 100,
+	for _, route := range h.routesV2() {
+		handler := timeoutMiddleware(wrapperPost(route.newReq, wrapperTraceLog(route.handler)))
+		if route.sizeObserved {
+			handler = restfulSizeMiddleware(handler, route.observeOutbound)
 		}
-	}, wrapperTraceLog(h.advancedSearch))), true))
-	// HybridSearch
-	router.POST(EntityCategory+HybridSearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
-		return &HybridSearchReq{
-			Limit: 100,
-		}
-	}, wrapperTraceLog(h.advancedSearch))), true))
-
-	router.POST(PartitionCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listPartitions))))
-	router.POST(PartitionCategory+HasAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.hasPartitions))))
-	router.POST(PartitionCategory+StatsAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.statsPartition))))
-
-	router.POST(PartitionCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.createPartition))))
-	router.POST(PartitionCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.dropPartition))))
-	router.POST(PartitionCategory+LoadAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.loadPartitions))))
-	router.POST(PartitionCategory+ReleaseAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.releasePartitions))))
-
-	router.POST(UserCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listUsers))))
-	router.POST(UserCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &UserReq{} }, wrapperTraceLog(h.describeUser))))
-
-	router.POST(UserCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PasswordReq{} }, wrapperTraceLog(h.createUser))))
-	router.POST(UserCategory+UpdatePasswordAction, timeoutMiddleware(wrapperPost(func() any { return &NewPasswordReq{} }, wrapperTraceLog(h.updateUser))))
-	router.POST(UserCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &UserReq{} }, wrapperTraceLog(h.dropUser))))
-	router.POST(UserCategory+GrantRoleAction, timeoutMiddleware(wrapperPost(func() any { return &UserRoleReq{} }, wrapperTraceLog(h.addRoleToUser))))
-	router.POST(UserCategory+RevokeRoleAction, timeoutMiddleware(wrapperPost(func() any { return &UserRoleReq{} }, wrapperTraceLog(h.removeRoleFromUser))))
-
-	router.POST(RoleCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listRoles))))
-	router.POST(RoleCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.describeRole))))
-
-	router.POST(RoleCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.createRole))))
-	router.POST(RoleCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.alterRole))))
-	router.POST(RoleCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.dropRole))))
-	router.POST(RoleCategory+GrantPrivilegeAction, timeoutMiddleware(wrapperPost(func() any { return &GrantReq{} }, wrapperTraceLog(h.addPrivilegeToRole))))
-	router.POST(RoleCategory+RevokePrivilegeAction, timeoutMiddleware(wrapperPost(func() any { return &GrantReq{} }, wrapperTraceLog(h.removePrivilegeFromRole))))
-	router.POST(RoleCategory+GrantPrivilegeActionV2, timeoutMiddleware(wrapperPost(func() any { return &GrantV2Req{} }, wrapperTraceLog(h.grantV2))))
-	router.POST(RoleCategory+RevokePrivilegeActionV2, timeoutMiddleware(wrapperPost(func() any { return &GrantV2Req{} }, wrapperTraceLog(h.revokeV2))))
-
-	// privilege group
-	router.POST(PrivilegeGroupCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.createPrivilegeGroup))))
-	router.POST(PrivilegeGroupCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.dropPrivilegeGroup))))
-	router.POST(PrivilegeGroupCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listPrivilegeGroups))))
-	router.POST(PrivilegeGroupCategory+AddPrivilegesToGroupAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.addPrivilegesToGroup))))
-	router.POST(PrivilegeGroupCategory+RemovePrivilegesFromGroupAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.removePrivilegesFromGroup))))
-
-	router.POST(IndexCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listIndexes))))
-	router.POST(IndexCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &IndexReq{} }, wrapperTraceLog(h.describeIndex))))
-
-	router.POST(IndexCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &IndexParamReq{} }, wrapperTraceLog(h.createIndex))))
-	// todo cannot drop index before release it ?
-	router.POST(IndexCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &IndexReq{} }, wrapperTraceLog(h.dropIndex))))
-	router.POST(IndexCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &IndexReqWithProperties{} }, wrapperTraceLog(h.alterIndexProperties))))
-	router.POST(IndexCategory+DropPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DropIndexPropertiesReq{} }, wrapperTraceLog(h.dropIndexProperties))))
-
-	router.POST(AliasCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listAlias))))
-	router.POST(AliasCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &AliasReq{} }, wrapperTraceLog(h.describeAlias))))
-
-	router.POST(AliasCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &AliasCollectionReq{} }, wrapperTraceLog(h.createAlias))))
-	router.POST(AliasCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &AliasReq{} }, wrapperTraceLog(h.dropAlias))))
-	router.POST(AliasCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &AliasCollectionReq{} }, wrapperTraceLog(h.alterAlias))))
-
-	router.POST(ImportJobCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listImportJob))))
-	router.POST(ImportJobCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &ImportReq{} }, wrapperTraceLog(h.createImportJob))))
-	router.POST(ImportJobCategory+GetProgressAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
-	router.POST(ImportJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
-	router.POST(ImportJobCategory+CommitAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.commitImportJob))))
-	router.POST(ImportJobCategory+AbortAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.abortImportJob))))
-	router.POST(SnapshotJobCategory+RestoreExternalAction, timeoutMiddleware(wrapperPost(func() any { return &RestoreExternalSnapshotReq{} }, wrapperTraceLog(h.restoreExternalSnapshot))))
-	router.POST(SnapshotJobCategory+ExportAction, timeoutMiddleware(wrapperPost(func() any { return &ExportSnapshotReq{} }, wrapperTraceLog(h.exportSnapshot))))
-	router.POST(SnapshotJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getRestoreSnapshotState))))
-	router.POST(SnapshotJobCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listRestoreSnapshotJobs))))
-	router.POST(ExternalCollectionJobCategory+RefreshAction, timeoutMiddleware(wrapperPost(func() any { return &RefreshExternalCollectionReq{} }, wrapperTraceLog(h.refreshExternalCollection))))
-	router.POST(ExternalCollectionJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &RefreshExternalCollectionProgressReq{} }, wrapperTraceLog(h.getRefreshExternalCollectionProgress))))
-	router.POST(ExternalCollectionJobCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listRefreshExternalCollectionJobs))))
-
-	// resource group
-	router.POST(ResourceGroupCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.createResourceGroup))))
-	router.POST(ResourceGroupCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.dropResourceGroup))))
-	router.POST(ResourceGroupCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &UpdateResourceGroupReq{} }, wrapperTraceLog(h.updateResourceGroup))))
-	router.POST(ResourceGroupCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.describeResourceGroup))))
-	router.POST(ResourceGroupCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &EmptyReq{} }, wrapperTraceLog(h.listResourceGroups))))
-	router.POST(ResourceGroupCategory+TransferReplicaAction, timeoutMiddleware(wrapperPost(func() any { return &TransferReplicaReq{} }, wrapperTraceLog(h.transferReplica))))
-
-	// segment group
-	router.POST(SegmentCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &GetSegmentsInfoReq{} }, wrapperTraceLog(h.getSegmentsInfo))))
-	router.POST(QuotaCenterCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &GetQuotaMetricsReq{} }, wrapperTraceLog(h.getQuotaMetrics))))
-
-	// common
-	router.POST(CommonCategory+RunAnalyzerAction, timeoutMiddleware(wrapperPost(func() any { return &RunAnalyzerReq{} }, wrapperTraceLog(h.runAnalyzer))))
+		router.POST(route.path, handler)
+	}
+	router.GET(OpenAPIPath, h.getOpenAPISpec)
 }
 
 type (
@@ -362,6 +359,15 @@ type (
 func wrapperPost(newReq newReqFunc, v2 handlerFuncV2) gin.HandlerFunc {
 	return func(gCtx *gin.Context) {
 		req := newReq()
+		if err := validateRequestBody(gCtx, req); err != nil {
+			mlog.Warn(context.TODO(), "high level restful api, request body doesn't match the OpenAPI spec", mlog.Err(err),
+				mlog.Any("url", gCtx.Request.URL.Path))
+			HTTPAbortReturn(gCtx, http.StatusOK, gin.H{
+				HTTPReturnCode:    merr.Code(err),
+				HTTPReturnMessage: err.Error(),
+			})
+			return
+		}
 		if err := gCtx.ShouldBindBodyWith(req, binding.JSON); err != nil {
 			mlog.Warn(context.TODO(), "high level restful api, read parameters from request body fail", mlog.Err(err),
 				mlog.Any("url", gCtx.Request.URL.Path))
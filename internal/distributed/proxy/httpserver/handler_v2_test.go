@@ -161,7 +161,7 @@ func TestHTTPWrapper(t *testing.T) {
 	postTestCases = append(postTestCases, requestBodyTestCase{
 		path:        path,
 		requestBody: []byte(`{}`),
-		errMsg:      "missing required parameters, error: Key: 'CollectionNameReq.CollectionName' Error:Field validation for 'CollectionName' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `collectionName` is required",
 		errCode:     1802, // ErrMissingRequiredParameters
 	})
 	postTestCases = append(postTestCases, requestBodyTestCase{
@@ -3740,7 +3740,7 @@ func TestDML(t *testing.T) {
 	queryTestCases = append(queryTestCases, requestBodyTestCase{
 		path:        GetAction,
 		requestBody: []byte(`{"collectionName": "book", "outputFields": ["book_id",  "word_count", "book_intro"]}`),
-		errMsg:      "missing required parameters, error: Key: 'CollectionIDReq.ID' Error:Field validation for 'ID' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `id` is required",
 		errCode:     1802, // ErrMissingRequiredParameters
 	})
 	queryTestCases = append(queryTestCases, requestBodyTestCase{
@@ -3786,7 +3786,7 @@ func TestDML(t *testing.T) {
 	queryTestCases = append(queryTestCases, requestBodyTestCase{
 		path:        DeleteAction,
 		requestBody: []byte(`{"collectionName": "book", "id" : [0]}`),
-		errMsg:      "missing required parameters, error: Key: 'CollectionFilterReq.Filter' Error:Field validation for 'Filter' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `filter` is required",
 		errCode:     1802, // ErrMissingRequiredParameters
 	})
 	queryTestCases = append(queryTestCases, requestBodyTestCase{
@@ -4742,7 +4742,7 @@ func (s *AddCollectionFieldSuite) TestAddCollectionFieldFail() {
 				path:        versionalV2(CollectionFieldCategory, AddAction),
 				requestBody: []byte(`{"collectionName": "book", "schema": {"fieldName": "new_field", "nullable": true, "elementTypeParams": {}}}`),
 				errCode:     1802, // missing param
-				errMsg:      "missing required parameters, error: Key: 'CollectionFieldReqWithSchema.Schema.DataType' Error:Field validation for 'DataType' failed on the 'required' tag",
+				errMsg:      "missing required parameters, error: `schema.dataType` is required",
 			},
 			{
 				path:        versionalV2(CollectionFieldCategory, AddAction),
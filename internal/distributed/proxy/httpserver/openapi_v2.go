@@ -0,0 +1,492 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/tidwall/gjson"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	// openAPIServerURL is the prefix the v2 routes are registered under, see registerHTTPServer.
+	openAPIServerURL   = "/v2/vectordb"
+	openAPISchemaRef   = "#/components/schemas/"
+	openAPIParamRef    = "#/components/parameters/"
+	openAPIResponseRef = "Response"
+	openAPIBearerAuth  = "bearerAuth"
+)
+
+type openAPIDocument struct {
+	OpenAPI    string                      `json:"openapi"`
+	Info       openAPIInfo                 `json:"info"`
+	Servers    []openAPIServer             `json:"servers"`
+	Paths      map[string]*openAPIPathItem `json:"paths"`
+	Components openAPIComponents           `json:"components"`
+	Security   []map[string][]string       `json:"security"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIPathItem struct {
+	Post *openAPIOperation `json:"post,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref         string         `json:"$ref,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	In          string         `json:"in,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Schema      *openAPISchema `json:"schema,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas"`
+	Parameters      map[string]*openAPIParameter      `json:"parameters"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes"`
+}
+
+// openAPISchema is the subset of the OpenAPI schema object used by the v2 request structs,
+// a schema without type accepts any value.
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+func (s *openAPISchema) isAny() bool {
+	return s == nil || (s.Ref == "" && s.Type == "")
+}
+
+// property returns the property matching the json key, the key is matched case-insensitively
+// as encoding/json does when binding the request.
+func (s *openAPISchema) property(key string) (string, *openAPISchema) {
+	if prop, ok := s.Properties[key]; ok {
+		return key, prop
+	}
+	for name, prop := range s.Properties {
+		if strings.EqualFold(name, key) {
+			return name, prop
+		}
+	}
+	return "", nil
+}
+
+// openAPISchemaBuilder builds the schemas from the request structs,
+// every named struct is put into components and referenced by its type name.
+type openAPISchemaBuilder struct {
+	schemas map[string]*openAPISchema
+}
+
+func newOpenAPISchemaBuilder() *openAPISchemaBuilder {
+	return &openAPISchemaBuilder{
+		schemas: make(map[string]*openAPISchema),
+	}
+}
+
+func (b *openAPISchemaBuilder) schemaOf(t reflect.Type) *openAPISchema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.schemaOf(t.Elem())
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "uint32"}
+	case reflect.Uint, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "uint64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &openAPISchema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, ok := b.schemas[t.Name()]; !ok {
+			// register before building the fields, the struct may refer to itself
+			b.schemas[t.Name()] = &openAPISchema{}
+			b.schemas[t.Name()] = b.structSchema(t)
+		}
+		return &openAPISchema{Ref: openAPISchemaRef + t.Name()}
+	default:
+		return &openAPISchema{}
+	}
+}
+
+func (b *openAPISchemaBuilder) structSchema(t reflect.Type) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = b.schemaOf(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// requestSchema is the schema of a request struct with the components it refers to.
+type requestSchema struct {
+	root    *openAPISchema
+	schemas map[string]*openAPISchema
+}
+
+var requestSchemas = typeutil.NewConcurrentMap[reflect.Type, *requestSchema]()
+
+func getRequestSchema(req any) *requestSchema {
+	t := reflect.TypeOf(req)
+	if schema, ok := requestSchemas.Get(t); ok {
+		return schema
+	}
+	builder := newOpenAPISchemaBuilder()
+	schema := &requestSchema{root: builder.schemaOf(t), schemas: builder.schemas}
+	requestSchemas.Insert(t, schema)
+	return schema
+}
+
+// buildOpenAPIDocument generates the OpenAPI spec of the v2 API from the route table and request structs.
+func buildOpenAPIDocument(routes []routeV2) *openAPIDocument {
+	builder := newOpenAPISchemaBuilder()
+	builder.schemas[openAPIResponseRef] = &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			HTTPReturnCode:    {Type: "integer", Format: "int32"},
+			HTTPReturnMessage: {Type: "string"},
+			HTTPReturnData:    {},
+			HTTPReturnCost:    {Type: "integer", Format: "int64"},
+		},
+		Required: []string{HTTPReturnCode},
+	}
+
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: "Milvus RESTful API", Version: "v2"},
+		Servers: []openAPIServer{{URL: openAPIServerURL}},
+		Paths:   make(map[string]*openAPIPathItem, len(routes)),
+		Components: openAPIComponents{
+			Schemas: builder.schemas,
+			Parameters: map[string]*openAPIParameter{
+				"DBName": {
+					Name: HTTPHeaderDBName, In: "header", Schema: &openAPISchema{Type: "string"},
+					Description: "database of the request when dbName is not set in the body",
+				},
+				"RequestTimeout": {
+					Name: HTTPHeaderRequestTimeout, In: "header", Schema: &openAPISchema{Type: "integer", Format: "int64"},
+					Description: "timeout of the request in seconds",
+				},
+				"AcceptTypeAllowInt64": {
+					Name: HTTPHeaderAllowInt64, In: "header", Schema: &openAPISchema{Type: "boolean"},
+					Description: "return int64 values as numbers instead of strings",
+				},
+			},
+			SecuritySchemes: map[string]*openAPISecurityScheme{
+				openAPIBearerAuth: {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{openAPIBearerAuth: {}}},
+	}
+
+	for _, route := range routes {
+		segments := strings.Split(strings.Trim(route.path, "/"), "/")
+		doc.Paths[route.path] = &openAPIPathItem{
+			Post: &openAPIOperation{
+				OperationID: openAPIOperationID(segments),
+				Summary:     routeToMethod[openAPIServerURL+route.path],
+				Tags:        []string{strings.Join(segments[:len(segments)-1], "/")},
+				Parameters: []*openAPIParameter{
+					{Ref: openAPIParamRef + "DBName"},
+					{Ref: openAPIParamRef + "RequestTimeout"},
+					{Ref: openAPIParamRef + "AcceptTypeAllowInt64"},
+				},
+				RequestBody: &openAPIRequestBody{
+					Required: true,
+					Content: map[string]*openAPIMediaType{
+						binding.MIMEJSON: {Schema: builder.schemaOf(reflect.TypeOf(route.newReq()))},
+					},
+				},
+				Responses: map[string]*openAPIResponse{
+					strconv.Itoa(http.StatusOK): {
+						Description: "the result of the request, code is 0 on success",
+						Content: map[string]*openAPIMediaType{
+							binding.MIMEJSON: {Schema: &openAPISchema{Ref: openAPISchemaRef + openAPIResponseRef}},
+						},
+					},
+				},
+			},
+		}
+	}
+	return doc
+}
+
+// openAPIOperationID converts the path segments into a camel case identifier,
+// e.g. collections/get_load_state to collectionsGetLoadState.
+func openAPIOperationID(segments []string) string {
+	var sb strings.Builder
+	for _, segment := range segments {
+		for _, word := range strings.Split(segment, "_") {
+			if word == "" {
+				continue
+			}
+			if sb.Len() == 0 {
+				sb.WriteString(word)
+			} else {
+				sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+			}
+		}
+	}
+	return sb.String()
+}
+
+var (
+	openAPISpecOnce sync.Once
+	openAPISpec     []byte
+	openAPISpecErr  error
+)
+
+func (h *HandlersV2) getOpenAPISpec(c *gin.Context) {
+	openAPISpecOnce.Do(func() {
+		openAPISpec, openAPISpecErr = json.Marshal(buildOpenAPIDocument(h.routesV2()))
+	})
+	if openAPISpecErr != nil {
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(openAPISpecErr),
+			HTTPReturnMessage: openAPISpecErr.Error(),
+		})
+		return
+	}
+	c.Data(http.StatusOK, binding.MIMEJSON, openAPISpec)
+}
+
+// openAPIValidator validates a json value against the schema, null is accepted anywhere
+// since it's decoded as the zero value.
+type openAPIValidator struct {
+	schemas map[string]*openAPISchema
+	missing []string
+	invalid []string
+}
+
+func (v *openAPIValidator) resolve(schema *openAPISchema) *openAPISchema {
+	for schema != nil && schema.Ref != "" {
+		schema = v.schemas[strings.TrimPrefix(schema.Ref, openAPISchemaRef)]
+	}
+	return schema
+}
+
+func (v *openAPIValidator) validate(path string, value gjson.Result, schema *openAPISchema) {
+	schema = v.resolve(schema)
+	if schema.isAny() || value.Type == gjson.Null {
+		return
+	}
+	switch schema.Type {
+	case "object":
+		if !value.IsObject() {
+			v.mismatch(path, schema)
+			return
+		}
+		if len(schema.Properties) == 0 && schema.AdditionalProperties.isAny() {
+			return
+		}
+		present := make(map[string]bool)
+		value.ForEach(func(key, val gjson.Result) bool {
+			name, prop := schema.property(key.String())
+			if prop == nil {
+				if schema.AdditionalProperties != nil {
+					v.validate(joinSchemaPath(path, key.String()), val, schema.AdditionalProperties)
+				}
+				return true
+			}
+			if val.Type != gjson.Null {
+				present[name] = true
+			}
+			v.validate(joinSchemaPath(path, name), val, prop)
+			return true
+		})
+		for _, name := range schema.Required {
+			if !present[name] {
+				v.missing = append(v.missing, fmt.Sprintf("`%s` is required", joinSchemaPath(path, name)))
+			}
+		}
+	case "array":
+		if !value.IsArray() {
+			v.mismatch(path, schema)
+			return
+		}
+		if v.resolve(schema.Items).isAny() {
+			return
+		}
+		i := 0
+		value.ForEach(func(_, item gjson.Result) bool {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), item, schema.Items)
+			i++
+			return true
+		})
+	case "string":
+		if value.Type != gjson.String {
+			v.mismatch(path, schema)
+		}
+	case "boolean":
+		if !value.IsBool() {
+			v.mismatch(path, schema)
+		}
+	case "number":
+		if value.Type != gjson.Number {
+			v.mismatch(path, schema)
+		}
+	case "integer":
+		if value.Type != gjson.Number || !isIntegerOfFormat(value.Raw, schema.Format) {
+			v.mismatch(path, schema)
+		}
+	}
+}
+
+func (v *openAPIValidator) mismatch(path string, schema *openAPISchema) {
+	expected := schema.Type
+	if schema.Type == "integer" && schema.Format != "" {
+		expected = schema.Format
+	}
+	v.invalid = append(v.invalid, fmt.Sprintf("`%s` should be %s", path, expected))
+}
+
+func isIntegerOfFormat(raw string, format string) bool {
+	var err error
+	switch format {
+	case "int32":
+		_, err = strconv.ParseInt(raw, 10, 32)
+	case "uint32":
+		_, err = strconv.ParseUint(raw, 10, 32)
+	case "uint64":
+		_, err = strconv.ParseUint(raw, 10, 64)
+	default:
+		_, err = strconv.ParseInt(raw, 10, 64)
+	}
+	return err == nil
+}
+
+func joinSchemaPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// validateRequestBody validates the request body against the schema of the request struct,
+// the body which is not a json object is left to the binding to report.
+func validateRequestBody(c *gin.Context, req any) error {
+	var body []byte
+	if cached, ok := c.Get(gin.BodyBytesKey); ok {
+		body, _ = cached.([]byte)
+	}
+	if body == nil {
+		var err error
+		body, err = c.GetRawData()
+		if err != nil {
+			return fmt.Errorf("%w, error: %s", merr.ErrIncorrectParameterFormat, err.Error())
+		}
+		// reuse the body when binding the request
+		c.Set(gin.BodyBytesKey, body)
+	}
+	if !gjson.ValidBytes(body) {
+		return nil
+	}
+	value := gjson.ParseBytes(body)
+	if !value.IsObject() {
+		return nil
+	}
+
+	schema := getRequestSchema(req)
+	v := &openAPIValidator{schemas: schema.schemas}
+	v.validate("", value, schema.root)
+	if len(v.invalid) > 0 {
+		return fmt.Errorf("%w, error: %s", merr.ErrIncorrectParameterFormat, strings.Join(v.invalid, "; "))
+	}
+	if len(v.missing) > 0 {
+		return fmt.Errorf("%w, error: %s", merr.ErrMissingRequiredParameters, strings.Join(v.missing, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// updateOpenAPISpec rewrites the committed spec with the served one, run
+// go test -run TestOpenAPISpecMatchesRoutes -update after changing the v2 API.
+var updateOpenAPISpec = flag.Bool("update", false, "update testdata/openapi_v2.json")
+
+// walkSchemaRefs calls fn with every $ref reachable from the schema.
+func walkSchemaRefs(schema *openAPISchema, fn func(ref string)) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		fn(schema.Ref)
+	}
+	for _, prop := range schema.Properties {
+		walkSchemaRefs(prop, fn)
+	}
+	walkSchemaRefs(schema.Items, fn)
+	walkSchemaRefs(schema.AdditionalProperties, fn)
+}
+
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	paramtable.Init()
+	ginHandler := gin.New()
+	appV2 := ginHandler.Group(openAPIServerURL, genAuthMiddleWare(false))
+	h := NewHandlersV2(nil)
+	h.RegisterRoutesToV2(appV2)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, openAPIServerURL+OpenAPIPath, nil)
+	ginHandler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// the served spec is the committed one, an API change must update it on purpose
+	specFile := filepath.Join("testdata", "openapi_v2.json")
+	if *updateOpenAPISpec {
+		indented, err := json.MarshalIndent(buildOpenAPIDocument(h.routesV2()), "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(specFile, append(indented, '\n'), 0o644))
+	}
+	committed, err := os.ReadFile(specFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(committed), w.Body.String(), "the OpenAPI spec differs from %s, run the test with -update if the change is intended", specFile)
+
+	doc := &openAPIDocument{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), doc))
+	assert.Equal(t, openAPIVersion, doc.OpenAPI)
+
+	// every registered route is in the spec and the method mapping of metrics
+	registered := make(map[string]struct{})
+	for _, route := range ginHandler.Routes() {
+		if route.Method != http.MethodPost {
+			continue
+		}
+		registered[route.Path] = struct{}{}
+		path := strings.TrimPrefix(route.Path, openAPIServerURL)
+		assert.Contains(t, doc.Paths, path, "route %s is not in the OpenAPI spec", route.Path)
+		assert.Contains(t, routeToMethod, route.Path, "route %s is not in routeToMethod", route.Path)
+	}
+	// every path in the spec is registered
+	operationIDs := make(map[string]struct{})
+	for path, item := range doc.Paths {
+		assert.Contains(t, registered, openAPIServerURL+path, "path %s of the OpenAPI spec is not registered", path)
+		require.NotNil(t, item.Post)
+		assert.NotContains(t, operationIDs, item.Post.OperationID)
+		operationIDs[item.Post.OperationID] = struct{}{}
+		assert.NotNil(t, item.Post.RequestBody.Content[binding.MIMEJSON].Schema)
+	}
+	assert.Equal(t, len(registered), len(doc.Paths))
+
+	// every reference is resolvable
+	checkRef := func(ref string) {
+		assert.Contains(t, doc.Components.Schemas, strings.TrimPrefix(ref, openAPISchemaRef), "unresolved reference %s", ref)
+	}
+	for _, schema := range doc.Components.Schemas {
+		walkSchemaRefs(schema, checkRef)
+	}
+	for _, item := range doc.Paths {
+		walkSchemaRefs(item.Post.RequestBody.Content[binding.MIMEJSON].Schema, checkRef)
+		for _, param := range item.Post.Parameters {
+			assert.Contains(t, doc.Components.Parameters, strings.TrimPrefix(param.Ref, openAPIParamRef))
+		}
+	}
+
+	collectionReq := doc.Components.Schemas["CollectionReq"]
+	require.NotNil(t, collectionReq)
+	assert.Equal(t, []string{"collectionName"}, collectionReq.Required)
+	assert.Equal(t, openAPISchemaRef+"CollectionSchema", collectionReq.Properties["schema"].Ref)
+	assert.Equal(t, "collectionsGetLoadState", doc.Paths[CollectionCategory+LoadStateAction].Post.OperationID)
+	assert.Equal(t, []string{"jobs/import"}, doc.Paths[ImportJobCategory+CreateAction].Post.Tags)
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	type testCase struct {
+		name    string
+		req     any
+		body    string
+		errCode int32
+		errMsg  string
+	}
+	testCases := []testCase{
+		{name: "valid", req: &CollectionNameReq{}, body: `{"collectionName": "book", "partitionNames": ["p1"]}`},
+		{name: "case_insensitive", req: &CollectionNameReq{}, body: `{"CollectionName": "book"}`},
+		{name: "null", req: &QueryReqV2{}, body: `{"collectionName": "book", "limit": null, "outputFields": null}`},
+		{name: "unknown_field", req: &CollectionNameReq{}, body: `{"collectionName": "book", "unknown": 1}`},
+		{name: "not_object", req: &CollectionNameReq{}, body: `[]`},
+		{name: "invalid_json", req: &CollectionNameReq{}, body: `{"collectionName"}`},
+		{name: "empty", req: &CollectionNameReq{}, body: ``},
+		{
+			name: "missing", req: &TransferReplicaReq{}, body: `{"collectionName": "book", "sourceRgName": null}`,
+			errCode: merr.Code(merr.ErrMissingRequiredParameters),
+			errMsg:  "missing required parameters, error: `sourceRgName` is required; `targetRgName` is required; `replicaNum` is required",
+		},
+		{
+			name: "nested_missing", req: &CollectionFieldReqWithSchema{}, body: `{"collectionName": "book", "schema": {"fieldName": "f"}}`,
+			errCode: merr.Code(merr.ErrMissingRequiredParameters),
+			errMsg:  "missing required parameters, error: `schema.dataType` is required",
+		},
+		{
+			name: "wrong_type", req: &QueryReqV2{}, body: `{"collectionName": 1, "limit": "10", "outputFields": "*"}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `collectionName` should be string; `limit` should be int32; `outputFields` should be array",
+		},
+		{
+			name: "wrong_type_before_missing", req: &QueryReqV2{}, body: `{"offset": 1.5}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `offset` should be int32",
+		},
+		{
+			name: "array_item", req: &CollectionNameReq{}, body: `{"collectionName": "book", "partitionNames": [1]}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `partitionNames[0]` should be string",
+		},
+		{
+			name: "int32_overflow", req: &SearchReqV2{}, body: `{"collectionName": "book", "limit": 4294967296}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `limit` should be int32",
+		},
+		{
+			name: "map", req: &UpdateResourceGroupReq{}, body: `{"resource_groups": {"rg": {"requests": {"node_num": 1}, "limits": {"node_num": "1"}}}}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `resource_groups.rg.limits.node_num` should be int32",
+		},
+		{
+			name: "recursive", req: &SearchReqV2{}, body: `{"collectionName": "book", "searchAggregation": {"subAggregation": {"size": true}}}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `searchAggregation.subAggregation.size` should be int64",
+		},
+		{
+			name: "rows", req: &CollectionDataReq{}, body: `{"collectionName": "book", "data": [{"id": 1}, 2]}`,
+			errCode: merr.Code(merr.ErrIncorrectParameterFormat),
+			errMsg:  "can only accept json format request, error: `data[1]` should be object",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.body)))
+			err := validateRequestBody(c, tc.req)
+			if tc.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tc.errCode, merr.Code(err))
+			assert.Equal(t, tc.errMsg, err.Error())
+		})
+	}
+
+	t.Run("bind_after_validate", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"collectionName": "book"}`)))
+		req := &CollectionNameReq{}
+		require.NoError(t, validateRequestBody(c, req))
+		require.NoError(t, c.ShouldBindBodyWith(req, binding.JSON))
+		assert.Equal(t, "book", req.CollectionName)
+	})
+}
@@ -84,7 +84,7 @@ func TestResourceGroupHandlerV2(t *testing.T) {
 		path:        versionalV2(ResourceGroupCategory, CreateAction),
 		requestBody: []byte(`{}`),
 		errCode:     1802,
-		errMsg:      "missing required parameters, error: Key: 'ResourceGroupReq.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `name` is required",
 	})
 
 	// test case: create resource group with name
@@ -105,7 +105,7 @@ func TestResourceGroupHandlerV2(t *testing.T) {
 		path:        versionalV2(ResourceGroupCategory, DescribeAction),
 		requestBody: []byte(`{}`),
 		errCode:     1802,
-		errMsg:      "missing required parameters, error: Key: 'ResourceGroupReq.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `name` is required",
 	})
 	// test case: describe resource group with name
 	testCases = append(testCases, requestBodyTestCase{
@@ -118,7 +118,7 @@ func TestResourceGroupHandlerV2(t *testing.T) {
 		path:        versionalV2(ResourceGroupCategory, DropAction),
 		requestBody: []byte(`{}`),
 		errCode:     1802,
-		errMsg:      "missing required parameters, error: Key: 'ResourceGroupReq.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `name` is required",
 	})
 	// test case: drop resource group with name
 	testCases = append(testCases, requestBodyTestCase{
@@ -137,7 +137,7 @@ func TestResourceGroupHandlerV2(t *testing.T) {
 		path:        versionalV2(ResourceGroupCategory, AlterAction),
 		requestBody: []byte(`{}`),
 		errCode:     1802,
-		errMsg:      "missing required parameters, error: Key: 'UpdateResourceGroupReq.ResourceGroups' Error:Field validation for 'ResourceGroups' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `resource_groups` is required",
 	})
 
 	// test case: update resource group with resource groups
@@ -152,7 +152,7 @@ func TestResourceGroupHandlerV2(t *testing.T) {
 		path:        versionalV2(ResourceGroupCategory, TransferReplicaAction),
 		requestBody: []byte(`{}`),
 		errCode:     1802,
-		errMsg:      "missing required parameters, error: Key: 'TransferReplicaReq.SourceRgName' Error:Field validation for 'SourceRgName' failed on the 'required' tag\nKey: 'TransferReplicaReq.TargetRgName' Error:Field validation for 'TargetRgName' failed on the 'required' tag\nKey: 'TransferReplicaReq.CollectionName' Error:Field validation for 'CollectionName' failed on the 'required' tag\nKey: 'TransferReplicaReq.ReplicaNum' Error:Field validation for 'ReplicaNum' failed on the 'required' tag",
+		errMsg:      "missing required parameters, error: `sourceRgName` is required; `targetRgName` is required; `collectionName` is required; `replicaNum` is required",
 	})
 
 	// test case: transfer replica
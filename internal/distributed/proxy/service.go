@@ -48,6 +48,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/federpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
 	mix "github.com/milvus-io/milvus/internal/distributed/mixcoord/client"
 	"github.com/milvus-io/milvus/internal/distributed/proxy/httpserver"
 	"github.com/milvus-io/milvus/internal/distributed/streaming"
@@ -242,7 +243,9 @@ func (s *Server) startHTTPServer(errChan chan error) {
 	errChan <- nil
 	if s.listenerManager.portShareMode {
 		serveErrChan := make(chan error, 2)
-		go func() { serveErrChan <- s.serveHTTP(s.listenerManager.HTTP2Listener()) }()
+		go func() {
+			serveErrChan <- s.serveHTTP(connection.GetManager().TrackListener(s.listenerManager.HTTP2Listener()))
+		}()
 		go func() { serveErrChan <- s.serveHTTP(s.listenerManager.HTTPListener()) }()
 		for i := 0; i < 2; i++ {
 			if err := <-serveErrChan; err != nil {
@@ -281,6 +284,10 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 		Time:    60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
 		Timeout: 10 * time.Second, // Wait 10 second for the ping ack before assuming the connection is dead
 	}
+	if maxIdle := paramtable.Get().ProxyCfg.MaxConnectionIdleSeconds.GetAsDuration(time.Second); maxIdle > 0 {
+		// Close the connection without any in-flight rpc for a long time, the client reconnects when it's used again
+		kasp.MaxConnectionIdle = maxIdle
+	}
 
 	limiter, err := s.proxy.GetRateLimiter()
 	if err != nil {
@@ -301,6 +308,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 			proxy.UnaryServerInterceptor(proxy.PrivilegeInterceptor),
 			proxy.UnaryServerHookInterceptor(),
 			mlog.UnaryServerInterceptor(typeutil.ProxyRole),
+			proxy.ConnectionLimitInterceptor(),
 			proxy.RateLimitInterceptor(limiter),
 			accesslog.UnaryUpdateAccessInfoInterceptor,
 			proxy.TraceLogInterceptor,
@@ -309,6 +317,14 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 	} else {
 		unaryServerOption = grpc.EmptyServerOption{}
 	}
+	var streamServerOption grpc.ServerOption
+	if enableCustomInterceptor {
+		streamServerOption = grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			proxy.ConnectionLimitStreamInterceptor(),
+		))
+	} else {
+		streamServerOption = grpc.EmptyServerOption{}
+	}
 
 	grpcOpts := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(kaep),
@@ -316,6 +332,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 		grpc.MaxRecvMsgSize(Params.ServerMaxRecvSize.GetAsInt()),
 		grpc.MaxSendMsgSize(Params.ServerMaxSendSize.GetAsInt()),
 		unaryServerOption,
+		streamServerOption,
 		grpc.StatsHandler(tracer.GetDynamicOtelGrpcServerStatsHandler()),
 		grpc.StatsHandler(metrics.NewGRPCSizeStatsHandler().
 			// both inbound and outbound
@@ -375,6 +392,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 
 	milvuspb.RegisterMilvusServiceServer(s.grpcExternalServer, s)
 	milvuspb.RegisterClientTelemetryServiceServer(s.grpcExternalServer, s)
+	changestreampb.RegisterChangeStreamServiceServer(s.grpcExternalServer, s)
 	grpc_health_v1.RegisterHealthServer(s.grpcExternalServer, s)
 	errChan <- nil
 
@@ -386,7 +404,8 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 		mlog.Info(context.TODO(), "Proxy external grpc server is served by shared http2 server")
 		return
 	}
-	if err := s.grpcExternalServer.Serve(s.listenerManager.ExternalGrpcListener()); err != nil && err != cmux.ErrServerClosed {
+	// the connections are tracked, so the clients could be disconnected by the management api.
+	if err := s.grpcExternalServer.Serve(connection.GetManager().TrackListener(s.listenerManager.ExternalGrpcListener())); err != nil && err != cmux.ErrServerClosed {
 		mlog.Error(context.TODO(), "failed to serve on Proxy's listener", mlog.Err(err))
 		errChan <- err
 		return
@@ -1281,6 +1300,11 @@ func (s *Server) DumpMessages(req *milvuspb.DumpMessagesRequest, stream milvuspb
 	return s.proxy.DumpMessages(req, stream)
 }
 
+// SubscribeChanges streams the decoded entity changes of one collection.
+func (s *Server) SubscribeChanges(req *changestreampb.SubscribeChangesRequest, stream changestreampb.ChangeStreamService_SubscribeChangesServer) error {
+	return s.proxy.SubscribeChanges(req, stream)
+}
+
 // ComputePhraseMatchSlop computes the minimum slop required for phrase matching.
 func (s *Server) ComputePhraseMatchSlop(ctx context.Context, req *milvuspb.ComputePhraseMatchSlopRequest) (*milvuspb.ComputePhraseMatchSlopResponse, error) {
 	return s.proxy.ComputePhraseMatchSlop(ctx, req)
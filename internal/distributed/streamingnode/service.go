@@ -33,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	mix "github.com/milvus-io/milvus/internal/distributed/mixcoord/client"
 	"github.com/milvus-io/milvus/internal/distributed/utils"
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	tikvkv "github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/storage"
@@ -83,6 +84,7 @@ type Server struct {
 	// component client
 	etcdCli        *clientv3.Client
 	tikvCli        *txnkv.Client
+	boltDB         *boltkv.DB
 	mixCoord       *syncutil.Future[types.MixCoordClient]
 	chunkManager   storage.ChunkManager
 	componentState *componentutil.ComponentStateService
@@ -179,6 +181,13 @@ func (s *Server) stop() {
 		}
 	}
 
+	// Release bolt db
+	if s.boltDB != nil {
+		if err := s.boltDB.Close(); err != nil {
+			mlog.Warn(context.TODO(), "streamingnode close bolt db failed", mlog.Err(err))
+		}
+	}
+
 	// Wait for grpc server to stop.
 	mlog.Info(context.TODO(), "wait for grpc server stop...")
 	<-s.grpcServerChan
@@ -283,6 +292,15 @@ func (s *Server) initMeta() error {
 		metaRootPath = params.EtcdCfg.MetaRootPath.GetValue()
 		s.metaKV = etcdkv.NewEtcdKV(s.etcdCli, metaRootPath,
 			etcdkv.WithRequestTimeout(paramtable.Get().EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+	case util.MetaStoreTypeBolt:
+		var err error
+		s.boltDB, err = boltkv.OpenDBWithConfig(&params.MetaStoreCfg)
+		if err != nil {
+			mlog.Warn(context.TODO(), "Streamingnode open bolt db failed", mlog.Err(err))
+			return err
+		}
+		metaRootPath = params.EtcdCfg.MetaRootPath.GetValue()
+		s.metaKV = boltkv.NewBoltKV(s.boltDB, metaRootPath)
 	}
 	return nil
 }
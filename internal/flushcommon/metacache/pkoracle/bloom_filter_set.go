@@ -108,7 +108,7 @@ func (bfs *BloomFilterSet) UpdatePKRange(ids storage.FieldData) error {
 
 	if bfs.current == nil {
 		bfs.current = &storage.PkStatistics{
-			PkFilter: bloomfilter.NewBloomFilterWithType(bfs.batchSize,
+			PkFilter: bloomfilter.NewMutableBloomFilterWithType(bfs.batchSize,
 				paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(),
 				paramtable.Get().CommonCfg.BloomFilterType.GetValue()),
 		}
@@ -58,6 +58,10 @@ const (
 
 	RouteCommitBackfill = "/management/datacoord/backfill/commit"
 
+	RouteListRecycleBin    = "/management/recycle_bin/list"
+	RouteRestoreRecycleBin = "/management/recycle_bin/restore"
+	RoutePurgeRecycleBin   = "/management/recycle_bin/purge"
+
 	RouteSuspendQueryCoordBalance = "/management/querycoord/balance/suspend"
 	RouteResumeQueryCoordBalance  = "/management/querycoord/balance/resume"
 	RouteQueryCoordBalanceStatus  = "/management/querycoord/balance/status"
@@ -70,6 +74,9 @@ const (
 	RouteGetQueryNodeDistribution   = "/management/querycoord/distribution/get"
 	RouteCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
 	RouteClearReadTaskQueue         = "/management/query/task_queue/clear"
+
+	RouteListClients      = "/management/proxy/clients/list"
+	RouteDisconnectClient = "/management/proxy/clients/disconnect"
 )
 
 const (
@@ -78,6 +85,8 @@ const (
 	BatchNodeStatusPath       = "/management/batch/nodes/status"
 	BatchNodeDistributionPath = "/management/batch/nodes/distribution"
 	BatchTransferPath         = "/management/batch/transfer"
+	BatchBalanceDryRunPath    = "/management/batch/balance/dry_run"
+	BatchCheckersDryRunPath   = "/management/batch/checkers/dry_run"
 
 	StreamingBalanceStatusPath    = "/management/streaming/balance/status"
 	StreamingNodesPath            = "/management/streaming/nodes"
@@ -0,0 +1,372 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boltkv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.etcd.io/bbolt"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/pkg/v3/kv"
+	"github.com/milvus-io/milvus/pkg/v3/kv/predicates"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// implementation assertion
+var _ kv.WatchKV = (*BoltKV)(nil)
+
+// BoltKV implements WatchKV interface on a bbolt file for single process deployments.
+// The keys are stored under rootPath, and the revisions follow the semantics of etcd.
+type BoltKV struct {
+	db       *DB
+	rootPath string
+}
+
+// NewBoltKV creates a BoltKV on the db, the db is owned by the caller.
+func NewBoltKV(db *DB, rootPath string) *BoltKV {
+	return &BoltKV{
+		db:       db,
+		rootPath: rootPath,
+	}
+}
+
+// Close closes the BoltKV, the db is kept open for the other BoltKVs.
+func (kv *BoltKV) Close() {
+	mlog.Debug(context.TODO(), "bolt kv closed", mlog.String("path", kv.rootPath))
+}
+
+// GetPath returns the path of the key.
+func (kv *BoltKV) GetPath(key string) string {
+	return util.GetPath(kv.rootPath, key)
+}
+
+// Load returns value of the key.
+func (kv *BoltKV) Load(ctx context.Context, key string) (string, error) {
+	key = kv.GetPath(key)
+	var value string
+	err := kv.view(metrics.MetaGetLabel, func(kvs *bbolt.Bucket) error {
+		meta, err := getKeyValue(kvs, []byte(key))
+		if err != nil {
+			return err
+		}
+		if meta == nil {
+			return merr.WrapErrIoKeyNotFound(key)
+		}
+		value = string(meta.Value)
+		return nil
+	})
+	return value, err
+}
+
+// MultiLoad gets the values of the keys, the missing keys are loaded as empty string with an error.
+func (kv *BoltKV) MultiLoad(ctx context.Context, keys []string) ([]string, error) {
+	result := make([]string, 0, len(keys))
+	invalid := make([]string, 0)
+	err := kv.view(metrics.MetaGetLabel, func(kvs *bbolt.Bucket) error {
+		for _, key := range keys {
+			meta, err := getKeyValue(kvs, []byte(kv.GetPath(key)))
+			if err != nil {
+				return err
+			}
+			if meta == nil {
+				invalid = append(invalid, key)
+				result = append(result, "")
+				continue
+			}
+			result = append(result, string(meta.Value))
+		}
+		return nil
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	if len(invalid) != 0 {
+		mlog.Warn(ctx, "MultiLoad: there are invalid keys", mlog.Strings("keys", invalid))
+		return result, merr.WrapErrIoKeyNotFound(fmt.Sprintf("%v", invalid))
+	}
+	return result, nil
+}
+
+// LoadWithPrefix returns all the keys and values with the given key prefix.
+func (kv *BoltKV) LoadWithPrefix(ctx context.Context, key string) ([]string, []string, error) {
+	prefix := []byte(kv.GetPath(key))
+	keys := make([]string, 0)
+	values := make([]string, 0)
+	err := kv.view(metrics.MetaGetLabel, func(kvs *bbolt.Bucket) error {
+		c := kvs.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			meta, err := unmarshalKeyValue(k, v)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, string(meta.Key))
+			values = append(values, string(meta.Value))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+// WalkWithPrefix visits each kv with the prefix in key order and applies fn to it.
+// The kvs are read in pages, so fn is free to access the kv.
+func (kv *BoltKV) WalkWithPrefix(ctx context.Context, prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	prefixBytes := []byte(kv.GetPath(prefix))
+	if paginationSize <= 0 {
+		paginationSize = 1
+	}
+
+	start := prefixBytes
+	for {
+		keys := make([][]byte, 0, paginationSize)
+		values := make([][]byte, 0, paginationSize)
+		more := false
+		err := kv.view(metrics.MetaGetLabel, func(kvs *bbolt.Bucket) error {
+			c := kvs.Cursor()
+			for k, v := c.Seek(start); k != nil && bytes.HasPrefix(k, prefixBytes); k, v = c.Next() {
+				if len(keys) >= paginationSize {
+					more = true
+					break
+				}
+				meta, err := unmarshalKeyValue(k, v)
+				if err != nil {
+					return err
+				}
+				keys = append(keys, meta.Key)
+				values = append(values, meta.Value)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range keys {
+			if err := fn(keys[i], values[i]); err != nil {
+				return err
+			}
+		}
+		if !more {
+			return nil
+		}
+		// move to next key
+		start = append(bytes.Clone(keys[len(keys)-1]), 0)
+	}
+}
+
+// Has returns whether the key exists.
+func (kv *BoltKV) Has(ctx context.Context, key string) (bool, error) {
+	key = kv.GetPath(key)
+	var has bool
+	err := kv.view(metrics.MetaGetLabel, func(kvs *bbolt.Bucket) error {
+		has = kvs.Get([]byte(key)) != nil
+		return nil
+	})
+	return has, err
+}
+
+// HasPrefix returns whether any key with the prefix exists.
+func (kv *BoltKV) HasPrefix(ctx context.Context, prefix string) (bool, error) {
+	prefixBytes := []byte(kv.GetPath(prefix))
+	var has bool
+	err := kv.view(metrics.MetaGetLabel, func(kvs *bbolt.Bucket) error {
+		k, _ := kvs.Cursor().Seek(prefixBytes)
+		has = k != nil && bytes.HasPrefix(k, prefixBytes)
+		return nil
+	})
+	return has, err
+}
+
+// Save saves the key-value pair.
+func (kv *BoltKV) Save(ctx context.Context, key, value string) error {
+	key = kv.GetPath(key)
+	return kv.update(metrics.MetaPutLabel, len(value), func(txn *writeTxn) error {
+		return txn.put([]byte(key), []byte(value))
+	})
+}
+
+// MultiSave saves the key-value pairs in a transaction.
+func (kv *BoltKV) MultiSave(ctx context.Context, kvs map[string]string) error {
+	return kv.MultiSaveAndRemove(ctx, kvs, nil)
+}
+
+// Remove removes the key.
+func (kv *BoltKV) Remove(ctx context.Context, key string) error {
+	key = kv.GetPath(key)
+	return kv.update(metrics.MetaRemoveLabel, 0, func(txn *writeTxn) error {
+		return txn.delete([]byte(key))
+	})
+}
+
+// MultiRemove removes the keys in a transaction.
+func (kv *BoltKV) MultiRemove(ctx context.Context, keys []string) error {
+	return kv.MultiSaveAndRemove(ctx, nil, keys)
+}
+
+// RemoveWithPrefix removes the keys with the given prefix.
+func (kv *BoltKV) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	return kv.MultiSaveAndRemoveWithPrefix(ctx, nil, []string{prefix})
+}
+
+// MultiSaveAndRemove saves the key-value pairs and removes the keys in a transaction.
+func (kv *BoltKV) MultiSaveAndRemove(ctx context.Context, saves map[string]string, removals []string, preds ...predicates.Predicate) error {
+	// use complement to remove keys that are not in saves
+	saveKeys := typeutil.NewSet(lo.Keys(saves)...)
+	removeKeys := typeutil.NewSet(removals...)
+	removals = removeKeys.Complement(saveKeys).Collect()
+
+	return kv.update(metrics.MetaTxnLabel, valuesSize(saves), func(txn *writeTxn) error {
+		if err := kv.checkPredicates(txn, preds...); err != nil {
+			return err
+		}
+		for _, key := range removals {
+			if err := txn.delete([]byte(kv.GetPath(key))); err != nil {
+				return err
+			}
+		}
+		return kv.putAll(txn, saves)
+	})
+}
+
+// MultiSaveAndRemoveWithPrefix saves the key-value pairs and removes the keys with the given prefixes in a transaction.
+func (kv *BoltKV) MultiSaveAndRemoveWithPrefix(ctx context.Context, saves map[string]string, removals []string, preds ...predicates.Predicate) error {
+	return kv.update(metrics.MetaTxnLabel, valuesSize(saves), func(txn *writeTxn) error {
+		if err := kv.checkPredicates(txn, preds...); err != nil {
+			return err
+		}
+		for _, prefix := range removals {
+			if err := txn.deletePrefix([]byte(kv.GetPath(prefix))); err != nil {
+				return err
+			}
+		}
+		return kv.putAll(txn, saves)
+	})
+}
+
+// CompareVersionAndSwap saves the target if the version of the key equals to the given one,
+// the version of a key not existing is 0.
+func (kv *BoltKV) CompareVersionAndSwap(ctx context.Context, key string, version int64, target string) (bool, error) {
+	key = kv.GetPath(key)
+	var succeeded bool
+	err := kv.update(metrics.MetaTxnLabel, len(target), func(txn *writeTxn) error {
+		meta, err := txn.get([]byte(key))
+		if err != nil {
+			return err
+		}
+		var current int64
+		if meta != nil {
+			current = meta.Version
+		}
+		if current != version {
+			return nil
+		}
+		succeeded = true
+		return txn.put([]byte(key), []byte(target))
+	})
+	return succeeded, err
+}
+
+// Watch starts watching a key, returns a watch channel.
+func (kv *BoltKV) Watch(ctx context.Context, key string) clientv3.WatchChan {
+	return kv.db.watch([]byte(kv.GetPath(key)), false, false, true, 0)
+}
+
+// WatchWithPrefix starts watching a key with prefix, returns a watch channel.
+func (kv *BoltKV) WatchWithPrefix(ctx context.Context, key string) clientv3.WatchChan {
+	return kv.db.watch([]byte(kv.GetPath(key)), true, false, true, 0)
+}
+
+// WatchWithRevision starts watching a key with prefix from the revision, returns a watch channel.
+// The watch is canceled with the compact revision if the revision is out of the retained history.
+func (kv *BoltKV) WatchWithRevision(ctx context.Context, key string, revision int64) clientv3.WatchChan {
+	return kv.db.watch([]byte(kv.GetPath(key)), true, true, false, revision)
+}
+
+func (kv *BoltKV) putAll(txn *writeTxn, saves map[string]string) error {
+	for key, value := range saves {
+		if err := txn.put([]byte(kv.GetPath(key)), []byte(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (kv *BoltKV) checkPredicates(txn *writeTxn, preds ...predicates.Predicate) error {
+	for _, pred := range preds {
+		if pred.Target() != predicates.PredTargetValue {
+			return merr.WrapErrParameterInvalid("valid predicate target", fmt.Sprintf("%d", pred.Target()))
+		}
+		if pred.Type() != predicates.PredTypeEqual {
+			return merr.WrapErrParameterInvalid("valid predicate type", fmt.Sprintf("%d", pred.Type()))
+		}
+		meta, err := txn.get([]byte(kv.GetPath(pred.Key())))
+		if err != nil {
+			return err
+		}
+		if meta == nil || !pred.IsTrue(meta.Value) {
+			return merr.WrapErrIoFailedReason("failed to meet predicate", fmt.Sprintf("key=%s, value=%v", pred.Key(), pred.TargetValue()))
+		}
+	}
+	return nil
+}
+
+func (kv *BoltKV) view(label string, fn func(kvs *bbolt.Bucket) error) error {
+	start := time.Now()
+	err := kv.db.view(fn)
+	observe(label, start, -1, err)
+	return err
+}
+
+func (kv *BoltKV) update(label string, size int, fn func(txn *writeTxn) error) error {
+	start := time.Now()
+	err := kv.db.update(fn)
+	observe(label, start, size, err)
+	return err
+}
+
+func observe(label string, start time.Time, size int, err error) {
+	metrics.MetaOpCounter.WithLabelValues(label, metrics.TotalLabel).Inc()
+	if err != nil && !errors.Is(err, merr.ErrIoKeyNotFound) {
+		metrics.MetaOpCounter.WithLabelValues(label, metrics.FailLabel).Inc()
+		return
+	}
+	if size >= 0 {
+		metrics.MetaKvSize.WithLabelValues(label).Observe(float64(size))
+	}
+	metrics.MetaRequestLatency.WithLabelValues(label).Observe(float64(time.Since(start).Milliseconds()))
+	metrics.MetaOpCounter.WithLabelValues(label, metrics.SuccessLabel).Inc()
+}
+
+func valuesSize(kvs map[string]string) int {
+	size := 0
+	for _, value := range kvs {
+		size += len(value)
+	}
+	return size
+}
@@ -0,0 +1,598 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boltkv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/exp/maps"
+
+	"github.com/milvus-io/milvus/pkg/v3/kv/predicates"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	code := m.Run()
+	os.Exit(code)
+}
+
+type BoltKVSuite struct {
+	suite.Suite
+
+	path     string
+	rootPath string
+	db       *DB
+	boltKV   *BoltKV
+}
+
+func (s *BoltKVSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "meta.db")
+	s.rootPath = "unittest/boltkv"
+
+	db, err := OpenDB(s.path, WithHistorySize(10))
+	s.Require().NoError(err)
+	s.db = db
+	s.boltKV = NewBoltKV(db, s.rootPath)
+}
+
+func (s *BoltKVSuite) TearDownTest() {
+	s.boltKV.Close()
+	s.db.Close()
+}
+
+func (s *BoltKVSuite) TestSaveLoad() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+	saveAndLoadTests := []struct {
+		key   string
+		value string
+	}{
+		{"test1", "value1"},
+		{"test2", "value2"},
+		{"test1/a", "value_a"},
+		{"test1/b", "value_b"},
+		{"empty", ""},
+	}
+
+	for _, test := range saveAndLoadTests {
+		err := boltKV.Save(ctx, test.key, test.value)
+		s.Require().NoError(err)
+
+		val, err := boltKV.Load(ctx, test.key)
+		s.Require().NoError(err)
+		s.Equal(test.value, val)
+	}
+
+	for _, invalidKey := range []string{"t", "a", "test1a"} {
+		val, err := boltKV.Load(ctx, invalidKey)
+		s.ErrorIs(err, merr.ErrIoKeyNotFound)
+		s.Zero(val)
+	}
+
+	loadPrefixTests := []struct {
+		prefix string
+
+		expectedKeys   []string
+		expectedValues []string
+	}{
+		{"test", []string{
+			boltKV.GetPath("test1"),
+			boltKV.GetPath("test1/a"),
+			boltKV.GetPath("test1/b"),
+			boltKV.GetPath("test2"),
+		}, []string{"value1", "value_a", "value_b", "value2"}},
+		{"test1", []string{
+			boltKV.GetPath("test1"),
+			boltKV.GetPath("test1/a"),
+			boltKV.GetPath("test1/b"),
+		}, []string{"value1", "value_a", "value_b"}},
+		{"test2", []string{boltKV.GetPath("test2")}, []string{"value2"}},
+		{"test1/a", []string{boltKV.GetPath("test1/a")}, []string{"value_a"}},
+		{"a", []string{}, []string{}},
+		{"root", []string{}, []string{}},
+	}
+
+	for _, test := range loadPrefixTests {
+		actualKeys, actualValues, err := boltKV.LoadWithPrefix(ctx, test.prefix)
+		s.NoError(err)
+		// keys are sorted
+		s.Equal(test.expectedKeys, actualKeys)
+		s.Equal(test.expectedValues, actualValues)
+	}
+
+	for _, key := range []string{"test1", "test1/a", "test1/b", "test2"} {
+		s.NoError(boltKV.Remove(ctx, key))
+		_, err := boltKV.Load(ctx, key)
+		s.Error(err)
+		// remove not existing key
+		s.NoError(boltKV.Remove(ctx, key))
+	}
+}
+
+func (s *BoltKVSuite) TestMultiSaveAndMultiLoad() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+	multiSaveTests := map[string]string{
+		"key_1":      "value_1",
+		"key_2":      "value_2",
+		"key_3/a":    "value_3a",
+		"multikey_1": "multivalue_1",
+		"multikey_2": "multivalue_2",
+		"_":          "other",
+	}
+
+	err := boltKV.MultiSave(ctx, multiSaveTests)
+	s.Require().NoError(err)
+
+	multiLoadTests := []struct {
+		inputKeys      []string
+		expectedValues []string
+	}{
+		{[]string{"key_1"}, []string{"value_1"}},
+		{[]string{"key_1", "key_2", "key_3/a"}, []string{"value_1", "value_2", "value_3a"}},
+		{[]string{"multikey_1", "multikey_2"}, []string{"multivalue_1", "multivalue_2"}},
+		{[]string{"_"}, []string{"other"}},
+	}
+	for _, test := range multiLoadTests {
+		vs, err := boltKV.MultiLoad(ctx, test.inputKeys)
+		s.NoError(err)
+		s.Equal(test.expectedValues, vs)
+	}
+
+	invalidMultiLoad := []struct {
+		invalidKeys    []string
+		expectedValues []string
+	}{
+		{[]string{"a", "key_1"}, []string{"", "value_1"}},
+		{[]string{"*********"}, []string{""}},
+		{[]string{"key_1", "1"}, []string{"value_1", ""}},
+	}
+	for _, test := range invalidMultiLoad {
+		vs, err := boltKV.MultiLoad(ctx, test.invalidKeys)
+		s.Error(err)
+		s.Equal(test.expectedValues, vs)
+	}
+
+	for _, k := range []string{"key_1", "multi"} {
+		s.NoError(boltKV.RemoveWithPrefix(ctx, k))
+		ks, vs, err := boltKV.LoadWithPrefix(ctx, k)
+		s.NoError(err)
+		s.Empty(ks)
+		s.Empty(vs)
+	}
+
+	err = boltKV.MultiRemove(ctx, []string{"key_2", "key_3/a", "multikey_2", "_"})
+	s.NoError(err)
+	ks, _, err := boltKV.LoadWithPrefix(ctx, "")
+	s.NoError(err)
+	s.Empty(ks)
+
+	multiSaveAndRemoveTests := []struct {
+		multiSaves   map[string]string
+		multiRemoves []string
+	}{
+		{map[string]string{"key_1": "value_1"}, []string{}},
+		{map[string]string{"key_2": "value_2"}, []string{"key_1"}},
+		{map[string]string{"key_3/a": "value_3a"}, []string{"key_2"}},
+		{map[string]string{"multikey_1": "multivalue_1"}, []string{}},
+		{map[string]string{"multikey_2": "multivalue_2"}, []string{"multikey_1", "key_3/a"}},
+		// saves win if the key is also removed
+		{map[string]string{"multikey_3": "multivalue_3"}, []string{"multikey_2", "multikey_3"}},
+		{make(map[string]string), []string{"multikey_3"}},
+	}
+	for _, test := range multiSaveAndRemoveTests {
+		s.NoError(boltKV.MultiSaveAndRemove(ctx, test.multiSaves, test.multiRemoves))
+		for key, value := range test.multiSaves {
+			actual, err := boltKV.Load(ctx, key)
+			s.NoError(err)
+			s.Equal(value, actual)
+		}
+	}
+	ks, _, err = boltKV.LoadWithPrefix(ctx, "")
+	s.NoError(err)
+	s.Empty(ks)
+}
+
+func (s *BoltKVSuite) TestTxnWithPredicates() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	err := boltKV.MultiSave(ctx, map[string]string{
+		"lease1": "1",
+		"lease2": "2",
+	})
+	s.Require().NoError(err)
+
+	badPredicate := predicates.NewMockPredicate(s.T())
+	badPredicate.EXPECT().Type().Return(0)
+	badPredicate.EXPECT().Target().Return(predicates.PredTargetValue)
+
+	multiSaveAndRemovePredTests := []struct {
+		tag           string
+		multiSave     map[string]string
+		preds         []predicates.Predicate
+		expectSuccess bool
+	}{
+		{"predicate_ok", map[string]string{"a": "b"}, []predicates.Predicate{predicates.ValueEqual("lease1", "1")}, true},
+		{"predicate_fail", map[string]string{"a": "b"}, []predicates.Predicate{predicates.ValueEqual("lease1", "2")}, false},
+		{"predicate_key_not_exist", map[string]string{"a": "b"}, []predicates.Predicate{predicates.ValueEqual("lease3", "3")}, false},
+		{"bad_predicate", map[string]string{"a": "b"}, []predicates.Predicate{badPredicate}, false},
+	}
+
+	for _, test := range multiSaveAndRemovePredTests {
+		s.Run(test.tag, func() {
+			s.NoError(boltKV.Remove(ctx, "a"))
+			err := boltKV.MultiSaveAndRemove(ctx, test.multiSave, nil, test.preds...)
+			if test.expectSuccess {
+				s.NoError(err)
+			} else {
+				s.Error(err)
+			}
+			err = boltKV.MultiSaveAndRemoveWithPrefix(ctx, test.multiSave, nil, test.preds...)
+			if test.expectSuccess {
+				s.NoError(err)
+			} else {
+				s.Error(err)
+			}
+			// transaction is not applied if predicates failed
+			has, err := boltKV.Has(ctx, "a")
+			s.NoError(err)
+			s.Equal(test.expectSuccess, has)
+		})
+	}
+}
+
+func (s *BoltKVSuite) TestMultiSaveAndRemoveWithPrefix() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	err := boltKV.MultiSave(ctx, map[string]string{
+		"x/abc/1": "1",
+		"x/abc/2": "2",
+		"x/def/1": "10",
+		"x/def/2": "20",
+		"x/den/1": "100",
+		"x/den/2": "200",
+	})
+	s.Require().NoError(err)
+
+	multiSaveAndRemoveWithPrefixTests := []struct {
+		multiSave map[string]string
+		prefix    []string
+
+		loadPrefix         string
+		lengthBeforeRemove int
+		lengthAfterRemove  int
+	}{
+		{map[string]string{}, []string{"x/abc", "x/def", "x/den"}, "x", 6, 0},
+		{map[string]string{"y/a": "vvv", "y/b": "vvv"}, []string{}, "y", 0, 2},
+		{map[string]string{"y/c": "vvv"}, []string{}, "y", 2, 3},
+		{map[string]string{"p/a": "vvv"}, []string{"y/a", "y"}, "y", 3, 0},
+		{map[string]string{}, []string{"p"}, "p", 1, 0},
+	}
+
+	for _, test := range multiSaveAndRemoveWithPrefixTests {
+		k, _, err := boltKV.LoadWithPrefix(ctx, test.loadPrefix)
+		s.NoError(err)
+		s.Equal(test.lengthBeforeRemove, len(k))
+
+		err = boltKV.MultiSaveAndRemoveWithPrefix(ctx, test.multiSave, test.prefix)
+		s.NoError(err)
+
+		k, _, err = boltKV.LoadWithPrefix(ctx, test.loadPrefix)
+		s.NoError(err)
+		s.Equal(test.lengthAfterRemove, len(k))
+	}
+}
+
+func (s *BoltKVSuite) TestHas() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	has, err := boltKV.Has(ctx, "key1")
+	s.NoError(err)
+	s.False(has)
+	has, err = boltKV.HasPrefix(ctx, "key")
+	s.NoError(err)
+	s.False(has)
+
+	s.NoError(boltKV.Save(ctx, "key1", "value1"))
+	has, err = boltKV.Has(ctx, "key1")
+	s.NoError(err)
+	s.True(has)
+	has, err = boltKV.HasPrefix(ctx, "key")
+	s.NoError(err)
+	s.True(has)
+	has, err = boltKV.HasPrefix(ctx, "key2")
+	s.NoError(err)
+	s.False(has)
+
+	s.NoError(boltKV.Remove(ctx, "key1"))
+	has, err = boltKV.Has(ctx, "key1")
+	s.NoError(err)
+	s.False(has)
+}
+
+func (s *BoltKVSuite) TestWalkWithPagination() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	err := boltKV.MultiSave(ctx, map[string]string{
+		"A/100":    "v1",
+		"AA/100":   "v2",
+		"AB/100":   "v3",
+		"AB/2/100": "v4",
+		"B/100":    "v5",
+	})
+	s.Require().NoError(err)
+
+	err = boltKV.WalkWithPrefix(ctx, "A", 5, func(key []byte, value []byte) error {
+		return errors.New("error")
+	})
+	s.Error(err)
+
+	err = boltKV.WalkWithPrefix(ctx, "non-exist-prefix", 5, func(key []byte, value []byte) error {
+		return nil
+	})
+	s.NoError(err)
+
+	expected := map[string]string{
+		"A/100":    "v1",
+		"AA/100":   "v2",
+		"AB/100":   "v3",
+		"AB/2/100": "v4",
+	}
+	expectedSortedKey := maps.Keys(expected)
+	sort.Strings(expectedSortedKey)
+
+	for _, pagination := range []int{-1, 0, 1, 3, 5, 100} {
+		ret := make(map[string]string)
+		actualSortedKey := make([]string, 0)
+		err = boltKV.WalkWithPrefix(ctx, "A", pagination, func(key []byte, value []byte) error {
+			k := string(key)[len(s.rootPath)+1:]
+			ret[k] = string(value)
+			actualSortedKey = append(actualSortedKey, k)
+			// write in walk function
+			return boltKV.Save(ctx, "B/"+k, string(value))
+		})
+		s.NoError(err)
+		s.Equal(expected, ret, fmt.Sprintf("pagination: %d", pagination))
+		s.Equal(expectedSortedKey, actualSortedKey, fmt.Sprintf("pagination: %d", pagination))
+	}
+}
+
+func (s *BoltKVSuite) TestLoadWithPrefixIsolation() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	err := boltKV.MultiSave(ctx, map[string]string{
+		"user_content/role1":           "v1",
+		"user_content/role2":           "v2",
+		"user_content_marketing/role3": "v3",
+		"user_content_marketing/role4": "v4",
+	})
+	s.Require().NoError(err)
+
+	keys, values, err := boltKV.LoadWithPrefix(ctx, "user_content/")
+	s.NoError(err)
+	s.Len(keys, 2)
+	s.ElementsMatch(values, []string{"v1", "v2"})
+
+	keys, _, err = boltKV.LoadWithPrefix(ctx, "user_content")
+	s.NoError(err)
+	s.Len(keys, 4)
+
+	// keys out of root path are invisible
+	other := NewBoltKV(s.db, "unittest/other")
+	keys, _, err = other.LoadWithPrefix(ctx, "")
+	s.NoError(err)
+	s.Empty(keys)
+}
+
+func (s *BoltKVSuite) TestCompareVersionAndSwap() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	success, err := boltKV.CompareVersionAndSwap(ctx, "a/b/c", 0, "1")
+	s.NoError(err)
+	s.True(success)
+
+	value, err := boltKV.Load(ctx, "a/b/c")
+	s.NoError(err)
+	s.Equal("1", value)
+
+	success, err = boltKV.CompareVersionAndSwap(ctx, "a/b/c", 0, "2")
+	s.NoError(err)
+	s.False(success)
+
+	success, err = boltKV.CompareVersionAndSwap(ctx, "a/b/c", 1, "2")
+	s.NoError(err)
+	s.True(success)
+
+	value, err = boltKV.Load(ctx, "a/b/c")
+	s.NoError(err)
+	s.Equal("2", value)
+}
+
+func (s *BoltKVSuite) receive(ch clientv3.WatchChan) clientv3.WatchResponse {
+	select {
+	case resp, ok := <-ch:
+		s.Require().True(ok)
+		return resp
+	case <-time.After(5 * time.Second):
+		s.FailNow("receive watch response timeout")
+	}
+	return clientv3.WatchResponse{}
+}
+
+func (s *BoltKVSuite) TestWatch() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	ch := boltKV.Watch(ctx, "x")
+	resp := s.receive(ch)
+	s.True(resp.Created)
+
+	prefixCh := boltKV.WatchWithPrefix(ctx, "x")
+	resp = s.receive(prefixCh)
+	s.True(resp.Created)
+
+	s.NoError(boltKV.Save(ctx, "x", "1"))
+	s.NoError(boltKV.MultiSave(ctx, map[string]string{"x/a": "2", "y": "3"}))
+	s.NoError(boltKV.RemoveWithPrefix(ctx, "x"))
+
+	resp = s.receive(ch)
+	s.Len(resp.Events, 1)
+	s.Equal(mvccpb.PUT, resp.Events[0].Type)
+	s.Equal(boltKV.GetPath("x"), string(resp.Events[0].Kv.Key))
+	s.Equal("1", string(resp.Events[0].Kv.Value))
+	s.True(resp.Events[0].IsCreate())
+	resp = s.receive(ch)
+	s.Len(resp.Events, 1)
+	s.Equal(mvccpb.DELETE, resp.Events[0].Type)
+	s.Nil(resp.Events[0].PrevKv)
+
+	var events []*clientv3.Event
+	for len(events) < 4 {
+		resp = s.receive(prefixCh)
+		events = append(events, resp.Events...)
+	}
+	s.Equal(mvccpb.PUT, events[0].Type)
+	s.Equal(boltKV.GetPath("x/a"), string(events[1].Kv.Key))
+	s.Equal(mvccpb.DELETE, events[2].Type)
+	s.Equal(mvccpb.DELETE, events[3].Type)
+}
+
+func (s *BoltKVSuite) TestRevision() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	revisionTests := []struct {
+		inKey       string
+		fistValue   string
+		secondValue string
+	}{
+		{"a", "v1", "v11"},
+		{"y", "v2", "v22"},
+		{"z", "v3", "v33"},
+	}
+
+	for _, test := range revisionTests {
+		err := boltKV.Save(ctx, test.inKey, test.fistValue)
+		s.Require().NoError(err)
+
+		revision := s.db.Revision()
+		ch := boltKV.WatchWithRevision(ctx, test.inKey, revision+1)
+
+		err = boltKV.Save(ctx, test.inKey, test.secondValue)
+		s.Require().NoError(err)
+
+		resp := s.receive(ch)
+		s.Equal(1, len(resp.Events))
+		s.Equal(test.secondValue, string(resp.Events[0].Kv.Value))
+		s.Equal(test.fistValue, string(resp.Events[0].PrevKv.Value))
+		s.Equal(int64(2), resp.Events[0].Kv.Version)
+		s.Equal(revision+1, resp.Events[0].Kv.ModRevision)
+		s.Equal(revision, resp.Events[0].Kv.CreateRevision)
+		s.Equal(revision+1, resp.Header.Revision)
+	}
+
+	// replay the history events
+	revision := s.db.Revision()
+	ch := boltKV.WatchWithRevision(ctx, "", revision-3)
+	for i := int64(0); i < 4; i++ {
+		resp := s.receive(ch)
+		s.Equal(revision-3+i, resp.Header.Revision)
+	}
+}
+
+func (s *BoltKVSuite) TestCompacted() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	for i := 0; i < 20; i++ {
+		s.Require().NoError(boltKV.Save(ctx, "key", fmt.Sprint(i)))
+	}
+	revision := s.db.Revision()
+
+	// history size is 10
+	ch := boltKV.WatchWithRevision(ctx, "key", revision-10)
+	resp := s.receive(ch)
+	s.True(resp.Canceled)
+	s.Equal(revision-10, resp.CompactRevision)
+	s.ErrorIs(resp.Err(), rpctypes.ErrCompacted)
+	_, ok := <-ch
+	s.False(ok)
+
+	ch = boltKV.WatchWithRevision(ctx, "key", revision-9)
+	resp = s.receive(ch)
+	s.Equal("10", string(resp.Events[0].Kv.Value))
+}
+
+func (s *BoltKVSuite) TestReopen() {
+	boltKV := s.boltKV
+	ctx := context.TODO()
+
+	s.Require().NoError(boltKV.Save(ctx, "key", "value"))
+	revision := s.db.Revision()
+
+	// the db is shared in process
+	db, err := OpenDB(s.path)
+	s.Require().NoError(err)
+	s.Same(s.db, db)
+	s.NoError(db.Close())
+
+	ch := boltKV.WatchWithPrefix(ctx, "")
+	s.receive(ch)
+	s.NoError(s.db.Close())
+	// watch channel is closed with the db
+	s.Eventually(func() bool {
+		_, ok := <-ch
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Error(boltKV.Save(ctx, "key", "value2"))
+
+	s.db, err = OpenDB(s.path)
+	s.Require().NoError(err)
+	boltKV = NewBoltKV(s.db, s.rootPath)
+	s.boltKV = boltKV
+
+	value, err := boltKV.Load(ctx, "key")
+	s.NoError(err)
+	s.Equal("value", value)
+	s.Equal(revision, s.db.Revision())
+
+	s.Require().NoError(boltKV.Save(ctx, "key", "value2"))
+	s.Equal(revision+1, s.db.Revision())
+}
+
+func TestBoltKV(t *testing.T) {
+	suite.Run(t, new(BoltKVSuite))
+}
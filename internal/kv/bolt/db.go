@@ -0,0 +1,437 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boltkv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.etcd.io/bbolt"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+var (
+	kvBucket      = []byte("kv")
+	historyBucket = []byte("history")
+	metaBucket    = []byte("meta")
+
+	revisionKey  = []byte("revision")
+	compactedKey = []byte("compacted")
+)
+
+var (
+	dbsMu sync.Mutex
+	dbs   = make(map[string]*DB)
+)
+
+// DB is a bbolt file storing the key-values with etcd like revisions.
+// The file is locked exclusively by bbolt, so the DB of the same path is shared in the process.
+//
+// Each write transaction with mutations is committed at a new revision,
+// the events of the latest revisions are kept to serve the watchers starting from a history revision.
+type DB struct {
+	path        string
+	bolt        *bbolt.DB
+	historySize int64
+	refCnt      int // guarded by dbsMu
+
+	// mu serializes the write transactions and the watcher registrations,
+	// so the watchers receive all the events in revision order.
+	mu        sync.Mutex
+	revision  int64
+	compacted int64
+	watchers  map[*watcher]struct{}
+	closed    bool
+}
+
+// OpenDB opens the bbolt file at path, or returns the one already opened in the process.
+// The options only take effect when the file is opened for the first time.
+func OpenDB(path string, options ...Option) (*DB, error) {
+	opt := defaultOption()
+	for _, option := range options {
+		option(opt)
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, merr.WrapErrIoFailed(path, err)
+	}
+
+	dbsMu.Lock()
+	defer dbsMu.Unlock()
+	if db, ok := dbs[path]; ok {
+		db.refCnt++
+		return db, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, merr.WrapErrIoFailed(path, err)
+	}
+	bdb, err := bbolt.Open(path, 0o600, &bbolt.Options{
+		Timeout: opt.openTimeout,
+		NoSync:  opt.noSync,
+	})
+	if err != nil {
+		return nil, merr.WrapErrIoFailed(path, err)
+	}
+
+	db := &DB{
+		path:        path,
+		bolt:        bdb,
+		historySize: opt.historySize,
+		refCnt:      1,
+		watchers:    make(map[*watcher]struct{}),
+	}
+	if err := db.init(); err != nil {
+		bdb.Close()
+		return nil, err
+	}
+	dbs[path] = db
+	mlog.Info(context.TODO(), "bolt db opened",
+		mlog.String("path", path),
+		mlog.Int64("revision", db.revision),
+		mlog.Int64("compacted", db.compacted))
+	return db, nil
+}
+
+func (db *DB) init() error {
+	err := db.bolt.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{kvBucket, historyBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		meta := tx.Bucket(metaBucket)
+		db.revision = decodeRevision(meta.Get(revisionKey))
+		db.compacted = decodeRevision(meta.Get(compactedKey))
+		return nil
+	})
+	if err != nil {
+		return merr.WrapErrIoFailed(db.path, err)
+	}
+	return nil
+}
+
+// Close releases the DB, the file is closed after all the users released it.
+func (db *DB) Close() error {
+	dbsMu.Lock()
+	defer dbsMu.Unlock()
+
+	db.refCnt--
+	if db.refCnt > 0 {
+		return nil
+	}
+	delete(dbs, db.path)
+
+	db.mu.Lock()
+	db.closed = true
+	for w := range db.watchers {
+		w.stop()
+	}
+	db.watchers = nil
+	db.mu.Unlock()
+
+	mlog.Info(context.TODO(), "bolt db closed", mlog.String("path", db.path))
+	return db.bolt.Close()
+}
+
+// Revision returns the revision of the latest write transaction.
+func (db *DB) Revision() int64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.revision
+}
+
+func (db *DB) view(fn func(kvs *bbolt.Bucket) error) error {
+	var fnErr error
+	err := db.bolt.View(func(tx *bbolt.Tx) error {
+		fnErr = fn(tx.Bucket(kvBucket))
+		return fnErr
+	})
+	if err != nil && fnErr == nil {
+		return merr.WrapErrIoFailedReason("failed to read bolt db", err.Error())
+	}
+	return err
+}
+
+// update runs fn in a write transaction, and notifies the watchers of the mutations after commit.
+func (db *DB) update(fn func(txn *writeTxn) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return merr.WrapErrServiceUnavailable("bolt db is closed")
+	}
+
+	txn := &writeTxn{revision: db.revision + 1, compacted: db.compacted}
+	var fnErr error
+	err := db.bolt.Update(func(tx *bbolt.Tx) error {
+		txn.kvs = tx.Bucket(kvBucket)
+		if fnErr = fn(txn); fnErr != nil {
+			return fnErr
+		}
+		if len(txn.events) == 0 {
+			return nil
+		}
+		return db.commitRevision(tx, txn)
+	})
+	if err != nil {
+		if fnErr == nil {
+			return merr.WrapErrIoFailedReason("failed to commit bolt txn", err.Error())
+		}
+		return err
+	}
+
+	if len(txn.events) > 0 {
+		db.revision = txn.revision
+		db.compacted = txn.compacted
+		db.notify(txn.revision, txn.events)
+	}
+	return nil
+}
+
+// commitRevision saves the revision and its events, and drops the events out of the history size.
+func (db *DB) commitRevision(tx *bbolt.Tx, txn *writeTxn) error {
+	meta := tx.Bucket(metaBucket)
+	history := tx.Bucket(historyBucket)
+
+	data, err := encodeEvents(txn.events)
+	if err != nil {
+		return err
+	}
+	if err := history.Put(encodeRevision(txn.revision), data); err != nil {
+		return err
+	}
+	if err := meta.Put(revisionKey, encodeRevision(txn.revision)); err != nil {
+		return err
+	}
+
+	if db.historySize <= 0 || txn.revision-txn.compacted <= db.historySize {
+		return nil
+	}
+	compacted := txn.revision - db.historySize
+	var keys [][]byte
+	c := history.Cursor()
+	for k, _ := c.First(); k != nil && decodeRevision(k) <= compacted; k, _ = c.Next() {
+		keys = append(keys, bytes.Clone(k))
+	}
+	for _, k := range keys {
+		if err := history.Delete(k); err != nil {
+			return err
+		}
+	}
+	if err := meta.Put(compactedKey, encodeRevision(compacted)); err != nil {
+		return err
+	}
+	txn.compacted = compacted
+	return nil
+}
+
+// watch registers a watcher on the key or prefix, the events since startRevision are replayed if it's positive.
+func (db *DB) watch(key []byte, prefix bool, prevKV bool, createdNotify bool, startRevision int64) clientv3.WatchChan {
+	w := newWatcher(key, prefix, prevKV)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		w.stop()
+		return w.ch
+	}
+	if createdNotify {
+		w.send(clientv3.WatchResponse{Header: db.header(db.revision), Created: true})
+	}
+	if startRevision > 0 && startRevision <= db.revision {
+		if startRevision <= db.compacted {
+			w.send(clientv3.WatchResponse{Header: db.header(db.revision), CompactRevision: db.compacted, Canceled: true})
+			w.finish()
+			return w.ch
+		}
+		if err := db.replay(w, startRevision); err != nil {
+			mlog.Warn(context.TODO(), "failed to replay history events of bolt db", mlog.Int64("revision", startRevision), mlog.Err(err))
+			w.send(clientv3.WatchResponse{Header: db.header(db.revision), Canceled: true})
+			w.finish()
+			return w.ch
+		}
+	}
+	db.watchers[w] = struct{}{}
+	return w.ch
+}
+
+func (db *DB) replay(w *watcher, startRevision int64) error {
+	return db.bolt.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(historyBucket).Cursor()
+		for k, v := c.Seek(encodeRevision(startRevision)); k != nil; k, v = c.Next() {
+			events, err := decodeEvents(v)
+			if err != nil {
+				return err
+			}
+			if matched := w.filter(events); len(matched) > 0 {
+				w.send(clientv3.WatchResponse{Header: db.header(decodeRevision(k)), Events: matched})
+			}
+		}
+		return nil
+	})
+}
+
+func (db *DB) notify(revision int64, events []*mvccpb.Event) {
+	for w := range db.watchers {
+		if matched := w.filter(events); len(matched) > 0 {
+			w.send(clientv3.WatchResponse{Header: db.header(revision), Events: matched})
+		}
+	}
+}
+
+func (db *DB) header(revision int64) etcdserverpb.ResponseHeader {
+	return etcdserverpb.ResponseHeader{Revision: revision}
+}
+
+// writeTxn records the mutations of a write transaction as events.
+type writeTxn struct {
+	kvs       *bbolt.Bucket
+	revision  int64
+	compacted int64
+	events    []*mvccpb.Event
+}
+
+func (txn *writeTxn) get(key []byte) (*mvccpb.KeyValue, error) {
+	return getKeyValue(txn.kvs, key)
+}
+
+func (txn *writeTxn) put(key, value []byte) error {
+	prev, err := txn.get(key)
+	if err != nil {
+		return err
+	}
+	kv := &mvccpb.KeyValue{
+		Key:            key,
+		Value:          value,
+		CreateRevision: txn.revision,
+		ModRevision:    txn.revision,
+		Version:        1,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+	}
+	data, err := kv.Marshal()
+	if err != nil {
+		return merr.WrapErrIoFailed(string(key), err)
+	}
+	if err := txn.kvs.Put(key, data); err != nil {
+		return merr.WrapErrIoFailed(string(key), err)
+	}
+	txn.events = append(txn.events, &mvccpb.Event{Type: mvccpb.PUT, Kv: kv, PrevKv: prev})
+	return nil
+}
+
+func (txn *writeTxn) delete(key []byte) error {
+	prev, err := txn.get(key)
+	if err != nil || prev == nil {
+		return err
+	}
+	if err := txn.kvs.Delete(key); err != nil {
+		return merr.WrapErrIoFailed(string(key), err)
+	}
+	txn.events = append(txn.events, &mvccpb.Event{
+		Type:   mvccpb.DELETE,
+		Kv:     &mvccpb.KeyValue{Key: key, ModRevision: txn.revision},
+		PrevKv: prev,
+	})
+	return nil
+}
+
+func (txn *writeTxn) deletePrefix(prefix []byte) error {
+	var keys [][]byte
+	c := txn.kvs.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, bytes.Clone(k))
+	}
+	for _, key := range keys {
+		if err := txn.delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getKeyValue(kvs *bbolt.Bucket, key []byte) (*mvccpb.KeyValue, error) {
+	data := kvs.Get(key)
+	if data == nil {
+		return nil, nil
+	}
+	return unmarshalKeyValue(key, data)
+}
+
+func unmarshalKeyValue(key, data []byte) (*mvccpb.KeyValue, error) {
+	meta := &mvccpb.KeyValue{}
+	if err := meta.Unmarshal(data); err != nil {
+		return nil, merr.WrapErrIoFailed(string(key), err)
+	}
+	return meta, nil
+}
+
+func encodeRevision(revision int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(revision))
+}
+
+func decodeRevision(data []byte) int64 {
+	if len(data) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(data))
+}
+
+// encodeEvents encodes the events as length prefixed protobuf messages.
+func encodeEvents(events []*mvccpb.Event) ([]byte, error) {
+	var buf []byte
+	for _, event := range events {
+		data, err := event.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	}
+	return buf, nil
+}
+
+func decodeEvents(buf []byte) ([]*mvccpb.Event, error) {
+	var events []*mvccpb.Event
+	for len(buf) > 0 {
+		size, n := binary.Uvarint(buf)
+		if n <= 0 || uint64(len(buf)-n) < size {
+			return nil, merr.WrapErrIoFailedReason("corrupted bolt history events")
+		}
+		event := &mvccpb.Event{}
+		if err := event.Unmarshal(buf[n : n+int(size)]); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+		buf = buf[n+int(size):]
+	}
+	return events, nil
+}
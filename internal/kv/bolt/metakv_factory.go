@@ -0,0 +1,28 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boltkv
+
+import (
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// OpenDBWithConfig opens the bolt db shared in the process with the metastore config.
+func OpenDBWithConfig(cfg *paramtable.MetaStoreConfig) (*DB, error) {
+	return OpenDB(cfg.BoltPath.GetValue(),
+		WithHistorySize(cfg.BoltHistorySize.GetAsInt64()),
+		WithNoSync(cfg.BoltNoSync.GetAsBool()))
+}
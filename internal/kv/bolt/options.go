@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boltkv
+
+import "time"
+
+const (
+	defaultHistorySize = 10000
+	defaultOpenTimeout = 10 * time.Second
+)
+
+type boltOpt struct {
+	historySize int64
+	noSync      bool
+	openTimeout time.Duration
+}
+
+type Option func(*boltOpt)
+
+// WithHistorySize sets the number of revisions whose events are retained for WatchWithRevision.
+func WithHistorySize(size int64) Option {
+	return func(opt *boltOpt) {
+		opt.historySize = size
+	}
+}
+
+// WithNoSync skips fsync after each commit, it's only safe if the data can be recovered on crash.
+func WithNoSync(noSync bool) Option {
+	return func(opt *boltOpt) {
+		opt.noSync = noSync
+	}
+}
+
+// WithOpenTimeout sets the timeout of acquiring the file lock of the db.
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(opt *boltOpt) {
+		opt.openTimeout = timeout
+	}
+}
+
+func defaultOption() *boltOpt {
+	return &boltOpt{
+		historySize: defaultHistorySize,
+		openTimeout: defaultOpenTimeout,
+	}
+}
@@ -0,0 +1,127 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boltkv
+
+import (
+	"bytes"
+	"sync"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// watcher delivers the watch responses to the channel in order,
+// the responses are queued so the writers never block on slow consumers.
+type watcher struct {
+	key    []byte
+	prefix bool
+	prevKV bool
+	ch     chan clientv3.WatchResponse
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	pending  []clientv3.WatchResponse
+	finished bool // close the channel after all pending responses are delivered
+	stopped  bool // close the channel immediately
+	stopCh   chan struct{}
+}
+
+func newWatcher(key []byte, prefix bool, prevKV bool) *watcher {
+	w := &watcher{
+		key:    key,
+		prefix: prefix,
+		prevKV: prevKV,
+		ch:     make(chan clientv3.WatchResponse),
+		stopCh: make(chan struct{}),
+	}
+	w.cond = sync.NewCond(&w.mu)
+	go w.loop()
+	return w
+}
+
+func (w *watcher) loop() {
+	defer close(w.ch)
+	for {
+		w.mu.Lock()
+		for len(w.pending) == 0 && !w.finished && !w.stopped {
+			w.cond.Wait()
+		}
+		if w.stopped || len(w.pending) == 0 {
+			w.mu.Unlock()
+			return
+		}
+		resp := w.pending[0]
+		w.pending = w.pending[1:]
+		w.mu.Unlock()
+
+		select {
+		case w.ch <- resp:
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+func (w *watcher) match(key []byte) bool {
+	if w.prefix {
+		return bytes.HasPrefix(key, w.key)
+	}
+	return bytes.Equal(key, w.key)
+}
+
+// filter returns the events of the watched keys.
+func (w *watcher) filter(events []*mvccpb.Event) []*clientv3.Event {
+	var matched []*clientv3.Event
+	for _, event := range events {
+		if !w.match(event.Kv.Key) {
+			continue
+		}
+		if !w.prevKV && event.PrevKv != nil {
+			event = &mvccpb.Event{Type: event.Type, Kv: event.Kv}
+		}
+		matched = append(matched, (*clientv3.Event)(event))
+	}
+	return matched
+}
+
+func (w *watcher) send(resp clientv3.WatchResponse) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.finished || w.stopped {
+		return
+	}
+	w.pending = append(w.pending, resp)
+	w.cond.Signal()
+}
+
+func (w *watcher) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.finished = true
+	w.cond.Signal()
+}
+
+func (w *watcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	close(w.stopCh)
+	w.cond.Signal()
+}
@@ -1763,6 +1763,21 @@ func (kc *Catalog) ListGrant(ctx context.Context, tenant string, entity *milvusp
 }
 
 func (kc *Catalog) DeleteGrantByCollectionName(ctx context.Context, tenant string, dbName string, collectionName string) error {
+	rowPolicies, err := kc.listRowPoliciesOfCollection(ctx, tenant, dbName, collectionName)
+	if err != nil {
+		return err
+	}
+	if len(rowPolicies) > 0 {
+		removeKeys := lo.Map(rowPolicies, func(policy *internalpb.RowPolicy, _ int) string {
+			return rowPolicyKey(tenant, policy)
+		})
+		if err = kc.Txn.MultiSaveAndRemove(ctx, nil, removeKeys); err != nil {
+			mlog.Warn(ctx, "fail to remove row policies for collection",
+				mlog.String("dbName", dbName), mlog.String("collectionName", collectionName), mlog.Err(err))
+			return err
+		}
+	}
+
 	granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 	keys, values, err := kc.Txn.LoadWithPrefix(ctx, granteeKey)
 	if err != nil {
@@ -1834,6 +1849,26 @@ func (kc *Catalog) DeleteGrantByCollectionName(ctx context.Context, tenant strin
 }
 
 func (kc *Catalog) MigrateGrantCollectionName(ctx context.Context, tenant string, oldDBName string, oldName string, newDBName string, newName string) error {
+	rowPolicies, err := kc.listRowPoliciesOfCollection(ctx, tenant, oldDBName, oldName)
+	if err != nil {
+		return err
+	}
+	if len(rowPolicies) > 0 {
+		saves := make(map[string]string, len(rowPolicies))
+		removeKeys := make([]string, 0, len(rowPolicies))
+		for _, policy := range rowPolicies {
+			removeKeys = append(removeKeys, rowPolicyKey(tenant, policy))
+			policy.DbName, policy.CollectionName = newDBName, newName
+			saves[rowPolicyKey(tenant, policy)] = policy.GetExpr()
+		}
+		if err = kc.Txn.MultiSaveAndRemove(ctx, saves, removeKeys); err != nil {
+			mlog.Warn(ctx, "fail to migrate row policies for renamed collection",
+				mlog.String("oldDBName", oldDBName), mlog.String("oldName", oldName),
+				mlog.String("newDBName", newDBName), mlog.String("newName", newName), mlog.Err(err))
+			return err
+		}
+	}
+
 	granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 	keys, values, err := kc.Txn.LoadWithPrefix(ctx, granteeKey)
 	if err != nil {
@@ -1968,6 +2003,8 @@ func (kc *Catalog) DeleteGrant(ctx context.Context, tenant string, role *milvusp
 		granteeIDKey := funcutil.HandleTenantForEtcdPrefix(GranteeIDPrefix, tenant, v)
 		removeKeys = append(removeKeys, granteeIDKey)
 	}
+	// the row policies of the role are dropped together with its grants
+	removeKeys = append(removeKeys, funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant, role.Name))
 
 	if err = kc.Txn.MultiSaveAndRemoveWithPrefix(ctx, nil, removeKeys); err != nil {
 		mlog.Error(ctx, "fail to remove with the prefix", mlog.String("key", k), mlog.Err(err))
@@ -2027,6 +2064,73 @@ func (kc *Catalog) ListPolicy(ctx context.Context, tenant string) ([]*milvuspb.G
 	return grants, nil
 }
 
+func rowPolicyKey(tenant string, policy *internalpb.RowPolicy) string {
+	return funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant, policy.GetRoleName()) +
+		funcutil.CombineObjectName(policy.GetDbName(), policy.GetCollectionName())
+}
+
+// AlterRowPolicies saves the row policies, the policies with empty expression are removed.
+func (kc *Catalog) AlterRowPolicies(ctx context.Context, tenant string, policies []*internalpb.RowPolicy) error {
+	saves := make(map[string]string)
+	var removeKeys []string
+	for _, policy := range policies {
+		k := rowPolicyKey(tenant, policy)
+		if policy.GetExpr() == "" {
+			removeKeys = append(removeKeys, k)
+			continue
+		}
+		saves[k] = policy.GetExpr()
+	}
+	if len(saves) == 0 && len(removeKeys) == 0 {
+		return nil
+	}
+	if err := kc.Txn.MultiSaveAndRemove(ctx, saves, removeKeys); err != nil {
+		mlog.Warn(ctx, "fail to alter row policies", mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (kc *Catalog) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	var policies []*internalpb.RowPolicy
+	rowPolicyPrefix := funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant)
+	keys, values, err := kc.Txn.LoadWithPrefix(ctx, rowPolicyPrefix)
+	if err != nil {
+		mlog.Error(ctx, "fail to load all row policies", mlog.String("key", rowPolicyPrefix), mlog.Err(err))
+		return nil, err
+	}
+	for i, key := range keys {
+		policyInfos := typeutil.AfterN(key, rowPolicyPrefix, "/")
+		if len(policyInfos) != 2 ||
+			funcutil.IsEmptyString(policyInfos[0]) ||
+			funcutil.IsEmptyString(policyInfos[1]) {
+			mlog.Warn(ctx, "invalid row policy key", mlog.String("string", key), mlog.String("sub_string", rowPolicyPrefix))
+			continue
+		}
+		dbName, collectionName := funcutil.SplitObjectName(policyInfos[1])
+		policies = append(policies, &internalpb.RowPolicy{
+			RoleName:       policyInfos[0],
+			DbName:         dbName,
+			CollectionName: collectionName,
+			Expr:           values[i],
+		})
+	}
+	return policies, nil
+}
+
+func (kc *Catalog) listRowPoliciesOfCollection(ctx context.Context, tenant string, dbName string, collectionName string) ([]*internalpb.RowPolicy, error) {
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	policies, err := kc.ListRowPolicies(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(policies, func(policy *internalpb.RowPolicy, _ int) bool {
+		return policy.GetDbName() == dbName && policy.GetCollectionName() == collectionName
+	}), nil
+}
+
 func (kc *Catalog) ListUserRole(ctx context.Context, tenant string) ([]string, error) {
 	var userRoles []string
 	k := funcutil.HandleTenantForEtcdPrefix(RoleMappingPrefix, tenant)
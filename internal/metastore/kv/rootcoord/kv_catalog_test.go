@@ -2826,7 +2826,7 @@ func TestRBAC_Grant(t *testing.T) {
 
 		kvmock.EXPECT().LoadWithPrefix(mock.Anything, loadErrorRolePrefix).Call.Return(nil, nil, errors.New("mock loadWithPrefix error"))
 		kvmock.EXPECT().LoadWithPrefix(mock.Anything, mock.Anything).Call.Return(nil, []string{granteeID}, nil)
-		kvmock.EXPECT().MultiSaveAndRemoveWithPrefix(mock.Anything, mock.Anything, []string{errorRolePrefix, granteePrefix}, mock.Anything).Call.Return(errors.New("mock removeWithPrefix error"))
+		kvmock.EXPECT().MultiSaveAndRemoveWithPrefix(mock.Anything, mock.Anything, []string{errorRolePrefix, granteePrefix, funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant, errorRole)}, mock.Anything).Call.Return(errors.New("mock removeWithPrefix error"))
 		kvmock.EXPECT().MultiSaveAndRemoveWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Call.Return(nil)
 
 		tests := []struct {
@@ -3083,6 +3083,69 @@ func TestRBAC_Grant(t *testing.T) {
 	})
 }
 
+func TestRBAC_RowPolicy(t *testing.T) {
+	ctx := context.TODO()
+	tenant := util.DefaultTenant
+	rowPolicyPrefix := funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant)
+
+	t.Run("test AlterRowPolicies", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		kvmock.EXPECT().MultiSaveAndRemove(mock.Anything,
+			map[string]string{rowPolicyPrefix + "role1/default.col1": "tenant == 1"},
+			[]string{rowPolicyPrefix + "role2/db1.col1"}).Return(nil)
+
+		err := c.AlterRowPolicies(ctx, tenant, []*internalpb.RowPolicy{
+			{RoleName: "role1", CollectionName: "col1", Expr: "tenant == 1"},
+			{RoleName: "role2", DbName: "db1", CollectionName: "col1"},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, c.AlterRowPolicies(ctx, tenant, nil))
+	})
+
+	t.Run("test AlterRowPolicies error", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		kvmock.EXPECT().MultiSaveAndRemove(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mock save error"))
+
+		err := c.AlterRowPolicies(ctx, tenant, []*internalpb.RowPolicy{
+			{RoleName: "role1", CollectionName: "col1", Expr: "tenant == 1"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("test ListRowPolicies", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(
+			[]string{
+				"/root/" + rowPolicyPrefix + "role1/default.col1",
+				rowPolicyPrefix + "role2/db1.col2",
+				rowPolicyPrefix + "invalid",
+			},
+			[]string{"tenant == 1", "tenant == 2", "tenant == 3"}, nil)
+
+		policies, err := c.ListRowPolicies(ctx, tenant)
+		assert.NoError(t, err)
+		assert.Len(t, policies, 2)
+		assert.Equal(t, "role1", policies[0].GetRoleName())
+		assert.Equal(t, "default", policies[0].GetDbName())
+		assert.Equal(t, "col1", policies[0].GetCollectionName())
+		assert.Equal(t, "tenant == 1", policies[0].GetExpr())
+		assert.Equal(t, "db1", policies[1].GetDbName())
+		assert.Equal(t, "col2", policies[1].GetCollectionName())
+	})
+
+	t.Run("test ListRowPolicies error", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, errors.New("mock load error"))
+
+		_, err := c.ListRowPolicies(ctx, tenant)
+		assert.Error(t, err)
+	})
+}
+
 func TestRBACGrantLegacyGranteeIDCompatibility(t *testing.T) {
 	ctx := context.Background()
 	etcdCli, _ := etcd.GetEtcdClient(
@@ -4485,10 +4548,12 @@ func TestCatalog_FileResource(t *testing.T) {
 func TestDeleteGrantByCollectionName(t *testing.T) {
 	ctx := context.Background()
 	tenant := util.DefaultTenant
+	rowPolicyPrefix := funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant)
 
 	t.Run("load error", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 		kvmock.EXPECT().LoadWithPrefix(mock.Anything, granteeKey).Return(nil, nil, errors.New("load error"))
 
@@ -4499,6 +4564,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("no matching grants", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		// Only a grant for a different collection
@@ -4513,6 +4579,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("deletes matching grants only", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/default.col1"
@@ -4541,6 +4608,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("handles old format without db prefix", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		// Old format: objectName without "." is treated as default db
@@ -4562,6 +4630,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("skips wildcard dbName grants on drop", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		// Wildcard grant *.col1 should NOT be deleted when dropping db1.col1,
@@ -4586,6 +4655,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("handles rootPath prefix in etcd keys", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		// Simulate etcd returning keys WITH rootPath prefix (as real etcd does)
@@ -4610,6 +4680,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("MultiSaveAndRemoveWithPrefix error", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/default.col1"
@@ -4627,6 +4698,7 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 	t.Run("MultiSaveAndRemove error", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/default.col1"
@@ -4642,15 +4714,42 @@ func TestDeleteGrantByCollectionName(t *testing.T) {
 		err := c.DeleteGrantByCollectionName(ctx, tenant, "default", "col1")
 		assert.Error(t, err)
 	})
+
+	t.Run("deletes row policies of the collection", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
+
+		key1 := rowPolicyPrefix + "role1/default.col1"
+		key2 := rowPolicyPrefix + "role1/default.other_col"
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(
+			[]string{key1, key2}, []string{"tenant == 1", "tenant == 2"}, nil)
+		kvmock.EXPECT().MultiSaveAndRemove(mock.Anything, (map[string]string)(nil), []string{key1}).Return(nil)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, granteeKey).Return(nil, nil, nil)
+
+		err := c.DeleteGrantByCollectionName(ctx, tenant, "default", "col1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("row policy load error", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, errors.New("load error"))
+
+		err := c.DeleteGrantByCollectionName(ctx, tenant, "default", "col1")
+		assert.Error(t, err)
+	})
 }
 
 func TestMigrateGrantCollectionName(t *testing.T) {
 	ctx := context.Background()
 	tenant := util.DefaultTenant
+	rowPolicyPrefix := funcutil.HandleTenantForEtcdPrefix(RowPolicyPrefix, tenant)
 
 	t.Run("load error", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 		kvmock.EXPECT().LoadWithPrefix(mock.Anything, granteeKey).Return(nil, nil, errors.New("load error"))
 
@@ -4661,6 +4760,7 @@ func TestMigrateGrantCollectionName(t *testing.T) {
 	t.Run("no matching grants", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/default.other_col"
@@ -4674,6 +4774,7 @@ func TestMigrateGrantCollectionName(t *testing.T) {
 	t.Run("migrates matching grants with grantee id recomputation", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/default.old_col"
@@ -4723,6 +4824,7 @@ func TestMigrateGrantCollectionName(t *testing.T) {
 	t.Run("cross-db rename", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/db1.col1"
@@ -4749,6 +4851,7 @@ func TestMigrateGrantCollectionName(t *testing.T) {
 	t.Run("skips wildcard dbName grants on rename", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		// Wildcard grant *.old_col should NOT be migrated when renaming db1.old_col,
@@ -4825,6 +4928,7 @@ func TestMigrateGrantCollectionName(t *testing.T) {
 	t.Run("save error", func(t *testing.T) {
 		kvmock := mocks.NewTxnKV(t)
 		c := NewCatalog(kvmock)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(nil, nil, nil)
 		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
 
 		key1 := granteeKey + "role1/Collection/default.old_col"
@@ -4841,4 +4945,21 @@ func TestMigrateGrantCollectionName(t *testing.T) {
 		err := c.MigrateGrantCollectionName(ctx, tenant, "default", "old_col", "default", "new_col")
 		assert.Error(t, err)
 	})
+
+	t.Run("migrates row policies of the collection", func(t *testing.T) {
+		kvmock := mocks.NewTxnKV(t)
+		c := NewCatalog(kvmock)
+		granteeKey := funcutil.HandleTenantForEtcdPrefix(GranteePrefix, tenant)
+
+		key1 := rowPolicyPrefix + "role1/default.old_col"
+		key2 := rowPolicyPrefix + "role1/default.other_col"
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, rowPolicyPrefix).Return(
+			[]string{key1, key2}, []string{"tenant == 1", "tenant == 2"}, nil)
+		kvmock.EXPECT().MultiSaveAndRemove(mock.Anything,
+			map[string]string{rowPolicyPrefix + "role1/db2.new_col": "tenant == 1"}, []string{key1}).Return(nil)
+		kvmock.EXPECT().LoadWithPrefix(mock.Anything, granteeKey).Return(nil, nil, nil)
+
+		err := c.MigrateGrantCollectionName(ctx, tenant, "default", "old_col", "db2", "new_col")
+		assert.NoError(t, err)
+	})
 }
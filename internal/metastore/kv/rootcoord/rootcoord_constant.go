@@ -54,6 +54,9 @@ const (
 	// GranteeIDPrefix prefix for mapping among privilege and grantor
 	GranteeIDPrefix = ComponentPrefix + CommonCredentialPrefix + "/grantee-id"
 
+	// RowPolicyPrefix prefix for mapping among role, collection and row policy expression
+	RowPolicyPrefix = ComponentPrefix + CommonCredentialPrefix + "/row-policy"
+
 	// PrivilegeGroupPrefix prefix for privilege group
 	PrivilegeGroupPrefix = ComponentPrefix + "/privilege-group"
 
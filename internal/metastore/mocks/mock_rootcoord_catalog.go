@@ -416,6 +416,54 @@ func (_c *RootCoordCatalog_AlterRole_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// AlterRowPolicies provides a mock function with given fields: ctx, tenant, policies
+func (_m *RootCoordCatalog) AlterRowPolicies(ctx context.Context, tenant string, policies []*internalpb.RowPolicy) error {
+	ret := _m.Called(ctx, tenant, policies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlterRowPolicies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*internalpb.RowPolicy) error); ok {
+		r0 = rf(ctx, tenant, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RootCoordCatalog_AlterRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlterRowPolicies'
+type RootCoordCatalog_AlterRowPolicies_Call struct {
+	*mock.Call
+}
+
+// AlterRowPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - policies []*internalpb.RowPolicy
+func (_e *RootCoordCatalog_Expecter) AlterRowPolicies(ctx interface{}, tenant interface{}, policies interface{}) *RootCoordCatalog_AlterRowPolicies_Call {
+	return &RootCoordCatalog_AlterRowPolicies_Call{Call: _e.mock.On("AlterRowPolicies", ctx, tenant, policies)}
+}
+
+func (_c *RootCoordCatalog_AlterRowPolicies_Call) Run(run func(ctx context.Context, tenant string, policies []*internalpb.RowPolicy)) *RootCoordCatalog_AlterRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]*internalpb.RowPolicy))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_AlterRowPolicies_Call) Return(_a0 error) *RootCoordCatalog_AlterRowPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RootCoordCatalog_AlterRowPolicies_Call) RunAndReturn(run func(context.Context, string, []*internalpb.RowPolicy) error) *RootCoordCatalog_AlterRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AlterUserRole provides a mock function with given fields: ctx, tenant, userEntity, roleEntity, operateType
 func (_m *RootCoordCatalog) AlterUserRole(ctx context.Context, tenant string, userEntity *milvuspb.UserEntity, roleEntity *milvuspb.RoleEntity, operateType milvuspb.OperateUserRoleType) error {
 	ret := _m.Called(ctx, tenant, userEntity, roleEntity, operateType)
@@ -2062,6 +2110,65 @@ func (_c *RootCoordCatalog_ListRole_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ListRowPolicies provides a mock function with given fields: ctx, tenant
+func (_m *RootCoordCatalog) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRowPolicies")
+	}
+
+	var r0 []*internalpb.RowPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*internalpb.RowPolicy, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*internalpb.RowPolicy); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*internalpb.RowPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootCoordCatalog_ListRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRowPolicies'
+type RootCoordCatalog_ListRowPolicies_Call struct {
+	*mock.Call
+}
+
+// ListRowPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+func (_e *RootCoordCatalog_Expecter) ListRowPolicies(ctx interface{}, tenant interface{}) *RootCoordCatalog_ListRowPolicies_Call {
+	return &RootCoordCatalog_ListRowPolicies_Call{Call: _e.mock.On("ListRowPolicies", ctx, tenant)}
+}
+
+func (_c *RootCoordCatalog_ListRowPolicies_Call) Run(run func(ctx context.Context, tenant string)) *RootCoordCatalog_ListRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RootCoordCatalog_ListRowPolicies_Call) Return(_a0 []*internalpb.RowPolicy, _a1 error) *RootCoordCatalog_ListRowPolicies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RootCoordCatalog_ListRowPolicies_Call) RunAndReturn(run func(context.Context, string) ([]*internalpb.RowPolicy, error)) *RootCoordCatalog_ListRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListUser provides a mock function with given fields: ctx, tenant, entity, includeRoleInfo
 func (_m *RootCoordCatalog) ListUser(ctx context.Context, tenant string, entity *milvuspb.UserEntity, includeRoleInfo bool) ([]*milvuspb.UserResult, error) {
 	ret := _m.Called(ctx, tenant, entity, includeRoleInfo)
@@ -68,7 +68,7 @@ type RootCoordCatalog interface {
 	// AlterGrant  grants or revokes a grant of a role to an object, according to the operateType.
 	// Please make sure entity and operateType are valid before calling this API
 	AlterGrant(ctx context.Context, tenant string, entity *milvuspb.GrantEntity, operateType milvuspb.OperatePrivilegeType) error
-	// DeleteGrant deletes all the grant for a role.
+	// DeleteGrant deletes all the grant and row policies for a role.
 	// Please make sure the role.Name isn't empty before call this API.
 	DeleteGrant(ctx context.Context, tenant string, role *milvuspb.RoleEntity) error
 	// ListGrant lists all grant infos accoording to entity for the tenant
@@ -79,9 +79,14 @@ type RootCoordCatalog interface {
 	// For example []string{"user1/role1"}
 	ListUserRole(ctx context.Context, tenant string) ([]string, error)
 
-	// DeleteGrantByCollectionName deletes all grants for a specific collection.
+	// AlterRowPolicies saves the row policies of roles on collections, the policies with empty expression are removed.
+	AlterRowPolicies(ctx context.Context, tenant string, policies []*internalpb.RowPolicy) error
+	// ListRowPolicies lists all the row policies for the tenant.
+	ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error)
+
+	// DeleteGrantByCollectionName deletes all grants and row policies for a specific collection.
 	DeleteGrantByCollectionName(ctx context.Context, tenant string, dbName string, collectionName string) error
-	// MigrateGrantCollectionName migrates all grants from oldName to newName when a collection is renamed.
+	// MigrateGrantCollectionName migrates all grants and row policies from oldName to newName when a collection is renamed.
 	MigrateGrantCollectionName(ctx context.Context, tenant string, oldDBName string, oldName string, newDBName string, newName string) error
 
 	BackupRBAC(ctx context.Context, tenant string) (*milvuspb.RBACMeta, error)
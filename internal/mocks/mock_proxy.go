@@ -10,6 +10,7 @@ import (
 	milvuspb "github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	mock "github.com/stretchr/testify/mock"
 
+	changestreampb "github.com/milvus-io/milvus/client/v3/changestreampb"
 	types "github.com/milvus-io/milvus/internal/types"
 	datapb "github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	internalpb "github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
@@ -9117,6 +9118,53 @@ func (_c *MockProxy_Stop_Call) RunAndReturn(run func() error) *MockProxy_Stop_Ca
 	return _c
 }
 
+// SubscribeChanges provides a mock function with given fields: _a0, _a1
+func (_m *MockProxy) SubscribeChanges(_a0 *changestreampb.SubscribeChangesRequest, _a1 changestreampb.ChangeStreamService_SubscribeChangesServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*changestreampb.SubscribeChangesRequest, changestreampb.ChangeStreamService_SubscribeChangesServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProxy_SubscribeChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeChanges'
+type MockProxy_SubscribeChanges_Call struct {
+	*mock.Call
+}
+
+// SubscribeChanges is a helper method to define mock.On call
+//   - _a0 *changestreampb.SubscribeChangesRequest
+//   - _a1 changestreampb.ChangeStreamService_SubscribeChangesServer
+func (_e *MockProxy_Expecter) SubscribeChanges(_a0 interface{}, _a1 interface{}) *MockProxy_SubscribeChanges_Call {
+	return &MockProxy_SubscribeChanges_Call{Call: _e.mock.On("SubscribeChanges", _a0, _a1)}
+}
+
+func (_c *MockProxy_SubscribeChanges_Call) Run(run func(_a0 *changestreampb.SubscribeChangesRequest, _a1 changestreampb.ChangeStreamService_SubscribeChangesServer)) *MockProxy_SubscribeChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*changestreampb.SubscribeChangesRequest), args[1].(changestreampb.ChangeStreamService_SubscribeChangesServer))
+	})
+	return _c
+}
+
+func (_c *MockProxy_SubscribeChanges_Call) Return(_a0 error) *MockProxy_SubscribeChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProxy_SubscribeChanges_Call) RunAndReturn(run func(*changestreampb.SubscribeChangesRequest, changestreampb.ChangeStreamService_SubscribeChangesServer) error) *MockProxy_SubscribeChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransferNode provides a mock function with given fields: _a0, _a1
 func (_m *MockProxy) TransferNode(_a0 context.Context, _a1 *milvuspb.TransferNodeRequest) (*commonpb.Status, error) {
 	ret := _m.Called(_a0, _a1)
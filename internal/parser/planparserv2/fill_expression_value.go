@@ -49,6 +49,8 @@ func FillExpressionValue(expr *planpb.Expr, templateValues map[string]*planpb.Ge
 		return nil
 	case *planpb.Expr_MatchExpr:
 		return FillExpressionValue(e.MatchExpr.GetPredicate(), templateValues)
+	case *planpb.Expr_StringFunctionFilterExpr:
+		return FillStringFunctionFilterExpressionValue(e.StringFunctionFilterExpr, templateValues)
 	default:
 		return merr.WrapErrQueryPlanMsg("this expression no need to fill placeholder with expr type: %T", e)
 	}
@@ -338,3 +340,51 @@ func FillJSONContainsExpressionValue(expr *planpb.JSONContainsExpr, templateValu
 	}
 	return nil
 }
+
+func FillStringFunctionFilterExpressionValue(expr *planpb.StringFunctionFilterExpr, templateValues map[string]*planpb.GenericValue) error {
+	value, ok := templateValues[expr.GetTemplateVariableName()]
+	if !ok {
+		return merr.WrapErrQueryPlanMsg("the value of expression template variable name {%s} is not found", expr.GetTemplateVariableName())
+	}
+	if value == nil {
+		return merr.WrapErrQueryPlanMsg("the value of expression template variable {%s} is nil", expr.GetTemplateVariableName())
+	}
+
+	if isLikeMatchOp(expr.GetOp()) {
+		if !IsString(value) {
+			return merr.WrapErrQueryPlanMsg("the value of like expression template variable {%s} is not string", expr.GetTemplateVariableName())
+		}
+		op, operand, err := translatePatternMatch(value.GetStringVal())
+		if err != nil {
+			return err
+		}
+		expr.Op = op
+		expr.Values = []*planpb.GenericValue{NewString(operand)}
+		return nil
+	}
+
+	dataType := stringFunctionResultType(expr.GetInput().GetFunctionName())
+	if expr.GetOp() != planpb.OpType_In {
+		castedValue, err := castValue(dataType, value)
+		if err != nil {
+			return err
+		}
+		expr.Values = []*planpb.GenericValue{castedValue}
+		return nil
+	}
+
+	if value.GetArrayVal() == nil {
+		return merr.WrapErrQueryPlanMsg("the value of term expression template variable {%s} is not array", expr.GetTemplateVariableName())
+	}
+	array := value.GetArrayVal().GetArray()
+	values := make([]*planpb.GenericValue, len(array))
+	for i, e := range array {
+		castedValue, err := castValue(dataType, e)
+		if err != nil {
+			return err
+		}
+		values[i] = castedValue
+	}
+	expr.Values = values
+	return nil
+}
@@ -597,17 +597,24 @@ func (v *ParserVisitor) VisitLike(ctx *parser.LikeContext) interface{} {
 		return merr.WrapErrQueryPlanMsg("the left operand of like is invalid")
 	}
 
+	stringFunc := leftExpr.expr.GetStringFunctionExpr()
 	column := toColumnInfo(leftExpr)
-	if column == nil {
-		return merr.WrapErrQueryPlanMsg("like operation on complicated expr is unsupported")
-	}
-	if err := checkDirectComparisonBinaryField(column); err != nil {
-		return err
-	}
+	if stringFunc != nil {
+		if !typeutil.IsStringType(leftExpr.dataType) {
+			return merr.WrapErrQueryPlanMsg("like operation on non-string function %s is unsupported", stringFunc.GetFunctionName())
+		}
+	} else {
+		if column == nil {
+			return merr.WrapErrQueryPlanMsg("like operation on complicated expr is unsupported")
+		}
+		if err := checkDirectComparisonBinaryField(column); err != nil {
+			return err
+		}
 
-	if !typeutil.IsStringType(leftExpr.dataType) && !typeutil.IsJSONType(leftExpr.dataType) &&
-		(!typeutil.IsArrayType(leftExpr.dataType) || !typeutil.IsStringType(column.GetElementType())) {
-		return merr.WrapErrQueryPlanMsg("like operation on non-string or no-json field is unsupported")
+		if !typeutil.IsStringType(leftExpr.dataType) && !typeutil.IsJSONType(leftExpr.dataType) &&
+			(!typeutil.IsArrayType(leftExpr.dataType) || !typeutil.IsStringType(column.GetElementType())) {
+			return merr.WrapErrQueryPlanMsg("like operation on non-string or no-json field is unsupported")
+		}
 	}
 
 	pattern, placeholder, isTemplate, err := v.parseStringLiteralOrTemplate(ctx.Expr(1), "like pattern")
@@ -627,6 +634,17 @@ func (v *ParserVisitor) VisitLike(ctx *parser.LikeContext) interface{} {
 		value = NewString(operand)
 	}
 
+	if stringFunc != nil {
+		var values []*planpb.GenericValue
+		if value != nil {
+			values = []*planpb.GenericValue{value}
+		}
+		return &ExprWithType{
+			expr:     newStringFunctionFilterExpr(stringFunc, op, values, placeholder),
+			dataType: schemapb.DataType_Bool,
+		}
+	}
+
 	return &ExprWithType{
 		expr: &planpb.Expr{
 			Expr: &planpb.Expr_UnaryRangeExpr{
@@ -1129,17 +1147,21 @@ func (v *ParserVisitor) VisitTerm(ctx *parser.TermContext) interface{} {
 	}
 
 	childExpr := getExpr(child)
+	stringFunc := childExpr.expr.GetStringFunctionExpr()
 	columnInfo := toColumnInfo(childExpr)
-	if columnInfo == nil {
+	if columnInfo == nil && stringFunc == nil {
 		return merr.WrapErrParameterInvalidMsg("'term' can only be used on single field, but got: %s", ctx.Expr(0).GetText())
 	}
 
-	dataType := columnInfo.GetDataType()
-	// Use element type for IN operation in two cases:
-	// 1. Array with nested path (e.g., arr[0] IN [1, 2, 3])
-	// 2. Array with element level flag (e.g., $[intField] IN [1, 2] in MATCH_ALL/ElementFilter)
-	if typeutil.IsArrayType(dataType) && (len(columnInfo.GetNestedPath()) != 0 || columnInfo.GetIsElementLevel()) {
-		dataType = columnInfo.GetElementType()
+	dataType := childExpr.dataType
+	if columnInfo != nil {
+		dataType = columnInfo.GetDataType()
+		// Use element type for IN operation in two cases:
+		// 1. Array with nested path (e.g., arr[0] IN [1, 2, 3])
+		// 2. Array with element level flag (e.g., $[intField] IN [1, 2] in MATCH_ALL/ElementFilter)
+		if typeutil.IsArrayType(dataType) && (len(columnInfo.GetNestedPath()) != 0 || columnInfo.GetIsElementLevel()) {
+			dataType = columnInfo.GetElementType()
+		}
 	}
 
 	term := ctx.Expr(1).Accept(v)
@@ -1207,6 +1229,9 @@ func (v *ParserVisitor) VisitTerm(ctx *parser.TermContext) interface{} {
 		},
 		IsTemplate: isTemplate,
 	}
+	if stringFunc != nil {
+		expr = newStringFunctionFilterExpr(stringFunc, planpb.OpType_In, values, placeholder)
+	}
 	if ctx.GetOp() != nil {
 		expr = &planpb.Expr{
 			Expr: &planpb.Expr_UnaryExpr{
@@ -1363,13 +1388,31 @@ func isUnsupportedNullExprVectorType(dataType schemapb.DataType) bool {
 func (v *ParserVisitor) VisitCall(ctx *parser.CallContext) interface{} {
 	functionName := strings.ToLower(ctx.Identifier().GetText())
 	numParams := len(ctx.AllExpr())
+	params := make([]*ExprWithType, 0, numParams)
 	funcParameters := make([]*planpb.Expr, 0, numParams)
 	for _, param := range ctx.AllExpr() {
 		paramExpr := param.Accept(v)
 		if err := getError(paramExpr); err != nil {
 			return err
 		}
-		funcParameters = append(funcParameters, getExpr(param.Accept(v)).expr)
+		params = append(params, getExpr(paramExpr))
+		funcParameters = append(funcParameters, getExpr(paramExpr).expr)
+	}
+
+	if isStringFunction(functionName) {
+		ret, err := handleStringFunction(functionName, params)
+		if err != nil {
+			return err
+		}
+		return ret
+	}
+	if functionName == stringFunctionStartsWith || functionName == stringFunctionEndsWith {
+		if expr := handleStringMatchFunction(functionName, params); expr != nil {
+			return &ExprWithType{
+				expr:     expr,
+				dataType: schemapb.DataType_Bool,
+			}
+		}
 	}
 	return &ExprWithType{
 		expr: &planpb.Expr{
@@ -320,6 +320,106 @@ func TestExpr_Call(t *testing.T) {
 	assert.Nil(t, expr)
 }
 
+func TestExpr_StringFunction(t *testing.T) {
+	schema := newTestSchema(true)
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+
+	t.Run("comparison", func(t *testing.T) {
+		expr, err := ParseExpr(helper, `lower(trim(VarCharField)) == "abc"`, nil)
+		assert.NoError(t, err)
+		filter := expr.GetStringFunctionFilterExpr()
+		assert.NotNil(t, filter)
+		assert.Equal(t, planpb.OpType_Equal, filter.GetOp())
+		assert.Equal(t, "abc", filter.GetValues()[0].GetStringVal())
+		assert.Equal(t, "lower", filter.GetInput().GetFunctionName())
+		trim := filter.GetInput().GetInput().GetStringFunctionExpr()
+		assert.Equal(t, "trim", trim.GetFunctionName())
+		assert.Equal(t, schemapb.DataType_VarChar, trim.GetInput().GetColumnExpr().GetInfo().GetDataType())
+
+		expr, err = ParseExpr(helper, `3 < length(JSONField["A"])`, nil)
+		assert.NoError(t, err)
+		filter = expr.GetStringFunctionFilterExpr()
+		assert.Equal(t, planpb.OpType_GreaterThan, filter.GetOp())
+		assert.Equal(t, int64(3), filter.GetValues()[0].GetInt64Val())
+		assert.Equal(t, []string{"A"}, filter.GetInput().GetInput().GetColumnExpr().GetInfo().GetNestedPath())
+
+		expr, err = ParseExpr(helper, `substr(upper(VarCharField), 2, 3) != "BCD"`, nil)
+		assert.NoError(t, err)
+		filter = expr.GetStringFunctionFilterExpr()
+		assert.Equal(t, planpb.OpType_NotEqual, filter.GetOp())
+		assert.Equal(t, []int64{2, 3}, filter.GetInput().GetArguments())
+	})
+
+	t.Run("term and like", func(t *testing.T) {
+		expr, err := ParseExpr(helper, `upper(VarCharField) not in ["A", "B"]`, nil)
+		assert.NoError(t, err)
+		filter := expr.GetUnaryExpr().GetChild().GetStringFunctionFilterExpr()
+		assert.Equal(t, planpb.OpType_In, filter.GetOp())
+		assert.Equal(t, 2, len(filter.GetValues()))
+
+		expr, err = ParseExpr(helper, `lower(VarCharField) like "ab%"`, nil)
+		assert.NoError(t, err)
+		filter = expr.GetStringFunctionFilterExpr()
+		assert.Equal(t, planpb.OpType_PrefixMatch, filter.GetOp())
+		assert.Equal(t, "ab", filter.GetValues()[0].GetStringVal())
+	})
+
+	t.Run("starts_with and ends_with", func(t *testing.T) {
+		expr, err := ParseExpr(helper, `starts_with(VarCharField, "ab")`, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, planpb.OpType_PrefixMatch, expr.GetUnaryRangeExpr().GetOp())
+		assert.Equal(t, "ab", expr.GetUnaryRangeExpr().GetValue().GetStringVal())
+
+		expr, err = ParseExpr(helper, `ends_with(lower(JSONField["A"]), "%z")`, nil)
+		assert.NoError(t, err)
+		filter := expr.GetStringFunctionFilterExpr()
+		assert.Equal(t, planpb.OpType_PostfixMatch, filter.GetOp())
+		assert.Equal(t, "%z", filter.GetValues()[0].GetStringVal())
+
+		// kept as function call if the pattern is not a string literal
+		expr, err = ParseExpr(helper, `starts_with(VarCharField, StringField)`, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "starts_with", expr.GetCallExpr().GetFunctionName())
+	})
+
+	t.Run("template", func(t *testing.T) {
+		expr, err := ParseExpr(helper, `length(VarCharField) >= {n} and lower(VarCharField) in {list} and upper(VarCharField) like {p}`,
+			map[string]*schemapb.TemplateValue{
+				"n": generateTemplateValue(schemapb.DataType_Int64, int64(3)),
+				"list": generateTemplateValue(schemapb.DataType_Array, &schemapb.TemplateArrayValue{
+					Data: &schemapb.TemplateArrayValue_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "b"}}},
+				}),
+				"p": generateTemplateValue(schemapb.DataType_VarChar, "%A%"),
+			})
+		assert.NoError(t, err)
+		left := expr.GetBinaryExpr().GetLeft().GetBinaryExpr()
+		assert.Equal(t, int64(3), left.GetLeft().GetStringFunctionFilterExpr().GetValues()[0].GetInt64Val())
+		assert.Equal(t, 2, len(left.GetRight().GetStringFunctionFilterExpr().GetValues()))
+		like := expr.GetBinaryExpr().GetRight().GetStringFunctionFilterExpr()
+		assert.Equal(t, planpb.OpType_InnerMatch, like.GetOp())
+		assert.Equal(t, "A", like.GetValues()[0].GetStringVal())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalidExprs := []string{
+			`lower(Int64Field) == "a"`,
+			`lower(VarCharField, 1) == "a"`,
+			`lower(length(VarCharField)) == "a"`,
+			`length(VarCharField) == "a"`,
+			`length(VarCharField) like "a%"`,
+			`substr(VarCharField) == "a"`,
+			`substr(VarCharField, 0) == "a"`,
+			`substr(VarCharField, 1, -1) == "a"`,
+			`substr(VarCharField, Int64Field) == "a"`,
+			`lower(VarCharField) == lower(StringField)`,
+		}
+		for _, exprStr := range invalidExprs {
+			assertInvalidExpr(t, helper, exprStr)
+		}
+	})
+}
+
 func TestExpr_Compare(t *testing.T) {
 	schema := newTestSchema(true)
 	helper, err := typeutil.CreateSchemaHelper(schema)
@@ -0,0 +1,139 @@
+package planparserv2
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// String functions evaluated on VarChar fields and JSON string paths.
+const (
+	stringFunctionLower  = "lower"
+	stringFunctionUpper  = "upper"
+	stringFunctionTrim   = "trim"
+	stringFunctionSubstr = "substr"
+	stringFunctionLength = "length"
+
+	stringFunctionStartsWith = "starts_with"
+	stringFunctionEndsWith   = "ends_with"
+)
+
+func isStringFunction(name string) bool {
+	switch name {
+	case stringFunctionLower, stringFunctionUpper, stringFunctionTrim, stringFunctionSubstr, stringFunctionLength:
+		return true
+	default:
+		return false
+	}
+}
+
+// stringFunctionResultType returns the data type of the value of the string function.
+func stringFunctionResultType(name string) schemapb.DataType {
+	if name == stringFunctionLength {
+		return schemapb.DataType_Int64
+	}
+	return schemapb.DataType_VarChar
+}
+
+// isStringFunctionInput checks if the expr can be the input of a string function,
+// which is a VarChar field, a JSON path, or another string function of string value.
+func isStringFunctionInput(expr *ExprWithType) bool {
+	if expr.expr.GetIsTemplate() {
+		return false
+	}
+	if expr.expr.GetStringFunctionExpr() != nil {
+		return typeutil.IsStringType(expr.dataType)
+	}
+	columnInfo := toColumnInfo(expr)
+	if columnInfo == nil {
+		return false
+	}
+	return typeutil.IsStringType(expr.dataType) || typeutil.IsJSONType(expr.dataType)
+}
+
+// handleStringFunction translates lower(s), upper(s), trim(s), length(s) and substr(s, start[, length])
+// to a StringFunctionExpr, substr counts characters from 1.
+func handleStringFunction(name string, params []*ExprWithType) (*ExprWithType, error) {
+	if name == stringFunctionSubstr {
+		if len(params) != 2 && len(params) != 3 {
+			return nil, merr.WrapErrParameterInvalidMsg("substr expects 2 or 3 parameters, but got %d", len(params))
+		}
+	} else if len(params) != 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("%s expects 1 parameter, but got %d", name, len(params))
+	}
+	if !isStringFunctionInput(params[0]) {
+		return nil, merr.WrapErrParameterInvalidMsg("%s can only be applied on VarChar field, JSON field or string function", name)
+	}
+
+	arguments := make([]int64, 0, len(params)-1)
+	for _, param := range params[1:] {
+		value := param.expr.GetValueExpr().GetValue()
+		if value == nil || !IsInteger(value) {
+			return nil, merr.WrapErrParameterInvalidMsg("the start and length of substr must be integer constants")
+		}
+		arguments = append(arguments, value.GetInt64Val())
+	}
+	if len(arguments) > 0 && arguments[0] < 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("the start of substr must be greater than 0, but got %d", arguments[0])
+	}
+	if len(arguments) > 1 && arguments[1] < 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("the length of substr cannot be negative, but got %d", arguments[1])
+	}
+
+	return &ExprWithType{
+		expr: &planpb.Expr{
+			Expr: &planpb.Expr_StringFunctionExpr{
+				StringFunctionExpr: &planpb.StringFunctionExpr{
+					FunctionName: name,
+					Input:        params[0].expr,
+					Arguments:    arguments,
+				},
+			},
+		},
+		dataType:      stringFunctionResultType(name),
+		nodeDependent: true,
+	}, nil
+}
+
+// handleStringMatchFunction translates starts_with(s, prefix) and ends_with(s, suffix) with a string literal
+// to a prefix or postfix match, it returns nil if the parameters are not supported so the call is kept as is.
+func handleStringMatchFunction(name string, params []*ExprWithType) *planpb.Expr {
+	if len(params) != 2 || !isStringFunctionInput(params[0]) {
+		return nil
+	}
+	value := params[1].expr.GetValueExpr().GetValue()
+	if value == nil || !IsString(value) {
+		return nil
+	}
+	op := planpb.OpType_PrefixMatch
+	if name == stringFunctionEndsWith {
+		op = planpb.OpType_PostfixMatch
+	}
+	if stringFunc := params[0].expr.GetStringFunctionExpr(); stringFunc != nil {
+		return newStringFunctionFilterExpr(stringFunc, op, []*planpb.GenericValue{value}, "")
+	}
+	return &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: toColumnInfo(params[0]),
+				Op:         op,
+				Value:      value,
+			},
+		},
+	}
+}
+
+func newStringFunctionFilterExpr(stringFunc *planpb.StringFunctionExpr, op planpb.OpType, values []*planpb.GenericValue, placeholder string) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_StringFunctionFilterExpr{
+			StringFunctionFilterExpr: &planpb.StringFunctionFilterExpr{
+				Input:                stringFunc,
+				Op:                   op,
+				Values:               values,
+				TemplateVariableName: placeholder,
+			},
+		},
+		IsTemplate: placeholder != "",
+	}
+}
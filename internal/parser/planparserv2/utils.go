@@ -400,6 +400,14 @@ func handleCompareRightValue(op planpb.OpType, left *ExprWithType, right *planpb
 		return handleBinaryArithExpr(op, leftArithExpr, left.dataType, right)
 	}
 
+	if stringFunc := left.expr.GetStringFunctionExpr(); stringFunc != nil {
+		var values []*planpb.GenericValue
+		if right.GetValue() != nil {
+			values = []*planpb.GenericValue{right.GetValue()}
+		}
+		return newStringFunctionFilterExpr(stringFunc, op, values, right.GetTemplateVariableName()), nil
+	}
+
 	if columnInfo == nil {
 		return nil, merr.WrapErrQueryPlanMsg("not supported to combine multiple fields")
 	}
@@ -579,6 +587,64 @@ func IsAlwaysTruePlan(plan *planpb.PlanNode) bool {
 	return false
 }
 
+func logicalExpr(op planpb.BinaryExpr_BinaryOp, left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{
+				Left:  left,
+				Right: right,
+				Op:    op,
+			},
+		},
+	}
+}
+
+// OrExprs combines the exprs by logical or, it returns nil if exprs is empty.
+func OrExprs(exprs ...*planpb.Expr) *planpb.Expr {
+	var result *planpb.Expr
+	for _, e := range exprs {
+		if result == nil {
+			result = e
+			continue
+		}
+		result = logicalExpr(planpb.BinaryExpr_LogicalOr, result, e)
+	}
+	return result
+}
+
+// NotExpr returns the logical not of the expr.
+func NotExpr(e *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_UnaryExpr{
+			UnaryExpr: &planpb.UnaryExpr{
+				Op:    planpb.UnaryExpr_Not,
+				Child: e,
+			},
+		},
+	}
+}
+
+// AndPlanPredicates restricts the predicates of the plan by logical and with expr.
+func AndPlanPredicates(plan *planpb.PlanNode, expr *planpb.Expr) error {
+	and := func(predicates *planpb.Expr) *planpb.Expr {
+		if predicates == nil || isAlwaysTrueExpr(predicates) {
+			return expr
+		}
+		return logicalExpr(planpb.BinaryExpr_LogicalAnd, expr, predicates)
+	}
+	switch realPlan := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		realPlan.VectorAnns.Predicates = and(realPlan.VectorAnns.GetPredicates())
+	case *planpb.PlanNode_Predicates:
+		realPlan.Predicates = and(realPlan.Predicates)
+	case *planpb.PlanNode_Query:
+		realPlan.Query.Predicates = and(realPlan.Query.GetPredicates())
+	default:
+		return merr.WrapErrQueryPlanMsg("unsupported plan node type %T", realPlan)
+	}
+	return nil
+}
+
 func canBeExecuted(e *ExprWithType) bool {
 	return typeutil.IsBoolType(e.dataType) && !e.nodeDependent
 }
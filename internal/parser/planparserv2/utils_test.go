@@ -134,6 +134,46 @@ func TestIsAlwaysTruePlan(t *testing.T) {
 	}
 }
 
+func TestAndPlanPredicates(t *testing.T) {
+	schemaHelper := newTestSchemaHelper(t)
+	policy, err := ParseExpr(schemaHelper, "Int64Field == 1", nil)
+	assert.NoError(t, err)
+	other, err := ParseExpr(schemaHelper, "Int64Field == 2", nil)
+	assert.NoError(t, err)
+
+	t.Run("always true", func(t *testing.T) {
+		plan, err := CreateRetrievePlan(schemaHelper, "", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, AndPlanPredicates(plan, policy))
+		assert.False(t, IsAlwaysTruePlan(plan))
+		assert.Equal(t, policy, plan.GetQuery().GetPredicates())
+	})
+
+	t.Run("and", func(t *testing.T) {
+		plan, err := CreateSearchPlan(schemaHelper, "Int32Field > 10", "FloatVectorField", &planpb.QueryInfo{Topk: 10, MetricType: "L2"}, nil, nil)
+		assert.NoError(t, err)
+		predicates := plan.GetVectorAnns().GetPredicates()
+		assert.NoError(t, AndPlanPredicates(plan, policy))
+		binary := plan.GetVectorAnns().GetPredicates().GetBinaryExpr()
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, binary.GetOp())
+		assert.Equal(t, policy, binary.GetLeft())
+		assert.Equal(t, predicates, binary.GetRight())
+	})
+
+	t.Run("or and not", func(t *testing.T) {
+		assert.Nil(t, OrExprs())
+		assert.Equal(t, policy, OrExprs(policy))
+		binary := OrExprs(policy, other).GetBinaryExpr()
+		assert.Equal(t, planpb.BinaryExpr_LogicalOr, binary.GetOp())
+		assert.Equal(t, other, binary.GetRight())
+		assert.Equal(t, policy, NotExpr(policy).GetUnaryExpr().GetChild())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		assert.Error(t, AndPlanPredicates(&planpb.PlanNode{}, policy))
+	})
+}
+
 func Test_canBeExecuted(t *testing.T) {
 	type args struct {
 		e *ExprWithType
@@ -18,9 +18,11 @@ package accesslog
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel"
@@ -190,6 +192,46 @@ func (s *LogFormatterSuite) TestFormatMethodResult() {
 	}
 }
 
+func (s *LogFormatterSuite) TestFormatJSON() {
+	fmt := "[$time_start] $method_name $method_status $user_name $time_cost $response_size $error_code $database_name $collection_name $partition_name $nq $method_name"
+	formatter := NewFormatter(fmt)
+
+	i := info.NewGrpcAccessInfo(s.ctx, s.serverinfo, &milvuspb.SearchRequest{
+		DbName:         "test-db",
+		CollectionName: "test-collection",
+		PartitionNames: []string{"test-partition-1", "test-partition-2"},
+		Nq:             10,
+	})
+	i.SetResult(&milvuspb.SearchResults{Status: merr.Status(nil)}, nil)
+
+	line := formatter.FormatJSON(i)
+	s.True(strings.HasSuffix(line, "\n"))
+
+	var fields map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(line), &fields))
+	s.Equal("test", fields["method_name"])
+	s.Equal("Successful", fields["method_status"])
+	s.Equal("mockUser", fields["user_name"])
+	s.Equal("test-db", fields["database_name"])
+	s.Equal("test-collection", fields["collection_name"])
+	s.Equal([]any{"test-partition-1", "test-partition-2"}, fields["partition_name"])
+	s.EqualValues(10, fields["nq"])
+	s.EqualValues(0, fields["error_code"])
+	s.IsType(float64(0), fields["time_cost_ms"])
+	s.IsType(float64(0), fields["response_size"])
+	_, err := time.Parse(time.RFC3339Nano, fields["time_start"].(string))
+	s.NoError(err)
+
+	// unknown fields are omitted
+	i = info.NewGrpcAccessInfo(s.ctx, s.serverinfo, nil)
+	s.Require().NoError(json.Unmarshal([]byte(formatter.FormatJSON(i)), &fields))
+	fields = make(map[string]any)
+	s.Require().NoError(json.Unmarshal([]byte(formatter.FormatJSON(i)), &fields))
+	s.NotContains(fields, "database_name")
+	s.NotContains(fields, "time_cost_ms")
+	s.Contains(fields, "method_name")
+}
+
 func (s *LogFormatterSuite) TestParseConfigKeyFailed() {
 	configKey := ".testf.invalidSub"
 	_, _, err := parseConfigKey(configKey)
@@ -17,6 +17,8 @@
 package accesslog
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -124,6 +126,35 @@ func (f *Formatter) Format(i info.AccessInfo) string {
 	return fmt.Sprintf(f.fmt, fieldValues...)
 }
 
+// FormatJSON encodes the fields of the formatter as a JSON object line,
+// the unknown fields are omitted.
+func (f *Formatter) FormatJSON(i info.AccessInfo) string {
+	fieldValues := info.GetTyped(i, f.fields...)
+	written := make(map[string]struct{}, len(f.fields))
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for id, field := range f.fields {
+		if _, ok := written[field]; ok || fieldValues[id] == nil {
+			continue
+		}
+		value, err := json.Marshal(fieldValues[id])
+		if err != nil {
+			continue
+		}
+		if len(written) > 0 {
+			buf.WriteByte(',')
+		}
+		written[field] = struct{}{}
+		name, _ := json.Marshal(info.FieldName(field))
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
 func parseConfigKey(k string) (string, string, error) {
 	fields := strings.Split(k, ".")
 	if len(fields) != 2 || (fields[1] != fomaterkey && fields[1] != methodKey) {
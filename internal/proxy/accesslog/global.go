@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	configEvent "github.com/milvus-io/milvus/pkg/v3/config"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
@@ -36,10 +37,20 @@ var (
 	once     sync.Once
 )
 
+const (
+	EncodingText = "text"
+	EncodingJSON = "json"
+
+	SinkFile   = "file"
+	SinkSyslog = "syslog"
+	SinkOTLP   = "otlp"
+)
+
 type AccessLogger struct {
 	enable     atomic.Bool
 	writer     io.Writer
 	formatters *FormatterManger
+	encoding   string
 	mu         sync.RWMutex
 }
 
@@ -54,6 +65,12 @@ func (l *AccessLogger) init(params *paramtable.ComponentParam) error {
 	}
 	l.formatters = formatters
 
+	encoding := params.ProxyCfg.AccessLog.Encoding.GetValue()
+	if encoding != EncodingText && encoding != EncodingJSON {
+		return merr.WrapErrParameterInvalid("text or json", encoding, "invalid access log encoding")
+	}
+	l.encoding = encoding
+
 	writer, err := initWriter(&params.ProxyCfg.AccessLog, &params.MinioCfg)
 	if err != nil {
 		return err
@@ -84,8 +101,7 @@ func (l *AccessLogger) Update(enable bool) error {
 	if !enable {
 		if l.enable.Load() != enable {
 			mlog.Info(context.TODO(), "start close access log")
-			if write, ok := l.writer.(*RotateWriter); ok {
-				write.Close()
+			if closeWriter(l.writer) {
 				l.writer = nil
 			}
 			l.enable.Store(enable)
@@ -95,9 +111,7 @@ func (l *AccessLogger) Update(enable bool) error {
 
 	// close old writer before re-init
 	if l.writer != nil {
-		if rw, ok := l.writer.(*RotateWriter); ok {
-			rw.Close()
-		}
+		closeWriter(l.writer)
 	}
 
 	// update access log params
@@ -126,7 +140,13 @@ func (l *AccessLogger) Write(info info.AccessInfo) bool {
 	if !ok {
 		return false
 	}
-	_, err := l.writer.Write([]byte(formatter.Format(info)))
+	var line string
+	if l.encoding == EncodingJSON {
+		line = formatter.FormatJSON(info)
+	} else {
+		line = formatter.Format(info)
+	}
+	_, err := l.writer.Write([]byte(line))
 	if err != nil {
 		mlog.Warn(context.TODO(), "write access log failed", mlog.Err(err))
 		return false
@@ -185,6 +205,16 @@ func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error)
 
 // initAccessLogger initializes a zap access logger for proxy
 func initWriter(logCfg *paramtable.AccessLogConfig, minioCfg *paramtable.MinioConfig) (io.Writer, error) {
+	switch sink := logCfg.Sink.GetValue(); sink {
+	case SinkFile:
+	case SinkSyslog:
+		return NewSyslogWriter(logCfg)
+	case SinkOTLP:
+		return NewOTLPWriter(logCfg)
+	default:
+		return nil, merr.WrapErrParameterInvalid("file, syslog or otlp", sink, "invalid access log sink")
+	}
+
 	if len(logCfg.Filename.GetValue()) > 0 {
 		lg, err := NewRotateWriter(logCfg, minioCfg)
 		if err != nil {
@@ -206,3 +236,20 @@ func initWriter(logCfg *paramtable.AccessLogConfig, minioCfg *paramtable.MinioCo
 
 	return os.Stdout, nil
 }
+
+// closeWriter closes the writer created by initWriter, returns false if the writer need not be closed, such as stdout.
+func closeWriter(writer io.Writer) bool {
+	switch w := writer.(type) {
+	case *RotateWriter:
+		w.Close()
+	case *CacheWriter:
+		w.Close()
+	case *SyslogWriter:
+		w.Close()
+	case *OTLPWriter:
+		w.Close()
+	default:
+		return false
+	}
+	return true
+}
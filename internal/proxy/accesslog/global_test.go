@@ -18,8 +18,10 @@ package accesslog
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -302,3 +304,60 @@ func TestAccessLogger_WithMinio(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(logfiles))
 }
+
+func TestAccessLogger_InvalidEncodingOrSink(t *testing.T) {
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.Enable.Key, "true")
+	params.Save(params.ProxyCfg.AccessLog.Filename.Key, "")
+
+	params.Save(params.ProxyCfg.AccessLog.Encoding.Key, "xml")
+	logger := NewAccessLogger()
+	assert.Error(t, logger.Init(&params))
+
+	params.Save(params.ProxyCfg.AccessLog.Encoding.Key, EncodingJSON)
+	params.Save(params.ProxyCfg.AccessLog.Sink.Key, "kafka")
+	logger = NewAccessLogger()
+	assert.Error(t, logger.Init(&params))
+}
+
+func TestAccessLogger_JSONToSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.Enable.Key, "true")
+	params.Save(params.ProxyCfg.AccessLog.Encoding.Key, EncodingJSON)
+	params.Save(params.ProxyCfg.AccessLog.Sink.Key, SinkSyslog)
+	params.Save(params.ProxyCfg.AccessLog.SyslogAddress.Key, conn.LocalAddr().String())
+
+	logger := NewAccessLogger()
+	require.NoError(t, logger.Init(&params))
+	defer closeWriter(logger.writer)
+
+	req := &milvuspb.QueryRequest{
+		DbName:         "test-db",
+		CollectionName: "test-collection",
+	}
+	rpcInfo := &grpc.UnaryServerInfo{Server: nil, FullMethod: "testMethod"}
+	accessInfo := info.NewGrpcAccessInfo(context.Background(), rpcInfo, req)
+	accessInfo.SetResult(&milvuspb.QueryResults{Status: &commonpb.Status{}}, nil)
+	assert.True(t, logger.Write(accessInfo))
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	msg := string(buf[:n])
+	idx := strings.Index(msg, " ACCESS - ")
+	require.True(t, idx > 0, msg)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal([]byte(msg[idx+len(" ACCESS - "):]), &fields))
+	assert.Equal(t, "testMethod", fields["method_name"])
+	assert.Equal(t, "Successful", fields["method_status"])
+	assert.EqualValues(t, 0, fields["error_code"])
+	assert.IsType(t, float64(0), fields["time_cost_ms"])
+}
@@ -34,7 +34,6 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
-	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/pkg/v3/util/logutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
@@ -91,29 +90,51 @@ func (i *GrpcAccessInfo) SetResult(resp interface{}, err error) {
 	}
 }
 
-func (i *GrpcAccessInfo) TimeCost() string {
+func (i *GrpcAccessInfo) request() any {
+	return i.req
+}
+
+func (i *GrpcAccessInfo) latency() (time.Duration, bool) {
 	if i.end.IsZero() {
+		return 0, false
+	}
+	return i.end.Sub(i.start), true
+}
+
+func (i *GrpcAccessInfo) TimeCost() string {
+	d, ok := i.latency()
+	if !ok {
 		return Unknown
 	}
-	return fmt.Sprint(i.end.Sub(i.start))
+	return fmt.Sprint(d)
 }
 
 func (i *GrpcAccessInfo) TimeNow() string {
 	return time.Now().Format(timeFormat)
 }
 
+func (i *GrpcAccessInfo) startAt() (time.Time, bool) {
+	return i.start, !i.start.IsZero()
+}
+
 func (i *GrpcAccessInfo) TimeStart() string {
-	if i.start.IsZero() {
+	start, ok := i.startAt()
+	if !ok {
 		return Unknown
 	}
-	return i.start.Format(timeFormat)
+	return start.Format(timeFormat)
+}
+
+func (i *GrpcAccessInfo) endAt() (time.Time, bool) {
+	return i.end, !i.end.IsZero()
 }
 
 func (i *GrpcAccessInfo) TimeEnd() string {
-	if i.end.IsZero() {
+	end, ok := i.endAt()
+	if !ok {
 		return Unknown
 	}
-	return i.end.Format(timeFormat)
+	return end.Format(timeFormat)
 }
 
 func (i *GrpcAccessInfo) MethodName() string {
@@ -171,17 +192,22 @@ type SizeResponse interface {
 	XXX_Size() int
 }
 
-func (i *GrpcAccessInfo) ResponseSize() string {
-	var size int
+func (i *GrpcAccessInfo) responseBytes() (int64, bool) {
 	switch r := i.resp.(type) {
 	case SizeResponse:
-		size = r.XXX_Size()
+		return int64(r.XXX_Size()), true
 	case proto.Message:
-		size = proto.Size(r)
+		return int64(proto.Size(r)), true
 	default:
-		return Unknown
+		return 0, false
 	}
+}
 
+func (i *GrpcAccessInfo) ResponseSize() string {
+	size, ok := i.responseBytes()
+	if !ok {
+		return Unknown
+	}
 	return fmt.Sprint(size)
 }
 
@@ -189,12 +215,17 @@ type BaseResponse interface {
 	GetStatus() *commonpb.Status
 }
 
-func (i *GrpcAccessInfo) ErrorCode() string {
+func (i *GrpcAccessInfo) returnCode() (int64, bool) {
 	if i.status != nil {
-		return fmt.Sprint(i.status.GetCode())
+		return int64(i.status.GetCode()), true
 	}
 
-	return fmt.Sprint(merr.Code(i.err))
+	return int64(merr.Code(i.err)), true
+}
+
+func (i *GrpcAccessInfo) ErrorCode() string {
+	code, _ := i.returnCode()
+	return fmt.Sprint(code)
 }
 
 func (i *GrpcAccessInfo) respStatus() *commonpb.Status {
@@ -246,41 +277,11 @@ func (i *GrpcAccessInfo) DbName() string {
 }
 
 func (i *GrpcAccessInfo) CollectionName() string {
-	name, ok := requestutil.GetCollectionNameFromRequest(i.req)
-	if ok {
-		return name.(string)
-	}
-
-	// requests such as Flush/ShowCollections carry a list of collection names
-	names, ok := requestutil.GetCollectionNamesFromRequest(i.req)
-	if ok {
-		return fmt.Sprint(names.([]string))
-	}
-
-	// requests that reference collections via non-standard fields
-	switch req := i.req.(type) {
-	case *milvuspb.RenameCollectionRequest:
-		// rename references both the source and target collection
-		return fmt.Sprintf("%s->%s", req.GetOldName(), req.GetNewName())
-	case *milvuspb.BatchDescribeCollectionRequest:
-		return fmt.Sprint(req.GetCollectionName())
-	}
-
-	return Unknown
+	return formatNames(collectionNameOf(i.req))
 }
 
 func (i *GrpcAccessInfo) PartitionName() string {
-	name, ok := requestutil.GetPartitionNameFromRequest(i.req)
-	if ok {
-		return name.(string)
-	}
-
-	names, ok := requestutil.GetPartitionNamesFromRequest(i.req)
-	if ok {
-		return fmt.Sprint(names.([]string))
-	}
-
-	return Unknown
+	return formatNames(partitionNameOf(i.req))
 }
 
 func (i *GrpcAccessInfo) Expression() string {
@@ -314,11 +315,7 @@ func (i *GrpcAccessInfo) SdkVersion() string {
 }
 
 func (i *GrpcAccessInfo) OutputFields() string {
-	fields, ok := requestutil.GetOutputFieldsFromRequest(i.req)
-	if ok {
-		return fmt.Sprint(fields.([]string))
-	}
-	return Unknown
+	return formatNames(outputFieldsOf(i.req))
 }
 
 func (i *GrpcAccessInfo) ConsistencyLevel() string {
@@ -334,55 +331,38 @@ func (i *GrpcAccessInfo) ConsistencyLevel() string {
 }
 
 func (i *GrpcAccessInfo) AnnsField() string {
-	if req, ok := i.req.(*milvuspb.SearchRequest); ok {
-		return getAnnsFieldFromKvs(req.GetSearchParams())
-	}
-
-	if req, ok := i.req.(*milvuspb.HybridSearchRequest); ok {
-		fields := lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return getAnnsFieldFromKvs(req.GetSearchParams()) })
-		return listToString(fields)
-	}
-	return Unknown
+	return formatAnnsField(annsFieldOf(i.req))
 }
 
 func (i *GrpcAccessInfo) NQ() string {
-	if req, ok := i.req.(*milvuspb.SearchRequest); ok {
-		return fmt.Sprint(req.GetNq())
-	}
-
-	if req, ok := i.req.(*milvuspb.HybridSearchRequest); ok {
-		return listToString(lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return fmt.Sprint(req.GetNq()) }))
-	}
-	return Unknown
+	return formatNQ(nqOf(i.req))
 }
 
 func (i *GrpcAccessInfo) SearchParams() string {
-	if req, ok := i.req.(*milvuspb.SearchRequest); ok {
-		return kvsToString(req.GetSearchParams())
-	}
-
-	if req, ok := i.req.(*milvuspb.HybridSearchRequest); ok {
-		return listToString(lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return kvsToString(req.GetSearchParams()) }))
-	}
-	return Unknown
+	return formatParams(searchParamsOf(i.req))
 }
 
 func (i *GrpcAccessInfo) QueryParams() string {
-	if req, ok := i.req.(*milvuspb.QueryRequest); ok {
-		return kvsToString(req.GetQueryParams())
+	return formatParams(queryParamsOf(i.req))
+}
+
+func (i *GrpcAccessInfo) clientRequestAt() (time.Time, bool) {
+	unixmsec, ok := logutil.GetClientReqUnixmsecGrpc(i.ctx)
+	if !ok {
+		return time.Time{}, false
 	}
-	return Unknown
+	return time.UnixMilli(unixmsec), true
 }
 
 // ClientRequestTime returns client-side request time string
 // this attribute is passed via grpc metadata
 func (i *GrpcAccessInfo) ClientRequestTime() string {
-	unixmsec, ok := logutil.GetClientReqUnixmsecGrpc(i.ctx)
+	t, ok := i.clientRequestAt()
 	if !ok {
 		return Unknown
 	}
 
-	return time.UnixMilli(unixmsec).Format(timeFormat)
+	return t.Format(timeFormat)
 }
 
 func (i *GrpcAccessInfo) SetActualConsistencyLevel(acl commonpb.ConsistencyLevel) {
@@ -390,22 +370,9 @@ func (i *GrpcAccessInfo) SetActualConsistencyLevel(acl commonpb.ConsistencyLevel
 }
 
 func (i *GrpcAccessInfo) TemplateValueLength() string {
-	templateValues, ok := requestutil.GetExprTemplateValues(i.req)
-	if !ok {
-		return NotAny
-	}
-
-	// get length only
-	m := lo.MapValues(templateValues, func(tv *schemapb.TemplateValue, _ string) int {
-		return getLengthFromTemplateValue(tv)
-	})
-
-	return fmt.Sprint(m)
+	return formatOrNotAny(templateValueLengthOf(i.req))
 }
 
 func (i *GrpcAccessInfo) PartialUpdate() string {
-	if req, ok := i.req.(*milvuspb.UpsertRequest); ok {
-		return fmt.Sprint(req.GetPartialUpdate())
-	}
-	return NotAny
+	return formatOrNotAny(partialUpdateOf(i.req))
 }
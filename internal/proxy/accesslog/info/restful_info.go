@@ -28,7 +28,6 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
-	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/requestutil"
@@ -72,19 +71,33 @@ func (i *RestfulInfo) InitReq() {
 	i.req = req
 }
 
+func (i *RestfulInfo) request() any {
+	return i.req
+}
+
+func (i *RestfulInfo) latency() (time.Duration, bool) {
+	return i.params.Latency, true
+}
+
 func (i *RestfulInfo) TimeCost() string {
-	return fmt.Sprint(i.params.Latency)
+	d, _ := i.latency()
+	return fmt.Sprint(d)
 }
 
 func (i *RestfulInfo) TimeNow() string {
 	return time.Now().Format(timeFormat)
 }
 
+func (i *RestfulInfo) startAt() (time.Time, bool) {
+	return i.start, !i.start.IsZero()
+}
+
 func (i *RestfulInfo) TimeStart() string {
-	if i.start.IsZero() {
+	start, ok := i.startAt()
+	if !ok {
 		return Unknown
 	}
-	return i.start.Format(timeFormat)
+	return start.Format(timeFormat)
 }
 
 // Start returns the request-entry timestamp captured by the access middleware.
@@ -94,8 +107,13 @@ func (i *RestfulInfo) Start() time.Time {
 	return i.start
 }
 
+func (i *RestfulInfo) endAt() (time.Time, bool) {
+	return i.params.TimeStamp, true
+}
+
 func (i *RestfulInfo) TimeEnd() string {
-	return i.params.TimeStamp.Format(timeFormat)
+	end, _ := i.endAt()
+	return end.Format(timeFormat)
 }
 
 func (i *RestfulInfo) MethodName() string {
@@ -145,11 +163,36 @@ func (i *RestfulInfo) UserName() string {
 	return username.(string)
 }
 
+func (i *RestfulInfo) responseBytes() (int64, bool) {
+	return int64(i.params.BodySize), true
+}
+
 func (i *RestfulInfo) ResponseSize() string {
-	return fmt.Sprint(i.params.BodySize)
+	size, _ := i.responseBytes()
+	return fmt.Sprint(size)
+}
+
+func (i *RestfulInfo) returnCode() (int64, bool) {
+	code, ok := i.ctx.Get(ContextReturnCode)
+	if !ok {
+		return 0, false
+	}
+	switch c := code.(type) {
+	case int32:
+		return int64(c), true
+	case int:
+		return int64(c), true
+	case int64:
+		return c, true
+	default:
+		return 0, false
+	}
 }
 
 func (i *RestfulInfo) ErrorCode() string {
+	if code, ok := i.returnCode(); ok {
+		return fmt.Sprint(code)
+	}
 	code, ok := i.ctx.Get(ContextReturnCode)
 	if !ok {
 		return Unknown
@@ -195,41 +238,11 @@ func (i *RestfulInfo) DbName() string {
 }
 
 func (i *RestfulInfo) CollectionName() string {
-	name, ok := requestutil.GetCollectionNameFromRequest(i.req)
-	if ok {
-		return name.(string)
-	}
-
-	// requests such as Flush/ShowCollections carry a list of collection names
-	names, ok := requestutil.GetCollectionNamesFromRequest(i.req)
-	if ok {
-		return fmt.Sprint(names.([]string))
-	}
-
-	// requests that reference collections via non-standard fields
-	switch req := i.req.(type) {
-	case *milvuspb.RenameCollectionRequest:
-		// rename references both the source and target collection
-		return fmt.Sprintf("%s->%s", req.GetOldName(), req.GetNewName())
-	case *milvuspb.BatchDescribeCollectionRequest:
-		return fmt.Sprint(req.GetCollectionName())
-	}
-
-	return Unknown
+	return formatNames(collectionNameOf(i.req))
 }
 
 func (i *RestfulInfo) PartitionName() string {
-	name, ok := requestutil.GetPartitionNameFromRequest(i.req)
-	if ok {
-		return name.(string)
-	}
-
-	names, ok := requestutil.GetPartitionNamesFromRequest(i.req)
-	if ok {
-		return fmt.Sprint(names.([]string))
-	}
-
-	return Unknown
+	return formatNames(partitionNameOf(i.req))
 }
 
 func (i *RestfulInfo) Expression() string {
@@ -250,11 +263,7 @@ func (i *RestfulInfo) Expression() string {
 }
 
 func (i *RestfulInfo) OutputFields() string {
-	fields, ok := requestutil.GetOutputFieldsFromRequest(i.req)
-	if ok {
-		return fmt.Sprint(fields.([]string))
-	}
-	return Unknown
+	return formatNames(outputFieldsOf(i.req))
 }
 
 func (i *RestfulInfo) ConsistencyLevel() string {
@@ -270,60 +279,44 @@ func (i *RestfulInfo) ConsistencyLevel() string {
 }
 
 func (i *RestfulInfo) AnnsField() string {
-	if req, ok := i.req.(*milvuspb.SearchRequest); ok {
-		return getAnnsFieldFromKvs(req.GetSearchParams())
-	}
-
-	if req, ok := i.req.(*milvuspb.HybridSearchRequest); ok {
-		return listToString(lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return getAnnsFieldFromKvs(req.GetSearchParams()) }))
-	}
-	return Unknown
+	return formatAnnsField(annsFieldOf(i.req))
 }
 
 func (i *RestfulInfo) NQ() string {
-	if req, ok := i.req.(*milvuspb.SearchRequest); ok {
-		return fmt.Sprint(req.GetNq())
-	}
-
-	if req, ok := i.req.(*milvuspb.HybridSearchRequest); ok {
-		return listToString(lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return fmt.Sprint(req.GetNq()) }))
-	}
-	return Unknown
+	return formatNQ(nqOf(i.req))
 }
 
 func (i *RestfulInfo) SearchParams() string {
-	if req, ok := i.req.(*milvuspb.SearchRequest); ok {
-		return kvsToString(req.GetSearchParams())
-	}
-
-	if req, ok := i.req.(*milvuspb.HybridSearchRequest); ok {
-		return listToString(lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return kvsToString(req.GetSearchParams()) }))
-	}
-	return Unknown
+	return formatParams(searchParamsOf(i.req))
 }
 
 func (i *RestfulInfo) QueryParams() string {
-	if req, ok := i.req.(*milvuspb.QueryRequest); ok {
-		return kvsToString(req.GetQueryParams())
-	}
-	return Unknown
+	return formatParams(queryParamsOf(i.req))
 }
 
-// ClientRequestTime returns client-side request time string.
-// REST clients pass it via the same key as gRPC metadata, but as an HTTP header.
-func (i *RestfulInfo) ClientRequestTime() string {
+func (i *RestfulInfo) clientRequestAt() (time.Time, bool) {
 	if i.ctx == nil || i.ctx.Request == nil {
-		return Unknown
+		return time.Time{}, false
 	}
 	timestamp := i.ctx.GetHeader(common.ClientRequestMsecKey)
 	if timestamp == "" {
-		return Unknown
+		return time.Time{}, false
 	}
 	unixmsec, err := strconv.ParseInt(timestamp, 10, 64)
 	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(unixmsec), true
+}
+
+// ClientRequestTime returns client-side request time string.
+// REST clients pass it via the same key as gRPC metadata, but as an HTTP header.
+func (i *RestfulInfo) ClientRequestTime() string {
+	t, ok := i.clientRequestAt()
+	if !ok {
 		return Unknown
 	}
-	return time.UnixMilli(unixmsec).Format(timeFormat)
+	return t.Format(timeFormat)
 }
 
 func (i *RestfulInfo) SetActualConsistencyLevel(acl commonpb.ConsistencyLevel) {
@@ -331,22 +324,9 @@ func (i *RestfulInfo) SetActualConsistencyLevel(acl commonpb.ConsistencyLevel) {
 }
 
 func (i *RestfulInfo) TemplateValueLength() string {
-	templateValues, ok := requestutil.GetExprTemplateValues(i.req)
-	if !ok {
-		return NotAny
-	}
-
-	// get length only
-	m := lo.MapValues(templateValues, func(tv *schemapb.TemplateValue, _ string) int {
-		return getLengthFromTemplateValue(tv)
-	})
-
-	return fmt.Sprint(m)
+	return formatOrNotAny(templateValueLengthOf(i.req))
 }
 
 func (i *RestfulInfo) PartialUpdate() string {
-	if req, ok := i.req.(*milvuspb.UpsertRequest); ok {
-		return fmt.Sprint(req.GetPartialUpdate())
-	}
-	return NotAny
+	return formatOrNotAny(partialUpdateOf(i.req))
 }
@@ -0,0 +1,310 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/requestutil"
+)
+
+// typedAccessInfo is the access info providing the typed values of the metrics,
+// the text metrics are formatted from the same values.
+type typedAccessInfo interface {
+	AccessInfo
+	request() any
+	latency() (time.Duration, bool)
+	startAt() (time.Time, bool)
+	endAt() (time.Time, bool)
+	clientRequestAt() (time.Time, bool)
+	responseBytes() (int64, bool)
+	returnCode() (int64, bool)
+}
+
+var (
+	_ typedAccessInfo = (*GrpcAccessInfo)(nil)
+	_ typedAccessInfo = (*RestfulInfo)(nil)
+)
+
+// getTypedMetricFunc returns the typed value of the metric, nil if it's unknown or not applicable.
+type getTypedMetricFunc func(i typedAccessInfo) any
+
+// typed metrics, metrics not listed are kept as string
+var typedMetricFuncMap = map[string]getTypedMetricFunc{
+	"$time_cost":             getTypedTimeCost,
+	"$time_now":              getTypedTimeNow,
+	"$time_start":            typedTime(typedAccessInfo.startAt),
+	"$time_end":              typedTime(typedAccessInfo.endAt),
+	"$client_request_time":   typedTime(typedAccessInfo.clientRequestAt),
+	"$response_size":         typedInt(typedAccessInfo.responseBytes),
+	"$error_code":            typedInt(typedAccessInfo.returnCode),
+	"$nq":                    typedRequest(nqOf),
+	"$partial_update":        typedRequest(partialUpdateOf),
+	"$search_params":         typedRequest(searchParamsOf),
+	"$query_params":          typedRequest(queryParamsOf),
+	"$anns_field":            typedRequest(annsFieldOf),
+	"$collection_name":       typedRequest(collectionNameOf),
+	"$partition_name":        typedRequest(partitionNameOf),
+	"$output_fields":         typedRequest(outputFieldsOf),
+	"$template_value_length": typedRequest(templateValueLengthOf),
+}
+
+// field names which are different from the metric names without "$"
+var typedFieldNameMap = map[string]string{
+	"$time_cost": "time_cost_ms",
+}
+
+// FieldName returns the field name of the metric in structured logs.
+func FieldName(metric string) string {
+	if name, ok := typedFieldNameMap[metric]; ok {
+		return name
+	}
+	return strings.TrimPrefix(metric, "$")
+}
+
+// GetTyped returns the typed values of the metrics for structured logs,
+// the value is nil if the metric is unknown or not applicable.
+func GetTyped(i AccessInfo, keys ...string) []any {
+	result := make([]any, 0, len(keys))
+	ti, typed := i.(typedAccessInfo)
+	for _, key := range keys {
+		if getFunc, ok := typedMetricFuncMap[key]; ok && typed {
+			result = append(result, getFunc(ti))
+			continue
+		}
+		getFunc, ok := MetricFuncMap[key]
+		if !ok {
+			result = append(result, nil)
+			continue
+		}
+		value := getFunc(i)
+		if value == Unknown || value == NotAny || value == "" {
+			result = append(result, nil)
+			continue
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+func getTypedTimeCost(i typedAccessInfo) any {
+	d, ok := i.latency()
+	if !ok {
+		return nil
+	}
+	return float64(d) / float64(time.Millisecond)
+}
+
+func getTypedTimeNow(i typedAccessInfo) any {
+	return time.Now().Format(time.RFC3339Nano)
+}
+
+func typedTime(get func(typedAccessInfo) (time.Time, bool)) getTypedMetricFunc {
+	return func(i typedAccessInfo) any {
+		t, ok := get(i)
+		if !ok {
+			return nil
+		}
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+func typedInt(get func(typedAccessInfo) (int64, bool)) getTypedMetricFunc {
+	return func(i typedAccessInfo) any {
+		v, ok := get(i)
+		if !ok {
+			return nil
+		}
+		return v
+	}
+}
+
+func typedRequest(get func(req any) any) getTypedMetricFunc {
+	return func(i typedAccessInfo) any {
+		return get(i.request())
+	}
+}
+
+// The metrics of the request below are shared by the text and typed formatters,
+// the value is nil if the request doesn't carry the metric.
+
+// collectionNameOf returns the collection name, or the list of collection names of the request.
+func collectionNameOf(req any) any {
+	if name, ok := requestutil.GetCollectionNameFromRequest(req); ok {
+		return name.(string)
+	}
+	// requests such as Flush/ShowCollections carry a list of collection names
+	if names, ok := requestutil.GetCollectionNamesFromRequest(req); ok {
+		return names.([]string)
+	}
+	// requests that reference collections via non-standard fields
+	switch req := req.(type) {
+	case *milvuspb.RenameCollectionRequest:
+		// rename references both the source and target collection
+		return fmt.Sprintf("%s->%s", req.GetOldName(), req.GetNewName())
+	case *milvuspb.BatchDescribeCollectionRequest:
+		return req.GetCollectionName()
+	}
+	return nil
+}
+
+// partitionNameOf returns the partition name, or the list of partition names of the request.
+func partitionNameOf(req any) any {
+	if name, ok := requestutil.GetPartitionNameFromRequest(req); ok {
+		return name.(string)
+	}
+	if names, ok := requestutil.GetPartitionNamesFromRequest(req); ok {
+		return names.([]string)
+	}
+	return nil
+}
+
+func outputFieldsOf(req any) any {
+	if fields, ok := requestutil.GetOutputFieldsFromRequest(req); ok {
+		return fields.([]string)
+	}
+	return nil
+}
+
+// annsFieldOf returns the anns field of search, or the list of anns fields of hybrid search.
+func annsFieldOf(req any) any {
+	switch req := req.(type) {
+	case *milvuspb.SearchRequest:
+		return getAnnsFieldFromKvs(req.GetSearchParams())
+	case *milvuspb.HybridSearchRequest:
+		return lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) string { return getAnnsFieldFromKvs(req.GetSearchParams()) })
+	}
+	return nil
+}
+
+// nqOf returns the nq of search, or the list of nq of hybrid search.
+func nqOf(req any) any {
+	switch req := req.(type) {
+	case *milvuspb.SearchRequest:
+		return req.GetNq()
+	case *milvuspb.HybridSearchRequest:
+		return lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) int64 { return req.GetNq() })
+	}
+	return nil
+}
+
+// searchParamsOf returns the search params of search, or the list of search params of hybrid search.
+func searchParamsOf(req any) any {
+	switch req := req.(type) {
+	case *milvuspb.SearchRequest:
+		return kvsToMap(req.GetSearchParams())
+	case *milvuspb.HybridSearchRequest:
+		return lo.Map(req.GetRequests(), func(req *milvuspb.SearchRequest, _ int) map[string]string { return kvsToMap(req.GetSearchParams()) })
+	}
+	return nil
+}
+
+func queryParamsOf(req any) any {
+	if req, ok := req.(*milvuspb.QueryRequest); ok {
+		return kvsToMap(req.GetQueryParams())
+	}
+	return nil
+}
+
+// templateValueLengthOf returns the length of each expression template value.
+func templateValueLengthOf(req any) any {
+	templateValues, ok := requestutil.GetExprTemplateValues(req)
+	if !ok {
+		return nil
+	}
+	// get length only
+	return lo.MapValues(templateValues, func(tv *schemapb.TemplateValue, _ string) int {
+		return getLengthFromTemplateValue(tv)
+	})
+}
+
+func partialUpdateOf(req any) any {
+	if req, ok := req.(*milvuspb.UpsertRequest); ok {
+		return req.GetPartialUpdate()
+	}
+	return nil
+}
+
+func kvsToMap(kvs []*commonpb.KeyValuePair) map[string]string {
+	m := make(map[string]string)
+	for _, kv := range kvs {
+		m[kv.GetKey()] = kv.GetValue()
+	}
+	return m
+}
+
+// The text formatters of the request metrics.
+
+// formatNames formats the name, or the list of names by fmt.Sprint.
+func formatNames(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return Unknown
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatAnnsField(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return Unknown
+	case []string:
+		return listToString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatNQ(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return Unknown
+	case []int64:
+		return listToString(lo.Map(v, func(nq int64, _ int) string { return fmt.Sprint(nq) }))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatParams(value any) string {
+	switch v := value.(type) {
+	case map[string]string:
+		return mapToString(v)
+	case []map[string]string:
+		return listToString(lo.Map(v, func(m map[string]string, _ int) string { return mapToString(m) }))
+	default:
+		return Unknown
+	}
+}
+
+// formatOrNotAny formats the metric only applicable to some requests.
+func formatOrNotAny(value any) string {
+	if value == nil {
+		return NotAny
+	}
+	return fmt.Sprint(value)
+}
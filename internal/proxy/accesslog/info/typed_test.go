@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestGetTyped(t *testing.T) {
+	params := []*commonpb.KeyValuePair{{Key: "anns_field", Value: "vec"}, {Key: "topk", Value: "10"}}
+	i := NewGrpcAccessInfo(context.Background(), &grpc.UnaryServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/HybridSearch"}, &milvuspb.HybridSearchRequest{
+		CollectionName: "coll",
+		PartitionNames: []string{"p1", "p2"},
+		OutputFields:   []string{"a b", "c"},
+		Requests: []*milvuspb.SearchRequest{
+			{Nq: 1, SearchParams: params},
+			{Nq: 2, SearchParams: params},
+		},
+	})
+	keys := []string{"$time_cost", "$time_start", "$nq", "$anns_field", "$search_params", "$collection_name", "$partition_name", "$output_fields", "$partial_update", "$method_name", "$unknown"}
+
+	// not finished yet.
+	values := GetTyped(i, keys...)
+	assert.Nil(t, values[0])
+
+	i.SetResult(&milvuspb.SearchResults{Status: merr.Status(nil)}, nil)
+	values = GetTyped(i, keys...)
+	assert.IsType(t, float64(0), values[0])
+	_, err := time.Parse(time.RFC3339Nano, values[1].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, values[2])
+	assert.Equal(t, []string{"vec", "vec"}, values[3])
+	assert.Equal(t, []map[string]string{{"anns_field": "vec", "topk": "10"}, {"anns_field": "vec", "topk": "10"}}, values[4])
+	assert.Equal(t, "coll", values[5])
+	assert.Equal(t, []string{"p1", "p2"}, values[6])
+	// the names containing spaces are kept.
+	assert.Equal(t, []string{"a b", "c"}, values[7])
+	assert.Nil(t, values[8])
+	assert.Equal(t, "HybridSearch", values[9])
+	assert.Nil(t, values[10])
+
+	// the text metrics are formatted from the typed values.
+	assert.Equal(t, `["1", "2"]`, i.NQ())
+	assert.Equal(t, `["vec", "vec"]`, i.AnnsField())
+	assert.Equal(t, "[p1 p2]", i.PartitionName())
+
+	i = NewGrpcAccessInfo(context.Background(), &grpc.UnaryServerInfo{}, &milvuspb.UpsertRequest{PartialUpdate: true})
+	i.SetResult(nil, merr.ErrCollectionNotFound)
+	values = GetTyped(i, "$partial_update", "$template_value_length", "$error_code", "$response_size", "$nq")
+	assert.Equal(t, true, values[0])
+	assert.Nil(t, values[1])
+	assert.Equal(t, int64(merr.Code(merr.ErrCollectionNotFound)), values[2])
+	assert.Nil(t, values[3])
+	assert.Nil(t, values[4])
+
+	i = NewGrpcAccessInfo(context.Background(), &grpc.UnaryServerInfo{}, &milvuspb.DeleteRequest{
+		ExprTemplateValues: map[string]*schemapb.TemplateValue{
+			"a": {Val: &schemapb.TemplateValue_Int64Val{Int64Val: 1}},
+		},
+	})
+	assert.Equal(t, map[string]int{"a": 1}, GetTyped(i, "$template_value_length")[0])
+	assert.Equal(t, "map[a:1]", i.TemplateValueLength())
+}
+
+func TestFieldName(t *testing.T) {
+	assert.Equal(t, "time_cost_ms", FieldName("$time_cost"))
+	assert.Equal(t, "method_name", FieldName("$method_name"))
+}
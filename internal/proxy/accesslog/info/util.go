@@ -143,12 +143,7 @@ func listToString(strs []string) string {
 	return b.String()
 }
 
-func kvsToString(kvs []*commonpb.KeyValuePair) string {
-	m := make(map[string]string)
-	for _, kv := range kvs {
-		m[kv.GetKey()] = kv.GetValue()
-	}
-
+func mapToString(m map[string]string) string {
 	v, err := json.Marshal(m)
 	if err != nil {
 		return Unknown
@@ -156,3 +151,7 @@ func kvsToString(kvs []*commonpb.KeyValuePair) string {
 
 	return string(v)
 }
+
+func kvsToString(kvs []*commonpb.KeyValuePair) string {
+	return mapToString(kvsToMap(kvs))
+}
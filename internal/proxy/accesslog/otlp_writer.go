@@ -0,0 +1,218 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	otlpServiceName   = "milvus-proxy"
+	otlpScopeName     = "milvus.accesslog"
+	otlpExportTimeout = 10 * time.Second
+	// max number of batches buffered before dropping new logs
+	otlpMaxBufferedBatches = 16
+)
+
+// OTLPWriter exports each access log line as a log record to the OTLP gRPC endpoint.
+// Records are buffered and exported in batches in the background.
+type OTLPWriter struct {
+	conn     *grpc.ClientConn
+	client   collogspb.LogsServiceClient
+	resource *resourcepb.Resource
+
+	batchSize     int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	records []*logspb.LogRecord
+	closed  bool
+
+	flushCh   chan struct{}
+	closeCh   chan struct{}
+	closeWg   sync.WaitGroup
+	closeOnce sync.Once
+}
+
+func NewOTLPWriter(logCfg *paramtable.AccessLogConfig) (*OTLPWriter, error) {
+	creds := insecure.NewCredentials()
+	if !logCfg.OTLPInsecure.GetAsBool() {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	endpoint := logCfg.OTLPEndpoint.GetValue()
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidErr(err, "invalid otlp endpoint %s", endpoint)
+	}
+
+	batchSize := logCfg.OTLPBatchSize.GetAsInt()
+	if batchSize <= 0 {
+		conn.Close()
+		return nil, merr.WrapErrParameterInvalidMsg("invalid otlp batch size %d", batchSize)
+	}
+
+	hostname, _ := os.Hostname()
+	w := &OTLPWriter{
+		conn:   conn,
+		client: collogspb.NewLogsServiceClient(conn),
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				stringAttribute("service.name", otlpServiceName),
+				stringAttribute("host.name", hostname),
+			},
+		},
+		batchSize:     batchSize,
+		flushInterval: logCfg.OTLPFlushInterval.GetAsDuration(time.Second),
+		flushCh:       make(chan struct{}, 1),
+		closeCh:       make(chan struct{}),
+	}
+	w.start()
+	mlog.Info(context.TODO(), "Access log export to otlp endpoint", mlog.String("endpoint", endpoint))
+	return w, nil
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// Write buffers p as a log record, it fails if the buffer is full because the endpoint is too slow.
+func (w *OTLPWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, merr.WrapErrParameterInvalidMsg("write to closed writer")
+	}
+	if len(w.records) >= w.batchSize*otlpMaxBufferedBatches {
+		return 0, merr.WrapErrServiceQuotaExceeded("otlp access log buffer is full")
+	}
+
+	now := uint64(time.Now().UnixNano())
+	w.records = append(w.records, &logspb.LogRecord{
+		TimeUnixNano:         now,
+		ObservedTimeUnixNano: now,
+		SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		SeverityText:         "INFO",
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(bytes.TrimRight(p, "\n"))}},
+	})
+	if len(w.records) >= w.batchSize {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Flush exports all the buffered records.
+func (w *OTLPWriter) Flush() error {
+	for {
+		w.mu.Lock()
+		size := min(len(w.records), w.batchSize)
+		batch := w.records[:size]
+		w.records = w.records[size:]
+		w.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := w.export(batch); err != nil {
+			return err
+		}
+	}
+}
+
+func (w *OTLPWriter) export(records []*logspb.LogRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+
+	_, err := w.client.Export(ctx, &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: w.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: otlpScopeName},
+				LogRecords: records,
+			}},
+		}},
+	})
+	if err != nil {
+		mlog.Warn(context.TODO(), "export access log to otlp endpoint failed", mlog.Int("records", len(records)), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (w *OTLPWriter) start() {
+	w.closeWg.Add(1)
+	go func() {
+		defer w.closeWg.Done()
+		var tickerCh <-chan time.Time
+		if w.flushInterval > 0 {
+			ticker := time.NewTicker(w.flushInterval)
+			defer ticker.Stop()
+			tickerCh = ticker.C
+		}
+
+		for {
+			select {
+			case <-tickerCh:
+				w.Flush()
+			case <-w.flushCh:
+				w.Flush()
+			case <-w.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (w *OTLPWriter) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+		w.closeWg.Wait()
+
+		w.mu.Lock()
+		w.closed = true
+		w.mu.Unlock()
+
+		// export remaining records
+		w.Flush()
+		err = w.conn.Close()
+	})
+	return err
+}
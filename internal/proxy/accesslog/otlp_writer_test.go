@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type mockLogsService struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu      sync.Mutex
+	exports int
+	bodies  []string
+}
+
+func (s *mockLogsService) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exports++
+	for _, rl := range req.GetResourceLogs() {
+		for _, sl := range rl.GetScopeLogs() {
+			for _, record := range sl.GetLogRecords() {
+				s.bodies = append(s.bodies, record.GetBody().GetStringValue())
+			}
+		}
+	}
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (s *mockLogsService) received() ([]string, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.bodies...), s.exports
+}
+
+func startMockLogsService(t *testing.T) (*mockLogsService, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	service := &mockLogsService{}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, service)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return service, lis.Addr().String()
+}
+
+func TestOTLPWriter_Batch(t *testing.T) {
+	service, addr := startMockLogsService(t)
+
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.OTLPEndpoint.Key, addr)
+	params.Save(params.ProxyCfg.AccessLog.OTLPBatchSize.Key, "2")
+	params.Save(params.ProxyCfg.AccessLog.OTLPFlushInterval.Key, "0")
+
+	w, err := NewOTLPWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+	defer w.Close()
+
+	for _, line := range []string{"log-1\n", "log-2\n"} {
+		_, err = w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	// full batch is exported in background
+	assert.Eventually(t, func() bool {
+		bodies, _ := service.received()
+		return len(bodies) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// remaining records are exported when close
+	_, err = w.Write([]byte("log-3\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	bodies, exports := service.received()
+	assert.Equal(t, []string{"log-1", "log-2", "log-3"}, bodies)
+	assert.Equal(t, 2, exports)
+
+	_, err = w.Write([]byte("log-4\n"))
+	assert.Error(t, err)
+}
+
+func TestOTLPWriter_BufferFull(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	lis.Close()
+
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.OTLPEndpoint.Key, addr)
+	params.Save(params.ProxyCfg.AccessLog.OTLPBatchSize.Key, "1")
+	params.Save(params.ProxyCfg.AccessLog.OTLPFlushInterval.Key, "0")
+
+	w, err := NewOTLPWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+	// keep the background flush from draining the buffer
+	w.mu.Lock()
+	for i := 0; i < otlpMaxBufferedBatches; i++ {
+		w.records = append(w.records, nil)
+	}
+	w.mu.Unlock()
+
+	_, err = w.Write([]byte("log\n"))
+	assert.Error(t, err)
+
+	w.mu.Lock()
+	w.records = nil
+	w.mu.Unlock()
+	w.Close()
+}
+
+func TestOTLPWriter_InvalidConfig(t *testing.T) {
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.OTLPBatchSize.Key, "0")
+
+	_, err := NewOTLPWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+}
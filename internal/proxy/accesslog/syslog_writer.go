@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	syslogSeverityInfo = 6
+	syslogMsgID        = "ACCESS"
+	syslogTimeFormat   = "2006-01-02T15:04:05.000000Z07:00"
+	syslogDialTimeout  = 5 * time.Second
+	// max length of the header fields defined by RFC5424
+	syslogMaxHostname = 255
+	syslogMaxAppName  = 48
+)
+
+// SyslogWriter sends each access log line as a RFC5424 message to the syslog server.
+// Messages over tcp are framed by octet counting (RFC6587).
+type SyslogWriter struct {
+	network  string
+	address  string
+	priority int
+	hostname string
+	appName  string
+	procID   string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+func NewSyslogWriter(logCfg *paramtable.AccessLogConfig) (*SyslogWriter, error) {
+	network := logCfg.SyslogNetwork.GetValue()
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+	default:
+		return nil, merr.WrapErrParameterInvalid("udp, tcp or unixgram", network, "invalid syslog network")
+	}
+	facility := logCfg.SyslogFacility.GetAsInt()
+	if facility < 0 || facility > 23 {
+		return nil, merr.WrapErrParameterInvalidRange(0, 23, facility, "invalid syslog facility")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = ""
+	}
+	w := &SyslogWriter{
+		network:  network,
+		address:  logCfg.SyslogAddress.GetValue(),
+		priority: facility*8 + syslogSeverityInfo,
+		hostname: syslogHeaderField(hostname, syslogMaxHostname),
+		appName:  syslogHeaderField(logCfg.SyslogAppName.GetValue(), syslogMaxAppName),
+		procID:   fmt.Sprint(os.Getpid()),
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	mlog.Info(context.TODO(), "Access log send to syslog", mlog.String("network", network), mlog.String("address", w.address))
+	return w, nil
+}
+
+// syslogHeaderField returns the printable header field without space, or NILVALUE if empty.
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func (w *SyslogWriter) connect() error {
+	conn, err := net.DialTimeout(w.network, w.address, syslogDialTimeout)
+	if err != nil {
+		return merr.WrapErrIoFailed(w.address, err)
+	}
+	w.conn = conn
+	return nil
+}
+
+func (w *SyslogWriter) format(now time.Time, p []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %s %s - ", w.priority, now.Format(syslogTimeFormat), w.hostname, w.appName, w.procID, syslogMsgID)
+	buf.Write(bytes.TrimRight(p, "\n"))
+
+	if strings.HasPrefix(w.network, "tcp") || w.network == "unix" {
+		framed := make([]byte, 0, buf.Len()+8)
+		framed = fmt.Appendf(framed, "%d ", buf.Len())
+		return append(framed, buf.Bytes()...)
+	}
+	return buf.Bytes()
+}
+
+// Write sends p as a syslog message, it reconnects once if the connection is broken.
+func (w *SyslogWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, merr.WrapErrParameterInvalidMsg("write to closed writer")
+	}
+
+	msg := w.format(time.Now(), p)
+	if w.conn != nil {
+		if _, err = w.conn.Write(msg); err == nil {
+			return len(p), nil
+		}
+		w.conn.Close()
+		w.conn = nil
+	}
+
+	if err := w.connect(); err != nil {
+		return 0, err
+	}
+	if _, err := w.conn.Write(msg); err != nil {
+		return 0, merr.WrapErrIoFailed(w.address, err)
+	}
+	return len(p), nil
+}
+
+func (w *SyslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
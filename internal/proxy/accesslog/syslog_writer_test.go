@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestSyslogWriter_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.SyslogNetwork.Key, "udp")
+	params.Save(params.ProxyCfg.AccessLog.SyslogAddress.Key, conn.LocalAddr().String())
+	params.Save(params.ProxyCfg.AccessLog.SyslogFacility.Key, "16")
+	params.Save(params.ProxyCfg.AccessLog.SyslogAppName.Key, "milvus test")
+
+	w, err := NewSyslogWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("test access log\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	msg := string(buf[:n])
+	assert.True(t, strings.HasPrefix(msg, "<134>1 "), msg)
+	assert.Contains(t, msg, " milvus_test ")
+	assert.True(t, strings.HasSuffix(msg, " ACCESS - test access log"), msg)
+
+	w.Close()
+	_, err = w.Write([]byte("test access log\n"))
+	assert.Error(t, err)
+}
+
+func TestSyslogWriter_TCP(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AccessLog.SyslogNetwork.Key, "tcp")
+	params.Save(params.ProxyCfg.AccessLog.SyslogAddress.Key, lis.Addr().String())
+
+	w, err := NewSyslogWriter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+	defer w.Close()
+
+	conn, err := lis.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, line := range []string{"first log\n", "second log\n"} {
+		_, err = w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	// messages are framed by octet counting
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	for _, expected := range []string{"first log", "second log"} {
+		length, err := reader.ReadString(' ')
+		require.NoError(t, err)
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		require.NoError(t, err)
+
+		msg := make([]byte, n)
+		_, err = io.ReadFull(reader, msg)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(msg), "<134>1 "), string(msg))
+		assert.True(t, strings.HasSuffix(string(msg), " - "+expected), string(msg))
+	}
+}
+
+func TestSyslogWriter_InvalidConfig(t *testing.T) {
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+
+	params.Save(params.ProxyCfg.AccessLog.SyslogNetwork.Key, "http")
+	_, err := NewSyslogWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+
+	params.Save(params.ProxyCfg.AccessLog.SyslogNetwork.Key, "udp")
+	params.Save(params.ProxyCfg.AccessLog.SyslogFacility.Key, "24")
+	_, err = NewSyslogWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+
+	// dial tcp failed
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	lis.Close()
+	params.Save(params.ProxyCfg.AccessLog.SyslogFacility.Key, "16")
+	params.Save(params.ProxyCfg.AccessLog.SyslogNetwork.Key, "tcp")
+	params.Save(params.ProxyCfg.AccessLog.SyslogAddress.Key, addr)
+	_, err = NewSyslogWriter(&params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+}
@@ -42,6 +42,14 @@ func (reducer *MilvusAggReducer) Reduce(results []*internalpb.RetrieveResults) (
 		indices := reducer.outputMap.IndexesAt(i)
 		if len(indices) == 0 {
 			return nil, merr.WrapErrParameterInvalidMsg("no indices found for output field at index %d", i)
+		} else if len(indices) == 1 && reducer.outputMap.IsStateAt(i) {
+			// Single index of state aggregation (e.g. count distinct, percentile): finalize the reduced states
+			finalizedFieldData, err := reducer.outputMap.FinalizeStateAt(i, reducedFieldDatas[indices[0]])
+			if err != nil {
+				return nil, merr.Wrapf(err, "failed to finalize aggregation for field %s", reducer.outputMap.NameAt(i))
+			}
+			finalizedFieldData.FieldName = reducer.outputMap.NameAt(i)
+			reOrganizedFieldDatas[i] = finalizedFieldData
 		} else if len(indices) == 1 {
 			// Single index: direct copy (non-avg aggregation or group-by field)
 			reOrganizedFieldDatas[i] = reducedFieldDatas[indices[0]]
@@ -0,0 +1,463 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message/adaptor"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/options"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// changeStreamCheckpointInterval is the interval to send the checkpoint without any events,
+// so the checkpoint keeps advancing even if no change matches the subscription.
+var changeStreamCheckpointInterval = 5 * time.Second
+
+// changeStreamPosition is the position of a vchannel in the checkpoint.
+// The subscription resumes from the last confirmed message and skips the messages not after the time tick.
+type changeStreamPosition struct {
+	MessageID string `json:"message_id"`
+	WALName   int32  `json:"wal_name"`
+	TimeTick  uint64 `json:"time_tick"`
+}
+
+// changeStreamCheckpoint is the opaque checkpoint token of change stream, the base64 encoded json of vchannel positions.
+type changeStreamCheckpoint map[string]*changeStreamPosition
+
+func (c changeStreamCheckpoint) Encode() string {
+	bs, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+func decodeChangeStreamCheckpoint(token string) (changeStreamCheckpoint, error) {
+	checkpoint := make(changeStreamCheckpoint)
+	if token == "" {
+		return checkpoint, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid checkpoint: %s", err.Error())
+	}
+	if err := json.Unmarshal(bs, &checkpoint); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid checkpoint: %s", err.Error())
+	}
+	return checkpoint, nil
+}
+
+// deliverPolicy returns the deliver policy and filters of the vchannel to resume from the checkpoint.
+func (c changeStreamCheckpoint) deliverPolicy(vchannel string, startPosition changestreampb.StartPosition) (options.DeliverPolicy, []options.DeliverFilter, error) {
+	position, ok := c[vchannel]
+	if !ok {
+		if startPosition == changestreampb.StartPosition_Earliest {
+			return options.DeliverPolicyAll(), nil, nil
+		}
+		return options.DeliverPolicyLatest(), nil, nil
+	}
+	msgID, err := message.UnmarshalMessageID(&commonpb.MessageID{Id: position.MessageID, WALName: commonpb.WALName(position.WALName)})
+	if err != nil {
+		return nil, nil, merr.WrapErrParameterInvalidMsg("invalid checkpoint of vchannel %s: %s", vchannel, err.Error())
+	}
+	return options.DeliverPolicyStartFrom(msgID), []options.DeliverFilter{options.DeliverFilterTimeTickGT(position.TimeTick)}, nil
+}
+
+func (c changeStreamCheckpoint) advance(vchannel string, msg message.ImmutableMessage) {
+	msgID := msg.LastConfirmedMessageID().IntoProto()
+	c[vchannel] = &changeStreamPosition{
+		MessageID: msgID.GetId(),
+		WALName:   int32(msgID.GetWALName()),
+		TimeTick:  msg.TimeTick(),
+	}
+}
+
+// changeStreamDecoder decodes the insert and delete messages of a collection into change events.
+type changeStreamDecoder struct {
+	collectionID int64
+	pkField      *schemapb.FieldSchema
+	// partitionIDs is nil if all partitions are subscribed.
+	partitionIDs typeutil.Set[int64]
+	// outputFieldIDs is nil if all fields are output.
+	outputFieldIDs typeutil.Set[int64]
+	filter         *exprutil.RowFilter
+}
+
+// Decode returns the change events of the message, the message in a transaction is decoded one by one.
+func (d *changeStreamDecoder) Decode(msg message.ImmutableMessage) ([]*changestreampb.ChangeEvent, error) {
+	if txnMsg, ok := msg.(message.ImmutableTxnMessage); ok {
+		var events []*changestreampb.ChangeEvent
+		err := txnMsg.RangeOver(func(msg message.ImmutableMessage) error {
+			evts, err := d.Decode(msg)
+			events = append(events, evts...)
+			return err
+		})
+		return events, err
+	}
+
+	switch msg.MessageType() {
+	case message.MessageTypeInsert:
+		insertMsg, err := message.AsImmutableInsertMessageV1(msg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := insertMsg.Body()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeInsert(msg, body)
+	case message.MessageTypeDelete:
+		// the deletes of upsert are covered by the upsert events,
+		// or the delete events of the upserted rows dropped by the filter.
+		if msg.Properties().Exist(message.PropertyUpsert) {
+			return nil, nil
+		}
+		deleteMsg, err := message.AsImmutableDeleteMessageV1(msg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := deleteMsg.Body()
+		if err != nil {
+			return nil, err
+		}
+		if body.GetCollectionID() != d.collectionID || !d.matchPartition(body.GetPartitionID()) || body.GetNumRows() == 0 {
+			return nil, nil
+		}
+		return []*changestreampb.ChangeEvent{{
+			Type:          changestreampb.ChangeType_Delete,
+			Vchannel:      msg.VChannel(),
+			PartitionName: body.GetPartitionName(),
+			Timestamp:     msg.TimeTick(),
+			NumRows:       body.GetNumRows(),
+			PrimaryKeys:   body.GetPrimaryKeys(),
+		}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (d *changeStreamDecoder) matchPartition(partitionID int64) bool {
+	return d.partitionIDs == nil || partitionID == common.AllPartitionsID || d.partitionIDs.Contain(partitionID)
+}
+
+// decodeInsert returns the insert or upsert event of the message. The upserted rows dropped by the filter
+// no longer match the subscription, so they are delivered as a delete event instead.
+func (d *changeStreamDecoder) decodeInsert(msg message.ImmutableMessage, body *msgpb.InsertRequest) ([]*changestreampb.ChangeEvent, error) {
+	if body.GetCollectionID() != d.collectionID || !d.matchPartition(body.GetPartitionID()) {
+		return nil, nil
+	}
+	numRows := int(body.GetNumRows())
+	fieldsData := body.GetFieldsData()
+	if d.outputFieldIDs != nil {
+		fieldsData = make([]*schemapb.FieldData, 0, len(d.outputFieldIDs))
+		for _, fieldData := range body.GetFieldsData() {
+			if d.outputFieldIDs.Contain(fieldData.GetFieldId()) {
+				fieldsData = append(fieldsData, fieldData)
+			}
+		}
+	}
+	pkFieldData, err := typeutil.GetPrimaryFieldData(body.GetFieldsData(), d.pkField)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := parsePrimaryFieldData2IDs(pkFieldData)
+	if err != nil {
+		return nil, err
+	}
+
+	isUpsert := msg.Properties().Exist(message.PropertyUpsert)
+	var events []*changestreampb.ChangeEvent
+	if d.filter != nil {
+		offsets := d.filter.Filter(body.GetFieldsData(), numRows)
+		if len(offsets) < numRows {
+			if isUpsert {
+				events = append(events, newDroppedUpsertEvent(msg, body, ids, offsets))
+			}
+			if len(offsets) == 0 {
+				return events, nil
+			}
+			filteredData := make([]*schemapb.FieldData, len(fieldsData))
+			filteredIDs := &schemapb.IDs{}
+			for _, offset := range offsets {
+				typeutil.AppendFieldData(filteredData, fieldsData, int64(offset))
+				typeutil.AppendIDs(filteredIDs, ids, offset)
+			}
+			fieldsData, ids, numRows = filteredData, filteredIDs, len(offsets)
+		}
+	}
+
+	changeType := changestreampb.ChangeType_Insert
+	if isUpsert {
+		changeType = changestreampb.ChangeType_Upsert
+	}
+	return append(events, &changestreampb.ChangeEvent{
+		Type:          changeType,
+		Vchannel:      msg.VChannel(),
+		PartitionName: body.GetPartitionName(),
+		Timestamp:     msg.TimeTick(),
+		NumRows:       int64(numRows),
+		FieldsData:    fieldsData,
+		PrimaryKeys:   ids,
+	}), nil
+}
+
+// newDroppedUpsertEvent returns the delete event of the upserted rows not at the kept offsets,
+// the old versions of these rows may have matched the subscription and are replaced by the upsert.
+func newDroppedUpsertEvent(msg message.ImmutableMessage, body *msgpb.InsertRequest, ids *schemapb.IDs, offsets []int) *changestreampb.ChangeEvent {
+	kept := typeutil.NewSet(offsets...)
+	droppedIDs := &schemapb.IDs{}
+	numRows := 0
+	for i := 0; i < int(body.GetNumRows()); i++ {
+		if !kept.Contain(i) {
+			typeutil.AppendIDs(droppedIDs, ids, i)
+			numRows++
+		}
+	}
+	return &changestreampb.ChangeEvent{
+		Type:          changestreampb.ChangeType_Delete,
+		Vchannel:      msg.VChannel(),
+		PartitionName: body.GetPartitionName(),
+		Timestamp:     msg.TimeTick(),
+		NumRows:       int64(numRows),
+		PrimaryKeys:   droppedIDs,
+	}
+}
+
+// newChangeStreamDecoder creates the decoder of the subscription, it's denied if the current user is restricted by row policies.
+func newChangeStreamDecoder(ctx context.Context, req *changestreampb.SubscribeChangesRequest, collectionID int64, schema *schemaInfo) (*changeStreamDecoder, error) {
+	// the delete messages only carry the primary keys, which can't be checked against the row policy,
+	// so the users restricted by row policies can't subscribe the changes, or the deleted keys of others leak.
+	policy, err := getRowPolicy(ctx, req.GetDbName(), req.GetCollectionName(), collectionID, schema.schemaHelper)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		return nil, merr.WrapErrPrivilegeNotPermitted("subscribing the changes of collection %s is not permitted under row policies", req.GetCollectionName())
+	}
+
+	decoder := &changeStreamDecoder{
+		collectionID: collectionID,
+		pkField:      schema.pkField,
+	}
+
+	if len(req.GetPartitionNames()) > 0 {
+		if schema.hasPartitionKeyField {
+			return nil, merr.WrapErrParameterInvalidMsg("not support manually specifying the partition names if partition key mode is used")
+		}
+		decoder.partitionIDs = typeutil.NewSet[int64]()
+		for _, partitionName := range req.GetPartitionNames() {
+			partitionID, err := globalMetaCache.GetPartitionID(ctx, req.GetDbName(), req.GetCollectionName(), partitionName)
+			if err != nil {
+				return nil, err
+			}
+			decoder.partitionIDs.Insert(partitionID)
+		}
+	}
+
+	outputFields := req.GetOutputFields()
+	if len(outputFields) > 0 && !(len(outputFields) == 1 && outputFields[0] == "*") {
+		decoder.outputFieldIDs = typeutil.NewSet[int64]()
+		for _, name := range outputFields {
+			field, err := schema.schemaHelper.GetFieldFromName(name)
+			if err != nil {
+				return nil, merr.WrapErrFieldNotFound(name)
+			}
+			decoder.outputFieldIDs.Insert(field.GetFieldID())
+		}
+	}
+
+	if req.GetFilter() != "" {
+		expr, err := planparserv2.ParseExpr(schema.schemaHelper, req.GetFilter(), nil)
+		if err != nil {
+			return nil, merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("failed to parse filter: %s", err.Error()))
+		}
+		filter, err := exprutil.NewRowFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		decoder.filter = filter
+	}
+	return decoder, nil
+}
+
+// changeStreamMessage is the message read from a vchannel of the subscribed collection.
+type changeStreamMessage struct {
+	vchannel string
+	msg      message.ImmutableMessage
+}
+
+// SubscribeChanges streams the decoded insert, upsert and delete events of one collection.
+// Every vchannel of the collection is read from the WAL concurrently, each response carries the events
+// decoded from one WAL message together with the checkpoint after it, so the events of one vchannel are
+// in order but the events of different vchannels may interleave. The subscription resumes from
+// a checkpoint at least once, the events after the checkpoint are never lost.
+// The filter is only applied to the inserted and upserted rows, deletes carry the primary keys only
+// and are always delivered. The upserted rows dropped by the filter are delivered as deletes.
+func (node *Proxy) SubscribeChanges(req *changestreampb.SubscribeChangesRequest, stream changestreampb.ChangeStreamService_SubscribeChangesServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-SubscribeChanges")
+	defer sp.End()
+
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return err
+	}
+	// the stream rpc is not covered by the unary interceptors, authenticate and check the privilege here.
+	ctx, err := AuthenticationInterceptor(ctx)
+	if err != nil {
+		return err
+	}
+	if req.GetDbName() == "" {
+		req.DbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	ctx, err = PrivilegeInterceptor(ctx, &milvuspb.QueryRequest{DbName: req.GetDbName(), CollectionName: req.GetCollectionName()})
+	if err != nil {
+		return err
+	}
+
+	logger := mlog.With(
+		mlog.String("db", req.GetDbName()),
+		mlog.FieldCollectionName(req.GetCollectionName()),
+		mlog.Strings("partitions", req.GetPartitionNames()),
+		mlog.String("filter", req.GetFilter()),
+	)
+
+	if err := validateCollectionName(req.GetCollectionName()); err != nil {
+		return err
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return err
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, req.GetDbName(), req.GetCollectionName())
+	if err != nil {
+		return err
+	}
+	decoder, err := newChangeStreamDecoder(ctx, req, collectionID, schema)
+	if err != nil {
+		return err
+	}
+	checkpoint, err := decodeChangeStreamCheckpoint(req.GetCheckpoint())
+	if err != nil {
+		return err
+	}
+	vchannels, err := node.chMgr.getVChannels(collectionID)
+	if err != nil {
+		return err
+	}
+
+	msgCh := make(chan changeStreamMessage, len(vchannels))
+	errCh := make(chan error, len(vchannels))
+	for _, vchannel := range vchannels {
+		deliverPolicy, deliverFilters, err := checkpoint.deliverPolicy(vchannel, req.GetStartPosition())
+		if err != nil {
+			return err
+		}
+		handler := make(adaptor.ChanMessageHandler, 16)
+		scanner := streaming.WAL().Read(ctx, streaming.ReadOption{
+			VChannel:       vchannel,
+			DeliverPolicy:  deliverPolicy,
+			DeliverFilters: deliverFilters,
+			MessageHandler: handler,
+		})
+		defer scanner.Close()
+		go func(vchannel string) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-scanner.Done():
+					err := scanner.Error()
+					if err == nil {
+						err = merr.WrapErrServiceUnavailableMsg("scanner of vchannel %s is closed", vchannel)
+					}
+					errCh <- err
+					return
+				case msg := <-handler:
+					select {
+					case msgCh <- changeStreamMessage{vchannel: vchannel, msg: msg}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(vchannel)
+	}
+	logger.Info(ctx, "SubscribeChanges started", mlog.Strings("vchannels", vchannels))
+
+	ticker := time.NewTicker(changeStreamCheckpointInterval)
+	defer ticker.Stop()
+	eventCount := 0
+	advanced := false
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info(ctx, "SubscribeChanges context canceled", mlog.Int("eventCount", eventCount))
+			return ctx.Err()
+		case err := <-errCh:
+			logger.Warn(ctx, "SubscribeChanges scanner error", mlog.Err(err), mlog.Int("eventCount", eventCount))
+			return err
+		case <-ticker.C:
+			if !advanced {
+				continue
+			}
+			if err := stream.Send(&changestreampb.SubscribeChangesResponse{
+				Status:     merr.Success(),
+				Checkpoint: checkpoint.Encode(),
+			}); err != nil {
+				return err
+			}
+			advanced = false
+		case m := <-msgCh:
+			events, err := decoder.Decode(m.msg)
+			if err != nil {
+				logger.Warn(ctx, "SubscribeChanges decode message failed", mlog.Err(err),
+					mlog.String("vchannel", m.vchannel), mlog.Stringer("messageID", m.msg.MessageID()))
+				return err
+			}
+			checkpoint.advance(m.vchannel, m.msg)
+			advanced = true
+			if len(events) == 0 {
+				continue
+			}
+			if err := stream.Send(&changestreampb.SubscribeChangesResponse{
+				Status:     merr.Success(),
+				Events:     events,
+				Checkpoint: checkpoint.Encode(),
+			}); err != nil {
+				return err
+			}
+			eventCount += len(events)
+			advanced = false
+		}
+	}
+}
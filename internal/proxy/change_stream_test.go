@@ -0,0 +1,194 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestChangeStreamCheckpoint(t *testing.T) {
+	checkpoint, err := decodeChangeStreamCheckpoint("")
+	require.NoError(t, err)
+	assert.Empty(t, checkpoint)
+
+	policy, filters, err := checkpoint.deliverPolicy("v0", changestreampb.StartPosition_Latest)
+	require.NoError(t, err)
+	assert.Empty(t, filters)
+	_, ok := policy.GetPolicy().(*streamingpb.DeliverPolicy_Latest)
+	assert.True(t, ok)
+	policy, _, err = checkpoint.deliverPolicy("v0", changestreampb.StartPosition_Earliest)
+	require.NoError(t, err)
+	_, ok = policy.GetPolicy().(*streamingpb.DeliverPolicy_All)
+	assert.True(t, ok)
+
+	checkpoint.advance("v0", buildTestImmutableMessageWithID(testPulsarMessageID(1), 100))
+	decoded, err := decodeChangeStreamCheckpoint(checkpoint.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint, decoded)
+	assert.EqualValues(t, 100, decoded["v0"].TimeTick)
+
+	policy, filters, err = decoded.deliverPolicy("v0", changestreampb.StartPosition_Latest)
+	require.NoError(t, err)
+	assert.Len(t, filters, 1)
+	_, ok = policy.GetPolicy().(*streamingpb.DeliverPolicy_StartFrom)
+	assert.True(t, ok)
+
+	_, err = decodeChangeStreamCheckpoint("invalid token")
+	assert.Error(t, err)
+	_, err = decodeChangeStreamCheckpoint("e30")
+	assert.NoError(t, err)
+}
+
+func TestChangeStreamDecoder(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int64},
+		},
+	}
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	require.NoError(t, err)
+	filter, err := exprutil.NewRowFilter(mustParseExpr(t, helper, "age > 15"))
+	require.NoError(t, err)
+
+	decoder := &changeStreamDecoder{
+		collectionID:   1,
+		pkField:        schema.Fields[0],
+		outputFieldIDs: typeutil.NewSet[int64](101),
+		filter:         filter,
+	}
+
+	insertBody := &msgpb.InsertRequest{
+		CollectionID:  1,
+		PartitionID:   10,
+		PartitionName: "_default",
+		NumRows:       3,
+		FieldsData: []*schemapb.FieldData{
+			{
+				FieldId: 100, FieldName: "pk", Type: schemapb.DataType_Int64,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2, 3}}}}},
+			},
+			{
+				FieldId: 101, FieldName: "age", Type: schemapb.DataType_Int64,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{10, 20, 30}}}}},
+			},
+		},
+	}
+	buildInsert := func(properties map[string]string) message.ImmutableMessage {
+		return message.NewInsertMessageBuilderV1().
+			WithHeader(&message.InsertMessageHeader{CollectionId: 1}).
+			WithBody(insertBody).
+			WithVChannel("v0").
+			WithProperties(properties).
+			MustBuildMutable().
+			WithTimeTick(100).
+			WithLastConfirmed(testPulsarMessageID(1)).
+			IntoImmutableMessage(testPulsarMessageID(1))
+	}
+	buildDelete := func(properties map[string]string) message.ImmutableMessage {
+		return message.NewDeleteMessageBuilderV1().
+			WithHeader(&message.DeleteMessageHeader{CollectionId: 1}).
+			WithBody(&msgpb.DeleteRequest{
+				CollectionID: 1,
+				PartitionID:  10,
+				NumRows:      1,
+				PrimaryKeys:  &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1}}}},
+			}).
+			WithVChannel("v0").
+			WithProperties(properties).
+			MustBuildMutable().
+			WithTimeTick(101).
+			WithLastConfirmed(testPulsarMessageID(2)).
+			IntoImmutableMessage(testPulsarMessageID(2))
+	}
+
+	events, err := decoder.Decode(buildInsert(nil))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, changestreampb.ChangeType_Insert, events[0].GetType())
+	assert.EqualValues(t, 2, events[0].GetNumRows())
+	assert.Equal(t, []int64{2, 3}, events[0].GetPrimaryKeys().GetIntId().GetData())
+	require.Len(t, events[0].GetFieldsData(), 1)
+	assert.Equal(t, []int64{20, 30}, events[0].GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	assert.EqualValues(t, 100, events[0].GetTimestamp())
+
+	// the upserted rows dropped by the filter are deleted
+	events, err = decoder.Decode(buildInsert(upsertMessageProperties))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, changestreampb.ChangeType_Delete, events[0].GetType())
+	assert.EqualValues(t, 1, events[0].GetNumRows())
+	assert.Equal(t, []int64{1}, events[0].GetPrimaryKeys().GetIntId().GetData())
+	assert.EqualValues(t, 100, events[0].GetTimestamp())
+	assert.Equal(t, changestreampb.ChangeType_Upsert, events[1].GetType())
+	assert.Equal(t, []int64{2, 3}, events[1].GetPrimaryKeys().GetIntId().GetData())
+
+	// all the upserted rows are dropped by the filter
+	decoder.filter, err = exprutil.NewRowFilter(mustParseExpr(t, helper, "age > 100"))
+	require.NoError(t, err)
+	events, err = decoder.Decode(buildInsert(upsertMessageProperties))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, changestreampb.ChangeType_Delete, events[0].GetType())
+	assert.Equal(t, []int64{1, 2, 3}, events[0].GetPrimaryKeys().GetIntId().GetData())
+	events, err = decoder.Decode(buildInsert(nil))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	decoder.filter = filter
+
+	events, err = decoder.Decode(buildDelete(nil))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, changestreampb.ChangeType_Delete, events[0].GetType())
+	assert.Equal(t, []int64{1}, events[0].GetPrimaryKeys().GetIntId().GetData())
+
+	// the deletes of upsert are skipped
+	events, err = decoder.Decode(buildDelete(upsertMessageProperties))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// not subscribed partition
+	decoder.partitionIDs = typeutil.NewSet[int64](11)
+	events, err = decoder.Decode(buildInsert(nil))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// other messages are ignored
+	events, err = decoder.Decode(buildTestImmutableMessage(102))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func mustParseExpr(t *testing.T, helper *typeutil.SchemaHelper, exprStr string) *planpb.Expr {
+	expr, err := planparserv2.ParseExpr(helper, exprStr, nil)
+	require.NoError(t, err)
+	return expr
+}
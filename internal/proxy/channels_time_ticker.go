@@ -28,6 +28,9 @@ import (
 // ticker can update ts only when the minTs are greater than the ts of ticker, we can use maxTs to update current later
 type getPChanStatisticsFuncType func() (map[pChan]*pChanStatistics, error)
 
+// dmlTickListenerFuncType is notified with the max timestamp of the DML tasks observed on pchan.
+type dmlTickListenerFuncType func(pchan pChan, ts Timestamp)
+
 // channelsTimeTicker manages the timestamp statistics
 type channelsTimeTicker interface {
 	// start starts the channels time ticker.
@@ -58,6 +61,9 @@ type channelsTimeTickerImpl struct {
 	cancel            context.CancelFunc
 	defaultTimestamp  Timestamp
 	minTimestamp      Timestamp
+
+	dmlTickListener dmlTickListenerFuncType
+	dmlTicks        map[pChan]Timestamp // pchan -> max Timestamp of DML notified, only accessed by tick loop
 }
 
 func (ticker *channelsTimeTickerImpl) getMinTsStatistics() (map[pChan]Timestamp, Timestamp, error) {
@@ -100,6 +106,7 @@ func (ticker *channelsTimeTickerImpl) tick() error {
 		mlog.Warn(ticker.ctx, "failed to get tt statistics", mlog.Err(err))
 		return nil
 	}
+	ticker.notifyDMLTicks(stats)
 
 	ticker.statisticsMtx.Lock()
 	defer ticker.statisticsMtx.Unlock()
@@ -150,6 +157,24 @@ func (ticker *channelsTimeTickerImpl) tick() error {
 	return nil
 }
 
+// notifyDMLTicks notifies the listener if there are new DML tasks on pchans since last tick.
+func (ticker *channelsTimeTickerImpl) notifyDMLTicks(stats map[pChan]*pChanStatistics) {
+	if ticker.dmlTickListener == nil {
+		return
+	}
+	for pchan, stat := range stats {
+		if stat.maxTs > ticker.dmlTicks[pchan] {
+			ticker.dmlTicks[pchan] = stat.maxTs
+			ticker.dmlTickListener(pchan, stat.maxTs)
+		}
+	}
+}
+
+// setDMLTickListener sets the listener of DML ticks, it should be called before start.
+func (ticker *channelsTimeTickerImpl) setDMLTickListener(listener dmlTickListenerFuncType) {
+	ticker.dmlTickListener = listener
+}
+
 func (ticker *channelsTimeTickerImpl) tickLoop() {
 	defer ticker.wg.Done()
 
@@ -225,6 +250,7 @@ func newChannelsTimeTicker(
 		getStatisticsFunc: getStatisticsFunc,
 		tso:               tso,
 		currents:          make(map[pChan]Timestamp),
+		dmlTicks:          make(map[pChan]Timestamp),
 		ctx:               ctx1,
 		cancel:            cancel,
 	}
@@ -235,3 +235,32 @@ func TestChannelsTimeTickerImpl_getMinTick(t *testing.T) {
 
 	time.Sleep(100 * time.Millisecond)
 }
+
+func TestChannelsTimeTickerImpl_notifyDMLTicks(t *testing.T) {
+	pchans := []pChan{"pchan1", "pchan2"}
+	stats := map[pChan]*pChanStatistics{
+		"pchan1": {minTs: 100, maxTs: 200},
+	}
+	getStatisticsFunc := func() (map[pChan]*pChanStatistics, error) {
+		return stats, nil
+	}
+	ticker := newChannelsTimeTicker(context.Background(), time.Millisecond*10, pchans, getStatisticsFunc, newMockTsoAllocator())
+
+	notified := make(map[pChan]Timestamp)
+	ticker.setDMLTickListener(func(pchan pChan, ts Timestamp) {
+		notified[pchan] = ts
+	})
+
+	assert.NoError(t, ticker.tick())
+	assert.Equal(t, map[pChan]Timestamp{"pchan1": 200}, notified)
+
+	// no new DML since last tick
+	delete(notified, "pchan1")
+	assert.NoError(t, ticker.tick())
+	assert.Empty(t, notified)
+
+	stats["pchan1"] = &pChanStatistics{minTs: 100, maxTs: 300}
+	stats["pchan2"] = &pChanStatistics{minTs: 250, maxTs: 260}
+	assert.NoError(t, ticker.tick())
+	assert.Equal(t, map[pChan]Timestamp{"pchan1": 300, "pchan2": 260}, notified)
+}
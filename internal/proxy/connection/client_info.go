@@ -10,6 +10,7 @@ import (
 type clientInfo struct {
 	*commonpb.ClientInfo
 	identifier     int64
+	address        string // the peer address of the connection the client registered from
 	lastActiveTime time.Time
 }
 
@@ -17,6 +18,7 @@ func (c *clientInfo) GetLogger() []mlog.Field {
 	fields := ClientInfoFields(c.ClientInfo)
 	fields = append(fields,
 		mlog.Int64("identifier", c.identifier),
+		mlog.String("address", c.address),
 		mlog.Time("last_active_time", c.lastActiveTime),
 	)
 	return fields
@@ -3,12 +3,15 @@ package connection
 import (
 	"container/heap"
 	"context"
+	"fmt"
+	"net"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -21,6 +24,13 @@ type connectionManager struct {
 	wg          sync.WaitGroup
 
 	clientInfos *typeutil.ConcurrentMap[int64, clientInfo]
+
+	mu             sync.Mutex
+	requestID      uint64
+	clientInflight map[string]map[uint64]context.CancelFunc // peer address -> in-flight requests
+	limitInflight  map[clientKey]int64                      // client -> number of in-flight requests
+	userInflight   map[string]int64
+	conns          map[string]net.Conn // peer address -> the connection accepted by the tracked listeners
 }
 
 func (s *connectionManager) init() {
@@ -97,6 +107,7 @@ func (s *connectionManager) Register(ctx context.Context, identifier int64, info
 	cli := clientInfo{
 		ClientInfo:     info,
 		identifier:     identifier,
+		address:        GetPeerAddressFromContext(ctx),
 		lastActiveTime: time.Now(),
 	}
 
@@ -119,6 +130,8 @@ func (s *connectionManager) List() []*commonpb.ClientInfo {
 			}
 			client.Reserved["identifier"] = string(strconv.AppendInt(nil, identifier, 10))
 			client.Reserved["last_active_time"] = info.lastActiveTime.String()
+			client.Reserved["address"] = info.address
+			client.Reserved["inflight_requests"] = strconv.Itoa(s.inflightNum(info.address))
 
 			clients = append(clients, client)
 		}
@@ -160,10 +173,200 @@ func (s *connectionManager) removeLongInactiveClients() {
 	})
 }
 
+// clientKey is the client the in-flight requests are limited by, which is the registered identifier,
+// or the peer host if the client isn't registered, so the connections of a client share its limit.
+type clientKey struct {
+	identifier int64
+	host       string
+}
+
+func (k clientKey) String() string {
+	if k.host != "" {
+		return k.host
+	}
+	return strconv.FormatInt(k.identifier, 10)
+}
+
+// getClientKey returns the client of the request, empty if neither the identifier nor the address is known.
+func (s *connectionManager) getClientKey(ctx context.Context, address string) clientKey {
+	if identifier, err := GetIdentifierFromContext(ctx); err == nil && s.clientInfos.Contain(identifier) {
+		return clientKey{identifier: identifier}
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return clientKey{host: host}
+}
+
+// Acquire registers an in-flight request of the client connection of the peer address and the authenticated user,
+// and rejects it if the number of in-flight requests of the client or user exceeds the limit.
+// The client is the registered identifier of the request, or the peer host if it isn't registered.
+// The returned context is canceled once the client is disconnected, and release must be called after the request is done.
+// Empty address or user means unknown, which is not limited.
+func (s *connectionManager) Acquire(ctx context.Context, address string, user string) (context.Context, func(), error) {
+	clientLimit := paramtable.Get().ProxyCfg.MaxInflightRequestsPerClient.GetAsInt()
+	userLimit := paramtable.Get().ProxyCfg.MaxInflightRequestsPerUser.GetAsInt()
+	var client clientKey
+	if address != "" {
+		client = s.getClientKey(ctx, address)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if address != "" && clientLimit > 0 && s.limitInflight[client] >= int64(clientLimit) {
+		return nil, nil, merr.WrapErrTooManyRequests(int32(clientLimit), fmt.Sprintf("too many in-flight requests of client %s", client))
+	}
+	if user != "" && userLimit > 0 && s.userInflight[user] >= int64(userLimit) {
+		return nil, nil, merr.WrapErrTooManyRequests(int32(userLimit), fmt.Sprintf("too many in-flight requests of user %s", user))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.requestID++
+	requestID := s.requestID
+	if address != "" {
+		requests, ok := s.clientInflight[address]
+		if !ok {
+			requests = make(map[uint64]context.CancelFunc)
+			s.clientInflight[address] = requests
+		}
+		requests[requestID] = cancel
+		s.limitInflight[client]++
+	}
+	if user != "" {
+		s.userInflight[user]++
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			cancel()
+			s.release(address, client, user, requestID)
+		})
+	}
+	return ctx, release, nil
+}
+
+func (s *connectionManager) release(address string, client clientKey, user string, requestID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if requests, ok := s.clientInflight[address]; ok {
+		delete(requests, requestID)
+		if len(requests) == 0 {
+			delete(s.clientInflight, address)
+		}
+		s.limitInflight[client]--
+		if s.limitInflight[client] <= 0 {
+			delete(s.limitInflight, client)
+		}
+	}
+	if user != "" {
+		s.userInflight[user]--
+		if s.userInflight[user] <= 0 {
+			delete(s.userInflight, user)
+		}
+	}
+}
+
+func (s *connectionManager) inflightNum(address string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clientInflight[address])
+}
+
+// Disconnect forcibly disconnects the client registered with the identifier, see DisconnectAddress.
+// Returns false if the client is not found.
+func (s *connectionManager) Disconnect(ctx context.Context, identifier int64) bool {
+	info, registered := s.clientInfos.GetAndRemove(identifier)
+	if !registered {
+		return false
+	}
+	mlog.Info(ctx, "disconnect client", info.GetLogger()...)
+	s.DisconnectAddress(ctx, info.address)
+	return true
+}
+
+// DisconnectAddress forcibly disconnects the client connection of the peer address,
+// its in-flight requests are canceled and the connection is closed, the client has to reconnect.
+// Returns false if there is neither connection nor in-flight request of the address.
+func (s *connectionManager) DisconnectAddress(ctx context.Context, address string) bool {
+	if address == "" {
+		return false
+	}
+	s.mu.Lock()
+	requests, inflight := s.clientInflight[address]
+	for _, cancel := range requests {
+		cancel()
+	}
+	conn, tracked := s.conns[address]
+	s.mu.Unlock()
+
+	if !inflight && !tracked {
+		return false
+	}
+	if tracked {
+		// closing the connection closes the transport and all the streams on it.
+		if err := conn.Close(); err != nil {
+			mlog.Warn(ctx, "failed to close client connection", mlog.String("address", address), mlog.Err(err))
+		}
+	}
+	mlog.Info(ctx, "client disconnected", mlog.String("address", address),
+		mlog.Int("inflight", len(requests)), mlog.Bool("closed", tracked))
+	return true
+}
+
+// TrackListener wraps the listener, so the connections accepted by it could be closed by Disconnect.
+func (s *connectionManager) TrackListener(lis net.Listener) net.Listener {
+	return &trackedListener{Listener: lis, manager: s}
+}
+
+// trackedListener records the accepted connections by their peer addresses.
+type trackedListener struct {
+	net.Listener
+	manager *connectionManager
+}
+
+func (l *trackedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	tracked := &trackedConn{Conn: conn, manager: l.manager, address: conn.RemoteAddr().String()}
+	l.manager.mu.Lock()
+	l.manager.conns[tracked.address] = tracked
+	l.manager.mu.Unlock()
+	return tracked, nil
+}
+
+// trackedConn forgets itself from the connection manager once it's closed.
+type trackedConn struct {
+	net.Conn
+	manager   *connectionManager
+	address   string
+	closeOnce sync.Once
+}
+
+func (c *trackedConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.manager.mu.Lock()
+		if c.manager.conns[c.address] == net.Conn(c) {
+			delete(c.manager.conns, c.address)
+		}
+		c.manager.mu.Unlock()
+	})
+	return c.Conn.Close()
+}
+
 func newConnectionManager() *connectionManager {
 	s := &connectionManager{
-		closeSignal: make(chan struct{}, 1),
-		clientInfos: typeutil.NewConcurrentMap[int64, clientInfo](),
+		closeSignal:    make(chan struct{}, 1),
+		clientInfos:    typeutil.NewConcurrentMap[int64, clientInfo](),
+		clientInflight: make(map[string]map[uint64]context.CancelFunc),
+		limitInflight:  make(map[clientKey]int64),
+		userInflight:   make(map[string]int64),
+		conns:          make(map[string]net.Conn),
 	}
 	s.init()
 
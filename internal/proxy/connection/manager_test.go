@@ -2,12 +2,18 @@ package connection
 
 import (
 	"context"
+	"io"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
@@ -65,3 +71,116 @@ func TestConnectionManager_Purge(t *testing.T) {
 		return s.clientInfos.Len() <= 2
 	}, time.Second*5, time.Second)
 }
+
+func TestConnectionManager_Inflight(t *testing.T) {
+	paramtable.Init()
+
+	pt := paramtable.Get()
+	pt.Save(pt.ProxyCfg.MaxInflightRequestsPerClient.Key, "2")
+	pt.Save(pt.ProxyCfg.MaxInflightRequestsPerUser.Key, "3")
+	defer pt.Reset(pt.ProxyCfg.MaxInflightRequestsPerClient.Key)
+	defer pt.Reset(pt.ProxyCfg.MaxInflightRequestsPerUser.Key)
+	s := newConnectionManager()
+	defer s.Stop()
+
+	address1 := "127.0.0.1:10001"
+	ctx := peer.NewContext(context.TODO(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 10001}})
+	s.Register(ctx, 1, &commonpb.ClientInfo{})
+	clientCtx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs(util.IdentifierKey, "1"))
+	_, release1, err := s.Acquire(clientCtx, address1, "user")
+	assert.NoError(t, err)
+	_, release2, err := s.Acquire(clientCtx, address1, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, address1, s.List()[0].GetReserved()["address"])
+	assert.Equal(t, "2", s.List()[0].GetReserved()["inflight_requests"])
+
+	// exceed the limit of client, which is shared by the connections of the registered client
+	_, _, err = s.Acquire(clientCtx, "127.0.0.1:10004", "user")
+	assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+	assert.ErrorContains(t, err, "client 1")
+
+	// exceed the limit of user
+	_, release3, err := s.Acquire(context.TODO(), "127.0.0.2:10002", "user")
+	assert.NoError(t, err)
+	_, _, err = s.Acquire(context.TODO(), "127.0.0.3:10003", "user")
+	assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+	assert.ErrorContains(t, err, "user user")
+
+	// the unregistered clients are limited by the peer host
+	_, release5, err := s.Acquire(context.TODO(), "127.0.0.2:10005", "")
+	assert.NoError(t, err)
+	_, _, err = s.Acquire(context.TODO(), "127.0.0.2:10006", "")
+	assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+	assert.ErrorContains(t, err, "client 127.0.0.2")
+	unregisteredCtx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs(util.IdentifierKey, "2"))
+	_, _, err = s.Acquire(unregisteredCtx, "127.0.0.2:10007", "")
+	assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+
+	// unknown client and user are not limited
+	for i := 0; i < 5; i++ {
+		_, release, err := s.Acquire(context.TODO(), "", "")
+		assert.NoError(t, err)
+		defer release()
+	}
+
+	release1()
+	// release twice takes no effect
+	release1()
+	_, release4, err := s.Acquire(clientCtx, address1, "user")
+	assert.NoError(t, err)
+
+	release2()
+	release3()
+	release4()
+	release5()
+	assert.Equal(t, 0, len(s.clientInflight))
+	assert.Equal(t, 0, len(s.limitInflight))
+	assert.Equal(t, 0, len(s.userInflight))
+}
+
+func TestConnectionManager_Disconnect(t *testing.T) {
+	paramtable.Init()
+
+	s := newConnectionManager()
+	defer s.Stop()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	lis = s.TrackListener(lis)
+	defer lis.Close()
+
+	clientConn, err := net.Dial("tcp", lis.Addr().String())
+	assert.NoError(t, err)
+	defer clientConn.Close()
+	serverConn, err := lis.Accept()
+	assert.NoError(t, err)
+	address := serverConn.RemoteAddr().String()
+
+	assert.False(t, s.Disconnect(context.TODO(), 1))
+	assert.False(t, s.DisconnectAddress(context.TODO(), "127.0.0.1:1"))
+
+	s.Register(peer.NewContext(context.TODO(), &peer.Peer{Addr: serverConn.RemoteAddr()}), 1, &commonpb.ClientInfo{})
+	ctx, release, err := s.Acquire(context.TODO(), address, "")
+	assert.NoError(t, err)
+	defer release()
+	otherCtx, otherRelease, err := s.Acquire(context.TODO(), "127.0.0.1:10002", "")
+	assert.NoError(t, err)
+	defer otherRelease()
+
+	assert.True(t, s.Disconnect(context.TODO(), 1))
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Equal(t, 0, len(s.List()))
+
+	// the connection is closed
+	clientConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = clientConn.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 0, len(s.conns))
+
+	// the other clients are not affected
+	assert.NoError(t, otherCtx.Err())
+
+	// the client is disconnected by the peer address
+	assert.True(t, s.DisconnectAddress(context.TODO(), "127.0.0.1:10002"))
+	assert.ErrorIs(t, otherCtx.Err(), context.Canceled)
+}
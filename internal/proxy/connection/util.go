@@ -6,6 +6,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
@@ -46,6 +47,16 @@ func GetIdentifierFromContext(ctx context.Context) (int64, error) {
 	return identifier, nil
 }
 
+// GetPeerAddressFromContext returns the peer address of the connection the request comes from,
+// empty if it's unknown. Unlike the identifier, it could not be forged by the client.
+func GetPeerAddressFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
+
 func KeepActiveInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	// We shouldn't block the normal rpc. though this may be not very accurate enough.
 	// On the other hand, too many goroutines will also influence the rpc.
@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// connectionLimitExemptMethods are never limited, so the disconnected clients could reconnect
+// and the health of proxy could always be checked.
+var connectionLimitExemptMethods = typeutil.NewSet(
+	milvuspb.MilvusService_Connect_FullMethodName,
+	milvuspb.MilvusService_CheckHealth_FullMethodName,
+	milvuspb.MilvusService_GetVersion_FullMethodName,
+)
+
+// ConnectionLimitInterceptor returns a new unary server interceptor that limits the in-flight requests
+// per client and authenticated user, the requests are canceled once the client is disconnected.
+func ConnectionLimitInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if connectionLimitExemptMethods.Contain(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		// the request has been authenticated by the authentication interceptor.
+		newCtx, release, err := acquireConnectionLimit(ctx, getConnectionLimitUser(ctx), info.FullMethod)
+		if err != nil {
+			rsp := GetFailedResponse(req, err)
+			if rsp != nil {
+				return rsp, nil
+			}
+			return nil, err
+		}
+		defer release()
+		return handler(newCtx, req)
+	}
+}
+
+// ConnectionLimitStreamInterceptor returns a new stream server interceptor that limits the in-flight streams
+// per client and authenticated user, the streams are canceled once the client is disconnected.
+func ConnectionLimitStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		// the streams are authenticated by their handlers, only the verified user is limited here.
+		var user string
+		if authCtx, err := AuthenticationInterceptor(ctx); err == nil {
+			user = getConnectionLimitUser(authCtx)
+		}
+		newCtx, release, err := acquireConnectionLimit(ctx, user, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, &connectionLimitServerStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+// getConnectionLimitUser returns the user of the authenticated request,
+// empty if authorization is disabled since the user isn't verified then.
+func getConnectionLimitUser(ctx context.Context) string {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return ""
+	}
+	user, _ := GetCurUserFromContext(ctx)
+	return user
+}
+
+// acquireConnectionLimit acquires the in-flight limit of the client and user of the request.
+func acquireConnectionLimit(ctx context.Context, user string, method string) (context.Context, func(), error) {
+	address := connection.GetPeerAddressFromContext(ctx)
+	newCtx, release, err := connection.GetManager().Acquire(ctx, address, user)
+	if err != nil {
+		mlog.RatedWarn(ctx, 10, "request rejected by connection limit",
+			mlog.String("address", address), mlog.String("user", user),
+			mlog.String("method", method), mlog.Err(err))
+		return nil, nil, err
+	}
+	return newCtx, release, nil
+}
+
+// connectionLimitServerStream overrides the context of the stream with the one canceled on disconnect.
+type connectionLimitServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *connectionLimitServerStream) Context() context.Context {
+	return s.ctx
+}
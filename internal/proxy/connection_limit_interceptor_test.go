@@ -0,0 +1,170 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestConnectionLimitInterceptor(t *testing.T) {
+	paramtable.Init()
+	pt := paramtable.Get()
+	pt.Save(pt.ProxyCfg.MaxInflightRequestsPerClient.Key, "1")
+	defer pt.Reset(pt.ProxyCfg.MaxInflightRequestsPerClient.Key)
+
+	identifier := int64(20240101)
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 20240}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	connection.GetManager().Register(ctx, identifier, &commonpb.ClientInfo{})
+
+	interceptor := ConnectionLimitInterceptor()
+	searchInfo := &grpc.UnaryServerInfo{FullMethod: milvuspb.MilvusService_Search_FullMethodName}
+
+	t.Run("exceed client limit", func(t *testing.T) {
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			// the nested request of the same client is rejected, even with another identifier
+			nestedCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(util.IdentifierKey, "1"))
+			rsp, err := interceptor(nestedCtx, &milvuspb.SearchRequest{}, searchInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &milvuspb.SearchResults{Status: merr.Success()}, nil
+			})
+			assert.NoError(t, err)
+			assert.ErrorIs(t, merr.Error(rsp.(*milvuspb.SearchResults).GetStatus()), merr.ErrServiceTooManyRequests)
+
+			// the requests of other clients are not affected
+			otherCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 20241}})
+			rsp, err = interceptor(otherCtx, &milvuspb.SearchRequest{}, searchInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &milvuspb.SearchResults{Status: merr.Success()}, nil
+			})
+			assert.NoError(t, err)
+			assert.True(t, merr.Ok(rsp.(*milvuspb.SearchResults).GetStatus()))
+			return &milvuspb.SearchResults{Status: merr.Success()}, nil
+		}
+		rsp, err := interceptor(ctx, &milvuspb.SearchRequest{}, searchInfo, handler)
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(rsp.(*milvuspb.SearchResults).GetStatus()))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		streamInterceptor := ConnectionLimitStreamInterceptor()
+		streamInfo := &grpc.StreamServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/Stream"}
+		err := streamInterceptor(nil, &mockServerStream{ctx: ctx}, streamInfo, func(srv any, stream grpc.ServerStream) error {
+			// the context of stream is replaced with the one canceled on disconnect
+			assert.NotEqual(t, ctx, stream.Context())
+
+			err := streamInterceptor(nil, &mockServerStream{ctx: ctx}, streamInfo, func(srv any, stream grpc.ServerStream) error {
+				return nil
+			})
+			assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+
+			// the streams and unary requests share the limit of client
+			rsp, err := interceptor(ctx, &milvuspb.SearchRequest{}, searchInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &milvuspb.SearchResults{Status: merr.Success()}, nil
+			})
+			assert.NoError(t, err)
+			assert.ErrorIs(t, merr.Error(rsp.(*milvuspb.SearchResults).GetStatus()), merr.ErrServiceTooManyRequests)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("disconnected", func(t *testing.T) {
+		handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/management/proxy/clients/disconnect",
+				strings.NewReader("identifier="+strconv.FormatInt(identifier, 10)))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			(&Proxy{}).DisconnectClient(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			// the in-flight request is canceled
+			assert.ErrorIs(t, ctx.Err(), context.Canceled)
+			return &milvuspb.SearchResults{Status: merr.Success()}, nil
+		}
+		rsp, err := interceptor(ctx, &milvuspb.SearchRequest{}, searchInfo, handler)
+		assert.NoError(t, err)
+		assert.True(t, merr.Ok(rsp.(*milvuspb.SearchResults).GetStatus()))
+
+		// disconnect by the peer address
+		handler = func(ctx context.Context, _ interface{}) (interface{}, error) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/management/proxy/clients/disconnect", strings.NewReader("address="+addr.String()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			(&Proxy{}).DisconnectClient(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			assert.ErrorIs(t, ctx.Err(), context.Canceled)
+			return &milvuspb.SearchResults{Status: merr.Success()}, nil
+		}
+		_, err = interceptor(ctx, &milvuspb.SearchRequest{}, searchInfo, handler)
+		assert.NoError(t, err)
+
+		// unknown client
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/management/proxy/clients/disconnect", strings.NewReader("identifier=1"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		(&Proxy{}).DisconnectClient(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// unknown address
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodPost, "/management/proxy/clients/disconnect", strings.NewReader("address=127.0.0.1:1"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		(&Proxy{}).DisconnectClient(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// invalid identifier
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodPost, "/management/proxy/clients/disconnect", strings.NewReader("identifier=abc"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		(&Proxy{}).DisconnectClient(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list clients", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/management/proxy/clients/list", nil)
+		(&Proxy{}).ListClients(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
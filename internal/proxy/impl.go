@@ -215,6 +215,11 @@ func (node *Proxy) InvalidateCollectionMetaCache(ctx context.Context, request *p
 		}
 	}
 
+	// the cached results are stale once the collection is loaded, released, altered or dropped
+	if node.resultCache != nil && collectionID != 0 {
+		node.resultCache.invalidateCollection(collectionID)
+	}
+
 	switch msgType {
 	case commonpb.MsgType_DropCollection:
 		// no need to handle error, since this Proxy may not create dml stream for the collection.
@@ -2951,6 +2956,7 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		enableMaterializedView: node.enableMaterializedView,
 		mustUsePartitionKey:    Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
 		chMgr:                  node.chMgr,
+		resultCache:            resultCacheState{cache: node.resultCache},
 	}
 
 	succeeded := false
@@ -3179,6 +3185,7 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 		shardClientMgr:      node.shardMgr,
 		mustUsePartitionKey: Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
 		chMgr:               node.chMgr,
+		resultCache:         resultCacheState{cache: node.resultCache},
 	}
 
 	succeeded := false
@@ -3756,6 +3763,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		shardclientMgr:      node.shardMgr,
 		mustUsePartitionKey: Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
 		chMgr:               node.chMgr,
+		resultCache:         resultCacheState{cache: node.resultCache},
 	}
 
 	subLabel := GetCollectionRateSubLabel(request)
@@ -4995,6 +5003,10 @@ func (node *Proxy) InvalidateCredentialCache(ctx context.Context, request *proxy
 	if priCache != nil {
 		priCache.RemoveCredential(username) // no need to return error, though credential may be not cached
 	}
+	// the credential is invalidated when the user is dropped
+	if node.simpleLimiter != nil {
+		node.simpleLimiter.RemoveUser(username)
+	}
 	mlog.Debug(ctx, "complete to invalidate credential cache")
 
 	return merr.Success(), nil
@@ -5725,6 +5737,10 @@ func (node *Proxy) RefreshPolicyInfoCache(ctx context.Context, req *proxypb.Refr
 			return merr.Status(err), nil
 		}
 	}
+	// the cached results may be unauthorized to the users now
+	if node.resultCache != nil {
+		node.resultCache.invalidatePolicy()
+	}
 	mlog.Debug(ctx, "RefreshPrivilegeInfoCache success")
 
 	return merr.Success(), nil
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	management "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
@@ -53,6 +54,18 @@ func RegisterMgrRoute(proxy *Proxy) {
 			Path:        management.RouteCommitBackfill,
 			HandlerFunc: proxy.CommitBackfillResult,
 		})
+		management.Register(&management.Handler{
+			Path:        management.RouteListRecycleBin,
+			HandlerFunc: proxy.ListRecycleBin,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteRestoreRecycleBin,
+			HandlerFunc: proxy.RestoreRecycleBin,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RoutePurgeRecycleBin,
+			HandlerFunc: proxy.PurgeRecycleBin,
+		})
 		management.Register(&management.Handler{
 			Path:        management.RouteListQueryNode,
 			HandlerFunc: proxy.ListQueryNode,
@@ -101,6 +114,14 @@ func RegisterMgrRoute(proxy *Proxy) {
 			Path:        management.RouteBackupEZ,
 			HandlerFunc: proxy.BackupEZ,
 		})
+		management.Register(&management.Handler{
+			Path:        management.RouteListClients,
+			HandlerFunc: proxy.ListClients,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteDisconnectClient,
+			HandlerFunc: proxy.DisconnectClient,
+		})
 	})
 }
 
@@ -422,6 +443,55 @@ func (node *Proxy) ClearReadTaskQueueManagement(w http.ResponseWriter, req *http
 	w.Write(bs)
 }
 
+// ListClients lists the clients connected to this proxy, with their in-flight request numbers.
+func (node *Proxy) ListClients(w http.ResponseWriter, req *http.Request) {
+	clients := connection.GetManager().List()
+	bs, err := json.Marshal(clients)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list clients, %s"}`, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+// DisconnectClient forcibly disconnects the client from this proxy by its identifier or peer address,
+// its in-flight requests are canceled and its connection is closed, the client has to reconnect.
+func (node *Proxy) DisconnectClient(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to disconnect client, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+
+	if address := req.FormValue("address"); address != "" { //nolint:gosec // internal admin endpoint
+		if !connection.GetManager().DisconnectAddress(req.Context(), address) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"msg": "failed to disconnect client, client %s not found"}`, address) //nolint:gosec // internal admin endpoint
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"msg": "OK"}`))
+		return
+	}
+
+	identifier, err := strconv.ParseInt(req.FormValue("identifier"), 10, 64) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to disconnect client, %s"}`, err.Error())
+		return
+	}
+	if !connection.GetManager().Disconnect(req.Context(), identifier) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"msg": "failed to disconnect client, client %d not found"}`, identifier)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
 func (node *Proxy) SuspendQueryNode(w http.ResponseWriter, req *http.Request) {
 	err := req.ParseForm() //nolint:gosec // internal admin endpoint
 	if err != nil {
@@ -18,6 +18,7 @@ package privilege
 
 import (
 	"context"
+	"maps"
 	"sync"
 	"sync/atomic"
 
@@ -47,8 +48,9 @@ type PrivilegeCache interface {
 
 	GetPrivilegeInfo(ctx context.Context) []string
 	GetUserRole(username string) []string
+	GetRowPolicies(dbName, collectionName string) map[string]string
 	RefreshPolicyInfo(op typeutil.CacheOp) error
-	InitPolicyInfo(info []string, userRoles []string)
+	InitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy)
 }
 
 var _ PrivilegeCache = (*privilegeCache)(nil)
@@ -57,13 +59,19 @@ type privilegeCache struct {
 	mixCoord types.MixCoordClient
 
 	mu             sync.RWMutex
-	privilegeInfos map[string]struct{}            // privileges cache
-	userToRoles    map[string]map[string]struct{} // user to role cache
+	privilegeInfos map[string]struct{}                // privileges cache
+	userToRoles    map[string]map[string]struct{}     // user to role cache
+	rowPolicies    map[rowPolicyKey]map[string]string // collection to role to row policy cache
 
 	credMut sync.RWMutex
 	credMap map[string]*internalpb.CredentialInfo
 }
 
+type rowPolicyKey struct {
+	dbName         string
+	collectionName string
+}
+
 func InitPrivilegeCache(ctx context.Context, mixCoord types.MixCoordClient) error {
 	privilegeCache := NewPrivilegeCache(mixCoord)
 	// The privilege info is a little more. And to get this info, the query operation of involving multiple table queries is required.
@@ -73,7 +81,7 @@ func InitPrivilegeCache(ctx context.Context, mixCoord types.MixCoordClient) erro
 		mlog.Error(ctx, "fail to init meta cache", mlog.Err(err))
 		return err
 	}
-	privilegeCache.InitPolicyInfo(resp.PolicyInfos, resp.UserRoles)
+	privilegeCache.InitPolicyInfo(resp.PolicyInfos, resp.UserRoles, resp.RowPolicies)
 	mlog.Info(ctx, "success to init privilege cache", mlog.Strings("policy_infos", resp.PolicyInfos))
 	return nil
 }
@@ -83,6 +91,7 @@ func NewPrivilegeCache(mixCoord types.MixCoordClient) *privilegeCache {
 		mixCoord:       mixCoord,
 		privilegeInfos: make(map[string]struct{}),
 		userToRoles:    make(map[string]map[string]struct{}),
+		rowPolicies:    make(map[rowPolicyKey]map[string]string),
 
 		credMap: make(map[string]*internalpb.CredentialInfo),
 	}
@@ -138,7 +147,7 @@ func (m *privilegeCache) UpdateCredential(credInfo *internalpb.CredentialInfo) {
 	m.credMap[username].Sha256Password = credInfo.Sha256Password
 }
 
-func (m *privilegeCache) InitPolicyInfo(info []string, userRoles []string) {
+func (m *privilegeCache) InitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy) {
 	defer func() {
 		err := GetEnforcer().LoadPolicy()
 		if err != nil {
@@ -148,10 +157,10 @@ func (m *privilegeCache) InitPolicyInfo(info []string, userRoles []string) {
 	}()
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.unsafeInitPolicyInfo(info, userRoles)
+	m.unsafeInitPolicyInfo(info, userRoles, rowPolicies)
 }
 
-func (m *privilegeCache) unsafeInitPolicyInfo(info []string, userRoles []string) {
+func (m *privilegeCache) unsafeInitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy) {
 	m.privilegeInfos = util.StringSet(info)
 	for _, userRole := range userRoles {
 		user, role, err := funcutil.DecodeUserRoleCache(userRole)
@@ -164,6 +173,13 @@ func (m *privilegeCache) unsafeInitPolicyInfo(info []string, userRoles []string)
 		}
 		m.userToRoles[user][role] = struct{}{}
 	}
+	for _, policy := range rowPolicies {
+		key := rowPolicyKey{dbName: policy.GetDbName(), collectionName: policy.GetCollectionName()}
+		if m.rowPolicies[key] == nil {
+			m.rowPolicies[key] = make(map[string]string)
+		}
+		m.rowPolicies[key][policy.GetRoleName()] = policy.GetExpr()
+	}
 }
 
 func (m *privilegeCache) GetPrivilegeInfo(ctx context.Context) []string {
@@ -180,6 +196,14 @@ func (m *privilegeCache) GetUserRole(user string) []string {
 	return util.StringList(m.userToRoles[user])
 }
 
+// GetRowPolicies returns the row policies of the collection, keyed by role name.
+func (m *privilegeCache) GetRowPolicies(dbName, collectionName string) map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return maps.Clone(m.rowPolicies[rowPolicyKey{dbName: dbName, collectionName: collectionName}])
+}
+
 func (m *privilegeCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 	defer func() {
 		if err == nil {
@@ -238,6 +262,10 @@ func (m *privilegeCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 				delete(m.privilegeInfos, policy)
 			}
 		}
+
+		for _, policies := range m.rowPolicies {
+			delete(policies, op.OpKey)
+		}
 	case typeutil.CacheRefresh:
 		resp, err := m.mixCoord.ListPolicy(context.Background(), &internalpb.ListPolicyRequest{})
 		if err != nil {
@@ -256,7 +284,8 @@ func (m *privilegeCache) RefreshPolicyInfo(op typeutil.CacheOp) (err error) {
 		defer m.mu.Unlock()
 		m.userToRoles = make(map[string]map[string]struct{})
 		m.privilegeInfos = make(map[string]struct{})
-		m.unsafeInitPolicyInfo(resp.PolicyInfos, resp.UserRoles)
+		m.rowPolicies = make(map[rowPolicyKey]map[string]string)
+		m.unsafeInitPolicyInfo(resp.PolicyInfos, resp.UserRoles, resp.RowPolicies)
 	default:
 		return merr.WrapErrParameterInvalidMsg("invalid opType, op_type: %d, op_key: %s", int(op.OpType), op.OpKey)
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
 type PrivilegeCacheTestSuite struct {
@@ -124,6 +125,37 @@ func (s *PrivilegeCacheTestSuite) TestGetCredentialInfo() {
 	})
 }
 
+func (s *PrivilegeCacheTestSuite) TestRowPolicies() {
+	cacheInst.Store(s.cache)
+	defer ResetPrivilegeCacheForTest()
+
+	s.cache.InitPolicyInfo(nil, nil, []*internalpb.RowPolicy{
+		{RoleName: "role1", DbName: "default", CollectionName: "coll", Expr: "a > 1"},
+		{RoleName: "role2", DbName: "default", CollectionName: "coll", Expr: "a < 1"},
+		{RoleName: "role1", DbName: "db1", CollectionName: "coll", Expr: "b > 1"},
+	})
+	s.Equal(map[string]string{"role1": "a > 1", "role2": "a < 1"}, s.cache.GetRowPolicies("default", "coll"))
+	s.Equal(map[string]string{"role1": "b > 1"}, s.cache.GetRowPolicies("db1", "coll"))
+	s.Empty(s.cache.GetRowPolicies("default", "other"))
+
+	// the returned policies are a copy of the cache.
+	s.cache.GetRowPolicies("default", "coll")["role3"] = "a == 1"
+	s.Len(s.cache.GetRowPolicies("default", "coll"), 2)
+
+	s.NoError(s.cache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheDropRole, OpKey: "role1"}))
+	s.Equal(map[string]string{"role2": "a < 1"}, s.cache.GetRowPolicies("default", "coll"))
+	s.Empty(s.cache.GetRowPolicies("db1", "coll"))
+
+	s.mockMixCoord.EXPECT().ListPolicy(mock.Anything, mock.Anything).Return(&internalpb.ListPolicyResponse{
+		Status: merr.Success(),
+		RowPolicies: []*internalpb.RowPolicy{
+			{RoleName: "role3", DbName: "default", CollectionName: "coll", Expr: "a == 1"},
+		},
+	}, nil).Once()
+	s.NoError(s.cache.RefreshPolicyInfo(typeutil.CacheOp{OpType: typeutil.CacheRefresh}))
+	s.Equal(map[string]string{"role3": "a == 1"}, s.cache.GetRowPolicies("default", "coll"))
+}
+
 func TestPrivilegeCache(t *testing.T) {
 	suite.Run(t, new(PrivilegeCacheTestSuite))
 }
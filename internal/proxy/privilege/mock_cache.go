@@ -131,6 +131,55 @@ func (_c *MockPrivilegeCache_GetPrivilegeInfo_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// GetRowPolicies provides a mock function with given fields: dbName, collectionName
+func (_m *MockPrivilegeCache) GetRowPolicies(dbName string, collectionName string) map[string]string {
+	ret := _m.Called(dbName, collectionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRowPolicies")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string, string) map[string]string); ok {
+		r0 = rf(dbName, collectionName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// MockPrivilegeCache_GetRowPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRowPolicies'
+type MockPrivilegeCache_GetRowPolicies_Call struct {
+	*mock.Call
+}
+
+// GetRowPolicies is a helper method to define mock.On call
+//   - dbName string
+//   - collectionName string
+func (_e *MockPrivilegeCache_Expecter) GetRowPolicies(dbName interface{}, collectionName interface{}) *MockPrivilegeCache_GetRowPolicies_Call {
+	return &MockPrivilegeCache_GetRowPolicies_Call{Call: _e.mock.On("GetRowPolicies", dbName, collectionName)}
+}
+
+func (_c *MockPrivilegeCache_GetRowPolicies_Call) Run(run func(dbName string, collectionName string)) *MockPrivilegeCache_GetRowPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPrivilegeCache_GetRowPolicies_Call) Return(_a0 map[string]string) *MockPrivilegeCache_GetRowPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPrivilegeCache_GetRowPolicies_Call) RunAndReturn(run func(string, string) map[string]string) *MockPrivilegeCache_GetRowPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserRole provides a mock function with given fields: username
 func (_m *MockPrivilegeCache) GetUserRole(username string) []string {
 	ret := _m.Called(username)
@@ -179,9 +228,9 @@ func (_c *MockPrivilegeCache_GetUserRole_Call) RunAndReturn(run func(string) []s
 	return _c
 }
 
-// InitPolicyInfo provides a mock function with given fields: info, userRoles
-func (_m *MockPrivilegeCache) InitPolicyInfo(info []string, userRoles []string) {
-	_m.Called(info, userRoles)
+// InitPolicyInfo provides a mock function with given fields: info, userRoles, rowPolicies
+func (_m *MockPrivilegeCache) InitPolicyInfo(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy) {
+	_m.Called(info, userRoles, rowPolicies)
 }
 
 // MockPrivilegeCache_InitPolicyInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitPolicyInfo'
@@ -192,13 +241,14 @@ type MockPrivilegeCache_InitPolicyInfo_Call struct {
 // InitPolicyInfo is a helper method to define mock.On call
 //   - info []string
 //   - userRoles []string
-func (_e *MockPrivilegeCache_Expecter) InitPolicyInfo(info interface{}, userRoles interface{}) *MockPrivilegeCache_InitPolicyInfo_Call {
-	return &MockPrivilegeCache_InitPolicyInfo_Call{Call: _e.mock.On("InitPolicyInfo", info, userRoles)}
+//   - rowPolicies []*internalpb.RowPolicy
+func (_e *MockPrivilegeCache_Expecter) InitPolicyInfo(info interface{}, userRoles interface{}, rowPolicies interface{}) *MockPrivilegeCache_InitPolicyInfo_Call {
+	return &MockPrivilegeCache_InitPolicyInfo_Call{Call: _e.mock.On("InitPolicyInfo", info, userRoles, rowPolicies)}
 }
 
-func (_c *MockPrivilegeCache_InitPolicyInfo_Call) Run(run func(info []string, userRoles []string)) *MockPrivilegeCache_InitPolicyInfo_Call {
+func (_c *MockPrivilegeCache_InitPolicyInfo_Call) Run(run func(info []string, userRoles []string, rowPolicies []*internalpb.RowPolicy)) *MockPrivilegeCache_InitPolicyInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string), args[1].([]string))
+		run(args[0].([]string), args[1].([]string), args[2].([]*internalpb.RowPolicy))
 	})
 	return _c
 }
@@ -208,7 +258,7 @@ func (_c *MockPrivilegeCache_InitPolicyInfo_Call) Return() *MockPrivilegeCache_I
 	return _c
 }
 
-func (_c *MockPrivilegeCache_InitPolicyInfo_Call) RunAndReturn(run func([]string, []string)) *MockPrivilegeCache_InitPolicyInfo_Call {
+func (_c *MockPrivilegeCache_InitPolicyInfo_Call) RunAndReturn(run func([]string, []string, []*internalpb.RowPolicy)) *MockPrivilegeCache_InitPolicyInfo_Call {
 	_c.Run(run)
 	return _c
 }
@@ -87,6 +87,10 @@ type Proxy struct {
 
 	sched *taskScheduler
 
+	// result cache of search and query, nil if disabled
+	resultCache *resultCache
+	chTicker    channelsTimeTicker
+
 	rowIDAllocator *allocator.IDAllocator
 	tsoAllocator   *timestampAllocator
 
@@ -232,6 +236,17 @@ func (node *Proxy) Init() error {
 	}
 	mlog.Debug(node.ctx, "create task scheduler done", mlog.String("role", typeutil.ProxyRole))
 
+	if Params.ProxyCfg.ResultCacheEnabled.GetAsBool() {
+		node.resultCache = newResultCache()
+		node.sched.dmQueue.setDMLDoneListener(node.resultCache.invalidateChannel)
+		// the DML tasks which are still running are observed by the time ticks
+		chTicker := newChannelsTimeTicker(node.ctx, Params.ProxyCfg.TimeTickInterval.GetAsDuration(time.Millisecond),
+			[]pChan{}, node.sched.getPChanStatistics, node.tsoAllocator)
+		chTicker.setDMLTickListener(node.resultCache.invalidateChannel)
+		node.chTicker = chTicker
+		mlog.Debug(node.ctx, "create result cache done", mlog.String("role", typeutil.ProxyRole))
+	}
+
 	node.enableComplexDeleteLimit = Params.QuotaConfig.ComplexDeleteLimitEnable.GetAsBool()
 	node.metricsCacheManager = metricsinfo.NewMetricsCacheManager()
 	mlog.Debug(node.ctx, "create metrics cache manager done", mlog.String("role", typeutil.ProxyRole))
@@ -271,6 +286,14 @@ func (node *Proxy) Start() error {
 	}
 	mlog.Debug(node.ctx, "start task scheduler done", mlog.String("role", typeutil.ProxyRole))
 
+	if node.chTicker != nil {
+		if err := node.chTicker.start(); err != nil {
+			mlog.Warn(node.ctx, "failed to start channels time ticker", mlog.String("role", typeutil.ProxyRole), mlog.Err(err))
+			return err
+		}
+		mlog.Debug(node.ctx, "start channels time ticker done", mlog.String("role", typeutil.ProxyRole))
+	}
+
 	if err := node.rowIDAllocator.Start(); err != nil {
 		mlog.Warn(node.ctx, "failed to start id allocator", mlog.String("role", typeutil.ProxyRole), mlog.Err(err))
 		return err
@@ -303,6 +326,11 @@ func (node *Proxy) Stop() error {
 		mlog.Info(node.ctx, "close id allocator", mlog.String("role", typeutil.ProxyRole))
 	}
 
+	if node.chTicker != nil {
+		node.chTicker.close()
+		mlog.Info(node.ctx, "close channels time ticker", mlog.String("role", typeutil.ProxyRole))
+	}
+
 	if node.sched != nil {
 		node.sched.Close()
 		mlog.Info(node.ctx, "close scheduler", mlog.String("role", typeutil.ProxyRole))
@@ -68,6 +68,8 @@ const (
 // GROUP BY + ORDER BY:
 //
 //	input -> [reduce_by_groups(raw)] -> [order] -> [slice] -> [agg_remap] -> output
+//
+// The HAVING predicate of GROUP BY is evaluated by reduce_by_groups on the reduced groups.
 type QueryPipeline struct {
 	pipeline       *queryutil.Pipeline
 	schema         *schemapb.CollectionSchema
@@ -84,6 +86,7 @@ func NewQueryPipeline(
 	groupByFieldIDs []int64,
 	aggregates []*planpb.Aggregate,
 	outputMap *agg.AggregationFieldMap,
+	having *agg.HavingFilter,
 	outputFieldIDs []int64,
 ) (*QueryPipeline, error) {
 	hasAggregation := len(groupByFieldIDs) > 0 || len(aggregates) > 0
@@ -92,9 +95,9 @@ func NewQueryPipeline(
 	var p *queryutil.Pipeline
 	var err error
 	if hasAggregation && hasOrderBy {
-		p, err = buildGroupByOrderByPipeline(schema, limit, offset, orderByFields, groupByFieldIDs, aggregates, outputMap)
+		p, err = buildGroupByOrderByPipeline(schema, limit, offset, orderByFields, groupByFieldIDs, aggregates, outputMap, having)
 	} else if hasAggregation {
-		p = buildGroupByPipeline(schema, limit, offset, groupByFieldIDs, aggregates, outputMap)
+		p = buildGroupByPipeline(schema, limit, offset, groupByFieldIDs, aggregates, outputMap, having)
 	} else if hasOrderBy {
 		p = buildOrderByPipeline(schema, limit, offset, orderByFields, outputFieldIDs)
 	} else {
@@ -151,9 +154,10 @@ func buildGroupByPipeline(
 	groupByFieldIDs []int64,
 	aggregates []*planpb.Aggregate,
 	outputMap *agg.AggregationFieldMap,
+	having *agg.HavingFilter,
 ) *queryutil.Pipeline {
 	b := queryutil.NewPipelineBuilder("proxy-query-groupby")
-	b.Add(queryutil.OpReduceByGroups, in(), ch(chanReduced), newReduceByGroupsOperator(schema, groupByFieldIDs, aggregates, outputMap))
+	b.Add(queryutil.OpReduceByGroups, in(), ch(chanReduced), newReduceByGroupsOperator(schema, groupByFieldIDs, aggregates, outputMap, having))
 	b.Add(queryutil.OpSlice, ch(chanReduced), out(), queryutil.NewSliceOperator(limit, offset))
 	return b.Build()
 }
@@ -166,6 +170,7 @@ func buildGroupByOrderByPipeline(
 	groupByFieldIDs []int64,
 	aggregates []*planpb.Aggregate,
 	outputMap *agg.AggregationFieldMap,
+	having *agg.HavingFilter,
 ) (*queryutil.Pipeline, error) {
 	// Positions based on reducer raw layout [group_cols..., agg_cols...]
 	positions, err := queryutil.ComputeGroupByOrderPositions(orderByFields, groupByFieldIDs, aggregates)
@@ -174,7 +179,7 @@ func buildGroupByOrderByPipeline(
 	}
 
 	b := queryutil.NewPipelineBuilder("proxy-query-groupby-orderby")
-	b.Add(queryutil.OpReduceByGroups, in(), ch(chanReduced), newRawReduceByGroupsOperator(schema, groupByFieldIDs, aggregates))
+	b.Add(queryutil.OpReduceByGroups, in(), ch(chanReduced), newRawReduceByGroupsOperator(schema, groupByFieldIDs, aggregates, having))
 	b.Add(queryutil.OpOrderByLimit, ch(chanReduced), ch(chanSorted), queryutil.NewOrderByLimitOperatorWithPositions(orderByFields, positions, offset+limit))
 	b.Add(queryutil.OpSlice, ch(chanSorted), ch(chanSliced), queryutil.NewSliceOperator(limit, offset))
 	b.Add(queryutil.OpRemap, ch(chanSliced), out(), newAggRemapOperator(outputMap))
@@ -264,6 +269,7 @@ func newReduceByGroupsOperator(
 	groupByFieldIDs []int64,
 	aggregates []*planpb.Aggregate,
 	outputMap *agg.AggregationFieldMap,
+	having *agg.HavingFilter,
 ) queryutil.Operator {
 	return queryutil.NewLambdaOperator(queryutil.OpReduceByGroups, func(ctx context.Context, span trace.Span, inputs ...any) ([]any, error) {
 		results := inputs[0].([]*internalpb.RetrieveResults)
@@ -275,6 +281,12 @@ func newReduceByGroupsOperator(
 		}
 
 		reducedFieldDatas := reducedRes.GetFieldDatas()
+		if having != nil {
+			reducedFieldDatas, err = having.Filter(reducedFieldDatas, -1)
+			if err != nil {
+				return nil, err
+			}
+		}
 		fieldCount := outputMap.Count()
 		reOrganizedFieldDatas := make([]*schemapb.FieldData, fieldCount)
 
@@ -282,6 +294,13 @@ func newReduceByGroupsOperator(
 			indices := outputMap.IndexesAt(i)
 			if len(indices) == 0 {
 				return nil, merr.WrapErrParameterInvalidMsg("no indices found for output field '%s'", outputMap.NameAt(i))
+			} else if len(indices) == 1 && outputMap.IsStateAt(i) {
+				finalizedFieldData, err := outputMap.FinalizeStateAt(i, reducedFieldDatas[indices[0]])
+				if err != nil {
+					return nil, err
+				}
+				finalizedFieldData.FieldName = outputMap.NameAt(i)
+				reOrganizedFieldDatas[i] = finalizedFieldData
 			} else if len(indices) == 1 {
 				reOrganizedFieldDatas[i] = reducedFieldDatas[indices[0]]
 				reOrganizedFieldDatas[i].FieldName = outputMap.NameAt(i)
@@ -311,6 +330,7 @@ func newRawReduceByGroupsOperator(
 	schema *schemapb.CollectionSchema,
 	groupByFieldIDs []int64,
 	aggregates []*planpb.Aggregate,
+	having *agg.HavingFilter,
 ) queryutil.Operator {
 	return queryutil.NewLambdaOperator(queryutil.OpReduceByGroups, func(ctx context.Context, span trace.Span, inputs ...any) ([]any, error) {
 		results := inputs[0].([]*internalpb.RetrieveResults)
@@ -321,14 +341,22 @@ func newRawReduceByGroupsOperator(
 			return nil, err
 		}
 
+		fieldDatas := reducedRes.GetFieldDatas()
+		if having != nil {
+			fieldDatas, err = having.Filter(fieldDatas, -1)
+			if err != nil {
+				return nil, err
+			}
+		}
 		return []any{&internalpb.RetrieveResults{
-			FieldsData: reducedRes.GetFieldDatas(),
+			FieldsData: fieldDatas,
 		}}, nil
 	})
 }
 
 // newAggRemapOperator reorganizes fields from the GroupAggReducer's raw layout
-// to the user's output_fields order, computing avg from sum+count where needed.
+// to the user's output_fields order, computing avg from sum+count and finalizing
+// the states of the state aggregations (e.g. count distinct, percentile) where needed.
 // Used after ORDER BY + slice in the GROUP BY + ORDER BY pipeline.
 func newAggRemapOperator(outputMap *agg.AggregationFieldMap) queryutil.Operator {
 	return queryutil.NewLambdaOperator(queryutil.OpRemap, func(ctx context.Context, span trace.Span, inputs ...any) ([]any, error) {
@@ -345,6 +373,13 @@ func newAggRemapOperator(outputMap *agg.AggregationFieldMap) queryutil.Operator
 			indices := outputMap.IndexesAt(i)
 			if len(indices) == 0 {
 				return nil, merr.WrapErrParameterInvalidMsg("no indices found for output field '%s'", outputMap.NameAt(i))
+			} else if len(indices) == 1 && outputMap.IsStateAt(i) {
+				finalizedFieldData, err := outputMap.FinalizeStateAt(i, rawFields[indices[0]])
+				if err != nil {
+					return nil, err
+				}
+				finalizedFieldData.FieldName = outputMap.NameAt(i)
+				remapped[i] = finalizedFieldData
 			} else if len(indices) == 1 {
 				remapped[i] = rawFields[indices[0]]
 				remapped[i].FieldName = outputMap.NameAt(i)
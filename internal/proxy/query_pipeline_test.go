@@ -86,7 +86,7 @@ func TestNewQueryPipeline_Plain(t *testing.T) {
 	schema := testSchema()
 	pipeline, err := NewQueryPipeline(
 		schema, 3, 0, reduce.IReduceNoOrder,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -121,7 +121,7 @@ func TestNewQueryPipeline_Plain_ComplementFields(t *testing.T) {
 	schema := testSchema()
 	pipeline, err := NewQueryPipeline(
 		schema, 2, 0, reduce.IReduceNoOrder,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -156,7 +156,7 @@ func TestNewQueryPipeline_OrderBy(t *testing.T) {
 	}
 	pipeline, err := NewQueryPipeline(
 		schema, 3, 0, reduce.IReduceNoOrder,
-		orderByFields, nil, nil, nil,
+		orderByFields, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -195,7 +195,7 @@ func TestNewQueryPipeline_OrderBy_WithOffset(t *testing.T) {
 	}
 	pipeline, err := NewQueryPipeline(
 		schema, 2, 1, reduce.IReduceNoOrder, // limit=2, offset=1
-		orderByFields, nil, nil, nil,
+		orderByFields, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -222,7 +222,7 @@ func TestNewQueryPipeline_EmptyResult(t *testing.T) {
 	schema := testSchema()
 	pipeline, err := NewQueryPipeline(
 		schema, 10, 0, reduce.IReduceNoOrder,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -261,6 +261,7 @@ func TestNewQueryPipeline_GroupBy(t *testing.T) {
 		[]int64{101}, // group by val
 		[]*planpb.Aggregate{{Op: planpb.AggregateOp_count, FieldId: 500}},
 		outputMap,
+		nil, // no HAVING
 		nil, // outputFieldIDs not used for GROUP BY
 	)
 	require.NoError(t, err)
@@ -286,6 +287,53 @@ func TestNewQueryPipeline_GroupBy(t *testing.T) {
 	assert.Equal(t, 2, len(result.GetFieldsData()))
 }
 
+func TestNewQueryPipeline_GroupByHaving(t *testing.T) {
+	schema := testSchema()
+
+	countAggs, err := agg.NewAggregate("count", 0, "count(*)", schemapb.DataType_None)
+	require.NoError(t, err)
+	having, hiddenAggs, err := agg.ParseHaving("count(*) > 4", schema, []string{"val"}, countAggs)
+	require.NoError(t, err)
+	require.Empty(t, hiddenAggs)
+	outputMap, err := agg.NewAggregationFieldMap(
+		[]string{"val", "count(*)"},
+		[]string{"val"},
+		countAggs,
+	)
+	require.NoError(t, err)
+
+	pipeline, err := NewQueryPipeline(
+		schema, 10, 0, reduce.IReduceNoOrder,
+		nil,
+		[]int64{101},
+		agg.AggregatesToPB(countAggs),
+		outputMap,
+		having,
+		nil,
+	)
+	require.NoError(t, err)
+
+	r1 := &internalpb.RetrieveResults{
+		FieldsData: []*schemapb.FieldData{
+			makeTestInt64Field(101, "val", []int64{10, 20}),
+			makeTestInt64Field(0, "count", []int64{3, 5}),
+		},
+	}
+	r2 := &internalpb.RetrieveResults{
+		FieldsData: []*schemapb.FieldData{
+			makeTestInt64Field(101, "val", []int64{10, 30}),
+			makeTestInt64Field(0, "count", []int64{2, 4}),
+		},
+	}
+
+	result, err := pipeline.Execute(context.Background(), []*internalpb.RetrieveResults{r1, r2})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(result.GetFieldsData()))
+	// val=10 (3+2) and val=20 (5) pass, val=30 (4) is filtered
+	vals := result.GetFieldsData()[0].GetScalars().GetLongData().GetData()
+	assert.ElementsMatch(t, []int64{10, 20}, vals)
+}
+
 func TestNewQueryPipeline_GroupByCountStarWithStructSchema(t *testing.T) {
 	schema := testSchemaWithStructArray()
 
@@ -307,6 +355,7 @@ func TestNewQueryPipeline_GroupByCountStarWithStructSchema(t *testing.T) {
 		[]*planpb.Aggregate{{Op: planpb.AggregateOp_count, FieldId: 0}},
 		outputMap,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -373,6 +422,7 @@ func TestNewQueryPipeline_GroupByOrderBy(t *testing.T) {
 		[]*planpb.Aggregate{{Op: planpb.AggregateOp_count, FieldId: 500}},
 		outputMap,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -405,7 +455,7 @@ func TestNewQueryPipeline_Plain_ElementIndices(t *testing.T) {
 	schema := testSchema()
 	pipeline, err := NewQueryPipeline(
 		schema, 3, 0, reduce.IReduceNoOrder,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -445,7 +495,7 @@ func TestNewQueryPipeline_Plain_NoElementLevel(t *testing.T) {
 	schema := testSchema()
 	pipeline, err := NewQueryPipeline(
 		schema, 3, 0, reduce.IReduceNoOrder,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		[]int64{100, 101},
 	)
 	require.NoError(t, err)
@@ -482,6 +532,7 @@ func TestNewQueryPipeline_GroupByOrderBy_InvalidField(t *testing.T) {
 		[]int64{101}, // group by val
 		nil,          // no aggregates
 		nil,          // outputMap (nil ok since we expect error before use)
+		nil,          // no HAVING
 		nil,
 	)
 	assert.Error(t, err)
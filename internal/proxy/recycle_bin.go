@@ -0,0 +1,320 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// The recycle bin keeps the dropped collections and partitions restorable for
+// common.recycleBin.retentionSeconds. Before a collection or partition is dropped, the proxy
+// takes a snapshot of the collection named by common.RecycleBinSnapshotName, datacoord keeps
+// the snapshot and the binlogs and indexes it references from the drop collection cascade and
+// garbage collection until the retention expires. The snapshot description records the
+// database and collection name the snapshot was taken from. The snapshot is removed again
+// if the drop fails.
+//
+// A dropped partition is restored in place into its source collection, a dropped collection
+// is restored as a new collection. The management apis of the recycle bin require the
+// snapshot privileges of the source collection.
+
+// recycleBinEntry is the recycle bin entry listed by the management api.
+type recycleBinEntry struct {
+	Name           string `json:"name"`
+	CollectionID   int64  `json:"collection_id"`
+	DbName         string `json:"db_name"`
+	CollectionName string `json:"collection_name"`
+	PartitionName  string `json:"partition_name,omitempty"`
+	DroppedAt      string `json:"dropped_at"`
+	ExpireAt       string `json:"expire_at"`
+}
+
+// moveToRecycleBin takes the recycle bin snapshot of the collection before dropping it,
+// or its partition if partitionName is not empty, and returns the snapshot name.
+// It's a no-op returning an empty name if the recycle bin is disabled.
+func moveToRecycleBin(ctx context.Context, mixCoord types.MixCoordClient, dbName, collectionName string, collectionID int64, partitionName string) (string, error) {
+	if Params.CommonCfg.RecycleBinRetentionSeconds.GetAsInt64() <= 0 {
+		return "", nil
+	}
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	name := common.RecycleBinSnapshotName(collectionID, partitionName, time.Now())
+	status, err := mixCoord.CreateSnapshot(ctx, &datapb.CreateSnapshotRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_CreateSnapshot),
+		),
+		Name:         name,
+		Description:  dbName + "." + collectionName,
+		CollectionId: collectionID,
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		mlog.Warn(ctx, "failed to move to recycle bin",
+			mlog.FieldCollectionName(collectionName),
+			mlog.String("partitionName", partitionName),
+			mlog.Err(err))
+		return "", merr.Wrap(err, "failed to move to recycle bin")
+	}
+	mlog.Info(ctx, "moved to recycle bin",
+		mlog.FieldCollectionName(collectionName),
+		mlog.String("partitionName", partitionName),
+		mlog.String("snapshotName", name))
+	return name, nil
+}
+
+// removeFromRecycleBin drops the recycle bin snapshot taken by moveToRecycleBin,
+// it's called when the drop fails so the recycle bin never keeps a collection or partition alive.
+func removeFromRecycleBin(ctx context.Context, mixCoord types.MixCoordClient, collectionID int64, name string) {
+	if name == "" {
+		return
+	}
+	status, err := mixCoord.DropSnapshot(ctx, &datapb.DropSnapshotRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_DropSnapshot),
+		),
+		Name:         name,
+		CollectionId: collectionID,
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		// the snapshot expires with the retention anyway.
+		mlog.Warn(ctx, "failed to remove from recycle bin", mlog.String("snapshotName", name), mlog.Err(err))
+		return
+	}
+	mlog.Info(ctx, "removed from recycle bin", mlog.String("snapshotName", name))
+}
+
+// checkRecycleBinPrivilege checks the privilege of the request user on the source collection
+// of the recycle bin entry, the error response is written if the check fails.
+func checkRecycleBinPrivilege(w http.ResponseWriter, req *http.Request, objectPrivilege commonpb.ObjectPrivilege, entry *recycleBinEntry) bool {
+	err := management.CheckPrivilege(req.Context(), req, commonpb.ObjectType_Collection,
+		objectPrivilege.String(), entry.CollectionName, entry.DbName)
+	if err != nil {
+		w.WriteHeader(management.HTTPStatusFromPrivilegeError(err))
+		fmt.Fprintf(w, `{"msg": "%s"}`, err.Error())
+		return false
+	}
+	return true
+}
+
+// describeRecycleBinEntry returns the recycle bin entry of the snapshot, ok is false if
+// the snapshot is not kept by the recycle bin.
+func (node *Proxy) describeRecycleBinEntry(ctx context.Context, name string) (*recycleBinEntry, bool, error) {
+	collectionID, partitionName, dropTime, ok := common.ParseRecycleBinSnapshotName(name)
+	if !ok {
+		return nil, false, nil
+	}
+	resp, err := node.mixCoord.DescribeSnapshot(ctx, &datapb.DescribeSnapshotRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		Name:         name,
+		CollectionId: collectionID,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, false, err
+	}
+	dbName, collectionName, _ := strings.Cut(resp.GetSnapshotInfo().GetDescription(), ".")
+	retention := Params.CommonCfg.RecycleBinRetentionSeconds.GetAsDuration(time.Second)
+	return &recycleBinEntry{
+		Name:           name,
+		CollectionID:   collectionID,
+		DbName:         dbName,
+		CollectionName: collectionName,
+		PartitionName:  partitionName,
+		DroppedAt:      dropTime.Format(time.RFC3339),
+		ExpireAt:       dropTime.Add(retention).Format(time.RFC3339),
+	}, true, nil
+}
+
+// ListRecycleBin lists the dropped collections and partitions in the recycle bin
+// which the request user has the DescribeSnapshot privilege of.
+func (node *Proxy) ListRecycleBin(w http.ResponseWriter, req *http.Request) {
+	resp, err := node.mixCoord.ListSnapshots(req.Context(), &datapb.ListSnapshotsRequest{
+		Base: commonpbutil.NewMsgBase(),
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list recycle bin, %s"}`, err.Error())
+		return
+	}
+
+	now := time.Now()
+	retention := Params.CommonCfg.RecycleBinRetentionSeconds.GetAsDuration(time.Second)
+	entries := make([]*recycleBinEntry, 0)
+	for _, name := range resp.GetSnapshots() {
+		// the expired ones are waiting for garbage collection, they are not restorable anymore.
+		if _, _, dropTime, ok := common.ParseRecycleBinSnapshotName(name); !ok || !now.Before(dropTime.Add(retention)) {
+			continue
+		}
+		entry, ok, err := node.describeRecycleBinEntry(req.Context(), name)
+		if err != nil {
+			if errors.Is(err, merr.ErrSnapshotNotFound) {
+				continue
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"msg": "failed to list recycle bin, %s"}`, err.Error())
+			return
+		}
+		if !ok {
+			continue
+		}
+		err = management.CheckPrivilege(req.Context(), req, commonpb.ObjectType_Collection,
+			commonpb.ObjectPrivilege_PrivilegeDescribeSnapshot.String(), entry.CollectionName, entry.DbName)
+		if err != nil {
+			// the entries of the collections the user could not describe are not listed.
+			if management.IsPermissionDeniedError(err) && Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+				continue
+			}
+			w.WriteHeader(management.HTTPStatusFromPrivilegeError(err))
+			fmt.Fprintf(w, `{"msg": "%s"}`, err.Error())
+			return
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	bs, err := json.Marshal(entries)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list recycle bin, %s"}`, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"msg": "OK", "recycle_bin": %s}`, string(bs))
+}
+
+// RestoreRecycleBin restores a dropped collection or partition from the recycle bin.
+// A dropped collection is restored with the original name or the one given by db_name and collection_name,
+// a dropped partition is restored in place into its source collection.
+// The data is restored asynchronously, the returned job id could be checked by GetRestoreSnapshotState.
+func (node *Proxy) RestoreRecycleBin(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to restore from recycle bin, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+
+	entry, ok, err := node.describeRecycleBinEntry(req.Context(), req.FormValue("name")) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to restore from recycle bin, %s"}`, err.Error())
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "failed to restore from recycle bin, invalid recycle bin name"}`))
+		return
+	}
+	if !checkRecycleBinPrivilege(w, req, commonpb.ObjectPrivilege_PrivilegeRestoreSnapshot, entry) {
+		return
+	}
+
+	restoreReq := &datapb.RestoreSnapshotRequest{
+		Base:               commonpbutil.NewMsgBase(),
+		Name:               entry.Name,
+		SourceCollectionId: entry.CollectionID,
+	}
+	if entry.PartitionName != "" {
+		if req.FormValue("db_name") != "" || req.FormValue("collection_name") != "" { //nolint:gosec // internal admin endpoint
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"msg": "failed to restore from recycle bin, a dropped partition is restored in place, db_name and collection_name are not allowed"}`))
+			return
+		}
+		restoreReq.TargetPartitionName = entry.PartitionName
+	} else {
+		restoreReq.TargetDbName = entry.DbName
+		if req.FormValue("db_name") != "" { //nolint:gosec // internal admin endpoint
+			restoreReq.TargetDbName = req.FormValue("db_name") //nolint:gosec // internal admin endpoint
+		}
+		restoreReq.TargetCollectionName = entry.CollectionName
+		if req.FormValue("collection_name") != "" { //nolint:gosec // internal admin endpoint
+			restoreReq.TargetCollectionName = req.FormValue("collection_name") //nolint:gosec // internal admin endpoint
+		}
+		if err := validateCollectionName(restoreReq.TargetCollectionName); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"msg": "failed to restore from recycle bin, %s"}`, err.Error())
+			return
+		}
+	}
+
+	resp, err := node.mixCoord.RestoreSnapshot(req.Context(), restoreReq)
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to restore from recycle bin, %s"}`, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"msg": "OK", "job_id": %d}`, resp.GetJobId())
+}
+
+// PurgeRecycleBin removes a dropped collection or partition from the recycle bin,
+// its data is garbage collected afterwards.
+func (node *Proxy) PurgeRecycleBin(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to purge recycle bin, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+
+	entry, ok, err := node.describeRecycleBinEntry(req.Context(), req.FormValue("name")) //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to purge recycle bin, %s"}`, err.Error())
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "failed to purge recycle bin, invalid recycle bin name"}`))
+		return
+	}
+	if !checkRecycleBinPrivilege(w, req, commonpb.ObjectPrivilege_PrivilegeDropSnapshot, entry) {
+		return
+	}
+
+	status, err := node.mixCoord.DropSnapshot(req.Context(), &datapb.DropSnapshotRequest{
+		Base:         commonpbutil.NewMsgBase(),
+		Name:         entry.Name,
+		CollectionId: entry.CollectionID,
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to purge recycle bin, %s"}`, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
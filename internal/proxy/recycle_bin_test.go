@@ -0,0 +1,232 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func (s *ProxyManagementSuite) TestRecycleBin() {
+	paramtable.Get().Save(Params.CommonCfg.RecycleBinRetentionSeconds.Key, "3600")
+	defer paramtable.Get().Reset(Params.CommonCfg.RecycleBinRetentionSeconds.Key)
+
+	collectionName := common.RecycleBinSnapshotName(100, "", time.Now())
+	partitionName := common.RecycleBinSnapshotName(100, "p1", time.Now())
+	expiredName := common.RecycleBinSnapshotName(200, "", time.Now().Add(-2*time.Hour))
+	describe := func(ctx context.Context, req *datapb.DescribeSnapshotRequest, options ...grpc.CallOption) (*datapb.DescribeSnapshotResponse, error) {
+		s.Equal(int64(100), req.GetCollectionId())
+		return &datapb.DescribeSnapshotResponse{
+			Status:       merr.Success(),
+			SnapshotInfo: &datapb.SnapshotInfo{Name: req.GetName(), Description: "db1.coll1"},
+		}, nil
+	}
+
+	s.Run("move_to_recycle_bin", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.mixcoord.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.CreateSnapshotRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			collectionID, partition, _, ok := common.ParseRecycleBinSnapshotName(req.GetName())
+			s.True(ok)
+			s.Equal(int64(100), collectionID)
+			s.Equal("p1", partition)
+			s.Equal(int64(100), req.GetCollectionId())
+			s.Equal("default.coll1", req.GetDescription())
+			return merr.Success(), nil
+		}).Once()
+		name, err := moveToRecycleBin(context.Background(), s.mixcoord, "", "coll1", 100, "p1")
+		s.NoError(err)
+		s.NotEmpty(name)
+
+		s.mixcoord.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).Return(merr.Status(merr.ErrServiceNotReady), nil).Once()
+		_, err = moveToRecycleBin(context.Background(), s.mixcoord, "db1", "coll1", 100, "")
+		s.Error(err)
+
+		paramtable.Get().Save(Params.CommonCfg.RecycleBinRetentionSeconds.Key, "0")
+		defer paramtable.Get().Save(Params.CommonCfg.RecycleBinRetentionSeconds.Key, "3600")
+		name, err = moveToRecycleBin(context.Background(), s.mixcoord, "db1", "coll1", 100, "")
+		s.NoError(err)
+		s.Empty(name)
+	})
+
+	s.Run("remove_from_recycle_bin_if_drop_fails", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		var snapshotName string
+		s.mixcoord.EXPECT().CreateSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.CreateSnapshotRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			snapshotName = req.GetName()
+			return merr.Success(), nil
+		}).Twice()
+		s.mixcoord.EXPECT().DropSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.DropSnapshotRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			s.Equal(snapshotName, req.GetName())
+			s.Equal(int64(100), req.GetCollectionId())
+			return merr.Success(), nil
+		}).Twice()
+		s.mixcoord.EXPECT().DropCollection(mock.Anything, mock.Anything).Return(merr.Status(merr.ErrServiceNotReady), nil).Once()
+		s.mixcoord.EXPECT().DropPartition(mock.Anything, mock.Anything).Return(merr.Status(merr.ErrServiceNotReady), nil).Once()
+
+		dct := &dropCollectionTask{
+			DropCollectionRequest: &milvuspb.DropCollectionRequest{DbName: "db1", CollectionName: "coll1"},
+			mixCoord:              s.mixcoord,
+			collectionID:          100,
+		}
+		s.Error(dct.Execute(context.Background()))
+
+		dpt := &dropPartitionTask{
+			DropPartitionRequest: &milvuspb.DropPartitionRequest{DbName: "db1", CollectionName: "coll1", PartitionName: "p1"},
+			mixCoord:             s.mixcoord,
+			collectionID:         100,
+		}
+		s.Error(dpt.Execute(context.Background()))
+	})
+
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+	management.RegisterPasswordVerifyFunc(func(ctx context.Context, username, password string) bool {
+		return username == util.UserRoot && password == "Milvus"
+	})
+	defer management.RegisterPasswordVerifyFunc(nil)
+	newRequest := func(method, url string, body io.Reader) *http.Request {
+		req, err := http.NewRequest(method, url, body)
+		s.Require().NoError(err)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		req.SetBasicAuth(util.UserRoot, "Milvus")
+		return req
+	}
+
+	s.Run("list", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.mixcoord.EXPECT().ListSnapshots(mock.Anything, mock.Anything).Return(&datapb.ListSnapshotsResponse{
+			Status:    merr.Success(),
+			Snapshots: []string{"backup", collectionName, partitionName, expiredName},
+		}, nil)
+		s.mixcoord.EXPECT().DescribeSnapshot(mock.Anything, mock.Anything).RunAndReturn(describe).Twice()
+
+		recorder := httptest.NewRecorder()
+		s.proxy.ListRecycleBin(recorder, newRequest(http.MethodGet, management.RouteListRecycleBin, nil))
+
+		s.Equal(http.StatusOK, recorder.Code)
+		body := recorder.Body.String()
+		s.Contains(body, collectionName)
+		s.Contains(body, partitionName)
+		s.Contains(body, `"collection_name":"coll1"`)
+		s.Contains(body, `"partition_name":"p1"`)
+		s.NotContains(body, expiredName)
+		s.NotContains(body, `"backup"`)
+	})
+
+	s.Run("restore", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.mixcoord.EXPECT().DescribeSnapshot(mock.Anything, mock.Anything).RunAndReturn(describe)
+		s.mixcoord.EXPECT().RestoreSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.RestoreSnapshotRequest, options ...grpc.CallOption) (*datapb.RestoreSnapshotResponse, error) {
+			s.Equal(collectionName, req.GetName())
+			s.Equal(int64(100), req.GetSourceCollectionId())
+			s.Equal("db1", req.GetTargetDbName())
+			s.Equal("coll1", req.GetTargetCollectionName())
+			s.Empty(req.GetTargetPartitionName())
+			return &datapb.RestoreSnapshotResponse{Status: merr.Success(), JobId: 1001}, nil
+		}).Once()
+
+		recorder := httptest.NewRecorder()
+		s.proxy.RestoreRecycleBin(recorder, newRequest(http.MethodPost, management.RouteRestoreRecycleBin, strings.NewReader("name="+collectionName)))
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), `"job_id": 1001`)
+
+		// a dropped partition is restored in place
+		s.mixcoord.EXPECT().RestoreSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.RestoreSnapshotRequest, options ...grpc.CallOption) (*datapb.RestoreSnapshotResponse, error) {
+			s.Equal(partitionName, req.GetName())
+			s.Equal(int64(100), req.GetSourceCollectionId())
+			s.Equal("p1", req.GetTargetPartitionName())
+			s.Empty(req.GetTargetDbName())
+			s.Empty(req.GetTargetCollectionName())
+			return &datapb.RestoreSnapshotResponse{Status: merr.Success(), JobId: 1002}, nil
+		}).Once()
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreRecycleBin(recorder, newRequest(http.MethodPost, management.RouteRestoreRecycleBin, strings.NewReader("name="+partitionName)))
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Contains(recorder.Body.String(), `"job_id": 1002`)
+
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreRecycleBin(recorder, newRequest(http.MethodPost, management.RouteRestoreRecycleBin, strings.NewReader("name="+partitionName+"&collection_name=coll2")))
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreRecycleBin(recorder, newRequest(http.MethodPost, management.RouteRestoreRecycleBin+"?name=backup", nil))
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		// the request without credentials is rejected
+		req, err := http.NewRequest(http.MethodPost, management.RouteRestoreRecycleBin+"?name="+collectionName, nil)
+		s.Require().NoError(err)
+		recorder = httptest.NewRecorder()
+		s.proxy.RestoreRecycleBin(recorder, req)
+		s.Equal(http.StatusUnauthorized, recorder.Code)
+	})
+
+	s.Run("purge", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+		s.mixcoord.EXPECT().DescribeSnapshot(mock.Anything, mock.Anything).RunAndReturn(describe)
+		s.mixcoord.EXPECT().DropSnapshot(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *datapb.DropSnapshotRequest, options ...grpc.CallOption) (*commonpb.Status, error) {
+			s.Equal(collectionName, req.GetName())
+			s.Equal(int64(100), req.GetCollectionId())
+			return merr.Success(), nil
+		}).Once()
+
+		recorder := httptest.NewRecorder()
+		s.proxy.PurgeRecycleBin(recorder, newRequest(http.MethodPost, management.RoutePurgeRecycleBin+"?name="+collectionName, nil))
+		s.Equal(http.StatusOK, recorder.Code)
+
+		recorder = httptest.NewRecorder()
+		s.proxy.PurgeRecycleBin(recorder, newRequest(http.MethodPost, management.RoutePurgeRecycleBin+"?name=backup", nil))
+		s.Equal(http.StatusBadRequest, recorder.Code)
+
+		// the request with invalid credentials is rejected
+		req, err := http.NewRequest(http.MethodPost, management.RoutePurgeRecycleBin+"?name="+collectionName, nil)
+		s.Require().NoError(err)
+		req.SetBasicAuth(util.UserRoot, "wrong")
+		recorder = httptest.NewRecorder()
+		s.proxy.PurgeRecycleBin(recorder, req)
+		s.Equal(http.StatusUnauthorized, recorder.Code)
+
+		// the management apis are unavailable if authorization is disabled
+		paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "false")
+		defer paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+		recorder = httptest.NewRecorder()
+		s.proxy.PurgeRecycleBin(recorder, newRequest(http.MethodPost, management.RoutePurgeRecycleBin+"?name="+collectionName, nil))
+		s.Equal(http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,336 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	searchResultCacheName = "SearchResultCache"
+	queryResultCacheName  = "QueryResultCache"
+
+	resultCacheEvictCapacity = "capacity"
+	resultCacheEvictExpired  = "expired"
+	resultCacheEvictDML      = "dml"
+	resultCacheEvictMeta     = "meta"
+	resultCacheEvictRBAC     = "rbac"
+)
+
+// resultCacheEntry is a cached search or query result.
+type resultCacheEntry struct {
+	key          string
+	collectionID UniqueID
+	pchans       []pChan
+	// ts is the timestamp before which all the writes are visible to the result
+	ts       Timestamp
+	expireAt time.Time
+	data     []byte // marshaled result
+}
+
+func (e *resultCacheEntry) size() int64 {
+	return int64(len(e.key) + len(e.data))
+}
+
+// resultCache is a LRU cache of search and query results, so identical requests
+// such as dashboard refreshes and retries could be served without searching the shards again.
+//
+// A result is honored only if it's fresh enough for the guarantee timestamp of the request,
+// and it's dropped once a DML on the physical channels of its collection is observed by the time ticks or done,
+// the collection is loaded, released or altered, or the rbac meta such as the roles and row policies is refreshed.
+// The writes through other proxies are not observed, the staleness is bounded by the TTL of entries then.
+type resultCache struct {
+	mu           sync.Mutex
+	entries      map[string]*list.Element
+	lru          *list.List // front is the most recently used
+	size         int64
+	loadVersions map[UniqueID]uint64
+	dmlTicks     map[pChan]Timestamp // the max timestamp of DML observed or finished on pchan
+	// policyVersion is increased every time the rbac meta is refreshed,
+	// the results cached under the former roles and row policies are never hit then
+	policyVersion uint64
+}
+
+func newResultCache() *resultCache {
+	return &resultCache{
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+		loadVersions: make(map[UniqueID]uint64),
+		dmlTicks:     make(map[pChan]Timestamp),
+	}
+}
+
+// get unmarshals the cached result of key into result,
+// returns false if there is no cached result or it's older than guaranteeTs.
+func (c *resultCache) get(name string, key string, guaranteeTs Timestamp, result proto.Message) bool {
+	hit := c.doGet(key, guaranteeTs, result)
+	state := metrics.CacheMissLabel
+	if hit {
+		state = metrics.CacheHitLabel
+	}
+	metrics.ProxyCacheStatsCounter.WithLabelValues(paramtable.GetStringNodeID(), name, state).Inc()
+	return hit
+}
+
+func (c *resultCache) doGet(key string, guaranteeTs Timestamp, result proto.Message) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	entry := elem.Value.(*resultCacheEntry)
+	if time.Now().After(entry.expireAt) {
+		c.remove(elem, resultCacheEvictExpired)
+		c.updateMetrics()
+		return false
+	}
+	if entry.ts < guaranteeTs {
+		return false
+	}
+	if err := proto.Unmarshal(entry.data, result); err != nil {
+		c.remove(elem, resultCacheEvictExpired)
+		c.updateMetrics()
+		return false
+	}
+	c.lru.MoveToFront(elem)
+	return true
+}
+
+// put caches the result of key, which contains all the writes before ts on pchans.
+func (c *resultCache) put(key string, collectionID UniqueID, pchans []pChan, ts Timestamp, result proto.Message) {
+	data, err := proto.Marshal(result)
+	if err != nil {
+		mlog.Warn(context.TODO(), "failed to marshal result to cache", mlog.Int64("collectionID", collectionID), mlog.Err(err))
+		return
+	}
+	entry := &resultCacheEntry{
+		key:          key,
+		collectionID: collectionID,
+		pchans:       pchans,
+		ts:           ts,
+		expireAt:     time.Now().Add(paramtable.Get().ProxyCfg.ResultCacheTTL.GetAsDuration(time.Second)),
+		data:         data,
+	}
+	maxEntrySize := paramtable.Get().ProxyCfg.ResultCacheMaxEntrySize.GetAsInt64() * 1024 * 1024
+	maxMemSize := paramtable.Get().ProxyCfg.ResultCacheMaxMemSize.GetAsInt64() * 1024 * 1024
+	if entry.size() > maxEntrySize || entry.size() > maxMemSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, pchan := range pchans {
+		if c.dmlTicks[pchan] > ts {
+			// the result has been invalidated by DML while searching
+			return
+		}
+	}
+	if elem, ok := c.entries[key]; ok {
+		if elem.Value.(*resultCacheEntry).ts > ts {
+			// a fresher result has been cached
+			return
+		}
+		c.remove(elem, "")
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += entry.size()
+	for c.size > maxMemSize {
+		c.remove(c.lru.Back(), resultCacheEvictCapacity)
+	}
+	c.updateMetrics()
+}
+
+// loadVersion returns the version of collection, which is increased every time the collection is invalidated.
+func (c *resultCache) loadVersion(collectionID UniqueID) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.loadVersions[collectionID]
+}
+
+// invalidateCollection drops all the cached results of the collection,
+// it's called when the collection is loaded, released, altered or dropped.
+func (c *resultCache) invalidateCollection(collectionID UniqueID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loadVersions[collectionID]++
+	c.removeIf(func(entry *resultCacheEntry) bool {
+		return entry.collectionID == collectionID
+	}, resultCacheEvictMeta)
+}
+
+// getPolicyVersion returns the version of the rbac meta.
+func (c *resultCache) getPolicyVersion() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.policyVersion
+}
+
+// invalidatePolicy drops all the cached results,
+// it's called when the rbac meta is refreshed, since the roles of users and the row policies may be changed.
+func (c *resultCache) invalidatePolicy() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.policyVersion++
+	c.removeIf(func(entry *resultCacheEntry) bool {
+		return true
+	}, resultCacheEvictRBAC)
+}
+
+// invalidateChannel drops the cached results which don't contain the writes on pchan before ts.
+func (c *resultCache) invalidateChannel(pchan pChan, ts Timestamp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ts <= c.dmlTicks[pchan] {
+		return
+	}
+	c.dmlTicks[pchan] = ts
+	c.removeIf(func(entry *resultCacheEntry) bool {
+		if entry.ts >= ts {
+			return false
+		}
+		for _, ch := range entry.pchans {
+			if ch == pchan {
+				return true
+			}
+		}
+		return false
+	}, resultCacheEvictDML)
+}
+
+func (c *resultCache) removeIf(predicate func(entry *resultCacheEntry) bool, reason string) {
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if predicate(elem.Value.(*resultCacheEntry)) {
+			c.remove(elem, reason)
+		}
+		elem = next
+	}
+	c.updateMetrics()
+}
+
+func (c *resultCache) remove(elem *list.Element, reason string) {
+	entry := c.lru.Remove(elem).(*resultCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size()
+	if reason != "" {
+		metrics.ProxyResultCacheEvictCount.WithLabelValues(paramtable.GetStringNodeID(), reason).Inc()
+	}
+}
+
+func (c *resultCache) updateMetrics() {
+	metrics.ProxyResultCacheSize.WithLabelValues(paramtable.GetStringNodeID()).Set(float64(c.size))
+	metrics.ProxyResultCacheEntryNum.WithLabelValues(paramtable.GetStringNodeID()).Set(float64(c.lru.Len()))
+}
+
+// resultCacheState is the result cache state of a search or query task.
+type resultCacheState struct {
+	cache  *resultCache // nil if the result cache is disabled
+	key    string
+	pchans []pChan
+	hit    bool
+}
+
+// lookup builds the cache key of request, and fills result with the cached result if it's fresh enough for guaranteeTs.
+func (s *resultCacheState) lookup(ctx context.Context, name string, collection *collectionInfo, request proto.Message, guaranteeTs Timestamp, result proto.Message) bool {
+	if s.cache == nil {
+		return false
+	}
+	username, _ := GetCurUserFromContext(ctx)
+	key, err := resultCacheKey(username, collection, s.cache.loadVersion(collection.collID), s.cache.getPolicyVersion(), request)
+	if err != nil {
+		mlog.Warn(ctx, "failed to build result cache key", mlog.Int64("collectionID", collection.collID), mlog.Err(err))
+		return false
+	}
+	s.key = key
+	s.pchans = collection.pChannels
+	s.hit = s.cache.get(name, key, guaranteeTs, result)
+	return s.hit
+}
+
+// store caches the result which contains all the writes before ts, unless it's from the cache.
+func (s *resultCacheState) store(collectionID UniqueID, ts Timestamp, result proto.Message) {
+	if s.cache == nil || s.key == "" || s.hit {
+		return
+	}
+	s.cache.put(s.key, collectionID, s.pchans, ts, result)
+}
+
+// resultCacheKey returns the cache key of a search or query request.
+// The fields which don't affect the result, such as the consistency level, are excluded,
+// and the collection schema, load and rbac versions are included, so the results before the meta changes are never hit.
+func resultCacheKey(username string, collection *collectionInfo, loadVersion uint64, policyVersion uint64, request proto.Message) (string, error) {
+	request = proto.Clone(request)
+	switch req := request.(type) {
+	case *milvuspb.SearchRequest:
+		req.Base = nil
+		req.TravelTimestamp = 0
+		req.GuaranteeTimestamp = 0
+		req.ConsistencyLevel = commonpb.ConsistencyLevel_Strong
+		req.UseDefaultConsistency = false
+		sortKeyValuePairsByKey(req.SearchParams)
+		sort.Strings(req.PartitionNames)
+		for _, subReq := range req.GetSubReqs() {
+			sortKeyValuePairsByKey(subReq.SearchParams)
+		}
+	case *milvuspb.QueryRequest:
+		req.Base = nil
+		req.TravelTimestamp = 0
+		req.GuaranteeTimestamp = 0
+		req.ConsistencyLevel = commonpb.ConsistencyLevel_Strong
+		req.UseDefaultConsistency = false
+		sortKeyValuePairsByKey(req.QueryParams)
+		sort.Strings(req.PartitionNames)
+	default:
+		return "", fmt.Errorf("unexpected request type %T to cache", request)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(data)
+	return fmt.Sprintf("%d/%s/%d/%d/%d/%d/%s", collection.collID, username,
+		collection.schema.GetVersion(), collection.updateTimestamp, loadVersion, policyVersion, hex.EncodeToString(digest[:])), nil
+}
+
+// sortKeyValuePairsByKey sorts the pairs by key, the pairs with the same key keep their order.
+func sortKeyValuePairsByKey(pairs []*commonpb.KeyValuePair) {
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].GetKey() < pairs[j].GetKey()
+	})
+}
@@ -0,0 +1,291 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newTestCachedResult(ids ...int64) *milvuspb.SearchResults {
+	return &milvuspb.SearchResults{
+		Status: merr.Success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       int64(len(ids)),
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		},
+	}
+}
+
+func TestResultCache_GetPut(t *testing.T) {
+	paramtable.Init()
+	cache := newResultCache()
+	cache.put("key", 1, []pChan{"pchan1"}, 100, newTestCachedResult(1, 2))
+
+	result := &milvuspb.SearchResults{}
+	assert.False(t, cache.get(searchResultCacheName, "other", 0, result))
+
+	// the cached result isn't fresh enough for the guarantee timestamp
+	assert.False(t, cache.get(searchResultCacheName, "key", 101, result))
+
+	assert.True(t, cache.get(searchResultCacheName, "key", 100, result))
+	assert.Equal(t, []int64{1, 2}, result.GetResults().GetIds().GetIntId().GetData())
+
+	// the returned result is a copy
+	result.Results.Ids.GetIntId().Data[0] = 10
+	result = &milvuspb.SearchResults{}
+	assert.True(t, cache.get(searchResultCacheName, "key", 1, result))
+	assert.Equal(t, []int64{1, 2}, result.GetResults().GetIds().GetIntId().GetData())
+
+	// an older result doesn't replace the fresher one
+	cache.put("key", 1, []pChan{"pchan1"}, 50, newTestCachedResult(3))
+	result = &milvuspb.SearchResults{}
+	assert.True(t, cache.get(searchResultCacheName, "key", 100, result))
+	assert.Equal(t, []int64{1, 2}, result.GetResults().GetIds().GetIntId().GetData())
+
+	cache.put("key", 1, []pChan{"pchan1"}, 200, newTestCachedResult(3))
+	result = &milvuspb.SearchResults{}
+	assert.True(t, cache.get(searchResultCacheName, "key", 200, result))
+	assert.Equal(t, []int64{3}, result.GetResults().GetIds().GetIntId().GetData())
+	assert.Equal(t, 1, cache.lru.Len())
+}
+
+func TestResultCache_Expire(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.ResultCacheTTL.Key, "0")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.ResultCacheTTL.Key)
+
+	cache := newResultCache()
+	cache.put("key", 1, []pChan{"pchan1"}, 100, newTestCachedResult(1))
+	assert.False(t, cache.get(searchResultCacheName, "key", 0, &milvuspb.SearchResults{}))
+	assert.Equal(t, 0, cache.lru.Len())
+	assert.Equal(t, int64(0), cache.size)
+}
+
+func TestResultCache_Capacity(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.ResultCacheMaxMemSize.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.ResultCacheMaxMemSize.Key)
+
+	newIDs := func(n int) []int64 {
+		ids := make([]int64, n)
+		for i := range ids {
+			ids[i] = 1<<40 + int64(i)
+		}
+		return ids
+	}
+	// each result is about 420KB
+	ids := newIDs(70000)
+	cache := newResultCache()
+	cache.put("key1", 1, nil, 100, newTestCachedResult(ids...))
+	cache.put("key2", 1, nil, 100, newTestCachedResult(ids...))
+	assert.True(t, cache.get(searchResultCacheName, "key1", 0, &milvuspb.SearchResults{}))
+
+	// key2 is the least recently used one
+	cache.put("key3", 1, nil, 100, newTestCachedResult(ids...))
+	assert.False(t, cache.get(searchResultCacheName, "key2", 0, &milvuspb.SearchResults{}))
+	assert.True(t, cache.get(searchResultCacheName, "key1", 0, &milvuspb.SearchResults{}))
+	assert.True(t, cache.get(searchResultCacheName, "key3", 0, &milvuspb.SearchResults{}))
+	assert.LessOrEqual(t, cache.size, int64(1024*1024))
+
+	// too large to cache
+	cache.put("key4", 1, nil, 100, newTestCachedResult(newIDs(200000)...))
+	assert.False(t, cache.get(searchResultCacheName, "key4", 0, &milvuspb.SearchResults{}))
+	assert.Equal(t, 2, cache.lru.Len())
+}
+
+func TestResultCache_Invalidate(t *testing.T) {
+	paramtable.Init()
+	cache := newResultCache()
+	cache.put("key1", 1, []pChan{"pchan1"}, 100, newTestCachedResult(1))
+	cache.put("key2", 1, []pChan{"pchan1"}, 300, newTestCachedResult(2))
+	cache.put("key3", 2, []pChan{"pchan2"}, 100, newTestCachedResult(3))
+
+	t.Run("invalidate channel", func(t *testing.T) {
+		cache.invalidateChannel("pchan1", 200)
+		assert.False(t, cache.get(searchResultCacheName, "key1", 0, &milvuspb.SearchResults{}))
+		assert.True(t, cache.get(searchResultCacheName, "key2", 0, &milvuspb.SearchResults{}))
+		assert.True(t, cache.get(searchResultCacheName, "key3", 0, &milvuspb.SearchResults{}))
+
+		// the result searched before the DML isn't cached
+		cache.put("key1", 1, []pChan{"pchan1"}, 150, newTestCachedResult(1))
+		assert.False(t, cache.get(searchResultCacheName, "key1", 0, &milvuspb.SearchResults{}))
+	})
+
+	t.Run("invalidate collection", func(t *testing.T) {
+		assert.Equal(t, uint64(0), cache.loadVersion(1))
+		cache.invalidateCollection(1)
+		assert.Equal(t, uint64(1), cache.loadVersion(1))
+		assert.Equal(t, uint64(0), cache.loadVersion(2))
+		assert.False(t, cache.get(searchResultCacheName, "key2", 0, &milvuspb.SearchResults{}))
+		assert.True(t, cache.get(searchResultCacheName, "key3", 0, &milvuspb.SearchResults{}))
+	})
+}
+
+func TestResultCacheKey(t *testing.T) {
+	collection := &collectionInfo{
+		collID:          1,
+		schema:          &schemaInfo{CollectionSchema: &schemapb.CollectionSchema{Version: 2}},
+		updateTimestamp: 100,
+	}
+	newRequest := func() *milvuspb.SearchRequest {
+		return &milvuspb.SearchRequest{
+			Base:           &commonpb.MsgBase{MsgID: 1},
+			CollectionName: "test",
+			PartitionNames: []string{"p1", "p2"},
+			Dsl:            "a > 1",
+			SearchParams: []*commonpb.KeyValuePair{
+				{Key: "topk", Value: "10"},
+				{Key: "anns_field", Value: "vec"},
+			},
+			GuaranteeTimestamp: 1000,
+			ConsistencyLevel:   commonpb.ConsistencyLevel_Bounded,
+		}
+	}
+
+	key, err := resultCacheKey("root", collection, 0, 0, newRequest())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, "1/root/2/100/0/0/"))
+
+	// the fields which don't affect the result are ignored
+	request := newRequest()
+	request.Base = &commonpb.MsgBase{MsgID: 2}
+	request.PartitionNames = []string{"p2", "p1"}
+	request.SearchParams = []*commonpb.KeyValuePair{request.SearchParams[1], request.SearchParams[0]}
+	request.GuaranteeTimestamp = 2000
+	request.ConsistencyLevel = commonpb.ConsistencyLevel_Eventually
+	request.UseDefaultConsistency = true
+	key2, err := resultCacheKey("root", collection, 0, 0, request)
+	require.NoError(t, err)
+	assert.Equal(t, key, key2)
+	// the request is not modified
+	assert.Equal(t, []string{"p2", "p1"}, request.PartitionNames)
+
+	request = newRequest()
+	request.Dsl = "a > 2"
+	key2, err = resultCacheKey("root", collection, 0, 0, request)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, key2)
+
+	key2, err = resultCacheKey("user", collection, 0, 0, newRequest())
+	require.NoError(t, err)
+	assert.NotEqual(t, key, key2)
+
+	key2, err = resultCacheKey("root", collection, 1, 0, newRequest())
+	require.NoError(t, err)
+	assert.NotEqual(t, key, key2)
+
+	key2, err = resultCacheKey("root", collection, 0, 1, newRequest())
+	require.NoError(t, err)
+	assert.NotEqual(t, key, key2)
+
+	queryKey, err := resultCacheKey("root", collection, 0, 0, &milvuspb.QueryRequest{CollectionName: "test", Expr: "a > 1"})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, queryKey)
+
+	_, err = resultCacheKey("root", collection, 0, 0, &milvuspb.DeleteRequest{})
+	assert.Error(t, err)
+}
+
+func TestResultCacheState(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	collection := &collectionInfo{
+		collID:    1,
+		schema:    &schemaInfo{CollectionSchema: &schemapb.CollectionSchema{}},
+		pChannels: []string{"pchan1"},
+	}
+	request := &milvuspb.QueryRequest{CollectionName: "test", Expr: "a > 1"}
+
+	disabled := resultCacheState{}
+	assert.False(t, disabled.lookup(ctx, queryResultCacheName, collection, request, 0, &milvuspb.QueryResults{}))
+	disabled.store(1, 100, &milvuspb.QueryResults{})
+
+	cache := newResultCache()
+	state := resultCacheState{cache: cache}
+	assert.False(t, state.lookup(ctx, queryResultCacheName, collection, request, 0, &milvuspb.QueryResults{}))
+	state.store(1, 100, &milvuspb.QueryResults{CollectionName: "test"})
+	assert.Equal(t, 1, cache.lru.Len())
+
+	state = resultCacheState{cache: cache}
+	result := &milvuspb.QueryResults{}
+	assert.True(t, state.lookup(ctx, queryResultCacheName, collection, request, 100, result))
+	assert.Equal(t, "test", result.GetCollectionName())
+
+	// the channel is changed by DML
+	cache.invalidateChannel("pchan1", 200)
+	state = resultCacheState{cache: cache}
+	assert.False(t, state.lookup(ctx, queryResultCacheName, collection, request, 0, &milvuspb.QueryResults{}))
+
+	// the rbac meta is refreshed
+	state.store(1, 300, &milvuspb.QueryResults{CollectionName: "test"})
+	stale := resultCacheState{cache: cache}
+	require.True(t, stale.lookup(ctx, queryResultCacheName, collection, request, 300, &milvuspb.QueryResults{}))
+	cache.invalidatePolicy()
+	assert.Equal(t, 0, cache.lru.Len())
+	state = resultCacheState{cache: cache}
+	assert.False(t, state.lookup(ctx, queryResultCacheName, collection, request, 0, &milvuspb.QueryResults{}))
+	// the result searched under the former rbac meta is never hit
+	stale.hit = false
+	stale.store(1, 300, &milvuspb.QueryResults{CollectionName: "test"})
+	state = resultCacheState{cache: cache}
+	assert.False(t, state.lookup(ctx, queryResultCacheName, collection, request, 0, &milvuspb.QueryResults{}))
+}
+
+func TestResultCache_InvalidateOnDMLDone(t *testing.T) {
+	paramtable.Init()
+	tso := newMockTsoAllocator()
+	queue := newDmTaskQueue(tso)
+	cache := newResultCache()
+	queue.setDMLDoneListener(cache.invalidateChannel)
+
+	st := newDefaultMockDmlTask()
+	pchan := st.getChannels()[0]
+	searchTs, err := tso.AllocOne(context.Background())
+	require.NoError(t, err)
+	cache.put("key1", 1, []pChan{pchan}, searchTs, newTestCachedResult(1))
+	require.True(t, cache.get(searchResultCacheName, "key1", 0, &milvuspb.SearchResults{}))
+
+	require.NoError(t, queue.Enqueue(st))
+	queue.AddActiveTask(queue.PopUnissuedTask())
+	// the search done while the DML is writing isn't invalidated yet
+	cache.put("key2", 1, []pChan{pchan}, st.EndTs()+1, newTestCachedResult(2))
+	require.True(t, cache.get(searchResultCacheName, "key2", 0, &milvuspb.SearchResults{}))
+
+	// the DML is done before any time tick, its stats are popped together with the task
+	queue.PopActiveTask(st.ID())
+	stats, err := queue.getPChanStatsInfo()
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+	assert.False(t, cache.get(searchResultCacheName, "key1", 0, &milvuspb.SearchResults{}))
+	assert.False(t, cache.get(searchResultCacheName, "key2", 0, &milvuspb.SearchResults{}))
+
+	// the result searched before the DML is done isn't cached
+	cache.put("key2", 1, []pChan{pchan}, st.EndTs()+1, newTestCachedResult(2))
+	assert.False(t, cache.get(searchResultCacheName, "key2", 0, &milvuspb.SearchResults{}))
+}
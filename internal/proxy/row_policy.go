@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proxy/privilege"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// Row policies restrict the rows a role could access in a collection. They are set by altering
+// the collection properties keyed by common.CollectionRowPolicyKeyPrefix + role name, but kept with
+// the rbac meta and cached by the privilege cache, so they are never exposed as collection properties
+// and are dropped together with the role. The proxy ANDs the policies of the current user into the
+// filter of search, query, delete and upsert, and checks the upserted rows against them.
+// Policies of multiple roles are combined by logical or, and the users without any role having
+// a policy are denied once the collection has policies. The root user and the users with
+// admin role are not restricted.
+
+// getRowPolicyRoles returns the roles of the current user, exempt is true if the user is not
+// restricted by row policies.
+func getRowPolicyRoles(ctx context.Context) (roles []string, exempt bool, err error) {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return nil, true, nil
+	}
+	username, err := GetCurUserFromContext(ctx)
+	if err != nil {
+		return nil, false, merr.WrapErrPrivilegeNotAuthenticated("failed to get current user: %v", err)
+	}
+	if !Params.CommonCfg.RootShouldBindRole.GetAsBool() && username == util.UserRoot {
+		return nil, true, nil
+	}
+	roles, err = GetRole(username)
+	if err != nil {
+		return nil, false, err
+	}
+	if lo.Contains(roles, util.RoleAdmin) {
+		return roles, true, nil
+	}
+	return append(roles, util.RolePublic), false, nil
+}
+
+// getRowPolicy returns the row policy of the current user on the collection,
+// it's nil if the user is not restricted by row policies.
+func getRowPolicy(ctx context.Context, dbName, collectionName string, collectionID int64, schemaHelper *typeutil.SchemaHelper) (*planpb.Expr, error) {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return nil, nil
+	}
+	collInfo, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	privCache := privilege.GetPrivilegeCache()
+	if privCache == nil {
+		return nil, merr.WrapErrServiceUnavailable("internal: Milvus Proxy is not ready yet. please wait")
+	}
+	policies := privCache.GetRowPolicies(normalizeDBName(lo.CoalesceOrEmpty(collInfo.dbName, dbName)), collInfo.schema.GetName())
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	roles, exempt, err := getRowPolicyRoles(ctx)
+	if err != nil || exempt {
+		return nil, err
+	}
+
+	exprs := make([]*planpb.Expr, 0, len(roles))
+	for _, role := range lo.Uniq(roles) {
+		policy, ok := policies[role]
+		if !ok {
+			continue
+		}
+		expr, err := planparserv2.ParseExpr(schemaHelper, policy, nil)
+		if err != nil {
+			return nil, merr.WrapErrServiceInternalMsg("invalid row policy of role %s on collection %s: %v", role, collectionName, err)
+		}
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 0 {
+		return nil, merr.WrapErrPrivilegeNotPermitted("no row policy of roles %v on collection %s", roles, collectionName)
+	}
+	return planparserv2.OrExprs(exprs...), nil
+}
+
+// applyRowPolicy restricts the plan by the row policy of the current user on the collection.
+func applyRowPolicy(ctx context.Context, dbName, collectionName string, collectionID int64, schemaHelper *typeutil.SchemaHelper, plan *planpb.PlanNode) error {
+	policy, err := getRowPolicy(ctx, dbName, collectionName, collectionID, schemaHelper)
+	if err != nil || policy == nil {
+		return err
+	}
+	return planparserv2.AndPlanPredicates(plan, policy)
+}
+
+// validateRowPolicies checks the row policies to be set or deleted in the collection properties,
+// only the users not restricted by row policies could change them.
+func validateRowPolicies(ctx context.Context, schemaHelper *typeutil.SchemaHelper, props []*commonpb.KeyValuePair, deleteKeys []string) error {
+	policies := common.GetCollectionRowPolicies(props...)
+	deleted := lo.Filter(deleteKeys, func(key string, _ int) bool {
+		return common.IsCollectionRowPolicyKey(key)
+	})
+	if len(policies) == 0 && len(deleted) == 0 {
+		return nil
+	}
+	if _, exempt, err := getRowPolicyRoles(ctx); err != nil {
+		return err
+	} else if !exempt {
+		return merr.WrapErrPrivilegeNotPermitted("only root or admin role could change the row policies")
+	}
+
+	roles := lo.Keys(policies)
+	sort.Strings(roles)
+	for _, role := range roles {
+		if role == "" {
+			return merr.WrapErrParameterInvalidMsg("role name of row policy is empty, the key should be %s<role>", common.CollectionRowPolicyKeyPrefix)
+		}
+		policy := policies[role]
+		if strings.TrimSpace(policy) == "" {
+			return merr.WrapErrParameterInvalidMsg("row policy of role %s is empty", role)
+		}
+		if _, err := planparserv2.ParseExpr(schemaHelper, policy, nil); err != nil {
+			return merr.WrapErrParameterInvalidMsg("invalid row policy of role %s: %v", role, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/changestreampb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/proxy/privilege"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestRowPolicy(t *testing.T) {
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	client := &MockMixCoordClientInterface{}
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status: merr.Success(),
+			UserRoles: []string{
+				funcutil.EncodeUserRoleCache("alice", "tenant_a"),
+				funcutil.EncodeUserRoleCache("bob", "tenant_a"),
+				funcutil.EncodeUserRoleCache("bob", "tenant_b"),
+				funcutil.EncodeUserRoleCache("dave", util.RoleAdmin),
+			},
+			RowPolicies: []*internalpb.RowPolicy{
+				{RoleName: "tenant_a", DbName: util.DefaultDBName, CollectionName: "test", Expr: "tenant_id == 1"},
+				{RoleName: "tenant_b", DbName: util.DefaultDBName, CollectionName: "test", Expr: "tenant_id == 2"},
+			},
+		}, nil
+	}
+	err := privilege.InitPrivilegeCache(context.Background(), client)
+	assert.NoError(t, err)
+
+	schema := &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "tenant_id", DataType: schemapb.DataType_Int64},
+		},
+	}
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+	testSchema, err := newSchemaInfo(schema)
+	assert.NoError(t, err)
+	noPolicySchema, err := newSchemaInfo(&schemapb.CollectionSchema{Name: "no_policy", Fields: schema.GetFields()})
+	assert.NoError(t, err)
+
+	cache := NewMockCache(t)
+	cache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, "test", mock.Anything).Return(&collectionInfo{
+		dbName: util.DefaultDBName,
+		schema: testSchema,
+		// the row policies are never read from the collection properties.
+		properties: []*commonpb.KeyValuePair{
+			{Key: common.CollectionRowPolicyKeyPrefix + "tenant_c", Value: "tenant_id == 3"},
+		},
+	}, nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, "no_policy", mock.Anything).Return(&collectionInfo{
+		dbName: util.DefaultDBName,
+		schema: noPolicySchema,
+	}, nil).Maybe()
+	oldCache := globalMetaCache
+	globalMetaCache = cache
+	defer func() { globalMetaCache = oldCache }()
+
+	t.Run("get row policy", func(t *testing.T) {
+		policy, err := getRowPolicy(GetContext(context.Background(), "alice:123456"), "", "test", 1, schemaHelper)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(101), policy.GetUnaryRangeExpr().GetColumnInfo().GetFieldId())
+
+		// policies of multiple roles are combined by or
+		policy, err = getRowPolicy(GetContext(context.Background(), "bob:123456"), "", "test", 1, schemaHelper)
+		assert.NoError(t, err)
+		assert.Equal(t, planpb.BinaryExpr_LogicalOr, policy.GetBinaryExpr().GetOp())
+
+		// the user without policy is denied
+		_, err = getRowPolicy(GetContext(context.Background(), "carol:123456"), "", "test", 1, schemaHelper)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+
+		// root and admin are not restricted
+		for _, user := range []string{"root:123456", "dave:123456"} {
+			policy, err = getRowPolicy(GetContext(context.Background(), user), "", "test", 1, schemaHelper)
+			assert.NoError(t, err)
+			assert.Nil(t, policy)
+		}
+
+		policy, err = getRowPolicy(GetContext(context.Background(), "carol:123456"), "", "no_policy", 1, schemaHelper)
+		assert.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("change stream", func(t *testing.T) {
+		req := &changestreampb.SubscribeChangesRequest{CollectionName: "test", Filter: "id > 10"}
+		_, err := newChangeStreamDecoder(GetContext(context.Background(), "alice:123456"), req, 1, testSchema)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+
+		decoder, err := newChangeStreamDecoder(GetContext(context.Background(), "root:123456"), req, 1, testSchema)
+		assert.NoError(t, err)
+		assert.NotNil(t, decoder.filter)
+	})
+
+	t.Run("apply row policy", func(t *testing.T) {
+		plan, err := planparserv2.CreateRetrievePlan(schemaHelper, "id > 10", nil)
+		assert.NoError(t, err)
+		err = applyRowPolicy(GetContext(context.Background(), "alice:123456"), "", "test", 1, schemaHelper, plan)
+		assert.NoError(t, err)
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, plan.GetQuery().GetPredicates().GetBinaryExpr().GetOp())
+
+		plan, err = planparserv2.CreateRetrievePlan(schemaHelper, "id > 10", nil)
+		assert.NoError(t, err)
+		err = applyRowPolicy(GetContext(context.Background(), "carol:123456"), "", "test", 1, schemaHelper, plan)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	})
+
+	t.Run("check upserted rows", func(t *testing.T) {
+		int64FieldData := func(fieldID int64, data []int64) *schemapb.FieldData {
+			return &schemapb.FieldData{
+				Type:    schemapb.DataType_Int64,
+				FieldId: fieldID,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}},
+				}},
+			}
+		}
+		newTask := func(tenantIDs ...int64) *upsertTask {
+			ids := make([]int64, len(tenantIDs))
+			for i := range tenantIDs {
+				ids[i] = int64(i)
+			}
+			return &upsertTask{
+				req:          &milvuspb.UpsertRequest{CollectionName: "test"},
+				collectionID: 1,
+				schema:       testSchema,
+				upsertMsg: &msgstream.UpsertMsg{
+					InsertMsg: &msgstream.InsertMsg{InsertRequest: &msgpb.InsertRequest{
+						NumRows: uint64(len(tenantIDs)),
+						FieldsData: []*schemapb.FieldData{
+							int64FieldData(100, ids),
+							int64FieldData(101, tenantIDs),
+						},
+					}},
+					DeleteMsg: &msgstream.DeleteMsg{DeleteRequest: &msgpb.DeleteRequest{
+						PrimaryKeys: &schemapb.IDs{},
+					}},
+				},
+			}
+		}
+		aliceCtx := GetContext(context.Background(), "alice:123456")
+		bobCtx := GetContext(context.Background(), "bob:123456")
+
+		assert.NoError(t, newTask(1, 1).checkRowPolicy(aliceCtx))
+		assert.ErrorIs(t, newTask(1, 2).checkRowPolicy(aliceCtx), merr.ErrPrivilegeNotPermitted)
+		assert.NoError(t, newTask(1, 2).checkRowPolicy(bobCtx))
+		assert.ErrorIs(t, newTask(3).checkRowPolicy(bobCtx), merr.ErrPrivilegeNotPermitted)
+	})
+
+	t.Run("validate row policies", func(t *testing.T) {
+		adminCtx := GetContext(context.Background(), "dave:123456")
+		aliceCtx := GetContext(context.Background(), "alice:123456")
+		policyKey := common.CollectionRowPolicyKeyPrefix + "tenant_c"
+
+		assert.NoError(t, validateRowPolicies(aliceCtx, schemaHelper, []*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "10"}}, nil))
+		assert.NoError(t, validateRowPolicies(adminCtx, schemaHelper, []*commonpb.KeyValuePair{{Key: policyKey, Value: "tenant_id in [3, 4]"}}, nil))
+		assert.NoError(t, validateRowPolicies(adminCtx, schemaHelper, nil, []string{policyKey}))
+
+		// only admin could change the policies
+		err := validateRowPolicies(aliceCtx, schemaHelper, []*commonpb.KeyValuePair{{Key: policyKey, Value: "tenant_id == 3"}}, nil)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+		err = validateRowPolicies(aliceCtx, schemaHelper, nil, []string{policyKey})
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+
+		err = validateRowPolicies(adminCtx, schemaHelper, []*commonpb.KeyValuePair{{Key: policyKey, Value: "unknown == 3"}}, nil)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		err = validateRowPolicies(adminCtx, schemaHelper, []*commonpb.KeyValuePair{{Key: policyKey, Value: " "}}, nil)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		err = validateRowPolicies(adminCtx, schemaHelper, []*commonpb.KeyValuePair{{Key: common.CollectionRowPolicyKeyPrefix, Value: "tenant_id == 3"}}, nil)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("authorization disabled", func(t *testing.T) {
+		paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "false")
+		defer paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+
+		policy, err := getRowPolicy(context.Background(), "", "test", 1, schemaHelper)
+		assert.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+}
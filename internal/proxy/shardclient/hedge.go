@@ -0,0 +1,360 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const (
+	// hedgeLatencyWindow is the number of the recent request latencies to compute the percentile.
+	hedgeLatencyWindow = 1000
+	// hedgeMinSamples is the min number of latency samples before hedging any request.
+	hedgeMinSamples = 100
+	// hedgeRefreshInterval is the number of samples between two percentile computations.
+	hedgeRefreshInterval = 50
+	// hedgeMaxTokens caps the budget saved up during idle time, so hedging can't burst.
+	hedgeMaxTokens = 10
+	// hedgerIdleTimeout is how long the hedger of a channel is kept without any request,
+	// the channels are released or moved to other proxies after that.
+	hedgerIdleTimeout = 10 * time.Minute
+)
+
+// errHedgeLost is the cancel cause of the attempt whose result is discarded since the other attempt won.
+var errHedgeLost = errors.New("hedged request lost")
+
+// hedgeGuard makes sure only one of the attempts of a hedged request takes effect.
+type hedgeGuard struct {
+	// winner is the index of the winning attempt plus one, 0 if no attempt wins yet.
+	winner atomic.Int32
+}
+
+// claim tries to make the attempt of index the winner, return true if it's the winner.
+func (g *hedgeGuard) claim(index int32) bool {
+	return g.winner.CompareAndSwap(0, index+1) || g.winner.Load() == index+1
+}
+
+type hedgeAttemptKey struct{}
+
+// hedgeAttempt is one of the attempts of a hedged request, carried by the context passed to ExecuteFunc.
+type hedgeAttempt struct {
+	guard *hedgeGuard
+	index int32
+}
+
+// ClaimHedgedResult must be called by the ExecuteFunc of a hedged workload before it applies the result,
+// the result should be discarded if false is returned since the result of the other attempt has been taken.
+// It always returns true if the request is not hedged.
+func ClaimHedgedResult(ctx context.Context) bool {
+	attempt, ok := ctx.Value(hedgeAttemptKey{}).(*hedgeAttempt)
+	if !ok {
+		return true
+	}
+	return attempt.guard.claim(attempt.index)
+}
+
+// IsHedgeLost returns whether the request is canceled since the other attempt of the hedged request won.
+func IsHedgeLost(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errHedgeLost)
+}
+
+// channelHedgers keeps a hedger per channel, so the latency percentile and the hedge budget
+// of a channel are not affected by the requests of the other collections and channels.
+type channelHedgers struct {
+	mu          sync.Mutex
+	hedgers     map[string]*hedger
+	lastCleanup time.Time
+}
+
+func newChannelHedgers() *channelHedgers {
+	return &channelHedgers{
+		hedgers:     make(map[string]*hedger),
+		lastCleanup: time.Now(),
+	}
+}
+
+// get returns the hedger of the channel, the hedgers idle for hedgerIdleTimeout are removed.
+func (c *channelHedgers) get(channel string) *hedger {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastCleanup) > hedgerIdleTimeout {
+		for name, h := range c.hedgers {
+			if now.Sub(h.lastUsed) > hedgerIdleTimeout {
+				delete(c.hedgers, name)
+				metrics.ProxyShardRequestHedgeDelay.DeleteLabelValues(name)
+			}
+		}
+		c.lastCleanup = now
+	}
+
+	h, ok := c.hedgers[channel]
+	if !ok {
+		h = newHedger(channel)
+		c.hedgers[channel] = h
+	}
+	h.lastUsed = now
+	return h
+}
+
+// hedger tracks the latency percentile of the shard requests of a channel and the budget to hedge them.
+type hedger struct {
+	channel string
+	// lastUsed is protected by the mutex of channelHedgers.
+	lastUsed time.Time
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+	pending   int
+	tokens    float64
+
+	// threshold is the latency percentile of the recent requests, 0 if there isn't enough samples.
+	threshold atomic.Duration
+}
+
+func newHedger(channel string) *hedger {
+	return &hedger{
+		channel:   channel,
+		latencies: make([]time.Duration, 0, hedgeLatencyWindow),
+	}
+}
+
+// observe records the latency of a successful request, and refreshes the threshold periodically.
+func (h *hedger) observe(latency time.Duration, percentile float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < hedgeLatencyWindow {
+		h.latencies = append(h.latencies, latency)
+	} else {
+		h.latencies[h.next] = latency
+	}
+	h.next = (h.next + 1) % hedgeLatencyWindow
+	h.pending++
+
+	if len(h.latencies) < hedgeMinSamples || h.pending < hedgeRefreshInterval {
+		return
+	}
+	h.pending = 0
+	sorted := slices.Clone(h.latencies)
+	slices.Sort(sorted)
+	idx := int(math.Ceil(float64(len(sorted))*percentile/100)) - 1
+	idx = min(max(idx, 0), len(sorted)-1)
+	h.threshold.Store(sorted[idx])
+	metrics.ProxyShardRequestHedgeDelay.WithLabelValues(h.channel).Set(float64(sorted[idx].Microseconds()) / 1000.0)
+}
+
+// delay returns how long to wait before hedging a request, false if there isn't enough samples.
+func (h *hedger) delay(minDelay time.Duration) (time.Duration, bool) {
+	threshold := h.threshold.Load()
+	if threshold <= 0 {
+		return 0, false
+	}
+	return max(threshold, minDelay), true
+}
+
+// deposit adds ratio tokens into the budget for each hedge eligible request.
+func (h *hedger) deposit(ratio float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens = min(h.tokens+ratio, hedgeMaxTokens)
+}
+
+// withdraw takes one token from the budget, return false if the budget is exhausted.
+func (h *hedger) withdraw() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+// hedgeResult is the result of an attempt of a hedged request.
+type hedgeResult struct {
+	index   int32
+	nodeID  int64
+	err     error
+	latency time.Duration
+}
+
+// executeHedged executes the workload on the target node, and sends a duplicate request to another replica
+// if the request is not done after the latency percentile of the recent requests.
+// The first successful result is taken and the other attempt is canceled.
+// The error of the target node is returned if all attempts fail, so the caller could handle it as usual.
+func (lb *LBPolicyImpl) executeHedged(ctx context.Context, balancer LBBalancer, workload ChannelWorkload,
+	targetNode NodeInfo, client types.QueryNodeClient, excludeNodes *typeutil.UniqueSet,
+) error {
+	log := mlog.With(
+		mlog.Int64("collectionID", workload.CollectionID),
+		mlog.String("channelName", workload.Channel),
+	)
+	pt := paramtable.Get()
+	percentile := pt.ProxyCfg.HedgeLatencyPercentile.GetAsFloat()
+	h := lb.hedgers.get(workload.Channel)
+	h.deposit(pt.ProxyCfg.HedgeBudgetRatio.GetAsFloat())
+
+	delay, ok := h.delay(pt.ProxyCfg.HedgeMinDelay.GetAsDurationByParse())
+	if !ok {
+		start := time.Now()
+		err := workload.Exec(ctx, targetNode.NodeID, client, workload.Channel)
+		if err == nil {
+			h.observe(time.Since(start), percentile)
+		}
+		return err
+	}
+
+	guard := &hedgeGuard{}
+	results := make(chan hedgeResult, 2)
+	cancels := make([]context.CancelCauseFunc, 0, 2)
+	defer func() {
+		for _, cancel := range cancels {
+			cancel(errHedgeLost)
+		}
+	}()
+	attempt := func(index int32, nodeID int64, client types.QueryNodeClient) {
+		attemptCtx, cancel := context.WithCancelCause(ctx)
+		cancels = append(cancels, cancel)
+		attemptCtx = context.WithValue(attemptCtx, hedgeAttemptKey{}, &hedgeAttempt{guard: guard, index: index})
+		go func() {
+			start := time.Now()
+			err := workload.Exec(attemptCtx, nodeID, client, workload.Channel)
+			results <- hedgeResult{index: index, nodeID: nodeID, err: err, latency: time.Since(start)}
+		}()
+	}
+
+	attempt(0, targetNode.NodeID, client)
+	pending := 1
+	hedged := false
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var primaryErr error
+	for {
+		select {
+		case <-timer.C:
+			hedgeNode, hedgeClient, ok := lb.selectHedgeNode(ctx, balancer, h, workload, targetNode.NodeID, excludeNodes)
+			if !ok {
+				continue
+			}
+			defer balancer.CancelWorkload(hedgeNode, workload.Nq)
+			log.Debug(ctx, "hedge search/query channel",
+				mlog.Int64("nodeID", targetNode.NodeID),
+				mlog.Int64("hedgeNodeID", hedgeNode),
+				mlog.Duration("delay", delay))
+			attempt(1, hedgeNode, hedgeClient)
+			pending++
+			hedged = true
+
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if !guard.claim(result.index) {
+					// the other attempt has taken its result, wait for it
+					continue
+				}
+				h.observe(result.latency, percentile)
+				if hedged {
+					if result.index == 1 {
+						metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeWonLabel).Inc()
+					} else {
+						metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeLostLabel).Inc()
+					}
+				}
+				return nil
+			}
+
+			if result.index == 0 {
+				primaryErr = result.err
+				// no need to wait for the hedged request if the request itself is invalid
+				if !hedged || merr.GetErrorType(result.err) == merr.InputError {
+					return result.err
+				}
+			} else {
+				log.Warn(ctx, "hedged search/query channel failed",
+					mlog.Int64("nodeID", result.nodeID),
+					mlog.Err(result.err))
+			}
+			if pending == 0 {
+				if primaryErr != nil {
+					return primaryErr
+				}
+				return result.err
+			}
+		}
+	}
+}
+
+// selectHedgeNode selects a serviceable replica other than the target node to send the hedged request,
+// return false if there is no such replica or the hedge budget is exhausted.
+func (lb *LBPolicyImpl) selectHedgeNode(ctx context.Context, balancer LBBalancer, h *hedger, workload ChannelWorkload,
+	targetNodeID int64, excludeNodes *typeutil.UniqueSet,
+) (int64, types.QueryNodeClient, bool) {
+	shardLeaders, err := lb.clientMgr.GetShard(ctx, true, workload.Db, workload.CollectionName, workload.CollectionID, workload.Channel)
+	if err != nil {
+		metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeNoReplicaLabel).Inc()
+		return 0, nil, false
+	}
+	candidates := lo.SliceToMap(lo.Filter(shardLeaders, func(node NodeInfo, _ int) bool {
+		return node.NodeID != targetNodeID && node.Serviceable && !excludeNodes.Contain(node.NodeID)
+	}), func(node NodeInfo) (int64, NodeInfo) {
+		return node.NodeID, node
+	})
+	if len(candidates) == 0 {
+		metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeNoReplicaLabel).Inc()
+		return 0, nil, false
+	}
+	if !h.withdraw() {
+		metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeBudgetExhaustedLabel).Inc()
+		return 0, nil, false
+	}
+
+	nodeID, err := balancer.SelectNode(ctx, lo.Keys(candidates), workload.Nq)
+	if err != nil {
+		return 0, nil, false
+	}
+	node, ok := candidates[nodeID]
+	if !ok {
+		balancer.CancelWorkload(nodeID, workload.Nq)
+		return 0, nil, false
+	}
+	client, err := lb.clientMgr.GetClient(ctx, node)
+	if err != nil {
+		balancer.CancelWorkload(nodeID, workload.Nq)
+		return 0, nil, false
+	}
+	metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeIssuedLabel).Inc()
+	return nodeID, client, true
+}
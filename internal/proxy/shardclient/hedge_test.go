@@ -0,0 +1,118 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedger(t *testing.T) {
+	t.Run("latency percentile", func(t *testing.T) {
+		h := newHedger("ch1")
+		for i := 1; i < hedgeMinSamples; i++ {
+			h.observe(time.Duration(i)*time.Millisecond, 95)
+		}
+		_, ok := h.delay(time.Millisecond)
+		assert.False(t, ok)
+
+		h.observe(hedgeMinSamples*time.Millisecond, 95)
+		delay, ok := h.delay(time.Millisecond)
+		assert.True(t, ok)
+		assert.Equal(t, 95*time.Millisecond, delay)
+
+		delay, ok = h.delay(time.Second)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, delay)
+	})
+
+	t.Run("latency window", func(t *testing.T) {
+		h := newHedger("ch1")
+		for i := 0; i < hedgeLatencyWindow; i++ {
+			h.observe(time.Second, 50)
+		}
+		for i := 0; i < hedgeLatencyWindow; i++ {
+			h.observe(time.Millisecond, 50)
+		}
+		assert.Len(t, h.latencies, hedgeLatencyWindow)
+		delay, ok := h.delay(0)
+		assert.True(t, ok)
+		assert.Equal(t, time.Millisecond, delay)
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		h := newHedger("ch1")
+		assert.False(t, h.withdraw())
+		for i := 0; i < 4; i++ {
+			h.deposit(0.25)
+		}
+		assert.True(t, h.withdraw())
+		assert.False(t, h.withdraw())
+
+		for i := 0; i < 100; i++ {
+			h.deposit(1)
+		}
+		for i := 0; i < hedgeMaxTokens; i++ {
+			assert.True(t, h.withdraw())
+		}
+		assert.False(t, h.withdraw())
+	})
+}
+
+func TestChannelHedgers(t *testing.T) {
+	hedgers := newChannelHedgers()
+	h1 := hedgers.get("ch1")
+	assert.Same(t, h1, hedgers.get("ch1"))
+
+	// the latency and budget of a channel are not shared with the other channels.
+	h1.threshold.Store(time.Second)
+	h1.deposit(1)
+	h2 := hedgers.get("ch2")
+	assert.NotSame(t, h1, h2)
+	_, ok := h2.delay(0)
+	assert.False(t, ok)
+	assert.False(t, h2.withdraw())
+
+	// the idle hedgers are removed.
+	hedgers.hedgers["ch2"].lastUsed = time.Now().Add(-2 * hedgerIdleTimeout)
+	hedgers.lastCleanup = time.Now().Add(-2 * hedgerIdleTimeout)
+	hedgers.get("ch1")
+	assert.Contains(t, hedgers.hedgers, "ch1")
+	assert.NotContains(t, hedgers.hedgers, "ch2")
+}
+
+func TestHedgeGuard(t *testing.T) {
+	assert.True(t, ClaimHedgedResult(context.Background()))
+	assert.False(t, IsHedgeLost(context.Background()))
+
+	guard := &hedgeGuard{}
+	primary := context.WithValue(context.Background(), hedgeAttemptKey{}, &hedgeAttempt{guard: guard, index: 0})
+	hedged := context.WithValue(context.Background(), hedgeAttemptKey{}, &hedgeAttempt{guard: guard, index: 1})
+	assert.True(t, ClaimHedgedResult(hedged))
+	assert.False(t, ClaimHedgedResult(primary))
+	assert.True(t, ClaimHedgedResult(hedged))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errHedgeLost)
+	assert.True(t, IsHedgeLost(ctx))
+	ctx, cancel = context.WithCancelCause(context.Background())
+	cancel(nil)
+	assert.False(t, IsHedgeLost(ctx))
+}
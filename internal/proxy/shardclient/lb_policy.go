@@ -46,6 +46,9 @@ type ChannelWorkload struct {
 	Nq              int64
 	Exec            ExecuteFunc
 	PreferredNodeID int64
+	// Hedge enables sending a duplicate request to another replica if the request is slow,
+	// the Exec must call ClaimHedgedResult before applying the result.
+	Hedge bool
 }
 
 type CollectionWorkLoad struct {
@@ -55,6 +58,7 @@ type CollectionWorkLoad struct {
 	Nq             int64
 	Exec           ExecuteFunc
 	PreferredNodes map[string]int64
+	Hedge          bool
 }
 
 type LBPolicy interface {
@@ -77,6 +81,7 @@ type LBPolicyImpl struct {
 	balancerMap    map[string]LBBalancer
 	retryOnReplica int
 	blacklist      *ChannelBlacklist
+	hedgers        *channelHedgers
 }
 
 func NewLBPolicyImpl(clientMgr ShardClientMgr) *LBPolicyImpl {
@@ -100,6 +105,7 @@ func NewLBPolicyImpl(clientMgr ShardClientMgr) *LBPolicyImpl {
 		balancerMap:    balancerMap,
 		retryOnReplica: retryOnReplica,
 		blacklist:      NewChannelBlacklist(),
+		hedgers:        newChannelHedgers(),
 	}
 }
 
@@ -323,7 +329,11 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 			return true, lastErr
 		}
 
-		err = workload.Exec(ctx, targetNode.NodeID, client, workload.Channel)
+		if workload.Hedge && paramtable.Get().ProxyCfg.HedgeEnabled.GetAsBool() {
+			err = lb.executeHedged(ctx, balancer, workload, targetNode, client, &excludeNodes)
+		} else {
+			err = workload.Exec(ctx, targetNode.NodeID, client, workload.Channel)
+		}
 		if err != nil {
 			log.Warn(ctx, "search/query channel failed",
 				mlog.Int64("nodeID", targetNode.NodeID),
@@ -390,6 +400,7 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 			Nq:              workload.Nq,
 			Exec:            workload.Exec,
 			PreferredNodeID: preferredNodeID(workload, channelList[0]),
+			Hedge:           workload.Hedge,
 		})
 	}
 
@@ -404,6 +415,7 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 				Nq:              workload.Nq,
 				Exec:            workload.Exec,
 				PreferredNodeID: preferredNodeID(workload, channel),
+				Hedge:           workload.Hedge,
 			})
 		})
 	}
@@ -428,6 +440,7 @@ func (lb *LBPolicyImpl) ExecuteOneChannel(ctx context.Context, workload Collecti
 			Nq:              workload.Nq,
 			Exec:            workload.Exec,
 			PreferredNodeID: preferredNodeID(workload, channel),
+			Hedge:           workload.Hedge,
 		})
 	}
 	// An empty leader list here is a transient routing-cache state (leaders are
@@ -20,6 +20,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -700,6 +701,98 @@ func (s *LBPolicySuite) TestExecuteWithRetryInputErrorSkipsBlacklist() {
 	s.NotContains(s.lbPolicy.blacklist.GetBlacklistedNodes(channel), int64(1))
 }
 
+func (s *LBPolicySuite) TestExecuteWithRetryHedge() {
+	ctx := context.Background()
+	channel := "hedge-channel"
+	nodes := []NodeInfo{
+		{NodeID: 1, Address: "localhost:9000", Serviceable: true},
+		{NodeID: 2, Address: "localhost:9001", Serviceable: true},
+	}
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.HedgeEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.HedgeEnabled.Key)
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.HedgeMinDelay.Key, "1ms")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.HedgeMinDelay.Key)
+	s.lbPolicy.hedgers.get(channel).threshold.Store(time.Millisecond)
+	s.lbPolicy.hedgers.get(channel).tokens = 1
+	wonBefore := testutil.ToFloat64(metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeWonLabel))
+
+	s.mgr.ExpectedCalls = nil
+	s.lbBalancer.ExpectedCalls = nil
+	s.mgr.EXPECT().GetShard(mock.Anything, true, s.dbName, s.collectionName, s.collectionID, channel).Return(nodes, nil)
+	s.mgr.EXPECT().GetClient(mock.Anything, mock.Anything).Return(s.qn, nil)
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, []int64{int64(2)}, int64(1)).Return(int64(2), nil).Once()
+	s.lbBalancer.EXPECT().CancelWorkload(int64(2), int64(1)).Once()
+
+	lost := make(chan bool, 1)
+	claimed := atomic.NewInt64(0)
+	err := s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		Db:              s.dbName,
+		CollectionName:  s.collectionName,
+		CollectionID:    s.collectionID,
+		Channel:         channel,
+		Nq:              1,
+		PreferredNodeID: 1,
+		Hedge:           true,
+		Exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			if nodeID == 1 {
+				<-ctx.Done()
+				lost <- IsHedgeLost(ctx)
+				return ctx.Err()
+			}
+			if ClaimHedgedResult(ctx) {
+				claimed.Store(nodeID)
+			}
+			return nil
+		},
+	})
+	s.NoError(err)
+	s.True(<-lost)
+	s.Equal(int64(2), claimed.Load())
+	s.Empty(s.lbPolicy.blacklist.GetBlacklistedNodes(channel))
+	s.Equal(float64(1), testutil.ToFloat64(metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeWonLabel))-wonBefore)
+}
+
+func (s *LBPolicySuite) TestExecuteWithRetryHedgeBudgetExhausted() {
+	ctx := context.Background()
+	channel := "hedge-budget-channel"
+	nodes := []NodeInfo{
+		{NodeID: 1, Address: "localhost:9000", Serviceable: true},
+		{NodeID: 2, Address: "localhost:9001", Serviceable: true},
+	}
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.HedgeEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.HedgeEnabled.Key)
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.HedgeMinDelay.Key, "1ms")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.HedgeMinDelay.Key)
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.HedgeBudgetRatio.Key, "0")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.HedgeBudgetRatio.Key)
+	s.lbPolicy.hedgers.get(channel).threshold.Store(time.Millisecond)
+	exhaustedBefore := testutil.ToFloat64(metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeBudgetExhaustedLabel))
+
+	s.mgr.ExpectedCalls = nil
+	s.lbBalancer.ExpectedCalls = nil
+	s.mgr.EXPECT().GetShard(mock.Anything, true, s.dbName, s.collectionName, s.collectionID, channel).Return(nodes, nil)
+	s.mgr.EXPECT().GetClient(mock.Anything, mock.Anything).Return(s.qn, nil)
+
+	executedNodes := typeutil.NewConcurrentSet[int64]()
+	err := s.lbPolicy.ExecuteWithRetry(ctx, ChannelWorkload{
+		Db:              s.dbName,
+		CollectionName:  s.collectionName,
+		CollectionID:    s.collectionID,
+		Channel:         channel,
+		Nq:              1,
+		PreferredNodeID: 1,
+		Hedge:           true,
+		Exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			executedNodes.Insert(nodeID)
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		},
+	})
+	s.NoError(err)
+	s.ElementsMatch([]int64{1}, executedNodes.Collect())
+	s.Equal(float64(1), testutil.ToFloat64(metrics.ProxyShardRequestHedgeCount.WithLabelValues(metrics.HedgeBudgetExhaustedLabel))-exhaustedBefore)
+}
+
 func (s *LBPolicySuite) TestExecuteOneChannel() {
 	ctx := context.Background()
 	mockErr := errors.New("mock error")
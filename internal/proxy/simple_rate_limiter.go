@@ -20,11 +20,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/privilege"
 	"github.com/milvus-io/milvus/internal/util/quota"
 	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
@@ -39,6 +41,9 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
+// userLimiterIdleTimeout is the duration after which the limiters of the idle users are cleared.
+const userLimiterIdleTimeout = 30 * time.Minute
+
 // SimpleLimiter is implemented based on Limiter interface
 type SimpleLimiter struct {
 	quotaStatesMu sync.RWMutex
@@ -53,18 +58,19 @@ type SimpleLimiter struct {
 func NewSimpleLimiter(allocWaitInterval time.Duration, allocRetryTimes uint) *SimpleLimiter {
 	rootRateLimiter := newClusterLimiter()
 	m := &SimpleLimiter{rateLimiter: rlinternal.NewRateLimiterTree(rootRateLimiter), allocWaitInterval: allocWaitInterval, allocRetryTimes: allocRetryTimes}
+	updateRoleLimiters(m.rateLimiter)
 	return m
 }
 
 // Alloc will retry till check pass or out of times.
 func (m *SimpleLimiter) Alloc(ctx context.Context, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
 	return retry.Do(ctx, func() error {
-		return m.Check(dbID, collectionIDToPartIDs, rt, n)
+		return m.Check(ctx, dbID, collectionIDToPartIDs, rt, n)
 	}, retry.Sleep(m.allocWaitInterval), retry.Attempts(m.allocRetryTimes))
 }
 
 // Check checks if request would be limited or denied.
-func (m *SimpleLimiter) Check(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
+func (m *SimpleLimiter) Check(ctx context.Context, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
 	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() {
 		return nil
 	}
@@ -75,24 +81,29 @@ func (m *SimpleLimiter) Check(dbID int64, collectionIDToPartIDs map[int64][]int6
 	m.quotaStatesMu.RLock()
 	defer m.quotaStatesMu.RUnlock()
 
-	// 1. check global(cluster) level rate limits
-	clusterRateLimiters := m.rateLimiter.GetRootLimiters()
-	ret := clusterRateLimiters.Check(rt, n)
-
+	// 0. check user and role level rate limits before the shared ones,
+	// so the requests rejected by the limits of a user don't consume the quota of others.
+	doneLimiters, ret := m.checkUserAndRoles(ctx, rt, n)
 	if ret != nil {
 		return ret
 	}
 
 	// store done limiters to cancel them when error occurs.
-	doneLimiters := make([]*rlinternal.RateLimiterNode, 0)
-	doneLimiters = append(doneLimiters, clusterRateLimiters)
-
 	cancelAllLimiters := func() {
 		for _, limiter := range doneLimiters {
 			limiter.Cancel(rt, n)
 		}
 	}
 
+	// 1. check global(cluster) level rate limits
+	clusterRateLimiters := m.rateLimiter.GetRootLimiters()
+	ret = clusterRateLimiters.Check(rt, n)
+	if ret != nil {
+		cancelAllLimiters()
+		return ret
+	}
+	doneLimiters = append(doneLimiters, clusterRateLimiters)
+
 	// 2. check database level rate limits
 	if dbID != util.InvalidDBID {
 		dbRateLimiters := m.rateLimiter.GetOrCreateDatabaseLimiters(dbID, newDatabaseLimiter)
@@ -144,6 +155,50 @@ func (m *SimpleLimiter) Check(dbID int64, collectionIDToPartIDs map[int64][]int6
 	return ret
 }
 
+// checkUserAndRoles checks the rate limits of the user in the context and the roles granted to the user,
+// returns the passed limiters.
+func (m *SimpleLimiter) checkUserAndRoles(ctx context.Context, rt internalpb.RateType, n int) ([]*rlinternal.RateLimiterNode, error) {
+	doneLimiters := make([]*rlinternal.RateLimiterNode, 0)
+	if !Params.QuotaConfig.UserRoleLimitEnabled.GetAsBool() {
+		return doneLimiters, nil
+	}
+	username, err := GetCurUserFromContext(ctx)
+	if err != nil || username == "" {
+		return doneLimiters, nil
+	}
+
+	nodeID := paramtable.GetStringNodeID()
+	userRateLimiters := m.rateLimiter.GetOrCreateUserLimiters(username, func() *rlinternal.RateLimiterNode {
+		return newUserLimiters(username)
+	})
+	if err := userRateLimiters.Check(rt, n); err != nil {
+		metrics.ProxyUserRateLimitRejectCount.WithLabelValues(nodeID, metrics.UserRateLimitScope, "", rt.String()).Inc()
+		return nil, err
+	}
+	doneLimiters = append(doneLimiters, userRateLimiters)
+
+	priCache := privilege.GetPrivilegeCache()
+	if priCache == nil {
+		return doneLimiters, nil
+	}
+	for _, role := range priCache.GetUserRole(username) {
+		// only the roles with configured limits have limiters
+		roleRateLimiters := m.rateLimiter.GetRoleLimiters(strings.ToLower(role))
+		if roleRateLimiters == nil {
+			continue
+		}
+		if err := roleRateLimiters.Check(rt, n); err != nil {
+			metrics.ProxyUserRateLimitRejectCount.WithLabelValues(nodeID, metrics.RoleRateLimitScope, role, rt.String()).Inc()
+			for _, limiter := range doneLimiters {
+				limiter.Cancel(rt, n)
+			}
+			return nil, err
+		}
+		doneLimiters = append(doneLimiters, roleRateLimiters)
+	}
+	return doneLimiters, nil
+}
+
 func isNotCollectionLevelLimitRequest(rt internalpb.RateType) bool {
 	// Most ddl is global level, only DDLFlush will be applied at collection
 	switch rt {
@@ -157,6 +212,11 @@ func isNotCollectionLevelLimitRequest(rt internalpb.RateType) bool {
 	}
 }
 
+// RemoveUser removes the rate limiters of the dropped user.
+func (m *SimpleLimiter) RemoveUser(username string) {
+	m.rateLimiter.RemoveUserLimiters(username)
+}
+
 // GetQuotaStates returns quota states.
 func (m *SimpleLimiter) GetQuotaStates() ([]milvuspb.QuotaState, []string) {
 	m.quotaStatesMu.RLock()
@@ -214,6 +274,15 @@ func (m *SimpleLimiter) SetRates(rootLimiter *proxypb.LimiterNode) error {
 		return true
 	})
 
+	m.rateLimiter.RangeUserLimiters(func(username string, userLimiter *rlinternal.RateLimiterNode) bool {
+		initNamedLimiter(getUserSourceID(username), userLimiter, quota.GetUserQuotaValues(username))
+		return true
+	})
+	if removed := m.rateLimiter.ClearIdleUserLimiters(userLimiterIdleTimeout); len(removed) > 0 {
+		mlog.Debug(context.TODO(), "clear the rate limiters of idle users", mlog.Int("num", len(removed)))
+	}
+	updateRoleLimiters(m.rateLimiter)
+
 	if err := m.updateRateLimiter(rootLimiter); err != nil {
 		return err
 	}
@@ -223,9 +292,39 @@ func (m *SimpleLimiter) SetRates(rootLimiter *proxypb.LimiterNode) error {
 }
 
 func initLimiter(source string, rln *rlinternal.RateLimiterNode, rateLimiterConfigs map[internalpb.RateType]*paramtable.ParamItem) {
+	rates := make(map[internalpb.RateType]float64, len(rateLimiterConfigs))
 	for rt, p := range rateLimiterConfigs {
-		newLimit := ratelimitutil.Limit(p.GetAsFloat())
-		burst := p.GetAsFloat() // use rate as burst, because SimpleLimiter is with punishment mechanism, burst is insignificant.
+		rates[rt] = p.GetAsFloat()
+	}
+	initLimiterWithRates(source, rln, rates)
+}
+
+// initNamedLimiter inits the limiter of user or role, the rate types which are not configured are reset to no limit.
+func initNamedLimiter(source string, rln *rlinternal.RateLimiterNode, rates map[internalpb.RateType]float64) {
+	rln.GetLimiters().Range(func(rt internalpb.RateType, _ *ratelimitutil.Limiter) bool {
+		if _, ok := rates[rt]; !ok {
+			rates[rt] = float64(ratelimitutil.Inf)
+		}
+		return true
+	})
+	initLimiterWithRates(source, rln, rates)
+}
+
+// initRoleLimiter inits the limiter of role and records its rates,
+// the rates of user limiters are not recorded to keep the metric labels bounded.
+func initRoleLimiter(role string, rln *rlinternal.RateLimiterNode) {
+	source := getRoleSourceID(role)
+	rates := quota.GetRoleQuotaValues(role)
+	initNamedLimiter(source, rln, rates)
+	for rt, rate := range rates {
+		setRateGaugeByRateType(rt, paramtable.GetNodeID(), source, rate)
+	}
+}
+
+func initLimiterWithRates(source string, rln *rlinternal.RateLimiterNode, rates map[internalpb.RateType]float64) {
+	for rt, rate := range rates {
+		newLimit := ratelimitutil.Limit(rate)
+		burst := rate // use rate as burst, because SimpleLimiter is with punishment mechanism, burst is insignificant.
 		old, ok := rln.GetLimiters().Get(rt)
 		updated := false
 		if ok {
@@ -278,6 +377,49 @@ func newPartitionLimiters() *rlinternal.RateLimiterNode {
 	return partRateLimiters
 }
 
+func newUserLimiters(username string) *rlinternal.RateLimiterNode {
+	userRateLimiters := rlinternal.NewRateLimiterNode(internalpb.RateScope_User)
+	initNamedLimiter(getUserSourceID(username), userRateLimiters, quota.GetUserQuotaValues(username))
+	return userRateLimiters
+}
+
+func newRoleLimiters(role string) *rlinternal.RateLimiterNode {
+	roleRateLimiters := rlinternal.NewRateLimiterNode(internalpb.RateScope_Role)
+	initRoleLimiter(role, roleRateLimiters)
+	return roleRateLimiters
+}
+
+// updateRoleLimiters creates or updates the limiters of the roles with configured limits,
+// and removes the limiters of the roles whose limits are removed.
+func updateRoleLimiters(tree *rlinternal.RateLimiterTree) {
+	roles := typeutil.NewSet[string]()
+	if Params.QuotaConfig.UserRoleLimitEnabled.GetAsBool() {
+		roles.Insert(quota.GetLimitedRoles()...)
+	}
+	for _, role := range tree.GetRolesLimiters().Keys() {
+		if !roles.Contain(strings.ToLower(role)) {
+			tree.GetRolesLimiters().Remove(role)
+		}
+	}
+	for role := range roles {
+		if roleLimiter := tree.GetRoleLimiters(role); roleLimiter != nil {
+			initRoleLimiter(role, roleLimiter)
+			continue
+		}
+		tree.GetOrCreateRoleLimiters(role, func() *rlinternal.RateLimiterNode {
+			return newRoleLimiters(role)
+		})
+	}
+}
+
+func getUserSourceID(username string) string {
+	return fmt.Sprintf("user.%s", username)
+}
+
+func getRoleSourceID(role string) string {
+	return fmt.Sprintf("role.%s", role)
+}
+
 func (m *SimpleLimiter) updateLimiterNode(req *proxypb.Limiter, node *rlinternal.RateLimiterNode, sourceID string) error {
 	curLimiters := node.GetLimiters()
 	for _, rate := range req.GetRates() {
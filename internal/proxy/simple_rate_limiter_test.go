@@ -17,6 +17,7 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
@@ -25,10 +26,12 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/privilege"
 	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
@@ -63,18 +66,18 @@ func TestSimpleRateLimiter(t *testing.T) {
 
 		for _, rt := range internalpb.RateType_value {
 			if IsDDLRequest(internalpb.RateType(rt)) {
-				err := simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
+				err := simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
 				assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
 			} else {
-				err := simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
+				err := simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), math.MaxInt)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), math.MaxInt)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), math.MaxInt)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), math.MaxInt)
 				assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
 			}
 		}
@@ -119,37 +122,37 @@ func TestSimpleRateLimiter(t *testing.T) {
 		for _, rt := range internalpb.RateType_value {
 			if internalpb.RateType_DDLFlush == internalpb.RateType(rt) {
 				// the flush request has 0.1 rate limiter that means only allow to execute one request each 10 seconds.
-				err := simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType_DDLFlush, 1)
+				err := simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType_DDLFlush, 1)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType_DDLFlush, 1)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType_DDLFlush, 1)
 				assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
 				continue
 			}
 			if internalpb.RateType_DDLDB == internalpb.RateType(rt) {
-				err := simpleLimiter.Check(util.InvalidDBID, nil, internalpb.RateType(rt), 1)
+				err := simpleLimiter.Check(context.TODO(), util.InvalidDBID, nil, internalpb.RateType(rt), 1)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(util.InvalidDBID, nil, internalpb.RateType(rt), 5)
+				err = simpleLimiter.Check(context.TODO(), util.InvalidDBID, nil, internalpb.RateType(rt), 5)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(util.InvalidDBID, nil, internalpb.RateType(rt), 1)
+				err = simpleLimiter.Check(context.TODO(), util.InvalidDBID, nil, internalpb.RateType(rt), 1)
 				assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
 				continue
 			}
 
 			if IsDDLRequest(internalpb.RateType(rt)) {
-				err := simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
+				err := simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
 				assert.NoError(t, err)
-				err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
+				err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 5)
 				assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
 				continue
 			}
 
-			err := simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
+			err := simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
 			assert.NoError(t, err)
-			err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
+			err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
 			assert.NoError(t, err)
-			err = simpleLimiter.Check(0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
+			err = simpleLimiter.Check(context.TODO(), 0, collectionIDToPartIDs, internalpb.RateType(rt), 1)
 			assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
 		}
 		Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
@@ -160,7 +163,7 @@ func TestSimpleRateLimiter(t *testing.T) {
 		bak := Params.QuotaConfig.QuotaAndLimitsEnabled.GetValue()
 		paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "false")
 		for _, rt := range internalpb.RateType_value {
-			err := simpleLimiter.Check(0, nil, internalpb.RateType(rt), 1)
+			err := simpleLimiter.Check(context.TODO(), 0, nil, internalpb.RateType(rt), 1)
 			assert.NoError(t, err)
 		}
 		Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
@@ -173,7 +176,7 @@ func TestSimpleRateLimiter(t *testing.T) {
 			simpleLimiter := NewSimpleLimiter(0, 0)
 			bak := Params.QuotaConfig.QuotaAndLimitsEnabled.GetValue()
 			paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
-			err := simpleLimiter.Check(0, nil, internalpb.RateType_DMLInsert, 1*1024*1024)
+			err := simpleLimiter.Check(context.TODO(), 0, nil, internalpb.RateType_DMLInsert, 1*1024*1024)
 			assert.NoError(t, err)
 			Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
 			Params.Save(Params.QuotaConfig.DMLMaxInsertRate.Key, bakInsertRate)
@@ -275,6 +278,90 @@ func newCollectionLimiterNode(collectionLimiterNodes map[int64]*proxypb.LimiterN
 	}
 }
 
+func TestSimpleRateLimiterUserAndRole(t *testing.T) {
+	pt := paramtable.Get()
+	pt.Save(pt.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+	defer pt.Reset(pt.QuotaConfig.QuotaAndLimitsEnabled.Key)
+	pt.Save(pt.QuotaConfig.UserRoleLimitEnabled.Key, "true")
+	defer pt.Reset(pt.QuotaConfig.UserRoleLimitEnabled.Key)
+	pt.Save(pt.QuotaConfig.DQLLimitEnabled.Key, "true")
+	defer pt.Reset(pt.QuotaConfig.DQLLimitEnabled.Key)
+	pt.Save(pt.QuotaConfig.DQLMaxSearchRatePerUser.Key, "10")
+	defer pt.Reset(pt.QuotaConfig.DQLMaxSearchRatePerUser.Key)
+	roleLimitKey := pt.QuotaConfig.RoleRateLimits.KeyPrefix + "Tenant.dqlQuery"
+	pt.SaveGroup(map[string]string{roleLimitKey: "5"})
+	defer pt.Reset(roleLimitKey)
+
+	client := &MockMixCoordClientInterface{}
+	client.listPolicy = func(ctx context.Context, in *internalpb.ListPolicyRequest) (*internalpb.ListPolicyResponse, error) {
+		return &internalpb.ListPolicyResponse{
+			Status:    merr.Success(),
+			UserRoles: []string{funcutil.EncodeUserRoleCache("alice", "Tenant")},
+		}, nil
+	}
+	err := privilege.InitPrivilegeCache(context.Background(), client)
+	assert.NoError(t, err)
+
+	simpleLimiter := NewSimpleLimiter(0, 0)
+	aliceCtx := GetContext(context.Background(), "alice:123456")
+	bobCtx := GetContext(context.Background(), "bob:123456")
+
+	t.Run("user", func(t *testing.T) {
+		err := simpleLimiter.Check(aliceCtx, util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 10)
+		assert.NoError(t, err)
+		err = simpleLimiter.Check(aliceCtx, util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 10)
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+
+		// the limits of users are isolated
+		err = simpleLimiter.Check(bobCtx, util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 10)
+		assert.NoError(t, err)
+
+		// no user in the context
+		err = simpleLimiter.Check(context.Background(), util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 10)
+		assert.NoError(t, err)
+	})
+
+	t.Run("role", func(t *testing.T) {
+		assert.NotNil(t, simpleLimiter.rateLimiter.GetRoleLimiters("tenant"))
+		err := simpleLimiter.Check(aliceCtx, util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 5)
+		assert.NoError(t, err)
+		err = simpleLimiter.Check(aliceCtx, util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 5)
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+
+		// bob is not granted the role
+		err = simpleLimiter.Check(bobCtx, util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove user", func(t *testing.T) {
+		assert.NotNil(t, simpleLimiter.rateLimiter.GetUserLimiters("bob"))
+		simpleLimiter.RemoveUser("bob")
+		assert.Nil(t, simpleLimiter.rateLimiter.GetUserLimiters("bob"))
+	})
+
+	t.Run("set rates", func(t *testing.T) {
+		pt.Save(pt.QuotaConfig.DQLMaxSearchRatePerUser.Key, "20")
+		err := simpleLimiter.SetRates(&proxypb.LimiterNode{
+			Limiter:  &proxypb.Limiter{},
+			Children: make(map[int64]*proxypb.LimiterNode),
+		})
+		assert.NoError(t, err)
+		limiter, ok := simpleLimiter.rateLimiter.GetUserLimiters("alice").GetLimiters().Get(internalpb.RateType_DQLSearch)
+		assert.True(t, ok)
+		assert.EqualValues(t, 20, limiter.Limit())
+
+		pt.Save(pt.QuotaConfig.UserRoleLimitEnabled.Key, "false")
+		err = simpleLimiter.SetRates(&proxypb.LimiterNode{
+			Limiter:  &proxypb.Limiter{},
+			Children: make(map[int64]*proxypb.LimiterNode),
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, simpleLimiter.rateLimiter.GetRoleLimiters("tenant"))
+		err = simpleLimiter.Check(aliceCtx, util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 5)
+		assert.NoError(t, err)
+	})
+}
+
 func TestRateLimiter(t *testing.T) {
 	t.Run("test limit", func(t *testing.T) {
 		simpleLimiter := NewSimpleLimiter(0, 0)
@@ -395,10 +482,10 @@ func TestRateLimiter(t *testing.T) {
 	t.Run("test quota", func(t *testing.T) {
 		paramtable.Init()
 		simpleLimiter := NewSimpleLimiter(0, 0)
-		err := simpleLimiter.Check(-1, nil, internalpb.RateType_DDLDB, 1)
+		err := simpleLimiter.Check(context.TODO(), -1, nil, internalpb.RateType_DDLDB, 1)
 		assert.NoError(t, err)
 
-		err = simpleLimiter.Check(-1, nil, internalpb.RateType_DDLDB, 0)
+		err = simpleLimiter.Check(context.TODO(), -1, nil, internalpb.RateType_DDLDB, 0)
 		assert.NoError(t, err)
 	})
 }
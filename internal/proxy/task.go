@@ -40,6 +40,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -87,7 +88,9 @@ const (
 	QueryIterLastOffsetKey = "query_iter_last_element_offset"
 	GroupByFieldsKey       = "group_by_fields"
 	OrderByFieldsKey       = "order_by_fields"
+	HavingKey              = "having"
 	PipelineTraceKey       = "pipeline_trace"
+	WorkloadClassKey       = "workload_class"
 
 	InsertTaskName                = "InsertTask"
 	CreateCollectionTaskName      = "CreateCollectionTask"
@@ -447,6 +450,15 @@ func validateCollectionTTL(props []*commonpb.KeyValuePair) (bool, error) {
 	return false, nil
 }
 
+func validateBinlogCompression(props []*commonpb.KeyValuePair) error {
+	if value, ok := common.GetStringValue(props, common.CollectionBinlogCompressionKey); ok {
+		if _, err := compressor.ParseCompressType(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateTTLField(props []*commonpb.KeyValuePair, fields []*schemapb.FieldSchema) (bool, error) {
 	for _, pair := range props {
 		if pair.Key == common.CollectionTTLFieldKey {
@@ -622,6 +634,13 @@ func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 		return merr.WrapErrParameterInvalidMsg("collection ttl property value not valid, parse error: %s", err.Error())
 	}
 
+	// row policies are validated against the schema with field ids assigned, so only allowed in alter collection
+	for _, prop := range t.GetProperties() {
+		if common.IsCollectionRowPolicyKey(prop.GetKey()) {
+			return merr.WrapErrParameterInvalidMsg("row policy %s could only be set by altering the collection properties", prop.GetKey())
+		}
+	}
+
 	// Validate warmup policy for all warmup keys
 	if hasWarmupProp(t.GetProperties()...) {
 		for _, prop := range t.GetProperties() {
@@ -677,6 +696,10 @@ func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err := validateBinlogCompression(t.GetProperties()); err != nil {
+		return err
+	}
+
 	t.Schema, err = proto.Marshal(t.schema)
 	if err != nil {
 		return err
@@ -1450,6 +1473,8 @@ type dropCollectionTask struct {
 	mixCoord types.MixCoordClient
 	result   *commonpb.Status
 	chMgr    channelsMgr
+
+	collectionID UniqueID
 }
 
 func (t *dropCollectionTask) TraceCtx() context.Context {
@@ -1497,7 +1522,7 @@ func (t *dropCollectionTask) PreExecute(ctx context.Context) error {
 	// No need to check collection name
 	// Validation shall be preformed in `CreateCollection`
 	// also permit drop collection one with bad collection name
-	_, err := globalMetaCache.GetCollectionID(ctx, t.GetDbName(), t.GetCollectionName())
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, t.GetDbName(), t.GetCollectionName())
 	if err != nil {
 		if errors.Is(err, merr.ErrCollectionNotFound) || errors.Is(err, merr.ErrDatabaseNotFound) {
 			// make dropping collection idempotent.
@@ -1506,14 +1531,27 @@ func (t *dropCollectionTask) PreExecute(ctx context.Context) error {
 		}
 		return err
 	}
+	t.collectionID = collectionID
 
 	return nil
 }
 
 func (t *dropCollectionTask) Execute(ctx context.Context) error {
-	var err error
+	var (
+		recycleBinName string
+		err            error
+	)
+	if t.collectionID != 0 {
+		if recycleBinName, err = moveToRecycleBin(ctx, t.mixCoord, t.GetDbName(), t.GetCollectionName(), t.collectionID, ""); err != nil {
+			return err
+		}
+	}
 	t.result, err = t.mixCoord.DropCollection(ctx, t.DropCollectionRequest)
-	return merr.CheckRPCCall(t.result, err)
+	if err := merr.CheckRPCCall(t.result, err); err != nil {
+		removeFromRecycleBin(ctx, t.mixCoord, t.collectionID, recycleBinName)
+		return err
+	}
+	return nil
 }
 
 func (t *dropCollectionTask) PostExecute(ctx context.Context) error {
@@ -2196,6 +2234,10 @@ func (t *alterCollectionTask) PreExecute(ctx context.Context) error {
 
 	t.CollectionID = collectionID
 
+	if err := validateRowPolicies(ctx, collSchema.schemaHelper, t.GetProperties(), t.GetDeleteKeys()); err != nil {
+		return err
+	}
+
 	if len(t.GetProperties()) > 0 {
 		hasMmap := hasMmapProp(t.Properties...)
 		hasWarmup := hasWarmupProp(t.Properties...)
@@ -2251,6 +2293,9 @@ func (t *alterCollectionTask) PreExecute(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if err := validateBinlogCompression(t.GetProperties()); err != nil {
+			return err
+		}
 		hasTTLField, err := validateTTLField(t.GetProperties(), collSchema.GetFields())
 		if err != nil {
 			return err
@@ -2771,6 +2816,8 @@ type dropPartitionTask struct {
 	ctx      context.Context
 	mixCoord types.MixCoordClient
 	result   *commonpb.Status
+
+	collectionID UniqueID
 }
 
 func (t *dropPartitionTask) TraceCtx() context.Context {
@@ -2859,13 +2906,24 @@ func (t *dropPartitionTask) PreExecute(ctx context.Context) error {
 			return merr.WrapErrParameterInvalidMsg("partition cannot be dropped, partition is loaded, please release it first")
 		}
 	}
+	t.collectionID = collID
 
 	return nil
 }
 
 func (t *dropPartitionTask) Execute(ctx context.Context) (err error) {
+	var recycleBinName string
+	if t.collectionID != 0 {
+		if recycleBinName, err = moveToRecycleBin(ctx, t.mixCoord, t.GetDbName(), t.GetCollectionName(), t.collectionID, t.GetPartitionName()); err != nil {
+			return err
+		}
+	}
 	t.result, err = t.mixCoord.DropPartition(ctx, t.DropPartitionRequest)
-	return merr.CheckRPCCall(t.result, err)
+	if err := merr.CheckRPCCall(t.result, err); err != nil {
+		removeFromRecycleBin(ctx, t.mixCoord, t.collectionID, recycleBinName)
+		return err
+	}
+	return nil
 }
 
 func (t *dropPartitionTask) PostExecute(ctx context.Context) error {
@@ -345,6 +345,9 @@ func (dr *deleteRunner) Init(ctx context.Context) error {
 	if planparserv2.IsAlwaysTruePlan(dr.plan) {
 		return merr.WrapErrAsInputError(merr.WrapErrParameterInvalidMsg("delete plan can't be empty or always true : %s", dr.req.GetExpr()))
 	}
+	if err := applyRowPolicy(ctx, dr.req.GetDbName(), collName, dr.collectionID, dr.schema.schemaHelper, dr.plan); err != nil {
+		return err
+	}
 
 	dr.plan.Namespace = namespaceForPlan(dr.schema.CollectionSchema, dr.req.Namespace)
 	// Set partitionIDs, could be empty if no partition name specified and no partition key
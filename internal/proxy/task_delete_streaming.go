@@ -69,6 +69,7 @@ func (dt *deleteTask) Execute(ctx context.Context) (err error) {
 				WithBody(deleteMsg.DeleteRequest).
 				WithVChannel(vchannel).
 				WithCipher(ez).
+				WithCompression(getWALCompressionConfig()).
 				BuildMutable()
 			if err != nil {
 				return err
@@ -63,9 +63,9 @@ func (it *insertTask) Execute(ctx context.Context) error {
 	// start to repack insert data
 	var msgs []message.MutableMessage
 	if it.partitionKeys == nil {
-		msgs, err = repackInsertDataForStreamingService(it.TraceCtx(), channelNames, it.insertMsg, it.result, ez, it.schemaVersion)
+		msgs, err = repackInsertDataForStreamingService(it.TraceCtx(), channelNames, it.insertMsg, it.result, ez, nil, it.schemaVersion)
 	} else {
-		msgs, err = repackInsertDataWithPartitionKeyForStreamingService(it.TraceCtx(), channelNames, it.insertMsg, it.result, it.partitionKeys, ez, it.schema, it.schemaVersion)
+		msgs, err = repackInsertDataWithPartitionKeyForStreamingService(it.TraceCtx(), channelNames, it.insertMsg, it.result, it.partitionKeys, ez, nil, it.schema, it.schemaVersion)
 	}
 	if err != nil {
 		mlog.Warn(ctx, "assign segmentID and repack insert data failed", mlog.Err(err))
@@ -92,6 +92,7 @@ func repackInsertDataForStreamingService(
 	insertMsg *msgstream.InsertMsg,
 	result *milvuspb.MutationResult,
 	ez *message.CipherConfig,
+	properties map[string]string,
 	schemaVersion int32,
 ) ([]message.MutableMessage, error) {
 	messages := make([]message.MutableMessage, 0)
@@ -129,6 +130,8 @@ func repackInsertDataForStreamingService(
 				}).
 				WithBody(insertRequest).
 				WithCipher(ez).
+				WithCompression(getWALCompressionConfig()).
+				WithProperties(properties).
 				BuildMutable()
 			if err != nil {
 				return nil, err
@@ -146,6 +149,7 @@ func repackInsertDataWithPartitionKeyForStreamingService(
 	result *milvuspb.MutationResult,
 	partitionKeys *schemapb.FieldData,
 	ez *message.CipherConfig,
+	properties map[string]string,
 	schema *schemapb.CollectionSchema,
 	schemaVersion int32,
 ) ([]message.MutableMessage, error) {
@@ -222,6 +226,8 @@ func repackInsertDataWithPartitionKeyForStreamingService(
 					}).
 					WithBody(insertRequest).
 					WithCipher(ez).
+					WithCompression(getWALCompressionConfig()).
+					WithProperties(properties).
 					BuildMutable()
 				if err != nil {
 					return nil, err
@@ -67,7 +67,7 @@ func TestRepackInsertDataForStreamingServicePreservesExplicitZeroSchemaVersion(t
 		},
 	}
 
-	msgs, err := repackInsertDataForStreamingService(context.Background(), []string{"ch"}, insertMsg, result, nil, 0)
+	msgs, err := repackInsertDataForStreamingService(context.Background(), []string{"ch"}, insertMsg, result, nil, nil, 0)
 	assert.NoError(t, err)
 	assert.Len(t, msgs, 1)
 
@@ -75,6 +75,13 @@ func TestRepackInsertDataForStreamingServicePreservesExplicitZeroSchemaVersion(t
 	header := msg.Header()
 	assert.NotNil(t, header.SchemaVersion)
 	assert.Equal(t, int32(0), header.GetSchemaVersion())
+	assert.False(t, msgs[0].Properties().Exist(message.PropertyUpsert))
+
+	// the messages written by upsert are marked.
+	msgs, err = repackInsertDataForStreamingService(context.Background(), []string{"ch"}, insertMsg, result, nil, upsertMessageProperties, 0)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.True(t, msgs[0].Properties().Exist(message.PropertyUpsert))
 }
 
 func TestInsertTaskPreExecuteTextRequiresStorageV3(t *testing.T) {
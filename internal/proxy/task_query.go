@@ -87,7 +87,9 @@ type queryTask struct {
 	resolvedTimezoneStr  string
 	storageCost          segcore.StorageCost
 	aggregationFieldMap  *agg.AggregationFieldMap
+	havingFilter         *agg.HavingFilter
 	chMgr                channelsMgr
+	resultCache          resultCacheState
 }
 
 func (t *queryTask) getQueryLabel() string {
@@ -105,6 +107,7 @@ type queryParams struct {
 	collectionID        int64
 	groupByFields       []string
 	orderByFields       []string // NEW: ORDER BY field specifications (e.g., "price:desc")
+	having              string   // HAVING predicate over group by fields and aggregations
 	timezone            string
 	extractTimeFields   []string
 	queryIteratorCursor *planpb.QueryIteratorCursor
@@ -202,7 +205,7 @@ func validateOrderByFieldsWithGroupBy(
 		fieldName := strings.ToLower(strings.TrimSpace(parts[0]))
 
 		// Reject aggregate expressions — not yet supported
-		if isAgg, _, _ := agg.MatchAggregationExpression(fieldName); isAgg {
+		if isAgg, _, _, _ := agg.MatchAggregationExpression(fieldName); isAgg {
 			return merr.WrapErrParameterInvalidMsg(
 				"ORDER BY on aggregate expression '%s' is not yet supported",
 				fieldName,
@@ -440,6 +443,13 @@ func parseQueryParams(queryParamsPair []*commonpb.KeyValuePair, largeTopKEnabled
 		}
 	}
 
+	// parse having predicate, it's parsed against the aggregations when creating plan
+	having, _ := funcutil.TryGetAttrByKeyFromRepeatedKV(HavingKey, queryParamsPair)
+	having = strings.TrimSpace(having)
+	if having != "" && isIterator {
+		return nil, merr.WrapErrParameterInvalidMsg("having with iterator is not supported")
+	}
+
 	queryIteratorCursor, err := parseQueryIteratorCursor(queryParamsPair, isIterator, pkDataType)
 	if err != nil {
 		return nil, err
@@ -453,6 +463,7 @@ func parseQueryParams(queryParamsPair []*commonpb.KeyValuePair, largeTopKEnabled
 		collectionID:        collectionID,
 		groupByFields:       groupByFields,
 		orderByFields:       orderByFields,
+		having:              having,
 		queryIteratorCursor: queryIteratorCursor,
 		timezone:            timezone,
 		extractTimeFields:   extractTimeFields,
@@ -560,6 +571,16 @@ func (t *queryTask) createPlanArgs(ctx context.Context, visitorArgs *planparserv
 		return err
 	}
 
+	// parse having, the aggregations only referenced by having are computed but not output
+	if t.queryParams.having != "" {
+		havingFilter, havingAggregates, err := agg.ParseHaving(t.queryParams.having, t.schema.CollectionSchema, t.queryParams.groupByFields, t.userAggregates)
+		if err != nil {
+			return err
+		}
+		t.havingFilter = havingFilter
+		t.userAggregates = append(t.userAggregates, havingAggregates...)
+	}
+
 	// parse aggregates
 	t.plan.GetQuery().Aggregates = agg.AggregatesToPB(t.userAggregates)
 	t.Aggregates = t.plan.GetQuery().GetAggregates()
@@ -591,6 +612,17 @@ func (t *queryTask) createPlanArgs(ctx context.Context, visitorArgs *planparserv
 	// without re-parsing serialized_expr_plan.
 	t.OrderByFields = orderByFields
 
+	// segcore has no kernels of the state aggregations, it computes the weighted inputs of every
+	// group instead, which are folded into the partial states by the query nodes.
+	// The RetrieveRequest keeps the original aggregations for the query nodes and the reducers.
+	if agg.HasStateAggregates(t.Aggregates) {
+		stateInputPlan := agg.NewStateInputPlan(t.GroupByFieldIds, t.Aggregates)
+		t.plan.GetQuery().GroupByFieldIds = stateInputPlan.SegcoreGroupByFieldIDs()
+		t.plan.GetQuery().Aggregates = stateInputPlan.SegcoreAggregates()
+		// the order and the limit apply to the folded groups, they are handled by the reducers
+		t.plan.GetQuery().OrderByFields = nil
+	}
+
 	hasAgg := len(t.GroupByFieldIds) > 0 || len(t.Aggregates) > 0
 	// parse output field ids
 	if hasAgg {
@@ -765,6 +797,11 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 
 	t.Limit = queryParams.limit + queryParams.offset
+	if queryParams.having != "" {
+		// groups can only be filtered by having after the final reduce,
+		// so query nodes must not truncate the groups by limit.
+		t.Limit = typeutil.Unlimited
+	}
 
 	if t.ids != nil {
 		pkField := ""
@@ -785,6 +822,8 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 		log.Debug(ctx, "determine timezone from collection", mlog.Any("collection timezone", t.resolvedTimezoneStr))
 	}
 
+	// the plan is given by the internal requests, such as retrieving by pks in upsert
+	planFromRequest := t.plan == nil
 	if err := t.createPlanArgs(ctx, &planparserv2.ParserVisitorArgs{Timezone: t.resolvedTimezoneStr}); err != nil {
 		return err
 	}
@@ -803,6 +842,12 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 		return merr.WrapErrParameterInvalidMsg("empty expression should be used with limit")
 	}
 
+	if planFromRequest {
+		if err := applyRowPolicy(ctx, t.request.GetDbName(), collectionName, t.CollectionID, schema.schemaHelper, t.plan); err != nil {
+			return err
+		}
+	}
+
 	// convert partition names only when requery is false
 	if !t.reQuery {
 		partitionNames := t.request.GetPartitionNames()
@@ -856,6 +901,7 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 			mlog.Err(err2))
 		return err2
 	}
+	t.WorkloadClass = getWorkloadClass(ctx, t.request.GetDbName(), collectionInfo, t.request.GetQueryParams())
 
 	guaranteeTs := t.request.GetGuaranteeTimestamp()
 	var consistencyLevel commonpb.ConsistencyLevel
@@ -911,6 +957,13 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 
 	t.DbID = 0 // TODO
+	if !t.queryParams.isIterator {
+		result := &milvuspb.QueryResults{}
+		if t.resultCache.lookup(ctx, queryResultCacheName, collectionInfo, t.request, t.GetGuaranteeTimestamp(), result) {
+			t.result = result
+			log.Debug(ctx, "query result cache hit", mlog.Uint64("guarantee_ts", t.GetGuaranteeTimestamp()))
+		}
+	}
 	log.Debug(ctx, "Query PreExecute done.",
 		mlog.Uint64("guarantee_ts", guaranteeTs),
 		mlog.Uint64("mvcc_ts", t.GetMvccTimestamp()),
@@ -926,6 +979,9 @@ func (t *queryTask) Execute(ctx context.Context) error {
 		mlog.Int64s("partitionIDs", t.GetPartitionIDs()),
 		mlog.String("requestType", t.getQueryLabel()))
 
+	if t.resultCache.hit {
+		return nil
+	}
 	t.resultBuf = typeutil.NewConcurrentSet[*internalpb.RetrieveResults]()
 	if namespacePartitionKeyModeEnabled(t.schema.CollectionSchema) && t.request.Namespace != nil {
 		channelNames, err := t.chMgr.getVChannels(t.CollectionID)
@@ -982,6 +1038,10 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 		mlog.Int64s("partitionIDs", t.GetPartitionIDs()),
 		mlog.String("requestType", t.getQueryLabel()))
 
+	if t.resultCache.hit {
+		return nil
+	}
+
 	var err error
 
 	toReduceResults := make([]*internalpb.RetrieveResults, 0)
@@ -1033,6 +1093,7 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 		t.GetGroupByFieldIds(),
 		t.GetAggregates(),
 		t.aggregationFieldMap,
+		t.havingFilter,
 		filterSystemFields(t.GetOutputFieldsId()),
 	)
 	if err != nil {
@@ -1086,6 +1147,8 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 			}
 		}
 	}
+	// the mvcc timestamp of query isn't returned, so only the guarantee timestamp is known to be visible
+	t.resultCache.store(t.GetCollectionID(), t.GetGuaranteeTimestamp(), t.result)
 	log.Debug(ctx, "Query PostExecute done")
 	return nil
 }
@@ -279,12 +279,22 @@ type pChanStatInfo struct {
 	tsSet map[Timestamp]struct{}
 }
 
+// dmlDoneListenerFuncType is notified with a timestamp after all the writes of a finished DML task on pchan.
+type dmlDoneListenerFuncType func(pchan pChan, ts Timestamp)
+
 // dmTaskQueue represents queue for DML task such as insert/delete/upsert
 type dmTaskQueue struct {
 	*baseTaskQueue
 
 	statsLock            sync.RWMutex
 	pChanStatisticsInfos map[pChan]*pChanStatInfo
+
+	dmlDoneListener dmlDoneListenerFuncType
+}
+
+// setDMLDoneListener sets the listener of the finished DML tasks, it should be called before the scheduler starts.
+func (queue *dmTaskQueue) setDMLDoneListener(listener dmlDoneListenerFuncType) {
+	queue.dmlDoneListener = listener
 }
 
 func (queue *dmTaskQueue) updateMetrics() {
@@ -329,6 +339,29 @@ func (queue *dmTaskQueue) Enqueue(t task) error {
 }
 
 func (queue *dmTaskQueue) PopActiveTask(taskID UniqueID) task {
+	t := queue.popActiveTask(taskID)
+	if t != nil && queue.dmlDoneListener != nil {
+		queue.notifyDMLDone(t)
+	}
+	return t
+}
+
+// notifyDMLDone notifies the listener with a timestamp allocated after the DML task is done,
+// so it's after the timetick of all the writes of the task, no matter whether the task succeeded or not.
+// The timestamp of the task is used if the allocation fails.
+func (queue *dmTaskQueue) notifyDMLDone(t task) {
+	ctx := context.WithoutCancel(t.TraceCtx())
+	ts, err := queue.tsoAllocatorIns.AllocOne(ctx)
+	if err != nil {
+		mlog.Warn(ctx, "failed to allocate timestamp for the finished dml task", mlog.FieldTaskID(t.ID()), mlog.Err(err))
+		ts = t.EndTs()
+	}
+	for _, pchan := range t.(dmlTask).getChannels() {
+		queue.dmlDoneListener(pchan, ts)
+	}
+}
+
+func (queue *dmTaskQueue) popActiveTask(taskID UniqueID) task {
 	queue.atLock.Lock()
 	defer queue.atLock.Unlock()
 	t, ok := queue.activeTasks[taskID]
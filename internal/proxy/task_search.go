@@ -127,6 +127,8 @@ type searchTask struct {
 	hybridElementLevel   bool
 
 	chMgr channelsMgr
+
+	resultCache resultCacheState
 }
 
 func (t *searchTask) CanSkipAllocTimestamp() bool {
@@ -329,6 +331,7 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	if username, _ := GetCurUserFromContext(ctx); username != "" {
 		t.Username = username
 	}
+	t.WorkloadClass = getWorkloadClass(ctx, t.request.GetDbName(), collectionInfo, t.request.GetSearchParams())
 
 	if collectionInfo.collectionTTL != 0 {
 		physicalTime := tsoutil.PhysicalTime(t.GetBase().GetTimestamp())
@@ -359,6 +362,14 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if !t.isIterator && !t.GetIsRecallEvaluation() {
+		result := &milvuspb.SearchResults{}
+		if t.resultCache.lookup(ctx, searchResultCacheName, collectionInfo, t.request, t.GetGuaranteeTimestamp(), result) {
+			t.result = result
+			log.Debug(ctx, "search result cache hit", mlog.Uint64("guarantee_ts", t.GetGuaranteeTimestamp()))
+		}
+	}
+
 	log.Debug(ctx, "search PreExecute done.",
 		mlog.Uint64("guarantee_ts", guaranteeTs),
 		mlog.Bool("use_default_consistency", useDefaultConsistency),
@@ -1173,7 +1184,12 @@ func (t *searchTask) tryGeneratePlan(params []*commonpb.KeyValuePair, dsl string
 
 	searchInfo.planInfo.QueryFieldId = annField.GetFieldID()
 
-	hasFilter := dsl != "" || len(exprTemplateValues) > 0
+	rowPolicy, err := getRowPolicy(t.ctx, t.request.GetDbName(), t.collectionName, t.GetCollectionID(), t.schema.schemaHelper)
+	if err != nil {
+		return nil, nil, 0, false, nil, internalpb.SearchType_DEFAULT, err
+	}
+
+	hasFilter := dsl != "" || len(exprTemplateValues) > 0 || rowPolicy != nil
 	searchType := internalpb.SearchType_DEFAULT
 	// if function rerank is set, keep searchType DEFAULT; optimizations will be disabled in queryhook
 	if !hasFunctionRerank(t.request) {
@@ -1190,6 +1206,11 @@ func (t *searchTask) tryGeneratePlan(params []*commonpb.KeyValuePair, dsl string
 		return nil, nil, 0, false, nil, internalpb.SearchType_DEFAULT, merr.WrapErrParameterInvalidMsg("failed to create query plan: %v", planErr)
 	}
 	metrics.ProxyParseExpressionLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), "search", metrics.SuccessLabel).Observe(float64(time.Since(start).Microseconds()) / 1000.0)
+	if rowPolicy != nil {
+		if err := planparserv2.AndPlanPredicates(plan, rowPolicy); err != nil {
+			return nil, nil, 0, false, nil, internalpb.SearchType_DEFAULT, err
+		}
+	}
 	mlog.Debug(t.ctx, "create query plan",
 		mlog.String("dsl", t.request.Dsl), // may be very large if large term passed.
 		mlog.String("anns field", annsFieldName), mlog.Any("query info", searchInfo.planInfo))
@@ -1246,6 +1267,9 @@ func (t *searchTask) Execute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute search %d", t.ID()))
 	defer tr.CtxElapse(ctx, "done")
 
+	if t.resultCache.hit {
+		return nil
+	}
 	t.queryChannelsNode = typeutil.NewConcurrentMap[string, int64]()
 	if namespacePartitionKeyModeEnabled(t.schema.CollectionSchema) && t.request.Namespace != nil {
 		channelNames, err := t.chMgr.getVChannels(t.CollectionID)
@@ -1266,6 +1290,7 @@ func (t *searchTask) Execute(ctx context.Context) error {
 				Nq:              t.Nq,
 				Exec:            t.searchShard,
 				PreferredNodeID: preferredNodeFromConcurrentMap(t.queryChannelsNode, channelName),
+				Hedge:           true,
 			}); err != nil {
 				log.Warn(ctx, "search execute failed", mlog.Err(err))
 				return errors.Wrap(err, "failed to search")
@@ -1283,6 +1308,7 @@ func (t *searchTask) Execute(ctx context.Context) error {
 		CollectionName: t.collectionName,
 		Nq:             t.Nq,
 		Exec:           t.searchShard,
+		Hedge:          true,
 	})
 	if err != nil {
 		log.Warn(ctx, "search execute failed", mlog.Err(err))
@@ -1361,6 +1387,9 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	}()
 	log := mlog.With(mlog.Int64("nq", t.GetNq()))
 
+	if t.resultCache.hit {
+		return nil
+	}
 	toReduceResults, err := t.collectSearchResults(ctx)
 	if err != nil {
 		log.Warn(ctx, "failed to collect search results", mlog.Err(err))
@@ -1497,12 +1526,27 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 		}
 	}
 
+	t.resultCache.store(t.GetCollectionID(), t.getResultTs(), t.result)
+
 	log.Debug(ctx, "Search post execute done",
 		mlog.Int64("collection", t.GetCollectionID()),
 		mlog.Int64s("partitionIDs", t.GetPartitionIDs()))
 	return nil
 }
 
+// getResultTs returns the timestamp before which all the writes are visible to the search result.
+func (t *searchTask) getResultTs() Timestamp {
+	ts := t.GetGuaranteeTimestamp()
+	if len(t.queryChannelsTs) == 0 {
+		return ts
+	}
+	minMvccTs := typeutil.MaxTimestamp
+	for _, mvccTs := range t.queryChannelsTs {
+		minMvccTs = min(minMvccTs, mvccTs)
+	}
+	return max(ts, minMvccTs)
+}
+
 func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.QueryNodeClient, channel string) error {
 	ctx = retry.WithMaxAttemptsContext(ctx, 1)
 	searchReq := shallowcopy.ShallowCopySearchRequest(t.SearchRequest, nodeID)
@@ -1523,6 +1567,10 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 
 	result, err = qn.Search(ctx, req)
 	if err != nil {
+		if shardclient.IsHedgeLost(ctx) {
+			// the hedged request on the other replica won, the shard leaders are fine
+			return err
+		}
 		log.Warn(ctx, "QueryNode search return error", mlog.Err(err))
 		// globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
 		t.shardClientMgr.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
@@ -1538,6 +1586,10 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 			mlog.String("reason", result.GetStatus().GetReason()))
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to search on QueryNode %d", nodeID)
 	}
+	if !shardclient.ClaimHedgedResult(ctx) {
+		// the result of the hedged request on the other replica has been taken
+		return nil
+	}
 	if t.resultBuf != nil {
 		t.resultBuf.Insert(result)
 	}
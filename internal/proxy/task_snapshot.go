@@ -18,10 +18,12 @@ package proxy
 
 import (
 	"context"
+	"strings"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
@@ -113,6 +115,9 @@ func (cst *createSnapshotTask) PreExecute(ctx context.Context) error {
 	if err := ValidateSnapshotName(cst.req.GetName()); err != nil {
 		return err
 	}
+	if strings.HasPrefix(cst.req.GetName(), common.RecycleBinSnapshotPrefix) {
+		return merr.WrapErrParameterInvalidMsg("snapshot name prefix %s is reserved for the recycle bin", common.RecycleBinSnapshotPrefix)
+	}
 
 	// Validate compaction protection duration
 	maxCompactionProtectionSeconds := paramtable.Get().DataCoordCfg.SnapshotMaxCompactionProtectionSeconds.GetAsInt64()
@@ -113,6 +113,20 @@ func TestCreateSnapshotTask_PreExecute_CollectionNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "collection not found")
 }
 
+func TestCreateSnapshotTask_PreExecute_RecycleBinPrefix(t *testing.T) {
+	task := &createSnapshotTask{
+		req: &milvuspb.CreateSnapshotRequest{
+			Name:           "__recycle_bin__100_1700000000",
+			DbName:         "default",
+			CollectionName: "test_collection",
+		},
+	}
+
+	err := task.PreExecute(context.Background())
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	assert.True(t, strings.Contains(err.Error(), "reserved for the recycle bin"))
+}
+
 func TestCreateSnapshotTask_PreExecute_ProtectionNegative(t *testing.T) {
 	task := &createSnapshotTask{
 		req: &milvuspb.CreateSnapshotRequest{
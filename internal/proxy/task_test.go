@@ -7399,6 +7399,15 @@ func TestValidateCollectionTTL(t *testing.T) {
 	}
 }
 
+func TestValidateBinlogCompression(t *testing.T) {
+	assert.NoError(t, validateBinlogCompression(nil))
+	for _, value := range []string{"zstd", "lz4", "snappy"} {
+		assert.NoError(t, validateBinlogCompression([]*commonpb.KeyValuePair{{Key: common.CollectionBinlogCompressionKey, Value: value}}))
+	}
+	err := validateBinlogCompression([]*commonpb.KeyValuePair{{Key: common.CollectionBinlogCompressionKey, Value: "gzip"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
 func TestHasWarmupProp(t *testing.T) {
 	t.Run("has generic warmup key", func(t *testing.T) {
 		props := []*commonpb.KeyValuePair{
@@ -30,12 +30,14 @@ import (
 	"github.com/milvus-io/milvus/internal/allocator"
 	"github.com/milvus-io/milvus/internal/parser/planparserv2"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
 	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -158,8 +160,15 @@ func (it *upsertTask) OnEnqueue() error {
 }
 
 func retrieveByPKs(ctx context.Context, t *upsertTask, ids *schemapb.IDs, outputFields []string) (*milvuspb.QueryResults, segcore.StorageCost, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(t.schema.CollectionSchema)
+	if err != nil {
+		return nil, segcore.StorageCost{}, err
+	}
+	return retrieveByPlan(ctx, t, planparserv2.CreateRequeryPlan(pkField, ids), outputFields)
+}
+
+func retrieveByPlan(ctx context.Context, t *upsertTask, plan *planpb.PlanNode, outputFields []string) (*milvuspb.QueryResults, segcore.StorageCost, error) {
 	log := mlog.With(mlog.String("collectionName", t.req.GetCollectionName()))
-	var err error
 	queryReq := &milvuspb.QueryRequest{
 		Base: &commonpb.MsgBase{
 			MsgType:   commonpb.MsgType_Retrieve,
@@ -169,15 +178,11 @@ func retrieveByPKs(ctx context.Context, t *upsertTask, ids *schemapb.IDs, output
 		CollectionName:        t.req.GetCollectionName(),
 		ConsistencyLevel:      commonpb.ConsistencyLevel_Strong,
 		NotReturnAllMeta:      false,
-		OutputFields:          []string{"*"},
+		OutputFields:          outputFields,
 		UseDefaultConsistency: false,
 		GuaranteeTimestamp:    t.BeginTs(),
 		Namespace:             t.req.Namespace,
 	}
-	pkField, err := typeutil.GetPrimaryFieldSchema(t.schema.CollectionSchema)
-	if err != nil {
-		return nil, segcore.StorageCost{}, err
-	}
 
 	var partitionIDs []int64
 	if t.partitionKeyMode {
@@ -202,7 +207,6 @@ func retrieveByPKs(ctx context.Context, t *upsertTask, ids *schemapb.IDs, output
 		queryReq.PartitionNames = []string{partName}
 	}
 
-	plan := planparserv2.CreateRequeryPlan(pkField, ids)
 	plan.Namespace = namespaceForPlan(t.schema.CollectionSchema, t.req.Namespace)
 	qt := &queryTask{
 		ctx:       t.ctx,
@@ -236,6 +240,49 @@ func retrieveByPKs(ctx context.Context, t *upsertTask, ids *schemapb.IDs, output
 	return queryResult, storageCost, err
 }
 
+// checkRowPolicy rejects the upsert if any upserted row or any existing row to be replaced
+// is out of the row policy of current user.
+func (it *upsertTask) checkRowPolicy(ctx context.Context) error {
+	policy, err := getRowPolicy(ctx, it.req.GetDbName(), it.req.GetCollectionName(), it.collectionID, it.schema.schemaHelper)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	// the upserted rows are evaluated by the same row filter as the rows read from change stream.
+	filter, err := exprutil.NewRowFilter(policy)
+	if err != nil {
+		return merr.WrapErrPrivilegeNotPermitted("row policy on collection %s could not be evaluated on the upserted rows: %v", it.req.GetCollectionName(), err)
+	}
+	numRows := int(it.upsertMsg.InsertMsg.GetNumRows())
+	if len(filter.Filter(it.upsertMsg.InsertMsg.GetFieldsData(), numRows)) != numRows {
+		return merr.WrapErrPrivilegeNotPermitted("upsert rows out of the row policy on collection %s", it.req.GetCollectionName())
+	}
+
+	ids := it.upsertMsg.DeleteMsg.PrimaryKeys
+	if typeutil.GetSizeOfIDs(ids) == 0 {
+		return nil
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(it.schema.CollectionSchema)
+	if err != nil {
+		return err
+	}
+
+	plan := planparserv2.CreateRequeryPlan(pkField, ids)
+	if err := planparserv2.AndPlanPredicates(plan, planparserv2.NotExpr(policy)); err != nil {
+		return err
+	}
+	resp, _, err := retrieveByPlan(ctx, it, plan, []string{pkField.GetName()})
+	if err != nil {
+		return err
+	}
+	for _, fieldData := range resp.GetFieldsData() {
+		if fieldData.GetFieldId() == pkField.GetFieldID() && typeutil.GetPKSize(fieldData) > 0 {
+			return merr.WrapErrPrivilegeNotPermitted("upsert rows out of the row policy on collection %s", it.req.GetCollectionName())
+		}
+	}
+	return nil
+}
+
 func (it *upsertTask) queryPreExecute(ctx context.Context) error {
 	log := mlog.With(mlog.String("collectionName", it.req.CollectionName))
 
@@ -1693,6 +1740,12 @@ func (it *upsertTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	err = it.checkRowPolicy(ctx)
+	if err != nil {
+		log.Warn(ctx, "Fail to check row policy", mlog.Err(err))
+		return err
+	}
+
 	it.result.DeleteCnt = it.upsertMsg.DeleteMsg.NumRows
 	it.result.InsertCnt = int64(it.upsertMsg.InsertMsg.NumRows)
 	if it.result.DeleteCnt != it.result.InsertCnt {
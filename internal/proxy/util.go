@@ -45,6 +45,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/function/models"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
+	"github.com/milvus-io/milvus/internal/util/searchutil/scheduler"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/milvus-io/milvus/pkg/v3/common"
@@ -54,8 +55,10 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/util"
 	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/contextutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/crypto"
 	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
@@ -1428,6 +1431,43 @@ func GetCurUserFromContextOrDefault(ctx context.Context) string {
 	return username
 }
 
+// getWorkloadClass returns the workload class of the read request for the query node scheduler.
+// The class granted by the administrators is the collection.workload.class property of the collection,
+// or the database.workload.class property of the database if not specified. The workload_class param
+// of the request is taken only if its weight is not higher than the granted one, so the users could
+// lower the priority of their requests, e.g. run a report in the batch class, but never raise it.
+// It's only looked up when the query nodes schedule the read tasks by workload class.
+func getWorkloadClass(ctx context.Context, dbName string, collInfo *collectionInfo, params []*commonpb.KeyValuePair) string {
+	if paramtable.Get().QueryNodeCfg.SchedulePolicyName.GetValue() != scheduler.SchedulePolicyNameWorkloadClass {
+		return ""
+	}
+	granted := getGrantedWorkloadClass(ctx, dbName, collInfo)
+	requested, ok := funcutil.TryGetAttrByKeyFromRepeatedKV(WorkloadClassKey, params)
+	if !ok || requested == "" || requested == granted {
+		return granted
+	}
+	if scheduler.WorkloadClassWeight(requested) > scheduler.WorkloadClassWeight(granted) {
+		mlog.Debug(ctx, "ignore the workload class of higher priority than granted",
+			mlog.String("requested", requested), mlog.String("granted", granted))
+		return granted
+	}
+	return requested
+}
+
+// getGrantedWorkloadClass returns the workload class set by the collection or database properties.
+func getGrantedWorkloadClass(ctx context.Context, dbName string, collInfo *collectionInfo) string {
+	if class, ok := funcutil.TryGetAttrByKeyFromRepeatedKV(common.CollectionWorkloadClassKey, collInfo.properties); ok && class != "" {
+		return class
+	}
+	dbInfo, err := globalMetaCache.GetDatabaseInfo(ctx, dbName)
+	if err != nil {
+		mlog.Warn(ctx, "failed to get database info for workload class", mlog.String("dbName", dbName), mlog.Err(err))
+		return ""
+	}
+	class, _ := funcutil.TryGetAttrByKeyFromRepeatedKV(common.DatabaseWorkloadClassKey, dbInfo.properties)
+	return class
+}
+
 func GetCurDBNameFromContextOrDefault(ctx context.Context) string {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -1683,9 +1723,9 @@ func translateOutputFields(outputFields []string, schema *schemaInfo, removePkFi
 			}
 			useAllDyncamicFields = true
 		} else {
-			if isAgg, aggregateName, aggFieldName := agg.MatchAggregationExpression(outputFieldName); isAgg {
+			if isAgg, aggregateName, aggFieldName, aggArg := agg.MatchAggregationExpression(outputFieldName); isAgg {
 				if aggField, ok := allFieldNameMap[aggFieldName]; ok {
-					aggFuncs, aggErr := agg.NewAggregate(aggregateName, aggField.GetFieldID(), outputFieldName, aggField.GetDataType())
+					aggFuncs, aggErr := agg.NewAggregateWithArg(aggregateName, aggField.GetFieldID(), outputFieldName, aggField.GetDataType(), aggArg)
 					if aggErr != nil {
 						return nil, nil, nil, nil, false, aggErr
 					}
@@ -3513,6 +3553,24 @@ func getBM25FunctionOfAnnsField(fieldID int64, functions []*schemapb.FunctionSch
 // requestutil.ParseMetricLabel emits at the gRPC interceptor. The status stays
 // the coarse "fail" so that a query written against it counts every hard
 // failure regardless of who caused it.
+// getWALCompressionConfig returns the compression config of the dml messages written by proxy,
+// nil if the wal compression is disabled.
+func getWALCompressionConfig() *message.CompressionConfig {
+	value := paramtable.Get().ProxyCfg.WALCompressionType.GetValue()
+	if value == "" {
+		return nil
+	}
+	typ, err := compressor.ParseCompressType(value)
+	if err != nil {
+		mlog.RatedWarn(context.TODO(), 60, "invalid wal compression type, disable the wal compression", mlog.String("type", value))
+		return nil
+	}
+	return &message.CompressionConfig{
+		Type:            typ,
+		MinPayloadBytes: paramtable.Get().ProxyCfg.WALCompressionMinPayloadBytes.GetAsInt(),
+	}
+}
+
 func failMetricLabel(err error) (status string, cause string) {
 	// Client cancellation is neither party's failure; cause is what lets a
 	// consumer exclude it (parity with ParseMetricLabel).
@@ -6889,3 +6889,62 @@ func TestFailMetricLabel(t *testing.T) {
 	assertLabels(metrics.CauseCancel, context.Canceled)
 	assertLabels(metrics.CauseCancel, errors.Wrap(context.Canceled, "rpc aborted"))
 }
+
+func TestGetWorkloadClass(t *testing.T) {
+	paramtable.Init()
+	cache := NewMockCache(t)
+	cache.EXPECT().GetDatabaseInfo(mock.Anything, "db1").Return(&databaseInfo{
+		properties: []*commonpb.KeyValuePair{{Key: common.DatabaseWorkloadClassKey, Value: "batch"}},
+	}, nil).Maybe()
+	cache.EXPECT().GetDatabaseInfo(mock.Anything, "db2").Return(&databaseInfo{}, nil).Maybe()
+	cache.EXPECT().GetDatabaseInfo(mock.Anything, "db3").Return(nil, merr.WrapErrDatabaseNotFound("db3")).Maybe()
+	oldCache := globalMetaCache
+	globalMetaCache = cache
+	defer func() { globalMetaCache = oldCache }()
+
+	ctx := context.Background()
+	collInfo := &collectionInfo{
+		properties: []*commonpb.KeyValuePair{{Key: common.CollectionWorkloadClassKey, Value: "interactive"}},
+	}
+
+	// the properties are ignored unless the query nodes schedule by workload class.
+	assert.Equal(t, "", getWorkloadClass(ctx, "db1", collInfo, nil))
+	assert.Equal(t, "", getWorkloadClass(ctx, "db1", &collectionInfo{}, nil))
+
+	paramtable.Get().Save(Params.QueryNodeCfg.SchedulePolicyName.Key, "workload-class")
+	defer paramtable.Get().Reset(Params.QueryNodeCfg.SchedulePolicyName.Key)
+	assert.Equal(t, "interactive", getWorkloadClass(ctx, "db1", collInfo, nil))
+	assert.Equal(t, "batch", getWorkloadClass(ctx, "db1", &collectionInfo{}, nil))
+	assert.Equal(t, "", getWorkloadClass(ctx, "db2", &collectionInfo{}, nil))
+	assert.Equal(t, "", getWorkloadClass(ctx, "db3", &collectionInfo{}, nil))
+
+	// the request could only lower its priority.
+	params := func(class string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: WorkloadClassKey, Value: class}}
+	}
+	assert.Equal(t, "batch", getWorkloadClass(ctx, "db1", collInfo, params("batch")))
+	assert.Equal(t, "iterator", getWorkloadClass(ctx, "db1", &collectionInfo{}, params("iterator")))
+	assert.Equal(t, "batch", getWorkloadClass(ctx, "db1", &collectionInfo{}, params("interactive")))
+	assert.Equal(t, "batch", getWorkloadClass(ctx, "db1", &collectionInfo{}, params("unknown")))
+	// the default class is granted without properties.
+	assert.Equal(t, "batch", getWorkloadClass(ctx, "db2", &collectionInfo{}, params("batch")))
+	assert.Equal(t, "interactive", getWorkloadClass(ctx, "db2", &collectionInfo{}, params("interactive")))
+}
+
+func TestGetWALCompressionConfig(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	assert.Nil(t, getWALCompressionConfig())
+
+	params.Save(params.ProxyCfg.WALCompressionType.Key, "lz4")
+	defer params.Reset(params.ProxyCfg.WALCompressionType.Key)
+	params.Save(params.ProxyCfg.WALCompressionMinPayloadBytes.Key, "1024")
+	defer params.Reset(params.ProxyCfg.WALCompressionMinPayloadBytes.Key)
+	cfg := getWALCompressionConfig()
+	assert.NotNil(t, cfg)
+	assert.EqualValues(t, "lz4", cfg.Type)
+	assert.Equal(t, 1024, cfg.MinPayloadBytes)
+
+	params.Save(params.ProxyCfg.WALCompressionType.Key, "gzip")
+	assert.Nil(t, getWALCompressionConfig())
+}
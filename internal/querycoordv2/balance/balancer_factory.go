@@ -119,6 +119,8 @@ func (f *BalancerFactory) GetBalancer() Balance {
 		balancer = NewMultiTargetBalancer(f.scheduler, f.nodeManager, f.dist, f.targetMgr)
 	case meta.ChannelLevelScoreBalancerName:
 		balancer = NewChannelLevelScoreBalancer(f.scheduler, f.nodeManager, f.dist, f.targetMgr)
+	case meta.ResourceAwareBalancerName:
+		balancer = NewResourceAwareBalancer(f.scheduler, f.nodeManager, f.dist, f.targetMgr)
 	default:
 		mlog.Info(context.TODO(), "Unknown balancer type, using default",
 			mlog.String("requested", balanceKey),
@@ -64,4 +64,11 @@ func TestBalancerFactory_GetBalancer(t *testing.T) {
 		balancer := f.GetBalancer()
 		assert.IsType(t, &RoundRobinBalancer{}, balancer)
 	})
+
+	t.Run("explicit resource aware balancer", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().QueryCoordCfg.Balancer.Key, meta.ResourceAwareBalancerName)
+
+		balancer := f.GetBalancer()
+		assert.IsType(t, &ResourceAwareBalancer{}, balancer)
+	})
 }
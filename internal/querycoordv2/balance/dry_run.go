@@ -0,0 +1,172 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"slices"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/assign"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+)
+
+// PlanExplain explains a planned segment or channel move of a balance dry run.
+type PlanExplain struct {
+	CollectionID int64   `json:"collection_id"`
+	ReplicaID    int64   `json:"replica_id"`
+	SegmentID    int64   `json:"segment_id,omitempty"`
+	Channel      string  `json:"channel,omitempty"`
+	From         int64   `json:"from"`
+	To           int64   `json:"to"`
+	Score        float64 `json:"score"`
+	Reason       string  `json:"reason"`
+}
+
+// NodeScoreExplain is the score of a node in a replica before and after the planned moves.
+// The scores are computed by the assign policy of the balancer if it's score aware,
+// otherwise the segment score is the row count and the channel score is the channel count of the collection on the node.
+type NodeScoreExplain struct {
+	CollectionID       int64   `json:"collection_id"`
+	ReplicaID          int64   `json:"replica_id"`
+	NodeID             int64   `json:"node_id"`
+	SegmentScoreBefore float64 `json:"segment_score_before"`
+	SegmentScoreAfter  float64 `json:"segment_score_after"`
+	ChannelScoreBefore float64 `json:"channel_score_before"`
+	ChannelScoreAfter  float64 `json:"channel_score_after"`
+}
+
+// PlanReport is the result of a balance dry run, nothing in it has been submitted to the scheduler.
+type PlanReport struct {
+	SegmentPlans []*PlanExplain      `json:"segment_plans"`
+	ChannelPlans []*PlanExplain      `json:"channel_plans"`
+	Nodes        []*NodeScoreExplain `json:"nodes"`
+}
+
+// NewPlanReport returns an empty PlanReport.
+func NewPlanReport() *PlanReport {
+	return &PlanReport{
+		SegmentPlans: make([]*PlanExplain, 0),
+		ChannelPlans: make([]*PlanExplain, 0),
+		Nodes:        make([]*NodeScoreExplain, 0),
+	}
+}
+
+// Empty returns whether there is no planned move in the report.
+func (r *PlanReport) Empty() bool {
+	return len(r.SegmentPlans) == 0 && len(r.ChannelPlans) == 0
+}
+
+// AddReplicaPlans explains the plans generated by balancer for replica, and records the score of every node of the replica.
+func (r *PlanReport) AddReplicaPlans(balancer Balance, dist *meta.DistributionManager, replica *meta.Replica,
+	segmentPlans []assign.SegmentAssignPlan, channelPlans []assign.ChannelAssignPlan, segmentReason, channelReason string,
+) {
+	if len(segmentPlans) == 0 && len(channelPlans) == 0 {
+		return
+	}
+
+	collectionID := replica.GetCollectionID()
+	nodes := slices.Sorted(slices.Values(replica.GetNodes()))
+	segmentScores, channelScores := nodeScores(balancer, dist, collectionID, nodes)
+	scorer, scoreAware := balancer.GetAssignPolicy().(assign.ScoreAwareAssignPolicy)
+
+	after := make(map[int64]*NodeScoreExplain, len(nodes))
+	for _, node := range nodes {
+		after[node] = &NodeScoreExplain{
+			CollectionID:       collectionID,
+			ReplicaID:          replica.GetID(),
+			NodeID:             node,
+			SegmentScoreBefore: segmentScores[node],
+			SegmentScoreAfter:  segmentScores[node],
+			ChannelScoreBefore: channelScores[node],
+			ChannelScoreAfter:  channelScores[node],
+		}
+	}
+
+	for _, plan := range segmentPlans {
+		score := float64(plan.Segment.GetNumOfRows())
+		if scoreAware {
+			score = scorer.CalculateSegmentScore(plan.Segment)
+		}
+		if item, ok := after[plan.From]; ok {
+			item.SegmentScoreAfter -= score
+		}
+		if item, ok := after[plan.To]; ok {
+			item.SegmentScoreAfter += score
+		}
+		r.SegmentPlans = append(r.SegmentPlans, &PlanExplain{
+			CollectionID: collectionID,
+			ReplicaID:    replica.GetID(),
+			SegmentID:    plan.Segment.GetID(),
+			Channel:      plan.Segment.GetInsertChannel(),
+			From:         plan.From,
+			To:           plan.To,
+			Score:        score,
+			Reason:       segmentReason,
+		})
+	}
+
+	for _, plan := range channelPlans {
+		score := float64(1)
+		if scoreAware {
+			score = scorer.CalculateChannelScore(plan.Channel, collectionID)
+		}
+		if item, ok := after[plan.From]; ok {
+			item.ChannelScoreAfter -= score
+		}
+		if item, ok := after[plan.To]; ok {
+			item.ChannelScoreAfter += score
+		}
+		r.ChannelPlans = append(r.ChannelPlans, &PlanExplain{
+			CollectionID: collectionID,
+			ReplicaID:    replica.GetID(),
+			Channel:      plan.Channel.GetChannelName(),
+			From:         plan.From,
+			To:           plan.To,
+			Score:        score,
+			Reason:       channelReason,
+		})
+	}
+
+	for _, node := range nodes {
+		r.Nodes = append(r.Nodes, after[node])
+	}
+}
+
+// nodeScores returns the current segment and channel scores of nodes for collection.
+func nodeScores(balancer Balance, dist *meta.DistributionManager, collectionID int64, nodes []int64) (map[int64]float64, map[int64]float64) {
+	segmentScores := make(map[int64]float64, len(nodes))
+	channelScores := make(map[int64]float64, len(nodes))
+
+	if scorer, ok := balancer.GetAssignPolicy().(assign.ScoreAwareAssignPolicy); ok {
+		for node, item := range scorer.ConvertToNodeItemsBySegment(collectionID, nodes) {
+			segmentScores[node] = item.GetCurrentScore()
+		}
+		for node, item := range scorer.ConvertToNodeItemsByChannel(collectionID, nodes) {
+			channelScores[node] = item.GetCurrentScore()
+		}
+		return segmentScores, channelScores
+	}
+
+	for _, node := range nodes {
+		segments := dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(collectionID), meta.WithNodeID(node))
+		for _, segment := range segments {
+			segmentScores[node] += float64(segment.GetNumOfRows())
+		}
+		channels := dist.ChannelDistManager.GetByFilter(meta.WithCollectionID2Channel(collectionID), meta.WithNodeID2Channel(node))
+		channelScores[node] = float64(len(channels))
+	}
+	return segmentScores, channelScores
+}
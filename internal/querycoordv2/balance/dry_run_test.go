@@ -0,0 +1,98 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/assign"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+)
+
+func TestPlanReportAddReplicaPlans(t *testing.T) {
+	dist := meta.NewDistributionManager(session.NewNodeManager())
+	segment1 := &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 1, CollectionID: 1, NumOfRows: 100, InsertChannel: "ch1"}, Node: 1}
+	segment2 := &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 2, CollectionID: 1, NumOfRows: 50, InsertChannel: "ch1"}, Node: 1}
+	dist.SegmentDistManager.Update(1, segment1, segment2)
+	dist.SegmentDistManager.Update(2, &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 3, CollectionID: 1, NumOfRows: 10, InsertChannel: "ch2"}, Node: 2})
+	channel1 := utils.CreateTestChannel(1, 1, 1, "ch1")
+	channel2 := utils.CreateTestChannel(1, 1, 1, "ch2")
+	dist.ChannelDistManager.Update(1, channel1, channel2)
+
+	balancer := NewMockBalancer(t)
+	balancer.EXPECT().GetAssignPolicy().Return(assign.NewMockAssignPolicy(t))
+	replica := utils.CreateTestReplica(10, 1, []int64{2, 1})
+
+	report := NewPlanReport()
+	report.AddReplicaPlans(balancer, dist, replica, nil, nil, "segment unbalanced", "channel unbalanced")
+	assert.True(t, report.Empty())
+	assert.Empty(t, report.Nodes)
+
+	segmentPlans := []assign.SegmentAssignPlan{{Segment: segment1, Replica: replica, From: 1, To: 2}}
+	channelPlans := []assign.ChannelAssignPlan{{Channel: channel2, Replica: replica, From: 1, To: 2}}
+	report.AddReplicaPlans(balancer, dist, replica, segmentPlans, channelPlans, "segment unbalanced", "channel unbalanced")
+	assert.False(t, report.Empty())
+
+	assert.Len(t, report.SegmentPlans, 1)
+	assert.Equal(t, &PlanExplain{
+		CollectionID: 1,
+		ReplicaID:    10,
+		SegmentID:    1,
+		Channel:      "ch1",
+		From:         1,
+		To:           2,
+		Score:        100,
+		Reason:       "segment unbalanced",
+	}, report.SegmentPlans[0])
+
+	assert.Len(t, report.ChannelPlans, 1)
+	assert.Equal(t, &PlanExplain{
+		CollectionID: 1,
+		ReplicaID:    10,
+		Channel:      "ch2",
+		From:         1,
+		To:           2,
+		Score:        1,
+		Reason:       "channel unbalanced",
+	}, report.ChannelPlans[0])
+
+	assert.Equal(t, []*NodeScoreExplain{
+		{
+			CollectionID:       1,
+			ReplicaID:          10,
+			NodeID:             1,
+			SegmentScoreBefore: 150,
+			SegmentScoreAfter:  50,
+			ChannelScoreBefore: 2,
+			ChannelScoreAfter:  1,
+		},
+		{
+			CollectionID:       1,
+			ReplicaID:          10,
+			NodeID:             2,
+			SegmentScoreBefore: 10,
+			SegmentScoreAfter:  110,
+			ChannelScoreBefore: 0,
+			ChannelScoreAfter:  1,
+		},
+	}, report.Nodes)
+}
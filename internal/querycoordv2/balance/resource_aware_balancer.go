@@ -0,0 +1,290 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/assign"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	// resourceLoadScale scales the node load in [0, 1] into score, so the integer priority of NodeItem keeps the precision.
+	resourceLoadScale = 10000
+	// resourceUsageStaleIntervals is the number of pull intervals after which the resource usage of node is stale.
+	resourceUsageStaleIntervals = 3
+)
+
+// ResourceAwareBalancer balances segments by the resource usage reported by query nodes.
+// The load of a node is the weighted sum of its cpu, memory and local disk usage,
+// and the share of a segment is estimated by its query cost share for cpu, and its row count share for memory and disk.
+// The hottest segments on the nodes whose load exceeds the average of the replica are moved to the least loaded nodes,
+// even if the row counts look even.
+// Channels are balanced the same as ScoreBasedBalancer, and it falls back to ScoreBasedBalancer
+// if the resource usage of any node isn't ready.
+type ResourceAwareBalancer struct {
+	*ScoreBasedBalancer
+}
+
+// NewResourceAwareBalancer creates a new ResourceAwareBalancer instance.
+func NewResourceAwareBalancer(scheduler task.Scheduler,
+	nodeManager *session.NodeManager,
+	dist *meta.DistributionManager,
+	targetMgr meta.TargetManagerInterface,
+) *ResourceAwareBalancer {
+	return &ResourceAwareBalancer{
+		ScoreBasedBalancer: NewScoreBasedBalancer(scheduler, nodeManager, dist, targetMgr),
+	}
+}
+
+// resourceWeights is the weights of resources in the node load.
+type resourceWeights struct {
+	cpu    float64
+	memory float64
+	disk   float64
+}
+
+func newResourceWeights() resourceWeights {
+	params := paramtable.Get()
+	return resourceWeights{
+		cpu:    params.QueryCoordCfg.ResourceAwareCPUWeight.GetAsFloat(),
+		memory: params.QueryCoordCfg.ResourceAwareMemoryWeight.GetAsFloat(),
+		disk:   params.QueryCoordCfg.ResourceAwareDiskWeight.GetAsFloat(),
+	}
+}
+
+// nodeResourceLoad is the resource load of a node.
+type nodeResourceLoad struct {
+	weights   resourceWeights
+	usage     *session.ResourceUsage
+	rowCount  int64   // row count of all sealed segments on the node
+	queryLoad float64 // query load of all sealed segments on the node
+}
+
+// score returns the load score of the node.
+func (l *nodeResourceLoad) score() float64 {
+	return (l.weights.cpu*l.usage.CPUUsage + l.weights.memory*l.usage.MemoryUsage + l.weights.disk*l.usage.DiskUsage) * resourceLoadScale
+}
+
+// rowScore returns the load score of rows on the node, which only takes memory and disk.
+func (l *nodeResourceLoad) rowScore(rows int64) float64 {
+	if l.rowCount <= 0 {
+		return 0
+	}
+	return (l.weights.memory*l.usage.MemoryUsage + l.weights.disk*l.usage.DiskUsage) * float64(rows) / float64(l.rowCount) * resourceLoadScale
+}
+
+// segmentScore returns the share of load score of segment on the node.
+func (l *nodeResourceLoad) segmentScore(s *meta.Segment) float64 {
+	score := l.rowScore(s.GetNumOfRows())
+	if l.queryLoad > 0 {
+		score += l.weights.cpu * l.usage.CPUUsage * l.usage.SegmentQueryLoad[s.GetID()] / l.queryLoad * resourceLoadScale
+	}
+	return score
+}
+
+// BalanceReplica balances segments and channels across nodes within a replica based on the resource usage of nodes.
+func (b *ResourceAwareBalancer) BalanceReplica(ctx context.Context, replica *meta.Replica) (segmentPlans []assign.SegmentAssignPlan, channelPlans []assign.ChannelAssignPlan) {
+	log := mlog.With(
+		mlog.Int64("collection", replica.GetCollectionID()),
+		mlog.Int64("replica id", replica.GetID()),
+		mlog.String("replica group", replica.GetResourceGroup()),
+	)
+	if replica.NodesCount() < 2 {
+		return nil, nil
+	}
+
+	rwNodes := replica.GetRWNodes()
+	loads, notReady := b.getNodeLoads(rwNodes)
+	if len(notReady) > 0 {
+		log.RatedInfo(ctx, rate.Limit(60), "resource usage of nodes not ready, fall back to score based balance",
+			mlog.Int64s("nodes", notReady))
+		return b.ScoreBasedBalancer.BalanceReplica(ctx, replica)
+	}
+
+	br := NewBalanceReport()
+	defer func() {
+		if len(segmentPlans) == 0 && len(channelPlans) == 0 {
+			log.RatedDebug(ctx, rate.Limit(60), "no plan generated, balance report", mlog.Stringers("nodesInfo", br.NodesInfo()), mlog.Stringers("records", br.detailRecords))
+		} else {
+			log.Info(ctx, "balance plan generated", mlog.Stringers("nodesInfo", br.NodesInfo()), mlog.Stringers("report details", br.records))
+		}
+	}()
+
+	if paramtable.Get().QueryCoordCfg.AutoBalanceChannel.GetAsBool() {
+		channelPlans = b.balanceChannels(ctx, br, replica)
+	}
+	if len(channelPlans) == 0 && len(rwNodes) >= 2 {
+		segmentPlans = b.genResourceSegmentPlan(ctx, br, replica, rwNodes, loads)
+	}
+	return segmentPlans, channelPlans
+}
+
+// getNodeLoads returns the resource load of nodes, and the nodes whose resource usage isn't ready.
+func (b *ResourceAwareBalancer) getNodeLoads(nodes []int64) (map[int64]*nodeResourceLoad, []int64) {
+	weights := newResourceWeights()
+	pullInterval := paramtable.Get().QueryCoordCfg.ResourceUsagePullInterval.GetAsDuration(time.Second)
+	staleBefore := time.Now().Add(-pullInterval * resourceUsageStaleIntervals)
+
+	loads := make(map[int64]*nodeResourceLoad, len(nodes))
+	notReady := make([]int64, 0)
+	for _, node := range nodes {
+		var usage *session.ResourceUsage
+		if info := b.nodeManager.Get(node); info != nil {
+			usage = info.ResourceUsage()
+		}
+		if !usage.IsReady(staleBefore) {
+			notReady = append(notReady, node)
+			continue
+		}
+		load := &nodeResourceLoad{
+			weights: weights,
+			usage:   usage,
+		}
+		for _, s := range b.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(node)) {
+			load.rowCount += s.GetNumOfRows()
+			load.queryLoad += load.usage.SegmentQueryLoad[s.GetID()]
+		}
+		loads[node] = load
+	}
+	return loads, notReady
+}
+
+// genResourceSegmentPlan picks the hottest segments from the nodes whose load exceeds the average,
+// and moves them to the least loaded nodes if the move doesn't reverse the unbalance.
+func (b *ResourceAwareBalancer) genResourceSegmentPlan(ctx context.Context, br *balanceReport, replica *meta.Replica,
+	rwNodes []int64, loads map[int64]*nodeResourceLoad,
+) []assign.SegmentAssignPlan {
+	// take the rows of executing segment tasks into account, since the usage reported hasn't include them yet
+	delta := b.scheduler.GetSegmentTaskDeltaSnapshot(rwNodes, replica.GetCollectionID())
+	nodeItems := make(map[int64]*assign.NodeItem, len(rwNodes))
+	totalScore := float64(0)
+	for _, node := range rwNodes {
+		load := loads[node]
+		item := assign.NewNodeItem(0, node)
+		item.AddCurrentScoreDelta(load.score() + load.rowScore(int64(delta.GetByNode(node))))
+		nodeItems[node] = &item
+		totalScore += item.GetCurrentScore()
+	}
+	average := totalScore / float64(len(rwNodes))
+	for _, item := range nodeItems {
+		item.AddAssignedScore(average)
+		br.AddNodeItem(item)
+	}
+
+	threshold := paramtable.Get().QueryCoordCfg.ResourceAwareUnbalanceThreshold.GetAsFloat() * resourceLoadScale
+	sourceNodes := lo.Filter(rwNodes, func(node int64, _ int) bool {
+		return nodeItems[node].GetCurrentScore()-nodeItems[node].GetAssignedScore() > threshold
+	})
+	if len(sourceNodes) == 0 {
+		br.AddRecord(StrRecordf("no node exceeds the average load(%f) by threshold(%f)", average, threshold))
+		return nil
+	}
+	sort.Slice(sourceNodes, func(i, j int) bool {
+		return nodeItems[sourceNodes[i]].GetCurrentScore() > nodeItems[sourceNodes[j]].GetCurrentScore()
+	})
+
+	// only the nodes which don't need to shed load could be the target
+	queue := assign.NewPriorityQueue()
+	for _, node := range rwNodes {
+		info := b.nodeManager.Get(node)
+		if lo.Contains(sourceNodes, node) || info == nil || info.GetState() != session.NodeStateNormal || b.nodeManager.IsResourceExhausted(node) {
+			continue
+		}
+		queue.Push(nodeItems[node])
+	}
+	if queue.Len() == 0 {
+		br.AddRecord(StrRecord("no available node to move segments to"))
+		return nil
+	}
+
+	batchSize := paramtable.Get().QueryCoordCfg.BalanceSegmentBatchSize.GetAsInt()
+	plans := make([]assign.SegmentAssignPlan, 0)
+	for _, node := range sourceNodes {
+		source := nodeItems[node]
+		load := loads[node]
+		for _, s := range b.getHotSegments(ctx, br, replica, node, load) {
+			if source.GetCurrentScore() <= source.GetAssignedScore() || len(plans) >= batchSize {
+				break
+			}
+			segmentScore := load.segmentScore(s)
+			if segmentScore <= 0 {
+				continue
+			}
+			target := queue.Pop().(*assign.NodeItem)
+			// the move shouldn't make the target node more loaded than the source node
+			if source.GetCurrentScore()-target.GetCurrentScore() <= threshold ||
+				target.GetCurrentScore()+segmentScore > source.GetCurrentScore()-segmentScore {
+				br.AddRecord(StrRecordf("skip segment %d with score %f on node %d(%f), since no enough benefit to move to node %d(%f)",
+					s.GetID(), segmentScore, node, source.GetCurrentScore(), target.NodeID, target.GetCurrentScore()))
+				queue.Push(target)
+				continue
+			}
+
+			br.AddRecord(StrRecordf("move segment %d with score %f from node %d(%f) to node %d(%f)",
+				s.GetID(), segmentScore, node, source.GetCurrentScore(), target.NodeID, target.GetCurrentScore()))
+			plans = append(plans, assign.SegmentAssignPlan{
+				Segment:      s,
+				Replica:      replica,
+				From:         node,
+				To:           target.NodeID,
+				FromScore:    int64(source.GetCurrentScore()),
+				ToScore:      int64(target.GetCurrentScore()),
+				SegmentScore: int64(segmentScore),
+			})
+			source.AddCurrentScoreDelta(-segmentScore)
+			target.AddCurrentScoreDelta(segmentScore)
+			queue.Push(target)
+		}
+	}
+	return plans
+}
+
+// getHotSegments returns the movable segments of replica on node, sorted by query load and row count in descending order.
+func (b *ResourceAwareBalancer) getHotSegments(ctx context.Context, br *balanceReport, replica *meta.Replica, node int64, load *nodeResourceLoad) []*meta.Segment {
+	segments := b.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(replica.GetCollectionID()), meta.WithNodeID(node))
+	segments = lo.Filter(segments, func(s *meta.Segment, _ int) bool {
+		if !b.targetMgr.CanSegmentBeMoved(ctx, s.GetCollectionID(), s.GetID()) {
+			return false
+		}
+		// if the segment are redundant, skip it's balance for now
+		times := len(b.dist.SegmentDistManager.GetByFilter(meta.WithReplica(replica), meta.WithSegmentID(s.GetID())))
+		if times != 1 {
+			br.AddRecord(StrRecordf("abort balancing segment %d since it appear multiple times(%d) in distribution", s.GetID(), times))
+			return false
+		}
+		return true
+	})
+	sort.Slice(segments, func(i, j int) bool {
+		loadI, loadJ := load.usage.SegmentQueryLoad[segments[i].GetID()], load.usage.SegmentQueryLoad[segments[j].GetID()]
+		if loadI != loadJ {
+			return loadI > loadJ
+		}
+		return segments[i].GetNumOfRows() > segments[j].GetNumOfRows()
+	})
+	return segments
+}
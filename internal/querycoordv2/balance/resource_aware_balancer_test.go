@@ -0,0 +1,164 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/assign"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type ResourceAwareBalancerTestSuite struct {
+	suite.Suite
+	balancer    *ResourceAwareBalancer
+	nodeManager *session.NodeManager
+	dist        *meta.DistributionManager
+	replica     *meta.Replica
+}
+
+func (suite *ResourceAwareBalancerTestSuite) SetupSuite() {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.AutoBalanceChannel.Key, "false")
+}
+
+func (suite *ResourceAwareBalancerTestSuite) TearDownSuite() {
+	paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.AutoBalanceChannel.Key)
+}
+
+func (suite *ResourceAwareBalancerTestSuite) SetupTest() {
+	suite.nodeManager = session.NewNodeManager()
+	suite.dist = meta.NewDistributionManager(suite.nodeManager)
+
+	scheduler := task.NewMockScheduler(suite.T())
+	scheduler.EXPECT().GetSegmentTaskDeltaSnapshot(mock.Anything, mock.Anything).
+		Return(task.NewSegmentTaskDeltaSnapshot(nil, nil)).Maybe()
+	scheduler.EXPECT().GetChannelTaskDelta(mock.Anything, mock.Anything).Return(0).Maybe()
+	targetMgr := meta.NewMockTargetManager(suite.T())
+	targetMgr.EXPECT().CanSegmentBeMoved(mock.Anything, mock.Anything, mock.Anything).Return(true).Maybe()
+
+	assign.ResetGlobalAssignPolicyFactoryForTest()
+	assign.InitGlobalAssignPolicyFactory(scheduler, suite.nodeManager, suite.dist, nil, targetMgr)
+	suite.balancer = NewResourceAwareBalancer(scheduler, suite.nodeManager, suite.dist, targetMgr)
+
+	// each node holds 6 segments with 100 rows, so the row counts are even
+	segmentID := int64(1)
+	for node := int64(1); node <= 3; node++ {
+		suite.nodeManager.Add(session.NewNodeInfo(session.ImmutableNodeInfo{NodeID: node}))
+		segments := make([]*meta.Segment, 0, 6)
+		for i := 0; i < 6; i++ {
+			segments = append(segments, &meta.Segment{
+				SegmentInfo: &datapb.SegmentInfo{ID: segmentID, CollectionID: 1, NumOfRows: 100, InsertChannel: "ch1"},
+				Node:        node,
+			})
+			segmentID++
+		}
+		suite.dist.SegmentDistManager.Update(node, segments...)
+	}
+	suite.replica = utils.CreateTestReplica(1, 1, []int64{1, 2, 3})
+}
+
+func (suite *ResourceAwareBalancerTestSuite) TearDownTest() {
+	assign.ResetGlobalAssignPolicyFactoryForTest()
+}
+
+// reportUsage reports the usage of node twice, so the query load of segments is ready.
+func (suite *ResourceAwareBalancerTestSuite) reportUsage(node int64, cpuUsage float64, segmentLoads map[int64]float64) {
+	segments := make([]*metricsinfo.SegmentQueryLoad, 0, len(segmentLoads))
+	for segmentID := range segmentLoads {
+		segments = append(segments, &metricsinfo.SegmentQueryLoad{SegmentID: segmentID})
+	}
+	report := &metricsinfo.QueryNodeResourceUsage{
+		NodeID:       node,
+		CPUCoreCount: 8,
+		CPUCoreUsage: cpuUsage,
+		Memory:       100,
+		MemoryUsage:  50,
+		Disk:         100,
+		DiskUsage:    10,
+		Segments:     segments,
+	}
+	now := time.Now()
+	info := suite.nodeManager.Get(node)
+	info.UpdateResourceUsage(report, now.Add(-10*time.Second))
+
+	segments = make([]*metricsinfo.SegmentQueryLoad, 0, len(segmentLoads))
+	for segmentID, load := range segmentLoads {
+		segments = append(segments, &metricsinfo.SegmentQueryLoad{SegmentID: segmentID, AccessCostMs: load * 10})
+	}
+	report.Segments = segments
+	info.UpdateResourceUsage(report, now)
+}
+
+func (suite *ResourceAwareBalancerTestSuite) TestBalanceHotSegments() {
+	ctx := context.Background()
+	suite.reportUsage(1, 90, map[int64]float64{1: 320, 2: 280, 3: 100, 4: 100, 5: 100, 6: 100})
+	suite.reportUsage(2, 20, map[int64]float64{7: 10, 8: 10})
+	suite.reportUsage(3, 20, map[int64]float64{13: 10, 14: 10})
+
+	segmentPlans, channelPlans := suite.balancer.BalanceReplica(ctx, suite.replica)
+	suite.Empty(channelPlans)
+	suite.Len(segmentPlans, 2)
+
+	// the hottest segment is moved first
+	suite.Equal(int64(1), segmentPlans[0].Segment.GetID())
+	suite.Equal(int64(1), segmentPlans[0].From)
+	suite.Contains([]int64{2, 3}, segmentPlans[0].To)
+
+	// the second hottest segment is skipped since it would make the target more loaded than the source
+	suite.Contains([]int64{3, 4, 5, 6}, segmentPlans[1].Segment.GetID())
+	suite.Equal(int64(1), segmentPlans[1].From)
+	suite.Contains([]int64{2, 3}, segmentPlans[1].To)
+	suite.NotEqual(segmentPlans[0].To, segmentPlans[1].To)
+}
+
+func (suite *ResourceAwareBalancerTestSuite) TestBalanceEvenLoad() {
+	ctx := context.Background()
+	suite.reportUsage(1, 30, map[int64]float64{1: 100})
+	suite.reportUsage(2, 20, map[int64]float64{7: 100})
+	suite.reportUsage(3, 25, map[int64]float64{13: 100})
+
+	segmentPlans, channelPlans := suite.balancer.BalanceReplica(ctx, suite.replica)
+	suite.Empty(channelPlans)
+	suite.Empty(segmentPlans)
+}
+
+func (suite *ResourceAwareBalancerTestSuite) TestFallbackWithoutUsage() {
+	ctx := context.Background()
+	suite.reportUsage(1, 90, map[int64]float64{1: 320, 2: 280})
+	suite.reportUsage(2, 20, map[int64]float64{7: 10})
+
+	// node 3 has no usage reported, fall back to score based balancer, which sees the even row counts
+	segmentPlans, channelPlans := suite.balancer.BalanceReplica(ctx, suite.replica)
+	suite.Empty(channelPlans)
+	suite.Empty(segmentPlans)
+}
+
+func TestResourceAwareBalancerSuite(t *testing.T) {
+	suite.Run(t, new(ResourceAwareBalancerTestSuite))
+}
@@ -240,6 +240,24 @@ func (b *BalanceChecker) constructStoppingBalanceQueue(ctx context.Context) *ass
 //
 // Returns a new priority queue with all eligible collections for normal balance.
 func (b *BalanceChecker) constructNormalBalanceQueue(ctx context.Context) *assign.PriorityQueue {
+	sortOrder := strings.ToLower(Params.QueryCoordCfg.BalanceTriggerOrder.GetValue())
+	if sortOrder == "" {
+		sortOrder = "byrowcount" // Default to ByRowCount
+	}
+
+	ret := b.filterCollectionForBalance(ctx, b.normalBalanceFilters()...)
+	pq := assign.NewPriorityQueuePtr()
+	for _, cid := range ret {
+		rowCount := b.targetMgr.GetCollectionRowCount(ctx, cid, meta.CurrentTargetFirst)
+		item := newCollectionBalanceItem(cid, int(rowCount), sortOrder)
+		pq.Push(item)
+	}
+	b.normalBalanceQueue = pq
+	return pq
+}
+
+// normalBalanceFilters returns the filters of collections eligible for normal balance.
+func (b *BalanceChecker) normalBalanceFilters() []ReadyForBalanceFilter {
 	filterLoadedCollections := func(ctx context.Context, cid int64) bool {
 		collection := b.meta.GetCollection(ctx, cid)
 		return collection != nil && collection.GetStatus() == querypb.LoadStatus_Loaded
@@ -267,20 +285,7 @@ func (b *BalanceChecker) constructNormalBalanceQueue(ctx context.Context) *assig
 		return true
 	}
 
-	sortOrder := strings.ToLower(Params.QueryCoordCfg.BalanceTriggerOrder.GetValue())
-	if sortOrder == "" {
-		sortOrder = "byrowcount" // Default to ByRowCount
-	}
-
-	ret := b.filterCollectionForBalance(ctx, b.readyToCheck, filterLoadedCollections, filterTargetReadyCollections, filterServiceableCollections)
-	pq := assign.NewPriorityQueuePtr()
-	for _, cid := range ret {
-		rowCount := b.targetMgr.GetCollectionRowCount(ctx, cid, meta.CurrentTargetFirst)
-		item := newCollectionBalanceItem(cid, int(rowCount), sortOrder)
-		pq.Push(item)
-	}
-	b.normalBalanceQueue = pq
-	return pq
+	return []ReadyForBalanceFilter{b.readyToCheck, filterLoadedCollections, filterTargetReadyCollections, filterServiceableCollections}
 }
 
 // getReplicaForStoppingBalance returns replicas that need stopping balance operations.
@@ -577,3 +582,47 @@ func (b *BalanceChecker) Check(ctx context.Context) []task.Task {
 	// Always return nil as tasks are submitted directly to scheduler
 	return nil
 }
+
+// DryRun runs the balancers on the given collections, or all collections ready for balance if empty,
+// and explains the planned moves without submitting any task.
+// Like Check, the stopping balance takes precedence over the normal balance,
+// but the batch size limits, the auto balance interval and the checker activation are ignored,
+// so all the moves the balancers would plan at the moment are reported.
+func (b *BalanceChecker) DryRun(ctx context.Context, collectionIDs []int64) *balance.PlanReport {
+	report := balance.NewPlanReport()
+	if paramtable.Get().QueryCoordCfg.EnableStoppingBalance.GetAsBool() {
+		collections := collectionIDs
+		if len(collections) == 0 {
+			collections = b.filterCollectionForBalance(ctx, b.readyToCheck)
+		}
+		b.dryRunReplicas(ctx, report, balance.GetGlobalBalancerFactory().GetStoppingBalancer(),
+			collections, b.getReplicaForStoppingBalance, "node stopping", "node stopping")
+		if !report.Empty() {
+			return report
+		}
+	}
+
+	collections := collectionIDs
+	if len(collections) == 0 {
+		collections = b.filterCollectionForBalance(ctx, b.normalBalanceFilters()...)
+	}
+	b.dryRunReplicas(ctx, report, balance.GetGlobalBalancerFactory().GetBalancer(),
+		collections, b.getReplicaForNormalBalance, "segment unbalanced", "channel unbalanced")
+	return report
+}
+
+// dryRunReplicas adds the plans of the replicas of collections generated by balancer into report.
+func (b *BalanceChecker) dryRunReplicas(ctx context.Context, report *balance.PlanReport, balancer balance.Balance,
+	collectionIDs []int64, getReplicasFunc func(context.Context, int64) []int64, segmentReason, channelReason string,
+) {
+	for _, collectionID := range collectionIDs {
+		for _, rid := range getReplicasFunc(ctx, collectionID) {
+			replica := b.meta.Get(ctx, rid)
+			if replica == nil {
+				continue
+			}
+			segmentPlans, channelPlans := balancer.BalanceReplica(ctx, replica)
+			report.AddReplicaPlans(balancer, b.dist, replica, segmentPlans, channelPlans, segmentReason, channelReason)
+		}
+	}
+}
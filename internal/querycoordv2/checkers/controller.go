@@ -61,16 +61,7 @@ func NewCheckerController(
 	scheduler task.Scheduler,
 	broker meta.Broker,
 ) *CheckerController {
-	// CheckerController runs checkers with the order,
-	// the former checker has higher priority
-	// Note: ChannelChecker and SegmentChecker now create their own RoundRobin policy internally
-	checkers := map[utils.CheckerType]Checker{
-		utils.ChannelChecker: NewChannelChecker(meta, dist, targetMgr, nodeMgr, scheduler),
-		utils.SegmentChecker: NewSegmentChecker(meta, dist, targetMgr, nodeMgr, scheduler),
-		utils.BalanceChecker: NewBalanceChecker(meta, dist, targetMgr, nodeMgr, scheduler),
-		utils.IndexChecker:   NewIndexChecker(meta, dist, broker, nodeMgr, targetMgr),
-		utils.LeaderChecker:  NewLeaderChecker(meta, dist, targetMgr, nodeMgr),
-	}
+	checkers := newCheckers(meta, dist, targetMgr, nodeMgr, scheduler, broker)
 
 	manualCheckChs := map[utils.CheckerType]chan struct{}{
 		utils.ChannelChecker: make(chan struct{}, 1),
@@ -83,12 +74,33 @@ func NewCheckerController(
 		meta:           meta,
 		dist:           dist,
 		targetMgr:      targetMgr,
+		nodeMgr:        nodeMgr,
 		scheduler:      scheduler,
 		checkers:       checkers,
 		broker:         broker,
 	}
 }
 
+func newCheckers(
+	meta *meta.Meta,
+	dist *meta.DistributionManager,
+	targetMgr meta.TargetManagerInterface,
+	nodeMgr *session.NodeManager,
+	scheduler task.Scheduler,
+	broker meta.Broker,
+) map[utils.CheckerType]Checker {
+	// CheckerController runs checkers with the order,
+	// the former checker has higher priority
+	// Note: ChannelChecker and SegmentChecker now create their own RoundRobin policy internally
+	return map[utils.CheckerType]Checker{
+		utils.ChannelChecker: NewChannelChecker(meta, dist, targetMgr, nodeMgr, scheduler),
+		utils.SegmentChecker: NewSegmentChecker(meta, dist, targetMgr, nodeMgr, scheduler),
+		utils.BalanceChecker: NewBalanceChecker(meta, dist, targetMgr, nodeMgr, scheduler),
+		utils.IndexChecker:   NewIndexChecker(meta, dist, broker, nodeMgr, targetMgr),
+		utils.LeaderChecker:  NewLeaderChecker(meta, dist, targetMgr, nodeMgr),
+	}
+}
+
 func (controller *CheckerController) Start() {
 	ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // cancel is stored and called in Stop()
 	controller.cancel = cancel
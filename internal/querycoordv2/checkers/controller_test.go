@@ -208,6 +208,60 @@ func (suite *CheckerControllerSuite) TestBasic() {
 	}, 3*time.Second, 1*time.Millisecond)
 }
 
+func (suite *CheckerControllerSuite) TestDryRun() {
+	ctx := context.Background()
+	suite.meta.PutCollection(ctx, utils.CreateTestCollection(1, 1))
+	suite.meta.PutPartition(ctx, utils.CreateTestPartition(1, 1))
+	suite.meta.Put(ctx, utils.CreateTestReplica(1, 1, []int64{1, 2}))
+	for _, nodeID := range []int64{1, 2} {
+		suite.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "localhost",
+			Hostname: "localhost",
+		}))
+		suite.meta.HandleNodeUp(ctx, nodeID)
+	}
+
+	channels := []*datapb.VchannelInfo{
+		{
+			CollectionID: 1,
+			ChannelName:  "test-insert-channel",
+		},
+	}
+	suite.broker.EXPECT().GetRecoveryInfoV2(mock.Anything, int64(1)).Return(channels, nil, nil)
+	suite.targetManager.UpdateCollectionNextTarget(ctx, int64(1))
+
+	// tasks generated by dry run must not be added into the real scheduler
+	suite.scheduler.EXPECT().GetSegmentTaskNum().Return(0).Maybe()
+	suite.scheduler.EXPECT().GetChannelTaskNum().Return(0).Maybe()
+	suite.scheduler.EXPECT().GetSegmentTaskDeltaSnapshot(mock.Anything, mock.Anything).Return(task.NewSegmentTaskDeltaSnapshot(nil, nil)).Maybe()
+	suite.scheduler.EXPECT().GetChannelTaskDelta(mock.Anything, mock.Anything).Return(0).Maybe()
+
+	suite.NoError(suite.controller.Deactivate(utils.SegmentChecker))
+	results := suite.controller.DryRun(ctx, int32(utils.ChannelChecker), int32(utils.SegmentChecker), 100)
+	suite.Len(results, 3)
+
+	suite.Equal(int32(utils.ChannelChecker), results[0].ID)
+	suite.True(results[0].Found)
+	suite.True(results[0].Activated)
+	suite.Len(results[0].Tasks, 1)
+	suite.Equal(int64(1), results[0].Tasks[0].CollectionID)
+	suite.Equal(task.TaskTypeGrow.String(), results[0].Tasks[0].TaskType)
+
+	suite.Equal(int32(utils.SegmentChecker), results[1].ID)
+	suite.True(results[1].Found)
+	suite.False(results[1].Activated)
+	suite.Empty(results[1].Tasks)
+
+	suite.Equal(int32(100), results[2].ID)
+	suite.False(results[2].Found)
+
+	// the real checkers keep their activation
+	active, err := suite.controller.IsActive(utils.ChannelChecker)
+	suite.NoError(err)
+	suite.True(active)
+}
+
 func TestCheckControllerSuite(t *testing.T) {
 	suite.Run(t, new(CheckerControllerSuite))
 }
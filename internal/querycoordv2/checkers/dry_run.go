@@ -0,0 +1,117 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkers
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+)
+
+var errDryRun = errors.New("task generated by dry run")
+
+// dryRunScheduler records the tasks added by checkers instead of scheduling them.
+// The other methods are delegated to the real scheduler, so the checkers still see the pending tasks.
+type dryRunScheduler struct {
+	task.Scheduler
+
+	mu    sync.Mutex
+	tasks []task.Task
+}
+
+func (s *dryRunScheduler) Add(t task.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, t)
+	return nil
+}
+
+func (s *dryRunScheduler) drain() []task.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := s.tasks
+	s.tasks = nil
+	return tasks
+}
+
+// CheckerDryRunResult is the tasks a checker would generate at the moment.
+type CheckerDryRunResult struct {
+	ID        int32                         `json:"id"`
+	Desc      string                        `json:"desc,omitempty"`
+	Activated bool                          `json:"activated"`
+	Found     bool                          `json:"found"`
+	Tasks     []*metricsinfo.QueryCoordTask `json:"tasks,omitempty"`
+}
+
+// DryRun runs the checkers of checkerIDs, or all checkers if empty, in simulation.
+// Each checker is run by a new instance with the same activation,
+// whose tasks are recorded and canceled instead of being scheduled.
+// As the new instances have no state of the previous rounds, they check everything from scratch.
+func (controller *CheckerController) DryRun(ctx context.Context, checkerIDs ...int32) []*CheckerDryRunResult {
+	scheduler := &dryRunScheduler{Scheduler: controller.scheduler}
+	simulated := newCheckers(controller.meta, controller.dist, controller.targetMgr, controller.nodeMgr, scheduler, controller.broker)
+
+	if len(checkerIDs) == 0 {
+		checkerIDs = lo.Map(lo.Keys(controller.checkers), func(typ utils.CheckerType, _ int) int32 {
+			return int32(typ)
+		})
+		sort.Slice(checkerIDs, func(i, j int) bool { return checkerIDs[i] < checkerIDs[j] })
+	}
+
+	results := make([]*CheckerDryRunResult, 0, len(checkerIDs))
+	for _, id := range checkerIDs {
+		typ := utils.CheckerType(id)
+		checker, ok := controller.checkers[typ]
+		if !ok {
+			results = append(results, &CheckerDryRunResult{ID: id, Found: false})
+			continue
+		}
+
+		simulatedChecker := simulated[typ]
+		if !checker.IsActive() {
+			simulatedChecker.Deactivate()
+		}
+		tasks := append(simulatedChecker.Check(ctx), scheduler.drain()...)
+		results = append(results, &CheckerDryRunResult{
+			ID:        id,
+			Desc:      typ.String(),
+			Activated: checker.IsActive(),
+			Found:     true,
+			Tasks: lo.Map(tasks, func(t task.Task, _ int) *metricsinfo.QueryCoordTask {
+				return task.NewTaskInfo(t)
+			}),
+		})
+		for _, t := range tasks {
+			t.Cancel(errDryRun)
+		}
+	}
+	return results
+}
+
+// DryRunBalance runs the balancers on the given collections, or all collections if empty,
+// and explains the planned moves without submitting any task.
+func (controller *CheckerController) DryRunBalance(ctx context.Context, collectionIDs ...int64) *balance.PlanReport {
+	return controller.checkers[utils.BalanceChecker].(*BalanceChecker).DryRun(ctx, collectionIDs)
+}
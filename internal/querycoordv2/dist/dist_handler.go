@@ -28,6 +28,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
@@ -39,6 +40,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v3/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v3/util/timerecord"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
@@ -69,7 +71,7 @@ func (dh *distHandler) start(ctx context.Context) {
 	mlog.Info(ctx, "start dist handler", mlog.Int64("nodeID", dh.nodeID))
 
 	var loopWG sync.WaitGroup
-	loopWG.Add(2)
+	loopWG.Add(3)
 	go func() {
 		defer loopWG.Done()
 		dh.startPullDistLoop(ctx)
@@ -78,6 +80,10 @@ func (dh *distHandler) start(ctx context.Context) {
 		defer loopWG.Done()
 		dh.startDispatchLoop(ctx)
 	}()
+	go func() {
+		defer loopWG.Done()
+		dh.startPullResourceUsageLoop(ctx)
+	}()
 	loopWG.Wait()
 }
 
@@ -137,6 +143,70 @@ func (dh *distHandler) startDispatchLoop(ctx context.Context) {
 	}
 }
 
+// startPullResourceUsageLoop pulls the resource usage and segment query load of the node periodically,
+// which is only used by ResourceAwareBalancer, so nothing is pulled if the balancer is not in use.
+func (dh *distHandler) startPullResourceUsageLoop(ctx context.Context) {
+	interval := Params.QueryCoordCfg.ResourceUsagePullInterval.GetAsDuration(time.Second)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			mlog.Info(ctx, "close resource usage pull loop due to context done", mlog.Int64("nodeID", dh.nodeID))
+			return
+		case <-dh.c:
+			mlog.Info(ctx, "close resource usage pull loop", mlog.Int64("nodeID", dh.nodeID))
+			return
+		case <-ticker.C:
+			if Params.QueryCoordCfg.Balancer.GetValue() == meta.ResourceAwareBalancerName {
+				dh.pullResourceUsage(ctx)
+			}
+			newInterval := Params.QueryCoordCfg.ResourceUsagePullInterval.GetAsDuration(time.Second)
+			if newInterval != interval {
+				interval = newInterval
+				select {
+				case <-ticker.C:
+				default:
+				}
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+func (dh *distHandler) pullResourceUsage(ctx context.Context) {
+	node := dh.nodeManager.Get(dh.nodeID)
+	if node == nil {
+		return
+	}
+	report, err := dh.getResourceUsage(ctx)
+	if err != nil {
+		mlog.RatedWarn(ctx, rate.Limit(30.0), "failed to get resource usage",
+			mlog.Int64("nodeID", dh.nodeID),
+			mlog.Err(err))
+		return
+	}
+	node.UpdateResourceUsage(report, time.Now())
+}
+
+func (dh *distHandler) getResourceUsage(ctx context.Context) (*metricsinfo.QueryNodeResourceUsage, error) {
+	req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.ResourceUsageKey)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.DistributionRequestTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+	resp, err := dh.client.GetMetrics(ctx, dh.nodeID, req)
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	report := &metricsinfo.QueryNodeResourceUsage{}
+	if err := json.Unmarshal([]byte(resp.GetResponse()), report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
 func (dh *distHandler) pullDist(ctx context.Context, failures *int) {
 	tr := timerecord.NewTimeRecorder("")
 	resp, err := dh.getDistribution(ctx)
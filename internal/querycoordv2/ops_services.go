@@ -25,6 +25,8 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus/internal/coordinator/snmanager"
+	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
+	"github.com/milvus-io/milvus/internal/querycoordv2/checkers"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
@@ -74,6 +76,37 @@ func (s *Server) ListCheckers(ctx context.Context, req *querypb.ListCheckersRequ
 	return resp, nil
 }
 
+// DryRunCheckers runs the checkers of checkerIDs, or all checkers if empty, in simulation,
+// and returns the tasks they would generate without scheduling any of them.
+func (s *Server) DryRunCheckers(ctx context.Context, checkerIDs ...int32) ([]*checkers.CheckerDryRunResult, error) {
+	log := mlog.With(mlog.Int32s("checkerIDs", checkerIDs))
+	log.Info(ctx, "dry run checkers request received")
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		log.Warn(ctx, "failed to dry run checkers", mlog.Err(err))
+		return nil, err
+	}
+	return s.checkerController.DryRun(ctx, checkerIDs...), nil
+}
+
+// DryRunBalance runs the configured balancers on the given collections, or all collections if empty, in simulation,
+// and returns the planned segment and channel moves with the node scores before and after them.
+func (s *Server) DryRunBalance(ctx context.Context, collectionIDs ...int64) (*balance.PlanReport, error) {
+	log := mlog.With(mlog.Int64s("collectionIDs", collectionIDs))
+	log.Info(ctx, "dry run balance request received")
+	if err := merr.CheckHealthy(s.State()); err != nil {
+		log.Warn(ctx, "failed to dry run balance", mlog.Err(err))
+		return nil, err
+	}
+	for _, collectionID := range collectionIDs {
+		if s.meta.GetCollection(ctx, collectionID) == nil {
+			err := merr.WrapErrCollectionNotLoaded(collectionID)
+			log.Warn(ctx, "failed to dry run balance", mlog.Err(err))
+			return nil, err
+		}
+	}
+	return s.checkerController.DryRunBalance(ctx, collectionIDs...), nil
+}
+
 func (s *Server) ActivateChecker(ctx context.Context, req *querypb.ActivateCheckerRequest) (*commonpb.Status, error) {
 	log := mlog.With()
 	log.Info(ctx, "activate checker request received")
@@ -34,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/metastore"
@@ -76,6 +77,7 @@ type Server struct {
 	status              atomic.Int32
 	etcdCli             *clientv3.Client
 	tikvCli             *txnkv.Client
+	boltDB              *boltkv.DB
 	address             string
 	session             sessionutil.SessionInterface
 	sessionWatcher      sessionutil.SessionWatcher
@@ -279,6 +281,14 @@ func (s *Server) initQueryCoord() error {
 		s.kv = etcdkv.NewEtcdKV(s.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(),
 			etcdkv.WithRequestTimeout(paramtable.Get().EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 		idAllocatorKV = tsoutil.NewTSOKVBase(s.etcdCli, Params.EtcdCfg.KvRootPath.GetValue(), "querycoord-id-allocator")
+	case util.MetaStoreTypeBolt:
+		db, err := boltkv.OpenDBWithConfig(&Params.MetaStoreCfg)
+		if err != nil {
+			return err
+		}
+		s.boltDB = db
+		s.kv = boltkv.NewBoltKV(s.boltDB, Params.EtcdCfg.MetaRootPath.GetValue())
+		idAllocatorKV = tsoutil.NewTSOBoltBase(s.boltDB, Params.EtcdCfg.KvRootPath.GetValue(), "querycoord-id-allocator")
 	default:
 		return merr.WrapErrServiceInternalMsg("unsupported meta store: %s", metaType)
 	}
@@ -601,6 +611,10 @@ func (s *Server) Stop() error {
 		s.session.Stop()
 	}
 
+	if s.boltDB != nil {
+		s.boltDB.Close()
+	}
+
 	mlog.Info(s.ctx, "QueryCoord stop successfully")
 	return nil
 }
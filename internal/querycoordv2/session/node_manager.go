@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
@@ -147,6 +148,9 @@ type NodeInfo struct {
 	// with a penalty duration during which it won't receive new loading tasks.
 	// Zero value means no active penalty.
 	resourceExhaustionExpireAt time.Time
+
+	// resourceUsage is the latest resource usage pulled from the query node, nil if never pulled.
+	resourceUsage *ResourceUsage
 }
 
 func (n *NodeInfo) ID() int64 {
@@ -241,6 +245,21 @@ func (n *NodeInfo) UpdateStats(opts ...StatsOption) {
 	n.mu.Unlock()
 }
 
+// ResourceUsage returns the latest resource usage pulled from the query node, nil if never pulled.
+// The returned usage is immutable.
+func (n *NodeInfo) ResourceUsage() *ResourceUsage {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.resourceUsage
+}
+
+// UpdateResourceUsage updates the resource usage with the report pulled from the query node at now.
+func (n *NodeInfo) UpdateResourceUsage(report *metricsinfo.QueryNodeResourceUsage, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.resourceUsage = newResourceUsage(report, n.resourceUsage, now)
+}
+
 func (n *NodeInfo) Version() semver.Version {
 	return n.immutableInfo.Version
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
 )
 
 type NodeManagerSuite struct {
@@ -255,6 +256,61 @@ func (s *NodeManagerSuite) TestNodeInfoLabels() {
 	s.Equal("rg1", info2.ResourceGroupName())
 }
 
+func (s *NodeManagerSuite) TestResourceUsage() {
+	node := NewNodeInfo(ImmutableNodeInfo{
+		NodeID:   1,
+		Address:  "localhost",
+		Hostname: "localhost",
+	})
+	s.Nil(node.ResourceUsage())
+	s.False(node.ResourceUsage().IsReady(time.Time{}))
+
+	now := time.Now()
+	node.UpdateResourceUsage(&metricsinfo.QueryNodeResourceUsage{
+		CPUCoreCount: 8,
+		CPUCoreUsage: 50,
+		Memory:       100,
+		MemoryUsage:  20,
+		Disk:         10,
+		DiskUsage:    15,
+		Segments: []*metricsinfo.SegmentQueryLoad{
+			{SegmentID: 1, AccessTotal: 10, AccessCostMs: 100},
+			{SegmentID: 2, AccessTotal: 10, AccessCostMs: 500},
+		},
+	}, now.Add(-10*time.Second))
+	usage := node.ResourceUsage()
+	s.Equal(8, usage.CPUNum)
+	s.InDelta(0.5, usage.CPUUsage, 1e-9)
+	s.InDelta(0.2, usage.MemoryUsage, 1e-9)
+	s.InDelta(1, usage.DiskUsage, 1e-9)
+	// the query load is unknown until the second pull
+	s.Nil(usage.SegmentQueryLoad)
+	s.False(usage.IsReady(time.Time{}))
+
+	node.UpdateResourceUsage(&metricsinfo.QueryNodeResourceUsage{
+		CPUCoreCount: 8,
+		CPUCoreUsage: 80,
+		Memory:       100,
+		MemoryUsage:  40,
+		Segments: []*metricsinfo.SegmentQueryLoad{
+			{SegmentID: 1, AccessTotal: 20, AccessCostMs: 300},
+			// segment 2 has been forgotten and accessed again
+			{SegmentID: 2, AccessTotal: 1, AccessCostMs: 50},
+			{SegmentID: 3, AccessTotal: 1, AccessCostMs: 10},
+		},
+	}, now)
+	usage = node.ResourceUsage()
+	s.InDelta(0.8, usage.CPUUsage, 1e-9)
+	s.InDelta(0.4, usage.MemoryUsage, 1e-9)
+	s.Zero(usage.DiskUsage)
+	s.Len(usage.SegmentQueryLoad, 3)
+	s.InDelta(20, usage.SegmentQueryLoad[1], 1e-9)
+	s.InDelta(5, usage.SegmentQueryLoad[2], 1e-9)
+	s.InDelta(1, usage.SegmentQueryLoad[3], 1e-9)
+	s.True(usage.IsReady(now.Add(-time.Second)))
+	s.False(usage.IsReady(now))
+}
+
 func TestNodeManagerSuite(t *testing.T) {
 	suite.Run(t, new(NodeManagerSuite))
 }
@@ -0,0 +1,85 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+)
+
+// ResourceUsage is the resource usage of a query node, pulled periodically when ResourceAwareBalancer is used.
+type ResourceUsage struct {
+	CPUNum      int
+	CPUUsage    float64 // ratio of cpu usage in [0, 1]
+	MemoryUsage float64 // ratio of memory usage in [0, 1]
+	DiskUsage   float64 // ratio of local disk usage in [0, 1]
+
+	// SegmentQueryLoad is the search and query cost in milliseconds spent on each sealed segment per second,
+	// it's computed from the accumulated costs of two pulls, so it's nil for the first pull.
+	SegmentQueryLoad map[int64]float64
+	UpdatedAt        time.Time
+
+	// segmentAccessCost is the accumulated access cost reported by the query node, to compute the load of next pull.
+	segmentAccessCost map[int64]float64
+}
+
+// newResourceUsage computes the resource usage from the report of query node, prev is the usage of the previous pull.
+func newResourceUsage(report *metricsinfo.QueryNodeResourceUsage, prev *ResourceUsage, now time.Time) *ResourceUsage {
+	usage := &ResourceUsage{
+		CPUNum:            report.CPUCoreCount,
+		CPUUsage:          clampRatio(report.CPUCoreUsage / 100),
+		UpdatedAt:         now,
+		segmentAccessCost: make(map[int64]float64, len(report.Segments)),
+	}
+	if report.Memory > 0 {
+		usage.MemoryUsage = clampRatio(float64(report.MemoryUsage) / float64(report.Memory))
+	}
+	if report.Disk > 0 {
+		usage.DiskUsage = clampRatio(report.DiskUsage / report.Disk)
+	}
+	for _, segment := range report.Segments {
+		usage.segmentAccessCost[segment.SegmentID] = segment.AccessCostMs
+	}
+
+	if prev == nil {
+		return usage
+	}
+	elapsed := now.Sub(prev.UpdatedAt).Seconds()
+	if elapsed <= 0 {
+		return usage
+	}
+	usage.SegmentQueryLoad = make(map[int64]float64, len(usage.segmentAccessCost))
+	for segmentID, cost := range usage.segmentAccessCost {
+		delta := cost - prev.segmentAccessCost[segmentID]
+		if delta < 0 {
+			// the segment has been forgotten by query node and accessed again, the cost is reset
+			delta = cost
+		}
+		usage.SegmentQueryLoad[segmentID] = delta / elapsed
+	}
+	return usage
+}
+
+// IsReady returns whether the query load of segments is available and the usage is updated after staleBefore.
+func (u *ResourceUsage) IsReady(staleBefore time.Time) bool {
+	return u != nil && u.SegmentQueryLoad != nil && u.UpdatedAt.After(staleBefore)
+}
+
+func clampRatio(ratio float64) float64 {
+	return min(max(ratio, 0), 1)
+}
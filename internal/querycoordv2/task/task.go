@@ -544,7 +544,12 @@ func NewDropIndexTask(ctx context.Context,
 }
 
 func marshalJSON(task Task) ([]byte, error) {
-	return json.Marshal(&metricsinfo.QueryCoordTask{
+	return json.Marshal(NewTaskInfo(task))
+}
+
+// NewTaskInfo returns the metrics info of task.
+func NewTaskInfo(task Task) *metricsinfo.QueryCoordTask {
+	return &metricsinfo.QueryCoordTask{
 		TaskName:     task.Name(),
 		CollectionID: task.CollectionID(),
 		Replica:      task.ReplicaID(),
@@ -556,7 +561,7 @@ func marshalJSON(task Task) ([]byte, error) {
 		}),
 		Step:   task.Step(),
 		Reason: task.GetReason(),
-	})
+	}
 }
 
 func (task *DropIndexTask) SegmentID() typeutil.UniqueID {
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querynodev2/collector"
 	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments/metricsutil"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
@@ -258,6 +259,39 @@ func getSegmentJSON(node *QueryNode, collectionID int64) string {
 	return string(ret)
 }
 
+// getResourceUsageJSON returns the JSON string of the resource usage and the query load of the sealed segments
+func getResourceUsageJSON(ctx context.Context, node *QueryNode) (string, error) {
+	usedDiskGB, totalDiskGB, err := hardware.GetDiskUsage(paramtable.Get().LocalStorageCfg.Path.GetValue())
+	if err != nil {
+		mlog.Warn(ctx, "get disk usage failed", mlog.Err(err))
+	}
+
+	queryLoads := metricsutil.GetSegmentQueryLoads()
+	sealedSegments := node.manager.Segment.GetBy(segments.WithType(segments.SegmentTypeSealed))
+	segmentLoads := make([]*metricsinfo.SegmentQueryLoad, 0, len(sealedSegments))
+	for _, s := range sealedSegments {
+		if load, ok := queryLoads[s.ID()]; ok {
+			segmentLoads = append(segmentLoads, load)
+		}
+	}
+
+	usage := &metricsinfo.QueryNodeResourceUsage{
+		NodeID:       node.GetNodeID(),
+		CPUCoreCount: hardware.GetCPUNum(),
+		CPUCoreUsage: hardware.GetCPUUsage(),
+		Memory:       hardware.GetMemoryCount(),
+		MemoryUsage:  hardware.GetUsedMemoryCount(),
+		Disk:         totalDiskGB,
+		DiskUsage:    usedDiskGB,
+		Segments:     segmentLoads,
+	}
+	ret, err := json.Marshal(usage)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
 // getSystemInfoMetrics returns metrics info of QueryNode
 func getSystemInfoMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest, node *QueryNode) (string, error) {
 	usedMem := hardware.GetUsedMemoryCount()
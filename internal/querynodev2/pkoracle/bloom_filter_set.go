@@ -118,7 +118,7 @@ func (s *BloomFilterSet) UpdatePkCandidate(pks []storage.PrimaryKey) {
 
 	if s.currentStat == nil {
 		s.currentStat = &storage.PkStatistics{
-			PkFilter: bloomfilter.NewBloomFilterWithType(
+			PkFilter: bloomfilter.NewMutableBloomFilterWithType(
 				paramtable.Get().CommonCfg.BloomFilterSize.GetAsUint(),
 				paramtable.Get().CommonCfg.MaxBloomFalsePositive.GetAsFloat(),
 				paramtable.Get().CommonCfg.BloomFilterType.GetValue(),
@@ -52,8 +52,9 @@ func getGlobalObserver() *segmentsObserver {
 // Used to check if a segment is hot or cold.
 func newSegmentsObserver() *segmentsObserver {
 	return &segmentsObserver{
-		nodeID:   strconv.FormatInt(paramtable.GetNodeID(), 10),
-		segments: typeutil.NewConcurrentMap[SegmentLabel, *segmentObserver](),
+		nodeID:     strconv.FormatInt(paramtable.GetNodeID(), 10),
+		segments:   typeutil.NewConcurrentMap[SegmentLabel, *segmentObserver](),
+		queryLoads: typeutil.NewConcurrentMap[int64, *segmentQueryLoad](),
 	}
 }
 
@@ -65,6 +66,7 @@ type segmentsObserver struct {
 	// no more search operation will be performed on the segment after it is removed.
 	// all related metric should be expired after a while.
 	// may be a huge map with 100000+ entries.
+	queryLoads *typeutil.ConcurrentMap[int64, *segmentQueryLoad] // map segment id to its query load.
 }
 
 // Observe records a new metric
@@ -95,6 +97,12 @@ func (o *segmentsObserver) Expire(expiredAt time.Time) {
 		}
 		return true
 	})
+	o.queryLoads.Range(func(segmentID int64, value *segmentQueryLoad) bool {
+		if value.IsExpired(expiredAt) {
+			o.queryLoads.Remove(segmentID)
+		}
+		return true
+	})
 }
 
 // newSegmentObserver creates a new segmentObserver.
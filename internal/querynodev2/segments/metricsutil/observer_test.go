@@ -62,3 +62,25 @@ func TestSegmentsGather(t *testing.T) {
 	g.Expire(time.Now())
 	assert.Zero(t, g.segments.Len())
 }
+
+func TestSegmentsQueryLoad(t *testing.T) {
+	g := newSegmentsObserver()
+	g.ObserveQueryLoad(1, 100, 10*time.Millisecond)
+	g.ObserveQueryLoad(1, 100, 30*time.Millisecond)
+	g.ObserveQueryLoad(1, 101, 5*time.Millisecond)
+
+	loads := g.GetQueryLoads()
+	assert.Len(t, loads, 2)
+	assert.EqualValues(t, 1, loads[100].CollectionID)
+	assert.EqualValues(t, 2, loads[100].AccessTotal)
+	assert.InDelta(t, 40, loads[100].AccessCostMs, 0.001)
+	assert.EqualValues(t, 1, loads[101].AccessTotal)
+	assert.InDelta(t, 5, loads[101].AccessCostMs, 0.001)
+
+	g.Expire(time.Now().Add(-time.Minute))
+	assert.Equal(t, 2, g.queryLoads.Len())
+
+	g.Expire(time.Now())
+	assert.Zero(t, g.queryLoads.Len())
+	assert.Empty(t, g.GetQueryLoads())
+}
@@ -0,0 +1,74 @@
+package metricsutil
+
+import (
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/metricsinfo"
+)
+
+// ObserveSegmentQueryLoad records a search or query access on the segment, which costs d.
+// The query load is reported to QueryCoord to find out the hot segments.
+func ObserveSegmentQueryLoad(collectionID int64, segmentID int64, d time.Duration) {
+	getGlobalObserver().ObserveQueryLoad(collectionID, segmentID, d)
+}
+
+// GetSegmentQueryLoads returns the accumulated query load of the segments accessed recently.
+func GetSegmentQueryLoads() map[int64]*metricsinfo.SegmentQueryLoad {
+	return getGlobalObserver().GetQueryLoads()
+}
+
+// ObserveQueryLoad records a search or query access on the segment.
+func (o *segmentsObserver) ObserveQueryLoad(collectionID int64, segmentID int64, d time.Duration) {
+	load, ok := o.queryLoads.Get(segmentID)
+	if !ok {
+		load, _ = o.queryLoads.GetOrInsert(segmentID, newSegmentQueryLoad(collectionID))
+	}
+	load.Observe(d)
+}
+
+// GetQueryLoads returns the accumulated query load of all observed segments.
+func (o *segmentsObserver) GetQueryLoads() map[int64]*metricsinfo.SegmentQueryLoad {
+	loads := make(map[int64]*metricsinfo.SegmentQueryLoad, o.queryLoads.Len())
+	o.queryLoads.Range(func(segmentID int64, value *segmentQueryLoad) bool {
+		loads[segmentID] = &metricsinfo.SegmentQueryLoad{
+			SegmentID:    segmentID,
+			CollectionID: value.collectionID,
+			AccessTotal:  value.accessTotal.Load(),
+			AccessCostMs: value.accessCost.Load().Seconds() * 1000,
+		}
+		return true
+	})
+	return loads
+}
+
+// newSegmentQueryLoad creates a new segmentQueryLoad.
+func newSegmentQueryLoad(collectionID int64) *segmentQueryLoad {
+	return &segmentQueryLoad{
+		collectionID: collectionID,
+		accessTotal:  atomic.NewInt64(0),
+		accessCost:   atomic.NewDuration(0),
+		lastAccess:   atomic.NewTime(time.Now()),
+	}
+}
+
+// segmentQueryLoad is the accumulated search and query accesses on a segment.
+type segmentQueryLoad struct {
+	collectionID int64 // never updates
+	accessTotal  *atomic.Int64
+	accessCost   *atomic.Duration
+	lastAccess   *atomic.Time // for expiration.
+}
+
+// Observe records a new access.
+func (l *segmentQueryLoad) Observe(d time.Duration) {
+	l.accessTotal.Inc()
+	l.accessCost.Add(d)
+	l.lastAccess.Store(time.Now())
+}
+
+// IsExpired checks if the segment isn't accessed since expireAt.
+func (l *segmentQueryLoad) IsExpired(expireAt time.Time) bool {
+	return l.lastAccess.Load().Before(expireAt)
+}
@@ -144,11 +144,27 @@ func buildStandardQNPipeline(
 	// Convert segcore results to internal format.
 	// For regular queries, filter by IDs; for aggregation/count, filter by FieldsData.
 	hasAggregation := len(req.GetReq().GetGroupByFieldIds()) > 0 || len(req.GetReq().GetAggregates()) > 0
+	// segcore computes the weighted inputs of the state aggregations, fold them into the partial states.
+	var stateInputPlan *agg.StateInputPlan
+	if agg.HasStateAggregates(req.GetReq().GetAggregates()) {
+		stateInputPlan = agg.NewStateInputPlan(req.GetReq().GetGroupByFieldIds(), req.GetReq().GetAggregates())
+	}
 	internalResults := make([]*internalpb.RetrieveResults, 0, len(segcoreResults))
 	for _, res := range segcoreResults {
 		if res == nil {
 			continue
 		}
+		if stateInputPlan != nil && len(res.GetFieldsData()) > 0 {
+			fieldsData, err := stateInputPlan.FoldSegcoreResult(res.GetFieldsData())
+			if err != nil {
+				return nil, nil, merr.Wrap(err, "failed to fold the inputs of state aggregations")
+			}
+			res = &segcorepb.RetrieveResults{
+				FieldsData:       fieldsData,
+				HasMoreResult:    res.GetHasMoreResult(),
+				AllRetrieveCount: res.GetAllRetrieveCount(),
+			}
+		}
 		if hasAggregation {
 			if len(res.GetFieldsData()) == 0 {
 				continue
@@ -26,6 +26,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/agg"
 	"github.com/milvus-io/milvus/internal/util/queryutil"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
@@ -346,6 +347,52 @@ func TestRunQNQueryPipeline(t *testing.T) {
 		require.Len(t, countVal, 1)
 		assert.Equal(t, int64(0), countVal[0], "count(*) on empty segments should be 0")
 	})
+
+	t.Run("state aggregation folds segcore inputs", func(t *testing.T) {
+		aggs := []*planpb.Aggregate{{Op: planpb.AggregateOp_count_distinct, FieldId: 101}}
+		req := buildQueryReqForDelegator(-1, nil, nil, []int64{200}, aggs)
+		rp := &segcore.RetrievePlan{}
+		makeColorField := func(vals ...string) *schemapb.FieldData {
+			return &schemapb.FieldData{
+				FieldId: 200,
+				Type:    schemapb.DataType_VarChar,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: vals}},
+					},
+				},
+			}
+		}
+
+		// segcore groups by color and age, and computes count(*)
+		segcoreResults := []*segcorepb.RetrieveResults{
+			{FieldsData: []*schemapb.FieldData{
+				makeColorField("red", "red", "blue"),
+				makeInt64Field(101, "age", []int64{10, 20, 10}),
+				makeInt64Field(0, "count(*)", []int64{3, 1, 2}),
+			}},
+			{FieldsData: []*schemapb.FieldData{
+				makeColorField("red"),
+				makeInt64Field(101, "age", []int64{30}),
+				makeInt64Field(0, "count(*)", []int64{1}),
+			}},
+		}
+
+		out, err := RunQNQueryPipeline(ctx, req, schema, plan, segcoreResults, nil, nil, rp)
+		require.NoError(t, err)
+		require.Len(t, out.GetFieldsData(), 2)
+		assert.Equal(t, schemapb.DataType_JSON, out.GetFieldsData()[1].GetType())
+
+		aggregate, err := agg.FromPB(aggs[0])
+		require.NoError(t, err)
+		counts, err := agg.FinalizeAggState(aggregate, out.GetFieldsData()[1])
+		require.NoError(t, err)
+		got := make(map[string]int64)
+		for i, color := range out.GetFieldsData()[0].GetScalars().GetStringData().GetData() {
+			got[color] = counts.GetScalars().GetLongData().GetData()[i]
+		}
+		assert.Equal(t, map[string]int64{"red": 3, "blue": 1}, got)
+	})
 }
 
 func TestEmptySegcoreResultAggregation(t *testing.T) {
@@ -18,6 +18,7 @@ package segments
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -61,8 +62,10 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 
 		var err error
 		accessRecord := metricsutil.NewSearchSegmentAccessRecord(getSegmentMetricLabel(seg))
+		start := time.Now()
 		defer func() {
 			accessRecord.Finish(err)
+			metricsutil.ObserveSegmentQueryLoad(seg.Collection(), seg.ID(), time.Since(start))
 		}()
 
 		return searcher(ctx, seg, idx)
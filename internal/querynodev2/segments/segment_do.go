@@ -2,6 +2,7 @@ package segments
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -15,8 +16,10 @@ func doOnSegment(ctx context.Context, mgr *Manager, seg Segment, do doOnSegmentF
 	// record search time and cache miss
 	var err error
 	accessRecord := metricsutil.NewQuerySegmentAccessRecord(getSegmentMetricLabel(seg), contextutil.GetQueryLabel(ctx))
+	start := time.Now()
 	defer func() {
 		accessRecord.Finish(err)
+		metricsutil.ObserveSegmentQueryLoad(seg.Collection(), seg.ID(), time.Since(start))
 	}()
 	return do(ctx, seg)
 }
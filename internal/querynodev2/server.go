@@ -321,6 +321,11 @@ func (node *QueryNode) registerMetricsRequest() {
 			collectionID := metricsinfo.GetCollectionIDFromRequest(jsonReq)
 			return getChannelJSON(node, collectionID), nil
 		})
+
+	node.metricsRequest.RegisterMetricsRequest(metricsinfo.ResourceUsageKey,
+		func(ctx context.Context, req *milvuspb.GetMetricsRequest, jsonReq gjson.Result) (string, error) {
+			return getResourceUsageJSON(ctx, node)
+		})
 	mlog.Info(node.ctx, "register metrics actions finished")
 }
 
@@ -475,7 +480,7 @@ func (node *QueryNode) Stop() error {
 		err := node.session.GoingStop()
 		if err != nil {
 			mlog.Warn(node.ctx, "session fail to go stopping state", mlog.Err(err))
-		} else if util.MustSelectWALName() != message.WALNameRocksmq { // rocksmq cannot support querynode graceful stop because of using local storage.
+		} else if walName := util.MustSelectWALName(); walName != message.WALNameRocksmq && walName != message.WALNameLocalFS { // rocksmq and localfs cannot support querynode graceful stop because of using local storage.
 			metrics.StoppingBalanceNodeNum.WithLabelValues().Set(1)
 			// TODO: Redundant timeout control, graceful stop timeout is controlled by outside by `component`.
 			// Integration test is still using it, Remove it in future.
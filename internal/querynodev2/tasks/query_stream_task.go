@@ -49,6 +49,18 @@ func (t *QueryStreamTask) Username() string {
 	return t.req.Req.GetUsername()
 }
 
+// Return the workload class which task is belong to,
+// the iterator task belongs to the iterator class if not specified.
+func (t *QueryStreamTask) WorkloadClass() string {
+	if class := t.req.GetReq().GetWorkloadClass(); class != "" {
+		return class
+	}
+	if t.req.GetReq().GetIsIterator() {
+		return scheduler.WorkloadClassIterator
+	}
+	return ""
+}
+
 func (t *QueryStreamTask) IsGpuIndex() bool {
 	return false
 }
@@ -44,6 +44,11 @@ func (c *Core) broadcastAlterCollectionForAlterCollection(ctx context.Context, r
 		return merr.WrapErrParameterInvalidMsg("can not provide properties and deletekeys at the same time")
 	}
 
+	if hasRowPolicyKeys(req.GetProperties(), req.GetDeleteKeys()) {
+		// row policies are kept with the rbac meta, they never reach the collection properties.
+		return c.broadcastAlterRowPolicies(ctx, req)
+	}
+
 	if err := validateReservedCollectionProperties(req.GetProperties(), req.GetDeleteKeys()); err != nil {
 		return err
 	}
@@ -20,9 +20,12 @@ import (
 	"context"
 	"strings"
 
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	"github.com/milvus-io/milvus/pkg/v3/util"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
@@ -85,10 +88,22 @@ func (c *Core) broadcastOperatePrivilege(ctx context.Context, in *milvuspb.Opera
 }
 
 func (c *DDLCallback) alterPrivilegeV2AckCallback(ctx context.Context, result message.BroadcastResultAlterPrivilegeMessageV2) error {
+	if policies := result.Message.Header().GetRowPolicies(); len(policies) > 0 {
+		return c.alterRowPolicies(ctx, policies)
+	}
 	return executeOperatePrivilegeTaskSteps(ctx, c.Core, result.Message.Header().Entity, milvuspb.OperatePrivilegeType_Grant)
 }
 
 func (c *DDLCallback) dropPrivilegeV2AckCallback(ctx context.Context, result message.BroadcastResultDropPrivilegeMessageV2) error {
+	if policies := result.Message.Header().GetRowPolicies(); len(policies) > 0 {
+		return c.alterRowPolicies(ctx, lo.Map(policies, func(policy *internalpb.RowPolicy, _ int) *internalpb.RowPolicy {
+			return &internalpb.RowPolicy{
+				RoleName:       policy.GetRoleName(),
+				DbName:         policy.GetDbName(),
+				CollectionName: policy.GetCollectionName(),
+			}
+		}))
+	}
 	return executeOperatePrivilegeTaskSteps(ctx, c.Core, result.Message.Header().Entity, milvuspb.OperatePrivilegeType_Revoke)
 }
 
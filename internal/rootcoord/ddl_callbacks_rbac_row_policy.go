@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/distributed/streaming"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// hasRowPolicyKeys returns true if the alter collection request sets or deletes any row policy.
+func hasRowPolicyKeys(props []*commonpb.KeyValuePair, deleteKeys []string) bool {
+	return lo.ContainsBy(props, func(prop *commonpb.KeyValuePair) bool {
+		return common.IsCollectionRowPolicyKey(prop.GetKey())
+	}) || lo.ContainsBy(deleteKeys, common.IsCollectionRowPolicyKey)
+}
+
+// broadcastAlterRowPolicies broadcasts the row policies carried by the alter collection request.
+// The row policies are kept with the rbac meta rather than the collection properties,
+// so they are never exposed by describe collection and are dropped together with the role.
+func (c *Core) broadcastAlterRowPolicies(ctx context.Context, req *milvuspb.AlterCollectionRequest) error {
+	keys := lo.Map(req.GetProperties(), func(prop *commonpb.KeyValuePair, _ int) string {
+		return prop.GetKey()
+	})
+	keys = append(keys, req.GetDeleteKeys()...)
+	if !lo.EveryBy(keys, common.IsCollectionRowPolicyKey) {
+		return merr.WrapErrParameterInvalidMsg("row policies could not be altered together with other collection properties")
+	}
+
+	broadcaster, err := startBroadcastWithRBACLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer broadcaster.Close()
+
+	coll, err := c.meta.GetCollectionByName(ctx, req.GetDbName(), req.GetCollectionName(), typeutil.MaxTimestamp, false)
+	if err != nil {
+		return err
+	}
+
+	policies := make([]*internalpb.RowPolicy, 0, len(keys))
+	if len(req.GetProperties()) > 0 {
+		exprs := common.GetCollectionRowPolicies(req.GetProperties()...)
+		roles := lo.Keys(exprs)
+		sort.Strings(roles)
+		for _, role := range roles {
+			if strings.TrimSpace(exprs[role]) == "" {
+				return merr.WrapErrParameterInvalidMsg("row policy of role %s is empty", role)
+			}
+			if err := c.isValidRole(ctx, &milvuspb.RoleEntity{Name: role}); err != nil {
+				return err
+			}
+			policies = append(policies, &internalpb.RowPolicy{
+				RoleName:       role,
+				DbName:         coll.DBName,
+				CollectionName: coll.Name,
+				Expr:           exprs[role],
+			})
+		}
+		msg := message.NewAlterPrivilegeMessageBuilderV2().
+			WithHeader(&message.AlterPrivilegeMessageHeader{
+				RowPolicies: policies,
+			}).
+			WithBody(&message.AlterPrivilegeMessageBody{}).
+			WithBroadcast([]string{streaming.WAL().ControlChannel()}).
+			MustBuildBroadcast()
+		_, err = broadcaster.Broadcast(ctx, msg)
+		return err
+	}
+
+	for _, key := range lo.Uniq(req.GetDeleteKeys()) {
+		role := strings.TrimPrefix(key, common.CollectionRowPolicyKeyPrefix)
+		if role == "" {
+			return merr.WrapErrParameterInvalidMsg("role name of row policy is empty, the key should be %s<role>", common.CollectionRowPolicyKeyPrefix)
+		}
+		policies = append(policies, &internalpb.RowPolicy{
+			RoleName:       role,
+			DbName:         coll.DBName,
+			CollectionName: coll.Name,
+		})
+	}
+	msg := message.NewDropPrivilegeMessageBuilderV2().
+		WithHeader(&message.DropPrivilegeMessageHeader{
+			RowPolicies: policies,
+		}).
+		WithBody(&message.DropPrivilegeMessageBody{}).
+		WithBroadcast([]string{streaming.WAL().ControlChannel()}).
+		MustBuildBroadcast()
+	_, err = broadcaster.Broadcast(ctx, msg)
+	return err
+}
+
+// alterRowPolicies saves the row policies into the rbac meta and refreshes the policy cache of proxies,
+// the policies with empty expression are dropped.
+func (c *Core) alterRowPolicies(ctx context.Context, policies []*internalpb.RowPolicy) error {
+	if err := c.meta.AlterRowPolicies(ctx, util.DefaultTenant, policies); err != nil {
+		return merr.Wrap(err, "failed to alter row policies")
+	}
+	if err := c.proxyClientManager.RefreshPolicyInfoCache(ctx, &proxypb.RefreshPolicyInfoCacheRequest{
+		OpType: int32(typeutil.CacheRefresh),
+	}); err != nil {
+		return merr.Wrap(err, "failed to refresh policy info cache")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestDDLCallbacksRBACRowPolicy(t *testing.T) {
+	core := initStreamingSystemAndCore(t)
+
+	ctx := context.Background()
+	dbName := "testDB" + funcutil.RandomString(10)
+	collectionName := "testCollection" + funcutil.RandomString(10)
+	createCollectionForTest(t, ctx, core, dbName, collectionName)
+
+	roleName := "tenant"
+	policyKey := common.CollectionRowPolicyKeyPrefix + roleName
+
+	// The role of row policy should exist.
+	resp, err := core.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties:     []*commonpb.KeyValuePair{{Key: policyKey, Value: "field1 > 1"}},
+	})
+	require.Error(t, merr.CheckRPCCall(resp, err))
+
+	status, err := core.CreateRole(ctx, &milvuspb.CreateRoleRequest{Entity: &milvuspb.RoleEntity{Name: roleName}})
+	require.NoError(t, merr.CheckRPCCall(status, err))
+
+	// Row policies could not be altered together with other collection properties.
+	resp, err = core.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties: []*commonpb.KeyValuePair{
+			{Key: policyKey, Value: "field1 > 1"},
+			{Key: common.CollectionReplicaNumber, Value: "1"},
+		},
+	})
+	require.ErrorIs(t, merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+
+	resp, err = core.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties:     []*commonpb.KeyValuePair{{Key: policyKey, Value: "field1 > 1"}},
+	})
+	require.NoError(t, merr.CheckRPCCall(resp, err))
+	assertRowPolicies(t, ctx, core, &internalpb.RowPolicy{RoleName: roleName, DbName: dbName, CollectionName: collectionName, Expr: "field1 > 1"})
+
+	// The row policies are not exposed as collection properties.
+	coll, err := core.meta.GetCollectionByName(ctx, dbName, collectionName, typeutil.MaxTimestamp, false)
+	require.NoError(t, err)
+	_, ok := common.GetCollectionRowPolicies(coll.Properties...)[roleName]
+	require.False(t, ok)
+
+	resp, err = core.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		DeleteKeys:     []string{policyKey},
+	})
+	require.NoError(t, merr.CheckRPCCall(resp, err))
+	assertRowPolicies(t, ctx, core)
+
+	// The row policies are dropped together with the role.
+	resp, err = core.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties:     []*commonpb.KeyValuePair{{Key: policyKey, Value: "field1 < 1"}},
+	})
+	require.NoError(t, merr.CheckRPCCall(resp, err))
+	assertRowPolicies(t, ctx, core, &internalpb.RowPolicy{RoleName: roleName, DbName: dbName, CollectionName: collectionName, Expr: "field1 < 1"})
+
+	status, err = core.DropRole(ctx, &milvuspb.DropRoleRequest{RoleName: roleName})
+	require.NoError(t, merr.CheckRPCCall(status, err))
+	assertRowPolicies(t, ctx, core)
+}
+
+func assertRowPolicies(t *testing.T, ctx context.Context, core *Core, expected ...*internalpb.RowPolicy) {
+	resp, err := core.ListPolicy(ctx, &internalpb.ListPolicyRequest{})
+	require.NoError(t, merr.CheckRPCCall(resp, err))
+	require.Len(t, resp.GetRowPolicies(), len(expected))
+	for i, policy := range expected {
+		require.Equal(t, policy.GetRoleName(), resp.GetRowPolicies()[i].GetRoleName())
+		require.Equal(t, policy.GetDbName(), resp.GetRowPolicies()[i].GetDbName())
+		require.Equal(t, policy.GetCollectionName(), resp.GetRowPolicies()[i].GetCollectionName())
+		require.Equal(t, policy.GetExpr(), resp.GetRowPolicies()[i].GetExpr())
+	}
+}
@@ -142,6 +142,8 @@ type IMetaTable interface {
 	DropGrant(ctx context.Context, tenant string, role *milvuspb.RoleEntity) error
 	ListPolicy(ctx context.Context, tenant string) ([]*milvuspb.GrantEntity, error)
 	ListUserRole(ctx context.Context, tenant string) ([]string, error)
+	AlterRowPolicies(ctx context.Context, tenant string, policies []*internalpb.RowPolicy) error
+	ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error)
 	BackupRBAC(ctx context.Context, tenant string) (*milvuspb.RBACMeta, error)
 	RestoreRBAC(ctx context.Context, tenant string, meta *milvuspb.RBACMeta) error
 	IsCustomPrivilegeGroup(ctx context.Context, groupName string) (bool, error)
@@ -2128,6 +2130,29 @@ func (mt *MetaTable) ListUserRole(ctx context.Context, tenant string) ([]string,
 	return mt.catalog.ListUserRole(ctx, tenant)
 }
 
+// AlterRowPolicies sets the row policies of roles on collections, the policies with empty expression are dropped.
+func (mt *MetaTable) AlterRowPolicies(ctx context.Context, tenant string, policies []*internalpb.RowPolicy) error {
+	for _, policy := range policies {
+		if funcutil.IsEmptyString(policy.GetRoleName()) {
+			return merr.WrapErrParameterInvalidMsg("the role name of the row policy is empty")
+		}
+		if funcutil.IsEmptyString(policy.GetCollectionName()) {
+			return merr.WrapErrParameterInvalidMsg("the collection name of the row policy is empty")
+		}
+	}
+	mt.permissionLock.Lock()
+	defer mt.permissionLock.Unlock()
+
+	return mt.catalog.AlterRowPolicies(ctx, tenant, policies)
+}
+
+func (mt *MetaTable) ListRowPolicies(ctx context.Context, tenant string) ([]*internalpb.RowPolicy, error) {
+	mt.permissionLock.RLock()
+	defer mt.permissionLock.RUnlock()
+
+	return mt.catalog.ListRowPolicies(ctx, tenant)
+}
+
 func (mt *MetaTable) BackupRBAC(ctx context.Context, tenant string) (*milvuspb.RBACMeta, error) {
 	mt.permissionLock.RLock()
 	defer mt.permissionLock.RUnlock()
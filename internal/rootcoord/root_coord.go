@@ -37,6 +37,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/allocator"
 	"github.com/milvus-io/milvus/internal/coordinator/snmanager"
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/internal/metastore"
@@ -135,6 +136,7 @@ type Core struct {
 	wg               sync.WaitGroup
 	etcdCli          *clientv3.Client
 	tikvCli          *txnkv.Client
+	boltDB           *boltkv.DB
 	address          string
 	meta             IMetaTable
 	scheduler        IScheduler
@@ -366,20 +368,31 @@ func (c *Core) SetSession(session sessionutil.SessionInterface) error {
 	return nil
 }
 
-func (c *Core) initKVCreator() {
+func (c *Core) initKVCreator() error {
 	if c.metaKVCreator == nil {
-		if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeTiKV {
+		switch Params.MetaStoreCfg.MetaStoreType.GetValue() {
+		case util.MetaStoreTypeTiKV:
 			c.metaKVCreator = func() kv.MetaKv {
 				return tikv.NewTiKV(c.tikvCli, Params.TiKVCfg.MetaRootPath.GetValue(),
 					tikv.WithRequestTimeout(paramtable.Get().TiKVCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 			}
-		} else {
+		case util.MetaStoreTypeBolt:
+			db, err := boltkv.OpenDBWithConfig(&Params.MetaStoreCfg)
+			if err != nil {
+				return err
+			}
+			c.boltDB = db
+			c.metaKVCreator = func() kv.MetaKv {
+				return boltkv.NewBoltKV(c.boltDB, Params.EtcdCfg.MetaRootPath.GetValue())
+			}
+		default:
 			c.metaKVCreator = func() kv.MetaKv {
 				return etcdkv.NewEtcdKV(c.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(),
 					etcdkv.WithRequestTimeout(paramtable.Get().EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
 			}
 		}
 	}
+	return nil
 }
 
 func (c *Core) initMetaTable(initCtx context.Context) error {
@@ -400,6 +413,12 @@ func (c *Core) initMetaTable(initCtx context.Context) error {
 			kvmetastore.StartLegacySnapshotGC(c.ctx, metaKV)
 			kvmetastore.StartLegacyTombstoneGC(c.ctx, metaKV)
 			catalog = kvmetastore.NewCatalog(metaKV)
+		case util.MetaStoreTypeBolt:
+			mlog.Info(initCtx, "Using bolt as meta storage.")
+			metaKV := c.metaKVCreator()
+			kvmetastore.StartLegacySnapshotGC(c.ctx, metaKV)
+			kvmetastore.StartLegacyTombstoneGC(c.ctx, metaKV)
+			catalog = kvmetastore.NewCatalog(metaKV)
 		default:
 			return retry.Unrecoverable(merr.WrapErrServiceInternalMsg("not supported meta store: %s", Params.MetaStoreCfg.MetaStoreType.GetValue()))
 		}
@@ -417,10 +436,14 @@ func (c *Core) initMetaTable(initCtx context.Context) error {
 func (c *Core) initIDAllocator(initCtx context.Context) error {
 	var tsoKV kv.TxnKV
 	var kvPath string
-	if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeTiKV {
+	switch Params.MetaStoreCfg.MetaStoreType.GetValue() {
+	case util.MetaStoreTypeTiKV:
 		kvPath = Params.TiKVCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOTiKVBase(c.tikvCli, kvPath, globalIDAllocatorSubPath)
-	} else {
+	case util.MetaStoreTypeBolt:
+		kvPath = Params.EtcdCfg.KvRootPath.GetValue()
+		tsoKV = tsoutil2.NewTSOBoltBase(c.boltDB, kvPath, globalIDAllocatorSubPath)
+	default:
 		kvPath = Params.EtcdCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOKVBase(c.etcdCli, kvPath, globalIDAllocatorSubPath)
 	}
@@ -441,10 +464,14 @@ func (c *Core) initIDAllocator(initCtx context.Context) error {
 func (c *Core) initTSOAllocator(initCtx context.Context) error {
 	var tsoKV kv.TxnKV
 	var kvPath string
-	if Params.MetaStoreCfg.MetaStoreType.GetValue() == util.MetaStoreTypeTiKV {
+	switch Params.MetaStoreCfg.MetaStoreType.GetValue() {
+	case util.MetaStoreTypeTiKV:
 		kvPath = Params.TiKVCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOTiKVBase(c.tikvCli, Params.TiKVCfg.KvRootPath.GetValue(), globalIDAllocatorSubPath)
-	} else {
+	case util.MetaStoreTypeBolt:
+		kvPath = Params.EtcdCfg.KvRootPath.GetValue()
+		tsoKV = tsoutil2.NewTSOBoltBase(c.boltDB, kvPath, globalIDAllocatorSubPath)
+	default:
 		kvPath = Params.EtcdCfg.KvRootPath.GetValue()
 		tsoKV = tsoutil2.NewTSOKVBase(c.etcdCli, Params.EtcdCfg.KvRootPath.GetValue(), globalIDAllocatorSubPath)
 	}
@@ -553,7 +580,9 @@ func (c *Core) Init() error {
 	var initError error
 	c.registerMetricsRequest()
 	c.factory.Init(Params)
-	c.initKVCreator()
+	if err := c.initKVCreator(); err != nil {
+		return err
+	}
 
 	c.initOnce.Do(func() {
 		initError = c.initInternal()
@@ -812,6 +841,9 @@ func (c *Core) Stop() error {
 	c.revokeSession()
 	c.cancelIfNotNil()
 	c.wg.Wait()
+	if c.boltDB != nil {
+		c.boltDB.Close()
+	}
 	return nil
 }
 
@@ -2741,6 +2773,14 @@ func (c *Core) ListPolicy(ctx context.Context, in *internalpb.ListPolicyRequest)
 		}, nil
 	}
 
+	rowPolicies, err := c.meta.ListRowPolicies(ctx, util.DefaultTenant)
+	if err != nil {
+		ctxLog.Error(ctx, "fail to list row policies", mlog.Err(err))
+		return &internalpb.ListPolicyResponse{
+			Status: merr.StatusWithErrorCode(merr.Wrap(err, "fail to list row policies"), commonpb.ErrorCode_ListPolicyFailure),
+		}, nil
+	}
+
 	ctxLog.Debug(ctx, method+" success")
 	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
 	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
@@ -2749,6 +2789,7 @@ func (c *Core) ListPolicy(ctx context.Context, in *internalpb.ListPolicyRequest)
 		PolicyInfos:     expandPolicies,
 		UserRoles:       userRoles,
 		PrivilegeGroups: allGroups,
+		RowPolicies:     rowPolicies,
 	}, nil
 }
 
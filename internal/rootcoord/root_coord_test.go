@@ -1028,10 +1028,15 @@ func TestRootCoord_ListPolicy(t *testing.T) {
 
 		meta.EXPECT().ListUserRole(ctx, util.DefaultTenant).Return([]string{}, nil)
 
+		meta.EXPECT().ListRowPolicies(ctx, util.DefaultTenant).Return([]*internalpb.RowPolicy{
+			{RoleName: "role", DbName: "default", CollectionName: "coll", Expr: "tenant == 1"},
+		}, nil)
+
 		resp, err := c.ListPolicy(ctx, &internalpb.ListPolicyRequest{})
 		assert.Equal(t, len(Params.RbacConfig.GetDefaultPrivilegeGroup("CollectionAdmin").Privileges), len(resp.PolicyInfos))
 		assert.NoError(t, err)
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Len(t, resp.GetRowPolicies(), 1)
 	})
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 )
 
@@ -303,6 +304,13 @@ func NewDeleteBinlogWriter(
 
 type BinlogWriterOptions func(base *baseBinlogWriter)
 
+// WithWriterCompressType records the compress type of the payload in the descriptor event.
+func WithWriterCompressType(compressType compressor.CompressType) BinlogWriterOptions {
+	return func(base *baseBinlogWriter) {
+		base.AddExtra(compressionKey, string(compressType))
+	}
+}
+
 func WithWriterEncryptionContext(ezID int64, edek []byte, encryptor hook.Encryptor) BinlogWriterOptions {
 	return func(base *baseBinlogWriter) {
 		base.AddExtra(edekKey, string(edek))
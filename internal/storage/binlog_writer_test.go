@@ -27,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 )
 
 func TestBinlogReaderWriterCipher(t *testing.T) {
@@ -93,6 +94,44 @@ func TestBinlogReaderWriterCipher(t *testing.T) {
 	assert.Nil(t, reader)
 }
 
+func TestBinlogReaderWriterCompressType(t *testing.T) {
+	for _, compressType := range []compressor.CompressType{compressor.CompressTypeLZ4, compressor.CompressTypeSnappy} {
+		binlogWriter := NewInsertBinlogWriter(schemapb.DataType_Int32, 10, 20, 30, 40, false, WithWriterCompressType(compressType))
+		binlogWriter.SetEventTimeStamp(1000, 2000)
+
+		field := &schemapb.FieldSchema{FieldID: 40, DataType: schemapb.DataType_Int32}
+		eventWriter, err := binlogWriter.NextInsertEventWriter(WithWriterProps(getFieldWriterProps(field, compressType)))
+		require.NoError(t, err)
+		err = eventWriter.AddInt32ToPayload([]int32{1, 2, 3}, nil)
+		assert.NoError(t, err)
+		eventWriter.SetEventTimestamp(1000, 2000)
+		binlogWriter.AddExtra(originalSizeKey, "12")
+		err = binlogWriter.Finish()
+		assert.NoError(t, err)
+		buffer, err := binlogWriter.GetBuffer()
+		assert.NoError(t, err)
+		binlogWriter.Close()
+
+		binlogReader, err := NewBinlogReader(buffer)
+		assert.NoError(t, err)
+		assert.Equal(t, compressType, binlogReader.GetCompressType())
+		eventReader, err := binlogReader.NextEventReader()
+		assert.NoError(t, err)
+		payload, _, err := eventReader.GetInt32FromPayload()
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{1, 2, 3}, payload)
+		binlogReader.Close()
+	}
+
+	// binlogs without compression extra are compressed by zstd
+	binlogWriter := NewInsertBinlogWriter(schemapb.DataType_Int32, 10, 20, 30, 40, false)
+	assert.Equal(t, compressor.CompressTypeZstd, binlogWriter.GetCompressType())
+
+	binlogWriter = NewInsertBinlogWriter(schemapb.DataType_Int32, 10, 20, 30, 40, false, WithWriterCompressType("gzip"))
+	binlogWriter.AddExtra(originalSizeKey, "0")
+	assert.Error(t, binlogWriter.FinishExtra())
+}
+
 func TestBinlogWriterReader(t *testing.T) {
 	binlogWriter := NewInsertBinlogWriter(schemapb.DataType_Int32, 10, 20, 30, 40, false)
 	tp := binlogWriter.GetBinlogType()
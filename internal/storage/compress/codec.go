@@ -0,0 +1,66 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v17/parquet/compress"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// ParquetCodec returns the parquet codec of the compress type, zstd is used for the unknown types.
+func ParquetCodec(typ compressor.CompressType) compress.Compression {
+	switch typ {
+	case compressor.CompressTypeLZ4:
+		return LZ4Raw
+	case compressor.CompressTypeSnappy:
+		return compress.Codecs.Snappy
+	default:
+		return compress.Codecs.Zstd
+	}
+}
+
+// GetBinlogCompressType returns the binlog compress type of the collection,
+// the collection property takes precedence over the common.storage.binlogCompression config.
+func GetBinlogCompressType(props []*commonpb.KeyValuePair) compressor.CompressType {
+	if value, ok := common.GetStringValue(props, common.CollectionBinlogCompressionKey); ok {
+		typ, err := compressor.ParseCompressType(value)
+		if err == nil {
+			return typ
+		}
+		// the property is validated by proxy, so it only happens on a corrupted meta.
+		mlog.Warn(context.TODO(), "invalid binlog compression of collection, use the default one", mlog.String("compression", value))
+	}
+	return GetDefaultBinlogCompressType()
+}
+
+// GetDefaultBinlogCompressType returns the compress type of common.storage.binlogCompression.
+func GetDefaultBinlogCompressType() compressor.CompressType {
+	value := paramtable.Get().CommonCfg.StorageBinlogCompression.GetValue()
+	typ, err := compressor.ParseCompressType(value)
+	if err != nil {
+		mlog.Warn(context.TODO(), "invalid binlog compression config, use zstd", mlog.String("compression", value))
+		return compressor.DefaultCompressAlgorithm
+	}
+	return typ
+}
@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestParquetCodec(t *testing.T) {
+	paramtable.Init()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "pk", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "text", Type: arrow.BinaryTypes.String},
+	}, nil)
+	for _, compressType := range []compressor.CompressType{compressor.CompressTypeZstd, compressor.CompressTypeLZ4, compressor.CompressTypeSnappy} {
+		t.Run(string(compressType), func(t *testing.T) {
+			pkBuilder := array.NewInt64Builder(memory.DefaultAllocator)
+			textBuilder := array.NewStringBuilder(memory.DefaultAllocator)
+			for i := 0; i < 10000; i++ {
+				pkBuilder.Append(int64(i))
+				textBuilder.Append(fmt.Sprintf("text of row %d", i%100))
+			}
+			pks, texts := pkBuilder.NewArray(), textBuilder.NewArray()
+			defer pks.Release()
+			defer texts.Release()
+			rec := array.NewRecord(schema, []arrow.Array{pks, texts}, 10000)
+			defer rec.Release()
+
+			var buf bytes.Buffer
+			props := parquet.NewWriterProperties(
+				parquet.WithCompression(ParquetCodec(compressType)),
+				parquet.WithDictionaryDefault(false),
+			)
+			w, err := pqarrow.NewFileWriter(schema, &buf, props, pqarrow.DefaultWriterProps())
+			require.NoError(t, err)
+			require.NoError(t, w.Write(rec))
+			require.NoError(t, w.Close())
+
+			reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			defer reader.Close()
+			column, err := reader.MetaData().RowGroup(0).ColumnChunk(0)
+			require.NoError(t, err)
+			assert.Equal(t, ParquetCodec(compressType), column.Compression())
+			assert.Less(t, column.TotalCompressedSize(), column.TotalUncompressedSize())
+
+			arrowReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{BatchSize: 10000}, memory.DefaultAllocator)
+			require.NoError(t, err)
+			table, err := arrowReader.ReadTable(context.Background())
+			require.NoError(t, err)
+			defer table.Release()
+			assert.Equal(t, int64(10000), table.NumRows())
+			assert.Equal(t, int64(9999), table.Column(0).Data().Chunk(0).(*array.Int64).Value(9999))
+			assert.Equal(t, "text of row 99", table.Column(1).Data().Chunk(0).(*array.String).Value(9999))
+		})
+	}
+}
+
+func TestLZ4RawCodec(t *testing.T) {
+	codec, err := compress.GetCodec(LZ4Raw)
+	require.NoError(t, err)
+
+	for _, src := range [][]byte{{}, []byte("a"), bytes.Repeat([]byte("milvus"), 1000)} {
+		// a short dst is grown
+		encoded := codec.Encode(nil, src)
+		assert.LessOrEqual(t, int64(len(encoded)), codec.CompressBound(int64(len(src))))
+		decoded := codec.Decode(make([]byte, len(src)), encoded)
+		assert.Equal(t, src, decoded)
+		assert.Equal(t, encoded, codec.EncodeLevel(make([]byte, 0, codec.CompressBound(int64(len(src)))), src, 1))
+	}
+	assert.Panics(t, func() {
+		codec.Decode(make([]byte, 1), []byte{0xff, 0xff})
+	})
+
+	var buf bytes.Buffer
+	w, err := codec.NewWriterLevel(&buf, 1)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello lz4"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	r := codec.NewReader(&buf)
+	var out bytes.Buffer
+	_, err = out.ReadFrom(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello lz4", out.String())
+	assert.NoError(t, r.Close())
+}
+
+func TestGetBinlogCompressType(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+
+	assert.Equal(t, compressor.CompressTypeZstd, GetBinlogCompressType(nil))
+
+	params.Save(params.CommonCfg.StorageBinlogCompression.Key, "snappy")
+	defer params.Reset(params.CommonCfg.StorageBinlogCompression.Key)
+	assert.Equal(t, compressor.CompressTypeSnappy, GetBinlogCompressType(nil))
+
+	props := []*commonpb.KeyValuePair{{Key: common.CollectionBinlogCompressionKey, Value: "lz4"}}
+	assert.Equal(t, compressor.CompressTypeLZ4, GetBinlogCompressType(props))
+
+	// invalid values fall back
+	props = []*commonpb.KeyValuePair{{Key: common.CollectionBinlogCompressionKey, Value: "gzip"}}
+	assert.Equal(t, compressor.CompressTypeSnappy, GetBinlogCompressType(props))
+	params.Save(params.CommonCfg.StorageBinlogCompression.Key, "gzip")
+	assert.Equal(t, compressor.CompressTypeZstd, GetBinlogCompressType(props))
+}
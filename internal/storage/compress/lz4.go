@@ -0,0 +1,76 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/pierrec/lz4/v4"
+)
+
+// LZ4Raw is the LZ4_RAW codec of parquet format, a plain lz4 block without any framing.
+// The arrow go library only declares the deprecated hadoop LZ4 codec, which is not implemented,
+// while the segcore arrow library reads LZ4_RAW natively.
+var LZ4Raw = compress.Compression(7)
+
+type lz4RawCodec struct{}
+
+func (lz4RawCodec) Decode(dst, src []byte) []byte {
+	n, err := lz4.UncompressBlock(src, dst)
+	if err != nil {
+		panic(err)
+	}
+	return dst[:n]
+}
+
+func (lz4RawCodec) NewReader(r io.Reader) io.ReadCloser {
+	return io.NopCloser(lz4.NewReader(r))
+}
+
+func (lz4RawCodec) NewWriter(w io.Writer) io.WriteCloser {
+	return lz4.NewWriter(w)
+}
+
+func (l lz4RawCodec) NewWriterLevel(w io.Writer, _ int) (io.WriteCloser, error) {
+	return l.NewWriter(w), nil
+}
+
+func (l lz4RawCodec) Encode(dst, src []byte) []byte {
+	bound := lz4.CompressBlockBound(len(src))
+	if cap(dst) < bound {
+		dst = make([]byte, bound)
+	}
+	// the destination is large enough, so the block is always written even if it is incompressible
+	n, err := lz4.CompressBlock(src, dst[:bound], nil)
+	if err != nil {
+		panic(err)
+	}
+	return dst[:n]
+}
+
+func (l lz4RawCodec) EncodeLevel(dst, src []byte, _ int) []byte {
+	return l.Encode(dst, src)
+}
+
+func (lz4RawCodec) CompressBound(len int64) int64 {
+	return int64(lz4.CompressBlockBound(int(len)))
+}
+
+func init() {
+	compress.RegisterCodec(LZ4Raw, lz4RawCodec{})
+}
@@ -27,10 +27,12 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
+	storagecompress "github.com/milvus-io/milvus/internal/storage/compress"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/etcdpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/metautil"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
@@ -256,6 +258,10 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 	}
 
 	binlogWriterOpts := []BinlogWriterOptions{}
+	compressType := storagecompress.GetBinlogCompressType(insertCodec.Schema.GetSchema().GetProperties())
+	if compressType != compressor.CompressTypeZstd {
+		binlogWriterOpts = append(binlogWriterOpts, WithWriterCompressType(compressType))
+	}
 	if hookutil.IsClusterEncryptionEnabled() {
 		if ez := hookutil.GetEzByCollProperties(insertCodec.Schema.GetSchema().GetProperties(), insertCodec.Schema.ID); ez != nil {
 			encryptor, safeKey, err := hookutil.GetCipher().GetEncryptor(ez.EzID, ez.CollectionID)
@@ -294,7 +300,7 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 		writer = NewInsertBinlogWriter(field.DataType, insertCodec.Schema.ID, partitionID, segmentID, field.FieldID, field.GetNullable(), binlogWriterOpts...)
 
 		// get payload writing configs, including nullable and fallback encoding method
-		payloadWriterOpts := []PayloadWriterOptions{WithNullable(field.GetNullable()), WithWriterProps(getFieldWriterProps(field))}
+		payloadWriterOpts := []PayloadWriterOptions{WithNullable(field.GetNullable()), WithWriterProps(getFieldWriterProps(field, compressType))}
 		if typeutil.IsVectorType(field.DataType) && !typeutil.IsSparseFloatVectorType(field.DataType) {
 			dim, err := typeutil.GetDim(field)
 			if err != nil {
@@ -929,7 +935,7 @@ func NewDeleteCodec() *DeleteCodec {
 func (deleteCodec *DeleteCodec) Serialize(collectionID UniqueID, partitionID UniqueID, segmentID UniqueID, data *DeleteData) (*Blob, error) {
 	binlogWriter := NewDeleteBinlogWriter(schemapb.DataType_String, collectionID, partitionID, segmentID)
 	field := &schemapb.FieldSchema{IsPrimaryKey: true, DataType: schemapb.DataType_String}
-	opts := []PayloadWriterOptions{WithWriterProps(getFieldWriterProps(field))}
+	opts := []PayloadWriterOptions{WithWriterProps(getFieldWriterProps(field, compressor.CompressTypeZstd))}
 	eventWriter, err := binlogWriter.NextDeleteEventWriter(opts...)
 	if err != nil {
 		binlogWriter.Close()
@@ -24,6 +24,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -34,6 +35,7 @@ const (
 	nullableKey     = "nullable"
 	edekKey         = "edek"
 	ezIDKey         = "encryption_zone"
+	compressionKey  = "compression"
 
 	// mark useMultiFieldFormat if there are multi fields in a log file
 	MultiField = "MULTI_FIELD"
@@ -107,6 +109,19 @@ func (data *descriptorEventData) GetEzID() (int64, bool) {
 	return ezid, true
 }
 
+// GetCompressType returns the compress type of the payload.
+func (data *descriptorEventData) GetCompressType() compressor.CompressType {
+	compressStored, ok := data.Extras[compressionKey]
+	// previous descriptorEventData not store compression, they are always compressed by zstd
+	if !ok {
+		return compressor.CompressTypeZstd
+	}
+
+	// won't be not ok, already checked format when write with FinishExtra
+	compressType, _ := compressStored.(string)
+	return compressor.CompressType(compressType)
+}
+
 // GetMemoryUsageInBytes returns the memory size of DescriptorEventDataFixPart.
 func (data *descriptorEventData) GetMemoryUsageInBytes() int32 {
 	return data.GetEventDataFixPartSize() + int32(binary.Size(data.PostHeaderLengths)) + int32(binary.Size(data.ExtraLength)) + data.ExtraLength
@@ -153,6 +168,16 @@ func (data *descriptorEventData) FinishExtra() error {
 			return merr.WrapErrDataIntegrityMsg("value of %v must in string format", edekKey)
 		}
 	}
+	compressStored, exist := data.Extras[compressionKey]
+	if exist {
+		compressType, ok := compressStored.(string)
+		if !ok {
+			return merr.WrapErrDataIntegrityMsg("value of %v must in string format", compressionKey)
+		}
+		if _, err := compressor.ParseCompressType(compressType); err != nil {
+			return merr.WrapErrDataIntegrityMsg("value of %v is not a valid compress type", compressionKey)
+		}
+	}
 	ezIDStored, exist := data.Extras[ezIDKey]
 	if exist {
 		_, ok := ezIDStored.(int64)
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 )
 
 func TestPayloadWriter_Failed(t *testing.T) {
@@ -713,7 +714,7 @@ func TestParquetEncoding(t *testing.T) {
 	t.Run("test int64 pk", func(t *testing.T) {
 		field := &schemapb.FieldSchema{IsPrimaryKey: true, DataType: schemapb.DataType_Int64}
 
-		w, err := NewPayloadWriter(schemapb.DataType_Int64, WithWriterProps(getFieldWriterProps(field)))
+		w, err := NewPayloadWriter(schemapb.DataType_Int64, WithWriterProps(getFieldWriterProps(field, compressor.CompressTypeZstd)))
 
 		assert.NoError(t, err)
 		err = w.AddDataToPayloadForUT([]int64{1, 2, 3}, nil)
@@ -727,7 +728,7 @@ func TestParquetEncoding(t *testing.T) {
 	t.Run("test string pk", func(t *testing.T) {
 		field := &schemapb.FieldSchema{IsPrimaryKey: true, DataType: schemapb.DataType_String}
 
-		w, err := NewPayloadWriter(schemapb.DataType_String, WithWriterProps(getFieldWriterProps(field)))
+		w, err := NewPayloadWriter(schemapb.DataType_String, WithWriterProps(getFieldWriterProps(field, compressor.CompressTypeZstd)))
 
 		assert.NoError(t, err)
 		err = w.AddOneStringToPayload("1", true)
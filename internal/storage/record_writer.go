@@ -28,10 +28,12 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	storagecompress "github.com/milvus-io/milvus/internal/storage/compress"
 	"github.com/milvus-io/milvus/internal/storagecommon"
 	"github.com/milvus-io/milvus/internal/storagev2/packed"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexcgopb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -150,7 +152,8 @@ func NewPackedRecordWriter(
 		return nil, merr.WrapErrServiceInternal(
 			fmt.Sprintf("can not convert collection schema %s to arrow schema: %s", schema.Name, err.Error()))
 	}
-	writer, err := packed.NewPackedWriter(paths, arrowSchema, bufferSize, multiPartUploadSize, columnGroups, storageConfig, storagePluginContext)
+	compressType := storagecompress.GetBinlogCompressType(schema.GetProperties())
+	writer, err := packed.NewPackedWriter(paths, arrowSchema, bufferSize, multiPartUploadSize, columnGroups, storageConfig, storagePluginContext, compressType)
 	if err != nil {
 		return nil, merr.WrapErrStorage(err, "can not new packed record writer")
 	}
@@ -362,6 +365,10 @@ func newPackedRecordBatchWriter(
 	if len(schemaBasedFormats) > 0 {
 		extraProperties[packed.PropertyWriterSchemaBasedFormats] = strings.Join(schemaBasedFormats, ",")
 	}
+	// the files compressed by zstd keep the default properties of the writer
+	if compressType := storagecompress.GetBinlogCompressType(schema.GetProperties()); compressType != compressor.CompressTypeZstd {
+		extraProperties[packed.PropertyWriterCompression] = string(compressType)
+	}
 	writer, err := packed.NewFFIPackedWriter(basePath, arrowSchema, columnGroups, storageConfig, storagePluginContext, extraProperties)
 	if err != nil {
 		return nil, merr.WrapErrStorage(err, "can not new packed record writer")
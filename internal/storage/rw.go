@@ -27,6 +27,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	storagecompress "github.com/milvus-io/milvus/internal/storage/compress"
 	"github.com/milvus-io/milvus/internal/storagecommon"
 	"github.com/milvus-io/milvus/internal/storagev2/packed"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
@@ -419,7 +420,7 @@ func NewBinlogRecordWriter(ctx context.Context, collectionID, partitionID, segme
 		return rwOptions.uploader(ctx, kvs)
 	}
 
-	opts := []StreamWriterOption{}
+	opts := []StreamWriterOption{WithCompressType(storagecompress.GetBinlogCompressType(schema.GetProperties()))}
 	var pluginContext *indexcgopb.StoragePluginContext
 	if hookutil.IsClusterEncryptionEnabled() {
 		ez := hookutil.GetEzByCollProperties(schema.GetProperties(), collectionID)
@@ -33,7 +33,9 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	storagecompress "github.com/milvus-io/milvus/internal/storage/compress"
 	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
@@ -800,18 +802,15 @@ var serdeMap = func() map[schemapb.DataType]serdeEntry {
 // Since parquet does not support custom fallback encoding for now,
 // we disable dict encoding for primary key.
 // It can be scale to all fields once parquet fallback encoding is available.
-func getFieldWriterProps(field *schemapb.FieldSchema) *parquet.WriterProperties {
+func getFieldWriterProps(field *schemapb.FieldSchema, compressType compressor.CompressType) *parquet.WriterProperties {
+	opts := []parquet.WriterProperty{
+		parquet.WithCompression(storagecompress.ParquetCodec(compressType)),
+		parquet.WithCompressionLevel(3),
+	}
 	if field.GetIsPrimaryKey() {
-		return parquet.NewWriterProperties(
-			parquet.WithCompression(compress.Codecs.Zstd),
-			parquet.WithCompressionLevel(3),
-			parquet.WithDictionaryDefault(false),
-		)
+		opts = append(opts, parquet.WithDictionaryDefault(false))
 	}
-	return parquet.NewWriterProperties(
-		parquet.WithCompression(compress.Codecs.Zstd),
-		parquet.WithCompressionLevel(3),
-	)
+	return parquet.NewWriterProperties(opts...)
 }
 
 type DeserializeReader[T any] interface {
@@ -36,6 +36,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/metautil"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
@@ -255,6 +256,13 @@ func WithEncryptor(encryptor hook.Encryptor) StreamWriterOption {
 	}
 }
 
+// WithCompressType sets the compress type of the payload, and records it in the descriptor event if it is not zstd.
+func WithCompressType(compressType compressor.CompressType) StreamWriterOption {
+	return func(w *BinlogStreamWriter) {
+		w.compressType = compressType
+	}
+}
+
 func WithHeaderExtraOptions(headerOpt HeaderExtraWriterOption) StreamWriterOption {
 	return func(w *BinlogStreamWriter) {
 		w.headerOpt = headerOpt
@@ -274,10 +282,11 @@ type BinlogStreamWriter struct {
 	segmentID    UniqueID
 	fieldSchema  *schemapb.FieldSchema
 
-	buf       bytes.Buffer
-	rw        *singleFieldRecordWriter
-	headerOpt HeaderExtraWriterOption
-	encryptor hook.Encryptor
+	buf          bytes.Buffer
+	rw           *singleFieldRecordWriter
+	headerOpt    HeaderExtraWriterOption
+	encryptor    hook.Encryptor
+	compressType compressor.CompressType
 }
 
 func (bsw *BinlogStreamWriter) GetRecordWriter() (RecordWriter, error) {
@@ -285,7 +294,7 @@ func (bsw *BinlogStreamWriter) GetRecordWriter() (RecordWriter, error) {
 		return bsw.rw, nil
 	}
 
-	rw, err := newSingleFieldRecordWriter(bsw.fieldSchema, &bsw.buf, WithRecordWriterProps(getFieldWriterProps(bsw.fieldSchema)))
+	rw, err := newSingleFieldRecordWriter(bsw.fieldSchema, &bsw.buf, WithRecordWriterProps(getFieldWriterProps(bsw.fieldSchema, bsw.compressType)))
 	if err != nil {
 		return nil, err
 	}
@@ -371,6 +380,10 @@ func (bsw *BinlogStreamWriter) writeBinlogHeaders(w io.Writer) error {
 	de.FieldID = bsw.fieldSchema.FieldID
 	de.AddExtra(originalSizeKey, strconv.Itoa(int(bsw.rw.writtenUncompressed)))
 	de.AddExtra(nullableKey, bsw.fieldSchema.Nullable)
+	// binlogs without the compression extra are compressed by zstd, keep them unchanged for the old readers
+	if bsw.compressType != compressor.CompressTypeZstd {
+		de.AddExtra(compressionKey, string(bsw.compressType))
+	}
 	// Additional head options
 	if bsw.headerOpt != nil {
 		bsw.headerOpt(de)
@@ -389,6 +402,7 @@ func newBinlogWriter(collectionID, partitionID, segmentID UniqueID,
 		partitionID:  partitionID,
 		segmentID:    segmentID,
 		fieldSchema:  field,
+		compressType: compressor.CompressTypeZstd,
 	}
 }
 
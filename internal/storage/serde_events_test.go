@@ -48,6 +48,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexcgopb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
@@ -147,6 +148,47 @@ func TestBinlogStreamWriter(t *testing.T) {
 		ok = rr.Next()
 		assert.False(t, ok)
 	})
+
+	t.Run("test compress type", func(t *testing.T) {
+		field := &schemapb.FieldSchema{
+			FieldID:  1,
+			DataType: schemapb.DataType_Bool,
+		}
+		for _, compressType := range []compressor.CompressType{compressor.CompressTypeZstd, compressor.CompressTypeLZ4, compressor.CompressTypeSnappy} {
+			bsw := newBinlogWriter(1, 2, 3, field)
+			WithCompressType(compressType)(bsw)
+			rw, err := bsw.GetRecordWriter()
+			require.NoError(t, err)
+
+			builder := array.NewBooleanBuilder(memory.DefaultAllocator)
+			builder.AppendValues([]bool{true, false, true}, nil)
+			arr := builder.NewArray()
+			ar := array.NewRecord(
+				arrow.NewSchema([]arrow.Field{{Name: "bool", Type: arrow.FixedWidthTypes.Boolean}}, nil),
+				[]arrow.Array{arr},
+				3,
+			)
+			r := NewSimpleArrowRecord(ar, map[FieldID]int{1: 0})
+			require.NoError(t, rw.Write(r))
+			r.Release()
+			arr.Release()
+
+			blob, err := bsw.Finalize()
+			require.NoError(t, err)
+			reader, err := NewBinlogReader(blob.Value)
+			require.NoError(t, err)
+			assert.Equal(t, compressType, reader.GetCompressType())
+			_, hasExtra := reader.Extras[compressionKey]
+			assert.Equal(t, compressType != compressor.CompressTypeZstd, hasExtra)
+
+			eventReader, err := reader.NextEventReader()
+			require.NoError(t, err)
+			values, _, err := eventReader.GetBoolFromPayload()
+			assert.NoError(t, err)
+			assert.Equal(t, []bool{true, false, true}, values)
+			reader.Close()
+		}
+	})
 }
 
 func TestRecordToInsertData(t *testing.T) {
@@ -94,6 +94,40 @@ func TestStatsWriter_BF(t *testing.T) {
 	assert.True(t, stats.MaxPk.EQ(NewInt64PrimaryKey(999999)))
 }
 
+func TestStatsWriter_BinaryFuseFilter(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().CommonCfg.BloomFilterType.Key, bloomfilter.BinaryFuseBFName)
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.BloomFilterType.Key)
+
+	data := &Int64FieldData{
+		Data: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+	sw := &StatsWriter{}
+	err := sw.GenerateByData(common.RowIDField, schemapb.DataType_Int64, data)
+	assert.NoError(t, err)
+
+	sr := &StatsReader{}
+	sr.SetBuffer(sw.GetBuffer())
+	stats, err := sr.GetPrimaryKeyStats()
+	assert.NoError(t, err)
+	assert.Equal(t, bloomfilter.BinaryFuseBF, stats.BFType)
+	assert.Equal(t, bloomfilter.BinaryFuseBF, stats.BF.Type())
+
+	pkStats := &PkStatistics{
+		PkFilter: stats.BF,
+		MinPK:    stats.MinPk,
+		MaxPK:    stats.MaxPk,
+	}
+	for _, id := range data.Data {
+		assert.True(t, pkStats.TestLocationCache(NewLocationsCache(NewInt64PrimaryKey(id))))
+	}
+	assert.False(t, pkStats.TestLocationCache(NewLocationsCache(NewInt64PrimaryKey(5000))))
+
+	pks := []PrimaryKey{NewInt64PrimaryKey(1), NewInt64PrimaryKey(5000), NewInt64PrimaryKey(9)}
+	hits := pkStats.BatchPkExist(NewBatchLocationsCache(pks), make([]bool, len(pks)))
+	assert.Equal(t, []bool{true, false, true}, hits)
+}
+
 func TestStatsWriter_VarCharPrimaryKey(t *testing.T) {
 	data := &StringFieldData{
 		Data: []string{"bc", "ac", "abd", "cd", "milvus"},
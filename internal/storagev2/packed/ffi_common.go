@@ -63,6 +63,7 @@ var (
 	PropertyWriterFormat             = C.GoString(C.loon_properties_writer_format)
 	PropertyWriterSchemaBasedPattern = C.GoString(C.loon_properties_writer_schema_base_patterns)
 	PropertyWriterSchemaBasedFormats = "writer.split.schema_based.formats"
+	PropertyWriterCompression        = "writer.compression" // compress type of the parquet files, zstd by default
 
 	// CMEK (Customer Managed Encryption Keys) writer properties
 	PropertyWriterEncEnable = C.GoString(C.loon_properties_writer_enc_enable)    // Enable encryption for written data
@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/storagev2"
 	"github.com/milvus-io/milvus/internal/util/initcore"
 	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
@@ -84,7 +85,7 @@ func (suite *PackedTestSuite) TestPackedOneFile() {
 	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
 	bufferSize := int64(10 * 1024 * 1024) // 10MB
 	multiPartUploadSize := int64(0)
-	pw, err := NewPackedWriter(paths, suite.schema, bufferSize, multiPartUploadSize, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, bufferSize, multiPartUploadSize, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	for i := 0; i < batches; i++ {
 		err = pw.WriteRecordBatch(suite.rec)
@@ -101,6 +102,31 @@ func (suite *PackedTestSuite) TestPackedOneFile() {
 	suite.Equal(int64(3*batches), rr.NumRows())
 }
 
+func (suite *PackedTestSuite) TestPackedCompressType() {
+	for _, compressType := range []compressor.CompressType{compressor.CompressTypeLZ4, compressor.CompressTypeSnappy} {
+		paths := []string{"/tmp/compress_" + string(compressType)}
+		columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
+		bufferSize := int64(10 * 1024 * 1024)
+		pw, err := NewPackedWriter(paths, suite.schema, bufferSize, 0, columnGroups, nil, nil, compressType)
+		suite.NoError(err)
+		suite.NoError(pw.WriteRecordBatch(suite.rec))
+		suite.NoError(pw.Close())
+
+		// the codec is recorded in the parquet files
+		reader, err := NewPackedReader(paths, suite.schema, bufferSize, nil, nil)
+		suite.NoError(err)
+		rr, err := reader.ReadNext()
+		suite.NoError(err)
+		suite.Equal(suite.rec.NumRows(), rr.NumRows())
+		rr.Release()
+	}
+
+	paths := []string{"/tmp/compress_invalid"}
+	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
+	_, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil, "gzip")
+	suite.Error(err)
+}
+
 func (suite *PackedTestSuite) TestPackedMultiFiles() {
 	batches := 1000
 
@@ -136,7 +162,7 @@ func (suite *PackedTestSuite) TestPackedMultiFiles() {
 	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{2}, GroupID: 2}, {Columns: []int{0, 1}, GroupID: storagecommon.DefaultShortColumnGroupID}}
 	bufferSize := int64(10 * 1024 * 1024) // 10MB
 	multiPartUploadSize := int64(0)
-	pw, err := NewPackedWriter(paths, suite.schema, bufferSize, multiPartUploadSize, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, bufferSize, multiPartUploadSize, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	for i := 0; i < batches; i++ {
 		err = pw.WriteRecordBatch(rec)
@@ -180,7 +206,7 @@ func (suite *PackedTestSuite) TestCloseAndTellOneGroup() {
 	paths := []string{"/tmp/tell_one_group"}
 	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
 	bufferSize := int64(10 * 1024 * 1024)
-	pw, err := NewPackedWriter(paths, suite.schema, bufferSize, 0, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, bufferSize, 0, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	for i := 0; i < batches; i++ {
 		err = pw.WriteRecordBatch(suite.rec)
@@ -195,7 +221,7 @@ func (suite *PackedTestSuite) TestCloseAndTellOneGroup() {
 func (suite *PackedTestSuite) TestCloseIsIdempotent() {
 	paths := []string{"/tmp/close_idempotent"}
 	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
-	pw, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	err = pw.WriteRecordBatch(suite.rec)
 	suite.NoError(err)
@@ -208,7 +234,7 @@ func (suite *PackedTestSuite) TestCloseIsIdempotent() {
 func (suite *PackedTestSuite) TestCloseAndTellIsIdempotent() {
 	paths := []string{"/tmp/close_and_tell_idempotent"}
 	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
-	pw, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	err = pw.WriteRecordBatch(suite.rec)
 	suite.NoError(err)
@@ -257,7 +283,7 @@ func (suite *PackedTestSuite) TestCloseAndTellMultiGroups() {
 		{Columns: []int{2}, GroupID: 2},
 		{Columns: []int{0, 1}, GroupID: storagecommon.DefaultShortColumnGroupID},
 	}
-	pw, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, int64(10*1024*1024), 0, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	for i := 0; i < batches; i++ {
 		err = pw.WriteRecordBatch(rec)
@@ -282,7 +308,7 @@ func (suite *PackedTestSuite) TestFilesystemMetrics() {
 
 	paths := []string{"/tmp/metrics_test"}
 	columnGroups := []storagecommon.ColumnGroup{{Columns: []int{0, 1, 2}, GroupID: storagecommon.DefaultShortColumnGroupID}}
-	pw, err := NewPackedWriter(paths, suite.schema, 10*1024*1024, 0, columnGroups, nil, nil)
+	pw, err := NewPackedWriter(paths, suite.schema, 10*1024*1024, 0, columnGroups, nil, nil, compressor.CompressTypeZstd)
 	suite.NoError(err)
 	for i := 0; i < 100; i++ {
 		err = pw.WriteRecordBatch(suite.rec)
@@ -0,0 +1,486 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bloomfilter
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+
+	"github.com/zeebo/xxh3"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const (
+	// binaryFuseArity is the number of slots each key is mapped to.
+	binaryFuseArity = 3
+	// binaryFuseMaxIterations is the max attempts of seeds to build the filter,
+	// the probability of failing all of them is negligible.
+	binaryFuseMaxIterations = 100
+)
+
+// binaryFuseFilter impl binary fuse filter with xxh3 hash, see "Binary Fuse Filters: Fast and Smaller Than Xor Filters".
+// It takes ~1.13 fingerprints per key, so it provides a lower false positive rate than bloom filters with the same size,
+// and a lookup only reads 3 fingerprints.
+//
+// The filter is static, the added keys are kept and the filter is (re)built on the first test or marshal after adding,
+// so it suits the stats of sealed segments, which are written once and tested many times.
+// Notice: a filter unmarshaled from the stats log doesn't keep the keys, adding items to it makes all tests return true.
+type binaryFuseFilter struct {
+	mu          sync.Mutex
+	fingerprint uint     // bits of fingerprint, 8 or 16
+	hashes      []uint64 // xxh3 hash of the added keys, nil if the filter is unmarshaled
+	frozen      bool     // the filter is unmarshaled, no more keys could be added
+	degraded    atomic.Bool
+	table       atomic.Pointer[binaryFuseTable] // nil if keys were added since the last build
+}
+
+func newBinaryFuseFilter(capacity uint, fp float64) *binaryFuseFilter {
+	// 8 bits fingerprint provides ~0.39% false positive rate, and 16 bits provides ~0.0015%
+	fingerprint := uint(16)
+	if fp >= 1.0/256 {
+		fingerprint = 8
+	}
+	return &binaryFuseFilter{
+		fingerprint: fingerprint,
+		hashes:      make([]uint64, 0, capacity),
+	}
+}
+
+func (b *binaryFuseFilter) Type() BFType {
+	return BinaryFuseBF
+}
+
+func (b *binaryFuseFilter) Cap() uint {
+	return uint(b.getTable().size()) * b.fingerprint
+}
+
+func (b *binaryFuseFilter) K() uint {
+	return binaryFuseArity
+}
+
+func (b *binaryFuseFilter) Add(data []byte) {
+	b.addHash(xxh3.Hash(data))
+}
+
+func (b *binaryFuseFilter) AddString(data string) {
+	b.addHash(xxh3.HashString(data))
+}
+
+func (b *binaryFuseFilter) addHash(h uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.frozen {
+		if !b.degraded.Swap(true) {
+			mlog.Warn(context.TODO(), "add item to unmarshaled binary fuse filter, all tests will return true")
+		}
+		return
+	}
+	b.hashes = append(b.hashes, h)
+	b.table.Store(nil)
+}
+
+func (b *binaryFuseFilter) Test(data []byte) bool {
+	return b.testHash(xxh3.Hash(data))
+}
+
+func (b *binaryFuseFilter) TestString(data string) bool {
+	return b.testHash(xxh3.HashString(data))
+}
+
+func (b *binaryFuseFilter) TestLocations(locs []uint64) bool {
+	// same as block bf, the hash result is cached as locations
+	if len(locs) != 1 {
+		return true
+	}
+	return b.testHash(locs[0])
+}
+
+func (b *binaryFuseFilter) BatchTestLocations(locs [][]uint64, hits []bool) []bool {
+	ret := make([]bool, len(locs))
+	table := b.getTable()
+	degraded := b.degraded.Load()
+	for i := range hits {
+		if !hits[i] {
+			if len(locs[i]) != 1 || degraded {
+				ret[i] = true
+				continue
+			}
+			ret[i] = table.contains(locs[i][0])
+		}
+	}
+	return ret
+}
+
+func (b *binaryFuseFilter) testHash(h uint64) bool {
+	if b.degraded.Load() {
+		return true
+	}
+	return b.getTable().contains(h)
+}
+
+// getTable returns the built filter, builds it with the added keys if necessary.
+func (b *binaryFuseFilter) getTable() *binaryFuseTable {
+	if table := b.table.Load(); table != nil {
+		return table
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if table := b.table.Load(); table != nil {
+		return table
+	}
+	table, err := newBinaryFuseTable(b.fingerprint, b.hashes)
+	if err != nil {
+		mlog.Warn(context.TODO(), "failed to build binary fuse filter, all tests will return true",
+			mlog.Int("keyNum", len(b.hashes)), mlog.Err(err))
+		b.degraded.Store(true)
+		table = &binaryFuseTable{}
+	}
+	b.table.Store(table)
+	return table
+}
+
+type binaryFuseJSON struct {
+	Fingerprint   uint   `json:"fingerprint"`
+	Seed          uint64 `json:"seed"`
+	SegmentLength uint32 `json:"segmentLength"`
+	SegmentCount  uint32 `json:"segmentCount"`
+	Fingerprints  []byte `json:"fingerprints"`
+}
+
+func (b *binaryFuseFilter) MarshalJSON() ([]byte, error) {
+	table := b.getTable()
+	if b.degraded.Load() {
+		return nil, merr.WrapErrServiceInternalMsg("binary fuse filter is degraded, keyNum %d", len(b.hashes))
+	}
+
+	data := binaryFuseJSON{
+		Fingerprint:   b.fingerprint,
+		Seed:          table.seed,
+		SegmentLength: table.segmentLength,
+		SegmentCount:  table.segmentCount,
+	}
+	switch b.fingerprint {
+	case 8:
+		data.Fingerprints = table.fingerprints8
+	default:
+		data.Fingerprints = make([]byte, len(table.fingerprints16)*2)
+		for i, f := range table.fingerprints16 {
+			binary.LittleEndian.PutUint16(data.Fingerprints[i*2:], f)
+		}
+	}
+	return json.Marshal(data)
+}
+
+func (b *binaryFuseFilter) UnmarshalJSON(data []byte) error {
+	var inner binaryFuseJSON
+	if err := json.Unmarshal(data, &inner); err != nil {
+		return err
+	}
+	if inner.SegmentLength == 0 || inner.SegmentLength&(inner.SegmentLength-1) != 0 {
+		return merr.WrapErrParameterInvalidMsg("invalid segment length %d of binary fuse filter", inner.SegmentLength)
+	}
+
+	table := &binaryFuseTable{
+		seed:               inner.Seed,
+		segmentLength:      inner.SegmentLength,
+		segmentLengthMask:  inner.SegmentLength - 1,
+		segmentCount:       inner.SegmentCount,
+		segmentCountLength: inner.SegmentCount * inner.SegmentLength,
+	}
+	arrayLength := int(inner.SegmentCount+binaryFuseArity-1) * int(inner.SegmentLength)
+	switch inner.Fingerprint {
+	case 8:
+		if len(inner.Fingerprints) != arrayLength {
+			return merr.WrapErrParameterInvalidMsg("expect %d fingerprints, got %d", arrayLength, len(inner.Fingerprints))
+		}
+		table.fingerprints8 = inner.Fingerprints
+	case 16:
+		if len(inner.Fingerprints) != arrayLength*2 {
+			return merr.WrapErrParameterInvalidMsg("expect %d fingerprints, got %d", arrayLength, len(inner.Fingerprints)/2)
+		}
+		table.fingerprints16 = make([]uint16, arrayLength)
+		for i := range table.fingerprints16 {
+			table.fingerprints16[i] = binary.LittleEndian.Uint16(inner.Fingerprints[i*2:])
+		}
+	default:
+		return merr.WrapErrParameterInvalidMsg("unsupported fingerprint bits %d of binary fuse filter", inner.Fingerprint)
+	}
+
+	b.fingerprint = inner.Fingerprint
+	b.hashes = nil
+	b.frozen = true
+	b.table.Store(table)
+	return nil
+}
+
+// binaryFuseTable is a built binary fuse filter with 3-wise fingerprints.
+type binaryFuseTable struct {
+	seed               uint64
+	segmentLength      uint32
+	segmentLengthMask  uint32
+	segmentCount       uint32
+	segmentCountLength uint32
+	fingerprints8      []uint8
+	fingerprints16     []uint16
+}
+
+func (t *binaryFuseTable) size() int {
+	return len(t.fingerprints8) + len(t.fingerprints16)
+}
+
+func (t *binaryFuseTable) contains(key uint64) bool {
+	hash := binaryFuseMix(key, t.seed)
+	h0, h1, h2 := t.locate(hash)
+	if t.fingerprints8 != nil {
+		return uint8(binaryFuseFingerprint(hash))^t.fingerprints8[h0]^t.fingerprints8[h1]^t.fingerprints8[h2] == 0
+	}
+	if t.fingerprints16 != nil {
+		return uint16(binaryFuseFingerprint(hash))^t.fingerprints16[h0]^t.fingerprints16[h1]^t.fingerprints16[h2] == 0
+	}
+	return true
+}
+
+// locate returns the 3 slots of hash, which are in 3 consecutive segments.
+func (t *binaryFuseTable) locate(hash uint64) (uint32, uint32, uint32) {
+	hi, _ := bits.Mul64(hash, uint64(t.segmentCountLength))
+	h0 := uint32(hi)
+	h1 := h0 + t.segmentLength
+	h2 := h1 + t.segmentLength
+	h1 ^= uint32(hash>>18) & t.segmentLengthMask
+	h2 ^= uint32(hash) & t.segmentLengthMask
+	return h0, h1, h2
+}
+
+func (t *binaryFuseTable) initParameters(size uint32) int {
+	t.segmentLength = binaryFuseSegmentLength(size)
+	t.segmentLengthMask = t.segmentLength - 1
+	capacity := uint32(0)
+	if size > 1 {
+		capacity = uint32(math.Round(float64(size) * binaryFuseSizeFactor(size)))
+	}
+	segmentCount := (capacity + t.segmentLength - 1) / t.segmentLength
+	if segmentCount <= binaryFuseArity-1 {
+		t.segmentCount = 1
+	} else {
+		t.segmentCount = segmentCount - (binaryFuseArity - 1)
+	}
+	t.segmentCountLength = t.segmentCount * t.segmentLength
+	return int(t.segmentCount+binaryFuseArity-1) * int(t.segmentLength)
+}
+
+// newBinaryFuseTable builds the filter of the hashes with fingerprint bits, the duplicated hashes are allowed.
+func newBinaryFuseTable(fingerprint uint, hashes []uint64) (*binaryFuseTable, error) {
+	t := &binaryFuseTable{}
+	size := uint32(len(hashes))
+	capacity := t.initParameters(size)
+
+	alone := make([]uint32, capacity)
+	// the lowest 2 bits are the xor of slot indexes (0, 1 or 2) of the keys, the others are the key count
+	t2count := make([]uint8, capacity)
+	t2hash := make([]uint64, capacity)
+	reverseH := make([]uint8, size)
+	reverseOrder := make([]uint64, size+1)
+	reverseOrder[size] = 1
+
+	blockBits := 1
+	for (uint32(1) << blockBits) < t.segmentCount {
+		blockBits++
+	}
+	startPos := make([]uint, 1<<blockBits)
+
+	var h012 [5]uint32
+	rngCounter := uint64(1)
+	t.seed = binaryFuseSplitMix(&rngCounter)
+	for iteration := 0; ; iteration++ {
+		if iteration >= binaryFuseMaxIterations {
+			return nil, merr.WrapErrServiceInternalMsg("failed to build binary fuse filter after %d iterations", iteration)
+		}
+		if iteration > 0 {
+			clear(reverseOrder[:size])
+			clear(t2count)
+			clear(t2hash)
+			t.seed = binaryFuseSplitMix(&rngCounter)
+		}
+
+		// sort the keys by segment roughly, to make the construction cache friendly
+		for i := range startPos {
+			startPos[i] = uint((uint64(i) * uint64(size)) >> blockBits)
+		}
+		for _, key := range hashes {
+			hash := binaryFuseMix(key, t.seed)
+			segmentIndex := hash >> (64 - blockBits)
+			for reverseOrder[startPos[segmentIndex]] != 0 {
+				segmentIndex++
+				segmentIndex &= (1 << blockBits) - 1
+			}
+			reverseOrder[startPos[segmentIndex]] = hash
+			startPos[segmentIndex]++
+		}
+
+		failed := false
+		duplicates := uint32(0)
+		for i := uint32(0); i < size; i++ {
+			hash := reverseOrder[i]
+			h0, h1, h2 := t.locate(hash)
+			t2count[h0] += 4
+			t2hash[h0] ^= hash
+			t2count[h1] += 4
+			t2count[h1] ^= 1
+			t2hash[h1] ^= hash
+			t2count[h2] += 4
+			t2count[h2] ^= 2
+			t2hash[h2] ^= hash
+			// a duplicated key cancels itself out in the slots
+			if t2hash[h0]&t2hash[h1]&t2hash[h2] == 0 {
+				if (t2hash[h0] == 0 && t2count[h0] == 8) ||
+					(t2hash[h1] == 0 && t2count[h1] == 8) ||
+					(t2hash[h2] == 0 && t2count[h2] == 8) {
+					duplicates++
+					t2count[h0] -= 4
+					t2hash[h0] ^= hash
+					t2count[h1] -= 4
+					t2count[h1] ^= 1
+					t2hash[h1] ^= hash
+					t2count[h2] -= 4
+					t2count[h2] ^= 2
+					t2hash[h2] ^= hash
+				}
+			}
+			// the count overflows
+			if t2count[h0] < 4 || t2count[h1] < 4 || t2count[h2] < 4 {
+				failed = true
+			}
+		}
+		if failed {
+			continue
+		}
+
+		// peel the slots with only one key
+		queueSize := 0
+		for i := 0; i < capacity; i++ {
+			alone[queueSize] = uint32(i)
+			if t2count[i]>>2 == 1 {
+				queueSize++
+			}
+		}
+		stackSize := uint32(0)
+		for queueSize > 0 {
+			queueSize--
+			index := alone[queueSize]
+			if t2count[index]>>2 != 1 {
+				continue
+			}
+			hash := t2hash[index]
+			found := t2count[index] & 3
+			reverseH[stackSize] = found
+			reverseOrder[stackSize] = hash
+			stackSize++
+
+			h0, h1, h2 := t.locate(hash)
+			h012[1] = h1
+			h012[2] = h2
+			h012[3] = h0
+			h012[4] = h1
+			for j := uint8(1); j <= 2; j++ {
+				other := h012[found+j]
+				alone[queueSize] = other
+				if t2count[other]>>2 == 2 {
+					queueSize++
+				}
+				t2count[other] -= 4
+				t2count[other] ^= (found + j) % binaryFuseArity
+				t2hash[other] ^= hash
+			}
+		}
+		if stackSize+duplicates == size {
+			size = stackSize
+			break
+		}
+	}
+
+	switch fingerprint {
+	case 8:
+		t.fingerprints8 = make([]uint8, capacity)
+		assignBinaryFuseFingerprints(t, t.fingerprints8, reverseOrder[:size], reverseH[:size])
+	default:
+		t.fingerprints16 = make([]uint16, capacity)
+		assignBinaryFuseFingerprints(t, t.fingerprints16, reverseOrder[:size], reverseH[:size])
+	}
+	return t, nil
+}
+
+// assignBinaryFuseFingerprints assigns the fingerprints in the reverse peeling order,
+// so the xor of the 3 slots of each key equals to its fingerprint.
+func assignBinaryFuseFingerprints[T uint8 | uint16](t *binaryFuseTable, fingerprints []T, order []uint64, slots []uint8) {
+	var h012 [5]uint32
+	for i := len(order) - 1; i >= 0; i-- {
+		hash := order[i]
+		h0, h1, h2 := t.locate(hash)
+		h012[0] = h0
+		h012[1] = h1
+		h012[2] = h2
+		h012[3] = h0
+		h012[4] = h1
+		found := slots[i]
+		fingerprints[h012[found]] = T(binaryFuseFingerprint(hash)) ^ fingerprints[h012[found+1]] ^ fingerprints[h012[found+2]]
+	}
+}
+
+func binaryFuseSegmentLength(size uint32) uint32 {
+	if size == 0 {
+		return 4
+	}
+	length := uint32(1) << int(math.Floor(math.Log(float64(size))/math.Log(3.33)+2.25))
+	if length > 262144 {
+		length = 262144
+	}
+	return length
+}
+
+func binaryFuseSizeFactor(size uint32) float64 {
+	return math.Max(1.125, 0.875+0.25*math.Log(1000000)/math.Log(float64(size)))
+}
+
+func binaryFuseMix(key, seed uint64) uint64 {
+	h := key + seed
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+func binaryFuseSplitMix(seed *uint64) uint64 {
+	*seed += 0x9E3779B97F4A7C15
+	z := *seed
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+func binaryFuseFingerprint(hash uint64) uint64 {
+	return hash ^ (hash >> 32)
+}
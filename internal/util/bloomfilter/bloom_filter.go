@@ -36,6 +36,7 @@ const (
 	BlockBFName       = "BlockedBloomFilter"
 	BasicBFName       = "BasicBloomFilter"
 	AlwaysTrueBFName  = "AlwaysTrueBloomFilter"
+	BinaryFuseBFName  = "BinaryFuseFilter"
 )
 
 const (
@@ -43,11 +44,13 @@ const (
 	AlwaysTrueBF         // empty bloom filter
 	BasicBF
 	BlockedBF
+	BinaryFuseBF
 )
 
 var bfNames = map[BFType]string{
 	BasicBF:       BasicBFName,
 	BlockedBF:     BlockBFName,
+	BinaryFuseBF:  BinaryFuseBFName,
 	AlwaysTrueBF:  AlwaysTrueBFName,
 	UnsupportedBF: UnsupportedBFName,
 }
@@ -62,6 +65,8 @@ func BFTypeFromString(name string) BFType {
 		return BasicBF
 	case BlockBFName:
 		return BlockedBF
+	case BinaryFuseBFName:
+		return BinaryFuseBF
 	case AlwaysTrueBFName:
 		return AlwaysTrueBF
 	default:
@@ -296,12 +301,23 @@ func NewBloomFilterWithType(capacity uint, fp float64, typeName string) BloomFil
 		return newBlockedBloomFilter(capacity, fp)
 	case BasicBF:
 		return newBasicBloomFilter(capacity, fp)
+	case BinaryFuseBF:
+		return newBinaryFuseFilter(capacity, fp)
 	default:
 		mlog.Info(context.TODO(), "unsupported bloom filter type, using block bloom filter", mlog.String("type", typeName))
 		return newBlockedBloomFilter(capacity, fp)
 	}
 }
 
+// NewMutableBloomFilterWithType creates a bloom filter which keeps adding items while being tested, like the filters of growing segments.
+// Binary fuse filter is rebuilt after adding items, so block bloom filter is used instead.
+func NewMutableBloomFilterWithType(capacity uint, fp float64, typeName string) BloomFilterInterface {
+	if BFTypeFromString(typeName) == BinaryFuseBF {
+		return newBlockedBloomFilter(capacity, fp)
+	}
+	return NewBloomFilterWithType(capacity, fp, typeName)
+}
+
 func UnmarshalJSON(data []byte, bfType BFType) (BloomFilterInterface, error) {
 	switch bfType {
 	case BlockedBF:
@@ -318,6 +334,13 @@ func UnmarshalJSON(data []byte, bfType BFType) (BloomFilterInterface, error) {
 			return nil, merr.Wrap(err, "failed to unmarshal blocked bloom filter")
 		}
 		return bf, nil
+	case BinaryFuseBF:
+		bf := &binaryFuseFilter{}
+		err := json.Unmarshal(data, bf)
+		if err != nil {
+			return nil, merr.Wrap(err, "failed to unmarshal binary fuse filter")
+		}
+		return bf, nil
 	case AlwaysTrueBF:
 		return AlwaysTrueBloomFilter, nil
 	default:
@@ -329,7 +352,7 @@ func Locations(data []byte, k uint, bfType BFType) []uint64 {
 	switch bfType {
 	case BasicBF:
 		return bloom.Locations(data, k)
-	case BlockedBF:
+	case BlockedBF, BinaryFuseBF:
 		return []uint64{xxh3.Hash(data)}
 	case AlwaysTrueBF:
 		return nil
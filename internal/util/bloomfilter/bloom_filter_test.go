@@ -68,6 +68,22 @@ func TestPerformance(t *testing.T) {
 		bf2.Test(key)
 	}
 	mlog.Info(context.TODO(), "Basic BF Test cost", mlog.Duration("time", time.Since(start4)))
+
+	bf3 := newBinaryFuseFilter(uint(capacity), fpr)
+	start5 := time.Now()
+	for _, key := range keys {
+		bf3.Add(key)
+	}
+	data, err = bf3.MarshalJSON()
+	assert.NoError(t, err)
+	mlog.Info(context.TODO(), "Binary Fuse BF construct time", mlog.Duration("time", time.Since(start5)))
+	mlog.Info(context.TODO(), "Binary Fuse BF size", mlog.Int("size", len(data)))
+
+	start6 := time.Now()
+	for _, key := range keys {
+		bf3.Test(key)
+	}
+	mlog.Info(context.TODO(), "Binary Fuse BF Test cost", mlog.Duration("time", time.Since(start6)))
 }
 
 func TestPerformance_MultiBF(t *testing.T) {
@@ -311,3 +327,96 @@ func TestMarshal(t *testing.T) {
 		assert.True(t, emptyBF2.Test(key))
 	}
 }
+
+func TestBinaryFuseFilter(t *testing.T) {
+	for _, capacity := range []int{0, 1, 2, 100, 10000, 200000} {
+		for _, fpr := range []float64{0.001, 0.01} {
+			keys := make([][]byte, 0, capacity)
+			for i := 0; i < capacity; i++ {
+				keys = append(keys, []byte(fmt.Sprintf("key%d", i)))
+			}
+
+			bf := NewBloomFilterWithType(uint(capacity), fpr, BinaryFuseBFName)
+			assert.Equal(t, BinaryFuseBF, bf.Type())
+			for _, key := range keys {
+				bf.Add(key)
+			}
+			// duplicated keys are allowed
+			for _, key := range lo.Slice(keys, 0, 10) {
+				bf.Add(key)
+			}
+			for _, key := range keys {
+				assert.True(t, bf.Test(key))
+				assert.True(t, bf.TestLocations(Locations(key, bf.K(), bf.Type())))
+			}
+
+			data, err := bf.MarshalJSON()
+			assert.NoError(t, err)
+			bf2, err := UnmarshalJSON(data, BinaryFuseBF)
+			assert.NoError(t, err)
+			assert.Equal(t, bf.Type(), bf2.Type())
+			assert.Equal(t, bf.Cap(), bf2.Cap())
+			for _, key := range keys {
+				assert.True(t, bf2.Test(key))
+			}
+
+			if capacity < 10000 {
+				continue
+			}
+			falsePositive := 0
+			testNum := 100000
+			for i := 0; i < testNum; i++ {
+				if bf2.TestString(fmt.Sprintf("absent%d", i)) {
+					falsePositive++
+				}
+			}
+			assert.Less(t, float64(falsePositive)/float64(testNum), fpr)
+		}
+	}
+}
+
+func TestBinaryFuseFilter_AddAfterBuild(t *testing.T) {
+	bf := NewBloomFilterWithType(100, 0.001, BinaryFuseBFName)
+	bf.AddString("key1")
+	assert.True(t, bf.TestString("key1"))
+	assert.False(t, bf.TestString("key2"))
+
+	// the filter is rebuilt with the new keys
+	bf.AddString("key2")
+	assert.True(t, bf.TestString("key1"))
+	assert.True(t, bf.TestString("key2"))
+
+	locs := [][]uint64{
+		Locations([]byte("key1"), bf.K(), bf.Type()),
+		Locations([]byte("key2"), bf.K(), bf.Type()),
+		Locations([]byte("key3"), bf.K(), bf.Type()),
+	}
+	assert.Equal(t, []bool{true, true, false}, bf.BatchTestLocations(locs, make([]bool, len(locs))))
+	assert.Equal(t, []bool{false, true, false}, bf.BatchTestLocations(locs, []bool{true, false, false}))
+
+	// the unmarshaled filter doesn't keep the keys, all tests return true after adding
+	data, err := bf.MarshalJSON()
+	assert.NoError(t, err)
+	bf2, err := UnmarshalJSON(data, BinaryFuseBF)
+	assert.NoError(t, err)
+	assert.False(t, bf2.TestString("key3"))
+	bf2.AddString("key3")
+	assert.True(t, bf2.TestString("key3"))
+	assert.True(t, bf2.TestString("key4"))
+	_, err = bf2.MarshalJSON()
+	assert.Error(t, err)
+
+	_, err = UnmarshalJSON([]byte(`{"fingerprint":8,"segmentLength":3}`), BinaryFuseBF)
+	assert.Error(t, err)
+	_, err = UnmarshalJSON([]byte(`{"fingerprint":32,"segmentLength":4,"segmentCount":1}`), BinaryFuseBF)
+	assert.Error(t, err)
+	_, err = UnmarshalJSON([]byte(`{"fingerprint":8,"segmentLength":4,"segmentCount":1,"fingerprints":"AAA="}`), BinaryFuseBF)
+	assert.Error(t, err)
+}
+
+func TestNewMutableBloomFilterWithType(t *testing.T) {
+	assert.Equal(t, BlockedBF, NewMutableBloomFilterWithType(100, 0.001, BinaryFuseBFName).Type())
+	assert.Equal(t, BasicBF, NewMutableBloomFilterWithType(100, 0.001, BasicBFName).Type())
+	assert.Equal(t, BlockedBF, NewMutableBloomFilterWithType(100, 0.001, BlockBFName).Type())
+	assert.Equal(t, BinaryFuseBF, BFTypeFromString(BinaryFuseBF.String()))
+}
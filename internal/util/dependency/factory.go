@@ -24,6 +24,7 @@ const (
 	mqTypeKafka      = "kafka"
 	mqTypePulsar     = "pulsar"
 	mqTypeWoodpecker = "woodpecker"
+	mqTypeLocalFS    = "localfs"
 )
 
 type mqEnable struct {
@@ -106,6 +107,9 @@ func (f *DefaultFactory) initMQ(standalone bool, params *paramtable.ComponentPar
 		f.msgStreamFactory = msgstream.NewKmsFactory(&params.ServiceParam)
 	case mqTypeWoodpecker:
 		f.msgStreamFactory = msgstream.NewWpmsFactory(&params.ServiceParam)
+	case mqTypeLocalFS:
+		// localfs only works with the streaming service, there's no msgstream implementation for it.
+		f.msgStreamFactory = &streamingOnlyMsgStreamFactory{mqType: mqType}
 	}
 	if f.msgStreamFactory == nil {
 		return merr.WrapErrServiceInternalMsg("failed to create MQ: check the milvus log for initialization failures")
@@ -142,10 +146,10 @@ func mustSelectMQType(standalone bool, mqType string, enable mqEnable) string {
 
 // Validate mq type.
 func validateMQType(standalone bool, mqType string) error {
-	if mqType != mqTypeRocksmq && mqType != mqTypeKafka && mqType != mqTypePulsar && mqType != mqTypeWoodpecker {
+	if mqType != mqTypeRocksmq && mqType != mqTypeKafka && mqType != mqTypePulsar && mqType != mqTypeWoodpecker && mqType != mqTypeLocalFS {
 		return merr.WrapErrParameterInvalidMsg("mq type %s is invalid", mqType)
 	}
-	if !standalone && mqType == mqTypeRocksmq {
+	if !standalone && (mqType == mqTypeRocksmq || mqType == mqTypeLocalFS) {
 		return merr.WrapErrParameterInvalidMsg("mq %s is only valid in standalone mode", mqType)
 	}
 	return nil
@@ -167,6 +171,25 @@ func (f *DefaultFactory) NewPersistentStorageChunkManager(ctx context.Context) (
 	return f.chunkManagerFactory.NewPersistentStorageChunkManager(ctx)
 }
 
+// streamingOnlyMsgStreamFactory is the msgstream factory of the mq which can only be used by streaming service.
+type streamingOnlyMsgStreamFactory struct {
+	mqType string
+}
+
+func (f *streamingOnlyMsgStreamFactory) NewMsgStream(ctx context.Context) (msgstream.MsgStream, error) {
+	return nil, merr.WrapErrServiceUnavailableMsg("msgstream is not supported by mq %s", f.mqType)
+}
+
+func (f *streamingOnlyMsgStreamFactory) NewTtMsgStream(ctx context.Context) (msgstream.MsgStream, error) {
+	return nil, merr.WrapErrServiceUnavailableMsg("msgstream is not supported by mq %s", f.mqType)
+}
+
+func (f *streamingOnlyMsgStreamFactory) NewMsgStreamDisposer(ctx context.Context) func([]string, string) error {
+	return func(channels []string, subName string) error {
+		return nil
+	}
+}
+
 type Factory interface {
 	msgstream.Factory
 	Init(p *paramtable.ComponentParam)
@@ -194,6 +217,8 @@ func HealthCheck(mqType string) *common.MQClusterStatus {
 	case mqTypeWoodpecker:
 		// TODO: implement health checker for woodpecker
 		clusterStatus.Health = true
+	case mqTypeLocalFS:
+		clusterStatus.Health = true
 	}
 	return clusterStatus
 }
@@ -15,6 +15,8 @@ func TestValidateMQType(t *testing.T) {
 	assert.Error(t, validateMQType(false, mqTypeRocksmq))
 	assert.NoError(t, validateMQType(true, mqTypeWoodpecker))
 	assert.NoError(t, validateMQType(false, mqTypeWoodpecker))
+	assert.NoError(t, validateMQType(true, mqTypeLocalFS))
+	assert.Error(t, validateMQType(false, mqTypeLocalFS))
 }
 
 func TestSelectMQType(t *testing.T) {
@@ -76,6 +78,7 @@ func TestHealthCheck(t *testing.T) {
 		{mqTypePulsar, false},
 		{mqTypeKafka, false},
 		{mqTypeWoodpecker, true},
+		{mqTypeLocalFS, true},
 		{"invalidType", false},
 	}
 
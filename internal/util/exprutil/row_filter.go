@@ -0,0 +1,367 @@
+package exprutil
+
+import (
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// ternary is the result of evaluating an expression on a row,
+// comparing with a null value is unknown rather than false, so `not (a > 1)` still skips the null rows.
+type ternary int8
+
+const (
+	ternaryFalse ternary = iota
+	ternaryTrue
+	ternaryUnknown
+)
+
+func (t ternary) not() ternary {
+	switch t {
+	case ternaryTrue:
+		return ternaryFalse
+	case ternaryFalse:
+		return ternaryTrue
+	default:
+		return ternaryUnknown
+	}
+}
+
+func ternaryOf(b bool) ternary {
+	if b {
+		return ternaryTrue
+	}
+	return ternaryFalse
+}
+
+// RowFilter evaluates the boolean expression on the rows of column based field data without segcore.
+// Only the expressions on scalar fields are supported:
+// comparison, range, term, null check, prefix/postfix/inner match and the logical and/or/not of them.
+type RowFilter struct {
+	expr *planpb.Expr
+}
+
+// NewRowFilter creates a RowFilter, an error is returned if the expression is not supported.
+// A nil expression matches all the rows.
+func NewRowFilter(expr *planpb.Expr) (*RowFilter, error) {
+	if err := checkRowFilterExpr(expr); err != nil {
+		return nil, err
+	}
+	return &RowFilter{expr: expr}, nil
+}
+
+// Filter returns the offsets of the rows matching the expression.
+func (f *RowFilter) Filter(fieldsData []*schemapb.FieldData, numRows int) []int {
+	offsets := make([]int, 0, numRows)
+	if f.expr == nil {
+		for i := 0; i < numRows; i++ {
+			offsets = append(offsets, i)
+		}
+		return offsets
+	}
+	columns := make(map[int64]*rowFilterColumn, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		columns[fieldData.GetFieldId()] = newRowFilterColumn(fieldData)
+	}
+	for i := 0; i < numRows; i++ {
+		if evalRowFilterExpr(f.expr, columns, i) == ternaryTrue {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+func checkRowFilterExpr(expr *planpb.Expr) error {
+	if expr == nil {
+		return nil
+	}
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_AlwaysTrueExpr:
+		return nil
+	case *planpb.Expr_BinaryExpr:
+		if err := checkRowFilterExpr(e.BinaryExpr.GetLeft()); err != nil {
+			return err
+		}
+		return checkRowFilterExpr(e.BinaryExpr.GetRight())
+	case *planpb.Expr_UnaryExpr:
+		return checkRowFilterExpr(e.UnaryExpr.GetChild())
+	case *planpb.Expr_UnaryRangeExpr:
+		switch e.UnaryRangeExpr.GetOp() {
+		case planpb.OpType_GreaterThan, planpb.OpType_GreaterEqual, planpb.OpType_LessThan, planpb.OpType_LessEqual,
+			planpb.OpType_Equal, planpb.OpType_NotEqual, planpb.OpType_PrefixMatch, planpb.OpType_PostfixMatch, planpb.OpType_InnerMatch:
+		default:
+			return merr.WrapErrParameterInvalidMsg("operator %s is not supported by row filter", e.UnaryRangeExpr.GetOp().String())
+		}
+		return checkRowFilterColumn(e.UnaryRangeExpr.GetColumnInfo())
+	case *planpb.Expr_BinaryRangeExpr:
+		return checkRowFilterColumn(e.BinaryRangeExpr.GetColumnInfo())
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetIsInField() {
+			return merr.WrapErrParameterInvalidMsg("in field expression is not supported by row filter")
+		}
+		return checkRowFilterColumn(e.TermExpr.GetColumnInfo())
+	case *planpb.Expr_NullExpr:
+		return checkRowFilterColumn(e.NullExpr.GetColumnInfo())
+	default:
+		return merr.WrapErrParameterInvalidMsg("expression %T is not supported by row filter", expr.GetExpr())
+	}
+}
+
+func checkRowFilterColumn(column *planpb.ColumnInfo) error {
+	if len(column.GetNestedPath()) > 0 {
+		return merr.WrapErrParameterInvalidMsg("nested path is not supported by row filter")
+	}
+	switch column.GetDataType() {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64,
+		schemapb.DataType_Float, schemapb.DataType_Double, schemapb.DataType_String, schemapb.DataType_VarChar:
+		return nil
+	default:
+		return merr.WrapErrParameterInvalidMsg("data type %s is not supported by row filter", column.GetDataType().String())
+	}
+}
+
+func evalRowFilterExpr(expr *planpb.Expr, columns map[int64]*rowFilterColumn, row int) ternary {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_AlwaysTrueExpr:
+		return ternaryTrue
+	case *planpb.Expr_BinaryExpr:
+		left := evalRowFilterExpr(e.BinaryExpr.GetLeft(), columns, row)
+		if e.BinaryExpr.GetOp() == planpb.BinaryExpr_LogicalAnd {
+			if left == ternaryFalse {
+				return ternaryFalse
+			}
+			right := evalRowFilterExpr(e.BinaryExpr.GetRight(), columns, row)
+			if right == ternaryFalse {
+				return ternaryFalse
+			}
+			if left == ternaryTrue && right == ternaryTrue {
+				return ternaryTrue
+			}
+			return ternaryUnknown
+		}
+		if left == ternaryTrue {
+			return ternaryTrue
+		}
+		right := evalRowFilterExpr(e.BinaryExpr.GetRight(), columns, row)
+		if right == ternaryTrue {
+			return ternaryTrue
+		}
+		if left == ternaryFalse && right == ternaryFalse {
+			return ternaryFalse
+		}
+		return ternaryUnknown
+	case *planpb.Expr_UnaryExpr:
+		return evalRowFilterExpr(e.UnaryExpr.GetChild(), columns, row).not()
+	case *planpb.Expr_UnaryRangeExpr:
+		v, ok := columns[e.UnaryRangeExpr.GetColumnInfo().GetFieldId()].value(row)
+		if !ok {
+			return ternaryUnknown
+		}
+		return ternaryOf(evalUnaryRange(e.UnaryRangeExpr.GetOp(), v, e.UnaryRangeExpr.GetValue()))
+	case *planpb.Expr_BinaryRangeExpr:
+		r := e.BinaryRangeExpr
+		v, ok := columns[r.GetColumnInfo().GetFieldId()].value(row)
+		if !ok {
+			return ternaryUnknown
+		}
+		lowerOp, upperOp := planpb.OpType_GreaterThan, planpb.OpType_LessThan
+		if r.GetLowerInclusive() {
+			lowerOp = planpb.OpType_GreaterEqual
+		}
+		if r.GetUpperInclusive() {
+			upperOp = planpb.OpType_LessEqual
+		}
+		return ternaryOf(evalUnaryRange(lowerOp, v, r.GetLowerValue()) && evalUnaryRange(upperOp, v, r.GetUpperValue()))
+	case *planpb.Expr_TermExpr:
+		v, ok := columns[e.TermExpr.GetColumnInfo().GetFieldId()].value(row)
+		if !ok {
+			return ternaryUnknown
+		}
+		for _, term := range e.TermExpr.GetValues() {
+			if evalUnaryRange(planpb.OpType_Equal, v, term) {
+				return ternaryTrue
+			}
+		}
+		return ternaryFalse
+	case *planpb.Expr_NullExpr:
+		_, ok := columns[e.NullExpr.GetColumnInfo().GetFieldId()].value(row)
+		if e.NullExpr.GetOp() == planpb.NullExpr_IsNull {
+			return ternaryOf(!ok)
+		}
+		return ternaryOf(ok)
+	default:
+		return ternaryUnknown
+	}
+}
+
+// evalUnaryRange evaluates `v op target`, v is bool, int64, float64 or string.
+func evalUnaryRange(op planpb.OpType, v any, target *planpb.GenericValue) bool {
+	switch op {
+	case planpb.OpType_PrefixMatch, planpb.OpType_PostfixMatch, planpb.OpType_InnerMatch:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		switch op {
+		case planpb.OpType_PrefixMatch:
+			return strings.HasPrefix(s, target.GetStringVal())
+		case planpb.OpType_PostfixMatch:
+			return strings.HasSuffix(s, target.GetStringVal())
+		default:
+			return strings.Contains(s, target.GetStringVal())
+		}
+	}
+
+	cmp, ok := compareGenericValue(v, target)
+	if !ok {
+		return false
+	}
+	switch op {
+	case planpb.OpType_GreaterThan:
+		return cmp > 0
+	case planpb.OpType_GreaterEqual:
+		return cmp >= 0
+	case planpb.OpType_LessThan:
+		return cmp < 0
+	case planpb.OpType_LessEqual:
+		return cmp <= 0
+	case planpb.OpType_Equal:
+		return cmp == 0
+	case planpb.OpType_NotEqual:
+		return cmp != 0
+	default:
+		return false
+	}
+}
+
+// compareGenericValue compares v with target, false is returned if they are not comparable.
+func compareGenericValue(v any, target *planpb.GenericValue) (int, bool) {
+	switch val := v.(type) {
+	case bool:
+		t, ok := target.GetVal().(*planpb.GenericValue_BoolVal)
+		if !ok {
+			return 0, false
+		}
+		// only equality makes sense for bool, treat false < true.
+		return compareOrdered(boolToInt(val), boolToInt(t.BoolVal)), true
+	case int64:
+		switch t := target.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			return compareOrdered(val, t.Int64Val), true
+		case *planpb.GenericValue_FloatVal:
+			return compareOrdered(float64(val), t.FloatVal), true
+		}
+	case float64:
+		switch t := target.GetVal().(type) {
+		case *planpb.GenericValue_Int64Val:
+			return compareOrdered(val, float64(t.Int64Val)), true
+		case *planpb.GenericValue_FloatVal:
+			return compareOrdered(val, t.FloatVal), true
+		}
+	case string:
+		if t, ok := target.GetVal().(*planpb.GenericValue_StringVal); ok {
+			return strings.Compare(val, t.StringVal), true
+		}
+	}
+	return 0, false
+}
+
+func compareOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// rowFilterColumn accesses the value of a scalar field data by row offset,
+// the data of nullable field may be either full or compacted to the valid rows.
+type rowFilterColumn struct {
+	fieldData *schemapb.FieldData
+	// dataOffsets maps the row offset to the data offset, nil if the data is not compacted.
+	dataOffsets []int
+}
+
+func newRowFilterColumn(fieldData *schemapb.FieldData) *rowFilterColumn {
+	column := &rowFilterColumn{fieldData: fieldData}
+	validData := fieldData.GetValidData()
+	if len(validData) > 0 && column.dataLen() < len(validData) {
+		column.dataOffsets = make([]int, len(validData))
+		offset := 0
+		for i, valid := range validData {
+			column.dataOffsets[i] = offset
+			if valid {
+				offset++
+			}
+		}
+	}
+	return column
+}
+
+func (c *rowFilterColumn) dataLen() int {
+	scalars := c.fieldData.GetScalars()
+	switch c.fieldData.GetType() {
+	case schemapb.DataType_Bool:
+		return len(scalars.GetBoolData().GetData())
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return len(scalars.GetIntData().GetData())
+	case schemapb.DataType_Int64:
+		return len(scalars.GetLongData().GetData())
+	case schemapb.DataType_Float:
+		return len(scalars.GetFloatData().GetData())
+	case schemapb.DataType_Double:
+		return len(scalars.GetDoubleData().GetData())
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return len(scalars.GetStringData().GetData())
+	default:
+		return 0
+	}
+}
+
+// value returns the value of the row as bool, int64, float64 or string,
+// false is returned if the value is null or the field is missing.
+func (c *rowFilterColumn) value(row int) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+	validData := c.fieldData.GetValidData()
+	if len(validData) > 0 && (row >= len(validData) || !validData[row]) {
+		return nil, false
+	}
+	offset := row
+	if c.dataOffsets != nil {
+		offset = c.dataOffsets[row]
+	}
+	if offset >= c.dataLen() {
+		return nil, false
+	}
+	scalars := c.fieldData.GetScalars()
+	switch c.fieldData.GetType() {
+	case schemapb.DataType_Bool:
+		return scalars.GetBoolData().GetData()[offset], true
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return int64(scalars.GetIntData().GetData()[offset]), true
+	case schemapb.DataType_Int64:
+		return scalars.GetLongData().GetData()[offset], true
+	case schemapb.DataType_Float:
+		return float64(scalars.GetFloatData().GetData()[offset]), true
+	case schemapb.DataType_Double:
+		return scalars.GetDoubleData().GetData()[offset], true
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return scalars.GetStringData().GetData()[offset], true
+	default:
+		return nil, false
+	}
+}
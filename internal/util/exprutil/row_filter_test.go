@@ -0,0 +1,87 @@
+package exprutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+func TestRowFilter(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int32},
+			{FieldID: 102, Name: "name", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: "max_length", Value: "64"}}},
+			{FieldID: 103, Name: "score", DataType: schemapb.DataType_Double, Nullable: true},
+			{FieldID: 104, Name: "meta", DataType: schemapb.DataType_JSON},
+			{FieldID: 105, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+		},
+	}
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	require.NoError(t, err)
+
+	fieldsData := []*schemapb.FieldData{
+		{
+			FieldId: 100, Type: schemapb.DataType_Int64,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2, 3, 4}}}}},
+		},
+		{
+			FieldId: 101, Type: schemapb.DataType_Int32,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{10, 20, 30, 40}}}}},
+		},
+		{
+			FieldId: 102, Type: schemapb.DataType_VarChar,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"alice", "bob", "carol", "alex"}}}}},
+		},
+		{
+			// compacted nullable data, the 2nd and the 4th rows are null
+			FieldId: 103, Type: schemapb.DataType_Double, ValidData: []bool{true, false, true, false},
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: []float64{1.5, 3.5}}}}},
+		},
+	}
+
+	cases := []struct {
+		expr   string
+		expect []int
+	}{
+		{"pk > 2", []int{2, 3}},
+		{"age >= 20 and age < 40", []int{1, 2}},
+		{"10 < age <= 30", []int{1, 2}},
+		{"pk in [1, 3, 5]", []int{0, 2}},
+		{"pk not in [1, 3]", []int{1, 3}},
+		{"name like \"al%\"", []int{0, 3}},
+		{"name == \"bob\" or pk == 3", []int{1, 2}},
+		{"score > 2", []int{2}},
+		{"not (score > 2)", []int{0}},
+		{"score is null", []int{1, 3}},
+		{"score is not null", []int{0, 2}},
+		{"score > 2 or age == 20", []int{1, 2}},
+	}
+	for _, c := range cases {
+		expr, err := planparserv2.ParseExpr(helper, c.expr, nil)
+		require.NoError(t, err, c.expr)
+		filter, err := NewRowFilter(expr)
+		require.NoError(t, err, c.expr)
+		assert.Equal(t, c.expect, filter.Filter(fieldsData, 4), c.expr)
+	}
+
+	// nil expression matches all
+	filter, err := NewRowFilter(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, filter.Filter(fieldsData, 4))
+
+	// unsupported expressions
+	for _, exprStr := range []string{"meta[\"a\"] > 1", "age + 1 > 2", "pk > age"} {
+		expr, err := planparserv2.ParseExpr(helper, exprStr, nil)
+		require.NoError(t, err, exprStr)
+		_, err = NewRowFilter(expr)
+		assert.Error(t, err, exprStr)
+	}
+}
@@ -0,0 +1,507 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fsck cross-checks the datacoord metadata against the object storage offline.
+//
+// The checker reports files referenced by metadata but missing in the storage, files whose
+// size differs from metadata, objects no metadata refers to and live segments whose
+// collection is gone. Like the garbage collector, the objects of the segments and index builds
+// referenced by snapshots are never orphans. Orphans can be moved into a quarantine prefix, and files that are
+// missing but still quarantined can be moved back, so no object is ever deleted by fsck.
+package fsck
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metautil"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// Mode decides what the checker does with the inconsistencies it finds.
+type Mode string
+
+const (
+	// ModeReport only reports, it never touches the storage.
+	ModeReport Mode = "report"
+	// ModeQuarantine moves orphan objects under QuarantinePath.
+	ModeQuarantine Mode = "quarantine"
+	// ModeRepair moves missing files back from QuarantinePath if they were quarantined before.
+	ModeRepair Mode = "repair"
+)
+
+// QuarantinePath is the prefix under the storage root path that holds quarantined objects,
+// an object keeps its path relative to the root path inside the quarantine.
+const QuarantinePath = "fsck_quarantine"
+
+const (
+	// legacyStorageVersion is storage.StorageV1, only its binlogs record the exact object size.
+	legacyStorageVersion int64 = 0
+
+	defaultConcurrency = 16
+)
+
+// ParseMode parses a mode name.
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(s); mode {
+	case ModeReport, ModeQuarantine, ModeRepair:
+		return mode, nil
+	default:
+		return "", merr.WrapErrParameterInvalid("report, quarantine or repair", s, "invalid fsck mode")
+	}
+}
+
+// ObjectStorage is the subset of the chunk manager used by the checker.
+type ObjectStorage interface {
+	RootPath() string
+	// Size returns the size of the object, merr.ErrIoKeyNotFound if it does not exist.
+	Size(ctx context.Context, filePath string) (int64, error)
+	// WalkWithPrefix calls walkFunc for each object under prefix recursively until it returns false.
+	WalkWithPrefix(ctx context.Context, prefix string, walkFunc func(filePath string, modifyTime time.Time) bool) error
+	Copy(ctx context.Context, srcFilePath string, dstFilePath string) error
+	Remove(ctx context.Context, filePath string) error
+}
+
+// Meta is the metadata to check.
+type Meta struct {
+	// CollectionIDs are the collections known by rootcoord, nil skips the reachability check.
+	CollectionIDs typeutil.UniqueSet
+	// Segments are the datacoord segments, including the dropped ones not collected yet.
+	Segments []*datapb.SegmentInfo
+	// SegmentIndexes are the segment indexes, including the deleted ones not collected yet.
+	SegmentIndexes []*model.SegmentIndex
+	// SnapshotSegmentIDs and SnapshotBuildIDs are referenced by the snapshots,
+	// their objects are kept by the garbage collector even if the segments or indexes are dropped.
+	SnapshotSegmentIDs typeutil.UniqueSet
+	SnapshotBuildIDs   typeutil.UniqueSet
+	// SnapshotBlockedCollectionIDs are the collections having snapshots whose references can not be loaded,
+	// none of their objects is an orphan then, and neither is any legacy index file which has no collection.
+	SnapshotBlockedCollectionIDs typeutil.UniqueSet
+}
+
+// Option controls a check.
+type Option struct {
+	Mode Mode
+	// CollectionID limits the storage walk to a single collection, 0 means all collections.
+	// Metadata shall be limited by the caller accordingly.
+	CollectionID int64
+	// OrphanMinAge ignores unreferenced objects modified recently, they may belong to
+	// a flush or an index build whose metadata is not saved yet.
+	OrphanMinAge time.Duration
+	// Concurrency is the max number of in-flight storage requests.
+	Concurrency int
+}
+
+// Checker checks the metadata against the object storage.
+type Checker struct {
+	storage ObjectStorage
+	meta    *Meta
+	option  Option
+	now     func() time.Time
+
+	mu     sync.Mutex
+	report *Report
+
+	segments   map[int64]*datapb.SegmentInfo
+	buildIDs   typeutil.UniqueSet
+	referenced typeutil.Set[string]
+}
+
+// NewChecker creates a checker.
+func NewChecker(storage ObjectStorage, meta *Meta, option Option) *Checker {
+	if option.Mode == "" {
+		option.Mode = ModeReport
+	}
+	if option.Concurrency <= 0 {
+		option.Concurrency = defaultConcurrency
+	}
+	return &Checker{
+		storage: storage,
+		meta:    meta,
+		option:  option,
+		now:     time.Now,
+	}
+}
+
+// Run runs the check and applies the mode, an error is returned only if the check can not finish.
+func (c *Checker) Run(ctx context.Context) (*Report, error) {
+	c.report = &Report{
+		RootPath:     c.storage.RootPath(),
+		Mode:         c.option.Mode,
+		CollectionID: c.option.CollectionID,
+		StartTime:    c.now(),
+	}
+	c.segments = make(map[int64]*datapb.SegmentInfo, len(c.meta.Segments))
+	c.buildIDs = typeutil.NewUniqueSet()
+	c.referenced = typeutil.NewSet[string]()
+
+	if err := c.checkSegments(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.checkSegmentIndexes(ctx); err != nil {
+		return nil, err
+	}
+	c.checkReachability()
+	if err := c.checkOrphans(ctx); err != nil {
+		return nil, err
+	}
+
+	switch c.option.Mode {
+	case ModeQuarantine:
+		c.quarantine(ctx)
+	case ModeRepair:
+		c.repair(ctx)
+	}
+	c.report.finish(c.now())
+	return c.report, nil
+}
+
+// fileToCheck is a file referenced by metadata.
+type fileToCheck struct {
+	kind         FileKind
+	path         string
+	collectionID int64
+	partitionID  int64
+	segmentID    int64
+	buildID      int64
+	// expectedSize is the recorded size of the object, 0 means unknown.
+	expectedSize int64
+}
+
+type kindLogs struct {
+	kind         FileKind
+	fieldBinlogs []*datapb.FieldBinlog
+}
+
+func (c *Checker) checkSegments(ctx context.Context) error {
+	rootPath := c.storage.RootPath()
+	files := make([]*fileToCheck, 0)
+	for _, segment := range c.meta.Segments {
+		c.segments[segment.GetID()] = segment
+		collectionID, partitionID, segmentID := segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID()
+		logs := []kindLogs{
+			{FileKindInsertLog, segment.GetBinlogs()},
+			{FileKindStatsLog, segment.GetStatslogs()},
+			{FileKindBM25Log, segment.GetBm25Statslogs()},
+		}
+		// deltalogs of manifest segments are pathless placeholders, the delta data is in the manifest.
+		if segment.GetManifestPath() == "" {
+			logs = append(logs, kindLogs{FileKindDeltaLog, segment.GetDeltalogs()})
+		}
+
+		segmentFiles := make([]*fileToCheck, 0)
+		for _, log := range logs {
+			for _, fieldBinlog := range log.fieldBinlogs {
+				for _, binlog := range fieldBinlog.GetBinlogs() {
+					filePath := binlog.GetLogPath()
+					if filePath == "" {
+						filePath = buildLogPath(rootPath, log.kind, collectionID, partitionID, segmentID, fieldBinlog.GetFieldID(), binlog.GetLogID())
+					}
+					c.referenced.Insert(filePath)
+					file := &fileToCheck{
+						kind:         log.kind,
+						path:         filePath,
+						collectionID: collectionID,
+						partitionID:  partitionID,
+						segmentID:    segmentID,
+					}
+					if segment.GetStorageVersion() == legacyStorageVersion && log.kind != FileKindDeltaLog {
+						file.expectedSize = binlog.GetLogSize()
+					}
+					segmentFiles = append(segmentFiles, file)
+				}
+			}
+		}
+
+		// files of dropped segments may be partially collected by the garbage collector already,
+		// and the files of manifest segments are listed in the manifest instead of the metadata.
+		if segment.GetState() == commonpb.SegmentState_Dropped || segment.GetManifestPath() != "" {
+			c.report.Summary.SkippedSegments++
+			continue
+		}
+		c.report.Summary.CheckedSegments++
+		files = append(files, segmentFiles...)
+	}
+	return c.checkFiles(ctx, files)
+}
+
+func (c *Checker) checkSegmentIndexes(ctx context.Context) error {
+	rootPath := c.storage.RootPath()
+	files := make([]*fileToCheck, 0)
+	for _, segIndex := range c.meta.SegmentIndexes {
+		c.buildIDs.Insert(segIndex.BuildID)
+		// only finished index records the actual path version.
+		if segIndex.IsDeleted || segIndex.IndexState != commonpb.IndexState_Finished {
+			continue
+		}
+		segment, ok := c.segments[segIndex.SegmentID]
+		if !ok || segment.GetState() == commonpb.SegmentState_Dropped {
+			continue
+		}
+		c.report.Summary.CheckedSegmentIndexes++
+		builder := metautil.NewIndexPathBuilder(rootPath, segIndex.IndexStorePathVersion, segIndex.CollectionID,
+			segIndex.PartitionID, segIndex.SegmentID, segIndex.BuildID, segIndex.IndexVersion)
+		for _, filePath := range builder.BuildFilePaths(segIndex.IndexFileKeys) {
+			files = append(files, &fileToCheck{
+				kind:         FileKindIndex,
+				path:         filePath,
+				collectionID: segIndex.CollectionID,
+				partitionID:  segIndex.PartitionID,
+				segmentID:    segIndex.SegmentID,
+				buildID:      segIndex.BuildID,
+			})
+		}
+	}
+	return c.checkFiles(ctx, files)
+}
+
+func (c *Checker) checkFiles(ctx context.Context, files []*fileToCheck) error {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(c.option.Concurrency)
+	for _, file := range files {
+		group.Go(func() error {
+			size, err := c.storage.Size(ctx, file.path)
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.report.Summary.CheckedFiles++
+			if errors.Is(err, merr.ErrIoKeyNotFound) {
+				c.report.MissingFiles = append(c.report.MissingFiles, &MissingFile{
+					Kind:         file.kind,
+					Path:         file.path,
+					CollectionID: file.collectionID,
+					PartitionID:  file.partitionID,
+					SegmentID:    file.segmentID,
+					BuildID:      file.buildID,
+				})
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if file.expectedSize > 0 && size != file.expectedSize {
+				c.report.SizeMismatches = append(c.report.SizeMismatches, &SizeMismatch{
+					Kind:         file.kind,
+					Path:         file.path,
+					CollectionID: file.collectionID,
+					PartitionID:  file.partitionID,
+					SegmentID:    file.segmentID,
+					ExpectedSize: file.expectedSize,
+					ActualSize:   size,
+				})
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+func (c *Checker) checkReachability() {
+	if c.meta.CollectionIDs == nil {
+		return
+	}
+	for _, segment := range c.meta.Segments {
+		if segment.GetState() == commonpb.SegmentState_Dropped || c.meta.CollectionIDs.Contain(segment.GetCollectionID()) {
+			continue
+		}
+		c.report.UnreachableSegments = append(c.report.UnreachableSegments, &UnreachableSegment{
+			CollectionID: segment.GetCollectionID(),
+			PartitionID:  segment.GetPartitionID(),
+			SegmentID:    segment.GetID(),
+			State:        segment.GetState().String(),
+		})
+	}
+}
+
+// checkOrphans walks the storage prefixes managed by datacoord, the rules follow the garbage collector:
+// an insert log is an orphan if its segment is unknown, a delta, stats or bm25 log is an orphan if the
+// log itself is not referenced, and an index file is an orphan if its build is unknown,
+// unless the segment or the build is protected by snapshots.
+func (c *Checker) checkOrphans(ctx context.Context) error {
+	rootPath := c.storage.RootPath()
+	kinds := []struct {
+		kind     FileKind
+		root     string
+		prefixed bool
+	}{
+		{FileKindInsertLog, common.SegmentInsertLogPath, true},
+		{FileKindDeltaLog, common.SegmentDeltaLogPath, true},
+		{FileKindStatsLog, common.SegmentStatslogPath, true},
+		{FileKindBM25Log, common.SegmentBm25LogPath, true},
+		{FileKindIndex, common.SegmentIndexV1Path, true},
+		// legacy index files are rooted by build id, they can't be limited to a collection.
+		{FileKindIndex, common.SegmentIndexV0Path, false},
+	}
+	for _, kind := range kinds {
+		prefix := path.Join(rootPath, kind.root)
+		if c.option.CollectionID != 0 {
+			if !kind.prefixed {
+				continue
+			}
+			prefix = path.Join(prefix, strconv.FormatInt(c.option.CollectionID, 10))
+		}
+		err := c.storage.WalkWithPrefix(ctx, prefix+"/", func(filePath string, modifyTime time.Time) bool {
+			c.report.Summary.ScannedObjects++
+			orphan := c.checkOrphan(kind.kind, kind.root, filePath)
+			if orphan == nil {
+				return true
+			}
+			if c.now().Sub(modifyTime) < c.option.OrphanMinAge {
+				mlog.Info(ctx, "skip recently modified unreferenced object", mlog.String("path", filePath), mlog.Time("modifyTime", modifyTime))
+				return true
+			}
+			orphan.ModifyTime = modifyTime
+			c.report.OrphanObjects = append(c.report.OrphanObjects, orphan)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkOrphan(kind FileKind, root string, filePath string) *OrphanObject {
+	keys := strings.Split(c.relativePath(filePath), "/")
+	ids := make([]int64, 0, len(keys)-1)
+	for _, key := range keys[1:] {
+		id, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			break
+		}
+		ids = append(ids, id)
+	}
+
+	orphan := &OrphanObject{Kind: kind, Path: filePath}
+	switch root {
+	case common.SegmentIndexV0Path, common.SegmentIndexV1Path:
+		// v0: index_files/{buildID}/..., v1: index_v1/{collID}/{partID}/{segID}/{buildID}/...
+		buildIDIdx := 0
+		if root == common.SegmentIndexV1Path {
+			buildIDIdx = 3
+		}
+		if len(ids) <= buildIDIdx {
+			return nil
+		}
+		orphan.BuildID = ids[buildIDIdx]
+		if c.buildIDs.Contain(orphan.BuildID) || c.meta.SnapshotBuildIDs.Contain(orphan.BuildID) {
+			return nil
+		}
+		// legacy index files have no collection, they are kept if any collection is blocked.
+		if (root == common.SegmentIndexV0Path && c.meta.SnapshotBlockedCollectionIDs.Len() > 0) ||
+			(root == common.SegmentIndexV1Path && c.meta.SnapshotBlockedCollectionIDs.Contain(ids[0])) {
+			return nil
+		}
+		orphan.Reason = "index build not found"
+		return orphan
+	}
+
+	// binlog: {kind}/{collID}/{partID}/{segID}/{fieldID}/{logID}, deltalog: delta_log/{collID}/{partID}/{segID}/{logID}
+	expectedIDs := 5
+	if kind == FileKindDeltaLog {
+		expectedIDs = 4
+	}
+	if len(keys) != expectedIDs+1 || len(ids) != expectedIDs {
+		return nil
+	}
+	orphan.SegmentID = ids[2]
+	if c.meta.SnapshotSegmentIDs.Contain(orphan.SegmentID) || c.meta.SnapshotBlockedCollectionIDs.Contain(ids[0]) {
+		return nil
+	}
+	if _, ok := c.segments[orphan.SegmentID]; !ok {
+		orphan.Reason = "segment not found"
+		return orphan
+	}
+	if kind != FileKindInsertLog && !c.referenced.Contain(filePath) {
+		orphan.Reason = "log not referenced by segment"
+		return orphan
+	}
+	return nil
+}
+
+func (c *Checker) relativePath(filePath string) string {
+	return strings.TrimLeft(strings.TrimPrefix(filePath, c.storage.RootPath()), "/")
+}
+
+func (c *Checker) quarantinePath(filePath string) string {
+	return path.Join(c.storage.RootPath(), QuarantinePath, c.relativePath(filePath))
+}
+
+// quarantine moves the orphans into the quarantine.
+func (c *Checker) quarantine(ctx context.Context) {
+	for _, orphan := range c.report.OrphanObjects {
+		if err := c.move(ctx, orphan.Path, c.quarantinePath(orphan.Path)); err != nil {
+			mlog.Warn(ctx, "failed to quarantine orphan object", mlog.String("path", orphan.Path), mlog.Err(err))
+			orphan.Error = err.Error()
+			continue
+		}
+		orphan.Quarantined = true
+		c.report.Summary.QuarantinedObjects++
+	}
+}
+
+// repair moves the missing files back if they are in the quarantine.
+func (c *Checker) repair(ctx context.Context) {
+	for _, missing := range c.report.MissingFiles {
+		quarantinePath := c.quarantinePath(missing.Path)
+		_, err := c.storage.Size(ctx, quarantinePath)
+		if errors.Is(err, merr.ErrIoKeyNotFound) {
+			continue
+		}
+		if err == nil {
+			err = c.move(ctx, quarantinePath, missing.Path)
+		}
+		if err != nil {
+			mlog.Warn(ctx, "failed to restore missing file", mlog.String("path", missing.Path), mlog.Err(err))
+			missing.Error = err.Error()
+			continue
+		}
+		missing.Restored = true
+		c.report.Summary.RestoredFiles++
+	}
+}
+
+func (c *Checker) move(ctx context.Context, src, dst string) error {
+	if err := c.storage.Copy(ctx, src, dst); err != nil {
+		return err
+	}
+	return c.storage.Remove(ctx, src)
+}
+
+func buildLogPath(rootPath string, kind FileKind, collectionID, partitionID, segmentID, fieldID, logID int64) string {
+	switch kind {
+	case FileKindDeltaLog:
+		return metautil.BuildDeltaLogPath(rootPath, collectionID, partitionID, segmentID, logID)
+	case FileKindStatsLog:
+		return metautil.BuildStatsLogPath(rootPath, collectionID, partitionID, segmentID, fieldID, logID)
+	case FileKindBM25Log:
+		return metautil.BuildBm25LogPath(rootPath, collectionID, partitionID, segmentID, fieldID, logID)
+	default:
+		return metautil.BuildInsertLogPath(rootPath, collectionID, partitionID, segmentID, fieldID, logID)
+	}
+}
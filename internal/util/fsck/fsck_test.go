@@ -0,0 +1,334 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsck
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const rootPath = "files"
+
+type memObject struct {
+	size       int64
+	modifyTime time.Time
+}
+
+// memStorage is an in-memory ObjectStorage.
+type memStorage struct {
+	mu      sync.Mutex
+	objects map[string]memObject
+	sizeErr error
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{objects: make(map[string]memObject)}
+}
+
+func (s *memStorage) put(filePath string, size int64, modifyTime time.Time) {
+	s.objects[filePath] = memObject{size: size, modifyTime: modifyTime}
+}
+
+func (s *memStorage) RootPath() string {
+	return rootPath
+}
+
+func (s *memStorage) Size(ctx context.Context, filePath string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sizeErr != nil {
+		return 0, s.sizeErr
+	}
+	obj, ok := s.objects[filePath]
+	if !ok {
+		return 0, merr.WrapErrIoKeyNotFound(filePath)
+	}
+	return obj.size, nil
+}
+
+func (s *memStorage) WalkWithPrefix(ctx context.Context, prefix string, walkFunc func(filePath string, modifyTime time.Time) bool) error {
+	s.mu.Lock()
+	paths := make([]string, 0)
+	for filePath := range s.objects {
+		if strings.HasPrefix(filePath, prefix) {
+			paths = append(paths, filePath)
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(paths)
+	for _, filePath := range paths {
+		if !walkFunc(filePath, s.objects[filePath].modifyTime) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *memStorage) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[srcFilePath]
+	if !ok {
+		return merr.WrapErrIoKeyNotFound(srcFilePath)
+	}
+	s.objects[dstFilePath] = obj
+	return nil
+}
+
+func (s *memStorage) Remove(ctx context.Context, filePath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, filePath)
+	return nil
+}
+
+func fieldBinlogs(fieldID int64, binlogs ...*datapb.Binlog) []*datapb.FieldBinlog {
+	return []*datapb.FieldBinlog{{FieldID: fieldID, Binlogs: binlogs}}
+}
+
+type FsckSuite struct {
+	suite.Suite
+
+	now     time.Time
+	old     time.Time
+	storage *memStorage
+	meta    *Meta
+}
+
+func (s *FsckSuite) SetupTest() {
+	s.now = time.Now()
+	s.old = s.now.Add(-time.Hour)
+	s.storage = newMemStorage()
+
+	// segment 100 of collection 1 is healthy except a missing insert log and a corrupted stats log.
+	s.storage.put("files/insert_log/1/10/100/101/1001", 64, s.old)
+	s.storage.put("files/stats_log/1/10/100/100/1003", 10, s.old)
+	s.storage.put("files/delta_log/1/10/100/1004", 99, s.old)
+	s.storage.put("files/index_files/5000/1/10/100/index_key", 128, s.old)
+	// segment 200 is dropped, its files are partially collected.
+	s.storage.put("files/insert_log/1/10/200/101/2001", 64, s.old)
+	// segment 300 belongs to a dropped collection.
+	s.storage.put("files/insert_log/2/20/300/101/3001", 64, s.old)
+	// orphans.
+	s.storage.put("files/insert_log/1/10/900/101/9001", 64, s.old)
+	s.storage.put("files/stats_log/1/10/100/100/9002", 10, s.old)
+	s.storage.put("files/delta_log/1/10/100/9003", 10, s.old)
+	s.storage.put("files/index_files/9004/1/10/100/index_key", 10, s.old)
+	s.storage.put("files/index_v1/1/10/100/9005/1/index_key", 10, s.old)
+	// recent objects may belong to in-flight flush.
+	s.storage.put("files/insert_log/1/10/901/101/9006", 64, s.now)
+	// unknown layout is ignored.
+	s.storage.put("files/insert_log/1/10/100/101", 1, s.old)
+
+	s.meta = &Meta{
+		CollectionIDs: typeutil.NewUniqueSet(1),
+		Segments: []*datapb.SegmentInfo{
+			{
+				ID: 100, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Flushed,
+				Binlogs: fieldBinlogs(101,
+					&datapb.Binlog{LogID: 1001, LogSize: 64},
+					&datapb.Binlog{LogID: 1002, LogSize: 64},
+				),
+				Statslogs: fieldBinlogs(100, &datapb.Binlog{LogID: 1003, LogSize: 20}),
+				Deltalogs: fieldBinlogs(0, &datapb.Binlog{LogID: 1004, LogSize: 25}),
+			},
+			{
+				ID: 200, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Dropped,
+				Binlogs: fieldBinlogs(101,
+					&datapb.Binlog{LogID: 2001, LogSize: 64},
+					&datapb.Binlog{LogID: 2002, LogSize: 64},
+				),
+			},
+			{
+				ID: 300, CollectionID: 2, PartitionID: 20, State: commonpb.SegmentState_Flushed,
+				Binlogs: fieldBinlogs(101, &datapb.Binlog{LogID: 3001, LogSize: 64}),
+			},
+			{
+				ID: 400, CollectionID: 1, PartitionID: 10, State: commonpb.SegmentState_Flushed,
+				ManifestPath: "files/insert_log/1/10/400/manifest", StorageVersion: 3,
+				Deltalogs: fieldBinlogs(0, &datapb.Binlog{LogID: 4001}),
+			},
+		},
+		SegmentIndexes: []*model.SegmentIndex{
+			{
+				SegmentID: 100, CollectionID: 1, PartitionID: 10, BuildID: 5000, IndexVersion: 1,
+				IndexState: commonpb.IndexState_Finished, IndexFileKeys: []string{"index_key"},
+			},
+			{
+				SegmentID: 100, CollectionID: 1, PartitionID: 10, BuildID: 5001, IndexVersion: 1,
+				IndexState:            commonpb.IndexState_Finished,
+				IndexStorePathVersion: indexpb.IndexStorePathVersion_INDEX_STORE_PATH_VERSION_COLLECTION_ROOTED,
+				IndexFileKeys:         []string{"index_key"},
+			},
+			{
+				SegmentID: 100, CollectionID: 1, PartitionID: 10, BuildID: 5002, IndexVersion: 1,
+				IndexState: commonpb.IndexState_InProgress,
+			},
+		},
+	}
+}
+
+func (s *FsckSuite) run(option Option) *Report {
+	option.OrphanMinAge = time.Minute
+	checker := NewChecker(s.storage, s.meta, option)
+	checker.now = func() time.Time { return s.now }
+	report, err := checker.Run(context.Background())
+	s.Require().NoError(err)
+	return report
+}
+
+func (s *FsckSuite) orphanPaths(report *Report) []string {
+	paths := make([]string, 0, len(report.OrphanObjects))
+	for _, orphan := range report.OrphanObjects {
+		paths = append(paths, orphan.Path)
+	}
+	return paths
+}
+
+func (s *FsckSuite) TestReport() {
+	objects := len(s.storage.objects)
+	report := s.run(Option{})
+	s.Len(s.storage.objects, objects)
+	s.True(report.HasIssues())
+	s.Equal(ModeReport, report.Mode)
+	s.Equal(2, report.Summary.CheckedSegments)
+	s.Equal(2, report.Summary.SkippedSegments)
+	s.Equal(2, report.Summary.CheckedSegmentIndexes)
+	s.Equal(7, report.Summary.CheckedFiles)
+
+	s.Equal([]*MissingFile{
+		{Kind: FileKindIndex, Path: "files/index_v1/1/10/100/5001/1/index_key", CollectionID: 1, PartitionID: 10, SegmentID: 100, BuildID: 5001},
+		{Kind: FileKindInsertLog, Path: "files/insert_log/1/10/100/101/1002", CollectionID: 1, PartitionID: 10, SegmentID: 100},
+	}, report.MissingFiles)
+	s.Equal([]*SizeMismatch{
+		{Kind: FileKindStatsLog, Path: "files/stats_log/1/10/100/100/1003", CollectionID: 1, PartitionID: 10, SegmentID: 100, ExpectedSize: 20, ActualSize: 10},
+	}, report.SizeMismatches)
+	s.Equal([]*UnreachableSegment{
+		{CollectionID: 2, PartitionID: 20, SegmentID: 300, State: commonpb.SegmentState_Flushed.String()},
+	}, report.UnreachableSegments)
+
+	s.Equal([]string{
+		"files/delta_log/1/10/100/9003",
+		"files/index_files/9004/1/10/100/index_key",
+		"files/index_v1/1/10/100/9005/1/index_key",
+		"files/insert_log/1/10/900/101/9001",
+		"files/stats_log/1/10/100/100/9002",
+	}, s.orphanPaths(report))
+	for _, orphan := range report.OrphanObjects {
+		s.Equal(s.old, orphan.ModifyTime)
+		s.False(orphan.Quarantined)
+	}
+	s.Equal(int64(900), report.OrphanObjects[3].SegmentID)
+	s.Equal("segment not found", report.OrphanObjects[3].Reason)
+	s.Equal(int64(9004), report.OrphanObjects[1].BuildID)
+	s.Equal(int64(9005), report.OrphanObjects[2].BuildID)
+
+	bytes, err := json.Marshal(report)
+	s.NoError(err)
+	s.Contains(string(bytes), `"missing_files":2`)
+}
+
+func (s *FsckSuite) TestCollectionFilter() {
+	report := s.run(Option{CollectionID: 2})
+	s.Empty(s.orphanPaths(report))
+
+	s.storage.put("files/insert_log/2/20/999/101/1", 1, s.old)
+	report = s.run(Option{CollectionID: 2})
+	s.Equal([]string{"files/insert_log/2/20/999/101/1"}, s.orphanPaths(report))
+}
+
+func (s *FsckSuite) TestQuarantineAndRepair() {
+	report := s.run(Option{Mode: ModeQuarantine})
+	s.Equal(5, report.Summary.QuarantinedObjects)
+	for _, orphan := range report.OrphanObjects {
+		s.True(orphan.Quarantined)
+		s.NotContains(s.storage.objects, orphan.Path)
+		s.Contains(s.storage.objects, "files/fsck_quarantine/"+strings.TrimPrefix(orphan.Path, "files/"))
+	}
+
+	report = s.run(Option{})
+	s.Empty(report.OrphanObjects)
+
+	// a referenced file was quarantined by mistake, repair moves it back.
+	s.meta.Segments[0].Deltalogs[0].Binlogs = append(s.meta.Segments[0].Deltalogs[0].Binlogs, &datapb.Binlog{LogID: 9003})
+	report = s.run(Option{Mode: ModeRepair})
+	s.Equal(1, report.Summary.RestoredFiles)
+	s.Len(report.MissingFiles, 3)
+	for _, missing := range report.MissingFiles {
+		s.Equal(missing.Path == "files/delta_log/1/10/100/9003", missing.Restored)
+	}
+	s.Contains(s.storage.objects, "files/delta_log/1/10/100/9003")
+	s.NotContains(s.storage.objects, "files/fsck_quarantine/delta_log/1/10/100/9003")
+}
+
+func (s *FsckSuite) TestSnapshotProtected() {
+	// the dropped segment 900 and the deleted build 9004 are referenced by snapshots.
+	s.meta.SnapshotSegmentIDs = typeutil.NewUniqueSet(900)
+	s.meta.SnapshotBuildIDs = typeutil.NewUniqueSet(9004)
+	report := s.run(Option{Mode: ModeQuarantine})
+	s.Equal([]string{
+		"files/delta_log/1/10/100/9003",
+		"files/index_v1/1/10/100/9005/1/index_key",
+		"files/stats_log/1/10/100/100/9002",
+	}, s.orphanPaths(report))
+	s.Contains(s.storage.objects, "files/insert_log/1/10/900/101/9001")
+	s.Contains(s.storage.objects, "files/index_files/9004/1/10/100/index_key")
+
+	// the references of the snapshots of collection 1 are unknown.
+	s.storage.put("files/insert_log/1/10/902/101/9007", 64, s.old)
+	s.storage.put("files/index_files/9008/1/10/100/index_key", 10, s.old)
+	s.storage.put("files/insert_log/2/20/999/101/9009", 64, s.old)
+	s.meta.SnapshotBlockedCollectionIDs = typeutil.NewUniqueSet(1)
+	report = s.run(Option{})
+	s.Equal([]string{"files/insert_log/2/20/999/101/9009"}, s.orphanPaths(report))
+}
+
+func (s *FsckSuite) TestStorageError() {
+	s.storage.sizeErr = errors.New("mock")
+	checker := NewChecker(s.storage, s.meta, Option{})
+	_, err := checker.Run(context.Background())
+	s.Error(err)
+}
+
+func (s *FsckSuite) TestParseMode() {
+	for _, mode := range []Mode{ModeReport, ModeQuarantine, ModeRepair} {
+		parsed, err := ParseMode(string(mode))
+		s.NoError(err)
+		s.Equal(mode, parsed)
+	}
+	_, err := ParseMode("delete")
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+}
+
+func TestFsck(t *testing.T) {
+	suite.Run(t, new(FsckSuite))
+}
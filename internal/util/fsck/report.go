@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsck
+
+import (
+	"sort"
+	"time"
+)
+
+// FileKind is the kind of a file managed by datacoord.
+type FileKind string
+
+const (
+	FileKindInsertLog FileKind = "insert_log"
+	FileKindDeltaLog  FileKind = "delta_log"
+	FileKindStatsLog  FileKind = "stats_log"
+	FileKindBM25Log   FileKind = "bm25_stats"
+	FileKindIndex     FileKind = "index"
+)
+
+// Summary counts what was checked and what was found.
+type Summary struct {
+	CheckedSegments       int `json:"checked_segments"`
+	SkippedSegments       int `json:"skipped_segments"`
+	CheckedSegmentIndexes int `json:"checked_segment_indexes"`
+	CheckedFiles          int `json:"checked_files"`
+	ScannedObjects        int `json:"scanned_objects"`
+
+	MissingFiles        int `json:"missing_files"`
+	SizeMismatches      int `json:"size_mismatches"`
+	OrphanObjects       int `json:"orphan_objects"`
+	UnreachableSegments int `json:"unreachable_segments"`
+
+	QuarantinedObjects int `json:"quarantined_objects"`
+	RestoredFiles      int `json:"restored_files"`
+}
+
+// MissingFile is a file referenced by metadata but absent in the object storage.
+type MissingFile struct {
+	Kind         FileKind `json:"kind"`
+	Path         string   `json:"path"`
+	CollectionID int64    `json:"collection_id"`
+	PartitionID  int64    `json:"partition_id"`
+	SegmentID    int64    `json:"segment_id"`
+	BuildID      int64    `json:"build_id,omitempty"`
+	// Restored is set when repair mode copied the file back from the quarantine.
+	Restored bool   `json:"restored,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SizeMismatch is a file whose object size differs from the size recorded in metadata.
+type SizeMismatch struct {
+	Kind         FileKind `json:"kind"`
+	Path         string   `json:"path"`
+	CollectionID int64    `json:"collection_id"`
+	PartitionID  int64    `json:"partition_id"`
+	SegmentID    int64    `json:"segment_id"`
+	ExpectedSize int64    `json:"expected_size"`
+	ActualSize   int64    `json:"actual_size"`
+}
+
+// OrphanObject is an object in the storage that no metadata refers to.
+type OrphanObject struct {
+	Kind       FileKind  `json:"kind"`
+	Path       string    `json:"path"`
+	SegmentID  int64     `json:"segment_id,omitempty"`
+	BuildID    int64     `json:"build_id,omitempty"`
+	Reason     string    `json:"reason"`
+	ModifyTime time.Time `json:"modify_time"`
+	// Quarantined is set when quarantine mode moved the object into the quarantine.
+	Quarantined bool   `json:"quarantined,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// UnreachableSegment is a live segment whose collection no longer exists in rootcoord.
+type UnreachableSegment struct {
+	CollectionID int64  `json:"collection_id"`
+	PartitionID  int64  `json:"partition_id"`
+	SegmentID    int64  `json:"segment_id"`
+	State        string `json:"state"`
+}
+
+// Report is the result of a check, it is designed to be dumped as json.
+type Report struct {
+	RootPath     string    `json:"root_path"`
+	Mode         Mode      `json:"mode"`
+	CollectionID int64     `json:"collection_id,omitempty"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Summary      Summary   `json:"summary"`
+
+	MissingFiles        []*MissingFile        `json:"missing_files"`
+	SizeMismatches      []*SizeMismatch       `json:"size_mismatches"`
+	OrphanObjects       []*OrphanObject       `json:"orphan_objects"`
+	UnreachableSegments []*UnreachableSegment `json:"unreachable_segments"`
+}
+
+// HasIssues returns true if any inconsistency is found.
+func (r *Report) HasIssues() bool {
+	return len(r.MissingFiles) > 0 || len(r.SizeMismatches) > 0 ||
+		len(r.OrphanObjects) > 0 || len(r.UnreachableSegments) > 0
+}
+
+func (r *Report) finish(endTime time.Time) {
+	sort.Slice(r.MissingFiles, func(i, j int) bool { return r.MissingFiles[i].Path < r.MissingFiles[j].Path })
+	sort.Slice(r.SizeMismatches, func(i, j int) bool { return r.SizeMismatches[i].Path < r.SizeMismatches[j].Path })
+	sort.Slice(r.OrphanObjects, func(i, j int) bool { return r.OrphanObjects[i].Path < r.OrphanObjects[j].Path })
+	sort.Slice(r.UnreachableSegments, func(i, j int) bool {
+		return r.UnreachableSegments[i].SegmentID < r.UnreachableSegments[j].SegmentID
+	})
+
+	r.Summary.MissingFiles = len(r.MissingFiles)
+	r.Summary.SizeMismatches = len(r.SizeMismatches)
+	r.Summary.OrphanObjects = len(r.OrphanObjects)
+	r.Summary.UnreachableSegments = len(r.UnreachableSegments)
+	r.EndTime = endTime
+}
@@ -0,0 +1,216 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrowipc
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// fileMagic is the magic bytes at the beginning of the arrow IPC file format (Feather V2),
+// the files without it are read as the arrow IPC streaming format.
+var fileMagic = []byte("ARROW1")
+
+type recordReader interface {
+	parquet.RecordBatchReader
+	Close()
+}
+
+type reader struct {
+	ctx    context.Context
+	cm     storage.ChunkManager
+	cmr    storage.FileReader
+	schema *schemapb.CollectionSchema
+
+	path string
+	rr   recordReader
+
+	fileSize   *atomic.Int64
+	bufferSize int
+	count      int64
+
+	frs map[int64]*parquet.FieldReader // fieldID -> FieldReader
+}
+
+func NewReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string, bufferSize int) (*reader, error) {
+	cmReader, err := cm.Reader(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	retryableReader := common.NewRetryableReader(ctx, path, cmReader)
+
+	rr, arrSchema, err := newRecordReader(ctx, retryableReader)
+	if err != nil {
+		retryableReader.Close()
+		return nil, merr.WrapErrImportSysFailedMsg("new arrow ipc reader failed, err=%v", err)
+	}
+
+	count, err := common.EstimateReadCountPerBatch(bufferSize, schema)
+	if err != nil {
+		rr.Close()
+		retryableReader.Close()
+		return nil, err
+	}
+
+	frs, err := parquet.CreateRecordFieldReaders(ctx, arrSchema, rr, schema)
+	if err != nil {
+		rr.Close()
+		retryableReader.Close()
+		return nil, err
+	}
+	return &reader{
+		ctx:        ctx,
+		cm:         cm,
+		cmr:        retryableReader,
+		schema:     schema,
+		path:       path,
+		rr:         rr,
+		fileSize:   atomic.NewInt64(0),
+		bufferSize: bufferSize,
+		count:      count,
+		frs:        frs,
+	}, nil
+}
+
+// newRecordReader opens the arrow IPC file format if the file starts with the magic bytes,
+// otherwise opens the arrow IPC streaming format.
+func newRecordReader(ctx context.Context, r storage.FileReader) (recordReader, *arrow.Schema, error) {
+	magic := make([]byte, len(fileMagic))
+	n, err := r.ReadAt(magic, 0)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if n == len(fileMagic) && bytes.Equal(magic, fileMagic) {
+		fr, err := ipc.NewFileReader(r, ipc.WithAllocator(memory.DefaultAllocator))
+		if err != nil {
+			return nil, nil, err
+		}
+		mlog.Info(ctx, "arrow ipc file info", mlog.Int("record batch num", fr.NumRecords()))
+		return &fileRecordReader{r: fr}, fr.Schema(), nil
+	}
+	sr, err := ipc.NewReader(r, ipc.WithAllocator(memory.DefaultAllocator))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &streamRecordReader{Reader: sr}, sr.Schema(), nil
+}
+
+// fileRecordReader reads the record batches of the arrow IPC file format in order.
+type fileRecordReader struct {
+	r      *ipc.FileReader
+	index  int
+	record arrow.Record
+	err    error
+}
+
+func (f *fileRecordReader) Next() bool {
+	if f.err != nil || f.index >= f.r.NumRecords() {
+		return false
+	}
+	f.record, f.err = f.r.Record(f.index)
+	if f.err != nil {
+		return false
+	}
+	f.index++
+	return true
+}
+
+func (f *fileRecordReader) Record() arrow.Record {
+	return f.record
+}
+
+func (f *fileRecordReader) Err() error {
+	return f.err
+}
+
+func (f *fileRecordReader) Close() {
+	f.r.Close()
+}
+
+// streamRecordReader reads the record batches of the arrow IPC streaming format.
+type streamRecordReader struct {
+	*ipc.Reader
+}
+
+func (s *streamRecordReader) Close() {
+	s.Release()
+}
+
+func (r *reader) Read() (*storage.InsertData, error) {
+	insertData, err := storage.NewInsertDataWithFunctionOutputField(r.schema)
+	if err != nil {
+		return nil, err
+	}
+OUTER:
+	for {
+		for fieldID, cr := range r.frs {
+			data, validData, err := cr.Next(r.count)
+			if err != nil {
+				return nil, err
+			}
+			if data == nil {
+				break OUTER
+			}
+			err = insertData.Data[fieldID].AppendRows(data, validData)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if insertData.GetMemorySize() >= r.bufferSize {
+			break
+		}
+	}
+	for fieldID := range r.frs {
+		if insertData.Data[fieldID].RowNum() == 0 {
+			return nil, io.EOF
+		}
+	}
+	common.RemoveUnpopulatedFunctionOutputFields(r.schema, insertData)
+	return insertData, nil
+}
+
+func (r *reader) Size() (int64, error) {
+	if size := r.fileSize.Load(); size != 0 {
+		return size, nil
+	}
+	size, err := r.cm.Size(r.ctx, r.path)
+	if err != nil {
+		return 0, err
+	}
+	r.fileSize.Store(size)
+	return size, nil
+}
+
+func (r *reader) Close() {
+	r.rr.Close()
+	if r.cmr != nil {
+		r.cmr.Close()
+	}
+}
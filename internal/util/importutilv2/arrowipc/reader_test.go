@@ -0,0 +1,319 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrowipc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
+	"github.com/milvus-io/milvus/internal/util/testutil"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+type ReaderSuite struct {
+	suite.Suite
+
+	numRows     int
+	batchRows   int
+	pkDataType  schemapb.DataType
+	vecDataType schemapb.DataType
+	streaming   bool
+	outputPath  string
+}
+
+func (s *ReaderSuite) SetupTest() {
+	// default suite params
+	s.numRows = 100
+	s.batchRows = 30
+	s.pkDataType = schemapb.DataType_Int64
+	s.vecDataType = schemapb.DataType_FloatVector
+	s.streaming = false
+	s.outputPath = s.T().TempDir()
+}
+
+// writeArrow writes the insert data as record batches of batchRows rows.
+func writeArrow(w io.WriteSeeker, schema *schemapb.CollectionSchema, numRows int, batchRows int, nullPercent int, streaming bool) (*storage.InsertData, error) {
+	useNullType := nullPercent == 100
+	arrSchema, err := parquet.ConvertToArrowSchemaForUT(schema, useNullType)
+	if err != nil {
+		return nil, err
+	}
+	insertData, err := testutil.CreateInsertData(schema, numRows, nullPercent)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := testutil.BuildArrayData(schema, insertData, useNullType)
+	if err != nil {
+		return nil, err
+	}
+	record := array.NewRecord(arrSchema, columns, int64(numRows))
+	defer record.Release()
+
+	var writer interface {
+		Write(rec arrow.Record) error
+		Close() error
+	}
+	if streaming {
+		writer = ipc.NewWriter(w, ipc.WithSchema(arrSchema))
+	} else {
+		writer, err = ipc.NewFileWriter(w, ipc.WithSchema(arrSchema))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for begin := 0; begin < numRows; begin += batchRows {
+		end := min(begin+batchRows, numRows)
+		batch := record.NewSlice(int64(begin), int64(end))
+		err = writer.Write(batch)
+		batch.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return insertData, writer.Close()
+}
+
+func (s *ReaderSuite) run(dataType schemapb.DataType, elemType schemapb.DataType, nullable bool, nullPercent int, bufferSize int) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     s.pkDataType,
+				TypeParams: []*commonpb.KeyValuePair{
+					{
+						Key:   "max_length",
+						Value: "256",
+					},
+				},
+			},
+			{
+				FieldID:  101,
+				Name:     "vec",
+				DataType: s.vecDataType,
+				TypeParams: []*commonpb.KeyValuePair{
+					{
+						Key:   common.DimKey,
+						Value: "8",
+					},
+				},
+			},
+			{
+				FieldID:     102,
+				Name:        dataType.String(),
+				DataType:    dataType,
+				ElementType: elemType,
+				TypeParams: []*commonpb.KeyValuePair{
+					{
+						Key:   "max_length",
+						Value: "256",
+					},
+					{
+						Key:   common.MaxCapacityKey,
+						Value: "256",
+					},
+				},
+				Nullable: nullable,
+			},
+		},
+	}
+
+	filePath := path.Join(s.outputPath, fmt.Sprintf("test_%d_reader.arrow", rand.Int()))
+	wf, err := os.Create(filePath)
+	s.NoError(err)
+	insertData, err := writeArrow(wf, schema, s.numRows, s.batchRows, nullPercent, s.streaming)
+	s.NoError(err)
+	s.NoError(wf.Close())
+
+	ctx := context.Background()
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath(s.outputPath))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	s.NoError(err)
+	reader, err := NewReader(ctx, cm, schema, filePath, bufferSize)
+	s.NoError(err)
+	s.NotNil(reader)
+	defer reader.Close()
+
+	size, err := reader.Size()
+	s.NoError(err)
+	s.True(size > int64(0))
+
+	offset := 0
+	for {
+		res, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		s.NoError(err)
+		rowNum := res.GetRowNum()
+		for fieldID, data := range res.Data {
+			s.Equal(rowNum, data.RowNum())
+			fieldDataType := typeutil.GetField(schema, fieldID).GetDataType()
+			for i := 0; i < rowNum; i++ {
+				expect := insertData.Data[fieldID].GetRow(i + offset)
+				actual := data.GetRow(i)
+				if msg, ok := expect.(proto.Message); ok {
+					s.True(proto.Equal(msg, actual.(proto.Message)))
+				} else if fieldDataType == schemapb.DataType_Geometry && expect != nil {
+					wkbValue, err := common.ConvertWKTToWKB(string(expect.([]byte)))
+					s.NoError(err)
+					s.Equal(wkbValue, actual.([]byte))
+				} else {
+					s.Equal(expect, actual)
+				}
+			}
+		}
+		offset += rowNum
+	}
+	s.Equal(s.numRows, offset)
+}
+
+func (s *ReaderSuite) TestReadScalarFields() {
+	elementTypes := []schemapb.DataType{
+		schemapb.DataType_Bool,
+		schemapb.DataType_Int8,
+		schemapb.DataType_Int16,
+		schemapb.DataType_Int32,
+		schemapb.DataType_Int64,
+		schemapb.DataType_Float,
+		schemapb.DataType_Double,
+		schemapb.DataType_String,
+	}
+
+	scalarTypes := append(elementTypes, []schemapb.DataType{schemapb.DataType_VarChar, schemapb.DataType_JSON, schemapb.DataType_Geometry, schemapb.DataType_Array}...)
+
+	for _, streaming := range []bool{false, true} {
+		s.streaming = streaming
+		for _, dataType := range scalarTypes {
+			if dataType == schemapb.DataType_Array {
+				for _, elementType := range elementTypes {
+					s.run(dataType, elementType, false, 0, 64*1024*1024)
+					for _, nullPercent := range []int{0, 50, 100} {
+						s.run(dataType, elementType, true, nullPercent, 64*1024*1024)
+					}
+				}
+			} else {
+				s.run(dataType, schemapb.DataType_None, false, 0, 64*1024*1024)
+				for _, nullPercent := range []int{0, 50, 100} {
+					s.run(dataType, schemapb.DataType_None, true, nullPercent, 64*1024*1024)
+				}
+			}
+		}
+	}
+}
+
+func (s *ReaderSuite) TestVector() {
+	dataTypes := []schemapb.DataType{
+		schemapb.DataType_BinaryVector,
+		schemapb.DataType_FloatVector,
+		schemapb.DataType_Float16Vector,
+		schemapb.DataType_BFloat16Vector,
+		schemapb.DataType_SparseFloatVector,
+		schemapb.DataType_Int8Vector,
+	}
+
+	for _, dataType := range dataTypes {
+		s.vecDataType = dataType
+		s.run(schemapb.DataType_Int32, schemapb.DataType_None, false, 0, 64*1024*1024)
+		s.run(schemapb.DataType_Array, schemapb.DataType_Int64, true, 50, 64*1024*1024)
+	}
+}
+
+func (s *ReaderSuite) TestReadAcrossRecordBatches() {
+	// a small buffer reads a few rows each time, the record batches are split and merged
+	for _, streaming := range []bool{false, true} {
+		s.streaming = streaming
+		s.run(schemapb.DataType_VarChar, schemapb.DataType_None, true, 50, 1024)
+		s.run(schemapb.DataType_Array, schemapb.DataType_Float, true, 50, 1024)
+	}
+}
+
+func TestArrowIPCReader(t *testing.T) {
+	suite.Run(t, new(ReaderSuite))
+}
+
+func TestArrowIPCReaderError(t *testing.T) {
+	ctx := context.Background()
+	outputPath := t.TempDir()
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath(outputPath))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			},
+			{
+				FieldID:  101,
+				Name:     "vec",
+				DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{
+						Key:   common.DimKey,
+						Value: "8",
+					},
+				},
+			},
+		},
+	}
+
+	// not an arrow file
+	filePath := path.Join(outputPath, "invalid.arrow")
+	assert.NoError(t, os.WriteFile(filePath, []byte("not an arrow file"), 0o600))
+	_, err = NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	assert.Error(t, err)
+
+	// the file doesn't exist
+	_, err = NewReader(ctx, cm, schema, path.Join(outputPath, "dummy.arrow"), 64*1024*1024)
+	assert.Error(t, err)
+
+	// field type mismatch
+	wrongSchema := proto.Clone(schema).(*schemapb.CollectionSchema)
+	wrongSchema.Fields[1].DataType = schemapb.DataType_Int64
+	wrongSchema.Fields[1].TypeParams = nil
+	filePath = path.Join(outputPath, "mismatch.arrow")
+	wf, err := os.Create(filePath)
+	assert.NoError(t, err)
+	_, err = writeArrow(wf, wrongSchema, 10, 10, 0, false)
+	assert.NoError(t, err)
+	assert.NoError(t, wf.Close())
+	_, err = NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	assert.Error(t, err)
+}
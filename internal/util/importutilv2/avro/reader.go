@@ -0,0 +1,241 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+type reader struct {
+	ctx    context.Context
+	cm     storage.ChunkManager
+	cmr    storage.FileReader
+	schema *schemapb.CollectionSchema
+
+	path string
+	rr   *recordReader
+
+	fileSize   *atomic.Int64
+	bufferSize int
+	count      int64
+
+	frs map[int64]*parquet.FieldReader // fieldID -> FieldReader
+}
+
+func NewReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string, bufferSize int) (*reader, error) {
+	cmReader, err := cm.Reader(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	retryableReader := common.NewRetryableReader(ctx, path, cmReader)
+
+	dec, err := ocf.NewDecoder(retryableReader)
+	if err != nil {
+		retryableReader.Close()
+		return nil, merr.WrapErrImportSysFailedMsg("new avro reader failed, err=%v", err)
+	}
+	recordSchema, ok := dec.Schema().(*avro.RecordSchema)
+	if !ok {
+		retryableReader.Close()
+		return nil, merr.WrapErrImportFailedMsg("the schema of avro file must be a record, but got '%s'", dec.Schema().Type())
+	}
+	arrSchema, columns, dynamicColumns, err := convertToArrowSchema(recordSchema, schema)
+	if err != nil {
+		retryableReader.Close()
+		return nil, err
+	}
+
+	count, err := common.EstimateReadCountPerBatch(bufferSize, schema)
+	if err != nil {
+		retryableReader.Close()
+		return nil, err
+	}
+
+	rr := &recordReader{
+		dec:            dec,
+		builder:        array.NewRecordBuilder(memory.DefaultAllocator, arrSchema),
+		columns:        columns,
+		dynamicColumns: dynamicColumns,
+		count:          count,
+	}
+	frs, err := parquet.CreateRecordFieldReaders(ctx, arrSchema, rr, schema)
+	if err != nil {
+		rr.Close()
+		retryableReader.Close()
+		return nil, err
+	}
+	return &reader{
+		ctx:        ctx,
+		cm:         cm,
+		cmr:        retryableReader,
+		schema:     schema,
+		path:       path,
+		rr:         rr,
+		fileSize:   atomic.NewInt64(0),
+		bufferSize: bufferSize,
+		count:      count,
+		frs:        frs,
+	}, nil
+}
+
+// recordReader decodes the rows of the avro file into arrow record batches of count rows.
+type recordReader struct {
+	dec            *ocf.Decoder
+	builder        *array.RecordBuilder
+	columns        []*column
+	dynamicColumns []*column // the avro fields stored in the dynamic field
+	count          int64
+
+	record arrow.Record
+	err    error
+}
+
+func (r *recordReader) Next() bool {
+	if r.record != nil {
+		r.record.Release()
+		r.record = nil
+	}
+	if r.err != nil {
+		return false
+	}
+	rows := int64(0)
+	for rows < r.count && r.dec.HasNext() {
+		var row map[string]any
+		if err := r.dec.Decode(&row); err != nil {
+			r.err = err
+			return false
+		}
+		if err := r.appendRow(row); err != nil {
+			r.err = err
+			return false
+		}
+		rows++
+	}
+	if err := r.dec.Error(); err != nil {
+		r.err = err
+		return false
+	}
+	if rows == 0 {
+		return false
+	}
+	r.record = r.builder.NewRecord()
+	return true
+}
+
+func (r *recordReader) appendRow(row map[string]any) error {
+	for i, col := range r.columns {
+		value := normalizeValue(col.schema, row[col.name])
+		if err := appendValue(r.builder.Field(i), value); err != nil {
+			return fmt.Errorf("failed to convert value of field '%s': %w", col.name, err)
+		}
+	}
+	if len(r.dynamicColumns) > 0 {
+		dynamicValues := make(map[string]any, len(r.dynamicColumns))
+		for _, col := range r.dynamicColumns {
+			if value := normalizeValue(col.schema, row[col.name]); value != nil {
+				dynamicValues[col.name] = value
+			}
+		}
+		if err := appendValue(r.builder.Field(len(r.columns)), dynamicValues); err != nil {
+			return fmt.Errorf("failed to convert value of dynamic field: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *recordReader) Record() arrow.Record {
+	return r.record
+}
+
+func (r *recordReader) Err() error {
+	return r.err
+}
+
+func (r *recordReader) Close() {
+	if r.record != nil {
+		r.record.Release()
+		r.record = nil
+	}
+	r.builder.Release()
+}
+
+func (r *reader) Read() (*storage.InsertData, error) {
+	insertData, err := storage.NewInsertDataWithFunctionOutputField(r.schema)
+	if err != nil {
+		return nil, err
+	}
+OUTER:
+	for {
+		for fieldID, cr := range r.frs {
+			data, validData, err := cr.Next(r.count)
+			if err != nil {
+				return nil, err
+			}
+			if data == nil {
+				break OUTER
+			}
+			err = insertData.Data[fieldID].AppendRows(data, validData)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if insertData.GetMemorySize() >= r.bufferSize {
+			break
+		}
+	}
+	for fieldID := range r.frs {
+		if insertData.Data[fieldID].RowNum() == 0 {
+			return nil, io.EOF
+		}
+	}
+	common.RemoveUnpopulatedFunctionOutputFields(r.schema, insertData)
+	return insertData, nil
+}
+
+func (r *reader) Size() (int64, error) {
+	if size := r.fileSize.Load(); size != 0 {
+		return size, nil
+	}
+	size, err := r.cm.Size(r.ctx, r.path)
+	if err != nil {
+		return 0, err
+	}
+	r.fileSize.Store(size)
+	return size, nil
+}
+
+func (r *reader) Close() {
+	r.rr.Close()
+	if r.cmr != nil {
+		r.cmr.Close()
+	}
+}
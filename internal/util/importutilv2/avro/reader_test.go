@@ -0,0 +1,262 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "Row",
+	"fields": [
+		{"name": "pk", "type": "long"},
+		{"name": "varchar", "type": "string"},
+		{"name": "nullable_int", "type": ["null", "int"]},
+		{"name": "float_vec", "type": {"type": "array", "items": "float"}},
+		{"name": "binary_vec", "type": {"type": "fixed", "name": "BinaryVector", "size": 4}},
+		{"name": "fp16_vec", "type": {"type": "array", "items": "float"}},
+		{"name": "sparse_vec", "type": {"type": "map", "values": "float"}},
+		{"name": "json", "type": ["null", {"type": "record", "name": "Doc", "fields": [{"name": "key", "type": "string"}]}]},
+		{"name": "array", "type": {"type": "array", "items": "long"}},
+		{"name": "struct_array", "type": {"type": "array", "items": {"type": "record", "name": "Element", "fields": [
+			{"name": "num", "type": "int"},
+			{"name": "vec", "type": {"type": "array", "items": "float"}}
+		]}}},
+		{"name": "extra", "type": "string"}
+	]
+}`
+
+func createTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		EnableDynamicField: true,
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{
+				FieldID: 101, Name: "varchar", DataType: schemapb.DataType_VarChar,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "64"}},
+			},
+			{FieldID: 102, Name: "nullable_int", DataType: schemapb.DataType_Int32, Nullable: true},
+			{
+				FieldID: 103, Name: "float_vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "4"}},
+			},
+			{
+				FieldID: 104, Name: "binary_vec", DataType: schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "32"}},
+			},
+			{
+				FieldID: 105, Name: "fp16_vec", DataType: schemapb.DataType_Float16Vector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "4"}},
+			},
+			{FieldID: 106, Name: "sparse_vec", DataType: schemapb.DataType_SparseFloatVector},
+			{FieldID: 107, Name: "json", DataType: schemapb.DataType_JSON, Nullable: true},
+			{
+				FieldID: 108, Name: "array", DataType: schemapb.DataType_Array, ElementType: schemapb.DataType_Int64,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxCapacityKey, Value: "16"}},
+			},
+			{FieldID: 109, Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+		},
+		StructArrayFields: []*schemapb.StructArrayFieldSchema{
+			{
+				FieldID: 200,
+				Name:    "struct_array",
+				Fields: []*schemapb.FieldSchema{
+					{
+						FieldID: 201, Name: "struct_array[num]", DataType: schemapb.DataType_Array, ElementType: schemapb.DataType_Int32,
+						TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxCapacityKey, Value: "16"}},
+					},
+					{
+						FieldID: 202, Name: "struct_array[vec]", DataType: schemapb.DataType_ArrayOfVector, ElementType: schemapb.DataType_FloatVector,
+						TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}, {Key: common.MaxCapacityKey, Value: "16"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createTestRow(i int) map[string]any {
+	var nullableInt any
+	if i%2 == 0 {
+		nullableInt = int32(i)
+	}
+	var doc any
+	if i%3 != 0 {
+		doc = map[string]any{"Doc": map[string]any{"key": fmt.Sprintf("value_%d", i)}}
+	}
+	return map[string]any{
+		"pk":           int64(i),
+		"varchar":      fmt.Sprintf("varchar_%d", i),
+		"nullable_int": nullableInt,
+		"float_vec":    []float32{float32(i), 1, 2, 3},
+		"binary_vec":   [4]byte{byte(i), 1, 2, 3},
+		"fp16_vec":     []float32{0.5, 1, 1.5, float32(i % 8)},
+		"sparse_vec":   map[string]float32{fmt.Sprint(i): 0.5},
+		"json":         doc,
+		"array":        []int64{int64(i), int64(i + 1)},
+		"struct_array": []map[string]any{
+			{"num": int32(i), "vec": []float32{1, 2}},
+			{"num": int32(0), "vec": []float32{3, 4}},
+		},
+		"extra": fmt.Sprintf("extra_%d", i),
+	}
+}
+
+func writeAvro(t *testing.T, filePath string, numRows int) {
+	f, err := os.Create(filePath)
+	assert.NoError(t, err)
+	defer f.Close()
+	enc, err := ocf.NewEncoder(testAvroSchema, f)
+	assert.NoError(t, err)
+	for i := 0; i < numRows; i++ {
+		assert.NoError(t, enc.Encode(createTestRow(i)))
+	}
+	assert.NoError(t, enc.Close())
+}
+
+func TestAvroReader(t *testing.T) {
+	ctx := context.Background()
+	outputPath := t.TempDir()
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath(outputPath))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+
+	schema := createTestSchema()
+	numRows := 100
+	filePath := path.Join(outputPath, "test_reader.avro")
+	writeAvro(t, filePath, numRows)
+
+	for _, bufferSize := range []int{64 * 1024 * 1024, 4096} {
+		reader, err := NewReader(ctx, cm, schema, filePath, bufferSize)
+		assert.NoError(t, err)
+
+		size, err := reader.Size()
+		assert.NoError(t, err)
+		assert.True(t, size > 0)
+
+		offset := 0
+		for {
+			insertData, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			rowNum := insertData.GetRowNum()
+			for j := 0; j < rowNum; j++ {
+				i := offset + j
+				assert.Equal(t, int64(i), insertData.Data[100].GetRow(j))
+				assert.Equal(t, fmt.Sprintf("varchar_%d", i), insertData.Data[101].GetRow(j))
+				if i%2 == 0 {
+					assert.Equal(t, int32(i), insertData.Data[102].GetRow(j))
+				} else {
+					assert.Nil(t, insertData.Data[102].GetRow(j))
+				}
+				assert.Equal(t, []float32{float32(i), 1, 2, 3}, insertData.Data[103].GetRow(j))
+				assert.Equal(t, []byte{byte(i), 1, 2, 3}, insertData.Data[104].GetRow(j))
+				assert.Equal(t, typeutil.Float32ArrayToFloat16Bytes([]float32{0.5, 1, 1.5, float32(i % 8)}), insertData.Data[105].GetRow(j))
+				assert.Equal(t, typeutil.CreateSparseFloatRow([]uint32{uint32(i)}, []float32{0.5}), insertData.Data[106].GetRow(j))
+				if i%3 != 0 {
+					assert.Equal(t, []byte(fmt.Sprintf(`{"key":"value_%d"}`, i)), insertData.Data[107].GetRow(j))
+				} else {
+					assert.Nil(t, insertData.Data[107].GetRow(j))
+				}
+				assert.Equal(t, []int64{int64(i), int64(i + 1)},
+					insertData.Data[108].GetRow(j).(*schemapb.ScalarField).GetLongData().GetData())
+
+				var dynamic map[string]any
+				assert.NoError(t, json.Unmarshal(insertData.Data[109].GetRow(j).([]byte), &dynamic))
+				assert.Equal(t, map[string]any{"extra": fmt.Sprintf("extra_%d", i)}, dynamic)
+
+				assert.Equal(t, []int32{int32(i), 0},
+					insertData.Data[201].GetRow(j).(*schemapb.ScalarField).GetIntData().GetData())
+				assert.Equal(t, []float32{1, 2, 3, 4},
+					insertData.Data[202].GetRow(j).(*schemapb.VectorField).GetFloatVector().GetData())
+			}
+			offset += rowNum
+		}
+		assert.Equal(t, numRows, offset)
+		reader.Close()
+	}
+}
+
+func TestAvroReaderError(t *testing.T) {
+	ctx := context.Background()
+	outputPath := t.TempDir()
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath(outputPath))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+
+	schema := createTestSchema()
+
+	// the file doesn't exist
+	_, err = NewReader(ctx, cm, schema, path.Join(outputPath, "dummy.avro"), 1024)
+	assert.Error(t, err)
+
+	// not an avro file
+	filePath := path.Join(outputPath, "invalid.avro")
+	assert.NoError(t, os.WriteFile(filePath, []byte("not an avro file"), 0o600))
+	_, err = NewReader(ctx, cm, schema, filePath, 1024)
+	assert.Error(t, err)
+
+	// the schema of the file is not a record
+	filePath = path.Join(outputPath, "not_record.avro")
+	wf, err := os.Create(filePath)
+	assert.NoError(t, err)
+	enc, err := ocf.NewEncoder(`"long"`, wf)
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Encode(int64(1)))
+	assert.NoError(t, enc.Close())
+	assert.NoError(t, wf.Close())
+	_, err = NewReader(ctx, cm, schema, filePath, 1024)
+	assert.Error(t, err)
+
+	// the value is out of the range of the field
+	filePath = path.Join(outputPath, "test_reader.avro")
+	writeAvro(t, filePath, 200)
+	int8Schema := createTestSchema()
+	int8Schema.Fields[2].DataType = schemapb.DataType_Int8
+	reader, err := NewReader(ctx, cm, int8Schema, filePath, 64*1024*1024)
+	assert.NoError(t, err)
+	_, err = reader.Read()
+	assert.Error(t, err)
+	reader.Close()
+
+	// the required field is not provided
+	missingSchema := createTestSchema()
+	missingSchema.Fields = append(missingSchema.Fields, &schemapb.FieldSchema{
+		FieldID: 110, Name: "missing", DataType: schemapb.DataType_Int64,
+	})
+	_, err = NewReader(ctx, cm, missingSchema, filePath, 1024)
+	assert.Error(t, err)
+}
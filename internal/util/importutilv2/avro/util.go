@@ -0,0 +1,356 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/hamba/avro/v2"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// column maps a field of the avro record to a column of the arrow record.
+type column struct {
+	name   string
+	schema avro.Schema
+}
+
+// convertToArrowSchema maps the fields of the avro record to the arrow columns expected by the field readers.
+// The avro fields not in the collection schema are stored in the dynamic field if it is enabled, otherwise ignored.
+func convertToArrowSchema(recordSchema *avro.RecordSchema, schema *schemapb.CollectionSchema) (*arrow.Schema, []*column, []*column, error) {
+	nameToField := lo.KeyBy(schema.GetFields(), func(field *schemapb.FieldSchema) string {
+		return field.GetName()
+	})
+	nameToStructField := lo.KeyBy(schema.GetStructArrayFields(), func(field *schemapb.StructArrayFieldSchema) string {
+		return field.GetName()
+	})
+	dynamicField := typeutil.GetDynamicField(schema)
+
+	arrFields := make([]arrow.Field, 0, len(recordSchema.Fields()))
+	columns := make([]*column, 0, len(recordSchema.Fields()))
+	dynamicColumns := make([]*column, 0)
+	for _, avroField := range recordSchema.Fields() {
+		col := &column{name: avroField.Name(), schema: avroField.Type()}
+		var arrDataType arrow.DataType
+		var err error
+		if field, ok := nameToField[avroField.Name()]; ok && !field.GetIsDynamic() {
+			arrDataType, err = parquet.ConvertToArrowDataType(field)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			// fp16/bf16 vectors can be provided as float arrays or raw bytes
+			if (field.GetDataType() == schemapb.DataType_Float16Vector || field.GetDataType() == schemapb.DataType_BFloat16Vector) &&
+				nonNullSchema(avroField.Type()).Type() == avro.Array {
+				arrDataType = arrow.ListOf(arrow.PrimitiveTypes.Float32)
+			}
+		} else if structField, ok := nameToStructField[avroField.Name()]; ok {
+			arrDataType, err = parquet.ConvertStructArrayToArrowDataType(structField)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		} else {
+			if dynamicField != nil {
+				dynamicColumns = append(dynamicColumns, col)
+			}
+			continue
+		}
+		arrFields = append(arrFields, arrow.Field{
+			Name:     avroField.Name(),
+			Type:     arrDataType,
+			Nullable: true,
+		})
+		columns = append(columns, col)
+	}
+	if len(dynamicColumns) > 0 {
+		arrFields = append(arrFields, arrow.Field{
+			Name:     dynamicField.GetName(),
+			Type:     arrow.BinaryTypes.String,
+			Nullable: true,
+		})
+	}
+	return arrow.NewSchema(arrFields, nil), columns, dynamicColumns, nil
+}
+
+// nonNullSchema returns the first non-null type of the union, or the schema itself if it is not a union.
+func nonNullSchema(schema avro.Schema) avro.Schema {
+	schema = derefSchema(schema)
+	union, ok := schema.(*avro.UnionSchema)
+	if !ok {
+		return schema
+	}
+	for _, t := range union.Types() {
+		if t.Type() != avro.Null {
+			return derefSchema(t)
+		}
+	}
+	return schema
+}
+
+func derefSchema(schema avro.Schema) avro.Schema {
+	if ref, ok := schema.(*avro.RefSchema); ok {
+		return ref.Schema()
+	}
+	return schema
+}
+
+// schemaTypeName returns the name of the type which is used as the key
+// by the avro decoder to wrap the value of a union.
+func schemaTypeName(schema avro.Schema) string {
+	schema = derefSchema(schema)
+	if named, ok := schema.(avro.NamedSchema); ok {
+		return named.FullName()
+	}
+	name := string(schema.Type())
+	if ls, ok := schema.(avro.LogicalTypeSchema); ok && ls.Logical() != nil {
+		name += "." + string(ls.Logical().Type())
+	}
+	return name
+}
+
+// normalizeValue converts the decoded avro value to plain go values, the unions are unwrapped,
+// records and maps become map[string]any, arrays become []any and fixed becomes []byte.
+func normalizeValue(schema avro.Schema, value any) any {
+	if value == nil {
+		return nil
+	}
+	schema = derefSchema(schema)
+	switch s := schema.(type) {
+	case *avro.UnionSchema:
+		var member avro.Schema
+		if wrapped, ok := value.(map[string]any); ok && len(wrapped) == 1 {
+			for _, t := range s.Types() {
+				if inner, ok := wrapped[schemaTypeName(t)]; ok && t.Type() != avro.Null {
+					member, value = t, inner
+					break
+				}
+			}
+		}
+		if member == nil {
+			member = nonNullSchema(s)
+		}
+		return normalizeValue(member, value)
+	case *avro.ArraySchema:
+		items, ok := value.([]any)
+		if !ok {
+			return value
+		}
+		result := make([]any, 0, len(items))
+		for _, item := range items {
+			result = append(result, normalizeValue(s.Items(), item))
+		}
+		return result
+	case *avro.MapSchema:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		result := make(map[string]any, len(values))
+		for k, v := range values {
+			result[k] = normalizeValue(s.Values(), v)
+		}
+		return result
+	case *avro.RecordSchema:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		result := make(map[string]any, len(values))
+		for _, f := range s.Fields() {
+			result[f.Name()] = normalizeValue(f.Type(), values[f.Name()])
+		}
+		return result
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(bytes), rv)
+			return bytes
+		}
+		return value
+	}
+}
+
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f, true
+	default:
+		i, ok := toInt64(value)
+		return float64(i), ok
+	}
+}
+
+func toInt64InRange(value any, minValue, maxValue int64) (int64, error) {
+	i, ok := toInt64(value)
+	if !ok {
+		return 0, fmt.Errorf("expect integer value, but got %T", value)
+	}
+	if i < minValue || i > maxValue {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", i, minValue, maxValue)
+	}
+	return i, nil
+}
+
+func toString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		// JSON field, sparse vector and dynamic field can be provided as avro map or record
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	}
+}
+
+// appendValue appends the normalized avro value to the arrow builder.
+func appendValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expect boolean value, but got %T", value)
+		}
+		b.Append(v)
+	case *array.Int8Builder:
+		v, err := toInt64InRange(value, math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return err
+		}
+		b.Append(int8(v))
+	case *array.Int16Builder:
+		v, err := toInt64InRange(value, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return err
+		}
+		b.Append(int16(v))
+	case *array.Int32Builder:
+		v, err := toInt64InRange(value, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		b.Append(int32(v))
+	case *array.Int64Builder:
+		v, err := toInt64InRange(value, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.Uint8Builder:
+		v, err := toInt64InRange(value, 0, math.MaxUint8)
+		if err != nil {
+			return err
+		}
+		b.Append(uint8(v))
+	case *array.Float32Builder:
+		v, ok := toFloat64(value)
+		if !ok {
+			return fmt.Errorf("expect numeric value, but got %T", value)
+		}
+		b.Append(float32(v))
+	case *array.Float64Builder:
+		v, ok := toFloat64(value)
+		if !ok {
+			return fmt.Errorf("expect numeric value, but got %T", value)
+		}
+		b.Append(v)
+	case *array.StringBuilder:
+		v, err := toString(value)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.ListBuilder:
+		b.Append(true)
+		switch v := value.(type) {
+		case []byte:
+			// binary vector, fp16/bf16 vector in bytes
+			for _, e := range v {
+				if err := appendValue(b.ValueBuilder(), int64(e)); err != nil {
+					return err
+				}
+			}
+		case []any:
+			for _, e := range v {
+				if err := appendValue(b.ValueBuilder(), e); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("expect array value, but got %T", value)
+		}
+	case *array.StructBuilder:
+		v, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expect record value, but got %T", value)
+		}
+		b.Append(true)
+		structType := b.Type().(*arrow.StructType)
+		for i, f := range structType.Fields() {
+			if err := appendValue(b.FieldBuilder(i), v[f.Name]); err != nil {
+				return fmt.Errorf("struct field '%s': %w", f.Name, err)
+			}
+		}
+	default:
+		return merr.WrapErrImportFailedMsg("unsupported arrow type %s", builder.Type().String())
+	}
+	return nil
+}
@@ -38,9 +38,16 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
+// ColumnReader reads the arrow arrays of a column batch by batch, it's implemented by
+// the parquet column reader and the column readers of the record batch based formats.
+type ColumnReader interface {
+	Field() *arrow.Field
+	NextBatch(batchSize int64) (*arrow.Chunked, error)
+}
+
 type FieldReader struct {
 	columnIndex  int
-	columnReader *pqarrow.ColumnReader
+	columnReader ColumnReader
 
 	dim            int
 	field          *schemapb.FieldSchema
@@ -58,7 +65,11 @@ func NewFieldReader(ctx context.Context, reader *pqarrow.FileReader, columnIndex
 	if err != nil {
 		return nil, err
 	}
+	return newFieldReader(columnReader, columnIndex, field, timezone)
+}
 
+func newFieldReader(columnReader ColumnReader, columnIndex int, field *schemapb.FieldSchema, timezone string) (*FieldReader, error) {
+	var err error
 	var dim int64 = 1
 	if typeutil.IsVectorType(field.GetDataType()) && !typeutil.IsSparseFloatVectorType(field.GetDataType()) {
 		dim, err = typeutil.GetDim(field)
@@ -0,0 +1,145 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// RecordBatchReader reads arrow record batches one by one, such as the arrow IPC readers.
+// The record returned by Record is only valid until the next call of Next.
+type RecordBatchReader interface {
+	Next() bool
+	Record() arrow.Record
+	Err() error
+}
+
+// CreateRecordFieldReaders creates the field readers of the columns of the record batches.
+// The record batches are read once and shared by the field readers, the columns of a record
+// batch are buffered until all the field readers have consumed them.
+func CreateRecordFieldReaders(ctx context.Context, arrSchema *arrow.Schema, rr RecordBatchReader,
+	schema *schemapb.CollectionSchema,
+) (map[int64]*FieldReader, error) {
+	source := &recordSource{reader: rr}
+	return createFieldReaders(ctx, arrSchema, func(columnIndex int) (ColumnReader, error) {
+		field := arrSchema.Field(columnIndex)
+		cr := &recordColumnReader{
+			source:      source,
+			columnIndex: columnIndex,
+			field:       &field,
+		}
+		source.columns = append(source.columns, cr)
+		return cr, nil
+	}, schema)
+}
+
+type recordSource struct {
+	reader  RecordBatchReader
+	columns []*recordColumnReader
+	eof     bool
+}
+
+// readNext reads the next record batch into the buffers of all the columns,
+// it returns false if there is no more record batch.
+func (s *recordSource) readNext() (bool, error) {
+	if s.eof {
+		return false, nil
+	}
+	for s.reader.Next() {
+		record := s.reader.Record()
+		if record.NumRows() == 0 {
+			continue
+		}
+		for _, cr := range s.columns {
+			column := record.Column(cr.columnIndex)
+			column.Retain()
+			cr.pending = append(cr.pending, column)
+		}
+		return true, nil
+	}
+	s.eof = true
+	if err := s.reader.Err(); err != nil {
+		return false, merr.WrapErrImportFailedMsg("read record batch failed, err=%v", err)
+	}
+	return false, nil
+}
+
+// recordColumnReader reads a column of the record batches of a recordSource.
+type recordColumnReader struct {
+	source      *recordSource
+	columnIndex int
+	field       *arrow.Field
+
+	pending []arrow.Array // buffered arrays of the column not consumed yet
+	offset  int64         // consumed rows of pending[0]
+}
+
+func (c *recordColumnReader) Field() *arrow.Field {
+	return c.field
+}
+
+func (c *recordColumnReader) NextBatch(batchSize int64) (*arrow.Chunked, error) {
+	chunks := make([]arrow.Array, 0, 1)
+	defer func() {
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}()
+	for batchSize > 0 {
+		if len(c.pending) == 0 {
+			ok, err := c.source.readNext()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			continue
+		}
+		head := c.pending[0]
+		n := min(int64(head.Len())-c.offset, batchSize)
+		if c.offset == 0 && n == int64(head.Len()) {
+			head.Retain()
+			chunks = append(chunks, head)
+		} else {
+			// the field readers access the buffers of the arrays directly,
+			// so the part of the array is copied rather than sliced
+			slice := array.NewSlice(head, c.offset, c.offset+n)
+			chunk, err := array.Concatenate([]arrow.Array{slice}, memory.DefaultAllocator)
+			slice.Release()
+			if err != nil {
+				return nil, merr.WrapErrImportFailedMsg("read column '%s' failed, err=%v", c.field.Name, err)
+			}
+			chunks = append(chunks, chunk)
+		}
+		c.offset += n
+		batchSize -= n
+		if c.offset == int64(head.Len()) {
+			head.Release()
+			c.pending = c.pending[1:]
+			c.offset = 0
+		}
+	}
+	return arrow.NewChunked(c.field.Type, chunks), nil
+}
@@ -33,7 +33,7 @@ import (
 
 // StructFieldReader reads a specific field from a list<struct> column
 type StructFieldReader struct {
-	columnReader *pqarrow.ColumnReader
+	columnReader ColumnReader
 	field        *schemapb.FieldSchema
 	fieldIndex   int
 	dim          int
@@ -47,7 +47,10 @@ func NewStructFieldReader(ctx context.Context, fileReader *pqarrow.FileReader, c
 	if err != nil {
 		return nil, err
 	}
+	return newStructFieldReader(columnReader, columnIndex, fieldIndex, field)
+}
 
+func newStructFieldReader(columnReader ColumnReader, columnIndex int, fieldIndex int, field *schemapb.FieldSchema) (*FieldReader, error) {
 	dim := 0
 	if typeutil.IsVectorType(field.GetDataType()) && !typeutil.IsSparseFloatVectorType(field.GetDataType()) {
 		d, err := typeutil.GetDim(field)
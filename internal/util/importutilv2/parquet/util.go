@@ -66,17 +66,26 @@ func WrapNullElementErr(field *schemapb.FieldSchema) error {
 }
 
 func CreateFieldReaders(ctx context.Context, fileReader *pqarrow.FileReader, schema *schemapb.CollectionSchema) (map[int64]*FieldReader, error) {
+	pqSchema, err := fileReader.Schema()
+	if err != nil {
+		return nil, merr.WrapErrImportFailedMsg("get parquet schema failed, err=%v", err)
+	}
+	return createFieldReaders(ctx, pqSchema, func(columnIndex int) (ColumnReader, error) {
+		return fileReader.GetColumn(ctx, columnIndex)
+	}, schema)
+}
+
+// createFieldReaders creates the field readers of the columns of the arrow schema,
+// newColumnReader is called once for each field reader to read the column at the index.
+func createFieldReaders(ctx context.Context, pqSchema *arrow.Schema, newColumnReader func(columnIndex int) (ColumnReader, error),
+	schema *schemapb.CollectionSchema,
+) (map[int64]*FieldReader, error) {
 	// Create map for all fields including sub-fields from StructArrayFields
 	allFields := typeutil.GetAllFieldSchemas(schema)
 	nameToField := lo.KeyBy(allFields, func(field *schemapb.FieldSchema) string {
 		return field.GetName()
 	})
 
-	pqSchema, err := fileReader.Schema()
-	if err != nil {
-		return nil, merr.WrapErrImportFailedMsg("get parquet schema failed, err=%v", err)
-	}
-
 	if err := rejectFlatStructSubFieldColumns(schema, pqSchema); err != nil {
 		return nil, err
 	}
@@ -118,7 +127,7 @@ func CreateFieldReaders(ctx context.Context, fileReader *pqarrow.FileReader, sch
 	}
 
 	// Original flat format handling
-	err = isSchemaEqual(schema, pqSchema)
+	err := isSchemaEqual(schema, pqSchema)
 	if err != nil {
 		return nil, merr.WrapErrImportFailedMsg("schema not equal, err=%v", err)
 	}
@@ -156,7 +165,11 @@ func CreateFieldReaders(ctx context.Context, fileReader *pqarrow.FileReader, sch
 						"set collection property '%s' to enable", field.GetName(), common.CollectionAllowInsertNonBM25FunctionOutputs))
 			}
 		}
-		cr, err := NewFieldReader(ctx, fileReader, i, field, common2.GetSchemaTimezone(schema))
+		columnReader, err := newColumnReader(i)
+		if err != nil {
+			return nil, err
+		}
+		cr, err := newFieldReader(columnReader, i, field, common2.GetSchemaTimezone(schema))
 		if err != nil {
 			return nil, err
 		}
@@ -201,7 +214,11 @@ func CreateFieldReaders(ctx context.Context, fileReader *pqarrow.FileReader, sch
 			}
 
 			// Create struct field reader
-			reader, err := NewStructFieldReader(ctx, fileReader, columnIndex, fieldIndex, subField)
+			columnReader, err := newColumnReader(columnIndex)
+			if err != nil {
+				return nil, err
+			}
+			reader, err := newStructFieldReader(columnReader, columnIndex, fieldIndex, subField)
 			if err != nil {
 				return nil, err
 			}
@@ -485,6 +502,46 @@ func convertToArrowDataType(field *schemapb.FieldSchema, isArray bool) (arrow.Da
 
 // This method is used only by import util and related tests. Returned arrow.Schema
 // doesn't include function output fields.
+// ConvertToArrowDataType returns the arrow data type expected by the field reader of the field.
+func ConvertToArrowDataType(field *schemapb.FieldSchema) (arrow.DataType, error) {
+	return convertToArrowDataType(field, false)
+}
+
+// ConvertStructArrayToArrowDataType returns the list<struct> arrow data type expected by
+// the field readers of the sub-fields of the struct array field.
+func ConvertStructArrayToArrowDataType(structField *schemapb.StructArrayFieldSchema) (arrow.DataType, error) {
+	// Build struct fields for row-wise format
+	structFields := make([]arrow.Field, 0, len(structField.Fields))
+	for _, subField := range structField.Fields {
+		fieldName, err := typeutil.ExtractStructFieldName(subField.Name)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(err.Error())
+		}
+
+		var arrDataType arrow.DataType
+		switch subField.DataType {
+		case schemapb.DataType_Array:
+			arrDataType, err = convertToArrowDataType(subField, true)
+		case schemapb.DataType_ArrayOfVector:
+			arrDataType, err = convertElementTypeOfVectorArrayToArrowType(subField)
+		default:
+			err = merr.WrapErrParameterInvalidMsg("unsupported data type in struct: %v", subField.DataType.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		structFields = append(structFields, arrow.Field{
+			Name:     fieldName,
+			Type:     arrDataType,
+			Nullable: subField.GetNullable(),
+		})
+	}
+
+	// Create list<struct> type
+	return arrow.ListOf(arrow.StructOf(structFields...)), nil
+}
+
 func ConvertToArrowSchemaForUT(schema *schemapb.CollectionSchema, useNullType bool) (*arrow.Schema, error) {
 	arrFields := make([]arrow.Field, 0, 10)
 
@@ -513,47 +570,10 @@ func ConvertToArrowSchemaForUT(schema *schemapb.CollectionSchema, useNullType bo
 	}
 
 	for _, structField := range schema.StructArrayFields {
-		// Build struct fields for row-wise format
-		structFields := make([]arrow.Field, 0, len(structField.Fields))
-		for _, subField := range structField.Fields {
-			fieldName, err := typeutil.ExtractStructFieldName(subField.Name)
-			if err != nil {
-				return nil, merr.WrapErrImportFailed(err.Error())
-			}
-
-			var arrDataType arrow.DataType
-			switch subField.DataType {
-			case schemapb.DataType_Array:
-				arrDataType, err = convertToArrowDataType(subField, true)
-				if err != nil {
-					return nil, err
-				}
-
-			case schemapb.DataType_ArrayOfVector:
-				arrDataType, err = convertElementTypeOfVectorArrayToArrowType(subField)
-				if err != nil {
-					return nil, err
-				}
-
-			default:
-				err = merr.WrapErrParameterInvalidMsg("unsupported data type in struct: %v", subField.DataType.String())
-			}
-
-			if err != nil {
-				return nil, err
-			}
-
-			structFields = append(structFields, arrow.Field{
-				Name:     fieldName,
-				Type:     arrDataType,
-				Nullable: subField.GetNullable(),
-			})
+		listType, err := ConvertStructArrayToArrowDataType(structField)
+		if err != nil {
+			return nil, err
 		}
-
-		// Create list<struct> type
-		structType := arrow.StructOf(structFields...)
-		listType := arrow.ListOf(structType)
-
 		arrFields = append(arrFields, arrow.Field{
 			Name:     structField.Name,
 			Type:     listType,
@@ -21,6 +21,8 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/arrowipc"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/avro"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/binlog"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/csv"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/json"
@@ -90,6 +92,10 @@ func NewReader(ctx context.Context,
 			return nil, err
 		}
 		return csv.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize, sep, nullkey)
+	case Avro:
+		return avro.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize)
+	case ArrowIPC:
+		return arrowipc.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize)
 	}
 	return nil, merr.WrapErrImportFailed("unexpected import file")
 }
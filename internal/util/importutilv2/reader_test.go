@@ -150,6 +150,30 @@ func TestImportNewReader(t *testing.T) {
 	}
 	checkFunc("io error", req, options)
 
+	// accepts only one avro file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.avro", "2.avro"},
+	}
+	checkFunc("accepts only one file", req, options)
+
+	// avro file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.avro"},
+	}
+	checkFunc("io error", req, options)
+
+	// accepts only one arrow ipc file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.arrow", "2.arrow"},
+	}
+	checkFunc("accepts only one file", req, options)
+
+	// arrow ipc file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.feather"},
+	}
+	checkFunc("io error", req, options)
+
 	// illegal sep
 	options = []*commonpb.KeyValuePair{
 		{
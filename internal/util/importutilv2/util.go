@@ -35,6 +35,8 @@ const (
 	Parquet   FileType = 3
 	CSV       FileType = 4
 	JSONLines FileType = 5
+	Avro      FileType = 6
+	ArrowIPC  FileType = 7
 
 	JSONFileExt    = ".json"
 	JSONLFileExt   = ".jsonl"
@@ -42,6 +44,9 @@ const (
 	NumpyFileExt   = ".npy"
 	ParquetFileExt = ".parquet"
 	CSVFileExt     = ".csv"
+	AvroFileExt    = ".avro"
+	ArrowFileExt   = ".arrow"
+	FeatherFileExt = ".feather"
 )
 
 var FileTypeName = map[int]string{
@@ -51,6 +56,8 @@ var FileTypeName = map[int]string{
 	3: "Parquet",
 	4: "CSV",
 	5: "JSONLines",
+	6: "Avro",
+	7: "ArrowIPC",
 }
 
 func (f FileType) String() string {
@@ -104,6 +111,16 @@ func GetFileType(file *internalpb.ImportFile) (FileType, error) {
 			return Invalid, merr.WrapErrImportFailed("for CSV import, accepts only one file")
 		}
 		return CSV, nil
+	case AvroFileExt:
+		if len(file.GetPaths()) != 1 {
+			return Invalid, merr.WrapErrImportFailed("for Avro import, accepts only one file")
+		}
+		return Avro, nil
+	case ArrowFileExt, FeatherFileExt:
+		if len(file.GetPaths()) != 1 {
+			return Invalid, merr.WrapErrImportFailed("for Arrow IPC import, accepts only one file")
+		}
+		return ArrowIPC, nil
 	}
 	return Invalid, merr.WrapErrImportFailedMsg("unexpected file type, files=%v", file.GetPaths())
 }
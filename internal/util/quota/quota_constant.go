@@ -21,8 +21,12 @@ package quota
 import (
 	"context"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus/pkg/v3/config"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
@@ -79,6 +83,16 @@ func initLimitConfigMaps() {
 				internalpb.RateType_DQLSearch:   &quotaConfig.DQLMaxSearchRatePerPartition,
 				internalpb.RateType_DQLQuery:    &quotaConfig.DQLMaxQueryRatePerPartition,
 			},
+			internalpb.RateScope_User: {
+				internalpb.RateType_DDLCollection: &quotaConfig.DDLCollectionRatePerUser,
+				internalpb.RateType_DDLPartition:  &quotaConfig.DDLPartitionRatePerUser,
+				internalpb.RateType_DMLInsert:     &quotaConfig.DMLMaxInsertRatePerUser,
+				internalpb.RateType_DMLDelete:     &quotaConfig.DMLMaxDeleteRatePerUser,
+				internalpb.RateType_DQLSearch:     &quotaConfig.DQLMaxSearchRatePerUser,
+				internalpb.RateType_DQLQuery:      &quotaConfig.DQLMaxQueryRatePerUser,
+			},
+			// role has no default limits, the limits are configured for each role
+			internalpb.RateScope_Role: {},
 		}
 
 		pt := paramtable.Get()
@@ -116,3 +130,78 @@ func GetQuotaValue(scope internalpb.RateScope, rateType internalpb.RateType, par
 	}
 	return config.GetAsFloat()
 }
+
+// GetUserQuotaValues returns the rates of the user, the default rates of the user scope are
+// overridden by the rates configured for the user.
+func GetUserQuotaValues(username string) map[internalpb.RateType]float64 {
+	rates := make(map[internalpb.RateType]float64)
+	for rt, item := range GetQuotaConfigMap(internalpb.RateScope_User) {
+		rates[rt] = item.GetAsFloat()
+	}
+	for rt, rate := range parseNamedQuotaValues(paramtable.Get().QuotaConfig.UserRateLimits.GetValue(), username) {
+		rates[rt] = rate
+	}
+	return rates
+}
+
+// GetRoleQuotaValues returns the rates configured for the role, it's empty if the role is not limited.
+func GetRoleQuotaValues(role string) map[internalpb.RateType]float64 {
+	return parseNamedQuotaValues(paramtable.Get().QuotaConfig.RoleRateLimits.GetValue(), role)
+}
+
+// GetLimitedRoles returns the roles which have configured rates.
+func GetLimitedRoles() []string {
+	roles := make(map[string]struct{})
+	for key := range paramtable.Get().QuotaConfig.RoleRateLimits.GetValue() {
+		if idx := strings.LastIndex(key, "."); idx > 0 {
+			roles[key[:idx]] = struct{}{}
+		}
+	}
+	return lo.Keys(roles)
+}
+
+// parseNamedQuotaValues parses the rates of the name from the config group,
+// whose keys are in format of {name}.{rateType}, the keys of config are case-insensitive.
+func parseNamedQuotaValues(values map[string]string, name string) map[internalpb.RateType]float64 {
+	rates := make(map[internalpb.RateType]float64)
+	prefix := strings.ToLower(name) + "."
+	for key, value := range values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rt, ok := rateTypeByName[strings.TrimPrefix(key, prefix)]
+		if !ok {
+			mlog.Warn(context.TODO(), "Unknown rate type", mlog.String("key", key))
+			continue
+		}
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			mlog.Warn(context.TODO(), "Invalid rate", mlog.String("key", key), mlog.String("value", value))
+			continue
+		}
+		if isDataRateType(rt) {
+			rate *= paramtable.MBSize
+		}
+		rates[rt] = rate
+	}
+	return rates
+}
+
+// rateTypeByName maps the lowercase name of rate type to rate type, such as dqlsearch.
+var rateTypeByName = func() map[string]internalpb.RateType {
+	m := make(map[string]internalpb.RateType)
+	for name, value := range internalpb.RateType_value {
+		m[strings.ToLower(name)] = internalpb.RateType(value)
+	}
+	return m
+}()
+
+// isDataRateType returns true if the rate is configured in MB/s.
+func isDataRateType(rt internalpb.RateType) bool {
+	switch rt {
+	case internalpb.RateType_DMLInsert, internalpb.RateType_DMLDelete, internalpb.RateType_DMLBulkLoad:
+		return true
+	default:
+		return false
+	}
+}
@@ -46,6 +46,14 @@ func TestGetQuotaConfigMap(t *testing.T) {
 		m := GetQuotaConfigMap(internalpb.RateScope_Partition)
 		assert.Equal(t, 5, len(m))
 	}
+	{
+		m := GetQuotaConfigMap(internalpb.RateScope_User)
+		assert.Equal(t, 6, len(m))
+	}
+	{
+		m := GetQuotaConfigMap(internalpb.RateScope_Role)
+		assert.Equal(t, 0, len(m))
+	}
 	{
 		m := GetQuotaConfigMap(internalpb.RateScope(1000))
 		assert.Equal(t, 0, len(m))
@@ -89,3 +97,42 @@ func TestGetQuotaValue(t *testing.T) {
 		assert.EqualValues(t, math.MaxFloat64, v)
 	})
 }
+
+func TestGetUserAndRoleQuotaValues(t *testing.T) {
+	paramtable.Init()
+	param := paramtable.Get()
+	param.Save(param.QuotaConfig.DQLLimitEnabled.Key, "true")
+	defer param.Reset(param.QuotaConfig.DQLLimitEnabled.Key)
+	param.Save(param.QuotaConfig.DQLMaxSearchRatePerUser.Key, "100")
+	defer param.Reset(param.QuotaConfig.DQLMaxSearchRatePerUser.Key)
+
+	limits := map[string]string{
+		param.QuotaConfig.UserRateLimits.KeyPrefix + "Alice.dqlSearch":        "10",
+		param.QuotaConfig.UserRateLimits.KeyPrefix + "alice.dmlInsert":        "2",
+		param.QuotaConfig.UserRateLimits.KeyPrefix + "alice.unknown":          "2",
+		param.QuotaConfig.RoleRateLimits.KeyPrefix + "tenant_a.ddlCollection": "5",
+		param.QuotaConfig.RoleRateLimits.KeyPrefix + "tenant_a.dqlQuery":      "invalid",
+	}
+	param.SaveGroup(limits)
+	for key := range limits {
+		defer param.Reset(key)
+	}
+
+	t.Run("user", func(t *testing.T) {
+		rates := GetUserQuotaValues("bob")
+		assert.Equal(t, 6, len(rates))
+		assert.EqualValues(t, 100, rates[internalpb.RateType_DQLSearch])
+		assert.EqualValues(t, math.MaxFloat64, rates[internalpb.RateType_DMLInsert])
+
+		rates = GetUserQuotaValues("Alice")
+		assert.Equal(t, 6, len(rates))
+		assert.EqualValues(t, 10, rates[internalpb.RateType_DQLSearch])
+		assert.EqualValues(t, 2*1024*1024, rates[internalpb.RateType_DMLInsert])
+	})
+	t.Run("role", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"tenant_a"}, GetLimitedRoles())
+		rates := GetRoleQuotaValues("tenant_a")
+		assert.Equal(t, map[internalpb.RateType]float64{internalpb.RateType_DDLCollection: 5}, rates)
+		assert.Empty(t, GetRoleQuotaValues("tenant_b"))
+	})
+}
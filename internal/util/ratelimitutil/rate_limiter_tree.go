@@ -21,6 +21,8 @@ import (
 	"sync"
 	"time"
 
+	"go.uber.org/atomic"
+
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
@@ -176,16 +178,33 @@ const clearInvalidNodeInterval = 1 * time.Minute
 //		-> database level
 //			-> collection level
 //				-> partition levelearl
+//
+// the user level and role level limiters are out of the hierarchy,
+// they are keyed by user name and role name.
 type RateLimiterTree struct {
-	root *RateLimiterNode
-	mu   sync.RWMutex
+	root  *RateLimiterNode
+	users *typeutil.ConcurrentMap[string, *userLimiterNode]
+	roles *typeutil.ConcurrentMap[string, *RateLimiterNode]
+	mu    sync.RWMutex
 
 	lastClearTime time.Time
 }
 
+// userLimiterNode is the limiter of user level with its last access time,
+// the limiters of idle users are cleared.
+type userLimiterNode struct {
+	node       *RateLimiterNode
+	lastAccess *atomic.Int64
+}
+
 // NewRateLimiterTree returns a new RateLimiterTree.
 func NewRateLimiterTree(root *RateLimiterNode) *RateLimiterTree {
-	return &RateLimiterTree{root: root, lastClearTime: time.Now()}
+	return &RateLimiterTree{
+		root:          root,
+		users:         typeutil.NewConcurrentMap[string, *userLimiterNode](),
+		roles:         typeutil.NewConcurrentMap[string, *RateLimiterNode](),
+		lastClearTime: time.Now(),
+	}
 }
 
 // GetRootLimiters get root limiters
@@ -355,3 +374,75 @@ func (m *RateLimiterTree) GetOrCreatePartitionLimiters(dbID int64, collectionID
 	collectionRateLimiters.AddChild(partitionID, partRateLimiters)
 	return partRateLimiters
 }
+
+// GetUserLimiters returns the limiter of user level, nil if it doesn't exist.
+func (m *RateLimiterTree) GetUserLimiters(username string) *RateLimiterNode {
+	n, ok := m.users.Get(username)
+	if !ok {
+		return nil
+	}
+	return n.node
+}
+
+// GetOrCreateUserLimiters get limiter of user level, or create a user limiter if it doesn't exist,
+// the access time of the user limiter is refreshed.
+func (m *RateLimiterTree) GetOrCreateUserLimiters(username string, newUserRateLimiter func() *RateLimiterNode) *RateLimiterNode {
+	n, ok := m.users.Get(username)
+	if !ok {
+		n, _ = m.users.GetOrInsert(username, &userLimiterNode{
+			node:       newUserRateLimiter(),
+			lastAccess: atomic.NewInt64(0),
+		})
+	}
+	n.lastAccess.Store(time.Now().UnixNano())
+	return n.node
+}
+
+// RangeUserLimiters calls f for the limiters of all users.
+func (m *RateLimiterTree) RangeUserLimiters(f func(username string, node *RateLimiterNode) bool) {
+	m.users.Range(func(username string, n *userLimiterNode) bool {
+		return f(username, n.node)
+	})
+}
+
+// RemoveUserLimiters removes the limiter of the user, e.g. when the user is dropped.
+func (m *RateLimiterTree) RemoveUserLimiters(username string) {
+	m.users.Remove(username)
+}
+
+// ClearIdleUserLimiters removes the limiters of the users which are not accessed in the idle duration,
+// returns the removed users.
+func (m *RateLimiterTree) ClearIdleUserLimiters(idle time.Duration) []string {
+	removed := make([]string, 0)
+	deadline := time.Now().Add(-idle).UnixNano()
+	m.users.Range(func(username string, n *userLimiterNode) bool {
+		if n.lastAccess.Load() < deadline {
+			removed = append(removed, username)
+		}
+		return true
+	})
+	for _, username := range removed {
+		m.users.Remove(username)
+	}
+	return removed
+}
+
+// GetRoleLimiters returns the limiter of role level, nil if it doesn't exist.
+func (m *RateLimiterTree) GetRoleLimiters(role string) *RateLimiterNode {
+	n, _ := m.roles.Get(role)
+	return n
+}
+
+// GetOrCreateRoleLimiters get limiter of role level, or create a role limiter if it doesn't exist.
+func (m *RateLimiterTree) GetOrCreateRoleLimiters(role string, newRoleRateLimiter func() *RateLimiterNode) *RateLimiterNode {
+	if n, ok := m.roles.Get(role); ok {
+		return n
+	}
+	n, _ := m.roles.GetOrInsert(role, newRoleRateLimiter())
+	return n
+}
+
+// GetRolesLimiters returns the limiters of all roles.
+func (m *RateLimiterTree) GetRolesLimiters() *typeutil.ConcurrentMap[string, *RateLimiterNode] {
+	return m.roles
+}
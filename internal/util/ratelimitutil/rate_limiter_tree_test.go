@@ -214,3 +214,48 @@ func TestRateLimiterTreeClearInvalidLimiterNode(t *testing.T) {
 	assert.Equal(t, 1, root.GetChild(1).GetChildren().Len())
 	assert.Equal(t, 1, root.GetChild(1).GetChild(10).GetChildren().Len())
 }
+
+func TestRateLimiterTreeUserAndRole(t *testing.T) {
+	tree := NewRateLimiterTree(NewRateLimiterNode(internalpb.RateScope_Cluster))
+	assert.Nil(t, tree.GetUserLimiters("alice"))
+	assert.Nil(t, tree.GetRoleLimiters("tenant"))
+
+	userNode := tree.GetOrCreateUserLimiters("alice", func() *RateLimiterNode {
+		return NewRateLimiterNode(internalpb.RateScope_User)
+	})
+	assert.Equal(t, internalpb.RateScope_User, userNode.Level())
+	assert.Same(t, userNode, tree.GetUserLimiters("alice"))
+	assert.Same(t, userNode, tree.GetOrCreateUserLimiters("alice", func() *RateLimiterNode {
+		t.Fatal("should not create user limiters again")
+		return nil
+	}))
+	users := make([]string, 0)
+	tree.RangeUserLimiters(func(username string, node *RateLimiterNode) bool {
+		assert.Same(t, userNode, node)
+		users = append(users, username)
+		return true
+	})
+	assert.Equal(t, []string{"alice"}, users)
+
+	// the limiters of idle and removed users are cleared
+	tree.GetOrCreateUserLimiters("bob", func() *RateLimiterNode {
+		return NewRateLimiterNode(internalpb.RateScope_User)
+	})
+	assert.Empty(t, tree.ClearIdleUserLimiters(time.Minute))
+	time.Sleep(10 * time.Millisecond)
+	tree.GetOrCreateUserLimiters("bob", nil)
+	assert.Equal(t, []string{"alice"}, tree.ClearIdleUserLimiters(5*time.Millisecond))
+	assert.Nil(t, tree.GetUserLimiters("alice"))
+	tree.RemoveUserLimiters("bob")
+	assert.Nil(t, tree.GetUserLimiters("bob"))
+
+	roleNode := tree.GetOrCreateRoleLimiters("tenant", func() *RateLimiterNode {
+		return NewRateLimiterNode(internalpb.RateScope_Role)
+	})
+	assert.Equal(t, internalpb.RateScope_Role, roleNode.Level())
+	assert.Same(t, roleNode, tree.GetRoleLimiters("tenant"))
+	assert.Equal(t, 1, tree.GetRolesLimiters().Len())
+
+	// user and role limiters are out of the hierarchy
+	assert.Equal(t, 0, tree.GetRootLimiters().GetChildren().Len())
+}
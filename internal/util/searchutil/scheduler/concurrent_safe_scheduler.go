@@ -25,14 +25,20 @@ const (
 	readTaskQueueOutcomeScheduled = "scheduled"
 	readTaskQueueOutcomeExpired   = "expired"
 	readTaskQueueOutcomeCleared   = "cleared"
+	readTaskQueueOutcomePreempted = "preempted"
 )
 
 // newScheduler create a scheduler with given schedule policy.
 func newScheduler(policy schedulePolicy) Scheduler {
 	maxReadConcurrency := paramtable.Get().QueryNodeCfg.MaxReadConcurrency.GetAsInt()
 	mlog.Info(context.TODO(), "query node use concurrent safe scheduler", mlog.Int("max_concurrency", maxReadConcurrency))
+	var notifyChan <-chan struct{}
+	if p, ok := policy.(trackingPolicy); ok {
+		notifyChan = p.Notify()
+	}
 	return &scheduler{
 		policy:           policy,
+		notifyChan:       notifyChan,
 		receiveChan:      make(chan addTaskReq),
 		clearChan:        make(chan clearQueuedReq),
 		execChan:         make(chan Task),
@@ -62,6 +68,7 @@ type clearQueuedResp struct {
 // scheduler is a general concurrent safe scheduler implementation by wrapping a schedule policy.
 type scheduler struct {
 	policy      schedulePolicy
+	notifyChan  <-chan struct{}
 	receiveChan chan addTaskReq
 	clearChan   chan clearQueuedReq
 	execChan    chan Task
@@ -186,6 +193,8 @@ func (s *scheduler) schedule() {
 			var result ClearResult
 			result, task = s.clearQueuedTasks(req.filter, req.reason, task, now)
 			req.resp <- clearQueuedResp{result: result}
+		case <-s.notifyChan:
+			// The tasks held back by policy may be ready, try to schedule them.
 		case execChan <- execTask:
 			// Task sent, drop the ownership of sent task.
 			// Update waiting task counter.
@@ -226,6 +235,9 @@ func (s *scheduler) handleAddTaskRequest(req addTaskReq, maxWaitTaskNum int64, n
 	if maxWaitTaskNum > 0 && s.GetWaitingTaskTotal() >= maxWaitTaskNum {
 		s.cleanupExpiredTasks(now)
 	}
+	if maxWaitTaskNum > 0 && s.GetWaitingTaskTotal() >= maxWaitTaskNum {
+		s.preemptQueuedTask(req.task, maxWaitTaskNum, now)
+	}
 
 	if err := req.task.Context().Err(); err != nil {
 		mlog.Warn(context.TODO(), "task canceled before enqueue", mlog.Err(err))
@@ -288,12 +300,12 @@ func (s *scheduler) exec() {
 		// Skip this task if task is canceled.
 		if err := t.Context().Err(); err != nil {
 			mlog.Warn(context.TODO(), "task canceled before executing", mlog.Err(err))
-			t.Done(err)
+			s.taskDone(t, err)
 			continue
 		}
 		if err := t.PreExecute(); err != nil {
 			mlog.Warn(context.TODO(), "failed to pre-execute task", mlog.Err(err))
-			t.Done(err)
+			s.taskDone(t, err)
 			continue
 		}
 
@@ -314,12 +326,20 @@ func (s *scheduler) exec() {
 			collector.Counter.Dec(metricsinfo.ExecuteQueueType)
 
 			// Notify task done.
-			t.Done(err)
+			s.taskDone(t, err)
 			return nil, err
 		})
 	}
 }
 
+// taskDone notifies the task and the tracking policy that the task is done.
+func (s *scheduler) taskDone(t Task, err error) {
+	t.Done(err)
+	if p, ok := s.policy.(trackingPolicy); ok {
+		p.Done(t)
+	}
+}
+
 func (s *scheduler) getPool(t Task) *conc.Pool[any] {
 	if t.IsGpuIndex() {
 		return s.gpuPool
@@ -352,7 +372,7 @@ func (s *scheduler) setupExecListener(lastWaitingTask *queuedTask, now time.Time
 			if err := lastWaitingTask.Context().Err(); err != nil {
 				s.updateWaitingTaskCounter(-1, -lastWaitingTask.NQ())
 				s.recordReadTaskQueueDuration(lastWaitingTask, now, readTaskQueueOutcomeExpired)
-				lastWaitingTask.Done(err)
+				s.taskDone(lastWaitingTask.Task, err)
 				lastWaitingTask = nil
 				continue
 			}
@@ -380,6 +400,25 @@ func (s *scheduler) cleanupExpiredTasks(now time.Time) {
 	}
 }
 
+// preemptQueuedTask makes room for the task by preempting a queued task with lower priority,
+// if the policy supports preemption.
+func (s *scheduler) preemptQueuedTask(task Task, maxWaitTaskNum int64, now time.Time) {
+	p, ok := s.policy.(preemptivePolicy)
+	if !ok || task.Context().Err() != nil {
+		return
+	}
+	preempted := p.Preempt(task, now)
+	if !preempted.valid() {
+		return
+	}
+	s.updateWaitingTaskCounter(-1, -preempted.NQ())
+	s.recordReadTaskQueueDuration(preempted, now, readTaskQueueOutcomePreempted)
+	preempted.Done(merr.WrapErrTooManyRequests(
+		int32(maxWaitTaskNum),
+		fmt.Sprintf("preempted by higher priority workload, limit by %s", paramtable.Get().QueryNodeCfg.MaxUnsolvedQueueSize.Key),
+	))
+}
+
 func (s *scheduler) clearQueuedTasks(filter TaskFilter, reason string, task *queuedTask, now time.Time) (ClearResult, *queuedTask) {
 	removed := s.policy.Remove(filter, now)
 	if task.valid() && (filter == nil || filter(task.Task)) {
@@ -398,7 +437,7 @@ func (s *scheduler) clearQueuedTasks(filter TaskFilter, reason string, task *que
 		result.QueuedNQCleared += nq
 		s.updateWaitingTaskCounter(-1, -nq)
 		s.recordReadTaskQueueDuration(removedTask, now, readTaskQueueOutcomeCleared)
-		removedTask.Done(clearErr)
+		s.taskDone(removedTask.Task, clearErr)
 	}
 	return result, task
 }
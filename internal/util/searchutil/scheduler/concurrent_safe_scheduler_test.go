@@ -28,6 +28,9 @@ func TestScheduler(t *testing.T) {
 	t.Run("fifo", func(t *testing.T) {
 		testScheduler(t, newFIFOPolicy())
 	})
+	t.Run("workload-class", func(t *testing.T) {
+		testScheduler(t, newWorkloadClassPolicy())
+	})
 	t.Run("scheduler_not_working", func(t *testing.T) {
 		scheduler := newScheduler(newFIFOPolicy())
 
@@ -199,6 +202,33 @@ func (s *SchedulerSuite) TestHandleAddTaskRequestRejectsWhenWaitingQueueFull() {
 	s.Equal(int64(1), scheduler.GetWaitingTaskTotal())
 }
 
+func (s *SchedulerSuite) TestHandleAddTaskRequestPreemptsLowerPriorityTask() {
+	scheduler := &scheduler{
+		policy:           newWorkloadClassPolicy(),
+		schedulerCounter: schedulerCounter{},
+	}
+
+	batch := newMockTask(mockTaskConfig{nq: 1, class: "batch"})
+	errCh := make(chan error, 1)
+	scheduler.handleAddTaskRequest(addTaskReq{task: batch, err: errCh}, 1, time.Now())
+	s.NoError(<-errCh)
+
+	// the task of same priority is rejected.
+	errCh = make(chan error, 1)
+	scheduler.handleAddTaskRequest(addTaskReq{task: newMockTask(mockTaskConfig{nq: 1, class: "batch"}), err: errCh}, 1, time.Now())
+	s.ErrorIs(<-errCh, merr.ErrServiceTooManyRequests)
+
+	// the task of higher priority preempts the queued one.
+	interactive := newMockTask(mockTaskConfig{nq: 2, class: "interactive"})
+	errCh = make(chan error, 1)
+	scheduler.handleAddTaskRequest(addTaskReq{task: interactive, err: errCh}, 1, time.Now())
+	s.NoError(<-errCh)
+	s.ErrorIs(batch.Wait(), merr.ErrServiceTooManyRequests)
+	s.Equal(int64(1), scheduler.GetWaitingTaskTotal())
+	s.Equal(int64(2), scheduler.GetWaitingTaskTotalNQ())
+	s.Same(interactive, scheduler.policy.Pop(time.Now()).Task)
+}
+
 func (s *SchedulerSuite) TestHandleAddTaskRequestCleansExpiredTasksBeforeQueueLimit() {
 	now := time.Now()
 	scheduler := &scheduler{
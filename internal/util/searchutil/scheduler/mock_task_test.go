@@ -19,6 +19,7 @@ type mockTaskConfig struct {
 	mergeAble   bool
 	nq          int64
 	username    string
+	class       string
 	executeCost time.Duration
 	execution   func(ctx context.Context) error
 }
@@ -41,6 +42,7 @@ func newMockTask(c mockTaskConfig) Task {
 		nq:          c.nq,
 		minNQ:       c.nq,
 		username:    c.username,
+		class:       c.class,
 		execution:   c.execution,
 		tr:          timerecord.NewTimeRecorderWithTrace(c.ctx, "searchTask"),
 	}
@@ -54,6 +56,7 @@ type MockTask struct {
 	nq          int64
 	minNQ       int64
 	username    string
+	class       string
 	execution   func(ctx context.Context) error
 	tr          *timerecord.TimeRecorder
 }
@@ -67,6 +70,10 @@ func (t *MockTask) Username() string {
 	return t.username
 }
 
+func (t *MockTask) WorkloadClass() string {
+	return t.class
+}
+
 func (t *MockTask) IsGpuIndex() bool {
 	return false
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
@@ -22,11 +23,129 @@ func TestFIFOPolicy(t *testing.T) {
 	testCommonPolicyOperation(t, newFIFOPolicy())
 }
 
+func TestWorkloadClassPolicy(t *testing.T) {
+	paramtable.Init()
+	testCommonPolicyOperation(t, newWorkloadClassPolicy())
+
+	popClasses := func(policy schedulePolicy, n int) []string {
+		classes := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			task := policy.Pop(time.Now())
+			if !task.valid() {
+				break
+			}
+			classes = append(classes, task.WorkloadClass())
+		}
+		return classes
+	}
+
+	t.Run("weighted_fair", func(t *testing.T) {
+		policy := newWorkloadClassPolicy()
+		for i := 0; i < 10; i++ {
+			for _, class := range []string{"interactive", "batch", "unknown"} {
+				_, err := policy.Push(newQueuedTask(newMockTask(mockTaskConfig{class: class}), time.Now()))
+				assert.NoError(t, err)
+			}
+		}
+		assert.Equal(t, 30, policy.Len())
+
+		// interactive:batch is 8:2, and the unknown class belongs to the default interactive class.
+		classes := popClasses(policy, 10)
+		assert.Equal(t, 8, lo.Count(classes, "interactive")+lo.Count(classes, "unknown"))
+		assert.Equal(t, 2, lo.Count(classes, "batch"))
+		assert.Len(t, popClasses(policy, 30), 20)
+		assert.Equal(t, 0, policy.Len())
+	})
+
+	t.Run("max_running", func(t *testing.T) {
+		old := paramtable.Get().QueryNodeCfg.SchedulePolicyWorkloadClassMaxRunning.SwapTempValue("iterator:1")
+		defer paramtable.Get().QueryNodeCfg.SchedulePolicyWorkloadClassMaxRunning.SwapTempValue(old)
+
+		policy := newWorkloadClassPolicy()
+		for i := 0; i < 2; i++ {
+			_, err := policy.Push(newQueuedTask(newMockTask(mockTaskConfig{class: WorkloadClassIterator}), time.Now()))
+			assert.NoError(t, err)
+		}
+		running := policy.Pop(time.Now())
+		assert.True(t, running.valid())
+		assert.False(t, policy.Pop(time.Now()).valid())
+		assert.Equal(t, 1, policy.Len())
+
+		// the held back task is ready once the running one is done.
+		policy.Done(newMockTask(mockTaskConfig{}))
+		assert.Len(t, policy.Notify(), 0)
+		policy.Done(running.Task)
+		assert.Len(t, policy.Notify(), 1)
+		assert.True(t, policy.Pop(time.Now()).valid())
+		assert.Equal(t, 0, policy.Len())
+	})
+
+	t.Run("queue_time_slo", func(t *testing.T) {
+		old := paramtable.Get().QueryNodeCfg.SchedulePolicyWorkloadClassQueueSLO.SwapTempValue("batch:100ms")
+		defer paramtable.Get().QueryNodeCfg.SchedulePolicyWorkloadClassQueueSLO.SwapTempValue(old)
+
+		policy := newWorkloadClassPolicy()
+		base := time.Now()
+		for _, class := range []string{"batch", "batch", "interactive", "interactive"} {
+			_, err := policy.Push(newQueuedTask(newMockTask(mockTaskConfig{class: class}), base))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, "batch", policy.Pop(base).WorkloadClass())
+		// the batch task hasn't reached its slo, the interactive ones go first by weight.
+		assert.Equal(t, "interactive", policy.Pop(base).WorkloadClass())
+		// the batch task queued longer than its slo is scheduled ahead of the weights.
+		assert.Equal(t, "batch", policy.Pop(base.Add(time.Second)).WorkloadClass())
+		assert.Equal(t, "interactive", policy.Pop(base.Add(time.Second)).WorkloadClass())
+	})
+
+	t.Run("refresh_config", func(t *testing.T) {
+		policy := newWorkloadClassPolicy()
+		assert.Equal(t, "interactive", policy.config.Load().classOf(newMockTask(mockTaskConfig{class: "unknown"})))
+
+		pt := paramtable.Get()
+		pt.Save(pt.QueryNodeCfg.SchedulePolicyWorkloadClassDefault.Key, "batch")
+		defer pt.Reset(pt.QueryNodeCfg.SchedulePolicyWorkloadClassDefault.Key)
+		assert.Equal(t, "batch", policy.config.Load().classOf(newMockTask(mockTaskConfig{class: "unknown"})))
+	})
+
+	t.Run("weight", func(t *testing.T) {
+		assert.Equal(t, float64(8), WorkloadClassWeight("interactive"))
+		assert.Equal(t, float64(1), WorkloadClassWeight(WorkloadClassIterator))
+		// the unknown class is scheduled in the default class.
+		assert.Equal(t, float64(8), WorkloadClassWeight(""))
+		assert.Equal(t, float64(8), WorkloadClassWeight("unknown"))
+	})
+
+	t.Run("preempt", func(t *testing.T) {
+		policy := newWorkloadClassPolicy()
+		first := newMockTask(mockTaskConfig{class: WorkloadClassIterator})
+		last := newMockTask(mockTaskConfig{class: WorkloadClassIterator})
+		for _, task := range []Task{
+			newMockTask(mockTaskConfig{class: "batch"}),
+			first,
+			last,
+		} {
+			_, err := policy.Push(newQueuedTask(task, time.Now()))
+			assert.NoError(t, err)
+		}
+
+		// there is no class with lower weight than iterator.
+		assert.False(t, policy.Preempt(newMockTask(mockTaskConfig{class: WorkloadClassIterator}), time.Now()).valid())
+		// the last queued task of the lowest class is preempted.
+		assert.Same(t, last, policy.Preempt(newMockTask(mockTaskConfig{class: "batch"}), time.Now()).Task)
+		assert.Same(t, first, policy.Preempt(newMockTask(mockTaskConfig{}), time.Now()).Task)
+		assert.Equal(t, "batch", policy.Preempt(newMockTask(mockTaskConfig{}), time.Now()).WorkloadClass())
+		assert.False(t, policy.Preempt(newMockTask(mockTaskConfig{}), time.Now()).valid())
+		assert.Equal(t, 0, policy.Len())
+	})
+}
+
 func TestPolicyCleanupExpiredTasks(t *testing.T) {
 	paramtable.Init()
 	for name, policy := range map[string]schedulePolicy{
 		"fifo":              newFIFOPolicy(),
 		"user-task-polling": newUserTaskPollingPolicy(),
+		"workload-class":    newWorkloadClassPolicy(),
 	} {
 		t.Run(name, func(t *testing.T) {
 			base := time.Now()
@@ -51,6 +170,7 @@ func TestPolicyCleanupCanceledTasks(t *testing.T) {
 	for name, policy := range map[string]schedulePolicy{
 		"fifo":              newFIFOPolicy(),
 		"user-task-polling": newUserTaskPollingPolicy(),
+		"workload-class":    newWorkloadClassPolicy(),
 	} {
 		t.Run(name, func(t *testing.T) {
 			base := time.Now()
@@ -75,6 +195,7 @@ func TestPolicyRemove(t *testing.T) {
 	for name, policy := range map[string]schedulePolicy{
 		"fifo":              newFIFOPolicy(),
 		"user-task-polling": newUserTaskPollingPolicy(),
+		"workload-class":    newWorkloadClassPolicy(),
 	} {
 		t.Run(name, func(t *testing.T) {
 			base := time.Now()
@@ -63,6 +63,26 @@ func (q *mergeTaskQueue) pop() *queuedTask {
 	}
 }
 
+// popBack pops the last element of taskQueue.
+func (q *mergeTaskQueue) popBack(now time.Time) *queuedTask {
+	for len(q.tasks) > 0 {
+		task := q.tasks[len(q.tasks)-1]
+		q.tasks[len(q.tasks)-1] = nil
+		q.tasks = q.tasks[:len(q.tasks)-1]
+		if !task.valid() {
+			continue
+		}
+
+		removed := q.markRemoved(task, now)
+		if q.len() == 0 {
+			clear(q.tasks)
+			q.tasks = nil
+		}
+		return removed
+	}
+	return nil
+}
+
 func (q *mergeTaskQueue) cleanup(now time.Time) []*queuedTask {
 	if q.len() == 0 {
 		return nil
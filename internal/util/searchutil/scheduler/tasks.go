@@ -25,6 +25,10 @@ func NewScheduler(policyName string) Scheduler {
 		return newScheduler(
 			newUserTaskPollingPolicy(),
 		)
+	case SchedulePolicyNameWorkloadClass:
+		return newScheduler(
+			newWorkloadClassPolicy(),
+		)
 	default:
 		panic("invalid schedule task policy")
 	}
@@ -91,6 +95,25 @@ type schedulePolicy interface {
 	Len() int
 }
 
+// preemptivePolicy is the schedule policy which could preempt the queued tasks.
+type preemptivePolicy interface {
+	// Preempt removes a queued task with lower priority than the given task,
+	// to make room for it when the scheduler reaches the queue limit.
+	// Return nil if there is no such task.
+	Preempt(task Task, now time.Time) *queuedTask
+}
+
+// trackingPolicy is the schedule policy which tracks the popped tasks until they are done,
+// it may hold back the queued tasks while the popped ones are running.
+type trackingPolicy interface {
+	// Done notifies the policy that the task is done, no-op if the task isn't popped from the policy.
+	// Concurrent safe.
+	Done(task Task)
+
+	// Notify returns the channel signaled once the held back tasks may be ready to pop.
+	Notify() <-chan struct{}
+}
+
 type queuedTask struct {
 	Task
 
@@ -153,6 +176,10 @@ type Task interface {
 	// Return "" if the task do not contain any user info.
 	Username() string
 
+	// Return the workload class which task is belong to.
+	// Return "" if the task do not specify any workload class.
+	WorkloadClass() string
+
 	// Return whether the task would be running on GPU.
 	IsGpuIndex() bool
 
@@ -0,0 +1,351 @@
+package scheduler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v3/config"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	// SchedulePolicyNameWorkloadClass is the name of workload class schedule policy.
+	SchedulePolicyNameWorkloadClass = "workload-class"
+	// WorkloadClassIterator is the workload class of the iterator tasks not specifying a workload class.
+	WorkloadClassIterator = "iterator"
+)
+
+var (
+	_ schedulePolicy   = &workloadClassPolicy{}
+	_ preemptivePolicy = &workloadClassPolicy{}
+	_ trackingPolicy   = &workloadClassPolicy{}
+)
+
+// newWorkloadClassPolicy create a new workload class schedule policy.
+func newWorkloadClassPolicy() *workloadClassPolicy {
+	p := &workloadClassPolicy{
+		classes:   make([]*workloadClassQueue, 0),
+		scheduled: make(map[Task]*workloadClassQueue),
+		notify:    make(chan struct{}, 1),
+	}
+	p.config.Store(loadWorkloadClassConfig())
+	p.watchConfig()
+	return p
+}
+
+// workloadClassPolicy is a weighted fair schedule policy between workload classes.
+// The class with the smallest virtual pass is scheduled first, and its pass is advanced
+// by the reciprocal of its weight, so the classes are scheduled in proportion to their weights.
+// The class reaching its max running tasks is held back until its running tasks are done,
+// and the class whose first task is queued longer than its queue time slo is scheduled ahead of the weights.
+type workloadClassPolicy struct {
+	classes []*workloadClassQueue
+	count   int
+	// pass is the virtual pass of the last scheduled class,
+	// a class becoming active starts from it and can't claim the share of its idle time.
+	pass float64
+	// config is the parsed workload class configurations, refreshed once the params are updated.
+	config atomic.Pointer[workloadClassConfig]
+
+	mu        sync.Mutex
+	scheduled map[Task]*workloadClassQueue
+	notify    chan struct{}
+}
+
+// workloadClassQueue is the task queue of a workload class.
+type workloadClassQueue struct {
+	*mergeTaskQueue
+	pass    float64
+	running atomic.Int64
+}
+
+// workloadClassConfig is the snapshot of workload class configurations.
+type workloadClassConfig struct {
+	defaultClass string
+	weights      map[string]float64
+	maxRunning   map[string]int64
+	queueSLO     map[string]time.Duration
+}
+
+func loadWorkloadClassConfig() *workloadClassConfig {
+	pt := paramtable.Get()
+	cfg := &workloadClassConfig{
+		defaultClass: pt.QueryNodeCfg.SchedulePolicyWorkloadClassDefault.GetValue(),
+		weights:      make(map[string]float64),
+		maxRunning:   make(map[string]int64),
+		queueSLO:     make(map[string]time.Duration),
+	}
+	for class, value := range parseWorkloadClassValues(&pt.QueryNodeCfg.SchedulePolicyWorkloadClassWeights) {
+		if weight, err := strconv.ParseFloat(value, 64); err == nil && weight > 0 {
+			cfg.weights[class] = weight
+		}
+	}
+	for class, value := range parseWorkloadClassValues(&pt.QueryNodeCfg.SchedulePolicyWorkloadClassMaxRunning) {
+		if limit, err := strconv.ParseInt(value, 10, 64); err == nil && limit > 0 {
+			cfg.maxRunning[class] = limit
+		}
+	}
+	for class, value := range parseWorkloadClassValues(&pt.QueryNodeCfg.SchedulePolicyWorkloadClassQueueSLO) {
+		if slo, err := time.ParseDuration(value); err == nil && slo > 0 {
+			cfg.queueSLO[class] = slo
+		}
+	}
+	return cfg
+}
+
+// watchConfig refreshes the cached configurations once any of the workload class params is updated.
+func (p *workloadClassPolicy) watchConfig() {
+	pt := paramtable.Get()
+	handler := config.NewHandler("querynode.scheduler.workload-class", func(_ *config.Event) {
+		p.config.Store(loadWorkloadClassConfig())
+	})
+	for _, key := range []string{
+		pt.QueryNodeCfg.SchedulePolicyWorkloadClassDefault.Key,
+		pt.QueryNodeCfg.SchedulePolicyWorkloadClassWeights.Key,
+		pt.QueryNodeCfg.SchedulePolicyWorkloadClassMaxRunning.Key,
+		pt.QueryNodeCfg.SchedulePolicyWorkloadClassQueueSLO.Key,
+	} {
+		pt.Watch(key, handler)
+	}
+}
+
+// parseWorkloadClassValues parses the comma separated class:value pairs of param.
+func parseWorkloadClassValues(param *paramtable.ParamItem) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range param.GetAsStrings() {
+		class, value, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(class)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+// classOf returns the workload class of task, the task not specifying a configured class belongs to the default class.
+func (c *workloadClassConfig) classOf(task Task) string {
+	return c.resolve(task.WorkloadClass())
+}
+
+// resolve returns the configured class which the tasks of class are scheduled in.
+func (c *workloadClassConfig) resolve(class string) string {
+	if class != "" {
+		if _, ok := c.weights[class]; ok {
+			return class
+		}
+	}
+	return c.defaultClass
+}
+
+// WorkloadClassWeight returns the weight of the class which the read tasks of class are scheduled in
+// by the workload-class policy, the empty or unknown class is scheduled in the default class.
+func WorkloadClassWeight(class string) float64 {
+	cfg := loadWorkloadClassConfig()
+	return cfg.weight(cfg.resolve(class))
+}
+
+// weight returns the weight of class, 1 if not configured.
+func (c *workloadClassConfig) weight(class string) float64 {
+	if weight, ok := c.weights[class]; ok {
+		return weight
+	}
+	return 1
+}
+
+// overdue returns how many times the queue duration of task exceeds the queue time slo of class,
+// 0 if the slo isn't configured or not exceeded.
+func (c *workloadClassConfig) overdue(class string, task *queuedTask, now time.Time) float64 {
+	slo, ok := c.queueSLO[class]
+	if !ok {
+		return 0
+	}
+	ratio := float64(task.queueDuration(now)) / float64(slo)
+	if ratio < 1 {
+		return 0
+	}
+	return ratio
+}
+
+// getOrCreateQueue returns the queue of class, create it if not exist.
+func (p *workloadClassPolicy) getOrCreateQueue(class string) *workloadClassQueue {
+	idx := sort.Search(len(p.classes), func(i int) bool {
+		return p.classes[i].name >= class
+	})
+	if idx < len(p.classes) && p.classes[idx].name == class {
+		return p.classes[idx]
+	}
+	queue := &workloadClassQueue{
+		mergeTaskQueue: newMergeTaskQueue(class),
+		pass:           p.pass,
+	}
+	p.classes = append(p.classes, nil)
+	copy(p.classes[idx+1:], p.classes[idx:])
+	p.classes[idx] = queue
+	return queue
+}
+
+func (p *workloadClassPolicy) Cleanup(now time.Time) []*queuedTask {
+	removed := make([]*queuedTask, 0)
+	for _, queue := range p.classes {
+		removed = append(removed, queue.cleanup(now)...)
+	}
+	p.count -= len(removed)
+	p.setupReadyLenMetric()
+	return removed
+}
+
+func (p *workloadClassPolicy) Remove(filter TaskFilter, now time.Time) []*queuedTask {
+	removed := make([]*queuedTask, 0)
+	for _, queue := range p.classes {
+		removed = append(removed, queue.remove(filter, now)...)
+	}
+	p.count -= len(removed)
+	p.setupReadyLenMetric()
+	return removed
+}
+
+// Push add a new task into scheduler, an error will be returned if scheduler reaches some limit.
+func (p *workloadClassPolicy) Push(task *queuedTask) (int, error) {
+	pt := paramtable.Get()
+	cfg := p.config.Load()
+	queue := p.getOrCreateQueue(cfg.classOf(task.Task))
+
+	// Try to merge task with the tasks of same class if task is mergeable.
+	if t := tryIntoMergeTask(task.Task); t != nil {
+		maxNQ := pt.QueryNodeCfg.MaxGroupNQ.GetAsInt64()
+		nqMergeRatio := pt.QueryNodeCfg.NQMergeRatio.GetAsFloat()
+		maxDeadlineMergeGap := pt.QueryNodeCfg.MaxDeadlineMergeGap.GetAsDurationByParse()
+		if queue.tryMerge(task, maxNQ, nqMergeRatio, maxDeadlineMergeGap) {
+			return 0, nil
+		}
+	}
+
+	if queue.len() == 0 && queue.pass < p.pass {
+		queue.pass = p.pass
+	}
+	queue.push(task)
+	p.count++
+	p.setupReadyLenMetric()
+	return 1, nil
+}
+
+// Pop get the task next ready to run.
+func (p *workloadClassPolicy) Pop(now time.Time) *queuedTask {
+	if p.count == 0 {
+		return nil
+	}
+	cfg := p.config.Load()
+
+	var selected *workloadClassQueue
+	selectedOverdue := float64(0)
+	for _, queue := range p.classes {
+		front := queue.front()
+		if !front.valid() {
+			continue
+		}
+		if limit, ok := cfg.maxRunning[queue.name]; ok && queue.running.Load() >= limit {
+			continue
+		}
+		overdue := cfg.overdue(queue.name, front, now)
+		if selected == nil ||
+			overdue > selectedOverdue ||
+			(overdue == selectedOverdue && queue.pass < selected.pass) {
+			selected, selectedOverdue = queue, overdue
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+
+	task := selected.pop()
+	if !task.valid() {
+		return nil
+	}
+	p.count--
+	p.pass = selected.pass
+	selected.pass += 1 / cfg.weight(selected.name)
+	selected.running.Inc()
+	p.mu.Lock()
+	p.scheduled[task.Task] = selected
+	p.mu.Unlock()
+
+	nodeID := paramtable.GetStringNodeID()
+	if selectedOverdue > 0 {
+		metrics.QueryNodeWorkloadClassSLOViolationCount.WithLabelValues(nodeID, selected.name).Inc()
+	}
+	metrics.QueryNodeWorkloadClassQueueDuration.WithLabelValues(nodeID, selected.name).
+		Observe(float64(task.queueDuration(now).Microseconds()) / 1000.0)
+	metrics.QueryNodeWorkloadClassRunning.WithLabelValues(nodeID, selected.name).Set(float64(selected.running.Load()))
+	p.setupReadyLenMetric()
+	return task
+}
+
+// Preempt removes the last queued task of the class with the lowest weight,
+// which is lower than the weight of the class of given task.
+func (p *workloadClassPolicy) Preempt(task Task, now time.Time) *queuedTask {
+	cfg := p.config.Load()
+	weight := cfg.weight(cfg.classOf(task))
+
+	var victim *workloadClassQueue
+	for _, queue := range p.classes {
+		if queue.len() == 0 || cfg.weight(queue.name) >= weight {
+			continue
+		}
+		if victim == nil || cfg.weight(queue.name) < cfg.weight(victim.name) {
+			victim = queue
+		}
+	}
+	if victim == nil {
+		return nil
+	}
+
+	removed := victim.popBack(now)
+	if !removed.valid() {
+		return nil
+	}
+	p.count--
+	metrics.QueryNodeWorkloadClassPreemptedCount.WithLabelValues(paramtable.GetStringNodeID(), victim.name).Inc()
+	p.setupReadyLenMetric()
+	return removed
+}
+
+// Done releases the running slot of the class of task.
+func (p *workloadClassPolicy) Done(task Task) {
+	p.mu.Lock()
+	queue, ok := p.scheduled[task]
+	delete(p.scheduled, task)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	running := queue.running.Dec()
+	metrics.QueryNodeWorkloadClassRunning.WithLabelValues(paramtable.GetStringNodeID(), queue.name).Set(float64(running))
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Notify returns the channel signaled once a running task is done.
+func (p *workloadClassPolicy) Notify() <-chan struct{} {
+	return p.notify
+}
+
+// Len get ready task counts.
+func (p *workloadClassPolicy) Len() int {
+	return p.count
+}
+
+func (p *workloadClassPolicy) setupReadyLenMetric() {
+	nodeID := paramtable.GetStringNodeID()
+	for _, queue := range p.classes {
+		metrics.QueryNodeWorkloadClassReadyLen.WithLabelValues(nodeID, queue.name).Set(float64(queue.len()))
+	}
+}
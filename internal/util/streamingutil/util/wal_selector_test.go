@@ -13,6 +13,11 @@ import (
 func TestValidateWALType(t *testing.T) {
 	_, err := validateWALName(false, message.WALNameRocksmq.String())
 	assert.Error(t, err)
+	_, err = validateWALName(false, message.WALNameLocalFS.String())
+	assert.Error(t, err)
+	name, err := validateWALName(true, message.WALNameLocalFS.String())
+	assert.NoError(t, err)
+	assert.Equal(t, message.WALNameLocalFS, name)
 }
 
 func TestSelectWALType(t *testing.T) {
@@ -22,6 +22,7 @@ import (
 	"github.com/tikv/client-go/v2/txnkv"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
+	boltkv "github.com/milvus-io/milvus/internal/kv/bolt"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
 	"github.com/milvus-io/milvus/pkg/v3/kv"
@@ -36,3 +37,8 @@ func NewTSOKVBase(client *clientv3.Client, tsoRoot, subPath string) kv.TxnKV {
 func NewTSOTiKVBase(client *txnkv.Client, tsoRoot, subPath string) kv.TxnKV {
 	return tikv.NewTiKV(client, path.Join(tsoRoot, subPath))
 }
+
+// NewTSOBoltBase returns a kv.TxnKV object
+func NewTSOBoltBase(db *boltkv.DB, tsoRoot, subPath string) kv.TxnKV {
+	return boltkv.NewBoltKV(db, path.Join(tsoRoot, subPath))
+}
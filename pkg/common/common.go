@@ -283,6 +283,17 @@ const (
 	CollectionSearchRateMinKey   = "collection.searchRate.min.vps"
 	CollectionDiskQuotaKey       = "collection.diskProtection.diskQuota.mb"
 
+	// compress type of the binlogs of the collection, one of zstd, lz4 and snappy.
+	CollectionBinlogCompressionKey = "collection.binlog.compression"
+
+	// workload class of the read requests of the collection, used by the query node scheduler,
+	// it overrides the database.workload.class of the database.
+	CollectionWorkloadClassKey = "collection.workload.class"
+
+	// row level security, the key is the prefix followed by the role name,
+	// and the value is the filter expression that the role is restricted to.
+	CollectionRowPolicyKeyPrefix = "collection.rowPolicy."
+
 	PartitionDiskQuotaKey = "partition.diskProtection.diskQuota.mb"
 
 	// database level properties
@@ -292,6 +303,8 @@ const (
 	DatabaseMaxCollectionsKey   = "database.max.collections"
 	DatabaseForceDenyWritingKey = "database.force.deny.writing"
 	DatabaseForceDenyReadingKey = "database.force.deny.reading"
+	// workload class of the read requests of the database, used by the query node scheduler
+	DatabaseWorkloadClassKey = "database.workload.class"
 
 	DatabaseForceDenyDDLKey           = "database.force.deny.ddl" // all ddl
 	DatabaseForceDenyCollectionDDLKey = "database.force.deny.collectionDDL"
@@ -374,6 +387,10 @@ const (
 	TszMicrosecond string = "microsecond"
 )
 
+// RecycleBinSnapshotPrefix is the name prefix of the snapshots which keep the dropped
+// collections and partitions in the recycle bin.
+const RecycleBinSnapshotPrefix = "__recycle_bin__"
+
 func IsSystemField(fieldID int64) bool {
 	return fieldID < StartOfUserFieldID
 }
@@ -663,6 +680,57 @@ func IsDisableFuncRuntimeCheck(kvs ...*commonpb.KeyValuePair) (bool, error) {
 	return false, nil
 }
 
+// IsCollectionRowPolicyKey returns true if the key is the row policy of a role.
+func IsCollectionRowPolicyKey(key string) bool {
+	return strings.HasPrefix(key, CollectionRowPolicyKeyPrefix)
+}
+
+// GetCollectionRowPolicies returns the row policy expressions of the collection, keyed by role name.
+func GetCollectionRowPolicies(kvs ...*commonpb.KeyValuePair) map[string]string {
+	policies := make(map[string]string)
+	for _, kv := range kvs {
+		if IsCollectionRowPolicyKey(kv.GetKey()) {
+			policies[strings.TrimPrefix(kv.GetKey(), CollectionRowPolicyKeyPrefix)] = kv.GetValue()
+		}
+	}
+	return policies
+}
+
+// RecycleBinSnapshotName returns the name of the recycle bin snapshot taken before dropping
+// the collection, or the partition of it if partitionName is not empty.
+// The name is formatted as <prefix><collectionID>_<drop unix seconds>[_<partitionName>].
+func RecycleBinSnapshotName(collectionID int64, partitionName string, dropTime time.Time) string {
+	name := RecycleBinSnapshotPrefix + strconv.FormatInt(collectionID, 10) + "_" + strconv.FormatInt(dropTime.Unix(), 10)
+	if partitionName != "" {
+		name += "_" + partitionName
+	}
+	return name
+}
+
+// ParseRecycleBinSnapshotName parses the recycle bin snapshot name generated by RecycleBinSnapshotName,
+// ok is false if the name is not a recycle bin snapshot.
+func ParseRecycleBinSnapshotName(name string) (collectionID int64, partitionName string, dropTime time.Time, ok bool) {
+	if !strings.HasPrefix(name, RecycleBinSnapshotPrefix) {
+		return 0, "", time.Time{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(name, RecycleBinSnapshotPrefix), "_", 3)
+	if len(parts) < 2 {
+		return 0, "", time.Time{}, false
+	}
+	collectionID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", time.Time{}, false
+	}
+	dropUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", time.Time{}, false
+	}
+	if len(parts) == 3 {
+		partitionName = parts[2]
+	}
+	return collectionID, partitionName, time.Unix(dropUnix, 0), true
+}
+
 func IsPartitionKeyIsolationPropEnabled(props map[string]string) (bool, error) {
 	val, ok := props[PartitionKeyIsolationKey]
 	if !ok {
@@ -315,6 +315,46 @@ func TestIsDisableFuncRuntimeCheck(t *testing.T) {
 	assert.False(t, disable)
 }
 
+func TestCollectionRowPolicies(t *testing.T) {
+	assert.True(t, IsCollectionRowPolicyKey(CollectionRowPolicyKeyPrefix+"tenant"))
+	assert.False(t, IsCollectionRowPolicyKey(CollectionTTLConfigKey))
+
+	policies := GetCollectionRowPolicies([]*commonpb.KeyValuePair{
+		{Key: CollectionRowPolicyKeyPrefix + "tenant_a", Value: "tenant_id == 1"},
+		{Key: CollectionRowPolicyKeyPrefix + "tenant_b", Value: "tenant_id in [2, 3]"},
+		{Key: CollectionTTLConfigKey, Value: "100"},
+	}...)
+	assert.Equal(t, map[string]string{
+		"tenant_a": "tenant_id == 1",
+		"tenant_b": "tenant_id in [2, 3]",
+	}, policies)
+	assert.Empty(t, GetCollectionRowPolicies())
+}
+
+func TestRecycleBinSnapshotName(t *testing.T) {
+	dropTime := time.Unix(1700000000, 0)
+
+	name := RecycleBinSnapshotName(100, "", dropTime)
+	assert.Equal(t, "__recycle_bin__100_1700000000", name)
+	collectionID, partitionName, ts, ok := ParseRecycleBinSnapshotName(name)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), collectionID)
+	assert.Empty(t, partitionName)
+	assert.Equal(t, dropTime, ts)
+
+	name = RecycleBinSnapshotName(100, "part_2024_01", dropTime)
+	collectionID, partitionName, ts, ok = ParseRecycleBinSnapshotName(name)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), collectionID)
+	assert.Equal(t, "part_2024_01", partitionName)
+	assert.Equal(t, dropTime, ts)
+
+	for _, name := range []string{"backup", RecycleBinSnapshotPrefix, RecycleBinSnapshotPrefix + "100", RecycleBinSnapshotPrefix + "a_1", RecycleBinSnapshotPrefix + "100_b"} {
+		_, _, _, ok = ParseRecycleBinSnapshotName(name)
+		assert.False(t, ok, name)
+	}
+}
+
 func TestGetCollectionTTL(t *testing.T) {
 	type testCase struct {
 		tag       string
@@ -71,6 +71,19 @@ const (
 	PreferredNodeRejectedLabel    = "rejected"
 )
 
+const (
+	HedgeIssuedLabel          = "issued"
+	HedgeWonLabel             = "won"
+	HedgeLostLabel            = "lost"
+	HedgeBudgetExhaustedLabel = "budget_exhausted"
+	HedgeNoReplicaLabel       = "no_replica"
+)
+
+const (
+	UserRateLimitScope = "user"
+	RoleRateLimitScope = "role"
+)
+
 const (
 	UnissuedIndexTaskLabel   = "unissued"
 	InProgressIndexTaskLabel = "in-progress"
@@ -162,6 +175,7 @@ const (
 	queueTypeLabelName             = `queue_type`
 	poolNameLabelName              = "pool_name"
 	outcomeLabelName               = "outcome"
+	workloadClassLabelName         = "workload_class"
 
 	// model function/UDF labels
 	functionTypeName = "function_type_name"
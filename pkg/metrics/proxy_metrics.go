@@ -376,6 +376,53 @@ var (
 			statusLabelName,
 		})
 
+	// ProxyShardRequestHedgeCount records hedged shard request results.
+	ProxyShardRequestHedgeCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "shard_request_hedge_count",
+			Help:      "counter of hedged shard requests, issued/won/lost/budget_exhausted/no_replica",
+		}, []string{
+			statusLabelName,
+		})
+
+	// ProxyShardRequestHedgeDelay records the latency threshold to hedge shard requests of each channel.
+	ProxyShardRequestHedgeDelay = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "shard_request_hedge_delay",
+			Help:      "latency threshold in ms to send the hedged shard request",
+		}, []string{channelNameLabelName})
+
+	// ProxyResultCacheSize records the memory size of the cached search and query results.
+	ProxyResultCacheSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "result_cache_size",
+			Help:      "memory size in bytes of the cached search and query results",
+		}, []string{nodeIDLabelName})
+
+	// ProxyResultCacheEntryNum records the number of the cached search and query results.
+	ProxyResultCacheEntryNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "result_cache_entry_num",
+			Help:      "number of the cached search and query results",
+		}, []string{nodeIDLabelName})
+
+	// ProxyResultCacheEvictCount records the number of evicted results from the result cache.
+	ProxyResultCacheEvictCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "result_cache_evict_count",
+			Help:      "counter of evicted results from the result cache, capacity/expired/dml/meta",
+		}, []string{nodeIDLabelName, reasonLabelName})
+
 	// ProxyRateLimitReqCount integrates a counter monitoring metric for the rate-limit rpc requests.
 	ProxyRateLimitReqCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -385,6 +432,16 @@ var (
 			Help:      "count of operation executed",
 		}, []string{nodeIDLabelName, msgTypeLabelName, statusLabelName})
 
+	// ProxyUserRateLimitRejectCount counts the requests rejected by the rate limiters of user or role,
+	// the user name is not a label to keep the cardinality bounded, the role name is empty for user scope.
+	ProxyUserRateLimitRejectCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "user_rate_limit_reject_count",
+			Help:      "count of requests rejected by the rate limiters of user or role",
+		}, []string{nodeIDLabelName, requestScope, roleNameLabelName, msgTypeLabelName})
+
 	ProxySlowQueryCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: milvusNamespace,
@@ -544,7 +601,13 @@ func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxyWorkLoadScore)
 	registry.MustRegister(ProxyExecutingTotalNq)
 	registry.MustRegister(ProxyShardLeaderPreferredNodeCount)
+	registry.MustRegister(ProxyShardRequestHedgeCount)
+	registry.MustRegister(ProxyShardRequestHedgeDelay)
+	registry.MustRegister(ProxyResultCacheSize)
+	registry.MustRegister(ProxyResultCacheEntryNum)
+	registry.MustRegister(ProxyResultCacheEvictCount)
 	registry.MustRegister(ProxyRateLimitReqCount)
+	registry.MustRegister(ProxyUserRateLimitRejectCount)
 
 	registry.MustRegister(ProxySlowQueryCount)
 	registry.MustRegister(ProxyReportValue)
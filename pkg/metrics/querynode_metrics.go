@@ -367,6 +367,62 @@ var (
 			nodeIDLabelName,
 		})
 
+	QueryNodeWorkloadClassReadyLen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "workload_class_ready_len",
+			Help:      "number of ready read tasks of each workload class in scheduler queue",
+		}, []string{
+			nodeIDLabelName,
+			workloadClassLabelName,
+		})
+
+	QueryNodeWorkloadClassRunning = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "workload_class_running",
+			Help:      "number of scheduled read tasks of each workload class not finished yet",
+		}, []string{
+			nodeIDLabelName,
+			workloadClassLabelName,
+		})
+
+	QueryNodeWorkloadClassQueueDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "workload_class_queue_duration",
+			Help:      "duration in milliseconds that read tasks of each workload class stay in scheduler queue before scheduled",
+			Buckets:   subMsBuckets,
+		}, []string{
+			nodeIDLabelName,
+			workloadClassLabelName,
+		})
+
+	QueryNodeWorkloadClassSLOViolationCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "workload_class_slo_violation_count",
+			Help:      "count of read tasks scheduled after the queue time slo of their workload class",
+		}, []string{
+			nodeIDLabelName,
+			workloadClassLabelName,
+		})
+
+	QueryNodeWorkloadClassPreemptedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "workload_class_preempted_count",
+			Help:      "count of queued read tasks of each workload class preempted by higher priority workload",
+		}, []string{
+			nodeIDLabelName,
+			workloadClassLabelName,
+		})
+
 	QueryNodeEstimateCPUUsage = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: milvusNamespace,
@@ -1025,6 +1081,11 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeReadTaskQueueDuration)
 	registry.MustRegister(QueryNodeReadTaskExecuteDuration)
 	registry.MustRegister(QueryNodeReadTaskConcurrency)
+	registry.MustRegister(QueryNodeWorkloadClassReadyLen)
+	registry.MustRegister(QueryNodeWorkloadClassRunning)
+	registry.MustRegister(QueryNodeWorkloadClassQueueDuration)
+	registry.MustRegister(QueryNodeWorkloadClassSLOViolationCount)
+	registry.MustRegister(QueryNodeWorkloadClassPreemptedCount)
 	registry.MustRegister(QueryNodeEstimateCPUUsage)
 	registry.MustRegister(QueryNodeSearchGroupNQ)
 	registry.MustRegister(QueryNodeSearchNQ)
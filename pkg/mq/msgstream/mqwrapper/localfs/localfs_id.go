@@ -0,0 +1,82 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localfs
+
+import (
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	mqcommon "github.com/milvus-io/milvus/pkg/v3/mq/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// LocalFSID is the msgstream message id of the localfs wal.
+// The localfs wal has no msgstream implementation, the id is only used to convert the positions.
+type LocalFSID struct {
+	MessageID int64
+}
+
+// Check if LocalFSID implements MessageID interface
+var _ mqcommon.MessageID = &LocalFSID{}
+
+// NewLocalFSID creates a new LocalFSID.
+func NewLocalFSID(id int64) *LocalFSID {
+	return &LocalFSID{MessageID: id}
+}
+
+// LocalFSID returns the logical offset of the message.
+func (lid *LocalFSID) LocalFSID() int64 {
+	return lid.MessageID
+}
+
+// Serialize convert localfs message id to []byte
+func (lid *LocalFSID) Serialize() []byte {
+	return SerializeLocalFSID(lid.MessageID)
+}
+
+func (lid *LocalFSID) AtEarliestPosition() bool {
+	return lid.MessageID <= 0
+}
+
+func (lid *LocalFSID) LessOrEqualThan(msgID []byte) (bool, error) {
+	id, err := DeserializeLocalFSID(msgID)
+	if err != nil {
+		return false, err
+	}
+	return lid.MessageID <= id, nil
+}
+
+func (lid *LocalFSID) Equal(msgID []byte) (bool, error) {
+	id, err := DeserializeLocalFSID(msgID)
+	if err != nil {
+		return false, err
+	}
+	return lid.MessageID == id, nil
+}
+
+// SerializeLocalFSID is used to serialize a message ID to byte array
+func SerializeLocalFSID(messageID int64) []byte {
+	b := make([]byte, 8)
+	common.Endian.PutUint64(b, uint64(messageID))
+	return b
+}
+
+// DeserializeLocalFSID is used to deserialize a message ID from byte array
+func DeserializeLocalFSID(messageID []byte) (int64, error) {
+	if len(messageID) != 8 {
+		return 0, merr.WrapErrParameterInvalidMsg("invalid localfs message id length %d, expected 8", len(messageID))
+	}
+	return int64(common.Endian.Uint64(messageID)), nil
+}
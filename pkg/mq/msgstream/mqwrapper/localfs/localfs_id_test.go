@@ -0,0 +1,48 @@
+package localfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestLocalFSID(t *testing.T) {
+	lid := NewLocalFSID(8)
+	assert.Equal(t, int64(8), lid.LocalFSID())
+	assert.False(t, lid.AtEarliestPosition())
+	assert.True(t, NewLocalFSID(0).AtEarliestPosition())
+
+	ret, err := lid.LessOrEqualThan(NewLocalFSID(9).Serialize())
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, err = lid.LessOrEqualThan(NewLocalFSID(7).Serialize())
+	assert.NoError(t, err)
+	assert.False(t, ret)
+
+	ret, err = lid.Equal(lid.Serialize())
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, err = lid.Equal(NewLocalFSID(7).Serialize())
+	assert.NoError(t, err)
+	assert.False(t, ret)
+}
+
+func TestDeserializeLocalFSID(t *testing.T) {
+	id, err := DeserializeLocalFSID(SerializeLocalFSID(10))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), id)
+
+	// the short or long input is rejected rather than panic
+	for _, bs := range [][]byte{nil, {1, 2, 3}, make([]byte, 9)} {
+		_, err = DeserializeLocalFSID(bs)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	}
+
+	lid := NewLocalFSID(1)
+	_, err = lid.LessOrEqualThan([]byte{1})
+	assert.Error(t, err)
+	_, err = lid.Equal([]byte{1})
+	assert.Error(t, err)
+}
@@ -12,10 +12,12 @@ import (
 	rawRocksmq "github.com/milvus-io/milvus/pkg/v3/mq/mqimpl/rocksmq/client"
 	"github.com/milvus-io/milvus/pkg/v3/mq/mqimpl/rocksmq/server"
 	mqkafka "github.com/milvus-io/milvus/pkg/v3/mq/msgstream/mqwrapper/kafka"
+	mqlocalfs "github.com/milvus-io/milvus/pkg/v3/mq/msgstream/mqwrapper/localfs"
 	mqpulsar "github.com/milvus-io/milvus/pkg/v3/mq/msgstream/mqwrapper/pulsar"
 	mqwoodpecker "github.com/milvus-io/milvus/pkg/v3/mq/msgstream/mqwrapper/wp"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
 	msgkafka "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/kafka"
+	msglocalfs "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/localfs"
 	msgpulsar "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/pulsar"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/rmq"
 	msgwoodpecker "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/wp"
@@ -34,6 +36,8 @@ func MustGetMQWrapperIDFromMessage(messageID message.MessageID) common.MessageID
 		return mqkafka.NewKafkaID(int64(id.KafkaID()))
 	} else if id, ok := messageID.(interface{ WoodpeckerID() *rawWP.LogMessageId }); ok {
 		return mqwoodpecker.NewWoodpeckerID(id.WoodpeckerID())
+	} else if id, ok := messageID.(interface{ LocalFSID() int64 }); ok {
+		return mqlocalfs.NewLocalFSID(id.LocalFSID())
 	}
 	panic("unsupported now")
 }
@@ -47,6 +51,8 @@ func MustGetMQWrapperIDAndWALNameFromMessage(messageID message.MessageID) (commo
 		return mqkafka.NewKafkaID(int64(id.KafkaID())), commonpb.WALName_Kafka
 	} else if id, ok := messageID.(interface{ WoodpeckerID() *rawWP.LogMessageId }); ok {
 		return mqwoodpecker.NewWoodpeckerID(id.WoodpeckerID()), commonpb.WALName_WoodPecker
+	} else if id, ok := messageID.(interface{ LocalFSID() int64 }); ok {
+		return mqlocalfs.NewLocalFSID(id.LocalFSID()), commonpb.WALName(message.WALNameLocalFS)
 	}
 	panic("unsupported now")
 }
@@ -62,6 +68,8 @@ func MustGetMessageIDFromMQWrapperID(commonMessageID common.MessageID) message.M
 		return msgkafka.NewKafkaID(rawKafka.Offset(id.MessageID))
 	} else if id, ok := commonMessageID.(interface{ WoodpeckerID() *rawWP.LogMessageId }); ok {
 		return msgwoodpecker.NewWpID(id.WoodpeckerID())
+	} else if id, ok := commonMessageID.(*mqlocalfs.LocalFSID); ok {
+		return msglocalfs.NewLocalFSID(id.MessageID)
 	}
 	return nil
 }
@@ -88,6 +96,12 @@ func DeserializeToMQWrapperID(msgID []byte, walName string) (common.MessageID, e
 			return nil, err
 		}
 		return mqwoodpecker.NewWoodpeckerID(wID), nil
+	case message.WALNameLocalFS.String():
+		lID, err := mqlocalfs.DeserializeLocalFSID(msgID)
+		if err != nil {
+			return nil, err
+		}
+		return mqlocalfs.NewLocalFSID(lID), nil
 	default:
 		return nil, merr.WrapErrParameterInvalidMsg("unsupported mq type %s", walName)
 	}
@@ -118,6 +132,12 @@ func MustGetMessageIDFromMQWrapperIDBytesWithWALName(walName message.WALName, ms
 			panic(err)
 		}
 		commonMsgID = mqwoodpecker.NewWoodpeckerID(msgID)
+	case message.WALNameLocalFS:
+		id, err := mqlocalfs.DeserializeLocalFSID(msgIDBytes)
+		if err != nil {
+			panic(err)
+		}
+		commonMsgID = mqlocalfs.NewLocalFSID(id)
 	default:
 		panic("unsupported now")
 	}
@@ -138,6 +158,9 @@ func MustGetEarliestMessageIDFromMQType(walName commonpb.WALName) (common.Messag
 	case commonpb.WALName_WoodPecker:
 		wID := rawWP.EarliestLogMessageID()
 		return mqwoodpecker.NewWoodpeckerID(&wID), commonpb.WALName_WoodPecker
+	case commonpb.WALName(message.WALNameLocalFS):
+		// the logical offset of localfs wal starts from 0.
+		return mqlocalfs.NewLocalFSID(0), commonpb.WALName(message.WALNameLocalFS)
 	default:
 		panic(fmt.Sprintf("unsupported mq type %s", walName))
 	}
@@ -1,16 +1,27 @@
 package adaptor
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	wp "github.com/zilliztech/woodpecker/woodpecker/log"
 
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/options"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
 	msgkafka "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/kafka"
+	msglocalfs "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/localfs"
 	msgpulsar "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/pulsar"
 	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/rmq"
 	msgwoodpecker "github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/impls/wp"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/registry"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
 func TestIDConvension(t *testing.T) {
@@ -27,4 +38,74 @@ func TestIDConvension(t *testing.T) {
 	logMsgId := wp.EarliestLogMessageID()
 	wpID := MustGetMessageIDFromMQWrapperID(MustGetMQWrapperIDFromMessage(msgwoodpecker.NewWpID(&logMsgId)))
 	assert.True(t, wpID.EQ(msgwoodpecker.NewWpID(&logMsgId)))
+
+	localfsID := MustGetMessageIDFromMQWrapperID(MustGetMQWrapperIDFromMessage(msglocalfs.NewLocalFSID(1)))
+	assert.True(t, localfsID.EQ(msglocalfs.NewLocalFSID(1)))
+
+	earliest, walName := MustGetEarliestMessageIDFromMQType(commonpb.WALName(message.WALNameLocalFS))
+	assert.Equal(t, commonpb.WALName(message.WALNameLocalFS), walName)
+	assert.True(t, earliest.AtEarliestPosition())
+}
+
+// TestLocalFSCreateCollectionAndFlush runs the id conversions of create collection and flush over a localfs wal.
+func TestLocalFSCreateCollectionAndFlush(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.LocalFSCfg.Path.Key, t.TempDir())
+	defer params.Reset(params.LocalFSCfg.Path.Key)
+
+	ctx := context.Background()
+	opener, err := registry.MustGetBuilder(message.WALNameLocalFS).Build()
+	require.NoError(t, err)
+	defer opener.Close()
+	w, err := opener.Open(ctx, &walimpls.OpenOption{
+		Channel: types.PChannelInfo{Name: "localfs-adaptor", Term: 1, AccessMode: types.AccessModeRW},
+	})
+	require.NoError(t, err)
+	defer w.Close()
+
+	tt := uint64(time.Now().UnixNano())
+	createCollection := message.CreateTestCreateCollectionMessage(t, 1, tt, msglocalfs.NewLocalFSID(0)).WithLastConfirmedUseMessageID()
+	createCollectionID, err := w.Append(ctx, createCollection)
+	require.NoError(t, err)
+
+	// the create collection callback of rootcoord takes the start position from the append result.
+	startPosition, walName := MustGetMQWrapperIDAndWALNameFromMessage(createCollectionID)
+	assert.Equal(t, commonpb.WALName(message.WALNameLocalFS), walName)
+	startFrom := MustGetMessageIDFromMQWrapperIDBytesWithWALName(message.WALNameLocalFS, startPosition.Serialize())
+	assert.True(t, startFrom.EQ(createCollectionID))
+
+	flush, err := message.NewFlushMessageBuilderV2().
+		WithVChannel("v1").
+		WithHeader(&message.FlushMessageHeader{}).
+		WithBody(&message.FlushMessageBody{}).
+		BuildMutable()
+	require.NoError(t, err)
+	flushID, err := w.Append(ctx, flush.WithTimeTick(tt+1).WithLastConfirmedUseMessageID())
+	require.NoError(t, err)
+
+	s, err := w.Read(ctx, walimpls.ReadOption{Name: "localfs-adaptor", DeliverPolicy: options.DeliverPolicyAll()})
+	require.NoError(t, err)
+	defer s.Close()
+	for _, expected := range []message.MessageID{createCollectionID, flushID} {
+		var msg message.ImmutableMessage
+		select {
+		case msg = <-s.Chan():
+		case <-time.After(5 * time.Second):
+			t.Fatal("read message timeout")
+		}
+		assert.True(t, msg.MessageID().EQ(expected))
+
+		// the flusher consumes the messages as msg pack, and recovers from the checkpoint of the position.
+		pack, err := NewMsgPackFromMessage(msg)
+		require.NoError(t, err)
+		position := pack.Msgs[0].Position()
+		assert.Equal(t, commonpb.WALName(message.WALNameLocalFS), position.GetWALName())
+		checkpoint := MustGetMessageIDFromMQWrapperIDBytesWithWALName(message.WALName(position.GetWALName()), position.GetMsgID())
+		assert.True(t, checkpoint.EQ(msg.LastConfirmedMessageID()))
+
+		mqID, err := DeserializeToMQWrapperID(position.GetMsgID(), message.WALNameLocalFS.String())
+		require.NoError(t, err)
+		assert.True(t, MustGetMessageIDFromMQWrapperID(mqID).EQ(msg.LastConfirmedMessageID()))
+	}
 }
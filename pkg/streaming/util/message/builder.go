@@ -126,11 +126,12 @@ func newMutableMessageBuilder[H proto.Message, B proto.Message]() *mutableMesasg
 
 // mutableMesasgeBuilder is the builder for message.
 type mutableMesasgeBuilder[H proto.Message, B proto.Message] struct {
-	header       H
-	body         B
-	properties   propertiesImpl
-	cipherConfig *CipherConfig
-	allVChannel  bool
+	header         H
+	body           B
+	properties     propertiesImpl
+	cipherConfig   *CipherConfig
+	compressConfig *CompressionConfig
+	allVChannel    bool
 }
 
 // WithMessageHeader creates a new builder with determined message type.
@@ -280,6 +281,13 @@ func (b *mutableMesasgeBuilder[H, B]) WithCipher(cipherConfig *CipherConfig) *mu
 	return b
 }
 
+// WithCompression creates a new builder with compression property.
+// The payload is compressed before encryption if the cipher is enabled.
+func (b *mutableMesasgeBuilder[H, B]) WithCompression(compressConfig *CompressionConfig) *mutableMesasgeBuilder[H, B] {
+	b.compressConfig = compressConfig
+	return b
+}
+
 // BuildMutable builds a mutable message.
 // Panic if not set payload and message type.
 // should only used at client side.
@@ -351,6 +359,17 @@ func (b *mutableMesasgeBuilder[H, B]) build() (*messageImpl, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal body")
 	}
+	if b.compressConfig != nil {
+		payloadBytes := len(payload)
+		compressed, ok, err := compressPayload(b.compressConfig, payload)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			payload = compressed
+			setCompressProperties(b.properties, b.compressConfig.Type, payloadBytes)
+		}
+	}
 	if b.cipherConfig != nil {
 		messageType := MustGetMessageTypeWithVersion[H, B]()
 		if !messageType.MessageType.CanEnableCipher() {
@@ -0,0 +1,86 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
+)
+
+// CompressionConfig is the configuration for compressing the message payload.
+type CompressionConfig struct {
+	// Type is the compress type of the payload.
+	Type compressor.CompressType
+
+	// MinPayloadBytes is the minimum payload size to compress,
+	// the smaller payload is kept uncompressed because the gain can not cover the cost.
+	MinPayloadBytes int
+}
+
+// compressPayload compresses the payload with the config.
+// The original payload is returned with ok=false if it's too small or can not be compressed smaller.
+func compressPayload(cfg *CompressionConfig, payload []byte) ([]byte, bool, error) {
+	if len(payload) < cfg.MinPayloadBytes {
+		return payload, false, nil
+	}
+	compressed, err := compressor.CompressBytes(cfg.Type, payload, nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to compress payload")
+	}
+	if len(compressed) >= len(payload) {
+		return payload, false, nil
+	}
+	return compressed, true, nil
+}
+
+// setCompressProperties records the compress type and the payload size before compression.
+func setCompressProperties(properties propertiesImpl, typ compressor.CompressType, payloadBytes int) {
+	properties.Set(messageCompressType, string(typ))
+	properties.Set(messageCompressPayloadBytes, EncodeInt64(int64(payloadBytes)))
+}
+
+// unsetCompressProperties removes the compression properties, the payload is not compressed.
+func unsetCompressProperties(properties propertiesImpl) {
+	delete(properties, messageCompressType)
+	delete(properties, messageCompressPayloadBytes)
+}
+
+// compressType returns the compress type of the payload, false if the payload is not compressed.
+func (m *messageImpl) compressType() (compressor.CompressType, bool) {
+	value, ok := m.properties.Get(messageCompressType)
+	if !ok {
+		return "", false
+	}
+	return compressor.CompressType(value), true
+}
+
+// compressPayloadBytes returns the payload size before compression.
+func (m *messageImpl) compressPayloadBytes() (int, bool) {
+	value, ok := m.properties.Get(messageCompressPayloadBytes)
+	if !ok {
+		return 0, false
+	}
+	payloadBytes, err := DecodeInt64(value)
+	if err != nil {
+		panic(fmt.Sprintf("can not decode compress payload bytes: %s", err))
+	}
+	return int(payloadBytes), true
+}
+
+// decompressPayload decompresses the payload if it is compressed.
+func (m *messageImpl) decompressPayload(payload []byte) []byte {
+	typ, ok := m.compressType()
+	if !ok {
+		return payload
+	}
+	var dst []byte
+	if payloadBytes, ok := m.compressPayloadBytes(); ok {
+		dst = make([]byte, 0, payloadBytes)
+	}
+	payload, err := compressor.DecompressBytes(typ, payload, dst)
+	if err != nil {
+		panic(fmt.Sprintf("can not decompress message: %s", err))
+	}
+	return payload
+}
@@ -0,0 +1,97 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/compressor"
+)
+
+func TestCompression(t *testing.T) {
+	body := &msgpb.InsertRequest{
+		ShardName: strings.Repeat("compressible shard name ", 100),
+	}
+	uncompressed := NewInsertMessageBuilderV1().
+		WithHeader(&InsertMessageHeader{}).
+		WithBody(body).
+		WithVChannel("v1").
+		MustBuildMutable()
+
+	for _, typ := range []compressor.CompressType{compressor.CompressTypeZstd, compressor.CompressTypeLZ4, compressor.CompressTypeSnappy} {
+		t.Run(string(typ), func(t *testing.T) {
+			msg := NewInsertMessageBuilderV1().
+				WithHeader(&InsertMessageHeader{}).
+				WithBody(body).
+				WithVChannel("v1").
+				WithCompression(&CompressionConfig{Type: typ, MinPayloadBytes: 1024}).
+				MustBuildMutable()
+			value, ok := msg.Properties().Get(messageCompressType)
+			assert.True(t, ok)
+			assert.Equal(t, string(typ), value)
+			assert.Less(t, len(msg.IntoMessageProto().Payload), len(uncompressed.Payload()))
+			assert.Equal(t, uncompressed.Payload(), msg.Payload())
+			// the estimated size is the size before compression plus the compression properties.
+			compressProps := len(messageCompressType) + len(typ) + len(messageCompressPayloadBytes) + len(EncodeInt64(int64(len(uncompressed.Payload()))))
+			assert.Equal(t, uncompressed.EstimateSize()+compressProps, msg.EstimateSize())
+
+			insertMsg := MustAsMutableInsertMessageV1(msg)
+			got, err := insertMsg.Body()
+			require.NoError(t, err)
+			assert.Equal(t, body.ShardName, got.ShardName)
+
+			// immutable message keeps the compression
+			immutableMsg := NewImmutableMesasge(testMessageID("1"), msg.IntoMessageProto().Payload, msg.Properties().ToRawMap())
+			got, err = MustAsImmutableInsertMessageV1(immutableMsg).Body()
+			require.NoError(t, err)
+			assert.Equal(t, body.ShardName, got.ShardName)
+
+			// overwrite body keeps the compress type
+			insertMsg.OverwriteBody(&msgpb.InsertRequest{ShardName: strings.Repeat("overwritten ", 200)})
+			value, ok = insertMsg.Properties().Get(messageCompressType)
+			assert.True(t, ok)
+			assert.Equal(t, string(typ), value)
+			got, err = insertMsg.Body()
+			require.NoError(t, err)
+			assert.Equal(t, strings.Repeat("overwritten ", 200), got.ShardName)
+
+			// overwritten body that can not be compressed smaller is kept uncompressed
+			insertMsg.OverwriteBody(&msgpb.InsertRequest{ShardName: "a"})
+			assert.False(t, insertMsg.Properties().Exist(messageCompressType))
+			assert.False(t, insertMsg.Properties().Exist(messageCompressPayloadBytes))
+			got, err = insertMsg.Body()
+			require.NoError(t, err)
+			assert.Equal(t, "a", got.ShardName)
+		})
+	}
+
+	// small payload is not compressed
+	msg := NewInsertMessageBuilderV1().
+		WithHeader(&InsertMessageHeader{}).
+		WithBody(&msgpb.InsertRequest{ShardName: "short"}).
+		WithVChannel("v1").
+		WithCompression(&CompressionConfig{Type: compressor.CompressTypeLZ4, MinPayloadBytes: 1024}).
+		MustBuildMutable()
+	assert.False(t, msg.Properties().Exist(messageCompressType))
+
+	// unknown compress type
+	_, err := NewInsertMessageBuilderV1().
+		WithHeader(&InsertMessageHeader{}).
+		WithBody(body).
+		WithVChannel("v1").
+		WithCompression(&CompressionConfig{Type: "gzip"}).
+		BuildMutable()
+	assert.Error(t, err)
+
+	// corrupted payload
+	corrupted := NewImmutableMesasge(testMessageID("1"), []byte("corrupted"), map[string]string{
+		messageTypeKey:      MessageTypeInsert.marshal(),
+		messageCompressType: string(compressor.CompressTypeLZ4),
+	})
+	assert.Panics(t, func() {
+		corrupted.Payload()
+	})
+}
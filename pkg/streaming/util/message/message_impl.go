@@ -42,6 +42,7 @@ func (m *messageImpl) MessageTypeWithVersion() MessageTypeWithVersion {
 
 // Payload returns payload of current message.
 // If the message is encrypted, it will be decrypted with automatic retry for transient KMS errors.
+// If the message is compressed, it will be decompressed after decryption.
 func (m *messageImpl) Payload() []byte {
 	if ch := m.cipherHeader(); ch != nil {
 		// Use getDecryptorWithRetry for resilient decryption with automatic retry
@@ -54,9 +55,9 @@ func (m *messageImpl) Payload() []byte {
 		if err != nil {
 			panic(fmt.Sprintf("can not decrypt message: %s", err))
 		}
-		return payload
+		return m.decompressPayload(payload)
 	}
-	return m.payload
+	return m.decompressPayload(m.payload)
 }
 
 // Properties returns the message properties.
@@ -89,6 +90,10 @@ func (m *messageImpl) IntoMessageProto() *messagespb.Message {
 
 // EstimateSize returns the estimated size of current message.
 func (m *messageImpl) EstimateSize() int {
+	if payloadBytes, ok := m.compressPayloadBytes(); ok {
+		// if it's a compressed message, we need to estimate the size of payload before compression.
+		return payloadBytes + m.properties.EstimateSize()
+	}
 	if ch := m.cipherHeader(); ch != nil {
 		// if it's a cipher message, we need to estimate the size of payload before encryption.
 		return int(ch.PayloadBytes) + m.properties.EstimateSize()
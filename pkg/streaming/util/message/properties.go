@@ -14,13 +14,20 @@ const (
 	messageHeader                           = "_h"   // specialized message header.
 	messageTxnContext                       = "_tx"  // transaction context.
 	messageCipherHeader                     = "_ch"  // message cipher header.
+	messageCompressType                     = "_cp"  // compress type of the message payload.
+	messageCompressPayloadBytes             = "_cpb" // payload size before compression.
 	messageNotPersisteted                   = "_np"  // check if the message is unpersisted.
 	messagePChannelLevel                    = "_pcl" // mark the message as pchannel level message.
 	messageReplicateMesssageHeader          = "_rh"  // replicate message header.
 	messageUnreplicable                     = "_ur"  // mark the message as unsafe to replicate.
 	messageTraceContext                     = "_tc"  // Trace context subset header.
+	messageUpsert                           = "_up"  // mark the insert and delete messages written by upsert.
 )
 
+// PropertyUpsert marks the insert and delete messages written by upsert,
+// so the consumers of wal could tell the upserted rows from the inserted and deleted ones.
+const PropertyUpsert = messageUpsert
+
 var (
 	_ RProperties = propertiesImpl{}
 	_ Properties  = propertiesImpl{}
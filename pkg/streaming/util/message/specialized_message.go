@@ -189,6 +189,20 @@ func (m *specializedMutableMessageImpl[H, B]) OverwriteBody(body B) {
 	if err != nil {
 		panic(fmt.Sprintf("failed to marshal specialized body, %s", err.Error()))
 	}
+	if typ, ok := m.compressType(); ok {
+		// keep the compress type of the message, the payload may be stored uncompressed if it can not be compressed smaller.
+		payloadBytes := len(payload)
+		compressed, ok, err := compressPayload(&CompressionConfig{Type: typ}, payload)
+		if err != nil {
+			panic(fmt.Sprintf("failed to compress overwritten specialized body, %s", err.Error()))
+		}
+		if ok {
+			payload = compressed
+			setCompressProperties(m.properties, typ, payloadBytes)
+		} else {
+			unsetCompressProperties(m.properties)
+		}
+	}
 	if ch := m.cipherHeader(); ch != nil {
 		cipher := mustGetCipher()
 		encryptor, safeKey, err := cipher.GetEncryptor(ch.EzId, ch.CollectionId)
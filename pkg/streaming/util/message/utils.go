@@ -3,7 +3,10 @@ package message
 import (
 	"reflect"
 
+	"github.com/cockroachdb/errors"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 )
 
 // AsImmutableTxnMessage converts an ImmutableMessage to ImmutableTxnMessage
@@ -43,6 +46,29 @@ func GetMessageTypeWithVersion[H proto.Message, B proto.Message]() (MessageTypeW
 	return mv, ok
 }
 
+// UnmarshalSpecializedMessage decodes the specialized header and body of the message
+// into the proto types registered for its message type and version.
+// It's used by the consumers that handle the messages without knowing the specialized type at compile time.
+func UnmarshalSpecializedMessage(msg BasicMessage) (proto.Message, proto.Message, error) {
+	typ, ok := GetSerializeType(msg.MessageTypeWithVersion())
+	if !ok {
+		return nil, nil, merr.WrapErrParameterInvalidMsg("message type %s version %d is not specialized", msg.MessageType(), msg.Version())
+	}
+	val, ok := msg.Properties().Get(messageHeader)
+	if !ok {
+		return nil, nil, merr.WrapErrServiceInternalMsg("lost specialized header, %s", msg.MessageType())
+	}
+	header := reflect.New(typ.HeaderType.Elem()).Interface().(proto.Message)
+	if err := DecodeProto(val, header); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode specialized header")
+	}
+	body := reflect.New(typ.BodyType.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(msg.Payload(), body); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode specialized body")
+	}
+	return header, body, nil
+}
+
 // MustGetMessageTypeWithVersion returns the message type with version for the given message type and version, panics on error.
 func MustGetMessageTypeWithVersion[H proto.Message, B proto.Message]() MessageTypeWithVersion {
 	mv, ok := GetMessageTypeWithVersion[H, B]()
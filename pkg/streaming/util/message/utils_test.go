@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/msgpb"
 )
 
 func TestClearReplicateHeader(t *testing.T) {
@@ -40,3 +43,29 @@ func TestClearReplicateHeader(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestUnmarshalSpecializedMessage(t *testing.T) {
+	msg := NewDropCollectionMessageBuilderV1().
+		WithHeader(&DropCollectionMessageHeader{CollectionId: 1}).
+		WithBody(&msgpb.DropCollectionRequest{CollectionName: "coll"}).
+		WithVChannel("v1").
+		MustBuildMutable()
+	header, body, err := UnmarshalSpecializedMessage(msg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), header.(*DropCollectionMessageHeader).GetCollectionId())
+	assert.Equal(t, "coll", body.(*msgpb.DropCollectionRequest).GetCollectionName())
+
+	// lost header
+	props := msg.Properties().ToRawMap()
+	delete(props, messageHeader)
+	_, _, err = UnmarshalSpecializedMessage(NewMutableMessageBeforeAppend(msg.Payload(), props))
+	assert.Error(t, err)
+
+	// corrupted body
+	_, _, err = UnmarshalSpecializedMessage(NewMutableMessageBeforeAppend([]byte("corrupted"), msg.Properties().ToRawMap()))
+	assert.Error(t, err)
+
+	// unknown message type
+	_, _, err = UnmarshalSpecializedMessage(NewMutableMessageBeforeAppend([]byte("payload"), map[string]string{}))
+	assert.Error(t, err)
+}
@@ -15,6 +15,10 @@ const (
 	WALNamePulsar     WALName = WALName(commonpb.WALName_Pulsar)
 	WALNameWoodpecker WALName = WALName(commonpb.WALName_WoodPecker)
 	WALNameTest       WALName = WALName(commonpb.WALName_Test)
+
+	// WALNameLocalFS is not declared in commonpb.WALName yet,
+	// the value is reserved here and is carried as an unknown enum value by the proto.
+	WALNameLocalFS WALName = 5
 )
 
 var defaultWALName = atomic.NewPointer[WALName](nil)
@@ -54,6 +58,7 @@ var walNameMap = map[WALName]string{
 	WALNamePulsar:     "pulsar",
 	WALNameWoodpecker: "woodpecker",
 	WALNameTest:       "walimplstest",
+	WALNameLocalFS:    "localfs",
 }
 
 // String returns the string representation of the WALName.
@@ -0,0 +1,85 @@
+package localfs
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/registry"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	syncPolicyAlways   syncPolicy = "always"
+	syncPolicyInterval syncPolicy = "interval"
+	syncPolicyNone     syncPolicy = "none"
+)
+
+func init() {
+	// register the builder to the registry.
+	registry.RegisterBuilder(&builderImpl{})
+	// register the unmarshaler to the message registry.
+	message.RegisterMessageIDUnmsarshaler(message.WALNameLocalFS, UnmarshalMessageID)
+}
+
+// syncPolicy is the fsync policy of the appended messages.
+type syncPolicy string
+
+// config is the config of localfs wal.
+type config struct {
+	root          string
+	segmentSize   int64
+	indexInterval int64
+	syncPolicy    syncPolicy
+	syncInterval  time.Duration
+}
+
+// newConfigFromParamTable creates the config from the paramtable.
+func newConfigFromParamTable() (*config, error) {
+	params := &paramtable.Get().LocalFSCfg
+	cfg := &config{
+		root:          params.Path.GetValue(),
+		segmentSize:   params.SegmentSize.GetAsSize(),
+		indexInterval: params.IndexInterval.GetAsSize(),
+		syncPolicy:    syncPolicy(params.SyncPolicy.GetValue()),
+		syncInterval:  params.SyncInterval.GetAsDurationByParse(),
+	}
+	if cfg.root == "" {
+		return nil, errors.New("localfs path is empty")
+	}
+	if cfg.segmentSize <= 0 {
+		return nil, errors.Errorf("invalid localfs segment size %s", params.SegmentSize.GetValue())
+	}
+	if cfg.indexInterval <= 0 {
+		return nil, errors.Errorf("invalid localfs index interval %s", params.IndexInterval.GetValue())
+	}
+	switch cfg.syncPolicy {
+	case syncPolicyAlways, syncPolicyNone:
+	case syncPolicyInterval:
+		if cfg.syncInterval <= 0 {
+			return nil, errors.Errorf("invalid localfs sync interval %s", params.SyncInterval.GetValue())
+		}
+	default:
+		return nil, errors.Errorf("invalid localfs sync policy %s", cfg.syncPolicy)
+	}
+	return cfg, nil
+}
+
+// builderImpl is the builder for localfs opener.
+type builderImpl struct{}
+
+// Name of the wal builder, should be a lowercase string.
+func (b *builderImpl) Name() message.WALName {
+	return message.WALNameLocalFS
+}
+
+// Build build a wal instance.
+func (b *builderImpl) Build() (walimpls.OpenerImpls, error) {
+	cfg, err := newConfigFromParamTable()
+	if err != nil {
+		return nil, err
+	}
+	return newOpener(cfg), nil
+}
@@ -0,0 +1,457 @@
+package localfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/flock"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/v3/util/syncutil"
+)
+
+const (
+	lockFileName = "LOCK"
+	termFileName = "TERM"
+)
+
+var errChannelLogClosed = errors.New("localfs channel log closed")
+
+// openChannelLog opens the log of a pchannel at the given directory.
+// The directory is created if not exist,
+// and a file lock is held until the log is closed to prevent the log from being opened by another process.
+func openChannelLog(dir string, cfg *config) (*channelLog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	lock := flock.New(filepath.Join(dir, lockFileName))
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, errors.Wrapf(err, "lock localfs channel log %s", dir)
+	}
+	if !locked {
+		return nil, errors.Errorf("localfs channel log %s is locked by another process", dir)
+	}
+	l := &channelLog{
+		dir:    dir,
+		cfg:    cfg,
+		lock:   lock,
+		cond:   syncutil.NewContextCond(&sync.Mutex{}),
+		stopCh: make(chan struct{}),
+	}
+	if err := l.recover(); err != nil {
+		l.closeFiles()
+		lock.Unlock()
+		return nil, err
+	}
+	if cfg.syncPolicy == syncPolicyInterval {
+		l.wg.Add(1)
+		go l.backgroundSync()
+	}
+	return l, nil
+}
+
+// channelLog is the on-disk log of a pchannel.
+// The log is made of a list of append-only segment files, only the last segment is writable.
+type channelLog struct {
+	dir    string
+	cfg    *config
+	lock   *flock.Flock
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	// cond protects all the fields below, and is broadcasted when new messages are appended.
+	cond        *syncutil.ContextCond
+	segments    []*segment
+	file        *os.File // file of the last segment.
+	indexFile   *os.File // sparse index file of the last segment.
+	lastIndexed int64    // position of the last index entry of the last segment.
+	nextOffset  int64
+	term        int64 // the term of the latest writer, the writers with lower term are fenced.
+	dirty       bool  // whether there's unsynced data in the last segment.
+	closed      bool
+}
+
+// recover recovers the log from the directory.
+func (l *channelLog) recover() error {
+	term, err := l.readTerm()
+	if err != nil {
+		return err
+	}
+	l.term = term
+
+	segments, err := listSegments(l.dir)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		segments = append(segments, newSegment(l.dir, 0))
+		if err := os.WriteFile(segments[0].path(), nil, 0o644); err != nil {
+			return err
+		}
+	}
+	for _, seg := range segments[:len(segments)-1] {
+		if err := seg.loadIndex(l.cfg.indexInterval); err != nil {
+			return errors.Wrapf(err, "load index of segment %s", seg.path())
+		}
+	}
+
+	// The last segment may be torn by a crash, so always scan it and rebuild the index.
+	last := segments[len(segments)-1]
+	next, truncated, err := last.recover(l.cfg.indexInterval)
+	if err != nil {
+		return errors.Wrapf(err, "recover the last segment %s", last.path())
+	}
+	if truncated {
+		mlog.Warn(context.TODO(), "localfs truncate the torn tail of the last segment",
+			mlog.String("segment", last.path()),
+			mlog.Int64("size", last.size))
+	}
+	if err := last.writeIndex(); err != nil {
+		return err
+	}
+	l.segments = segments
+	l.nextOffset = next
+	return l.openLastSegment()
+}
+
+// openLastSegment opens the files of the last segment for writing.
+func (l *channelLog) openLastSegment() error {
+	last := l.segments[len(l.segments)-1]
+	f, err := os.OpenFile(last.path(), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	indexFile, err := os.OpenFile(last.indexPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file = f
+	l.indexFile = indexFile
+	l.lastIndexed = -l.cfg.indexInterval
+	if len(last.index) > 0 {
+		l.lastIndexed = last.index[len(last.index)-1].position
+	}
+	return nil
+}
+
+// fence updates the term of the writer, the writers with lower term will be fenced.
+// A writer with a term lower than the persisted one can never be opened.
+func (l *channelLog) fence(term int64) error {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	if l.closed {
+		return errChannelLogClosed
+	}
+	if term < l.term {
+		return errors.Mark(errors.Errorf("term %d is lower than the term %d of the current writer", term, l.term), walimpls.ErrFenced)
+	}
+	if term == l.term {
+		return nil
+	}
+	if err := l.writeTerm(term); err != nil {
+		return err
+	}
+	l.term = term
+	return nil
+}
+
+// append appends a message into the log with the term of writer.
+func (l *channelLog) append(term int64, data []byte) (int64, error) {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	if l.closed {
+		return 0, errChannelLogClosed
+	}
+	if term != l.term {
+		return 0, errors.Mark(errors.Errorf("writer of term %d is fenced by term %d", term, l.term), walimpls.ErrFenced)
+	}
+
+	last := l.segments[len(l.segments)-1]
+	offset := l.nextOffset
+	record := appendRecord(make([]byte, 0, recordHeaderSize+len(data)), offset, data)
+	if _, err := l.file.WriteAt(record, last.size); err != nil {
+		// drop the partial written record.
+		_ = l.file.Truncate(last.size)
+		return 0, err
+	}
+	switch l.cfg.syncPolicy {
+	case syncPolicyAlways:
+		if err := l.file.Sync(); err != nil {
+			_ = l.file.Truncate(last.size)
+			return 0, err
+		}
+	case syncPolicyInterval:
+		l.dirty = true
+	}
+
+	position := last.size
+	if position-l.lastIndexed >= l.cfg.indexInterval {
+		entry := indexEntry{offset: offset, position: position}
+		last.index = append(last.index, entry)
+		l.lastIndexed = position
+		// index file of the last segment is always rebuilt when recovering, so no sync here.
+		if _, err := l.indexFile.Write(appendIndexEntry(nil, entry)); err != nil {
+			mlog.Warn(context.TODO(), "localfs write sparse index failed", mlog.String("segment", last.path()), mlog.Err(err))
+		}
+	}
+	last.size += int64(len(record))
+	l.nextOffset++
+	l.cond.UnsafeBroadcast()
+
+	if last.size >= l.cfg.segmentSize {
+		if err := l.rollSegment(); err != nil {
+			// the message is already persisted, the roll will be retried by next append.
+			mlog.Warn(context.TODO(), "localfs roll segment failed", mlog.String("dir", l.dir), mlog.Err(err))
+		}
+	}
+	return offset, nil
+}
+
+// rollSegment seals the last segment and creates a new one.
+// Must be called with the lock held.
+func (l *channelLog) rollSegment() error {
+	last := l.segments[len(l.segments)-1]
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	if err := l.indexFile.Sync(); err != nil {
+		return err
+	}
+	seg := newSegment(l.dir, l.nextOffset)
+	f, err := os.OpenFile(seg.path(), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	indexFile, err := os.OpenFile(seg.indexPath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0o644)
+	if err != nil {
+		f.Close()
+		seg.remove()
+		return err
+	}
+	if err := syncDir(l.dir); err != nil {
+		f.Close()
+		indexFile.Close()
+		seg.remove()
+		return err
+	}
+	l.file.Close()
+	l.indexFile.Close()
+	l.file = f
+	l.indexFile = indexFile
+	l.lastIndexed = -l.cfg.indexInterval
+	l.dirty = false
+	l.segments = append(l.segments, seg)
+	mlog.Info(context.TODO(), "localfs segment rolled",
+		mlog.String("dir", l.dir),
+		mlog.Int64("sealedSegmentBase", last.base),
+		mlog.Int64("sealedSegmentSize", last.size),
+		mlog.Int64("newSegmentBase", seg.base))
+	return nil
+}
+
+// truncate removes all sealed segments whose messages are all less than or equal to the given offset.
+func (l *channelLog) truncate(offset int64) error {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	if l.closed {
+		return errChannelLogClosed
+	}
+	n := 0
+	for n < len(l.segments)-1 && l.segments[n+1].base <= offset+1 {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		if err := l.segments[i].remove(); err != nil {
+			// keep the segments that are failed to remove, the truncate will be retried later.
+			l.segments = l.segments[i:]
+			return err
+		}
+	}
+	l.segments = l.segments[n:]
+	return nil
+}
+
+// locate returns the segment base and the position to start scanning to read the message at the given offset.
+// If the offset is already truncated, the first message of the log will be located.
+func (l *channelLog) locate(offset int64) (int64, int64, error) {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	if l.closed {
+		return 0, 0, errChannelLogClosed
+	}
+	last := l.segments[len(l.segments)-1]
+	if offset >= l.nextOffset {
+		return last.base, last.size, nil
+	}
+	for i := len(l.segments) - 1; i >= 0; i-- {
+		if l.segments[i].base <= offset {
+			return l.segments[i].base, l.segments[i].lookup(offset), nil
+		}
+	}
+	return l.segments[0].base, 0, nil
+}
+
+// latestOffset returns the offset that the next appended message will be assigned.
+func (l *channelLog) latestOffset() int64 {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+	return l.nextOffset
+}
+
+// waitReadable blocks until there's data after the position in the segment with given base.
+// Return the readable limit of the segment, and the base of next segment if the segment is sealed.
+func (l *channelLog) waitReadable(ctx context.Context, base int64, position int64) (limit int64, nextBase int64, sealed bool, err error) {
+	l.cond.L.Lock()
+	for {
+		if l.closed {
+			l.cond.L.Unlock()
+			return 0, 0, false, errChannelLogClosed
+		}
+		idx := -1
+		for i, seg := range l.segments {
+			if seg.base == base {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			l.cond.L.Unlock()
+			return 0, 0, false, errors.Errorf("localfs segment %d is truncated", base)
+		}
+		size := l.segments[idx].size
+		if idx < len(l.segments)-1 {
+			nextBase := l.segments[idx+1].base
+			l.cond.L.Unlock()
+			return size, nextBase, true, nil
+		}
+		if position < size {
+			l.cond.L.Unlock()
+			return size, 0, false, nil
+		}
+		if err := l.cond.Wait(ctx); err != nil {
+			return 0, 0, false, err
+		}
+	}
+}
+
+// backgroundSync syncs the last segment periodically.
+func (l *channelLog) backgroundSync() {
+	defer l.wg.Done()
+	ticker := time.NewTicker(l.cfg.syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+			l.cond.L.Lock()
+			if !l.closed && l.dirty {
+				if err := l.file.Sync(); err != nil {
+					mlog.Warn(context.TODO(), "localfs background sync failed", mlog.String("dir", l.dir), mlog.Err(err))
+				} else {
+					l.dirty = false
+				}
+			}
+			l.cond.L.Unlock()
+		}
+	}
+}
+
+// Close closes the log and releases the file lock.
+func (l *channelLog) Close() {
+	l.cond.LockAndBroadcast()
+	if l.closed {
+		l.cond.L.Unlock()
+		return
+	}
+	l.closed = true
+	if l.dirty {
+		if err := l.file.Sync(); err != nil {
+			mlog.Warn(context.TODO(), "localfs sync on close failed", mlog.String("dir", l.dir), mlog.Err(err))
+		}
+	}
+	l.closeFiles()
+	l.cond.L.Unlock()
+
+	close(l.stopCh)
+	l.wg.Wait()
+	if err := l.lock.Unlock(); err != nil {
+		mlog.Warn(context.TODO(), "localfs release file lock failed", mlog.String("dir", l.dir), mlog.Err(err))
+	}
+}
+
+// closeFiles closes the opened files of the last segment.
+func (l *channelLog) closeFiles() {
+	if l.file != nil {
+		l.file.Close()
+	}
+	if l.indexFile != nil {
+		l.indexFile.Close()
+	}
+}
+
+// readTerm reads the persisted term of the latest writer.
+func (l *channelLog) readTerm() (int64, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, termFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	term, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse term file of %s", l.dir)
+	}
+	return term, nil
+}
+
+// writeTerm persists the term of the latest writer atomically.
+func (l *channelLog) writeTerm(term int64) error {
+	tmp := filepath.Join(l.dir, termFileName+".tmp")
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(strconv.FormatInt(term, 10)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(l.dir, termFileName)); err != nil {
+		return err
+	}
+	return syncDir(l.dir)
+}
+
+// syncDir syncs the directory to make the file creation or rename durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,239 @@
+package localfs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/options"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/registry"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	tmpPath, err := os.MkdirTemp("", "localfs_test")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(tmpPath)
+	paramtable.Get().Save(paramtable.Get().LocalFSCfg.Path.Key, tmpPath)
+	m.Run()
+}
+
+func TestRegistry(t *testing.T) {
+	registeredB := registry.MustGetBuilder(message.WALNameLocalFS)
+	assert.NotNil(t, registeredB)
+	assert.Equal(t, message.WALNameLocalFS, registeredB.Name())
+
+	id, err := message.UnmarshalMessageID(&commonpb.MessageID{
+		WALName: commonpb.WALName(message.WALNameLocalFS),
+		Id:      localfsID(1).Marshal(),
+	})
+	assert.NoError(t, err)
+	assert.True(t, id.EQ(localfsID(1)))
+
+	id, err = message.UnmarshalMessageID(localfsID(10).IntoProto())
+	assert.NoError(t, err)
+	assert.True(t, id.EQ(localfsID(10)))
+}
+
+func TestWAL(t *testing.T) {
+	walimpls.NewWALImplsTestFramework(t, 1000, &builderImpl{}).Run()
+}
+
+func TestWALWithSegmentRolling(t *testing.T) {
+	params := paramtable.Get()
+	params.Save(params.LocalFSCfg.SegmentSize.Key, "4k")
+	params.Save(params.LocalFSCfg.IndexInterval.Key, "256")
+	params.Save(params.LocalFSCfg.SyncPolicy.Key, string(syncPolicyInterval))
+	defer func() {
+		params.Reset(params.LocalFSCfg.SegmentSize.Key)
+		params.Reset(params.LocalFSCfg.IndexInterval.Key)
+		params.Reset(params.LocalFSCfg.SyncPolicy.Key)
+	}()
+	walimpls.NewWALImplsTestFramework(t, 1000, &builderImpl{}).Run()
+}
+
+func TestConfig(t *testing.T) {
+	params := paramtable.Get()
+	cfg, err := newConfigFromParamTable()
+	assert.NoError(t, err)
+	assert.Equal(t, syncPolicyAlways, cfg.syncPolicy)
+	assert.Equal(t, int64(64<<20), cfg.segmentSize)
+
+	params.Save(params.LocalFSCfg.SyncPolicy.Key, "unknown")
+	_, err = newConfigFromParamTable()
+	assert.Error(t, err)
+	params.Reset(params.LocalFSCfg.SyncPolicy.Key)
+
+	params.Save(params.LocalFSCfg.SegmentSize.Key, "0")
+	_, err = newConfigFromParamTable()
+	assert.Error(t, err)
+	params.Reset(params.LocalFSCfg.SegmentSize.Key)
+}
+
+func newTestOpener(t *testing.T, segmentSize int64) *openerImpl {
+	return newOpener(&config{
+		root:          t.TempDir(),
+		segmentSize:   segmentSize,
+		indexInterval: 128,
+		syncPolicy:    syncPolicyNone,
+	})
+}
+
+func openTestWAL(t *testing.T, o *openerImpl, term int64, mode types.AccessMode) walimpls.WALImpls {
+	w, err := o.Open(context.Background(), &walimpls.OpenOption{
+		Channel: types.PChannelInfo{Name: "test", Term: term, AccessMode: mode},
+	})
+	require.NoError(t, err)
+	return w
+}
+
+func appendTestMessages(t *testing.T, w walimpls.WALImpls, n int) []message.MessageID {
+	ids := make([]message.MessageID, 0, n)
+	for i := 0; i < n; i++ {
+		id, err := w.Append(context.Background(), message.CreateTestEmptyInsertMesage(int64(i), map[string]string{
+			"id": fmt.Sprintf("%d", i),
+		}))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func readTestMessages(t *testing.T, w walimpls.ROWALImpls, policy options.DeliverPolicy, n int) []message.ImmutableMessage {
+	s, err := w.Read(context.Background(), walimpls.ReadOption{Name: "test", DeliverPolicy: policy})
+	require.NoError(t, err)
+	defer s.Close()
+	msgs := make([]message.ImmutableMessage, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case msg := <-s.Chan():
+			msgs = append(msgs, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("read message timeout, expected %d, got %d", n, len(msgs))
+		}
+	}
+	return msgs
+}
+
+func TestFence(t *testing.T) {
+	o := newTestOpener(t, 1<<20)
+	defer o.Close()
+
+	w1 := openTestWAL(t, o, 2, types.AccessModeRW)
+	appendTestMessages(t, w1, 1)
+
+	w2 := openTestWAL(t, o, 3, types.AccessModeRW)
+	_, err := w1.Append(context.Background(), message.CreateTestEmptyInsertMesage(1, map[string]string{}))
+	assert.True(t, errors.Is(err, walimpls.ErrFenced))
+	appendTestMessages(t, w2, 1)
+	w1.Close()
+	w2.Close()
+
+	// the term is persisted, so a stale writer can not be opened after reopen.
+	_, err = o.Open(context.Background(), &walimpls.OpenOption{
+		Channel: types.PChannelInfo{Name: "test", Term: 2, AccessMode: types.AccessModeRW},
+	})
+	assert.True(t, errors.Is(err, walimpls.ErrFenced))
+
+	// the log is locked by the opener, another opener can not open it.
+	w := openTestWAL(t, o, 3, types.AccessModeRW)
+	defer w.Close()
+	o2 := newOpener(o.cfg)
+	defer o2.Close()
+	_, err = o2.Open(context.Background(), &walimpls.OpenOption{
+		Channel: types.PChannelInfo{Name: "test", Term: 4, AccessMode: types.AccessModeRW},
+	})
+	assert.Error(t, err)
+}
+
+func TestTruncate(t *testing.T) {
+	o := newTestOpener(t, 1024)
+	defer o.Close()
+
+	w := openTestWAL(t, o, 1, types.AccessModeRW)
+	ids := appendTestMessages(t, w, 200)
+	segments, err := listSegments(filepath.Join(o.cfg.root, "test"))
+	require.NoError(t, err)
+	assert.Greater(t, len(segments), 2)
+
+	truncateAt := ids[150]
+	assert.NoError(t, w.Truncate(context.Background(), truncateAt))
+	remains, err := listSegments(filepath.Join(o.cfg.root, "test"))
+	require.NoError(t, err)
+	assert.Less(t, len(remains), len(segments))
+	assert.LessOrEqual(t, remains[0].base, int64(truncateAt.(localfsID))+1)
+
+	// read all should start from the first message of the remaining segments.
+	msgs := readTestMessages(t, w, options.DeliverPolicyAll(), 200-int(remains[0].base))
+	assert.True(t, msgs[0].MessageID().EQ(localfsID(remains[0].base)))
+	assert.True(t, msgs[len(msgs)-1].MessageID().EQ(ids[199]))
+
+	// read from a truncated message should start from the first remaining message.
+	msgs = readTestMessages(t, w, options.DeliverPolicyStartFrom(ids[0]), 1)
+	assert.True(t, msgs[0].MessageID().EQ(localfsID(remains[0].base)))
+	w.Close()
+}
+
+func TestRecoverTornTail(t *testing.T) {
+	o := newTestOpener(t, 1<<20)
+	defer o.Close()
+
+	w := openTestWAL(t, o, 1, types.AccessModeRW)
+	ids := appendTestMessages(t, w, 10)
+	w.Close()
+
+	// simulate a torn write on the last segment.
+	segments, err := listSegments(filepath.Join(o.cfg.root, "test"))
+	require.NoError(t, err)
+	last := segments[len(segments)-1]
+	f, err := os.OpenFile(last.path(), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.Write(appendRecord(nil, 10, []byte("torn"))[:recordHeaderSize+2])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	// the index file is always rebuilt for the last segment.
+	require.NoError(t, os.Remove(last.indexPath()))
+
+	w = openTestWAL(t, o, 1, types.AccessModeRW)
+	defer w.Close()
+	newIDs := appendTestMessages(t, w, 1)
+	assert.True(t, newIDs[0].EQ(localfsID(10)))
+
+	msgs := readTestMessages(t, w, options.DeliverPolicyStartAfter(ids[4]), 6)
+	for i, msg := range msgs {
+		assert.True(t, msg.MessageID().EQ(localfsID(5+i)))
+	}
+}
+
+func TestRecordCodec(t *testing.T) {
+	buf := appendRecord(nil, 7, []byte("hello"))
+	offset, data, n, err := readRecord(bufio.NewReader(bytes.NewReader(buf)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), offset)
+	assert.Equal(t, []byte("hello"), data)
+	assert.Equal(t, len(buf), n)
+
+	_, _, _, err = readRecord(bufio.NewReader(bytes.NewReader(buf[:len(buf)-1])))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	buf[len(buf)-1] ^= 0xff
+	_, _, _, err = readRecord(bufio.NewReader(bytes.NewReader(buf)))
+	assert.ErrorIs(t, err, errCorruptedRecord)
+}
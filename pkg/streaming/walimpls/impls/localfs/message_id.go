@@ -0,0 +1,84 @@
+package localfs
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+)
+
+var _ message.MessageID = localfsID(0)
+
+// NewLocalFSID creates a new localfsID.
+// TODO: remove in future.
+func NewLocalFSID(id int64) message.MessageID {
+	return localfsID(id)
+}
+
+// UnmarshalMessageID unmarshal the message id.
+func UnmarshalMessageID(data string) (message.MessageID, error) {
+	id, err := unmarshalMessageID(data)
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// unmarshalMessageID unmarshal the message id.
+func unmarshalMessageID(data string) (localfsID, error) {
+	v, err := message.DecodeInt64(data)
+	if err != nil {
+		return 0, errors.Wrapf(message.ErrInvalidMessageID, "decode localfsID fail with err: %s, id: %s", err.Error(), data)
+	}
+	return localfsID(v), nil
+}
+
+// localfsID is the message id for localfs wal.
+// It's the logical offset of the message in the pchannel, starts from 0 and increases by 1 for each message.
+type localfsID int64
+
+// LocalFSID returns the message id for conversion
+// Don't delete this function until conversion logic removed.
+// TODO: remove in future.
+func (id localfsID) LocalFSID() int64 {
+	return int64(id)
+}
+
+// WALName returns the name of message id related wal.
+func (id localfsID) WALName() message.WALName {
+	return message.WALNameLocalFS
+}
+
+// LT less than.
+func (id localfsID) LT(other message.MessageID) bool {
+	return id < other.(localfsID)
+}
+
+// LTE less than or equal to.
+func (id localfsID) LTE(other message.MessageID) bool {
+	return id <= other.(localfsID)
+}
+
+// EQ Equal to.
+func (id localfsID) EQ(other message.MessageID) bool {
+	return id == other.(localfsID)
+}
+
+// Marshal marshal the message id.
+func (id localfsID) Marshal() string {
+	return message.EncodeInt64(int64(id))
+}
+
+// IntoProto marshal the message id to proto.
+func (id localfsID) IntoProto() *commonpb.MessageID {
+	return &commonpb.MessageID{
+		Id:      message.EncodeInt64(int64(id)),
+		WALName: commonpb.WALName(id.WALName()),
+	}
+}
+
+func (id localfsID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
@@ -0,0 +1,30 @@
+package localfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+)
+
+func TestMessageID(t *testing.T) {
+	assert.Equal(t, message.WALNameLocalFS, localfsID(1).WALName())
+
+	assert.True(t, localfsID(1).LT(localfsID(2)))
+	assert.True(t, localfsID(1).EQ(localfsID(1)))
+	assert.True(t, localfsID(1).LTE(localfsID(1)))
+	assert.True(t, localfsID(1).LTE(localfsID(2)))
+	assert.False(t, localfsID(2).LT(localfsID(1)))
+	assert.False(t, localfsID(2).EQ(localfsID(1)))
+	assert.False(t, localfsID(2).LTE(localfsID(1)))
+	assert.True(t, localfsID(2).LTE(localfsID(2)))
+	assert.Equal(t, "2", localfsID(2).String())
+
+	msgID, err := UnmarshalMessageID(localfsID(1).Marshal())
+	assert.NoError(t, err)
+	assert.Equal(t, localfsID(1), msgID)
+
+	_, err = UnmarshalMessageID(string([]byte{0x01, 0x02, 0x03, 0x04}))
+	assert.Error(t, err)
+}
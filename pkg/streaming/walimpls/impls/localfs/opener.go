@@ -0,0 +1,113 @@
+package localfs
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/helper"
+)
+
+var (
+	_ walimpls.OpenerImpls = (*openerImpl)(nil)
+
+	errOpenerClosed = errors.New("localfs opener closed")
+)
+
+// refCountedLog is a channel log shared by the wal instances and scanners of the same pchannel.
+type refCountedLog struct {
+	*channelLog
+	ref int
+}
+
+// newOpener creates a new opener.
+func newOpener(cfg *config) *openerImpl {
+	return &openerImpl{
+		cfg:  cfg,
+		logs: make(map[string]*refCountedLog),
+	}
+}
+
+// openerImpl is the implementation of walimpls.Opener interface.
+type openerImpl struct {
+	cfg    *config
+	mu     sync.Mutex
+	logs   map[string]*refCountedLog
+	closed bool
+}
+
+// Open opens a new wal.
+func (o *openerImpl) Open(ctx context.Context, opt *walimpls.OpenOption) (walimpls.WALImpls, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	l, err := o.acquire(opt.Channel.Name)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Channel.AccessMode == types.AccessModeRW {
+		if err := l.fence(opt.Channel.Term); err != nil {
+			o.release(opt.Channel.Name)
+			return nil, err
+		}
+	}
+	return &walImpl{
+		WALHelper: helper.NewWALHelper(opt),
+		o:         o,
+		l:         l,
+	}, nil
+}
+
+// acquire gets the channel log of the pchannel and increases the reference count,
+// the log is opened if it's not opened yet.
+func (o *openerImpl) acquire(name string) (*channelLog, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return nil, errOpenerClosed
+	}
+	if l, ok := o.logs[name]; ok {
+		l.ref++
+		return l.channelLog, nil
+	}
+	l, err := openChannelLog(filepath.Join(o.cfg.root, name), o.cfg)
+	if err != nil {
+		return nil, err
+	}
+	o.logs[name] = &refCountedLog{channelLog: l, ref: 1}
+	return l, nil
+}
+
+// release decreases the reference count of the channel log,
+// the log is closed when no one references it.
+func (o *openerImpl) release(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	l, ok := o.logs[name]
+	if !ok {
+		return
+	}
+	l.ref--
+	if l.ref <= 0 {
+		l.Close()
+		delete(o.logs, name)
+	}
+}
+
+// Close closes the opener resources.
+func (o *openerImpl) Close() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.closed = true
+	for name, l := range o.logs {
+		l.Close()
+		delete(o.logs, name)
+	}
+}
@@ -0,0 +1,133 @@
+package localfs
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/messagespb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/helper"
+)
+
+var _ walimpls.ScannerImpls = (*scannerImpl)(nil)
+
+// newScanner creates a new scanner which starts to read the message with offset `from`
+// at the position of the segment with given base.
+func newScanner(
+	opt walimpls.ReadOption,
+	l *channelLog,
+	release func(),
+	from int64,
+	base int64,
+	position int64,
+) *scannerImpl {
+	s := &scannerImpl{
+		ScannerHelper: helper.NewScannerHelper(opt.Name),
+		l:             l,
+		release:       release,
+		from:          from,
+		base:          base,
+		position:      position,
+		msgChannel:    make(chan message.ImmutableMessage, opt.ReadAheadBufferSize),
+	}
+	go s.executeConsume()
+	return s
+}
+
+// scannerImpl is the implementation of ScannerImpls for localfs.
+type scannerImpl struct {
+	*helper.ScannerHelper
+	l          *channelLog
+	release    func()
+	from       int64
+	base       int64
+	position   int64
+	file       *os.File
+	msgChannel chan message.ImmutableMessage
+}
+
+// Chan returns the channel of message.
+func (s *scannerImpl) Chan() <-chan message.ImmutableMessage {
+	return s.msgChannel
+}
+
+// Close the scanner, release the underlying resources.
+// Return the error same with `Error`
+func (s *scannerImpl) Close() error {
+	return s.ScannerHelper.Close()
+}
+
+// executeConsume reads the segment files one by one and delivers the messages.
+func (s *scannerImpl) executeConsume() (err error) {
+	defer func() {
+		if s.file != nil {
+			s.file.Close()
+		}
+		s.release()
+		if s.Context().Err() != nil {
+			err = nil
+		}
+		s.Finish(err)
+		close(s.msgChannel)
+	}()
+
+	for {
+		limit, nextBase, sealed, err := s.l.waitReadable(s.Context(), s.base, s.position)
+		if err != nil {
+			return err
+		}
+		if s.position < limit {
+			if err := s.readUntil(limit); err != nil {
+				return err
+			}
+			continue
+		}
+		if sealed {
+			// the segment is fully consumed, move to the next segment.
+			if s.file != nil {
+				s.file.Close()
+				s.file = nil
+			}
+			s.base = nextBase
+			s.position = 0
+		}
+	}
+}
+
+// readUntil reads and delivers the messages of current segment until the limit.
+func (s *scannerImpl) readUntil(limit int64) error {
+	if s.file == nil {
+		f, err := os.Open(newSegment(s.l.dir, s.base).path())
+		if err != nil {
+			return errors.Wrapf(err, "open localfs segment %d", s.base)
+		}
+		s.file = f
+	}
+	r := bufio.NewReader(io.NewSectionReader(s.file, s.position, limit-s.position))
+	for s.position < limit {
+		offset, data, n, err := readRecord(r)
+		if err != nil {
+			return errors.Wrapf(err, "read localfs segment %d at position %d", s.base, s.position)
+		}
+		s.position += int64(n)
+		if offset < s.from {
+			continue
+		}
+		pb := &messagespb.Message{}
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return errors.Wrapf(err, "unmarshal localfs message %d", offset)
+		}
+		msg := message.NewImmutableMesasge(localfsID(offset), pb.GetPayload(), pb.GetProperties())
+		select {
+		case <-s.Context().Done():
+			return s.Context().Err()
+		case s.msgChannel <- msg:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package localfs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	segmentFileSuffix = ".log"
+	indexFileSuffix   = ".idx"
+
+	// recordHeaderSize is the size of the record header.
+	// | length uint32 | crc32 uint32 | offset int64 | data [length]byte |
+	// crc32 is computed over the offset and data.
+	recordHeaderSize = 16
+	// indexEntrySize is the size of a sparse index entry.
+	// | offset int64 | position int64 |
+	indexEntrySize = 16
+	// maxRecordSize is the upper bound of a record, used to detect a garbage length of a torn write.
+	maxRecordSize = 1 << 30
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errCorruptedRecord = errors.New("corrupted record")
+)
+
+// indexEntry is a entry of the sparse offset index,
+// the record with the offset starts at the position of the segment file.
+type indexEntry struct {
+	offset   int64
+	position int64
+}
+
+// segment is a append-only file which holds the records with offset in [base, next segment base).
+type segment struct {
+	base  int64
+	dir   string
+	index []indexEntry
+	// size is the committed size of the segment file.
+	// only be updated on the active segment.
+	size int64
+}
+
+// newSegment creates a new segment descriptor.
+func newSegment(dir string, base int64) *segment {
+	return &segment{
+		base: base,
+		dir:  dir,
+	}
+}
+
+// path returns the path of the segment file.
+func (s *segment) path() string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.base, segmentFileSuffix))
+}
+
+// indexPath returns the path of the sparse index file.
+func (s *segment) indexPath() string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.base, indexFileSuffix))
+}
+
+// lookup returns the position to start scanning to find the given offset.
+func (s *segment) lookup(offset int64) int64 {
+	i := sort.Search(len(s.index), func(i int) bool {
+		return s.index[i].offset > offset
+	})
+	if i == 0 {
+		return 0
+	}
+	return s.index[i-1].position
+}
+
+// loadIndex loads the sparse index from the index file.
+// The index is rebuilt from the segment file if the index file is missing or broken.
+func (s *segment) loadIndex(indexInterval int64) error {
+	data, err := os.ReadFile(s.indexPath())
+	if err == nil && len(data)%indexEntrySize == 0 {
+		index := make([]indexEntry, 0, len(data)/indexEntrySize)
+		for i := 0; i < len(data); i += indexEntrySize {
+			index = append(index, indexEntry{
+				offset:   int64(binary.LittleEndian.Uint64(data[i:])),
+				position: int64(binary.LittleEndian.Uint64(data[i+8:])),
+			})
+		}
+		fi, err := os.Stat(s.path())
+		if err != nil {
+			return err
+		}
+		s.index = index
+		s.size = fi.Size()
+		return nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if _, _, err := s.recover(indexInterval); err != nil {
+		return err
+	}
+	return s.writeIndex()
+}
+
+// recover scans the whole segment file to rebuild the sparse index and find the tail of the segment.
+// The torn or corrupted tail of the segment file is truncated.
+// Return the next offset after the last valid record, and whether the file is truncated.
+func (s *segment) recover(indexInterval int64) (int64, bool, error) {
+	f, err := os.OpenFile(s.path(), os.O_RDWR, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, false, err
+	}
+
+	s.index = s.index[:0]
+	next := s.base
+	position := int64(0)
+	lastIndexed := int64(-indexInterval)
+	r := bufio.NewReader(f)
+	for {
+		offset, _, n, err := readRecord(r)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, errCorruptedRecord) {
+			// the end of the segment or a torn tail.
+			break
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		if offset != next {
+			// a record from a stale write, treat as a corrupted tail.
+			break
+		}
+		if position-lastIndexed >= indexInterval {
+			s.index = append(s.index, indexEntry{offset: offset, position: position})
+			lastIndexed = position
+		}
+		position += int64(n)
+		next++
+	}
+	s.size = position
+	if position == fi.Size() {
+		return next, false, nil
+	}
+	if err := f.Truncate(position); err != nil {
+		return 0, false, errors.Wrapf(err, "truncate the broken tail of segment %s", s.path())
+	}
+	if err := f.Sync(); err != nil {
+		return 0, false, err
+	}
+	return next, true, nil
+}
+
+// writeIndex writes the whole sparse index into the index file.
+func (s *segment) writeIndex() error {
+	buf := make([]byte, 0, len(s.index)*indexEntrySize)
+	for _, entry := range s.index {
+		buf = appendIndexEntry(buf, entry)
+	}
+	return os.WriteFile(s.indexPath(), buf, 0o644)
+}
+
+// remove removes the segment file and the index file.
+func (s *segment) remove() error {
+	if err := os.Remove(s.path()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(s.indexPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// listSegments lists all segments in the directory, sorted by the base offset.
+func listSegments(dir string) ([]*segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]*segment, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentFileSuffix) {
+			continue
+		}
+		base, err := strconv.ParseInt(strings.TrimSuffix(name, segmentFileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, newSegment(dir, base))
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].base < segments[j].base
+	})
+	return segments, nil
+}
+
+// appendRecord appends the encoded record into buf.
+func appendRecord(buf []byte, offset int64, data []byte) []byte {
+	var header [recordHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:], uint32(len(data)))
+	binary.LittleEndian.PutUint64(header[8:], uint64(offset))
+	crc := crc32.Update(0, crcTable, header[8:])
+	crc = crc32.Update(crc, crcTable, data)
+	binary.LittleEndian.PutUint32(header[4:], crc)
+	buf = append(buf, header[:]...)
+	return append(buf, data...)
+}
+
+// readRecord reads a record from the reader.
+// Return the offset, data and the bytes consumed by the record.
+// io.EOF is returned if there's no more record, io.ErrUnexpectedEOF is returned if the record is torn.
+func readRecord(r *bufio.Reader) (int64, []byte, int, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, 0, err
+	}
+	length := binary.LittleEndian.Uint32(header[0:])
+	if length > maxRecordSize {
+		return 0, nil, 0, errCorruptedRecord
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil, 0, io.ErrUnexpectedEOF
+		}
+		return 0, nil, 0, err
+	}
+	crc := crc32.Update(0, crcTable, header[8:])
+	crc = crc32.Update(crc, crcTable, data)
+	if crc != binary.LittleEndian.Uint32(header[4:]) {
+		return 0, nil, 0, errCorruptedRecord
+	}
+	return int64(binary.LittleEndian.Uint64(header[8:])), data, recordHeaderSize + int(length), nil
+}
+
+// appendIndexEntry appends the encoded index entry into buf.
+func appendIndexEntry(buf []byte, entry indexEntry) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(entry.offset))
+	return binary.LittleEndian.AppendUint64(buf, uint64(entry.position))
+}
@@ -0,0 +1,97 @@
+package localfs
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/streamingpb"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/message"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/util/types"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls"
+	"github.com/milvus-io/milvus/pkg/v3/streaming/walimpls/helper"
+)
+
+const defaultReadAheadBufferSize = 1024
+
+var _ walimpls.WALImpls = (*walImpl)(nil)
+
+// walImpl is the implementation of walimpls.WAL interface.
+type walImpl struct {
+	*helper.WALHelper
+	o *openerImpl
+	l *channelLog
+}
+
+func (w *walImpl) WALName() message.WALName {
+	return message.WALNameLocalFS
+}
+
+// Append appends a message to the wal.
+func (w *walImpl) Append(ctx context.Context, msg message.MutableMessage) (message.MessageID, error) {
+	if w.Channel().AccessMode != types.AccessModeRW {
+		panic("write on a wal that is not in read-write mode")
+	}
+	data, err := proto.Marshal(msg.IntoMessageProto())
+	if err != nil {
+		return nil, err
+	}
+	offset, err := w.l.append(w.Channel().Term, data)
+	if err != nil {
+		w.Log().RatedWarn(ctx, rate.Limit(1), "append message to localfs failed", mlog.Err(err))
+		return nil, err
+	}
+	return localfsID(offset), nil
+}
+
+// Read create a scanner to read the wal.
+func (w *walImpl) Read(ctx context.Context, opt walimpls.ReadOption) (walimpls.ScannerImpls, error) {
+	if opt.ReadAheadBufferSize == 0 {
+		opt.ReadAheadBufferSize = defaultReadAheadBufferSize
+	}
+	var from int64
+	switch t := opt.DeliverPolicy.GetPolicy().(type) {
+	case *streamingpb.DeliverPolicy_All:
+		from = 0
+	case *streamingpb.DeliverPolicy_Latest:
+		from = w.l.latestOffset()
+	case *streamingpb.DeliverPolicy_StartFrom:
+		id, err := unmarshalMessageID(t.StartFrom.GetId())
+		if err != nil {
+			return nil, err
+		}
+		from = int64(id)
+	case *streamingpb.DeliverPolicy_StartAfter:
+		id, err := unmarshalMessageID(t.StartAfter.GetId())
+		if err != nil {
+			return nil, err
+		}
+		from = int64(id) + 1
+	}
+	base, position, err := w.l.locate(from)
+	if err != nil {
+		return nil, err
+	}
+	// the scanner holds a reference of the channel log until it's closed.
+	l, err := w.o.acquire(w.Channel().Name)
+	if err != nil {
+		return nil, err
+	}
+	return newScanner(opt, l, func() { w.o.release(w.Channel().Name) }, from, base, position), nil
+}
+
+// Truncate truncates the wal to the given id (inclusive).
+// Only the whole sealed segments are removed, so some messages before the id may be kept.
+func (w *walImpl) Truncate(ctx context.Context, id message.MessageID) error {
+	if w.Channel().AccessMode != types.AccessModeRW {
+		panic("truncate on a wal that is not in read-write mode")
+	}
+	return w.l.truncate(int64(id.(localfsID)))
+}
+
+// Close closes the wal.
+func (w *walImpl) Close() {
+	w.o.release(w.Channel().Name)
+}
@@ -4,16 +4,30 @@ import (
 	"io"
 
 	"github.com/klauspost/compress/zstd"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 )
 
 type CompressType string
 
 const (
-	CompressTypeZstd CompressType = "zstd"
+	CompressTypeZstd   CompressType = "zstd"
+	CompressTypeLZ4    CompressType = "lz4"
+	CompressTypeSnappy CompressType = "snappy"
 
 	DefaultCompressAlgorithm CompressType = CompressTypeZstd
 )
 
+// ParseCompressType parses the name of a compress type, case sensitive.
+func ParseCompressType(name string) (CompressType, error) {
+	switch typ := CompressType(name); typ {
+	case CompressTypeZstd, CompressTypeLZ4, CompressTypeSnappy:
+		return typ, nil
+	default:
+		return "", merr.WrapErrParameterInvalidMsg("unknown compress type %s, should be one of zstd, lz4 and snappy", name)
+	}
+}
+
 type Compressor interface {
 	Compress(in io.Reader) error
 	CompressBytes(src, dst []byte) []byte
@@ -34,8 +48,42 @@ type Decompressor interface {
 var (
 	_ Compressor   = (*ZstdCompressor)(nil)
 	_ Decompressor = (*ZstdDecompressor)(nil)
+	_ Compressor   = (*LZ4Compressor)(nil)
+	_ Decompressor = (*LZ4Decompressor)(nil)
+	_ Compressor   = (*SnappyCompressor)(nil)
+	_ Decompressor = (*SnappyDecompressor)(nil)
 )
 
+// NewCompressor creates a compressor of the compress type with default options.
+// For compressing small blocks, pass nil to the `out` parameter
+func NewCompressor(typ CompressType, out io.Writer) (Compressor, error) {
+	switch typ {
+	case CompressTypeZstd:
+		return NewZstdCompressor(out)
+	case CompressTypeLZ4:
+		return NewLZ4Compressor(out), nil
+	case CompressTypeSnappy:
+		return NewSnappyCompressor(out), nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unknown compress type %s", typ)
+	}
+}
+
+// NewDecompressor creates a decompressor of the compress type with default options.
+// For decompressing small blocks, pass nil to the `in` parameter
+func NewDecompressor(typ CompressType, in io.Reader) (Decompressor, error) {
+	switch typ {
+	case CompressTypeZstd:
+		return NewZstdDecompressor(in)
+	case CompressTypeLZ4:
+		return NewLZ4Decompressor(in), nil
+	case CompressTypeSnappy:
+		return NewSnappyDecompressor(in), nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unknown compress type %s", typ)
+	}
+}
+
 type ZstdCompressor struct {
 	encoder *zstd.Encoder
 }
@@ -193,3 +241,35 @@ func ZstdCompressBytes(src, dst []byte) []byte {
 func ZstdDecompressBytes(src, dst []byte) ([]byte, error) {
 	return globalZstdDecompressor.DecodeAll(src, dst)
 }
+
+// Use case: compress small blocks with the compress type
+// This compresses the src bytes and appends it to the dst bytes, then return the result
+// This can be called concurrently
+func CompressBytes(typ CompressType, src, dst []byte) ([]byte, error) {
+	switch typ {
+	case CompressTypeZstd:
+		return ZstdCompressBytes(src, dst), nil
+	case CompressTypeLZ4:
+		return LZ4CompressBytes(src, dst), nil
+	case CompressTypeSnappy:
+		return SnappyCompressBytes(src, dst), nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unknown compress type %s", typ)
+	}
+}
+
+// Use case: decompress small blocks compressed by CompressBytes with the same compress type
+// This decompresses the src bytes and appends it to the dst bytes, then return the result
+// This can be called concurrently
+func DecompressBytes(typ CompressType, src, dst []byte) ([]byte, error) {
+	switch typ {
+	case CompressTypeZstd:
+		return ZstdDecompressBytes(src, dst)
+	case CompressTypeLZ4:
+		return LZ4DecompressBytes(src, dst)
+	case CompressTypeSnappy:
+		return SnappyDecompressBytes(src, dst)
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unknown compress type %s", typ)
+	}
+}
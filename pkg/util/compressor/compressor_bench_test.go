@@ -0,0 +1,103 @@
+package compressor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// benchmarkData generates semi-structured data similar to binlog and wal payloads,
+// ascending int64 primary keys, low-cardinality strings and random float vectors.
+func benchmarkData(size int) map[string][]byte {
+	r := rand.New(rand.NewSource(0))
+	pks := make([]byte, 0, size)
+	for i := int64(0); len(pks) < size; i++ {
+		pks = binary.LittleEndian.AppendUint64(pks, uint64(450000000000000000+i))
+	}
+	words := []string{"milvus", "vector", "database", "segment", "collection", "partition"}
+	texts := new(bytes.Buffer)
+	for texts.Len() < size {
+		texts.WriteString(words[r.Intn(len(words))])
+		texts.WriteByte(' ')
+	}
+	vectors := make([]byte, 0, size)
+	for len(vectors) < size {
+		vectors = binary.LittleEndian.AppendUint32(vectors, r.Uint32())
+	}
+	return map[string][]byte{
+		"pk":     pks[:size],
+		"text":   texts.Bytes()[:size],
+		"vector": vectors[:size],
+	}
+}
+
+func BenchmarkCompressBytes(b *testing.B) {
+	for _, size := range []int{4 << 10, 1 << 20} {
+		for name, data := range benchmarkData(size) {
+			for _, typ := range []CompressType{CompressTypeZstd, CompressTypeLZ4, CompressTypeSnappy} {
+				b.Run(fmt.Sprintf("%s/%s/%d", typ, name, size), func(b *testing.B) {
+					var compressed []byte
+					b.SetBytes(int64(len(data)))
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						compressed, _ = CompressBytes(typ, data, compressed[:0])
+					}
+					b.ReportMetric(float64(len(data))/float64(len(compressed)), "ratio")
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkDecompressBytes(b *testing.B) {
+	for _, size := range []int{4 << 10, 1 << 20} {
+		for name, data := range benchmarkData(size) {
+			for _, typ := range []CompressType{CompressTypeZstd, CompressTypeLZ4, CompressTypeSnappy} {
+				compressed, err := CompressBytes(typ, data, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.Run(fmt.Sprintf("%s/%s/%d", typ, name, size), func(b *testing.B) {
+					var origin []byte
+					b.SetBytes(int64(len(data)))
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						origin, err = DecompressBytes(typ, compressed, origin[:0])
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkCompressStream(b *testing.B) {
+	for name, data := range benchmarkData(16 << 20) {
+		for _, typ := range []CompressType{CompressTypeZstd, CompressTypeLZ4, CompressTypeSnappy} {
+			b.Run(fmt.Sprintf("%s/%s", typ, name), func(b *testing.B) {
+				compressed := new(bytes.Buffer)
+				enc, err := NewCompressor(typ, compressed)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.SetBytes(int64(len(data)))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					compressed.Reset()
+					enc.ResetWriter(compressed)
+					if err := enc.Compress(bytes.NewReader(data)); err != nil {
+						b.Fatal(err)
+					}
+					if err := enc.Close(); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(data))/float64(compressed.Len()), "ratio")
+			})
+		}
+	}
+}
@@ -175,3 +175,125 @@ type ErrWriter struct {
 func (w *ErrWriter) Write(p []byte) (n int, err error) {
 	return 0, w.Err
 }
+
+func TestFastCompress(t *testing.T) {
+	data := strings.Repeat("hello fast compress algorithm! ", 100)
+	for _, typ := range []CompressType{CompressTypeLZ4, CompressTypeSnappy} {
+		t.Run(string(typ), func(t *testing.T) {
+			compressed := new(bytes.Buffer)
+			enc, err := NewCompressor(typ, compressed)
+			assert.NoError(t, err)
+			assert.Equal(t, typ, enc.GetType())
+
+			// Stream
+			assert.NoError(t, enc.Compress(strings.NewReader(data)))
+			assert.NoError(t, enc.Close())
+			assert.Less(t, compressed.Len(), len(data))
+
+			origin := new(bytes.Buffer)
+			dec, err := NewDecompressor(typ, compressed)
+			assert.NoError(t, err)
+			assert.Equal(t, typ, dec.GetType())
+			assert.NoError(t, dec.Decompress(origin))
+			assert.Equal(t, data, origin.String())
+
+			// Reuse
+			compressed.Reset()
+			enc.ResetWriter(compressed)
+			assert.NoError(t, enc.Compress(strings.NewReader(data+": reuse")))
+			assert.NoError(t, enc.Close())
+			origin.Reset()
+			dec.ResetReader(compressed)
+			assert.NoError(t, dec.Decompress(origin))
+			assert.Equal(t, data+": reuse", origin.String())
+
+			// Bytes appended to the dst
+			prefix := []byte("prefix")
+			compressedBytes := enc.CompressBytes([]byte(data), prefix)
+			assert.Equal(t, prefix, compressedBytes[:len(prefix)])
+			originBytes, err := dec.DecompressBytes(compressedBytes[len(prefix):], prefix)
+			assert.NoError(t, err)
+			assert.Equal(t, "prefix"+data, string(originBytes))
+
+			// Empty and incompressible bytes
+			for _, src := range [][]byte{{}, []byte("a"), bytes.Repeat([]byte{0x1, 0xf3, 0x7c, 0x42}, 3)} {
+				compressedBytes, err := CompressBytes(typ, src, nil)
+				assert.NoError(t, err)
+				originBytes, err := DecompressBytes(typ, compressedBytes, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, len(src), len(originBytes))
+				assert.Equal(t, string(src), string(originBytes))
+			}
+
+			// Corrupted bytes
+			_, err = DecompressBytes(typ, []byte{0xff, 0xff, 0xff}, nil)
+			assert.Error(t, err)
+
+			// Mock error reader/writer
+			errReader := &ErrReader{Err: io.ErrUnexpectedEOF}
+			errWriter := &ErrWriter{Err: io.ErrShortWrite}
+			enc.ResetWriter(new(bytes.Buffer))
+			err = enc.Compress(errReader)
+			assert.ErrorIs(t, err, errReader.Err)
+
+			compressed.Reset()
+			enc.ResetWriter(compressed)
+			assert.NoError(t, enc.Compress(strings.NewReader(data)))
+			assert.NoError(t, enc.Close())
+			dec.ResetReader(compressed)
+			err = dec.Decompress(errWriter)
+			assert.ErrorIs(t, err, errWriter.Err)
+			dec.Close()
+		})
+	}
+}
+
+func TestFastGlobalMethods(t *testing.T) {
+	data := "hello fast compress algorithm!"
+	type streamFunc func(in io.Reader, out io.Writer) error
+	for typ, funcs := range map[CompressType][2]streamFunc{
+		CompressTypeLZ4:    {LZ4Compress, LZ4Decompress},
+		CompressTypeSnappy: {SnappyCompress, SnappyDecompress},
+	} {
+		compressed := new(bytes.Buffer)
+		origin := new(bytes.Buffer)
+		assert.NoError(t, funcs[0](strings.NewReader(data), compressed), typ)
+		assert.NoError(t, funcs[1](compressed, origin), typ)
+		assert.Equal(t, data, origin.String(), typ)
+
+		assert.Error(t, funcs[0](&ErrReader{Err: io.ErrUnexpectedEOF}, compressed), typ)
+	}
+}
+
+func TestCompressType(t *testing.T) {
+	for _, typ := range []CompressType{CompressTypeZstd, CompressTypeLZ4, CompressTypeSnappy} {
+		parsed, err := ParseCompressType(string(typ))
+		assert.NoError(t, err)
+		assert.Equal(t, typ, parsed)
+
+		enc, err := NewCompressor(typ, nil)
+		assert.NoError(t, err)
+		dec, err := NewDecompressor(typ, nil)
+		assert.NoError(t, err)
+		data := []byte("hello compress type")
+		compressed := enc.CompressBytes(data, nil)
+		origin, err := dec.DecompressBytes(compressed, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, data, origin)
+		// the package level methods are compatible with the compressors
+		origin, err = DecompressBytes(typ, compressed, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, data, origin)
+	}
+
+	_, err := ParseCompressType("gzip")
+	assert.Error(t, err)
+	_, err = NewCompressor("gzip", nil)
+	assert.Error(t, err)
+	_, err = NewDecompressor("gzip", nil)
+	assert.Error(t, err)
+	_, err = CompressBytes("gzip", nil, nil)
+	assert.Error(t, err)
+	_, err = DecompressBytes("gzip", nil, nil)
+	assert.Error(t, err)
+}
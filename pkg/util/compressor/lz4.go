@@ -0,0 +1,152 @@
+package compressor
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// LZ4Compressor writes the lz4 frame format for streams,
+// and the lz4 block format prefixed with the uvarint original size for small blocks
+type LZ4Compressor struct {
+	writer *lz4.Writer
+}
+
+// For compressing small blocks, pass nil to the `out` parameter
+func NewLZ4Compressor(out io.Writer) *LZ4Compressor {
+	return &LZ4Compressor{lz4.NewWriter(out)}
+}
+
+// Use case: compress stream
+// Call Close() to make sure the data is flushed to the underlying writer
+// after the last Compress() call
+func (c *LZ4Compressor) Compress(in io.Reader) error {
+	// hide the ReadFrom() of lz4 writer, it treats io.ErrUnexpectedEOF from `in` as the end of data
+	_, err := io.Copy(struct{ io.Writer }{c.writer}, in)
+	if err != nil {
+		c.writer.Close()
+		return err
+	}
+
+	return nil
+}
+
+// Use case: compress small blocks
+// This compresses the src bytes and appends it to the dst bytes, then return the result
+// This can be called concurrently
+func (c *LZ4Compressor) CompressBytes(src []byte, dst []byte) []byte {
+	return LZ4CompressBytes(src, dst)
+}
+
+// Reset the writer to reuse the compressor
+func (c *LZ4Compressor) ResetWriter(out io.Writer) {
+	c.writer.Reset(out)
+}
+
+// The compressor is still re-used after calling this
+func (c *LZ4Compressor) Close() error {
+	return c.writer.Close()
+}
+
+func (c *LZ4Compressor) GetType() CompressType {
+	return CompressTypeLZ4
+}
+
+type LZ4Decompressor struct {
+	reader *lz4.Reader
+}
+
+// For decompressing small blocks, pass nil to the `in` parameter
+func NewLZ4Decompressor(in io.Reader) *LZ4Decompressor {
+	return &LZ4Decompressor{lz4.NewReader(in)}
+}
+
+// Usa case: decompress stream
+// Write the decompressed data into `out`
+func (dec *LZ4Decompressor) Decompress(out io.Writer) error {
+	_, err := io.Copy(out, dec.reader)
+	return err
+}
+
+// Use case: decompress small blocks
+// This decompresses the src bytes and appends it to the dst bytes, then return the result
+// This can be called concurrently
+func (dec *LZ4Decompressor) DecompressBytes(src []byte, dst []byte) ([]byte, error) {
+	return LZ4DecompressBytes(src, dst)
+}
+
+// Reset the reader to reuse the decompressor
+func (dec *LZ4Decompressor) ResetReader(in io.Reader) {
+	dec.reader.Reset(in)
+}
+
+// The lz4 reader holds no resource to release
+func (dec *LZ4Decompressor) Close() {}
+
+func (dec *LZ4Decompressor) GetType() CompressType {
+	return CompressTypeLZ4
+}
+
+// Global methods
+
+// Usa case: compress stream, large object only once
+// This can be called concurrently
+func LZ4Compress(in io.Reader, out io.Writer) error {
+	enc := NewLZ4Compressor(out)
+	if err := enc.Compress(in); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Usa case: decompress stream, large object only once
+// This can be called concurrently
+func LZ4Decompress(in io.Reader, out io.Writer) error {
+	return NewLZ4Decompressor(in).Decompress(out)
+}
+
+// Use case: compress small blocks
+// This can be called concurrently
+func LZ4CompressBytes(src []byte, dst []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(src)))
+	offset := len(dst)
+	dst = grow(dst, lz4.CompressBlockBound(len(src)))
+	// the destination is large enough, so the block is always written even if it is incompressible
+	n, _ := lz4.CompressBlock(src, dst[offset:], nil)
+	return dst[:offset+n]
+}
+
+// Use case: decompress small blocks
+// This can be called concurrently
+func LZ4DecompressBytes(src []byte, dst []byte) ([]byte, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid lz4 block header")
+	}
+	offset := len(dst)
+	dst = grow(dst, int(size))
+	if size == 0 {
+		return dst, nil
+	}
+	written, err := lz4.UncompressBlock(src[n:], dst[offset:])
+	if err != nil {
+		return nil, err
+	}
+	if written != int(size) {
+		return nil, merr.WrapErrParameterInvalidMsg("lz4 block size mismatch, expected %d, actual %d", size, written)
+	}
+	return dst, nil
+}
+
+// grow extends the length of buf by n bytes, reallocating if the capacity is not enough.
+func grow(buf []byte, n int) []byte {
+	if cap(buf)-len(buf) >= n {
+		return buf[:len(buf)+n]
+	}
+	newBuf := make([]byte, len(buf)+n)
+	copy(newBuf, buf)
+	return newBuf
+}
@@ -0,0 +1,129 @@
+package compressor
+
+import (
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// SnappyCompressor writes the snappy framing format for streams,
+// and the snappy block format for small blocks
+type SnappyCompressor struct {
+	writer *snappy.Writer
+}
+
+// For compressing small blocks, pass nil to the `out` parameter
+func NewSnappyCompressor(out io.Writer) *SnappyCompressor {
+	return &SnappyCompressor{snappy.NewBufferedWriter(out)}
+}
+
+// Use case: compress stream
+// Call Close() to make sure the data is flushed to the underlying writer
+// after the last Compress() call
+func (c *SnappyCompressor) Compress(in io.Reader) error {
+	_, err := io.Copy(c.writer, in)
+	if err != nil {
+		c.writer.Close()
+		return err
+	}
+
+	return nil
+}
+
+// Use case: compress small blocks
+// This compresses the src bytes and appends it to the dst bytes, then return the result
+// This can be called concurrently
+func (c *SnappyCompressor) CompressBytes(src []byte, dst []byte) []byte {
+	return SnappyCompressBytes(src, dst)
+}
+
+// Reset the writer to reuse the compressor
+func (c *SnappyCompressor) ResetWriter(out io.Writer) {
+	c.writer.Reset(out)
+}
+
+// The compressor is still re-used after calling this
+func (c *SnappyCompressor) Close() error {
+	return c.writer.Close()
+}
+
+func (c *SnappyCompressor) GetType() CompressType {
+	return CompressTypeSnappy
+}
+
+type SnappyDecompressor struct {
+	reader *snappy.Reader
+}
+
+// For decompressing small blocks, pass nil to the `in` parameter
+func NewSnappyDecompressor(in io.Reader) *SnappyDecompressor {
+	return &SnappyDecompressor{snappy.NewReader(in)}
+}
+
+// Usa case: decompress stream
+// Write the decompressed data into `out`
+func (dec *SnappyDecompressor) Decompress(out io.Writer) error {
+	_, err := io.Copy(out, dec.reader)
+	return err
+}
+
+// Use case: decompress small blocks
+// This decompresses the src bytes and appends it to the dst bytes, then return the result
+// This can be called concurrently
+func (dec *SnappyDecompressor) DecompressBytes(src []byte, dst []byte) ([]byte, error) {
+	return SnappyDecompressBytes(src, dst)
+}
+
+// Reset the reader to reuse the decompressor
+func (dec *SnappyDecompressor) ResetReader(in io.Reader) {
+	dec.reader.Reset(in)
+}
+
+// The snappy reader holds no resource to release
+func (dec *SnappyDecompressor) Close() {}
+
+func (dec *SnappyDecompressor) GetType() CompressType {
+	return CompressTypeSnappy
+}
+
+// Global methods
+
+// Usa case: compress stream, large object only once
+// This can be called concurrently
+func SnappyCompress(in io.Reader, out io.Writer) error {
+	enc := NewSnappyCompressor(out)
+	if err := enc.Compress(in); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Usa case: decompress stream, large object only once
+// This can be called concurrently
+func SnappyDecompress(in io.Reader, out io.Writer) error {
+	return NewSnappyDecompressor(in).Decompress(out)
+}
+
+// Use case: compress small blocks
+// This can be called concurrently
+func SnappyCompressBytes(src []byte, dst []byte) []byte {
+	offset := len(dst)
+	dst = grow(dst, snappy.MaxEncodedLen(len(src)))
+	encoded := snappy.Encode(dst[offset:], src)
+	return dst[:offset+len(encoded)]
+}
+
+// Use case: decompress small blocks
+// This can be called concurrently
+func SnappyDecompressBytes(src []byte, dst []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(src)
+	if err != nil {
+		return nil, err
+	}
+	offset := len(dst)
+	dst = grow(dst, size)
+	if _, err := snappy.Decode(dst[offset:], src); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
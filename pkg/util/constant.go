@@ -30,6 +30,7 @@ import (
 const (
 	MetaStoreTypeEtcd = "etcd"
 	MetaStoreTypeTiKV = "tikv"
+	MetaStoreTypeBolt = "bolt"
 
 	SegmentMetaPrefix    = "queryCoord-segmentMeta"
 	ChangeInfoMetaPrefix = "queryCoord-sealedSegmentChangeInfo"
@@ -81,6 +81,9 @@ const (
 	// SyncTaskKey request for get sync tasks from the datanode
 	SyncTaskKey = "sync_tasks"
 
+	// ResourceUsageKey request for get the resource usage and the segment query load from the querynode
+	ResourceUsageKey = "resource_usage"
+
 	// MetricRequestParamVerboseKey as a request parameter decide to whether return verbose value
 	MetricRequestParamVerboseKey = "verbose"
 
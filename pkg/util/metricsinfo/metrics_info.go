@@ -284,6 +284,27 @@ type QueryNodeInfos struct {
 	CollectionMetrics    *QueryNodeCollectionMetrics `json:"collection_metrics"`
 }
 
+// SegmentQueryLoad records the accumulated search and query accesses on a sealed segment of querynode.
+// The values only grow, until the segment isn't accessed for a while and is forgotten by the querynode.
+type SegmentQueryLoad struct {
+	SegmentID    int64   `json:"segment_id,omitempty,string"`
+	CollectionID int64   `json:"collection_id,omitempty,string"`
+	AccessTotal  int64   `json:"access_total,omitempty,string"`
+	AccessCostMs float64 `json:"access_cost_ms,omitempty"`
+}
+
+// QueryNodeResourceUsage records the resource usage and the segment query load of querynode.
+type QueryNodeResourceUsage struct {
+	NodeID       int64               `json:"node_id,omitempty,string"`
+	CPUCoreCount int                 `json:"cpu_core_count"`
+	CPUCoreUsage float64             `json:"cpu_core_usage"` // cpu usage in percentage
+	Memory       uint64              `json:"memory"`
+	MemoryUsage  uint64              `json:"memory_usage"`
+	Disk         float64             `json:"disk"`       // local disk capacity in GB
+	DiskUsage    float64             `json:"disk_usage"` // local disk usage in GB, includes mmap files and disk cache
+	Segments     []*SegmentQueryLoad `json:"segments,omitempty"`
+}
+
 // QueryCoordConfiguration records the configuration of QueryCoord.
 type QueryCoordConfiguration struct {
 	SearchChannelPrefix       string `json:"search_channel_prefix"`
@@ -311,6 +311,7 @@ type commonConfig struct {
 	StoragePathPrefix        ParamItem `refreshable:"false"`
 	StorageZstdConcurrency   ParamItem `refreshable:"false"`
 	StorageReadRetryAttempts ParamItem `refreshable:"true"`
+	StorageBinlogCompression ParamItem `refreshable:"true"`
 
 	TraceLogMode              ParamItem `refreshable:"true"`
 	VisibilityFilterEnabled   ParamItem `refreshable:"false"`
@@ -362,6 +363,11 @@ type commonConfig struct {
 
 	// group by
 	GroupByMaxGroups ParamItem `refreshable:"false"`
+
+	CountDistinctMaxValues ParamItem `refreshable:"false"`
+
+	// recycle bin
+	RecycleBinRetentionSeconds ParamItem `refreshable:"true"`
 }
 
 func (p *commonConfig) init(base *BaseTable) {
@@ -1186,6 +1192,18 @@ The default value is 1, which is enough for most cases.`,
 	}
 	p.StorageReadRetryAttempts.Init(base.mgr)
 
+	p.StorageBinlogCompression = ParamItem{
+		Key:          "common.storage.binlogCompression",
+		Version:      "3.0.0",
+		DefaultValue: "zstd",
+		Doc: `The compress type of binlogs, one of zstd, lz4 and snappy.
+lz4 and snappy compress several times faster than zstd with a lower compression ratio, which suits latency-sensitive ingestion.
+It can be overridden by the collection property collection.binlog.compression, and applies to the binlogs and packed files written afterwards.
+The compress type is recorded in the binlogs and the parquet column chunks, so the files written with different compress types are always readable.`,
+		Export: true,
+	}
+	p.StorageBinlogCompression.Init(base.mgr)
+
 	p.TraceLogMode = ParamItem{
 		Key:          "common.traceLogMode",
 		Version:      "2.3.4",
@@ -1226,8 +1244,10 @@ The default value is 1, which is enough for most cases.`,
 		Key:          "common.bloomFilterType",
 		Version:      "2.4.3",
 		DefaultValue: "BlockedBloomFilter",
-		Doc:          "bloom filter type, support BasicBloomFilter and BlockedBloomFilter",
-		Export:       true,
+		Doc: `bloom filter type, support BasicBloomFilter, BlockedBloomFilter and BinaryFuseFilter.
+BinaryFuseFilter is only used for the stats of sealed segments, the growing segments use BlockedBloomFilter instead.
+The existing segments keep their bloom filter type until they are compacted, enable BinaryFuseFilter only after all the nodes are upgraded.`,
+		Export: true,
 	}
 	p.BloomFilterType.Init(base.mgr)
 
@@ -1520,6 +1540,39 @@ If enabled, IPv6 ULA/global addresses will be prioritized ahead of IPv4.`,
 		},
 	}
 	p.GroupByMaxGroups.Init(base.mgr)
+
+	p.CountDistinctMaxValues = ParamItem{
+		Key:          "common.countDistinct.maxValues",
+		Version:      "2.7.0",
+		DefaultValue: "100000",
+		Doc:          "Maximum number of distinct values tracked per group by exact count(distinct) aggregation. Exceeding this limit fails the query, use approx_count_distinct for high-cardinality fields.",
+		Export:       true,
+		Formatter: func(v string) string {
+			if getAsInt64(v) <= 0 {
+				return "100000"
+			}
+			return v
+		},
+	}
+	p.CountDistinctMaxValues.Init(base.mgr)
+
+	p.RecycleBinRetentionSeconds = ParamItem{
+		Key:          "common.recycleBin.retentionSeconds",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc: `Retention in seconds of the dropped collections and partitions in the recycle bin. ` +
+			`When it's greater than 0, a snapshot is taken before dropping a collection or partition, ` +
+			`its binlogs and indexes are kept from garbage collection until the retention expires ` +
+			`or it's purged, and it could be restored through the proxy management api. 0 disables the recycle bin.`,
+		Export: true,
+		Formatter: func(v string) string {
+			if getAsInt64(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+	}
+	p.RecycleBinRetentionSeconds.Init(base.mgr)
 }
 
 type gpuConfig struct {
@@ -2063,6 +2116,19 @@ type AccessLogConfig struct {
 
 	CacheSize          ParamItem `refreshable:"false"`
 	CacheFlushInterval ParamItem `refreshable:"false"`
+
+	Encoding ParamItem `refreshable:"false"`
+	Sink     ParamItem `refreshable:"false"`
+
+	SyslogNetwork  ParamItem `refreshable:"false"`
+	SyslogAddress  ParamItem `refreshable:"false"`
+	SyslogFacility ParamItem `refreshable:"false"`
+	SyslogAppName  ParamItem `refreshable:"false"`
+
+	OTLPEndpoint      ParamItem `refreshable:"false"`
+	OTLPInsecure      ParamItem `refreshable:"false"`
+	OTLPBatchSize     ParamItem `refreshable:"false"`
+	OTLPFlushInterval ParamItem `refreshable:"false"`
 }
 
 type proxyConfig struct {
@@ -2072,6 +2138,8 @@ type proxyConfig struct {
 	TimeTickInterval                  ParamItem `refreshable:"false"`
 	HealthCheckTimeout                ParamItem `refreshable:"true"`
 	MsgStreamTimeTickBufSize          ParamItem `refreshable:"true"`
+	WALCompressionType                ParamItem `refreshable:"true"`
+	WALCompressionMinPayloadBytes     ParamItem `refreshable:"true"`
 	MaxNameLength                     ParamItem `refreshable:"true"`
 	MaxCollectionDescriptionLength    ParamItem `refreshable:"true"`
 	MaxUsernameLength                 ParamItem `refreshable:"true"`
@@ -2102,6 +2170,14 @@ type proxyConfig struct {
 	RetryTimesOnHealthCheck           ParamItem `refreshable:"true"`
 	ReplicaBlacklistDuration          ParamItem `refreshable:"true"`
 	ReplicaBlacklistCleanupInterval   ParamItem `refreshable:"true"`
+	HedgeEnabled                      ParamItem `refreshable:"true"`
+	HedgeLatencyPercentile            ParamItem `refreshable:"true"`
+	HedgeMinDelay                     ParamItem `refreshable:"true"`
+	HedgeBudgetRatio                  ParamItem `refreshable:"true"`
+	ResultCacheEnabled                ParamItem `refreshable:"false"`
+	ResultCacheMaxMemSize             ParamItem `refreshable:"true"`
+	ResultCacheMaxEntrySize           ParamItem `refreshable:"true"`
+	ResultCacheTTL                    ParamItem `refreshable:"true"`
 	PartitionNameRegexp               ParamItem `refreshable:"true"`
 	MustUsePartitionKey               ParamItem `refreshable:"true"`
 	SkipAutoIDCheck                   ParamItem `refreshable:"true"`
@@ -2121,6 +2197,9 @@ type proxyConfig struct {
 	ConnectionCheckIntervalSeconds ParamItem `refreshable:"true"`
 	ConnectionClientInfoTTLSeconds ParamItem `refreshable:"true"`
 	MaxConnectionNum               ParamItem `refreshable:"true"`
+	MaxInflightRequestsPerClient   ParamItem `refreshable:"true"`
+	MaxInflightRequestsPerUser     ParamItem `refreshable:"true"`
+	MaxConnectionIdleSeconds       ParamItem `refreshable:"false"`
 
 	GracefulStopTimeout ParamItem `refreshable:"true"`
 
@@ -2162,6 +2241,26 @@ func (p *proxyConfig) init(base *BaseTable) {
 	}
 	p.MsgStreamTimeTickBufSize.Init(base.mgr)
 
+	p.WALCompressionType = ParamItem{
+		Key:          "proxy.walCompression.type",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `The compress type of the insert, upsert and delete message bodies written into the wal, one of zstd, lz4 and snappy.
+Empty means no compression. The compress type is recorded in the message, so the messages written with different compress types are always readable.
+Enable it only after all the streaming nodes and query nodes are upgraded, the older ones can not read the compressed messages.`,
+		Export: true,
+	}
+	p.WALCompressionType.Init(base.mgr)
+
+	p.WALCompressionMinPayloadBytes = ParamItem{
+		Key:          "proxy.walCompression.minPayloadBytes",
+		Version:      "3.0.0",
+		DefaultValue: "4096",
+		Doc:          "The message bodies smaller than the size in bytes are not compressed.",
+		Export:       true,
+	}
+	p.WALCompressionMinPayloadBytes.Init(base.mgr)
+
 	p.MaxNameLength = ParamItem{
 		Key:          "proxy.maxNameLength",
 		DefaultValue: "255",
@@ -2466,6 +2565,101 @@ please adjust in embedded Milvus: false`,
 	}
 	p.AccessLog.Formatter.Init(base.mgr)
 
+	p.AccessLog.Encoding = ParamItem{
+		Key:          "proxy.accessLog.encoding",
+		Version:      "3.0.0",
+		DefaultValue: "text",
+		Doc: `The encoding of access log, text or json.
+text: format the log lines by the formatters.
+json: write a JSON object per line with the typed fields of the formatters.`,
+		Export: true,
+	}
+	p.AccessLog.Encoding.Init(base.mgr)
+
+	p.AccessLog.Sink = ParamItem{
+		Key:          "proxy.accessLog.sink",
+		Version:      "3.0.0",
+		DefaultValue: "file",
+		Doc: `The sink to write access log, file, syslog or otlp.
+file: write to the file of proxy.accessLog.filename, or stdout if the filename is empty.
+syslog: send RFC5424 messages to the syslog server.
+otlp: export the logs to the OTLP gRPC endpoint.`,
+		Export: true,
+	}
+	p.AccessLog.Sink.Init(base.mgr)
+
+	p.AccessLog.SyslogNetwork = ParamItem{
+		Key:          "proxy.accessLog.syslog.network",
+		Version:      "3.0.0",
+		DefaultValue: "udp",
+		Doc:          "The network of the syslog server, udp, tcp or unixgram.",
+		Export:       true,
+	}
+	p.AccessLog.SyslogNetwork.Init(base.mgr)
+
+	p.AccessLog.SyslogAddress = ParamItem{
+		Key:          "proxy.accessLog.syslog.address",
+		Version:      "3.0.0",
+		DefaultValue: "localhost:514",
+		Doc:          "The address of the syslog server.",
+		Export:       true,
+	}
+	p.AccessLog.SyslogAddress.Init(base.mgr)
+
+	p.AccessLog.SyslogFacility = ParamItem{
+		Key:          "proxy.accessLog.syslog.facility",
+		Version:      "3.0.0",
+		DefaultValue: "16",
+		Doc:          "The facility code of the syslog messages, 16 is local0.",
+		Export:       true,
+	}
+	p.AccessLog.SyslogFacility.Init(base.mgr)
+
+	p.AccessLog.SyslogAppName = ParamItem{
+		Key:          "proxy.accessLog.syslog.appName",
+		Version:      "3.0.0",
+		DefaultValue: "milvus",
+		Doc:          "The APP-NAME of the syslog messages.",
+		Export:       true,
+	}
+	p.AccessLog.SyslogAppName.Init(base.mgr)
+
+	p.AccessLog.OTLPEndpoint = ParamItem{
+		Key:          "proxy.accessLog.otlp.endpoint",
+		Version:      "3.0.0",
+		DefaultValue: "localhost:4317",
+		Doc:          "The OTLP gRPC endpoint to export access logs.",
+		Export:       true,
+	}
+	p.AccessLog.OTLPEndpoint.Init(base.mgr)
+
+	p.AccessLog.OTLPInsecure = ParamItem{
+		Key:          "proxy.accessLog.otlp.insecure",
+		Version:      "3.0.0",
+		DefaultValue: "true",
+		Doc:          "Whether to export access logs without TLS.",
+		Export:       true,
+	}
+	p.AccessLog.OTLPInsecure.Init(base.mgr)
+
+	p.AccessLog.OTLPBatchSize = ParamItem{
+		Key:          "proxy.accessLog.otlp.batchSize",
+		Version:      "3.0.0",
+		DefaultValue: "512",
+		Doc:          "The max number of access logs in an export request.",
+		Export:       true,
+	}
+	p.AccessLog.OTLPBatchSize.Init(base.mgr)
+
+	p.AccessLog.OTLPFlushInterval = ParamItem{
+		Key:          "proxy.accessLog.otlp.flushInterval",
+		Version:      "3.0.0",
+		DefaultValue: "3",
+		Doc:          "The time interval to export the buffered access logs, in seconds.",
+		Export:       true,
+	}
+	p.AccessLog.OTLPFlushInterval.Init(base.mgr)
+
 	p.ShardLeaderCacheInterval = ParamItem{
 		Key:          "proxy.shardLeaderCacheInterval",
 		Version:      "2.2.4",
@@ -2547,6 +2741,98 @@ please adjust in embedded Milvus: false`,
 	}
 	p.ReplicaBlacklistCleanupInterval.Init(base.mgr)
 
+	p.HedgeEnabled = ParamItem{
+		Key:          "proxy.hedge.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Whether to hedge the search requests across replicas. If a shard search request exceeds the latency percentile,
+a duplicate request is sent to another replica, the first response is taken and the other one is canceled`,
+		Export: true,
+	}
+	p.HedgeEnabled.Init(base.mgr)
+
+	p.HedgeLatencyPercentile = ParamItem{
+		Key:          "proxy.hedge.latencyPercentile",
+		Version:      "3.0.0",
+		DefaultValue: "95",
+		Formatter: func(v string) string {
+			percentile := getAsFloat(v)
+			if percentile <= 0 || percentile >= 100 {
+				return "95"
+			}
+			return v
+		},
+		Doc:    "The latency percentile of the recent shard search requests, in (0, 100), after which the hedged request is sent",
+		Export: true,
+	}
+	p.HedgeLatencyPercentile.Init(base.mgr)
+
+	p.HedgeMinDelay = ParamItem{
+		Key:          "proxy.hedge.minDelay",
+		Version:      "3.0.0",
+		DefaultValue: "10ms",
+		Doc:          "The min delay before sending the hedged request, to avoid hedging the requests which are fast enough",
+		Export:       true,
+	}
+	p.HedgeMinDelay.Init(base.mgr)
+
+	p.HedgeBudgetRatio = ParamItem{
+		Key:          "proxy.hedge.budgetRatio",
+		Version:      "3.0.0",
+		DefaultValue: "0.05",
+		Formatter: func(v string) string {
+			ratio := getAsFloat(v)
+			if ratio < 0 {
+				return "0"
+			}
+			if ratio > 1 {
+				return "1"
+			}
+			return v
+		},
+		Doc:    "The max ratio of hedged requests to the shard search requests, in [0, 1]",
+		Export: true,
+	}
+	p.HedgeBudgetRatio.Init(base.mgr)
+
+	p.ResultCacheEnabled = ParamItem{
+		Key:          "proxy.resultCache.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Whether to cache the results of search and query requests in proxy.
+Cached results are served only if they satisfy the consistency level and guarantee timestamp of the request,
+and are invalidated by the DML time ticks observed by this proxy, once the DML requests through this proxy are done, or the rbac meta is refreshed`,
+		Export: true,
+	}
+	p.ResultCacheEnabled.Init(base.mgr)
+
+	p.ResultCacheMaxMemSize = ParamItem{
+		Key:          "proxy.resultCache.maxMemSize",
+		Version:      "3.0.0",
+		DefaultValue: "256",
+		Doc:          "The max memory size in MB of the cached search and query results, least recently used results are evicted if exceeded",
+		Export:       true,
+	}
+	p.ResultCacheMaxMemSize.Init(base.mgr)
+
+	p.ResultCacheMaxEntrySize = ParamItem{
+		Key:          "proxy.resultCache.maxEntrySize",
+		Version:      "3.0.0",
+		DefaultValue: "4",
+		Doc:          "The max size in MB of a single result to be cached",
+		Export:       true,
+	}
+	p.ResultCacheMaxEntrySize.Init(base.mgr)
+
+	p.ResultCacheTTL = ParamItem{
+		Key:          "proxy.resultCache.ttl",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "The max time in seconds a cached result lives, which bounds the staleness caused by the writes not observed by this proxy",
+		Export:       true,
+	}
+	p.ResultCacheTTL.Init(base.mgr)
+
 	p.PartitionNameRegexp = ParamItem{
 		Key:          "proxy.partitionNameRegexp",
 		Version:      "2.3.4",
@@ -2697,6 +2983,33 @@ Disabled if the value is less or equal to 0.`,
 	}
 	p.MaxConnectionNum.Init(base.mgr)
 
+	p.MaxInflightRequestsPerClient = ParamItem{
+		Key:          "proxy.maxInflightRequestsPerClient",
+		Version:      "3.0.0",
+		Doc:          "the max number of concurrent in-flight requests of a client, which is the registered client or the peer host otherwise, 0 means no limit",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxInflightRequestsPerClient.Init(base.mgr)
+
+	p.MaxInflightRequestsPerUser = ParamItem{
+		Key:          "proxy.maxInflightRequestsPerUser",
+		Version:      "3.0.0",
+		Doc:          "the max number of concurrent in-flight requests of an authenticated user, 0 means no limit",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxInflightRequestsPerUser.Init(base.mgr)
+
+	p.MaxConnectionIdleSeconds = ParamItem{
+		Key:          "proxy.maxConnectionIdleSeconds",
+		Version:      "3.0.0",
+		Doc:          "the connection without any in-flight requests for longer than this duration is closed, in seconds, 0 means never",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxConnectionIdleSeconds.Init(base.mgr)
+
 	p.SlowQuerySpanInSeconds = ParamItem{
 		Key:          "proxy.slowQuerySpanInSeconds",
 		Version:      "2.3.11",
@@ -2753,6 +3066,11 @@ type queryCoordConfig struct {
 	GrowingRowCountWeight               ParamItem `refreshable:"true"`
 	DelegatorMemoryOverloadFactor       ParamItem `refreshable:"true"`
 	BalanceCostThreshold                ParamItem `refreshable:"true"`
+	ResourceAwareCPUWeight              ParamItem `refreshable:"true"`
+	ResourceAwareMemoryWeight           ParamItem `refreshable:"true"`
+	ResourceAwareDiskWeight             ParamItem `refreshable:"true"`
+	ResourceAwareUnbalanceThreshold     ParamItem `refreshable:"true"`
+	ResourceUsagePullInterval           ParamItem `refreshable:"true"`
 
 	SegmentCheckInterval       ParamItem `refreshable:"true"`
 	ChannelCheckInterval       ParamItem `refreshable:"true"`
@@ -3057,6 +3375,57 @@ If this parameter is set false, Milvus simply searches the growing segments with
 	}
 	p.BalanceCostThreshold.Init(base.mgr)
 
+	p.ResourceAwareCPUWeight = ParamItem{
+		Key:          "queryCoord.resourceAwareCPUWeight",
+		Version:      "3.0.0",
+		DefaultValue: "0.5",
+		PanicIfEmpty: true,
+		Doc:          "the weight of cpu usage in the node load of ResourceAwareBalancer",
+		Export:       true,
+	}
+	p.ResourceAwareCPUWeight.Init(base.mgr)
+
+	p.ResourceAwareMemoryWeight = ParamItem{
+		Key:          "queryCoord.resourceAwareMemoryWeight",
+		Version:      "3.0.0",
+		DefaultValue: "0.3",
+		PanicIfEmpty: true,
+		Doc:          "the weight of memory usage in the node load of ResourceAwareBalancer",
+		Export:       true,
+	}
+	p.ResourceAwareMemoryWeight.Init(base.mgr)
+
+	p.ResourceAwareDiskWeight = ParamItem{
+		Key:          "queryCoord.resourceAwareDiskWeight",
+		Version:      "3.0.0",
+		DefaultValue: "0.2",
+		PanicIfEmpty: true,
+		Doc:          "the weight of local disk usage, including mmap files and disk cache, in the node load of ResourceAwareBalancer",
+		Export:       true,
+	}
+	p.ResourceAwareDiskWeight.Init(base.mgr)
+
+	p.ResourceAwareUnbalanceThreshold = ParamItem{
+		Key:          "queryCoord.resourceAwareUnbalanceThreshold",
+		Version:      "3.0.0",
+		DefaultValue: "0.1",
+		PanicIfEmpty: true,
+		Doc: `the node load difference in [0, 1] tolerated by ResourceAwareBalancer,
+segments are moved away from a node only if its load exceeds the average load of the replica by this value`,
+		Export: true,
+	}
+	p.ResourceAwareUnbalanceThreshold.Init(base.mgr)
+
+	p.ResourceUsagePullInterval = ParamItem{
+		Key:          "queryCoord.resourceUsagePullInterval",
+		Version:      "3.0.0",
+		DefaultValue: "10",
+		PanicIfEmpty: true,
+		Doc:          "the interval in seconds to pull resource usage and segment query load from queryNodes, only works when ResourceAwareBalancer is used",
+		Export:       true,
+	}
+	p.ResourceUsagePullInterval.Init(base.mgr)
+
 	p.MemoryUsageMaxDifferencePercentage = ParamItem{
 		Key:          "queryCoord.memoryUsageMaxDifferencePercentage",
 		Version:      "2.0.0",
@@ -3661,6 +4030,10 @@ type queryNodeConfig struct {
 	SchedulePolicyTaskDeadlineAdvance     ParamItem `refreshable:"true"`
 	SchedulePolicyEnableCrossUserGrouping ParamItem `refreshable:"true"`
 	SchedulePolicyMaxPendingTaskPerUser   ParamItem `refreshable:"true"`
+	SchedulePolicyWorkloadClassDefault    ParamItem `refreshable:"true"`
+	SchedulePolicyWorkloadClassWeights    ParamItem `refreshable:"true"`
+	SchedulePolicyWorkloadClassMaxRunning ParamItem `refreshable:"true"`
+	SchedulePolicyWorkloadClassQueueSLO   ParamItem `refreshable:"true"`
 
 	// CGOPoolSize ratio to MaxReadConcurrency
 	CGOPoolSizeRatio ParamItem `refreshable:"true"`
@@ -4815,7 +5188,13 @@ user-task-polling:
 	Scheduling is fair on task granularity.
 	The policy is based on the username for authentication.
 	And an empty username is considered the same user.
-	When there are no multi-users, the policy decay into FIFO.`,
+	When there are no multi-users, the policy decay into FIFO.
+workload-class:
+	The tasks are grouped by workload class, e.g. interactive, batch and iterator,
+	which is granted by the collection.workload.class collection property or the database.workload.class database property,
+	and could only be lowered by the workload_class param of the request.
+	The classes share the read concurrency by weight, and could be limited by max running tasks and queue time slo.
+	The queued tasks of the class with lower weight are preempted if the queue is full.`,
 		Export: true,
 	}
 	p.SchedulePolicyName.Init(base.mgr)
@@ -4852,6 +5231,38 @@ user-task-polling:
 		Export:       true,
 	}
 	p.SchedulePolicyMaxPendingTaskPerUser.Init(base.mgr)
+	p.SchedulePolicyWorkloadClassDefault = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.workloadClass.default",
+		Version:      "3.0.0",
+		DefaultValue: "interactive",
+		Doc:          "The workload class of the read tasks not specifying a known workload class when using workload-class policy, the iterator tasks use the iterator class if it's configured in the weights",
+		Export:       true,
+	}
+	p.SchedulePolicyWorkloadClassDefault.Init(base.mgr)
+	p.SchedulePolicyWorkloadClassWeights = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.workloadClass.weights",
+		Version:      "3.0.0",
+		DefaultValue: "interactive:8,batch:2,iterator:1",
+		Doc:          "The comma separated class:weight of workload classes when using workload-class policy, the classes are scheduled in proportion to their weights, and the queued tasks of the class with lower weight are preempted first if the queue is full",
+		Export:       true,
+	}
+	p.SchedulePolicyWorkloadClassWeights.Init(base.mgr)
+	p.SchedulePolicyWorkloadClassMaxRunning = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.workloadClass.maxRunningTasks",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "The comma separated class:limit of the max number of scheduled but unfinished tasks of workload classes when using workload-class policy, e.g. iterator:2, no limit if not set or not positive",
+		Export:       true,
+	}
+	p.SchedulePolicyWorkloadClassMaxRunning.Init(base.mgr)
+	p.SchedulePolicyWorkloadClassQueueSLO = ParamItem{
+		Key:          "queryNode.scheduler.scheduleReadPolicy.workloadClass.queueTimeSLO",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "The comma separated class:duration of the queue time slo of workload classes when using workload-class policy, e.g. interactive:100ms, the tasks queued longer than the slo are scheduled ahead of the weights",
+		Export:       true,
+	}
+	p.SchedulePolicyWorkloadClassQueueSLO.Init(base.mgr)
 	p.CGOPoolSizeRatio = ParamItem{
 		Key:          "queryNode.segcore.cgoPoolSizeRatio",
 		Version:      "2.3.0",
@@ -188,12 +188,23 @@ func TestComponentParam(t *testing.T) {
 		params.Save("common.storage.zstd.concurrency", "2")
 		assert.Equal(t, 2, params.CommonCfg.StorageZstdConcurrency.GetAsInt())
 
+		assert.Equal(t, "zstd", params.CommonCfg.StorageBinlogCompression.GetValue())
+		params.Save("common.storage.binlogCompression", "lz4")
+		assert.Equal(t, "lz4", params.CommonCfg.StorageBinlogCompression.GetValue())
+
 		assert.Equal(t, 0, params.CommonCfg.ClusterID.GetAsInt())
 		params.Save("common.clusterID", "32")
 		assert.Panics(t, func() {
 			params.CommonCfg.ClusterID.GetAsInt()
 		})
 		params.Save("common.clusterID", "0")
+
+		assert.Equal(t, int64(0), params.CommonCfg.RecycleBinRetentionSeconds.GetAsInt64())
+		params.Save("common.recycleBin.retentionSeconds", "86400")
+		assert.Equal(t, 24*time.Hour, params.CommonCfg.RecycleBinRetentionSeconds.GetAsDuration(time.Second))
+		params.Save("common.recycleBin.retentionSeconds", "-1")
+		assert.Equal(t, int64(0), params.CommonCfg.RecycleBinRetentionSeconds.GetAsInt64())
+		params.Reset("common.recycleBin.retentionSeconds")
 	})
 
 	t.Run("test logConfig", func(t *testing.T) {
@@ -238,6 +249,9 @@ func TestComponentParam(t *testing.T) {
 
 		t.Logf("MsgStreamTimeTickBufSize: %d", Params.MsgStreamTimeTickBufSize.GetAsInt64())
 
+		assert.Equal(t, "", Params.WALCompressionType.GetValue())
+		assert.Equal(t, 4096, Params.WALCompressionMinPayloadBytes.GetAsInt())
+
 		t.Logf("MaxNameLength: %d", Params.MaxNameLength.GetAsInt64())
 		assert.Equal(t, 1024, Params.MaxUserDescriptionLength.GetAsInt())
 
@@ -282,6 +296,28 @@ func TestComponentParam(t *testing.T) {
 		params.Save("proxy.replicaBlacklistCleanupInterval", "30s")
 		assert.Equal(t, 30*time.Second, Params.ReplicaBlacklistCleanupInterval.GetAsDurationByParse())
 
+		// Test hedge params
+		assert.False(t, Params.HedgeEnabled.GetAsBool())
+		assert.Equal(t, 95.0, Params.HedgeLatencyPercentile.GetAsFloat())
+		params.Save("proxy.hedge.latencyPercentile", "100")
+		assert.Equal(t, 95.0, Params.HedgeLatencyPercentile.GetAsFloat())
+		params.Save("proxy.hedge.latencyPercentile", "99")
+		assert.Equal(t, 99.0, Params.HedgeLatencyPercentile.GetAsFloat())
+		assert.Equal(t, 10*time.Millisecond, Params.HedgeMinDelay.GetAsDurationByParse())
+		assert.Equal(t, 0.05, Params.HedgeBudgetRatio.GetAsFloat())
+		params.Save("proxy.hedge.budgetRatio", "2")
+		assert.Equal(t, 1.0, Params.HedgeBudgetRatio.GetAsFloat())
+
+		// Test result cache params
+		assert.False(t, Params.ResultCacheEnabled.GetAsBool())
+		assert.Equal(t, int64(256), Params.ResultCacheMaxMemSize.GetAsInt64())
+		assert.Equal(t, int64(4), Params.ResultCacheMaxEntrySize.GetAsInt64())
+		assert.Equal(t, 60*time.Second, Params.ResultCacheTTL.GetAsDuration(time.Second))
+
+		assert.Equal(t, int64(0), Params.MaxInflightRequestsPerClient.GetAsInt64())
+		assert.Equal(t, int64(0), Params.MaxInflightRequestsPerUser.GetAsInt64())
+		assert.Equal(t, time.Duration(0), Params.MaxConnectionIdleSeconds.GetAsDuration(time.Second))
+
 		params.Save("proxy.gracefulStopTimeout", "100")
 		assert.Equal(t, 100*time.Second, Params.GracefulStopTimeout.GetAsDuration(time.Second))
 
@@ -525,6 +561,10 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 100*time.Millisecond, Params.SchedulePolicyTaskDeadlineAdvance.GetAsDurationByParse())
 		assert.NoError(t, params.Save(Params.SchedulePolicyTaskDeadlineAdvance.Key, "100"))
 		assert.Equal(t, 100*time.Millisecond, Params.SchedulePolicyTaskDeadlineAdvance.GetAsDurationByParse())
+		assert.Equal(t, "interactive", Params.SchedulePolicyWorkloadClassDefault.GetValue())
+		assert.Equal(t, []string{"interactive:8", "batch:2", "iterator:1"}, Params.SchedulePolicyWorkloadClassWeights.GetAsStrings())
+		assert.Empty(t, Params.SchedulePolicyWorkloadClassMaxRunning.GetAsStrings())
+		assert.Empty(t, Params.SchedulePolicyWorkloadClassQueueSLO.GetAsStrings())
 		assert.Equal(t, 10.0, Params.CPURatio.GetAsFloat())
 		assert.Equal(t, uint32(hardware.GetCPUNum()), Params.KnowhereThreadPoolSize.GetAsUint32())
 
@@ -119,6 +119,17 @@ type quotaConfig struct {
 	DQLMaxQueryRatePerPartition   ParamItem `refreshable:"true"`
 	DQLMinQueryRatePerPartition   ParamItem `refreshable:"true"`
 
+	// user and role
+	UserRoleLimitEnabled     ParamItem  `refreshable:"true"`
+	DMLMaxInsertRatePerUser  ParamItem  `refreshable:"true"`
+	DMLMaxDeleteRatePerUser  ParamItem  `refreshable:"true"`
+	DQLMaxSearchRatePerUser  ParamItem  `refreshable:"true"`
+	DQLMaxQueryRatePerUser   ParamItem  `refreshable:"true"`
+	DDLCollectionRatePerUser ParamItem  `refreshable:"true"`
+	DDLPartitionRatePerUser  ParamItem  `refreshable:"true"`
+	UserRateLimits           ParamGroup `refreshable:"true"`
+	RoleRateLimits           ParamGroup `refreshable:"true"`
+
 	// limits
 	MaxCollectionNum               ParamItem `refreshable:"true"`
 	MaxCollectionNumPerDB          ParamItem `refreshable:"true"`
@@ -1424,6 +1435,155 @@ To use this setting, set quotaAndLimits.dql.enabled to true at the same time.`,
 	}
 	p.DQLMinQueryRatePerPartition.Init(base.mgr)
 
+	// user and role
+	p.UserRoleLimitEnabled = ParamItem{
+		Key:          "quotaAndLimits.userAndRole.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Whether to throttle requests per user and per role, the limits are applied on each proxy.
+The default limits of each user are set by quotaAndLimits.{dml,dql,ddl}.*.user, and could be overridden by quotaAndLimits.userAndRole.users.{user}.{rateType}.
+The limits of a role are shared by all the users granted the role, which are set by quotaAndLimits.userAndRole.roles.{role}.{rateType}.
+The rateType is one of dmlInsert, dmlDelete, dmlBulkLoad (MB/s), dqlSearch (vps), dqlQuery, ddlCollection, ddlPartition, ddlIndex, ddlFlush, ddlCompaction and ddlDB (qps).`,
+		Export: true,
+	}
+	p.UserRoleLimitEnabled.Init(base.mgr)
+
+	p.DMLMaxInsertRatePerUser = ParamItem{
+		Key:          "quotaAndLimits.dml.insertRate.user.max",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			if !p.DMLLimitEnabled.GetAsBool() {
+				return max
+			}
+			rate := getAsFloat(v)
+			if math.Abs(rate-defaultMax) > 0.001 { // maxRate != defaultMax
+				rate = megaBytes2Bytes(rate)
+			}
+			// [0, inf)
+			if rate < 0 {
+				return max
+			}
+			return fmt.Sprintf("%f", rate)
+		},
+		Doc:    "MB/s of each user, default no limit",
+		Export: true,
+	}
+	p.DMLMaxInsertRatePerUser.Init(base.mgr)
+
+	p.DMLMaxDeleteRatePerUser = ParamItem{
+		Key:          "quotaAndLimits.dml.deleteRate.user.max",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			if !p.DMLLimitEnabled.GetAsBool() {
+				return max
+			}
+			rate := getAsFloat(v)
+			if math.Abs(rate-defaultMax) > 0.001 { // maxRate != defaultMax
+				rate = megaBytes2Bytes(rate)
+			}
+			// [0, inf)
+			if rate < 0 {
+				return max
+			}
+			return fmt.Sprintf("%f", rate)
+		},
+		Doc:    "MB/s of each user, default no limit",
+		Export: true,
+	}
+	p.DMLMaxDeleteRatePerUser.Init(base.mgr)
+
+	p.DQLMaxSearchRatePerUser = ParamItem{
+		Key:          "quotaAndLimits.dql.searchRate.user.max",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0, inf)
+			if getAsFloat(v) < 0 {
+				return max
+			}
+			return v
+		},
+		Doc:    "vps (vectors per second) of each user, default no limit",
+		Export: true,
+	}
+	p.DQLMaxSearchRatePerUser.Init(base.mgr)
+
+	p.DQLMaxQueryRatePerUser = ParamItem{
+		Key:          "quotaAndLimits.dql.queryRate.user.max",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			if !p.DQLLimitEnabled.GetAsBool() {
+				return max
+			}
+			// [0, inf)
+			if getAsFloat(v) < 0 {
+				return max
+			}
+			return v
+		},
+		Doc:    "qps of each user, default no limit",
+		Export: true,
+	}
+	p.DQLMaxQueryRatePerUser.Init(base.mgr)
+
+	p.DDLCollectionRatePerUser = ParamItem{
+		Key:          "quotaAndLimits.ddl.user.collectionRate",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			switcher, ok := getLimitSwicher()
+			if ok {
+				return switcher
+			}
+			// [0 ~ Inf)
+			if getAsInt(v) < 0 {
+				return max
+			}
+			return v
+		},
+		Doc:    "qps of each user, default no limit, rate for CreateCollection, DropCollection, LoadCollection, ReleaseCollection",
+		Export: true,
+	}
+	p.DDLCollectionRatePerUser.Init(base.mgr)
+
+	p.DDLPartitionRatePerUser = ParamItem{
+		Key:          "quotaAndLimits.ddl.user.partitionRate",
+		Version:      "3.0.0",
+		DefaultValue: max,
+		Formatter: func(v string) string {
+			switcher, ok := getLimitSwicher()
+			if ok {
+				return switcher
+			}
+			// [0 ~ Inf)
+			if getAsInt(v) < 0 {
+				return max
+			}
+			return v
+		},
+		Doc:    "qps of each user, default no limit, rate for CreatePartition, DropPartition, LoadPartition, ReleasePartition",
+		Export: true,
+	}
+	p.DDLPartitionRatePerUser.Init(base.mgr)
+
+	p.UserRateLimits = ParamGroup{
+		KeyPrefix: "quotaAndLimits.userAndRole.users.",
+		Version:   "3.0.0",
+	}
+	p.UserRateLimits.Init(base.mgr)
+
+	p.RoleRateLimits = ParamGroup{
+		KeyPrefix: "quotaAndLimits.userAndRole.roles.",
+		Version:   "3.0.0",
+	}
+	p.RoleRateLimits.Init(base.mgr)
+
 	// limits
 	p.MaxCollectionNum = ParamItem{
 		Key:          "quotaAndLimits.limits.maxCollectionNum",
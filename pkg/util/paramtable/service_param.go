@@ -53,6 +53,7 @@ type ServiceParam struct {
 	PulsarCfg       PulsarConfig
 	KafkaCfg        KafkaConfig
 	RocksmqCfg      RocksmqConfig
+	LocalFSCfg      LocalFSConfig
 	MinioCfg        MinioConfig
 	ProfileCfg      ProfileConfig
 }
@@ -67,6 +68,7 @@ func (p *ServiceParam) init(bt *BaseTable) {
 	p.PulsarCfg.Init(bt)
 	p.KafkaCfg.Init(bt)
 	p.RocksmqCfg.Init(bt)
+	p.LocalFSCfg.Init(bt)
 	p.MinioCfg.Init(bt)
 	p.ProfileCfg.Init(bt)
 }
@@ -538,6 +540,10 @@ type MetaStoreConfig struct {
 	PaginationSize  ParamItem `refreshable:"true"`
 	ReadConcurrency ParamItem `refreshable:"true"`
 	MaxEtcdTxnNum   ParamItem `refreshable:"true"`
+
+	BoltPath        ParamItem `refreshable:"false"`
+	BoltHistorySize ParamItem `refreshable:"false"`
+	BoltNoSync      ParamItem `refreshable:"false"`
 }
 
 func (p *MetaStoreConfig) Init(base *BaseTable) {
@@ -545,7 +551,7 @@ func (p *MetaStoreConfig) Init(base *BaseTable) {
 		Key:          "metastore.type",
 		Version:      "2.2.0",
 		DefaultValue: util.MetaStoreTypeEtcd,
-		Doc:          `Default value: etcd, Valid values: [etcd, tikv]`,
+		Doc:          `Default value: etcd, Valid values: [etcd, tikv, bolt]`,
 		Export:       true,
 	}
 	p.MetaStoreType.Init(base.mgr)
@@ -575,6 +581,34 @@ func (p *MetaStoreConfig) Init(base *BaseTable) {
 	}
 	p.MaxEtcdTxnNum.Init(base.mgr)
 
+	p.BoltPath = ParamItem{
+		Key:          "metastore.bolt.path",
+		Version:      "3.0.0",
+		DefaultValue: "default.bolt/meta.db",
+		Doc: `Bolt only. File path of the embedded metadata store for single process deployments.
+The keys are stored under etcd.rootPath, and the file could not be shared by multiple processes.`,
+		Export: true,
+	}
+	p.BoltPath.Init(base.mgr)
+
+	p.BoltHistorySize = ParamItem{
+		Key:          "metastore.bolt.historySize",
+		Version:      "3.0.0",
+		DefaultValue: "10000",
+		Doc:          `Bolt only. Number of latest revisions whose events are retained for watching from a history revision.`,
+		Export:       true,
+	}
+	p.BoltHistorySize.Init(base.mgr)
+
+	p.BoltNoSync = ParamItem{
+		Key:          "metastore.bolt.noSync",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          `Bolt only. Skip fsync after each commit, metadata may be lost on machine crash if enabled.`,
+		Export:       true,
+	}
+	p.BoltNoSync.Init(base.mgr)
+
 	// TODO: The initialization operation of metadata storage is called in the initialization phase of every node.
 	// There should be a single initialization operation for meta store, then move the metrics registration to there.
 	metrics.RegisterMetaType(p.MetaStoreType.GetValue())
@@ -609,7 +643,7 @@ func (p *MQConfig) Init(base *BaseTable) {
 		Version:      "2.3.0",
 		DefaultValue: "default",
 		Doc: `Default value: "default"
-Valid values: [default, pulsar, kafka, rocksmq, woodpecker]`,
+Valid values: [default, pulsar, kafka, rocksmq, woodpecker, localfs]`,
 		Export:    true,
 		Immutable: true,
 	}
@@ -1473,6 +1507,71 @@ Set an easy-to-identify root key prefix for Milvus if etcd service already exist
 	r.CompressionTypes.Init(base.mgr)
 }
 
+// /////////////////////////////////////////////////////////////////////////////
+// --- localfs ---
+type LocalFSConfig struct {
+	Path ParamItem `refreshable:"false"`
+	// SegmentSize is the size of a segment file before it is rolled.
+	SegmentSize ParamItem `refreshable:"false"`
+	// IndexInterval is the bytes between two sparse index entries of a segment.
+	IndexInterval ParamItem `refreshable:"false"`
+	// SyncPolicy is the fsync policy of the appended messages, one of [always, interval, none].
+	SyncPolicy ParamItem `refreshable:"false"`
+	// SyncInterval is the fsync interval when SyncPolicy is interval.
+	SyncInterval ParamItem `refreshable:"false"`
+}
+
+func (l *LocalFSConfig) Init(base *BaseTable) {
+	l.Path = ParamItem{
+		Key:          "localfs.path",
+		Version:      "2.7.0",
+		DefaultValue: "/var/lib/milvus/localfs_data",
+		Doc: `Root directory where the localfs wal stores the segment files of each pchannel.
+Caution: Changing this parameter after using Milvus for a period of time will affect your access to old data.`,
+		Export: true,
+	}
+	l.Path.Init(base.mgr)
+
+	l.SegmentSize = ParamItem{
+		Key:          "localfs.segmentSize",
+		Version:      "2.7.0",
+		DefaultValue: "64m",
+		Doc:          "The maximum size of a segment file of the localfs wal, a new segment file will be created when the size is exceeded. Truncation of the wal is done by removing whole segment files.",
+		Export:       true,
+	}
+	l.SegmentSize.Init(base.mgr)
+
+	l.IndexInterval = ParamItem{
+		Key:          "localfs.indexInterval",
+		Version:      "2.7.0",
+		DefaultValue: "4k",
+		Doc:          "The bytes of messages between two entries of the sparse offset index of a localfs segment.",
+		Export:       true,
+	}
+	l.IndexInterval.Init(base.mgr)
+
+	l.SyncPolicy = ParamItem{
+		Key:          "localfs.syncPolicy",
+		Version:      "2.7.0",
+		DefaultValue: "always",
+		Doc: `The fsync policy of the localfs wal.
+always: fsync before every append returns, no acknowledged message will be lost on power failure.
+interval: fsync in background every localfs.syncInterval, messages appended in the last interval may be lost on power failure.
+none: never fsync explicitly, rely on the operating system to flush the page cache.`,
+		Export: true,
+	}
+	l.SyncPolicy.Init(base.mgr)
+
+	l.SyncInterval = ParamItem{
+		Key:          "localfs.syncInterval",
+		Version:      "2.7.0",
+		DefaultValue: "100ms",
+		Doc:          "The interval of background fsync when localfs.syncPolicy is interval.",
+		Export:       true,
+	}
+	l.SyncInterval.Init(base.mgr)
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- minio ---
 type MinioConfig struct {
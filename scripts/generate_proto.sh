@@ -64,6 +64,7 @@ backup_generated_files() {
     done < <(find \
         "${ROOT_DIR}/pkg/proto" \
         "${ROOT_DIR}/pkg/eventlog" \
+        "${ROOT_DIR}/client/changestreampb" \
         "${ROOT_DIR}/cmd/tools/migration/backend" \
         "${ROOT_DIR}/cmd/tools/migration/legacy/legacypb" \
         "${CPP_SRC_DIR}/src/pb" \
@@ -127,6 +128,7 @@ ${protoc_opt} --go_out=paths=source_relative:./streamingpb --go-grpc_out=require
 ${protoc_opt} --go_out=paths=source_relative:./workerpb --go-grpc_out=require_unimplemented_servers=false,paths=source_relative:./workerpb worker.proto|| { echo 'generate worker.proto failed'; exit 1; }
 
 ${protoc_opt} --proto_path=$ROOT_DIR/pkg/eventlog/ --go_out=paths=source_relative:../../pkg/eventlog/ --go-grpc_out=require_unimplemented_servers=false,paths=source_relative:../../pkg/eventlog/ event_log.proto || { echo 'generate event_log.proto failed'; exit 1; }
+${protoc_opt} --proto_path=$ROOT_DIR/client/changestreampb --go_out=paths=source_relative:../../client/changestreampb/ --go-grpc_out=require_unimplemented_servers=false,paths=source_relative:../../client/changestreampb/ change_stream.proto || { echo 'generate change_stream.proto failed'; exit 1; }
 ${protoc_opt} --proto_path=$ROOT_DIR/cmd/tools/migration/backend --go_out=paths=source_relative:../../cmd/tools/migration/backend/ --go-grpc_out=require_unimplemented_servers=false,paths=source_relative:../../cmd/tools/migration/backend backup_header.proto || { echo 'generate backup_header.proto failed'; exit 1; }
 
 ${protoc_opt} --proto_path=$ROOT_DIR/cmd/tools/migration/legacy/ \